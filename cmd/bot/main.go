@@ -69,6 +69,7 @@ func main() {
 	chatRepo := storage.NewMemoryChatRepository(cfg.MaxContextSize)
 	productRepo := storage.NewMemoryProductRepository()
 	adminRepo := storage.NewMemoryAdminRepository()
+	promoRepo := storage.NewMemoryPromotionRepository("data/promotions.json")
 	logger.InfoLogger.Println("✅ Repositories tayyor (in-memory)")
 
 	// 3. Excel parser
@@ -76,7 +77,8 @@ func main() {
 	logger.InfoLogger.Println("✅ Excel parser tayyor")
 
 	// 5. Use cases
-	chatUseCase := usecase.NewChatUseCase(aiRepo, chatRepo, productRepo)
+	promotionUseCase := usecase.NewPromotionUseCase(promoRepo, productRepo)
+	chatUseCase := usecase.NewChatUseCase(aiRepo, chatRepo, productRepo, promotionUseCase)
 	adminUseCase := usecase.NewAdminUseCase(adminRepo, productRepo, excelParser, chatRepo, cfg.AdminPassword)
 	productUseCase := usecase.NewProductUseCase(productRepo)
 	logger.InfoLogger.Println("✅ Use cases tayyor")
@@ -97,6 +99,7 @@ func main() {
 		chatUseCase,
 		adminUseCase,
 		productUseCase,
+		promotionUseCase,
 		aiRepo.GetRawClient(), // Gemini client for SmartRouter
 	)
 	if err != nil {
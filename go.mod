@@ -9,6 +9,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/lib/pq v1.10.9
 	github.com/xuri/excelize/v2 v2.10.0
+	golang.org/x/image v0.25.0
 	google.golang.org/api v0.257.0
 )
 
@@ -190,8 +190,8 @@ func (h *BotHandler) handleInlineQuery(ctx context.Context, query *tgbotapi.Inli
 		if p.Category != "" {
 			descParts = append(descParts, p.Category)
 		}
-		if p.Price > 0 {
-			descParts = append(descParts, fmt.Sprintf("$%.2f", p.Price))
+		if p.Price.Amount > 0 {
+			descParts = append(descParts, p.Price.String())
 		}
 		descParts = append(descParts, fmt.Sprintf("Soni: %d", p.Stock))
 		result.Description = strings.Join(descParts, " | ")
@@ -328,6 +328,7 @@ func extractAddProductSelection(text string) string {
 }
 
 func (h *BotHandler) startAddProductFlow(userID, chatID int64, delta int) {
+	h.enterConversation(userID, convFlowAddProduct, "need_select", chatID)
 	h.addProductMu.Lock()
 	h.addProductState[userID] = &addProductState{
 		ChatID: chatID,
@@ -338,6 +339,7 @@ func (h *BotHandler) startAddProductFlow(userID, chatID int64, delta int) {
 }
 
 func (h *BotHandler) setAddProductSelection(userID int64, productID, productName string) addProductState {
+	h.enterConversation(userID, convFlowAddProduct, "need_qty", 0)
 	h.addProductMu.Lock()
 	state := h.addProductState[userID]
 	if state == nil {
@@ -369,4 +371,5 @@ func (h *BotHandler) clearAddProductState(userID int64) {
 	h.addProductMu.Lock()
 	delete(h.addProductState, userID)
 	h.addProductMu.Unlock()
+	h.leaveConversation(userID, convFlowAddProduct)
 }
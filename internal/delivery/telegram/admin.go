@@ -96,19 +96,16 @@ func (h *BotHandler) isAdminAuthorized(userID int64) bool {
 }
 
 func (h *BotHandler) setAwaitingSearch(userID int64, awaiting bool) {
-	h.searchMu.Lock()
 	if awaiting {
-		h.searchAwait[userID] = true
+		h.enterConversation(userID, convFlowSearch, "need_query", 0)
 	} else {
-		delete(h.searchAwait, userID)
+		h.leaveConversation(userID, convFlowSearch)
 	}
-	h.searchMu.Unlock()
 }
 
 func (h *BotHandler) isAwaitingSearch(userID int64) bool {
-	h.searchMu.RLock()
-	defer h.searchMu.RUnlock()
-	return h.searchAwait[userID]
+	_, ok := h.conversationStateIn(userID, convFlowSearch)
+	return ok
 }
 
 func (h *BotHandler) addAdminMessage(userID, chatID int64, msgID int) {
@@ -185,7 +182,7 @@ func (h *BotHandler) handleValCommand(ctx context.Context, message *tgbotapi.Mes
 			tgbotapi.NewInlineKeyboardButtonData("$", "val_usd"),
 		),
 	)
-	msg := tgbotapi.NewMessage(message.Chat.ID, "Valyuta rejimini tanlang:")
+	msg := tgbotapi.NewMessage(message.Chat.ID, h.currencyStatusText()+"\nValyuta rejimini tanlang:")
 	msg.ReplyMarkup = kb
 	if sent, err := h.sendAndLog(msg); err == nil {
 		h.trackAdminMessage(message.Chat.ID, sent.MessageID)
@@ -319,6 +316,22 @@ func (h *BotHandler) sendOrderDetailsWithButtons(chatID int64, ord orderStatusIn
 	if ord.Total != "" {
 		sb.WriteString(fmt.Sprintf("💰 Jami: %s\n", ord.Total))
 	}
+	sb.WriteString(fmt.Sprintf("💳 To'lov: %s\n", adminPaymentLabel(ord.PaymentStatus)))
+	if ord.Installment != "" {
+		sb.WriteString(fmt.Sprintf("📅 Muddatli to'lov: %s\n", ord.Installment))
+	}
+	if ord.DeliveryZone != "" {
+		sb.WriteString(fmt.Sprintf("🚚 Yetkazish narxi: %s (%s hududi)\n", deliveryFeeLabel(ord.DeliveryFee), ord.DeliveryZone))
+	}
+	if ord.Branch != "" {
+		sb.WriteString(fmt.Sprintf("🏬 Filial: %s\n", h.branchAdminLabel(ord.Branch)))
+	}
+	if ord.CourierID != 0 {
+		sb.WriteString(fmt.Sprintf("🛵 Kuryer: %s\n", h.courierName(ord.CourierID)))
+	}
+	if label := promoDiscountLabel(ord.Lines, ord.PromoCode); label != "" {
+		sb.WriteString(fmt.Sprintf("🏷 Chegirma: %s\n", label))
+	}
 	if ord.Delivery != "" {
 		sb.WriteString(fmt.Sprintf("🚚 Yetkazish: %s\n", deliveryDisplay(ord.Delivery, lang)))
 	}
@@ -389,6 +402,23 @@ func (h *BotHandler) sendOrderDetailsWithButtons(chatID int64, ord orderStatusIn
 		}
 	}
 
+	if !strings.EqualFold(ord.Delivery, "pickup") && len(h.getCouriers()) > 0 &&
+		(currentStatus == "processing" || currentStatus == "ready_delivery" || currentStatus == "onway") {
+		label := "🛵 Kuryer tayinlash"
+		if ord.CourierID != 0 {
+			label = "🛵 Kuryerni almashtirish"
+		}
+		buttons = append(buttons, []tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardButtonData(label, "ordcour:"+ord.OrderID),
+		})
+	}
+
+	if ord.PaymentStatus == "paid" {
+		buttons = append(buttons, []tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardButtonData("↩️ To'lovni qaytarish", "payrefund|"+ord.OrderID),
+		})
+	}
+
 	// Add view customer button
 	if ord.UserChat != 0 {
 		buttons = append(buttons, []tgbotapi.InlineKeyboardButton{
@@ -434,38 +464,8 @@ func (h *BotHandler) handleOrderStatusChange(ctx context.Context, chatID, adminI
 		statusLabel(oldStatus, "uz"),
 		statusLabel(newStatus, "uz")))
 
-	// Notify user if available
-	if order.UserChat != 0 {
-		userLang := h.getUserLang(order.UserID)
-		var userMsg string
-		switch newStatus {
-		case "ready_delivery":
-			userMsg = t(userLang,
-				"📦 Buyurtmangiz tayyor! Tez orada yetkazib beriladi.\nOrderID: "+orderID,
-				"📦 Ваш заказ готов! Скоро доставим.\nOrderID: "+orderID)
-		case "ready_pickup":
-			userMsg = t(userLang,
-				"🏪 Buyurtmangiz tayyor! Olib ketishingiz mumkin.\nOrderID: "+orderID,
-				"🏪 Ваш заказ готов! Можете забрать.\nOrderID: "+orderID)
-		case "onway":
-			userMsg = t(userLang,
-				"🚚 Buyurtmangiz yo'lda! Tez orada yetkaziladi.\nOrderID: "+orderID,
-				"🚚 Ваш заказ в пути! Скоро доставим.\nOrderID: "+orderID)
-		case "delivered":
-			userMsg = t(userLang,
-				"✅ Buyurtmangiz yetkazildi! Xarid uchun rahmat!\nOrderID: "+orderID,
-				"✅ Ваш заказ доставлен! Спасибо за покупку!\nOrderID: "+orderID)
-		case "processing":
-			userMsg = t(userLang,
-				"⏳ Buyurtmangiz qayta ishlanmoqda.\nOrderID: "+orderID,
-				"⏳ Ваш заказ обрабатывается.\nOrderID: "+orderID)
-		default:
-			userMsg = t(userLang,
-				fmt.Sprintf("🔔 Buyurtma holati o'zgardi: %s\nOrderID: %s", statusLabel(newStatus, userLang), orderID),
-				fmt.Sprintf("🔔 Статус заказа изменен: %s\nOrderID: %s", statusLabel(newStatus, userLang), orderID))
-		}
-		h.sendMessage(order.UserChat, userMsg)
-	}
+	// Mijozga avtomatik xabar (user /myorders da o'chirmagan bo'lsa)
+	h.notifyOrderStatusChange(*order, newStatus)
 }
 
 // formatDuration formats duration in a human-readable way
@@ -824,13 +824,13 @@ func (h *BotHandler) buildOnlineStats(lang string) string {
 	})
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("%s\n", t(lang, "👥 Onlayn statistika", "👥 Онлайн статистика")))
-	sb.WriteString(fmt.Sprintf("%s: %d\n", t(lang, "⏱️ Oxirgi 5 daqiqa", "⏱️ Последние 5 минут"), last5m))
-	sb.WriteString(fmt.Sprintf("%s: %d\n", t(lang, "🕒 Oxirgi 1 soat", "🕒 Последний час"), last1h))
-	sb.WriteString(fmt.Sprintf("%s: %d\n", t(lang, "📅 Oxirgi 24 soat", "📅 Последние 24 часа"), last24h))
-	sb.WriteString(fmt.Sprintf("%s: %d\n", t(lang, "📊 Jami kuzatuv", "📊 Всего в базе"), total))
+	sb.WriteString(fmt.Sprintf("%s\n", tr(lang, "admin.online.header")))
+	sb.WriteString(fmt.Sprintf("%s: %d\n", tr(lang, "admin.online.last5m"), last5m))
+	sb.WriteString(fmt.Sprintf("%s: %d\n", tr(lang, "admin.online.last1h"), last1h))
+	sb.WriteString(fmt.Sprintf("%s: %d\n", tr(lang, "admin.online.last24h"), last24h))
+	sb.WriteString(fmt.Sprintf("%s: %d\n", tr(lang, "admin.online.total"), total))
 	sb.WriteString("\n")
-	sb.WriteString(t(lang, "🟢 So'nggi faol foydalanuvchilar:\n", "🟢 Последние активные пользователи:\n"))
+	sb.WriteString(tr(lang, "admin.online.recent"))
 	maxShow := 20
 	shown := 0
 	for _, item := range list {
@@ -849,11 +849,11 @@ func (h *BotHandler) buildOnlineStats(lang string) string {
 		} else {
 			name = fmt.Sprintf("%s (ID:%d)", name, item.id)
 		}
-		sb.WriteString(fmt.Sprintf("• %s — %s, %s: %s\n", name, formatAgo(now.Sub(item.at), lang), t(lang, "oxirgi", "посл."), formatClock(item.at)))
+		sb.WriteString(fmt.Sprintf("• %s — %s, %s: %s\n", name, formatAgo(now.Sub(item.at), lang), tr(lang, "time.ago.last"), formatClock(item.at)))
 		shown++
 	}
 	if shown == 0 {
-		sb.WriteString(t(lang, "Hali faollik qayd etilmagan.", "Пока нет активности.") + "\n")
+		sb.WriteString(tr(lang, "admin.online.none") + "\n")
 	}
 	return sb.String()
 }
@@ -868,28 +868,43 @@ func (h *BotHandler) buildUsersStats(lang string) string {
 	}
 	var list []seenInfo
 
-	h.lastSeenMu.RLock()
-	h.nameMu.RLock()
-	for id, ts := range h.lastSeen {
+	// userStore (DB) + kesh: restartdan keyin ham to'liq ro'yxat
+	records := h.listUserRecords()
+	var newToday, blocked, withPhone int
+	for _, rec := range records {
+		if !rec.FirstSeen.IsZero() && now.Sub(rec.FirstSeen) <= 24*time.Hour {
+			newToday++
+		}
+		if rec.Blocked {
+			blocked++
+		}
+		if strings.TrimSpace(rec.Phone) != "" {
+			withPhone++
+		}
+		name := rec.Username
+		if strings.TrimSpace(name) == "" {
+			name = rec.Name
+		}
 		list = append(list, seenInfo{
-			id:   id,
-			at:   ts,
-			name: h.lastName[id],
+			id:   rec.UserID,
+			at:   rec.LastSeen,
+			name: name,
 		})
 	}
-	h.nameMu.RUnlock()
 	total := len(list)
-	h.lastSeenMu.RUnlock()
 
 	sort.Slice(list, func(i, j int) bool {
 		return list[i].at.After(list[j].at)
 	})
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("%s\n", t(lang, "👥 Foydalanuvchilar ro'yxati", "👥 Список пользователей")))
-	sb.WriteString(fmt.Sprintf("%s: %d\n\n", t(lang, "Jami foydalanuvchi", "Всего пользователей"), total))
+	sb.WriteString(fmt.Sprintf("%s\n", tr(lang, "admin.users.header")))
+	sb.WriteString(fmt.Sprintf("%s: %d\n", tr(lang, "admin.users.total"), total))
+	sb.WriteString(fmt.Sprintf("%s: %d\n", tr(lang, "admin.users.new24h"), newToday))
+	sb.WriteString(fmt.Sprintf("%s: %d\n", tr(lang, "admin.users.with_phone"), withPhone))
+	sb.WriteString(fmt.Sprintf("%s: %d\n\n", tr(lang, "admin.users.blocked"), blocked))
 
-	sb.WriteString(t(lang, "🟢 Oxirgi faollar:\n", "🟢 Последние активные:\n"))
+	sb.WriteString(tr(lang, "admin.users.recent"))
 	maxShow := 30
 	shown := 0
 	for _, item := range list {
@@ -909,7 +924,7 @@ func (h *BotHandler) buildUsersStats(lang string) string {
 		shown++
 	}
 	if shown == 0 {
-		sb.WriteString(t(lang, "Hali foydalanuvchi yozmagan.", "Пока нет сообщений от пользователей.") + "\n")
+		sb.WriteString(tr(lang, "admin.users.none") + "\n")
 	}
 
 	return sb.String()
@@ -918,8 +933,8 @@ func (h *BotHandler) buildUsersStats(lang string) string {
 func (h *BotHandler) onlineStatsKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🔄 Yangilash", "🔄 Обновить"), "online_refresh"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "online_back"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "admin.refresh"), "online_refresh"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "online_back"),
 		),
 	)
 }
@@ -927,8 +942,8 @@ func (h *BotHandler) onlineStatsKeyboard(lang string) tgbotapi.InlineKeyboardMar
 func (h *BotHandler) usersStatsKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🔄 Yangilash", "🔄 Обновить"), "users_refresh"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "online_back"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "admin.refresh"), "users_refresh"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "online_back"),
 		),
 	)
 }
@@ -1039,6 +1054,8 @@ func (h *BotHandler) getAdminMenuText() string {
 📤 *Katalog:*
 • Excel yuklash
 • /catalog - Katalog statistikasi
+• /specs - Xususiyatlari aniqlanmagan mahsulotlar
+• /bench - PC tahlil benchmark bazasi (CSV)
 • /products - Mahsulotlar ro'yxati
 • /search - Mahsulot qidirish (so'rov keyin yoziladi)
 • /add\_product - Mahsulot sonini qo'shish
@@ -1066,12 +1083,24 @@ func (h *BotHandler) getAdminMenuText() string {
 • /orders - So'nggi buyurtmalar
 • /top - TOP mahsulotlar
 • /hisobot - Kunlik/oylik hisobot
+• /refund - To'lovni qaytarish (/refund OrderID)
+• /order\_grace - Mijoz buyurtmani o'zgartira oladigan vaqt
+• /installment - Muddatli to'lov rejalari
+• /promo - Aksiyalar va promo kodlar
+• /zone - Yetkazish hududlari va narxlari
+• /branch - Filiallar, qoldiq ustunlari va buyurtma guruhlari
+• /courier - Kuryerlar va yetkazishni biriktirish
+• /warranty - Kafolat muddatlari va servis guruhi
+• /serial - Seriya raqamlarini kiritish
+• /tickets - Ochiq servis so'rovlari
 
 ⚙️ *Sozlamalar:*
 • /val - Valyuta rejimi
 • /sticker - Sticker sozlash
 • /stats - Buyurtma statistika
 • /not - Eslatmalar
+• /state - Userlarning faol jarayonlari (/state <userID>)
+• /cancel - Joriy jarayonni bekor qilish
 
 🚪 /logout - Chiqish`, productCount, userCount, onlineCount, todayOrders)
 }
@@ -1122,6 +1151,20 @@ func (h *BotHandler) handleDocumentMessage(ctx context.Context, message *tgbotap
 		return
 	}
 
+	// Yetkazish hududlari: .geojson (yoki "/zone" izohli .json)
+	lowerName := strings.ToLower(doc.FileName)
+	if strings.HasSuffix(lowerName, ".geojson") ||
+		(strings.HasSuffix(lowerName, ".json") && strings.HasPrefix(strings.TrimSpace(message.Caption), "/zone")) {
+		h.importDeliveryZones(message.Chat.ID, doc)
+		return
+	}
+
+	// PC tahlil benchmark bazasi: "/bench" izohli yoki nomida "bench" bo'lgan .csv
+	if isBenchmarkUpload(doc, message.Caption) {
+		h.importBenchmarks(message.Chat.ID, doc)
+		return
+	}
+
 	if !strings.HasSuffix(doc.FileName, ".xlsx") && !strings.HasSuffix(doc.FileName, ".xls") {
 		h.sendMessage(message.Chat.ID, "❌ Faqat Excel fayllari (.xlsx, .xls) qabul qilinadi!")
 		return
@@ -1148,7 +1191,7 @@ func (h *BotHandler) handleDocumentMessage(ctx context.Context, message *tgbotap
 			return
 		}
 
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Katalog muvaffaqiyatli yangilandi! %d ta mahsulot qo'shildi.", count))
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Katalog muvaffaqiyatli yangilandi! %d ta mahsulot qo'shildi.", count)+h.specGapsNote(goroutineCtx))
 	}()
 }
 
@@ -1214,7 +1257,7 @@ func (h *BotHandler) sendAdminSearchResults(ctx context.Context, chatID int64, q
 			break
 		}
 		sb.WriteString(fmt.Sprintf("\n📦 *%s*\n", p.Name))
-		sb.WriteString(fmt.Sprintf("💰 Narx: $%.2f\n", p.Price))
+		sb.WriteString(fmt.Sprintf("💰 Narx: %s\n", p.Price))
 		sb.WriteString(fmt.Sprintf("📁 Kategoriya: %s\n", p.Category))
 		if p.Stock > 0 {
 			sb.WriteString(fmt.Sprintf("📊 Soni: %d\n", p.Stock))
@@ -1269,9 +1312,7 @@ Barcha foydalanuvchilarga xabar yuborish uchun:
 		),
 	)
 
-	h.lastSeenMu.RLock()
-	userCount := len(h.lastSeen)
-	h.lastSeenMu.RUnlock()
+	userCount := len(h.broadcastRecipients())
 
 	confirmText := fmt.Sprintf(`📢 *Broadcast xabar*
 
@@ -1302,12 +1343,7 @@ func (h *BotHandler) handleBroadcastConfirm(ctx context.Context, chatID, userID
 
 	// Barcha foydalanuvchilarga yuborish
 	var success, failed int
-	h.lastSeenMu.RLock()
-	users := make([]int64, 0, len(h.lastSeen))
-	for id := range h.lastSeen {
-		users = append(users, id)
-	}
-	h.lastSeenMu.RUnlock()
+	users := h.broadcastRecipients()
 
 	for _, targetID := range users {
 		msg := tgbotapi.NewMessage(targetID, broadcastMsg)
@@ -9,7 +9,6 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
-	"github.com/yourusername/telegram-ai-bot/internal/usecase"
 )
 
 // handleAnalyzePCCallback "Analyze PC" button bosilganda
@@ -18,33 +17,27 @@ func (h *BotHandler) handleAnalyzePCCallback(ctx context.Context, callback *tgbo
 	lang := h.getUserLang(userID)
 
 	// Callback javob (button endi yuklanayotganini ko'rsatish)
-	h.bot.Request(tgbotapi.NewCallback(callback.ID, t(lang, "Tahlil qilinmoqda...", "Анализируется...")))
+	h.bot.Request(tgbotapi.NewCallback(callback.ID, tr(lang, "analyze.progress_short")))
 
 	// PC build ni olish (bu yerda siz qanday qilib build ni saqlab qo'yganingizga qarab)
 	// Misol uchun, oxirgi konfiguratsiyani chat history'dan olish mumkin
 	build, err := h.extractPCBuildFromHistory(ctx, userID)
 	if err != nil {
-		h.sendMessage(callback.Message.Chat.ID, t(lang,
-			"❌ Konfiguratsiya topilmadi. Avval /configuratsiya orqali PC yig'ing!",
-			"❌ Конфигурация не найдена. Сначала соберите ПК через /configuratsiya!"))
+		h.sendMessage(callback.Message.Chat.ID, tr(lang, "sheet.not_found"))
 		return
 	}
 
 	// Progress message
-	progressMsg, _ := h.sendMessageWithResp(callback.Message.Chat.ID, t(lang,
-		"⏳ PC tahlil qilinmoqda...\n\n🔍 FPS hisoblanmoqda...\n🌡️ Temperatura aniqlanmoqda...\n⚖️ Bottleneck tekshirilmoqda...\n⚡ Quvvat hisoblanmoqda...",
-		"⏳ Анализ ПК...\n\n🔍 Расчет FPS...\n🌡️ Определение температуры...\n⚖️ Проверка узких мест...\n⚡ Расчет мощности..."))
+	progressMsg, _ := h.sendMessageWithResp(callback.Message.Chat.ID, tr(lang, "analyze.progress"))
 
 	// PC Analyzer yaratish
-	analyzer := usecase.NewPCAnalyzer(h.chatUseCase)
+	analyzer := h.newPCAnalyzer()
 
 	// Tahlil qilish
 	analytics, err := analyzer.AnalyzePC(ctx, build, lang)
 	if err != nil {
 		log.Printf("❌ PC tahlil xatosi: %v", err)
-		h.sendMessage(callback.Message.Chat.ID, t(lang,
-			"❌ Tahlil qilishda xatolik yuz berdi. Iltimos, qayta urinib ko'ring.",
-			"❌ Ошибка при анализе. Попробуйте еще раз."))
+		h.sendMessage(callback.Message.Chat.ID, tr(lang, "analyze.failed"))
 		return
 	}
 
@@ -58,6 +51,7 @@ func (h *BotHandler) handleAnalyzePCCallback(ctx context.Context, callback *tgbo
 
 	// Yuborish
 	h.sendMessage(callback.Message.Chat.ID, formattedAnalytics)
+	h.sendSpecSheet(callback.Message.Chat.ID, 0, 0, "spec.pdf", build, analytics, "", lang)
 
 	if h.isConfigOrderLocked(userID) {
 		return
@@ -67,25 +61,23 @@ func (h *BotHandler) handleAnalyzePCCallback(ctx context.Context, callback *tgbo
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				t(lang, "📊 PDF Hisobot", "📊 PDF Отчет"),
+				tr(lang, "analyze.pdf_button"),
 				"download_pdf_report",
 			),
 		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				t(lang, "🛒 Sotib olish", "🛒 Купить"),
+				tr(lang, "analyze.buy_button"),
 				"purchase_config",
 			),
 			tgbotapi.NewInlineKeyboardButtonData(
-				t(lang, "🔄 Boshqa variant", "🔄 Другой вариант"),
+				tr(lang, "analyze.other_button"),
 				"new_config",
 			),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(callback.Message.Chat.ID, t(lang,
-		"📊 Tahlil tugadi! Yuqoridagi ma'lumotlarni ko'rib chiqing.\n\nSavol bo'lsa menga yozishingiz mumkin, agar zarur bo'lsa @Ingame_support ga murojaat qiling! 😊",
-		"📊 Анализ завершен! Просмотрите информацию выше.\n\nЕсли есть вопросы, можете написать мне, если нужно - обращайтесь к @Ingame_support! 😊"))
+	msg := tgbotapi.NewMessage(callback.Message.Chat.ID, tr(lang, "analyze.done"))
 	msg.ReplyMarkup = keyboard
 	_, _ = h.sendAndLog(msg)
 }
@@ -185,7 +177,7 @@ func parseComponent(configText, pattern, componentType string) *entity.Product {
 
 	return &entity.Product{
 		Name:  name,
-		Price: price,
+		Price: entity.NewMoney(price, entity.CurrencyUSD),
 	}
 }
 
@@ -230,33 +222,27 @@ func (h *BotHandler) handlePCAnalysisRequest(ctx context.Context, userID int64,
 	lang := h.getUserLang(userID)
 
 	// Progress message
-	progressMsg, _ := h.sendMessageWithResp(chatID, t(lang,
-		"⏳ PC tahlil qilinmoqda...\n\n🔍 FPS hisoblanmoqda...\n🌡️ Temperatura aniqlanmoqda...\n⚖️ Bottleneck tekshirilmoqda...\n⚡ Quvvat hisoblanmoqda...",
-		"⏳ Анализ ПК...\n\n🔍 Расчет FPS...\n🌡️ Определение температуры...\n⚖️ Проверка узких мест...\n⚡ Расчет мощности..."))
+	progressMsg, _ := h.sendMessageWithResp(chatID, tr(lang, "analyze.progress"))
 
-	// PC build'ni config text'dan extract qilish
-	build := h.extractPCBuildFromText(userID, configText, purposeHint)
+	// PC build'ni config text'dan extract qilish (katalogdagi Specs bilan)
+	build := h.catalogBuild(ctx, userID, configText, purposeHint)
 	if build == nil {
 		if progressMsg != nil {
 			h.deleteMessage(chatID, progressMsg.MessageID)
 		}
-		h.sendMessage(chatID, t(lang,
-			"❌ Konfiguratsiya tahlillanmadi. Iltimos, qayta urinib ko'ring.",
-			"❌ Не удалось проанализировать конфигурацию. Пожалуйста, попробуйте еще раз."))
+		h.sendMessage(chatID, tr(lang, "analyze.config_failed"))
 		return
 	}
 
 	// PC Analyzer yaratish va tahlil qilish
-	analyzer := usecase.NewPCAnalyzer(h.chatUseCase)
+	analyzer := h.newPCAnalyzer()
 	analytics, err := analyzer.AnalyzePC(ctx, build, lang)
 	if err != nil {
 		log.Printf("PC tahlil xatosi: %v", err)
 		if progressMsg != nil {
 			h.deleteMessage(chatID, progressMsg.MessageID)
 		}
-		h.sendMessage(chatID, t(lang,
-			"❌ Tahlil qilishda xatolik yuz berdi. Iltimos, qayta urinib ko'ring.",
-			"❌ Ошибка при анализе. Попробуйте еще раз."))
+		h.sendMessage(chatID, tr(lang, "analyze.failed"))
 		return
 	}
 
@@ -270,6 +256,7 @@ func (h *BotHandler) handlePCAnalysisRequest(ctx context.Context, userID int64,
 
 	// Yuborish
 	h.sendMessage(chatID, formattedAnalytics)
+	h.sendSpecSheet(chatID, 0, 0, "spec.pdf", build, analytics, "", lang)
 
 	if h.isConfigOrderLocked(userID) {
 		return
@@ -279,19 +266,17 @@ func (h *BotHandler) handlePCAnalysisRequest(ctx context.Context, userID int64,
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				t(lang, "✅ Zakaz beraman", "✅ Заказать"),
+				tr(lang, "config.feedback.order"),
 				"cfg_fb_yes|",
 			),
 			tgbotapi.NewInlineKeyboardButtonData(
-				t(lang, "🔄 O'zgartirish", "🔄 Изменить"),
+				tr(lang, "analyze.change_button"),
 				"cfg_fb_change|",
 			),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, t(lang,
-		"📊 Tahlil tugadi! Yuqoridagi ma'lumotlarni ko'rib chiqing.\n\nSavol bo'lsa menga yozishingiz mumkin, agar zarur bo'lsa @Ingame_support ga murojaat qiling! 😊",
-		"📊 Анализ завершен! Просмотрите информацию выше.\n\nЕсли есть вопросы, можете написать мне, если нужно - обращайтесь к @Ingame_support! 😊"))
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "analyze.done"))
 	msg.ReplyMarkup = keyboard
 	_, _ = h.sendAndLog(msg)
 }
@@ -388,10 +373,13 @@ func (h *BotHandler) extractPCBuildFromText(userID int64, configText string, pur
 			continue
 		}
 
+		// Konfiguratsiya matnidagi narxlar dollarda
+		money := entity.NewMoney(price, entity.CurrencyUSD)
+
 		// Match component by LABEL, not by name keywords
 		switch {
 		case strings.Contains(labelLower, "cpu") && !strings.Contains(labelLower, "cooler"):
-			build.CPU = entity.Product{Name: name, Price: price}
+			build.CPU = entity.Product{Name: name, Price: money}
 			log.Printf("🔧 [Parse] CPU: %s - %.2f$", name, price)
 
 		case strings.Contains(labelLower, "cooler"):
@@ -399,27 +387,27 @@ func (h *BotHandler) extractPCBuildFromText(userID int64, configText string, pur
 				build.Cooler = &entity.Product{}
 			}
 			build.Cooler.Name = name
-			build.Cooler.Price = price
+			build.Cooler.Price = money
 			log.Printf("🔧 [Parse] CPU Cooler: %s - %.2f$", name, price)
 
 		case strings.Contains(labelLower, "ram"):
-			build.RAM = entity.Product{Name: name, Price: price}
+			build.RAM = entity.Product{Name: name, Price: money}
 			log.Printf("🔧 [Parse] RAM: %s - %.2f$", name, price)
 
 		case strings.Contains(labelLower, "gpu"):
-			build.GPU = entity.Product{Name: name, Price: price}
+			build.GPU = entity.Product{Name: name, Price: money}
 			log.Printf("🔧 [Parse] GPU: %s - %.2f$", name, price)
 
 		case strings.Contains(labelLower, "ssd"):
-			build.SSD = entity.Product{Name: name, Price: price}
+			build.SSD = entity.Product{Name: name, Price: money}
 			log.Printf("🔧 [Parse] SSD: %s - %.2f$", name, price)
 
 		case strings.Contains(labelLower, "motherboard"):
-			build.Motherboard = entity.Product{Name: name, Price: price}
+			build.Motherboard = entity.Product{Name: name, Price: money}
 			log.Printf("🔧 [Parse] Motherboard: %s - %.2f$", name, price)
 
 		case strings.Contains(labelLower, "psu"):
-			build.PSU = entity.Product{Name: name, Price: price}
+			build.PSU = entity.Product{Name: name, Price: money}
 			log.Printf("🔧 [Parse] PSU: %s - %.2f$", name, price)
 
 		case strings.Contains(labelLower, "case"):
@@ -427,20 +415,15 @@ func (h *BotHandler) extractPCBuildFromText(userID int64, configText string, pur
 				build.Case = &entity.Product{}
 			}
 			build.Case.Name = name
-			build.Case.Price = price
+			build.Case.Price = money
 			log.Printf("🔧 [Parse] Case: %s - %.2f$", name, price)
 		}
 	}
 
 	// If total not found, calculate it
 	if totalPrice == 0 {
-		totalPrice = build.CPU.Price + build.GPU.Price + build.RAM.Price +
-			build.SSD.Price + build.Motherboard.Price + build.PSU.Price
-		if build.Case != nil {
-			totalPrice += build.Case.Price
-		}
-		if build.Cooler != nil && build.Cooler.Price > 0 {
-			totalPrice += build.Cooler.Price
+		if sum, err := build.GetTotalPrice(nil, entity.CurrencyUSD); err == nil {
+			totalPrice = sum.Major()
 		}
 	}
 
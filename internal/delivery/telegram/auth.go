@@ -1,18 +1,15 @@
 package telegram
 
-// Parol kutish holatini boshqarish
+// Parol kutish holatini boshqarish (convFlowAdminLogin)
 func (h *BotHandler) isAwaitingPassword(userID int64) bool {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
-	return h.awaitingPassword[userID]
+	_, ok := h.conversationStateIn(userID, convFlowAdminLogin)
+	return ok
 }
 
 func (h *BotHandler) setAwaitingPassword(userID int64, awaiting bool) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
 	if awaiting {
-		h.awaitingPassword[userID] = true
+		h.enterConversation(userID, convFlowAdminLogin, "need_password", 0)
 	} else {
-		delete(h.awaitingPassword, userID)
+		h.leaveConversation(userID, convFlowAdminLogin)
 	}
 }
@@ -0,0 +1,132 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// Benchmark bazasi (PC tahlilidagi FPS, bottleneck, harorat shundan hisoblanadi).
+// Standart baza dastur ichida; admin "/bench" izohli .csv yuborsa u almashtiriladi
+// va diskda saqlanadi, "/bench reset" standartga qaytaradi.
+
+const benchmarksFile = "data/benchmarks.csv"
+
+func (h *BotHandler) loadBenchmarksFromDisk() {
+	b, err := os.ReadFile(benchmarksFile)
+	if err != nil {
+		return
+	}
+	db, err := usecase.ParseBenchmarkCSV(bytes.NewReader(b))
+	if err != nil {
+		log.Printf("benchmarks parse failed: %v", err)
+		return
+	}
+	h.setBenchmarks(db)
+}
+
+func (h *BotHandler) setBenchmarks(db *usecase.BenchmarkDB) {
+	h.benchMu.Lock()
+	h.bench = db
+	h.benchMu.Unlock()
+}
+
+// benchmarks joriy baza (yuklanmagan bo'lsa standart)
+func (h *BotHandler) benchmarks() *usecase.BenchmarkDB {
+	h.benchMu.RLock()
+	defer h.benchMu.RUnlock()
+	if h.bench == nil {
+		return usecase.DefaultBenchmarks()
+	}
+	return h.bench
+}
+
+// newPCAnalyzer joriy benchmark bazasi bilan
+func (h *BotHandler) newPCAnalyzer() *usecase.PCAnalyzer {
+	return usecase.NewPCAnalyzer(h.chatUseCase, h.benchmarks())
+}
+
+func benchmarksText(db *usecase.BenchmarkDB, custom bool) string {
+	source := "standart (dastur bilan)"
+	if custom {
+		source = "admin yuklagan CSV"
+	}
+	return fmt.Sprintf("📈 Benchmark bazasi: %s\n• CPU: %d\n• GPU: %d\n• O'yinlar: %d\n• Aniq FPS o'lchovlari: %d\n\n"+
+		"Yangilash: .csv faylni \"/bench\" izohi bilan yuboring (shablon quyida).\n"+
+		"Ustunlar: kind (cpu/gpu/game/fps), name, aliases (| bilan), score, tdp, gpu, fps_1080p, fps_1440p, cpu_fps.\n"+
+		"/bench reset - standart bazaga qaytish",
+		source, len(db.CPUs), len(db.GPUs), len(db.Games), db.MeasuredCount())
+}
+
+// handleBenchCommand /bench - holat va joriy CSV; /bench reset - standartga qaytarish
+func (h *BotHandler) handleBenchCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+	if strings.EqualFold(strings.TrimSpace(message.CommandArguments()), "reset") {
+		if err := os.Remove(benchmarksFile); err != nil && !os.IsNotExist(err) {
+			log.Printf("benchmarks reset failed: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Benchmark faylini o'chirishda xatolik.")
+			return
+		}
+		h.setBenchmarks(nil)
+		h.sendMessage(message.Chat.ID, "✅ Standart benchmark bazasi tiklandi.\n\n"+benchmarksText(h.benchmarks(), false))
+		return
+	}
+
+	data, err := os.ReadFile(benchmarksFile)
+	custom := err == nil
+	if !custom {
+		data = usecase.DefaultBenchmarksCSV()
+	}
+	h.sendMessage(message.Chat.ID, benchmarksText(h.benchmarks(), custom))
+	doc := tgbotapi.NewDocument(message.Chat.ID, tgbotapi.FileBytes{Name: "benchmarks.csv", Bytes: data})
+	if _, err := h.sendAndLog(doc); err != nil {
+		log.Printf("benchmarks csv send error: %v", err)
+	}
+}
+
+// isBenchmarkUpload admin yuborgan hujjat benchmark CSV mi
+func isBenchmarkUpload(doc *tgbotapi.Document, caption string) bool {
+	name := strings.ToLower(doc.FileName)
+	if !strings.HasSuffix(name, ".csv") {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(caption), "/bench") || strings.Contains(name, "bench")
+}
+
+// importBenchmarks CSV ni tekshiradi, saqlaydi va darhol ishlatila boshlaydi
+func (h *BotHandler) importBenchmarks(chatID int64, doc *tgbotapi.Document) {
+	data, err := h.downloadFile(doc.FileID)
+	if err != nil {
+		log.Printf("benchmarks download error: %v", err)
+		h.sendMessage(chatID, "❌ Faylni yuklashda xatolik yuz berdi.")
+		return
+	}
+	db, err := usecase.ParseBenchmarkCSV(bytes.NewReader(data))
+	if err != nil {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Benchmark CSV o'qilmadi: %v", err))
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(benchmarksFile), 0o755); err != nil {
+		log.Printf("benchmarks save failed: %v", err)
+		h.sendMessage(chatID, "❌ Benchmark bazasini saqlashda xatolik.")
+		return
+	}
+	if err := os.WriteFile(benchmarksFile, data, 0o600); err != nil {
+		log.Printf("benchmarks save failed: %v", err)
+		h.sendMessage(chatID, "❌ Benchmark bazasini saqlashda xatolik.")
+		return
+	}
+	h.setBenchmarks(db)
+	h.sendMessage(chatID, "✅ Benchmark bazasi yangilandi.\n\n"+benchmarksText(db, true))
+}
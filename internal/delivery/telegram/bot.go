@@ -7,6 +7,8 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/google/generative-ai-go/genai"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/exrate"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/payment"
 	"github.com/yourusername/telegram-ai-bot/internal/usecase"
 )
 
@@ -27,11 +29,11 @@ type BotHandler struct {
 	activeOrdersThreadID int
 	profileMu            sync.RWMutex
 	profiles             map[int64]userProfile
-	profileStage         map[int64]string
 	profileMeta          map[int64]profileMeta
 	chatUseCase          usecase.ChatUseCase
 	adminUseCase         usecase.AdminUseCase
 	productUseCase       usecase.ProductUseCase
+	promotionUseCase     usecase.PromotionUseCase
 	configBuilder        *ConfigurationBuilder
 	configMu             sync.RWMutex
 	configSessions       map[int64]*configSession
@@ -78,10 +80,6 @@ type BotHandler struct {
 	adminActive      map[int64]bool
 	adminAuthMu      sync.RWMutex
 	adminAuthorized  map[int64]bool
-	searchMu         sync.RWMutex
-	searchAwait      map[int64]bool
-	userHistoryMu    sync.RWMutex
-	userHistoryAwait map[int64]bool
 	userHistoryMsgMu sync.RWMutex
 	userHistoryMsgs  map[string][]adminMessage
 	addProductMu     sync.RWMutex
@@ -94,7 +92,12 @@ type BotHandler struct {
 	currencyMu       sync.RWMutex
 	currencyMode     string
 	currencyRate     float64
-	currencyAwait    map[int64]bool
+	// Kurs manbai, tarix va eskirish holati (currency_rate.go)
+	rateSource           exrate.Source
+	currencyRateAt       time.Time
+	currencyRateSource   string
+	currencyRateHistory  []exrate.Rate
+	currencyStaleAlerted bool
 	purchaseMu       sync.RWMutex
 	purchasePrompt   map[int64]string
 	purchaseTitle    map[int64]string
@@ -107,9 +110,8 @@ type BotHandler struct {
 	sheetMasterMu  sync.RWMutex
 	sheetMasterCfg *sheetMasterConfig
 
-	stickerMu    sync.RWMutex
-	stickerCfg   *stickerConfig
-	stickerAwait map[int64]stickerSlot
+	stickerMu  sync.RWMutex
+	stickerCfg *stickerConfig
 
 	group1PendingMu       sync.RWMutex
 	group1PendingApprovals map[int]struct{}
@@ -142,15 +144,17 @@ type BotHandler struct {
 	liveFeedMu   sync.RWMutex
 	liveFeedSent map[int64]time.Time
 
-	// Admin login kutilayotgan userlar
-	awaitingPassword map[int64]bool
-	mu               sync.RWMutex
-	welcomeMu        sync.RWMutex
-	welcomeMsgs      map[int64][]int
+	// Suhbat jarayonlari (conversation_state.go)
+	convMu     sync.RWMutex
+	convStates map[int64]*conversationState
+
+	welcomeMu   sync.RWMutex
+	welcomeMsgs map[int64][]int
 
 	// Performance optimizations
 	workerPool *workerPool
 	cache      *responseCache
+	outbound   *outboundQueue
 
 	// AI client for SmartRouter
 	geminiClient *genai.Client
@@ -164,6 +168,71 @@ type BotHandler struct {
 
 	orderStore OrderStore
 	chatStore  ChatStore
+	userStore  UserStore
+
+	// Onlayn to'lov (payments.go): nil registry - to'lov o'chiq
+	paymentMu       sync.Mutex
+	paymentStore    PaymentStore
+	payments        *payment.Registry
+	paymentCurrency string
+
+	// Mijoz buyurtmani o'zgartira oladigan vaqt (order_changes.go)
+	orderGraceMu  sync.RWMutex
+	orderGrace    time.Duration
+	orderGraceSet bool
+
+	// Muddatli to'lov rejalari (installment.go)
+	installmentMu    sync.RWMutex
+	installmentPlans []installmentPlan
+
+	// Yetkazish hududlari (delivery_zones.go)
+	deliveryZoneMu sync.RWMutex
+	deliveryZones  []deliveryZone
+
+	// Filiallar va mijoz tanlagan filial (branches.go)
+	branchMu   sync.RWMutex
+	branches   []storeBranch
+	userBranch map[int64]string
+
+	// Kuryerlar va ulardan kutilayotgan kiritish (couriers.go)
+	courierMu     sync.RWMutex
+	couriers      []courier
+	courierAwaits map[int64]courierAwait
+
+	// Mahsulot obunalari: omborga kelishi va narx tushishi (stock_alerts.go)
+	stockAlertMu sync.Mutex
+	stockAlerts  []stockAlert
+
+	// Kafolat, seriya raqamlari va servis so'rovlari (warranty.go)
+	warrantyMu     sync.RWMutex
+	warranty       warrantySettings
+	warrantyClaims map[int64]warrantyClaim
+
+	// PC upgrade maslahatchisi: oxirgi takliflar (upgrade_advisor.go)
+	upgradeMu     sync.Mutex
+	upgradeOffers map[int64]upgradeOffer
+
+	// Periferiya to'plami: oxirgi to'plamlar (peripheral_advisor.go)
+	peripheralMu     sync.Mutex
+	peripheralOffers map[int64]peripheralOffer
+
+	// Noutbuk/tayyor PC tanlash: oxirgi reytinglar (system_advisor.go)
+	laptopMu     sync.Mutex
+	laptopOffers map[int64]laptopOffer
+
+	// Saqlangan konfiguratsiyalar va nom kutilayotgan yig'ma (saved_builds.go)
+	savedBuildsMu sync.RWMutex
+	savedBuilds   []savedBuild
+	buildRenames  map[int64]string
+
+	// PC tahlil benchmark bazasi (benchmarks.go), nil - standart
+	benchMu sync.RWMutex
+	bench   *usecase.BenchmarkDB
+
+	// userStore keshi holati (users.go)
+	usersMu       sync.Mutex
+	usersHydrated bool
+	usersLoaded   map[int64]bool
 
 	// Konfiguratsiya yakunidan keyingi avtomatik eslatmalar
 	configReminder map[int64]*time.Timer
@@ -185,6 +254,7 @@ func NewBotHandler(
 	chatUseCase usecase.ChatUseCase,
 	adminUseCase usecase.AdminUseCase,
 	productUseCase usecase.ProductUseCase,
+	promotionUseCase usecase.PromotionUseCase,
 	geminiClient *genai.Client,
 ) (*BotHandler, error) {
 	bot, err := tgbotapi.NewBotAPI(token)
@@ -200,6 +270,14 @@ func NewBotHandler(
 	if err != nil {
 		return nil, fmt.Errorf("failed to init chat store: %w", err)
 	}
+	userStore, err := newUserStoreFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to init user store: %w", err)
+	}
+	paymentStore, err := newPaymentStoreFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to init payment store: %w", err)
+	}
 
 	handler := &BotHandler{
 		bot:                bot,
@@ -216,6 +294,7 @@ func NewBotHandler(
 		chatUseCase:        chatUseCase,
 		adminUseCase:       adminUseCase,
 		productUseCase:     productUseCase,
+		promotionUseCase:   promotionUseCase,
 		configBuilder:      NewConfigurationBuilder(productUseCase),
 		geminiClient:       geminiClient,
 		configSessions:     make(map[int64]*configSession),
@@ -241,8 +320,6 @@ func NewBotHandler(
 		adminMessages:      make(map[int64][]adminMessage),
 		adminActive:        make(map[int64]bool),
 		adminAuthorized:    make(map[int64]bool),
-		searchAwait:        make(map[int64]bool),
-		userHistoryAwait:   make(map[int64]bool),
 		userHistoryMsgs:    make(map[string][]adminMessage),
 		addProductState:    make(map[int64]*addProductState),
 		configCTAMsg:       make(map[int64]int),
@@ -250,30 +327,33 @@ func NewBotHandler(
 		cartItems:          make(map[int64][]cartItem),
 		currencyMode:       "usd",
 		currencyRate:       0,
-		currencyAwait:      make(map[int64]bool),
+		rateSource:         newRateSourceFromEnv(),
 		purchasePrompt:     make(map[int64]string),
 		purchaseTitle:      make(map[int64]string),
 		purchaseMsg:        make(map[int64]purchasePromptMessage),
-		stickerAwait:       make(map[int64]stickerSlot),
 		group1PendingApprovals: make(map[int]struct{}),
 		sheetMasterSetup:   make(map[int64]*sheetMasterSetupState),
 		lastSeen:           make(map[int64]time.Time),
 		lastName:           make(map[int64]string),
 		liveFeedSent:       make(map[int64]time.Time),
-		awaitingPassword:   make(map[int64]bool),
+		convStates:         make(map[int64]*conversationState),
 		welcomeMsgs:        make(map[int64][]int),
 		cache:              newResponseCache(defaultCacheTTL, defaultMaxCacheSize),
 		userLang:           make(map[int64]string),
 		botStartedAt:       time.Now(),
 		orderStore:         orderStore,
 		chatStore:          chatStore,
+		userStore:          userStore,
+		paymentStore:       paymentStore,
+		payments:           newPaymentRegistryFromEnv(),
+		paymentCurrency:    paymentCurrencyFromEnv(),
+		usersLoaded:        make(map[int64]bool),
 		configReminder:     make(map[int64]*time.Timer),
 		reminderInput:      make(map[int64]*reminderInputState),
 		reminderInterval:   defaultReminderInterval,
 		reminderEnabled:    true,
 		orderCounter:       make(map[string]int),
 		profiles:           make(map[int64]userProfile),
-		profileStage:       make(map[int64]string),
 		profileMeta:        make(map[int64]profileMeta),
 		importAutoInput:    make(map[int64]*importAutoInputState),
 		importAutoInterval: defaultImportAutoInterval,
@@ -289,10 +369,37 @@ func NewBotHandler(
 
 	// Initialize worker pool
 	handler.workerPool = newWorkerPool(handler, defaultWorkerCount)
+	handler.outbound = newOutboundQueue()
 
 	// Load SheetMaster config from disk (optional)
 	handler.loadSheetMasterConfigFromDisk()
 	handler.loadStickerConfigFromDisk()
+	loadLocaleOverridesFromDisk()
+	handler.loadUsersFromStore()
+	handler.loadOrderSettingsFromDisk()
+	handler.loadInstallmentPlansFromDisk()
+	handler.loadDeliveryZonesFromDisk()
+	handler.loadBranchesFromDisk()
+	handler.loadCouriersFromDisk()
+	handler.loadStockAlertsFromDisk()
+	handler.loadWarrantyFromDisk()
+	handler.loadBenchmarksFromDisk()
+	handler.loadSavedBuildsFromDisk()
+	if adminUseCase != nil {
+		adminUseCase.SetCatalogListener(handler.onCatalogUpdated)
+	}
+	if chatUseCase != nil {
+		chatUseCase.SetBranchResolver(handler.branchStockFor)
+	}
+	handler.loadCurrencySettingsFromDisk()
+	rateConverter := exrate.NewConverter(func() float64 {
+		_, rate := handler.getCurrencySettings()
+		return rate
+	})
+	handler.configBuilder.SetConverter(rateConverter)
+	if promotionUseCase != nil {
+		promotionUseCase.SetConverter(rateConverter)
+	}
 
 	return handler, nil
 }
@@ -0,0 +1,427 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Filiallar (do'kon/ombor): har filialning o'z qoldiq ustuni ("Stock: Chilonzor" yoki
+// shu nomli varaq), olib ketish manzili va buyurtmalar guruhi. Mijoz olib ketishda filial
+// tanlaydi, buyurtma shu filial guruhiga boradi, ombor shu filial ustunidan kamayadi.
+
+const branchesFile = "data/branches.json"
+
+// storeBranch bitta filial / olib ketish punkti
+type storeBranch struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Address string `json:"address,omitempty"`
+	// Stock katalogdagi qoldiq ustuni/varag'i nomi (bo'sh - Name)
+	Stock    string `json:"stock,omitempty"`
+	ChatID   int64  `json:"chat_id,omitempty"` // 0 - umumiy active orders kanali
+	ThreadID int    `json:"thread_id,omitempty"`
+}
+
+type branchSettings struct {
+	Branches []storeBranch `json:"branches"`
+	// UserBranch mijoz tanlagan filial (qoldiq shu filial bo'yicha ko'rsatiladi)
+	UserBranch map[int64]string `json:"user_branch,omitempty"`
+}
+
+func (b storeBranch) stockKey() string {
+	return strings.ToLower(strings.Join(strings.Fields(nonEmpty(b.Stock, b.Name)), " "))
+}
+
+// label mijozga ko'rinadigan nom (manzil bilan)
+func (b storeBranch) label() string {
+	if b.Address == "" {
+		return b.Name
+	}
+	return fmt.Sprintf("%s (%s)", b.Name, b.Address)
+}
+
+func branchIDFromName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
+
+func (h *BotHandler) loadBranchesFromDisk() {
+	b, err := os.ReadFile(branchesFile)
+	if err != nil {
+		return
+	}
+	var cfg branchSettings
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Printf("branches parse failed: %v", err)
+		return
+	}
+	h.branchMu.Lock()
+	h.branches = cfg.Branches
+	h.userBranch = cfg.UserBranch
+	h.branchMu.Unlock()
+}
+
+func (h *BotHandler) saveBranchesLocked() error {
+	dir := filepath.Dir(branchesFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(branchSettings{Branches: h.branches, UserBranch: h.userBranch}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(branchesFile, b, 0o600)
+}
+
+func (h *BotHandler) setBranches(branches []storeBranch) error {
+	h.branchMu.Lock()
+	defer h.branchMu.Unlock()
+	h.branches = branches
+	return h.saveBranchesLocked()
+}
+
+func (h *BotHandler) getBranches() []storeBranch {
+	h.branchMu.RLock()
+	defer h.branchMu.RUnlock()
+	return append([]storeBranch(nil), h.branches...)
+}
+
+// findBranch ID yoki nom bo'yicha (katta-kichik harf farqsiz)
+func (h *BotHandler) findBranch(key string) (storeBranch, bool) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return storeBranch{}, false
+	}
+	for _, b := range h.getBranches() {
+		if strings.EqualFold(b.ID, key) || strings.EqualFold(b.Name, key) || b.ID == branchIDFromName(key) {
+			return b, true
+		}
+	}
+	return storeBranch{}, false
+}
+
+func (h *BotHandler) setUserBranch(userID int64, branchID string) error {
+	h.branchMu.Lock()
+	defer h.branchMu.Unlock()
+	if h.userBranch == nil {
+		h.userBranch = make(map[int64]string)
+	}
+	if branchID == "" {
+		delete(h.userBranch, userID)
+	} else {
+		h.userBranch[userID] = branchID
+	}
+	return h.saveBranchesLocked()
+}
+
+// preferredBranch mijoz tanlagan filial (o'chirilgan bo'lsa false)
+func (h *BotHandler) preferredBranch(userID int64) (storeBranch, bool) {
+	h.branchMu.RLock()
+	id := h.userBranch[userID]
+	h.branchMu.RUnlock()
+	return h.findBranch(id)
+}
+
+// branchStockFor katalog filtri uchun: buyurtmadagi filial, aks holda mijoz tanlagani
+func (h *BotHandler) branchStockFor(userID int64) string {
+	h.orderMu.RLock()
+	sess := h.orderSessions[userID]
+	h.orderMu.RUnlock()
+	if sess != nil && sess.Branch != nil {
+		return sess.Branch.stockKey()
+	}
+	if b, ok := h.preferredBranch(userID); ok {
+		return b.stockKey()
+	}
+	return ""
+}
+
+// branchStockKey buyurtmadagi filial ID sidan qoldiq ustuni
+func (h *BotHandler) branchStockKey(branchID string) string {
+	if b, ok := h.findBranch(branchID); ok {
+		return b.stockKey()
+	}
+	return ""
+}
+
+// orderChannel buyurtma yuboriladigan chat/topic: filial guruhi yoki umumiy active orders
+func (h *BotHandler) orderChannel(branchID string) (int64, int) {
+	if b, ok := h.findBranch(branchID); ok && b.ChatID != 0 {
+		return b.ChatID, b.ThreadID
+	}
+	return h.activeOrdersChatID, h.activeOrdersThreadID
+}
+
+// isBranchChat chat biror filialning buyurtmalar guruhimi
+func (h *BotHandler) isBranchChat(chatID int64) bool {
+	if chatID == 0 {
+		return false
+	}
+	for _, b := range h.getBranches() {
+		if b.ChatID == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+// isOrderChannel buyurtma tugmalari (Tayyor/Yo'lda/Bekor) shu chat/topicda ishlaydimi
+func (h *BotHandler) isOrderChannel(chatID int64, threadID int) bool {
+	if chatID == h.activeOrdersChatID && threadID != 0 {
+		return true
+	}
+	for _, b := range h.getBranches() {
+		if b.ChatID == chatID && (b.ThreadID == 0 || b.ThreadID == threadID) {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyOrderChannel buyurtma haqidagi xabar uning filial guruhiga (bo'lmasa active orders)
+func (h *BotHandler) notifyOrderChannel(info orderStatusInfo, text string) {
+	chatID, threadID := h.orderChannel(info.Branch)
+	if chatID == 0 {
+		return
+	}
+	if _, err := h.sendText(chatID, text, "", nil, threadID); err != nil {
+		log.Printf("order channel notify failed order=%s: %v", info.OrderID, err)
+	}
+}
+
+// branchAdminLabel admin xabarlari uchun filial nomi (o'chirilgan bo'lsa ID)
+func (h *BotHandler) branchAdminLabel(branchID string) string {
+	if b, ok := h.findBranch(branchID); ok {
+		return b.label()
+	}
+	return branchID
+}
+
+func (h *BotHandler) branchKeyboard(prefix, currentID string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, b := range h.getBranches() {
+		text := "🏬 " + b.label()
+		if b.ID == currentID {
+			text = "⭐ " + b.label()
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(text, prefix+b.ID)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// askPickupBranch olib ketish tanlanganda: filial 1 ta bo'lsa avtomatik, ko'p bo'lsa so'raladi.
+// true - filial so'raldi (buyurtma hali yuborilmaydi).
+func (h *BotHandler) askPickupBranch(userID int64, session *orderSession) bool {
+	branches := h.getBranches()
+	switch len(branches) {
+	case 0:
+		return false
+	case 1:
+		b := branches[0]
+		h.orderMu.Lock()
+		session.Branch = &b
+		h.orderMu.Unlock()
+		return false
+	}
+	current := ""
+	if pref, ok := h.preferredBranch(userID); ok {
+		current = pref.ID
+	}
+	h.orderMu.Lock()
+	session.Stage = orderStageNeedBranch
+	h.orderSessions[userID] = session
+	h.orderMu.Unlock()
+	h.sendBranchChoiceForm(userID, current)
+	return true
+}
+
+func (h *BotHandler) sendBranchChoiceForm(userID int64, currentID string) {
+	lang := h.getUserLang(userID)
+	kb := h.branchKeyboard("branch_pick|", currentID)
+	h.sendOrderForm(userID, tr(lang, "branch.choose"), &kb)
+}
+
+// handleBranchPick buyurtma jarayonida olib ketish filiali tanlandi
+func (h *BotHandler) handleBranchPick(userID, chatID int64, branchID string) {
+	b, found := h.findBranch(branchID)
+	h.orderMu.Lock()
+	session, ok := h.orderSessions[userID]
+	if ok && found && session.Stage == orderStageNeedBranch {
+		session.Branch = &b
+		h.orderSessions[userID] = session
+	}
+	h.orderMu.Unlock()
+	if !ok || session.Stage != orderStageNeedBranch {
+		return
+	}
+	if !found {
+		h.sendBranchChoiceForm(userID, "")
+		return
+	}
+	if err := h.setUserBranch(userID, b.ID); err != nil {
+		log.Printf("user branch save failed user=%d: %v", userID, err)
+	}
+	h.finishPickupOrder(userID, chatID, session)
+}
+
+// finishPickupOrder olib ketish buyurtmasini guruhga yuborib, sessiyani yopadi
+func (h *BotHandler) finishPickupOrder(userID, chatID int64, session *orderSession) {
+	lang := h.getUserLang(userID)
+	prompt := tr(lang, "order.accepted_admin_contact")
+	if session.Branch != nil {
+		prompt = tr(lang, "branch.pickup_accepted", "branch", session.Branch.label())
+	}
+	h.sendOrderForm(userID, prompt, nil)
+	h.sendOrderToGroup2(userID, session, "Olib ketish", session.PickupNote)
+	h.sendStickerIfConfigured(chatID, stickerSlotOrderPlaced)
+	h.clearOrderSession(userID)
+}
+
+// handleBranchPref mijoz /branch orqali o'z filialini tanladi
+func (h *BotHandler) handleBranchPref(userID, chatID int64, branchID string) {
+	lang := h.getUserLang(userID)
+	b, ok := h.findBranch(branchID)
+	if !ok {
+		h.sendMessage(chatID, tr(lang, "branch.not_found"))
+		return
+	}
+	if err := h.setUserBranch(userID, b.ID); err != nil {
+		log.Printf("user branch save failed user=%d: %v", userID, err)
+	}
+	h.sendMessage(chatID, tr(lang, "branch.selected", "branch", b.label()))
+}
+
+func (h *BotHandler) branchesText() string {
+	branches := h.getBranches()
+	if len(branches) == 0 {
+		return "🏬 Filiallar yo'q (bitta ombor, buyurtmalar active orders guruhiga)."
+	}
+	var sb strings.Builder
+	sb.WriteString("🏬 Filiallar:\n")
+	for _, b := range branches {
+		sb.WriteString(fmt.Sprintf("• %s [%s] - qoldiq ustuni \"Stock: %s\"", b.label(), b.ID, b.stockKey()))
+		if b.ChatID != 0 {
+			sb.WriteString(fmt.Sprintf(", guruh %d", b.ChatID))
+			if b.ThreadID != 0 {
+				sb.WriteString(fmt.Sprintf("/%d", b.ThreadID))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+const branchesUsage = `Foydalanish:
+/branch add <nom> [manzil] - filial qo'shish (qoldiq ustuni "Stock: <nom>" yoki <nom> varag'i)
+/branch stock <nom> <ustun> - qoldiq ustuni/varag'i nomi boshqacha bo'lsa
+/branch chat <nom> [chat_id] [thread_id] - buyurtmalar guruhi (chat_id bo'lmasa - shu chat)
+/branch remove <nom> - o'chirish
+Bo'shliqli nomlar qo'shtirnoqda: "Yunusobod filiali"`
+
+// handleBranchCommand /branch - admin filiallarni boshqaradi, mijoz o'z filialini tanlaydi
+func (h *BotHandler) handleBranchCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := message.From.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		lang := h.getUserLang(userID)
+		if len(h.getBranches()) == 0 {
+			h.sendMessage(chatID, tr(lang, "branch.none"))
+			return
+		}
+		current := ""
+		if b, ok := h.preferredBranch(userID); ok {
+			current = b.ID
+		}
+		msg := tgbotapi.NewMessage(chatID, tr(lang, "branch.pick_preferred"))
+		msg.ReplyMarkup = h.branchKeyboard("branch_pref|", current)
+		_, _ = h.sendAndLog(msg)
+		return
+	}
+
+	args := splitPromoArgs(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(chatID, h.branchesText()+"\n"+branchesUsage)
+		return
+	}
+	if len(args) < 2 {
+		h.sendMessage(chatID, "❌ Noto'g'ri format.\n"+branchesUsage)
+		return
+	}
+
+	branches := h.getBranches()
+	name := args[1]
+	idx := -1
+	for i := range branches {
+		if strings.EqualFold(branches[i].ID, name) || strings.EqualFold(branches[i].Name, name) {
+			idx = i
+			break
+		}
+	}
+	if strings.ToLower(args[0]) != "add" && idx < 0 {
+		h.sendMessage(chatID, fmt.Sprintf("❌ %q filiali topilmadi.", name))
+		return
+	}
+
+	var reply string
+	switch strings.ToLower(args[0]) {
+	case "add":
+		b := storeBranch{ID: branchIDFromName(name), Name: name, Address: strings.Join(args[2:], " ")}
+		if idx >= 0 {
+			b.Stock, b.ChatID, b.ThreadID = branches[idx].Stock, branches[idx].ChatID, branches[idx].ThreadID
+			branches[idx] = b
+		} else {
+			branches = append(branches, b)
+		}
+		reply = fmt.Sprintf("✅ %s filiali saqlandi. Katalogda \"Stock: %s\" ustuni yoki %q varag'i bo'lsin.", b.Name, b.stockKey(), b.Name)
+	case "stock":
+		if len(args) < 3 {
+			h.sendMessage(chatID, "❌ Noto'g'ri format.\n"+branchesUsage)
+			return
+		}
+		branches[idx].Stock = strings.Join(args[2:], " ")
+		reply = fmt.Sprintf("✅ %s qoldig'i: \"Stock: %s\" ustuni.", branches[idx].Name, branches[idx].stockKey())
+	case "chat":
+		target, thread := chatID, 0
+		if len(args) > 2 {
+			id, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				h.sendMessage(chatID, "❌ Noto'g'ri chat_id.\n"+branchesUsage)
+				return
+			}
+			target = id
+		}
+		if len(args) > 3 {
+			th, err := strconv.Atoi(args[3])
+			if err != nil {
+				h.sendMessage(chatID, "❌ Noto'g'ri thread_id.\n"+branchesUsage)
+				return
+			}
+			thread = th
+		}
+		branches[idx].ChatID, branches[idx].ThreadID = target, thread
+		reply = fmt.Sprintf("✅ %s buyurtmalari %d guruhiga yuboriladi.", branches[idx].Name, target)
+	case "remove", "delete":
+		reply = fmt.Sprintf("🗑 %s filiali o'chirildi.", branches[idx].Name)
+		branches = append(branches[:idx], branches[idx+1:]...)
+	default:
+		h.sendMessage(chatID, branchesUsage)
+		return
+	}
+
+	if err := h.setBranches(branches); err != nil {
+		log.Printf("branches save failed: %v", err)
+		h.sendMessage(chatID, "❌ Filiallarni saqlashda xatolik.")
+		return
+	}
+	h.sendMessage(chatID, reply+"\n\n"+h.branchesText())
+}
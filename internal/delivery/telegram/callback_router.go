@@ -24,10 +24,13 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 	// Guruhlarda AI callbacklari ishlatilmaydi (faqat ma'lum topiklar)
 	// Guruhlarda AI callbacklari faqat ma'lum chatlar uchun ishlaydi
 	if cq.Message.Chat != nil && (cq.Message.Chat.IsGroup() || cq.Message.Chat.IsSuperGroup()) &&
-		chatID != h.group1ChatID && chatID != h.group2ChatID && chatID != h.group4ChatID {
+		chatID != h.group1ChatID && chatID != h.group2ChatID && chatID != h.group4ChatID && !h.isBranchChat(chatID) && !h.isServiceChat(chatID) {
 		return
 	}
 
+	// Tugma bosilishi ham jarayonni faol deb hisoblanadi (timeout uchun)
+	h.touchConversation(userID)
+
 	// Callback ga javob (spinnerni to'xtatish)
 	callback := tgbotapi.NewCallback(cq.ID, "")
 	if _, err := h.bot.Request(callback); err != nil {
@@ -45,7 +48,7 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 				threadID = info.ThreadID
 			}
 		}
-		if chatID != h.activeOrdersChatID || threadID == 0 {
+		if !h.isOrderChannel(chatID, threadID) {
 			return
 		}
 		h.handleOrderReadyCallback(chatID, threadID, orderID, cq.Message)
@@ -63,7 +66,7 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 				threadID = info.ThreadID
 			}
 		}
-		if chatID != h.activeOrdersChatID || threadID == 0 {
+		if !h.isOrderChannel(chatID, threadID) {
 			return
 		}
 		h.handleOrderOnWayCallback(chatID, threadID, userID, orderID)
@@ -81,7 +84,7 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 				threadID = info.ThreadID
 			}
 		}
-		if chatID != h.activeOrdersChatID || threadID == 0 {
+		if !h.isOrderChannel(chatID, threadID) {
 			return
 		}
 		h.handleOrderCancelCallback(chatID, threadID, orderID, cq.Message)
@@ -207,6 +210,71 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 		h.applyDatabaseSelection(ctx, chatID, uint(id))
 		return
 	}
+	if strings.HasPrefix(data, "pay|") {
+		h.handlePayCallback(ctx, chatID, userID, data)
+		return
+	}
+	if strings.HasPrefix(data, "branch_pick|") {
+		h.handleBranchPick(userID, chatID, strings.TrimPrefix(data, "branch_pick|"))
+		return
+	}
+
+	if strings.HasPrefix(data, "branch_pref|") {
+		h.handleBranchPref(userID, chatID, strings.TrimPrefix(data, "branch_pref|"))
+		return
+	}
+
+	if strings.HasPrefix(data, "wty_claim|") {
+		h.handleWarrantyClaimCallback(userID, chatID, data)
+		return
+	}
+
+	if strings.HasPrefix(data, "bld_") {
+		h.handleSavedBuildCallback(ctx, cq)
+		return
+	}
+
+	if strings.HasPrefix(data, "upg_cart|") {
+		h.handleUpgradeCartCallback(userID, chatID, data, cq.Message)
+		return
+	}
+
+	if strings.HasPrefix(data, "peri_cart|") {
+		h.handlePeripheralsCartCallback(userID, chatID, data, cq.Message)
+		return
+	}
+
+	if strings.HasPrefix(data, "lap_cart|") {
+		h.handleLaptopCartCallback(userID, chatID, data, cq.Message)
+		return
+	}
+
+	if strings.HasPrefix(data, "alert_off|") {
+		h.handleAlertOffCallback(userID, chatID, strings.TrimPrefix(data, "alert_off|"))
+		return
+	}
+
+	if strings.HasPrefix(data, "cour_") {
+		// Format: cour_<pick|done|fail>|<orderID>
+		parts := strings.SplitN(strings.TrimPrefix(data, "cour_"), "|", 2)
+		if len(parts) == 2 {
+			h.handleCourierAction(userID, chatID, parts[0], parts[1], cq.Message)
+		}
+		return
+	}
+
+	if strings.HasPrefix(data, "inst|") {
+		h.handleInstallmentCallback(userID, chatID, data)
+		return
+	}
+	if strings.HasPrefix(data, "payrefund|") {
+		h.refundOrderAsAdmin(ctx, chatID, userID, strings.TrimPrefix(data, "payrefund|"))
+		return
+	}
+	if strings.HasPrefix(data, "myord|") {
+		h.handleMyOrdersCallback(chatID, userID, data, cq.Message)
+		return
+	}
 	if strings.HasPrefix(data, "order_view|") {
 		orderID := strings.TrimPrefix(data, "order_view|")
 		h.handleOrderViewCallback(chatID, userID, orderID)
@@ -306,6 +374,40 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 		return
 	}
 
+	// Servis so'rovi holati: tkt_<status>:<ticketID>
+	if strings.HasPrefix(data, "tkt_") {
+		isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+		if !isAdmin {
+			h.sendMessage(chatID, "❌ Bu funksiya faqat adminlar uchun.")
+			return
+		}
+		parts := strings.SplitN(strings.TrimPrefix(data, "tkt_"), ":", 2)
+		if len(parts) == 2 {
+			h.handleTicketStatusChange(chatID, parts[1], parts[0], cq.Message)
+		}
+		return
+	}
+
+	// Kuryer tayinlash: ordcour:<orderID>, ordcour_set:<orderID>:<courierID>
+	if strings.HasPrefix(data, "ordcour") {
+		isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+		if !isAdmin {
+			h.sendMessage(chatID, "❌ Bu funksiya faqat adminlar uchun.")
+			return
+		}
+		if strings.HasPrefix(data, "ordcour_set:") {
+			parts := strings.SplitN(strings.TrimPrefix(data, "ordcour_set:"), ":", 2)
+			if len(parts) == 2 {
+				if courierID, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+					h.assignCourier(chatID, parts[0], courierID)
+				}
+			}
+			return
+		}
+		h.handleCourierPickerCallback(chatID, strings.TrimPrefix(data, "ordcour:"))
+		return
+	}
+
 	// Message to customer callback
 	if strings.HasPrefix(data, "ordmsg:") {
 		isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
@@ -441,6 +543,10 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 		h.sendMessage(chatID, "Qaysi komponentni o'zgartirish kerakligini yozib yuboring.")
 	case "cfg_fb_delete":
 		h.sendDeleteComponentPrompt(chatID)
+	case "cfg_opt":
+		h.handleConfigOptimize(ctx, userID, chatID, offerID)
+	case "cfg_opt_pick":
+		h.handleConfigOptimizePick(ctx, userID, chatID, offerID)
 	case "cfg_analyze_pc":
 		// PC Analyze tugmasi bosildi
 		var configText string
@@ -462,7 +568,7 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 		if configText != "" {
 			h.handlePCAnalysisRequest(ctx, userID, username, chatID, configText, purposeHint)
 		} else {
-			h.sendMessage(chatID, t(lang, "❌ PC konfiguratsiyasi topilmadi. Iltimos, /configuratsiya ni qaytadan bosing.", "❌ Конфигурация ПК не найдена. Пожалуйста, нажмите /configuratsiya еще раз."))
+			h.sendMessage(chatID, tr(lang, "config.not_found_restart"))
 		}
 	case "cfg_change_cpu":
 		if offerID != "" {
@@ -597,7 +703,7 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 		} else {
 			h.popFeedback(userID)
 		}
-		h.sendMessage(chatID, t(lang, "👌 Tushunarli. Boshqa savollar bo'lsa, menga yozishingiz mumkin!", "👌 Понял. Если будут вопросы, пишите!"))
+		h.sendMessage(chatID, tr(lang, "chat.understood"))
 	case "online_refresh":
 		h.handleOnlineRefreshCallback(ctx, chatID, userID, cq.Message)
 	case "online_back":
@@ -688,6 +794,8 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 	case "analyze_pc":
 		// Analyze PC callback
 		h.handleAnalyzePCCallback(ctx, cq)
+	case "download_pdf_report":
+		h.handleSpecSheetCallback(ctx, userID, chatID)
 	case "purchase_config":
 		h.clearInlineButtons(cq)
 		h.setConfigOrderLocked(userID, true)
@@ -715,7 +823,7 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 			}
 		}
 		if strings.TrimSpace(configText) == "" {
-			h.sendMessage(chatID, t(lang, "❌ PC konfiguratsiyasi topilmadi. Iltimos, /configuratsiya ni qaytadan bosing.", "❌ Конфигурация ПК не найдена. Пожалуйста, нажмите /configuratsiya еще раз."))
+			h.sendMessage(chatID, tr(lang, "config.not_found_restart"))
 			return
 		}
 
@@ -757,7 +865,7 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 		h.sendMessage(chatID, adminApprovalWaitMessage(lang, time.Now()))
 	case "new_config":
 		// Yangi konfiguratsiya
-		h.sendMessage(chatID, t(lang, "🔄 Yangi konfiguratsiya uchun /configuratsiya ni bosing", "🔄 Для новой конфигурации нажмите /configuratsiya"))
+		h.sendMessage(chatID, tr(lang, "config.new_hint"))
 	default:
 		// boshqa callback lar uchun hech narsa qilmaymiz
 	}
@@ -270,7 +270,8 @@ func isBackCommand(text string) bool {
 	}
 	lower := strings.ToLower(trim)
 	lower = strings.TrimPrefix(lower, "/")
-	return strings.Contains(lower, "orqaga") || strings.Contains(lower, "назад") || lower == "back"
+	return strings.Contains(lower, "orqaga") || strings.Contains(lower, "орқага") || strings.Contains(lower, "назад") ||
+		lower == "back" || strings.HasSuffix(lower, " back")
 }
 
 // isProductModelMention - matnda aniq model (CPU/GPU/umumiy) borligini tekshiradi
@@ -349,21 +350,17 @@ func (h *BotHandler) handleCartAddCallback(chatID, userID int64, offerID string,
 		}
 	}
 	if !ok {
-		h.sendMessage(chatID, t(lang, "❌ Mahsulot ma'lumotlari topilmadi.", "❌ Данные товара не найдены."))
+		h.sendMessage(chatID, tr(lang, "cart.product_missing"))
 		return
 	}
 
 	title := cartTitleFromText(info.ConfigText)
 	if title == "" {
-		title = t(lang, "Mahsulot", "Товар")
+		title = tr(lang, "cart.product_fallback")
 	}
 	h.addToCart(userID, cartItem{Title: title, Text: info.ConfigText})
 	if msg != nil {
-		tag := t(lang,
-			fmt.Sprintf("✅ Savatga qo'shildi: %s", title),
-			fmt.Sprintf("✅ Добавлено в корзину: %s", title),
-		)
-		tag += "\n" + t(lang, "🛒 Savatdagi mahsulotlaringizni ko'rish uchun savatcha tugmasini bosing.", "🛒 Нажмите на корзину, чтобы увидеть товары.")
+		tag := tr(lang, "cart.added", "title", title) + "\n" + tr(lang, "cart.added_hint")
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, msg.MessageID, tag, tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
 				tgbotapi.NewInlineKeyboardButtonData("🛒 Savatcha", "cart_open"),
@@ -379,12 +376,12 @@ func (h *BotHandler) handleCartCheckoutCallback(chatID, userID int64, offerID st
 	lang := h.getUserLang(userID)
 	idx, err := strconv.Atoi(offerID)
 	if err != nil {
-		h.sendMessage(chatID, t(lang, "❌ Noto'g'ri savat indeksi.", "❌ Неверный индекс корзины."))
+		h.sendMessage(chatID, tr(lang, "cart.bad_index"))
 		return
 	}
 	items := h.listCart(userID)
 	if idx < 0 || idx >= len(items) {
-		h.sendMessage(chatID, t(lang, "❌ Savatdagi mahsulot topilmadi.", "❌ Товар в корзине не найден."))
+		h.sendMessage(chatID, tr(lang, "cart.item_missing"))
 		return
 	}
 	text := strings.TrimSpace(items[idx].Text)
@@ -407,7 +404,7 @@ func (h *BotHandler) handleCartClearCallback(chatID, userID int64, msg *tgbotapi
 	items := h.listCart(userID)
 	lang := h.getUserLang(userID)
 	if len(items) == 0 {
-		h.sendMessage(chatID, t(lang, "🛒 Savatingiz bo'sh.", "🛒 Корзина пуста."))
+		h.sendMessage(chatID, tr(lang, "cart.is_empty"))
 		return
 	}
 	var rows [][]tgbotapi.InlineKeyboardButton
@@ -417,9 +414,9 @@ func (h *BotHandler) handleCartClearCallback(chatID, userID int64, msg *tgbotapi
 		))
 	}
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "cart_back"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "cart_back"),
 	))
-	text := t(lang, "Qaysi mahsulotni o'chiramiz?", "Какой товар удалить?")
+	text := tr(lang, "cart.remove_prompt")
 	if msg != nil {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, msg.MessageID, text, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows})
 		if _, err := h.bot.Send(edit); err != nil {
@@ -436,11 +433,11 @@ func (h *BotHandler) handleCartDeleteCallback(chatID, userID int64, offerID stri
 	lang := h.getUserLang(userID)
 	idx, err := strconv.Atoi(offerID)
 	if err != nil {
-		h.sendMessage(chatID, t(lang, "❌ Noto'g'ri indeks.", "❌ Неверный индекс."))
+		h.sendMessage(chatID, tr(lang, "cart.bad_item"))
 		return
 	}
 	if !h.removeCartItem(userID, idx) {
-		h.sendMessage(chatID, t(lang, "❌ Savatdan o'chirib bo'lmadi.", "❌ Не удалось удалить из корзины."))
+		h.sendMessage(chatID, tr(lang, "cart.remove_failed"))
 		return
 	}
 	if msg != nil {
@@ -453,7 +450,7 @@ func (h *BotHandler) handleCartDeleteCallback(chatID, userID int64, offerID stri
 func (h *BotHandler) handleCartClearAllCallback(chatID, userID int64, msg *tgbotapi.Message) {
 	h.clearCart(userID)
 	lang := h.getUserLang(userID)
-	text := t(lang, "♻️ Savat tozalandi.", "♻️ Корзина очищена.")
+	text := tr(lang, "cart.cleared")
 	if msg != nil {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, msg.MessageID, text, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
 		if _, err := h.bot.Send(edit); err != nil {
@@ -469,7 +466,7 @@ func (h *BotHandler) handleCartCommand(chatID, userID int64) {
 	items := h.listCart(userID)
 	lang := h.getUserLang(userID)
 	if len(items) == 0 {
-		h.sendMessage(chatID, t(lang, "🛒 Savatingiz bo'sh. Mahsulot yonidagi \"Savatga qo'shish\" tugmasini bosing.", "🛒 Ваша корзина пуста. Нажмите \"Добавить в корзину\" возле товара."))
+		h.sendMessage(chatID, tr(lang, "cart.empty"))
 		return
 	}
 	var rows [][]tgbotapi.InlineKeyboardButton
@@ -479,11 +476,15 @@ func (h *BotHandler) handleCartCommand(chatID, userID int64) {
 		))
 	}
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "🗑️ Tanlab o'chirish", "🗑️ Удалить выборочно"), "cart_clear"),
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "♻️ Hammasini tozalash", "♻️ Очистить всё"), "cart_clear_all"),
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "🛍️ Barchasini rasmiylashtirish", "🛍️ Оформить всё"), "cart_checkout_all"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "cart.remove_some"), "cart_clear"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "cart.clear_all"), "cart_clear_all"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "cart.checkout_all"), "cart_checkout_all"),
 	))
-	msg := tgbotapi.NewMessage(chatID, t(lang, "🛒 Savatdagi mahsulotlar:", "🛒 Товары в корзине:"))
+	text := trPlural(lang, "cart.header", len(items))
+	if summary := h.cartSummaryText(lang, items); summary != "" {
+		text += "\n\n" + summary
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ReplyMarkup = tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
 	_, _ = h.sendAndLog(msg)
 }
@@ -493,7 +494,7 @@ func (h *BotHandler) handleCartOpenCallback(chatID, userID int64, msg *tgbotapi.
 	lang := h.getUserLang(userID)
 	items := h.listCart(userID)
 	if len(items) == 0 {
-		text := t(lang, "🛒 Savatingiz bo'sh.", "🛒 Корзина пуста.")
+		text := tr(lang, "cart.is_empty")
 		if msg != nil {
 			edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, msg.MessageID, text, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
 			_, _ = h.sendAndLog(edit)
@@ -515,17 +516,20 @@ func (h *BotHandler) handleCartOpenCallback(chatID, userID int64, msg *tgbotapi.
 		))
 	}
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "🧹 Tanlab o'chirish", "🧹 Удалить выборочно"), "cart_clear"),
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "♻️ Hammasini tozalash", "♻️ Очистить всё"), "cart_clear_all"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "cart.remove_some_list"), "cart_clear"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "cart.clear_all"), "cart_clear_all"),
 	))
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "🛒 Barchasini rasmiylashtirish", "🛒 Оформить всё"), "cart_checkout_all"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "cart.checkout_all_list"), "cart_checkout_all"),
 	))
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "cart_back"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "cart_back"),
 	))
 
-	text := t(lang, "🛒 Savatdagi mahsulotlar (quyidagi tugmalardan tanlang):", "🛒 Товары в корзине (выберите кнопкой ниже):")
+	text := tr(lang, "cart.list_header")
+	if summary := h.cartSummaryText(lang, items); summary != "" {
+		text += "\n\n" + summary
+	}
 	if msg != nil {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, msg.MessageID, text, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows})
 		if _, err := h.bot.Send(edit); err != nil {
@@ -544,7 +548,7 @@ func (h *BotHandler) handleCartBack(chatID, userID int64, msg *tgbotapi.Message)
 	lang := h.getUserLang(userID)
 	items := h.listCart(userID)
 	if len(items) == 0 {
-		text := t(lang, "🛒 Savatingiz bo'sh.", "🛒 Корзина пуста.")
+		text := tr(lang, "cart.is_empty")
 		if msg != nil {
 			edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, msg.MessageID, text, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
 			_, _ = h.sendAndLog(edit)
@@ -556,12 +560,9 @@ func (h *BotHandler) handleCartBack(chatID, userID int64, msg *tgbotapi.Message)
 
 	title := cartTitleFromText(items[0].Text)
 	if title == "" {
-		title = t(lang, "Mahsulot", "Товар")
+		title = tr(lang, "cart.product_fallback")
 	}
-	text := t(lang,
-		fmt.Sprintf("✅ Savatga qo'shildi: %s\n🛒 Savatdagi mahsulotlaringizni ko'rish uchun savatcha tugmasini bosing.", title),
-		fmt.Sprintf("✅ Добавлено в корзину: %s\n🛒 Нажмите на корзину, чтобы увидеть товары.", title),
-	)
+	text := tr(lang, "cart.added", "title", title) + "\n" + tr(lang, "cart.added_hint")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🛒 Savatcha", "cart_open"),
@@ -603,7 +604,7 @@ func (h *BotHandler) handlePurchaseIntentPrompt(ctx context.Context, userID int6
 	lang := h.getUserLang(userID)
 
 	if h.isProcessing(userID) {
-		h.sendMessage(chatID, t(lang, "⏳ Oldingi so'rov yakunlanmoqda, iltimos kuting.", "⏳ Предыдущий запрос обрабатывается, подождите."))
+		h.sendMessage(chatID, tr(lang, "common.busy"))
 		return
 	}
 
@@ -615,7 +616,7 @@ func (h *BotHandler) handlePurchaseIntentPrompt(ctx context.Context, userID int6
 	}
 
 	if suggestion == "" {
-		h.sendMessage(chatID, t(lang, "Qaysi mahsulotni sotib olmoqchisiz? Model yoki to'liq nomini yozing.", "Какой товар хотите купить? Напишите модель или полное название."))
+		h.sendMessage(chatID, tr(lang, "purchase.ask_product"))
 		return
 	}
 
@@ -635,9 +636,7 @@ func (h *BotHandler) handlePurchaseIntentPrompt(ctx context.Context, userID int6
 				return
 			}
 		}
-		h.sendMessage(chatID, t(lang,
-			"Bu to'liq konfiguratsiya. Qaysi bitta komponent/mahsulotni olmoqchisiz? Modelini yoki CPU/GPU/RAM kabi nomini yozing.",
-			"Это полная конфигурация. Какой конкретный компонент/товар хотите купить? Напишите модель или тип (CPU/GPU/RAM и т.д.)."))
+		h.sendMessage(chatID, tr(lang, "purchase.ask_component"))
 		return
 	}
 
@@ -648,15 +647,9 @@ func (h *BotHandler) handlePurchaseIntentPrompt(ctx context.Context, userID int6
 
 	var prompt string
 	if len(products) > 0 {
-		prompt = t(lang,
-			fmt.Sprintf("Quyidagi mahsulotni sotib olmoqchimisiz?\n• %s", strings.Join(products, "\n• ")),
-			fmt.Sprintf("Хотите купить эти товары?\n• %s", strings.Join(products, "\n• ")),
-		)
+		prompt = tr(lang, "purchase.confirm_products", "products", strings.Join(products, "\n• "))
 	} else {
-		prompt = t(lang,
-			fmt.Sprintf("Quyidagi taklifni sotib olmoqchimisiz?\n%s", suggestion),
-			fmt.Sprintf("Хотите купить подборку?\n%s", suggestion),
-		)
+		prompt = tr(lang, "purchase.confirm_offer", "offer", suggestion)
 	}
 
 	offerID := newUUID()
@@ -666,11 +659,11 @@ func (h *BotHandler) handlePurchaseIntentPrompt(ctx context.Context, userID int6
 	}
 	kb := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "✅ Ha", "✅ Да"), "purchase_yes|"+offerID),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "❌ Yo'q", "❌ Нет"), "purchase_no"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.yes"), "purchase_yes|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.no"), "purchase_no"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🛒 Savatga qo'shish", "🛒 Добавить в корзину"), "cart_add|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.add_to_cart"), "cart_add|"+offerID),
 		),
 	)
 
@@ -720,7 +713,7 @@ func (h *BotHandler) sendPurchaseConfirmationButtons(chatID, userID int64, sugge
 	lang := h.getUserLang(userID)
 	title := cartTitleFromText(suggestion)
 	if title == "" {
-		title = t(lang, "Taklif", "Подбор")
+		title = tr(lang, "purchase.offer_fallback")
 	}
 	if isConfigLikeResponse(suggestion) {
 		log.Printf("⚠️ [Purchase Buttons] Skipping - config-like response")
@@ -735,15 +728,12 @@ func (h *BotHandler) sendPurchaseConfirmationButtons(chatID, userID int64, sugge
 		return
 	}
 	log.Printf("📤 [Purchase Buttons] Sending buttons to userID=%d", userID)
-	text := t(lang,
-		fmt.Sprintf("Sotib olamizmi? %s", title),
-		fmt.Sprintf("Оформляем покупку? %s", title),
-	)
+	text := tr(lang, "purchase.confirm_title", "title", title)
 	kb := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "✅ Zakaz beraman", "✅ Оформить"), "purchase_yes|"+offerID),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🛒 Savatga qo'shish", "🛒 В корзину"), "cart_add|"+offerID),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "❌ Bekor qilish", "❌ Отмена"), "purchase_no"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "reminder.order_yes"), "purchase_yes|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.to_cart"), "cart_add|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.cancel"), "purchase_no"),
 		),
 	)
 	msg := tgbotapi.NewMessage(chatID, text)
@@ -760,7 +750,7 @@ func (h *BotHandler) handlePurchaseYes(ctx context.Context, userID int64, userna
 	_ = ctx
 	if h.hasOrderSession(userID) {
 		lang := h.getUserLang(userID)
-		h.sendMessage(chatID, t(lang, "🧾 Buyurtma jarayoni allaqachon boshlangan. Iltimos, avvalgi buyurtmani yakunlang.", "🧾 Оформление уже начато. Пожалуйста, завершите текущий заказ."))
+		h.sendMessage(chatID, tr(lang, "purchase.already_started"))
 		return
 	}
 	var editChatID int64
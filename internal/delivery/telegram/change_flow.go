@@ -11,6 +11,7 @@ import (
 
 // Pending change helpers
 func (h *BotHandler) setPendingChange(userID int64, cr changeRequest) {
+	h.enterConversation(userID, convFlowComponentChange, cr.Component, 0)
 	h.changeMu.Lock()
 	defer h.changeMu.Unlock()
 	h.pendingChange[userID] = cr
@@ -18,11 +19,12 @@ func (h *BotHandler) setPendingChange(userID int64, cr changeRequest) {
 
 func (h *BotHandler) popPendingChange(userID int64) (changeRequest, bool) {
 	h.changeMu.Lock()
-	defer h.changeMu.Unlock()
 	cr, ok := h.pendingChange[userID]
 	if ok {
 		delete(h.pendingChange, userID)
 	}
+	h.changeMu.Unlock()
+	h.leaveConversation(userID, convFlowComponentChange)
 	return cr, ok
 }
 
@@ -42,7 +44,7 @@ func (h *BotHandler) handleChangeRequest(ctx context.Context, userID int64, user
 	}
 
 	if !h.startProcessing(userID) {
-		h.sendMessage(chatID, t(lang, "⏳ Oldingi so'rov yakunlanmoqda, iltimos kuting.", "⏳ Предыдущий запрос обрабатывается, подождите."))
+		h.sendMessage(chatID, tr(lang, "common.busy"))
 		return
 	}
 	defer h.clearWaitingMessage(userID)
@@ -50,7 +52,7 @@ func (h *BotHandler) handleChangeRequest(ctx context.Context, userID int64, user
 
 	newValue := strings.TrimSpace(text)
 	if newValue == "" {
-		h.sendMessage(chatID, t(lang, "Iltimos, o'zgartirmoqchi bo'lgan komponent nomini yozing.", "Пожалуйста, укажите компонент для замены."))
+		h.sendMessage(chatID, tr(lang, "config.change.ask_component"))
 		return
 	}
 
@@ -96,11 +98,9 @@ func (h *BotHandler) handleChangeRequest(ctx context.Context, userID int64, user
 			newValue,
 		)
 	}
-	if lang == "ru" {
-		prompt = "Отвечай только на русском языке.\n" + prompt
-	}
+	prompt = aiLanguageInstruction(lang) + prompt
 
-	waitMsg, err := h.sendMessageWithResp(chatID, t(lang, "⏳ Iltimos, javobni kuting.", "⏳ Пожалуйста, подождите."))
+	waitMsg, err := h.sendMessageWithResp(chatID, tr(lang, "common.wait_answer"))
 	if err == nil {
 		h.setWaitingMessage(userID, chatID, waitMsg.MessageID)
 	}
@@ -112,8 +112,12 @@ func (h *BotHandler) handleChangeRequest(ctx context.Context, userID int64, user
 		return
 	}
 
+	response, violations := h.ensureConfigCompatibility(ctx, userID, username, prompt, response)
 	response = h.applyCurrencyPreference(response)
 	h.sendMessage(chatID, response)
+	if notes := compatNotes(lang, violations); notes != "" {
+		h.sendMessage(chatID, notes)
+	}
 
 	offerID := h.saveFeedback(userID, feedbackInfo{
 		Summary:    fmt.Sprintf("Maqsad: %s, Budjet: %s, CPU: %s, RAM: %s, Xotira: %s, GPU: %s", spec.PCType, spec.Budget, spec.CPU, spec.RAM, spec.Storage, spec.GPU),
@@ -129,14 +133,14 @@ func (h *BotHandler) handleChangeRequest(ctx context.Context, userID int64, user
 // Komponent o'chirish tugmalari
 func (h *BotHandler) sendDeleteComponentPrompt(chatID int64) {
 	lang := h.getUserLang(chatID)
-	msg := tgbotapi.NewMessage(chatID, t(lang, "Qaysi komponentni o'chirmoqchisiz?", "Какой компонент удалить?"))
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "config.delete.ask"))
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🖥️ Monitor", "cfg_del_monitor"),
 			tgbotapi.NewInlineKeyboardButtonData("🖱️ Peripherals", "cfg_del_peripherals"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Boshqa", "Другое"), "cfg_del_other"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.delete.other"), "cfg_del_other"),
 		),
 	)
 	_, _ = h.sendAndLog(msg)
@@ -149,12 +153,12 @@ func (h *BotHandler) handleDeleteAction(ctx context.Context, userID int64, chatI
 	// Feedback ma'lumotlarini olish
 	info, ok := h.getLatestFeedback(userID)
 	if !ok {
-		h.sendMessage(chatID, t(lang, "❌ Konfiguratsiya topilmadi.", "❌ Конфигурация не найдена."))
+		h.sendMessage(chatID, tr(lang, "config.not_found"))
 		return
 	}
 
 	if !h.startProcessing(userID) {
-		h.sendMessage(chatID, t(lang, "⏳ Oldingi so'rov yakunlanmoqda, iltimos kuting.", "⏳ Предыдущий запрос обрабатывается, подождите."))
+		h.sendMessage(chatID, tr(lang, "common.busy"))
 		return
 	}
 	defer h.clearWaitingMessage(userID)
@@ -171,7 +175,7 @@ func (h *BotHandler) handleDeleteAction(ctx context.Context, userID int64, chatI
 		changeDesc = "Periferiyani olib tashlash"
 	case "other":
 		h.setPendingChange(userID, changeRequest{Component: "delete_other", Spec: spec})
-		h.sendMessage(chatID, t(lang, "Nimani o'chirmoqchisiz? Yozib yuboring.", "Что удалить? Напишите."))
+		h.sendMessage(chatID, tr(lang, "config.delete.ask_text"))
 		return
 	}
 
@@ -184,11 +188,9 @@ func (h *BotHandler) handleDeleteAction(ctx context.Context, userID int64, chatI
 		nonEmpty(spec.Storage, "aniqlanmagan"),
 		nonEmpty(spec.GPU, "aniqlanmagan"),
 	)
-	if lang == "ru" {
-		prompt = "Отвечай только на русском языке.\n" + prompt
-	}
+	prompt = aiLanguageInstruction(lang) + prompt
 
-	waitMsg, err := h.sendMessageWithResp(chatID, t(lang, "⏳ Iltimos, javobni kuting.", "⏳ Пожалуйста, подождите."))
+	waitMsg, err := h.sendMessageWithResp(chatID, tr(lang, "common.wait_answer"))
 	if err == nil {
 		h.setWaitingMessage(userID, chatID, waitMsg.MessageID)
 	}
@@ -200,8 +202,12 @@ func (h *BotHandler) handleDeleteAction(ctx context.Context, userID int64, chatI
 		return
 	}
 
+	response, violations := h.ensureConfigCompatibility(ctx, userID, info.Username, prompt, response)
 	response = h.applyCurrencyPreference(response)
 	h.sendMessage(chatID, response)
+	if notes := compatNotes(lang, violations); notes != "" {
+		h.sendMessage(chatID, notes)
+	}
 
 	offerID := h.saveFeedback(userID, feedbackInfo{
 		Summary:    fmt.Sprintf("O'zgartirildi: %s. Maqsad: %s, Budjet: %s", changeDesc, spec.PCType, spec.Budget),
@@ -29,6 +29,9 @@ func (h *BotHandler) cleanupSessions(ctx context.Context) {
 			}
 			h.configMu.Unlock()
 
+			// Muddati o'tgan jarayonlar (conversation state machine)
+			h.sweepExpiredConversations(now)
+
 			// Order sessiyalarni tozalash (timeout bo'lsa)
 			// Order session'da LastUpdate yo'q, lekin qo'shish mumkin
 			// Hozircha skip qilamiz
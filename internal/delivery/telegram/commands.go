@@ -9,6 +9,7 @@ import (
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
 )
 
 // handleCommand komandalarni qayta ishlash
@@ -19,7 +20,7 @@ func (h *BotHandler) handleCommand(ctx context.Context, message *tgbotapi.Messag
 	userID := message.From.ID
 	cmd := extractCommand(message)
 	if cmd == "" {
-		h.sendMessage(message.Chat.ID, "Noma'lum komanda. /help yordam uchun.")
+		h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "common.unknown_command"))
 		return
 	}
 	if h.isAdminActive(userID) {
@@ -34,11 +35,9 @@ func (h *BotHandler) handleCommand(ctx context.Context, message *tgbotapi.Messag
 
 	switch cmd {
 	case "start":
-		// Har doim til tanlash menyusini yuborish
-		h.trackWelcomeMessage(message.Chat.ID, message.MessageID)
-		h.sendLanguageSelector(message.Chat.ID)
+		h.handleStartCommand(ctx, message)
 	case "help":
-		h.sendMessage(message.Chat.ID, h.getHelpMessage())
+		h.sendMessage(message.Chat.ID, h.getHelpMessage(h.getUserLang(userID)))
 	case "clear":
 		h.handleClearCommand(ctx, message)
 	case "history":
@@ -55,8 +54,20 @@ func (h *BotHandler) handleCommand(ctx context.Context, message *tgbotapi.Messag
 		h.handleConfigCommand(ctx, message)
 	case "chat":
 		h.handleChatCommand(ctx, message)
+	case "subscribe":
+		h.handleMarketingConsentCommand(message, true)
+	case "unsubscribe":
+		h.handleMarketingConsentCommand(message, false)
+	case "cancel":
+		h.handleCancelCommand(ctx, message)
+	case "state":
+		h.handleStateCommand(ctx, message)
 	case "order":
 		h.handleOrderCommand(ctx, message)
+	case "myorders":
+		h.handleMyOrdersCommand(ctx, message)
+	case "order_grace":
+		h.handleOrderGraceCommand(ctx, message)
 	case "ordersadmin":
 		h.handleOrdersAdminCommand(ctx, message)
 	case "online":
@@ -100,6 +111,50 @@ func (h *BotHandler) handleCommand(ctx context.Context, message *tgbotapi.Messag
 		h.handleTopCommand(ctx, message)
 	case "orders":
 		h.handleOrdersAdminCommand(ctx, message)
+	case "refund":
+		h.handleRefundCommand(ctx, message)
+	case "installment":
+		h.handleInstallmentCommand(ctx, message)
+	case "promo":
+		h.handlePromoCommand(ctx, message)
+	case "zone", "zones":
+		h.handleZoneCommand(ctx, message)
+	case "branch", "branches":
+		h.handleBranchCommand(ctx, message)
+	case "courier", "couriers":
+		h.handleCourierCommand(ctx, message)
+	case "tasks":
+		h.handleTasksCommand(ctx, message)
+	case "notify":
+		h.handleNotifyCommand(ctx, message)
+	case "alerts":
+		h.handleAlertsCommand(message)
+	case "warranty":
+		h.handleWarrantyCommand(ctx, message)
+	case "upgrade":
+		h.handleUpgradeCommand(ctx, message)
+	case "psu":
+		h.handlePSUCommand(ctx, message)
+	case "peripherals":
+		h.handlePeripheralsCommand(ctx, message)
+	case "laptop":
+		h.handleLaptopCommand(ctx, message)
+	case "builds":
+		h.handleBuildsCommand(ctx, message)
+	case "build_rename":
+		h.handleBuildRenameCommand(ctx, message)
+	case "build_delete":
+		h.handleBuildDeleteCommand(ctx, message)
+	case "build_compare":
+		h.handleBuildCompareCommand(ctx, message)
+	case "serial":
+		h.handleSerialCommand(ctx, message)
+	case "tickets":
+		h.handleTicketsCommand(ctx, message)
+	case "specs":
+		h.handleSpecsCommand(ctx, message)
+	case "bench":
+		h.handleBenchCommand(ctx, message)
 	case "db_set":
 		h.handleDBSetCommand(ctx, message)
 	case "db_cancel":
@@ -131,7 +186,7 @@ func (h *BotHandler) handleCommand(ctx context.Context, message *tgbotapi.Messag
 	case "import_auto_off":
 		h.handleImportAutoOffCommand(ctx, message)
 	default:
-		h.sendMessage(message.Chat.ID, "Noma'lum komanda. /help yordam uchun.")
+		h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "common.unknown_command"))
 	}
 }
 
@@ -198,6 +253,31 @@ func (h *BotHandler) handleHistoryCommand(ctx context.Context, message *tgbotapi
 	h.sendMessageMarkdown(message.Chat.ID, sb.String())
 }
 
+// handleStartCommand - /start: til tanlash menyusi. Til hali tanlanmagan bo'lsa
+// Telegram LanguageCode dan aniqlanadi va darhol qo'llanadi.
+func (h *BotHandler) handleStartCommand(ctx context.Context, message *tgbotapi.Message) {
+	_ = ctx
+	userID := message.From.ID
+	h.trackWelcomeMessage(message.Chat.ID, message.MessageID)
+
+	detected := ""
+	if !h.hasUserLang(userID) {
+		if lang, ok := i18n.Detect(message.From.LanguageCode); ok {
+			detected = lang
+			h.setUserLang(userID, lang)
+		}
+	}
+	// Ulashilgan yig'ma havolasi: /start build_<id>
+	if h.handleBuildDeepLink(message, strings.TrimSpace(message.CommandArguments())) {
+		return
+	}
+	// Har doim til tanlash menyusini yuborish
+	h.sendLanguageSelector(message.Chat.ID, detected)
+	if detected != "" {
+		h.maybeAskProfile(userID, message.Chat.ID, detected)
+	}
+}
+
 // handleChatCommand - /chat: konfiguratsiya sessiyasidan chiqib, erkin chatga qaytish
 func (h *BotHandler) handleChatCommand(ctx context.Context, message *tgbotapi.Message) {
 	_ = ctx
@@ -207,16 +287,12 @@ func (h *BotHandler) handleChatCommand(ctx context.Context, message *tgbotapi.Me
 	if h.hasConfigSession(userID) {
 		h.cancelConfigSession(userID)
 		h.cancelConfigReminder(userID)
-		h.sendMessage(message.Chat.ID, t(lang,
-			"Konfiguratsiya jarayoni to'xtatildi. Endi erkin chatdamsiz. Savol yoki mahsulot bo'yicha yozishingiz mumkin.",
-			"Сессия конфигурации остановлена. Теперь вы в свободном чате, можете задавать вопросы или искать товары."))
+		h.sendMessage(message.Chat.ID, tr(lang, "chat.config_stopped"))
 		return
 	}
 
 	// Agar konfiguratsiya yo'q bo'lsa, oddiy chat allaqachon aktiv
-	h.sendMessage(message.Chat.ID, t(lang,
-		"Allaqachon erkin chatdasiz. Mahsulot yoki savollarni yozishingiz mumkin. Yangi konfiguratsiya uchun /configuratsiya ni bosing.",
-		"Вы уже в свободном чате. Можете задавать вопросы или искать товары. Для новой конфигурации нажмите /configuratsiya."))
+	h.sendMessage(message.Chat.ID, tr(lang, "chat.already_free"))
 }
 
 // handleAdminCommand admin login boshlash yoki admin menyu ko'rsatish
@@ -13,12 +13,20 @@ import (
 // ConfigurationBuilder - intelligent PC component selection
 type ConfigurationBuilder struct {
 	productUseCase usecase.ProductUseCase
+	converter      entity.Converter // so'mdagi narxlarni budjet (USD) bilan solishtirish uchun
+	compat         *usecase.CompatibilityChecker
+}
+
+// SetConverter valyuta kursi servisini ulaydi
+func (cb *ConfigurationBuilder) SetConverter(conv entity.Converter) {
+	cb.converter = conv
 }
 
 // NewConfigurationBuilder creates a new intelligent PC builder
 func NewConfigurationBuilder(productUseCase usecase.ProductUseCase) *ConfigurationBuilder {
 	return &ConfigurationBuilder{
 		productUseCase: productUseCase,
+		compat:         usecase.NewCompatibilityChecker(),
 	}
 }
 
@@ -34,7 +42,33 @@ type SelectedConfiguration struct {
 	Case        *entity.Product
 	Monitor     *entity.Product
 	Peripherals []*entity.Product
-	TotalPrice  float64
+	TotalPrice  entity.Money
+	Violations  []usecase.Violation // moslik tekshiruvi natijasi
+}
+
+// PCBuild tanlangan komponentlarni moslik tekshiruvi/tahlil uchun PCBuild ga o'giradi
+func (cfg *SelectedConfiguration) PCBuild() *entity.PCBuild {
+	build := &entity.PCBuild{}
+	if cfg == nil {
+		return build
+	}
+	value := func(p *entity.Product) entity.Product {
+		if p == nil {
+			return entity.Product{}
+		}
+		return *p
+	}
+	build.CPU = value(cfg.CPU)
+	build.RAM = value(cfg.RAM)
+	build.GPU = value(cfg.GPU)
+	build.SSD = value(cfg.SSD)
+	build.Motherboard = value(cfg.Motherboard)
+	build.PSU = value(cfg.PSU)
+	build.Cooler = cfg.Cooler
+	build.Case = cfg.Case
+	build.Monitor = cfg.Monitor
+	build.HasMonitor = cfg.Monitor != nil
+	return build
 }
 
 // BuildConfiguration - intelligent configuration selection
@@ -51,11 +85,47 @@ func (cb *ConfigurationBuilder) BuildConfiguration(
 
 	cfg := &SelectedConfiguration{}
 
+	// 2-9. Asosiy komponentlar: budjet ichidagi eng yuqori ballli mos yig'ma (optimizator).
+	// Katalogda kerakli turlar yetishmasa - ketma-ket (greedy) tanlov.
+	if alts, err := cb.BuildAlternatives(ctx, cb.optimizeRequest(budgetNum, pcType, cpuBrand, gpuBrand), 1); err == nil && len(alts) > 0 {
+		cfg.applyBuild(alts[0].Build)
+	} else if err := cb.selectCoreGreedy(ctx, cfg, budgetNum, pcType, cpuBrand, gpuBrand, storage); err != nil {
+		return nil, err
+	}
+
+	// 10-11. Monitor va periferiya - xohishlar, budjet ulushi va GPU imkoniyati bo'yicha
+	var kinds []string
+	if needMonitor {
+		kinds = append(kinds, usecase.PartMonitor)
+	}
+	if needPeripherals {
+		kinds = append(kinds, usecase.PartKeyboard, usecase.PartMouse, usecase.PartHeadset)
+	}
+	if len(kinds) > 0 {
+		prefs := usecase.PeripheralPrefs{MonitorHz: usecase.ParseHz(monitorHz), MonitorPanel: monitorDisplay}
+		req := usecase.PeripheralRequest{BudgetUSD: usecase.PeripheralBudget(budgetNum, kinds), Kinds: kinds, Prefs: prefs, Purpose: pcType}
+		if cfg.GPU != nil {
+			req.GPU = *cfg.GPU
+		}
+		if bundle, err := cb.PeripheralBundle(ctx, req); err == nil {
+			cfg.applyPeripherals(bundle)
+		}
+	}
+
+	// Calculate total price
+	cfg.TotalPrice = cb.calculateTotalPrice(cfg)
+	cfg.Violations = cb.compat.Check(cfg.PCBuild())
+
+	return cfg, nil
+}
+
+// selectCoreGreedy - komponentlarni ketma-ket tanlash (optimizator yig'ma topa olmaganda)
+func (cb *ConfigurationBuilder) selectCoreGreedy(ctx context.Context, cfg *SelectedConfiguration, budgetNum float64, pcType, cpuBrand, gpuBrand, storage string) error {
 	// 2. CPU selection - budjet va type asosida
 	cpuList, err := cb.productUseCase.GetByCategory(ctx, "CPU")
 	if err != nil {
 		log.Printf("CPU selection error: %v", err)
-		return nil, err
+		return err
 	}
 
 	cpu := cb.selectCPU(toProductPtrs(cpuList), cpuBrand, budgetNum, pcType)
@@ -75,7 +145,7 @@ func (cb *ConfigurationBuilder) BuildConfiguration(
 	// 4. Motherboard selection - CPU + RAM match
 	mbList, err := cb.productUseCase.GetByCategory(ctx, "Motherboard")
 	if err == nil {
-		mb := cb.selectMotherboard(toProductPtrs(mbList), cpu, cfg.RAM)
+		mb := cb.selectMotherboard(toProductPtrs(mbList), cfg.PCBuild())
 		if mb != nil {
 			cfg.Motherboard = mb
 		}
@@ -84,7 +154,7 @@ func (cb *ConfigurationBuilder) BuildConfiguration(
 	// 5. GPU selection - remaining budget
 	gpuList, err := cb.productUseCase.GetByCategory(ctx, "GPU")
 	if err == nil {
-		usedBudget := cb.getComponentPrice(cfg.CPU) + cb.getComponentPrice(cfg.RAM) + cb.getComponentPrice(cfg.Motherboard)
+		usedBudget := cb.priceUSD(cfg.CPU) + cb.priceUSD(cfg.RAM) + cb.priceUSD(cfg.Motherboard)
 		gpu := cb.selectGPU(toProductPtrs(gpuList), gpuBrand, budgetNum-usedBudget, pcType)
 		if gpu != nil {
 			cfg.GPU = gpu
@@ -113,7 +183,7 @@ func (cb *ConfigurationBuilder) BuildConfiguration(
 	// 7. PSU selection - CPU TDP + GPU power
 	psuList, err := cb.productUseCase.GetByCategory(ctx, "PSU")
 	if err == nil {
-		psu := cb.selectPSU(toProductPtrs(psuList), cfg.CPU, cfg.GPU)
+		psu := cb.selectPSU(toProductPtrs(psuList), cfg.PCBuild())
 		if psu != nil {
 			cfg.PSU = psu
 		}
@@ -133,7 +203,7 @@ func (cb *ConfigurationBuilder) BuildConfiguration(
 		}
 	}
 	if err == nil && len(coolerList) > 0 {
-		if cooler := cb.selectCooler(toProductPtrs(coolerList), cfg.CPU); cooler != nil {
+		if cooler := cb.selectCooler(toProductPtrs(coolerList), cfg.PCBuild()); cooler != nil {
 			cfg.Cooler = cooler
 		}
 	}
@@ -141,43 +211,57 @@ func (cb *ConfigurationBuilder) BuildConfiguration(
 	// 9. Case selection
 	caseList, err := cb.productUseCase.GetByCategory(ctx, "Case")
 	if err == nil {
-		cse := cb.selectCase(toProductPtrs(caseList))
+		cse := cb.selectCase(toProductPtrs(caseList), cfg.PCBuild())
 		if cse != nil {
 			cfg.Case = cse
 		}
 	}
+	return nil
+}
 
-	// 10. Monitor selection
-	if needMonitor {
-		monList, err := cb.productUseCase.GetByCategory(ctx, "Monitor")
-		if err == nil {
-			mon := cb.selectMonitor(toProductPtrs(monList), monitorHz, monitorDisplay)
-			if mon != nil {
-				cfg.Monitor = mon
-			}
-		}
+// optimizeRequest konfigurator tanlovlaridan optimizator so'rovi; gpuBrand bo'sh - farqi yo'q,
+// "kerak emas" - o'rnatilgan grafika
+func (cb *ConfigurationBuilder) optimizeRequest(budgetUSD float64, pcType, cpuBrand, gpuBrand string) usecase.OptimizeRequest {
+	noGPU := strings.TrimSpace(gpuBrand) != "" && isGPUDisabled(gpuBrand)
+	if noGPU {
+		gpuBrand = ""
 	}
-
-	// 11. Peripherals selection
-	if needPeripherals {
-		periList, err := cb.productUseCase.GetByCategory(ctx, "Peripherals")
-		if err == nil && len(periList) == 0 {
-			// Fallback: some catalogs store peripherals as separate categories
-			for _, cat := range []string{"Keyboard", "Mouse", "Headset", "Microphone", "Mousepad"} {
-				if alt, err2 := cb.productUseCase.GetByCategory(ctx, cat); err2 == nil {
-					periList = append(periList, alt...)
-				}
-			}
-		}
-		if err == nil && len(periList) > 0 {
-			cfg.Peripherals = cb.selectPeripherals(toProductPtrs(periList))
-		}
+	return usecase.OptimizeRequest{
+		BudgetUSD: budgetUSD,
+		Purpose:   pcType,
+		CPUBrand:  cpuBrand,
+		GPUBrand:  gpuBrand,
+		NoGPU:     noGPU,
 	}
+}
 
-	// Calculate total price
-	cfg.TotalPrice = cb.calculateTotalPrice(cfg)
+// BuildAlternatives katalogdagi qoldiqdan budjet ichida eng yaxshi limit ta yig'ma
+// (narxlar kurs orqali dollarga o'giriladi)
+func (cb *ConfigurationBuilder) BuildAlternatives(ctx context.Context, req usecase.OptimizeRequest, limit int) ([]usecase.OptimizedBuild, error) {
+	products, err := cb.productUseCase.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Price = func(p entity.Product) float64 { return cb.priceUSD(&p) }
+	return usecase.OptimizeBuilds(products, req, limit), nil
+}
 
-	return cfg, nil
+// applyBuild optimizator yig'masini tanlangan komponentlarga o'tkazadi
+func (cfg *SelectedConfiguration) applyBuild(build *entity.PCBuild) {
+	ptr := func(p entity.Product) *entity.Product {
+		if strings.TrimSpace(p.Name) == "" {
+			return nil
+		}
+		return &p
+	}
+	cfg.CPU = ptr(build.CPU)
+	cfg.RAM = ptr(build.RAM)
+	cfg.GPU = ptr(build.GPU)
+	cfg.SSD = ptr(build.SSD)
+	cfg.Motherboard = ptr(build.Motherboard)
+	cfg.PSU = ptr(build.PSU)
+	cfg.Cooler = build.Cooler
+	cfg.Case = build.Case
 }
 
 // toProductPtrs converts a slice of products to a slice of product pointers.
@@ -215,7 +299,7 @@ func (cb *ConfigurationBuilder) selectCPU(cpus []*entity.Product, brand string,
 		for _, cpu := range filtered {
 			nameL := strings.ToLower(cpu.Name)
 			if strings.Contains(nameL, "k ") || strings.Contains(nameL, "-k") {
-				if selected == nil || selected.Price < cpu.Price {
+				if selected == nil || cb.priceUSD(selected) < cb.priceUSD(cpu) {
 					selected = cpu
 				}
 			}
@@ -223,7 +307,7 @@ func (cb *ConfigurationBuilder) selectCPU(cpus []*entity.Product, brand string,
 		// K-series bo'lmasa, yaxshi non-K ol
 		if selected == nil {
 			for _, cpu := range filtered {
-				if selected == nil || (cpu.Price > selected.Price && cpu.Price < budget*0.3) {
+				if selected == nil || (cb.priceUSD(cpu) > cb.priceUSD(selected) && cb.priceUSD(cpu) < budget*0.3) {
 					selected = cpu
 				}
 			}
@@ -231,7 +315,7 @@ func (cb *ConfigurationBuilder) selectCPU(cpus []*entity.Product, brand string,
 	} else {
 		// Office/Montaj: kam quvvat, kam narx
 		for _, cpu := range filtered {
-			if selected == nil || (cpu.Price < selected.Price && cpu.Price < budget*0.15) {
+			if selected == nil || (cb.priceUSD(cpu) < cb.priceUSD(selected) && cb.priceUSD(cpu) < budget*0.15) {
 				selected = cpu
 			}
 		}
@@ -246,20 +330,22 @@ func (cb *ConfigurationBuilder) selectCPU(cpus []*entity.Product, brand string,
 
 // selectRAM - CPU mos RAM tanlaydi (DDR4 vs DDR5)
 func (cb *ConfigurationBuilder) selectRAM(rams []*entity.Product, budget float64, cpu *entity.Product) *entity.Product {
-	_ = cpu
-	// Determine DDR type based on budget
-	var ddrType string
+	// CPU faqat bitta avlodni qo'llasa (AM4 - DDR4, AM5 - DDR5) - shu; aks holda budjet bo'yicha
+	ddrType := "DDR4"
 	if budget >= 1200 {
 		ddrType = "DDR5"
-	} else {
-		ddrType = "DDR4"
+	}
+	if cpu != nil {
+		if gens := usecase.PartAttrs(usecase.PartCPU, *cpu).Memory; len(gens) == 1 {
+			ddrType = gens[0]
+		}
 	}
 
 	// Find RAM matching DDR type
 	var filtered []*entity.Product
 	for _, ram := range rams {
-		nameL := strings.ToLower(ram.Name)
-		if strings.Contains(nameL, ddrType) {
+		gens := usecase.PartAttrs(usecase.PartRAM, *ram).Memory
+		if len(gens) == 1 && gens[0] == ddrType {
 			filtered = append(filtered, ram)
 		}
 	}
@@ -273,7 +359,7 @@ func (cb *ConfigurationBuilder) selectRAM(rams []*entity.Product, budget float64
 	for _, ram := range filtered {
 		nameL := strings.ToLower(ram.Name)
 		if strings.Contains(nameL, "32gb") {
-			if selected == nil || ram.Price < selected.Price {
+			if selected == nil || cb.priceUSD(ram) < cb.priceUSD(selected) {
 				selected = ram
 			}
 		}
@@ -286,61 +372,13 @@ func (cb *ConfigurationBuilder) selectRAM(rams []*entity.Product, budget float64
 	return selected
 }
 
-// selectMotherboard - CPU + RAM matchi motherboard tanlaydi
-func (cb *ConfigurationBuilder) selectMotherboard(mbs []*entity.Product, cpu, ram *entity.Product) *entity.Product {
-	if cpu == nil {
+// selectMotherboard - CPU + RAM bilan mos plata tanlaydi (soket, chipset, DDR avlodi).
+// Ogohlantirishsiz (masalan, K protsessorga Z chipset) platalar afzal; mosi bo'lmasa nil.
+func (cb *ConfigurationBuilder) selectMotherboard(mbs []*entity.Product, build *entity.PCBuild) *entity.Product {
+	if strings.TrimSpace(build.CPU.Name) == "" {
 		return nil
 	}
-
-	// Determine chipset based on CPU
-	var needChipset string
-	cpuNameL := strings.ToLower(cpu.Name)
-
-	if strings.Contains(cpuNameL, "k ") || strings.Contains(cpuNameL, "-k") {
-		// K-series needs Z-chipset
-		needChipset = "Z"
-	} else {
-		// Non-K needs B-chipset
-		needChipset = "B"
-	}
-
-	// Determine DDR type
-	var needDDR string
-	if ram != nil {
-		ramNameL := strings.ToLower(ram.Name)
-		if strings.Contains(ramNameL, "ddr5") {
-			needDDR = "DDR5"
-		} else {
-			needDDR = "DDR4"
-		}
-	}
-
-	// Filter by chipset and DDR type
-	var filtered []*entity.Product
-	for _, mb := range mbs {
-		nameL := strings.ToLower(mb.Name)
-
-		// Check chipset
-		if !strings.Contains(nameL, needChipset) {
-			continue
-		}
-
-		// Check DDR compatibility
-		if needDDR != "" && !strings.Contains(nameL, needDDR) {
-			continue
-		}
-
-		filtered = append(filtered, mb)
-	}
-
-	var selected *entity.Product
-	if len(filtered) > 0 {
-		selected = filtered[0]
-	} else if len(mbs) > 0 {
-		selected = mbs[0]
-	}
-
-	return selected
+	return cb.cheapestCompatible(mbs, build, func(b *entity.PCBuild, p *entity.Product) { b.Motherboard = *p }, nil)
 }
 
 // selectGPU - budjet asosida GPU tanlaydi
@@ -349,7 +387,7 @@ func (cb *ConfigurationBuilder) selectGPU(gpus []*entity.Product, brand string,
 	// Filter by budget
 	var filtered []*entity.Product
 	for _, gpu := range gpus {
-		if gpu.Price <= budget {
+		if cb.priceUSD(gpu) <= budget {
 			filtered = append(filtered, gpu)
 		}
 	}
@@ -374,7 +412,7 @@ func (cb *ConfigurationBuilder) selectGPU(gpus []*entity.Product, brand string,
 	// Select highest price GPU within budget
 	var selected *entity.Product
 	for _, gpu := range filtered {
-		if selected == nil || (gpu.Price > selected.Price && gpu.Price <= budget) {
+		if selected == nil || (cb.priceUSD(gpu) > cb.priceUSD(selected) && cb.priceUSD(gpu) <= budget) {
 			selected = gpu
 		}
 	}
@@ -390,7 +428,8 @@ func (cb *ConfigurationBuilder) selectSSD(ssds []*entity.Product, storageType st
 
 	for _, pref := range preferOrder {
 		for _, ssd := range ssds {
-			nameL := strings.ToLower(ssd.Name)
+			// Importda aniqlangan interfeys nomdan ishonchliroq
+			nameL := strings.ToLower(ssd.Name + " " + ssd.Spec(entity.SpecInterface))
 			if strings.Contains(nameL, strings.ToLower(pref)) {
 				return ssd
 			}
@@ -403,147 +442,136 @@ func (cb *ConfigurationBuilder) selectSSD(ssds []*entity.Product, storageType st
 	return nil
 }
 
-// selectPSU - CPU + GPU TDP asosida PSU tanlaydi
-func (cb *ConfigurationBuilder) selectPSU(psus []*entity.Product, cpu, gpu *entity.Product) *entity.Product {
-	_ = cpu
-	_ = gpu
-	// Find PSU >= estimatedTDP
-	var selected *entity.Product
-	for _, psu := range psus {
-		if strings.Contains(strings.ToLower(psu.Name), "650w") || strings.Contains(strings.ToLower(psu.Name), "750w") {
-			if selected == nil || psu.Price < selected.Price {
-				selected = psu
-			}
-		}
-	}
-
-	if selected == nil && len(psus) > 0 {
-		selected = psus[0]
-	}
-
-	return selected
+// selectPSU - quvvat modeli bo'yicha PSU tanlaydi: tavsiya etilgan quvvatdagi eng arzoni,
+// bo'lmasa minimaldan kuchlisi (kuchsiz blok psu_wattage xatosi bilan chetlanadi)
+func (cb *ConfigurationBuilder) selectPSU(psus []*entity.Product, build *entity.PCBuild) *entity.Product {
+	budget := usecase.ComputePowerBudget(build, nil)
+	return cb.cheapestCompatible(psus, build, func(b *entity.PCBuild, p *entity.Product) { b.PSU = *p }, func(p *entity.Product) bool {
+		return budget.Check(usecase.PartAttrs(usecase.PartPSU, *p).Watts) == usecase.PSUAdequate
+	})
 }
 
-// selectCooler - CPU TDP asosida cooler tanlaydi
-func (cb *ConfigurationBuilder) selectCooler(coolers []*entity.Product, cpu *entity.Product) *entity.Product {
-	_ = cpu
-	// For most CPUs, AIO is good choice
-	var selected *entity.Product
+// selectCooler - CPU soketi va TDP ga mos cooler tanlaydi (AIO afzal)
+func (cb *ConfigurationBuilder) selectCooler(coolers []*entity.Product, build *entity.PCBuild) *entity.Product {
+	return cb.cheapestCompatible(coolers, build, func(b *entity.PCBuild, p *entity.Product) { b.Cooler = p }, func(p *entity.Product) bool {
+		return strings.Contains(strings.ToLower(p.Name), "aio")
+	})
+}
 
-	for _, cooler := range coolers {
-		nameL := strings.ToLower(cooler.Name)
-		if strings.Contains(nameL, "aio") {
-			if selected == nil || cooler.Price < selected.Price {
-				selected = cooler
-			}
+// selectCase - plata, GPU va cooler sig'adigan korpus tanlaydi (o'rta narxli, 200$ gacha)
+func (cb *ConfigurationBuilder) selectCase(cases []*entity.Product, build *entity.PCBuild) *entity.Product {
+	var fitting []*entity.Product
+	for _, cse := range cases {
+		candidate := *build
+		candidate.Case = cse
+		if cb.compat.Compatible(&candidate) {
+			fitting = append(fitting, cse)
 		}
 	}
-
-	if selected == nil && len(coolers) > 0 {
-		selected = coolers[0]
+	var selected *entity.Product
+	for _, cse := range fitting {
+		if selected == nil || (cb.priceUSD(cse) > cb.priceUSD(selected) && cb.priceUSD(cse) < 200) {
+			selected = cse
+		}
 	}
-
 	return selected
 }
 
-// selectCase - PC case tanlaydi
-func (cb *ConfigurationBuilder) selectCase(cases []*entity.Product) *entity.Product {
-	if len(cases) > 0 {
-		// Select mid-range case
-		var selected *entity.Product
-		for _, cse := range cases {
-			if selected == nil || (cse.Price > selected.Price && cse.Price < 200) {
-				selected = cse
-			}
+// cheapestCompatible - yig'maga qo'yilganda moslik xatosi bermaydigan eng arzon nomzod.
+// Tartib: prefer ga mos va ogohlantirishsiz > ogohlantirishsiz > prefer ga mos > qolgan mos.
+func (cb *ConfigurationBuilder) cheapestCompatible(candidates []*entity.Product, build *entity.PCBuild, place func(*entity.PCBuild, *entity.Product), prefer func(*entity.Product) bool) *entity.Product {
+	var best *entity.Product
+	bestRank := -1
+	for _, cand := range candidates {
+		trial := *build
+		place(&trial, cand)
+		violations := cb.compat.Check(&trial)
+		if usecase.HasCompatErrors(violations) {
+			continue
 		}
-		if selected != nil {
-			return selected
+		rank := 0
+		if len(violations) == 0 {
+			rank += 2
 		}
-		return cases[0]
-	}
-	return nil
-}
-
-// selectMonitor - monitor Hz + display asosida tanlaydi
-func (cb *ConfigurationBuilder) selectMonitor(monitors []*entity.Product, hz, display string) *entity.Product {
-	var filtered []*entity.Product
-
-	if hz != "" {
-		for _, mon := range monitors {
-			if strings.Contains(strings.ToLower(mon.Name), strings.ToLower(hz)) {
-				filtered = append(filtered, mon)
-			}
+		if prefer == nil || prefer(cand) {
+			rank++
+		}
+		if rank > bestRank || (rank == bestRank && cb.priceUSD(cand) < cb.priceUSD(best)) {
+			best, bestRank = cand, rank
 		}
 	}
+	return best
+}
 
-	if len(filtered) == 0 {
-		filtered = monitors
+// PeripheralBundle katalog qoldig'idan monitor/periferiya to'plami (narxlar kurs orqali dollarda)
+func (cb *ConfigurationBuilder) PeripheralBundle(ctx context.Context, req usecase.PeripheralRequest) (*usecase.PeripheralBundle, error) {
+	products, err := cb.productUseCase.GetAll(ctx)
+	if err != nil {
+		return nil, err
 	}
-
-	return filtered[0]
+	req.Price = func(p entity.Product) float64 { return cb.priceUSD(&p) }
+	return usecase.BuildPeripheralBundle(products, req), nil
 }
 
-// selectPeripherals - gaming peripherals tanlaydi
-func (cb *ConfigurationBuilder) selectPeripherals(peris []*entity.Product) []*entity.Product {
-	// Select gaming peripherals (keyboard, mouse, headset)
-	var result []*entity.Product
-
-	keyboard := cb.findPeripheral(peris, "keyboard", "klaviatura")
-	mouse := cb.findPeripheral(peris, "mouse", "sichqoncha")
-	headset := cb.findPeripheral(peris, "headset", "headphone", "quloqchin")
-
-	if keyboard != nil {
-		result = append(result, keyboard)
-	}
-	if mouse != nil {
-		result = append(result, mouse)
-	}
-	if headset != nil {
-		result = append(result, headset)
+// RecommendSystems qoldiqdagi noutbuk/tayyor kompyuterlar reytingi (narxlar dollarga o'girilib)
+func (cb *ConfigurationBuilder) RecommendSystems(ctx context.Context, req usecase.SystemRequest) ([]usecase.SystemPick, error) {
+	products, err := cb.productUseCase.GetAll(ctx)
+	if err != nil {
+		return nil, err
 	}
-
-	return result
+	req.Price = func(p entity.Product) float64 { return cb.priceUSD(&p) }
+	return usecase.RankSystems(products, req), nil
 }
 
-func (cb *ConfigurationBuilder) findPeripheral(peris []*entity.Product, keywords ...string) *entity.Product {
-	for _, peri := range peris {
-		nameL := strings.ToLower(peri.Name)
-		for _, kw := range keywords {
-			if strings.Contains(nameL, strings.ToLower(kw)) {
-				return peri
-			}
+// applyPeripherals to'plamdagi monitorni va qolgan periferiyani tanlanganlarga o'tkazadi
+func (cfg *SelectedConfiguration) applyPeripherals(bundle *usecase.PeripheralBundle) {
+	for _, pick := range bundle.Picks {
+		p := pick.Product
+		if pick.Kind == usecase.PartMonitor {
+			cfg.Monitor = &p
+			continue
 		}
+		cfg.Peripherals = append(cfg.Peripherals, &p)
 	}
-	return nil
 }
 
-// calculateTotalPrice - jami narx hisoblaydi
-func (cb *ConfigurationBuilder) calculateTotalPrice(cfg *SelectedConfiguration) float64 {
-	total := 0.0
-	total += cb.getComponentPrice(cfg.CPU)
-	total += cb.getComponentPrice(cfg.RAM)
-	total += cb.getComponentPrice(cfg.GPU)
-	total += cb.getComponentPrice(cfg.SSD)
-	total += cb.getComponentPrice(cfg.Motherboard)
-	total += cb.getComponentPrice(cfg.PSU)
-	total += cb.getComponentPrice(cfg.Cooler)
-	total += cb.getComponentPrice(cfg.Case)
-	total += cb.getComponentPrice(cfg.Monitor)
-
+// calculateTotalPrice - jami narx hisoblaydi (USD da; boshqa valyutadagilar kurs orqali o'giriladi)
+func (cb *ConfigurationBuilder) calculateTotalPrice(cfg *SelectedConfiguration) entity.Money {
+	var prices []entity.Money
+	for _, comp := range []*entity.Product{cfg.CPU, cfg.RAM, cfg.GPU, cfg.SSD, cfg.Motherboard, cfg.PSU, cfg.Cooler, cfg.Case, cfg.Monitor} {
+		prices = append(prices, cb.getComponentPrice(comp))
+	}
 	for _, peri := range cfg.Peripherals {
-		total += cb.getComponentPrice(peri)
+		prices = append(prices, cb.getComponentPrice(peri))
 	}
 
+	total, err := entity.Sum(cb.converter, entity.CurrencyUSD, prices...)
+	if err != nil {
+		log.Printf("config total price: %v", err)
+	}
 	return total
 }
 
-func (cb *ConfigurationBuilder) getComponentPrice(comp *entity.Product) float64 {
+func (cb *ConfigurationBuilder) getComponentPrice(comp *entity.Product) entity.Money {
 	if comp == nil {
-		return 0
+		return entity.Money{}
 	}
 	return comp.Price
 }
 
+// priceUSD komponent narxi dollarda (budjet bilan solishtirish uchun); o'girib bo'lmasa 0
+func (cb *ConfigurationBuilder) priceUSD(comp *entity.Product) float64 {
+	if comp == nil {
+		return 0
+	}
+	usd, err := entity.Sum(cb.converter, entity.CurrencyUSD, comp.Price)
+	if err != nil {
+		log.Printf("config price %q: %v", comp.Name, err)
+		return 0
+	}
+	return usd.Major()
+}
+
 // Helper: extract budget number
 func extractBudgetNumber(budgetStr string) float64 {
 	// Remove currency symbols and spaces
@@ -121,11 +121,11 @@ func (h *BotHandler) handleConfigColorSelection(ctx context.Context, userID, cha
 	lang := h.getUserLang(userID)
 	switch value {
 	case "black":
-		h.setConfigColorAndAskCPU(userID, chatID, t(lang, "Qora", "Чёрный"))
+		h.setConfigColorAndAskCPU(userID, chatID, tr(lang, "config.color.black"))
 	case "white":
-		h.setConfigColorAndAskCPU(userID, chatID, t(lang, "Oq", "Белый"))
+		h.setConfigColorAndAskCPU(userID, chatID, tr(lang, "config.color.white"))
 	default:
-		h.setConfigColorAndAskCPU(userID, chatID, t(lang, "Qora", "Чёрный")) // Default black
+		h.setConfigColorAndAskCPU(userID, chatID, tr(lang, "config.color.black")) // Default black
 	}
 }
 
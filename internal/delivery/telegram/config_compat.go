@@ -0,0 +1,91 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// AI tuzgan konfiguratsiya mijozga ko'rsatilishidan oldin moslik qoidalari bilan tekshiriladi:
+// xato bo'lsa AI dan bir marta tuzatish so'raladi, qolgan muammolar alohida xabar bo'lib ketadi.
+
+// catalogBuild konfiguratsiya matnidan PCBuild; komponentlar katalogdagi nomi va Specs bilan
+func (h *BotHandler) catalogBuild(ctx context.Context, userID int64, configText, purposeHint string) *entity.PCBuild {
+	build := h.extractPCBuildFromText(userID, configText, purposeHint)
+	if build == nil || h.productUseCase == nil {
+		return build
+	}
+	products, err := h.productUseCase.GetAll(ctx)
+	if err != nil || len(products) == 0 {
+		return build
+	}
+	for _, part := range []*entity.Product{&build.CPU, &build.RAM, &build.GPU, &build.SSD, &build.Motherboard, &build.PSU, build.Cooler, build.Case} {
+		if part == nil || strings.TrimSpace(part.Name) == "" {
+			continue
+		}
+		if match, ok := usecase.MatchCatalogProduct(products, part.Name); ok {
+			part.Name = match.Name
+			part.Category = match.Category
+			part.Specs = match.Specs
+		}
+	}
+	return build
+}
+
+func countCompatErrors(vs []usecase.Violation) int {
+	n := 0
+	for _, v := range vs {
+		if v.Severity == usecase.CompatError {
+			n++
+		}
+	}
+	return n
+}
+
+// ensureConfigCompatibility javobni tekshiradi; yig'ib bo'lmaydigan juftliklar bo'lsa AI dan
+// tuzatilgan variant so'raydi va xatosi kamroq variantni qaytaradi
+func (h *BotHandler) ensureConfigCompatibility(ctx context.Context, userID int64, username, prompt, response string) (string, []usecase.Violation) {
+	checker := usecase.NewCompatibilityChecker()
+	violations := checker.Check(h.catalogBuild(ctx, userID, response, ""))
+	if !usecase.HasCompatErrors(violations) {
+		return response, violations
+	}
+
+	var note strings.Builder
+	note.WriteString("⚠️ MOSLIK XATOLARI - quyidagi komponentlarni bir-biriga mos modellar bilan almashtir:\n")
+	for _, v := range violations {
+		if v.Severity == usecase.CompatError {
+			note.WriteString("• " + v.Message("uz") + "\n")
+		}
+	}
+	updated, err := h.chatUseCase.ProcessConfigMessage(ctx, userID, username, prompt+"\n\n"+note.String())
+	if err != nil || strings.TrimSpace(updated) == "" {
+		return response, violations
+	}
+	again := checker.Check(h.catalogBuild(ctx, userID, updated, ""))
+	if countCompatErrors(again) < countCompatErrors(violations) {
+		return updated, again
+	}
+	return response, violations
+}
+
+// compatNotes mijozga ko'rsatiladigan moslik izohlari; muammo bo'lmasa bo'sh satr
+func compatNotes(lang string, vs []usecase.Violation) string {
+	if len(vs) == 0 {
+		return ""
+	}
+	lines := []string{tr(lang, "compat.header")}
+	for _, v := range vs {
+		icon := "⚠️"
+		if v.Severity == usecase.CompatError {
+			icon = "❌"
+		}
+		lines = append(lines, icon+" "+v.Message(lang))
+	}
+	if usecase.HasCompatErrors(vs) {
+		lines = append(lines, "", tr(lang, "compat.manager_check"))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
 )
 
 // handleConfigCommand konfiguratsiya sessiyasini boshlash
@@ -24,6 +25,7 @@ func (h *BotHandler) handleConfigCommand(ctx context.Context, message *tgbotapi.
 // startConfigSession yangi konfiguratsiya sessiyasini yaratish
 func (h *BotHandler) startConfigSession(userID int64) {
 	h.setConfigOrderLocked(userID, false)
+	h.enterConversation(userID, convFlowConfig, configStageNeedName.String(), 0)
 	h.configMu.Lock()
 	h.configSessions[userID] = &configSession{
 		Stage:      configStageNeedName,
@@ -69,7 +71,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 	}
 	h.configMu.Unlock()
 	if !ok {
-		h.sendMessage(chatID, t(lang, "Konfiguratsiya sessiyasi topilmadi. Boshlash uchun /configuratsiya ni bosing.", "Сессия конфигурации не найдена. Нажмите /configuratsiya чтобы начать."))
+		h.sendMessage(chatID, tr(lang, "config.session_missing"))
 		return
 	}
 
@@ -80,7 +82,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		switch session.Stage {
 		case configStageNeedName:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Konfiguratsiyaga nom bering (bo'sh bo'lmasin).", "Дайте имя конфигурации (не пустое)."))
+				h.sendMessage(chatID, tr(lang, "config.ask_name_required"))
 				return
 			}
 			h.configMu.Lock()
@@ -95,7 +97,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedType:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, PC turi haqida yozing.", "Пожалуйста, укажите тип ПК."))
+				h.sendMessage(chatID, tr(lang, "config.require.type"))
 				return
 			}
 			h.setConfigTypeAndAskBudget(userID, chatID, input)
@@ -103,7 +105,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedBudget:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, budjetni kiriting.", "Пожалуйста, укажите бюджет."))
+				h.sendMessage(chatID, tr(lang, "config.require.budget"))
 				return
 			}
 			h.setConfigBudgetAndAskColor(userID, chatID, input)
@@ -111,7 +113,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedColor:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, rangini kiriting.", "Пожалуйста, укажите цвет."))
+				h.sendMessage(chatID, tr(lang, "config.require.color"))
 				return
 			}
 			h.setConfigColorAndAskCPU(userID, chatID, input)
@@ -119,7 +121,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedCPU:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, protsessor nomini kiriting.", "Пожалуйста, укажите название процессора."))
+				h.sendMessage(chatID, tr(lang, "config.require.cpu"))
 				return
 			}
 			h.setConfigCPUAndAskCPUCooler(userID, chatID, input)
@@ -127,7 +129,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedCPUCooler:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, CPU sovutgichi nomini kiriting.", "Пожалуйста, укажите название процессорного охладителя."))
+				h.sendMessage(chatID, tr(lang, "config.require.cooler"))
 				return
 			}
 			h.setConfigCPUCoolerAndAskStorage(userID, chatID, input)
@@ -135,7 +137,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedStorage:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, xotira turini kiriting.", "Пожалуйста, укажите тип накопителя."))
+				h.sendMessage(chatID, tr(lang, "config.require.storage"))
 				return
 			}
 			h.setConfigStorageAndAskGPU(userID, chatID, input)
@@ -143,7 +145,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedGPU:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, grafik karta nomini kiriting.", "Пожалуйста, укажите модель видеокарты."))
+				h.sendMessage(chatID, tr(lang, "config.require.gpu"))
 				return
 			}
 			h.deleteUserMessage(chatID, msg)
@@ -151,7 +153,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedMonitorHz:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, monitor Hz qiymatini kiriting.", "Пожалуйста, укажите Hz монитора."))
+				h.sendMessage(chatID, tr(lang, "config.require.hz"))
 				return
 			}
 			h.setConfigMonitorHzAndAskDisplay(userID, chatID, input)
@@ -159,7 +161,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 			return
 		case configStageNeedMonitorDisplay:
 			if input == "" {
-				h.sendMessage(chatID, t(lang, "Iltimos, display turini kiriting.", "Пожалуйста, укажите тип дисплея."))
+				h.sendMessage(chatID, tr(lang, "config.require.display"))
 				return
 			}
 			h.setConfigMonitorDisplayAndAskPeripherals(userID, chatID, input)
@@ -174,7 +176,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 	case configStageNeedName:
 		session.Name = strings.TrimSpace(input)
 		if session.Name == "" {
-			h.sendMessage(chatID, t(lang, "Konfiguratsiyaga nom bering (bo'sh bo'lmasin).", "Дайте имя конфигурации (не пустое)."))
+			h.sendMessage(chatID, tr(lang, "config.ask_name_required"))
 			return
 		}
 		session.Stage = configStageNeedType
@@ -190,7 +192,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		h.configMu.Lock()
 		h.configSessions[userID] = session
 		h.configMu.Unlock()
-		h.sendMessage(chatID, t(lang, "💰 Budjetni kiriting (masalan: 800$, 10 000 000 so'm). Aniq bo'lmasa, taxminiy yozing.", "💰 Укажите бюджет (например: 800$, 10 000 000 сум). Можно приблизительно."))
+		h.sendMessage(chatID, tr(lang, "config.ask.budget_hint"))
 		return
 	case configStageNeedBudget:
 		session.Budget = input
@@ -199,7 +201,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		h.configSessions[userID] = session
 		h.configMu.Unlock()
 		h.clearConfigCTA(chatID)
-		h.sendMessage(chatID, t(lang, "🎨 Rangni kiriting:", "🎨 Укажите цвет:"))
+		h.sendMessage(chatID, tr(lang, "config.ask.color"))
 		return
 	case configStageNeedColor:
 		session.Color = input
@@ -207,7 +209,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		h.configMu.Lock()
 		h.configSessions[userID] = session
 		h.configMu.Unlock()
-		h.sendMessage(chatID, t(lang, "🧠 Qaysi protsessor turini xohlaysiz? Intel yoki AMD?", "🧠 Какой процессор предпочитаете? Intel или AMD?"))
+		h.sendMessage(chatID, tr(lang, "config.ask.cpu_brand"))
 		return
 	case configStageNeedCPU:
 		session.CPUBrand = input
@@ -215,7 +217,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		h.configMu.Lock()
 		h.configSessions[userID] = session
 		h.configMu.Unlock()
-		h.sendMessage(chatID, t(lang, "❄️ CPU sovutgichini kiriting:", "❄️ Укажите процессорный охладитель:"))
+		h.sendMessage(chatID, tr(lang, "config.ask.cooler"))
 		return
 	case configStageNeedCPUCooler:
 		session.CPUCooler = input
@@ -223,7 +225,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		h.configMu.Lock()
 		h.configSessions[userID] = session
 		h.configMu.Unlock()
-		h.sendMessage(chatID, t(lang, "💾 Xotira turi? HDD, SSD yoki NVMe?", "💾 Какой тип накопителя? HDD, SSD или NVMe?"))
+		h.sendMessage(chatID, tr(lang, "config.ask.storage"))
 		return
 	case configStageNeedStorage:
 		session.Storage = input
@@ -231,7 +233,7 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		h.configMu.Lock()
 		h.configSessions[userID] = session
 		h.configMu.Unlock()
-		h.sendMessage(chatID, t(lang, "🎮 Grafik karta: NVIDIA RTXmi yoki AMD Radeon?", "🎮 Видеокарта: NVIDIA RTX или AMD Radeon?"))
+		h.sendMessage(chatID, tr(lang, "config.ask.gpu_brand"))
 		return
 	case configStageNeedGPU:
 		session.GPUBrand = normalizeGPUSelection(input)
@@ -245,7 +247,8 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 		h.configMu.Lock()
 		delete(h.configSessions, userID)
 		h.configMu.Unlock()
-		h.sendMessage(chatID, t(lang, "Sessiya qayta ishga tushirildi. Yangi boshlash uchun /configuratsiya ni bosing.", "Сессия перезапущена. Для начала нажмите /configuratsiya."))
+		h.leaveConversation(userID, convFlowConfig)
+		h.sendMessage(chatID, tr(lang, "config.session_restarted"))
 		return
 	}
 }
@@ -254,14 +257,14 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 func (h *BotHandler) finishConfigSession(ctx context.Context, userID int64, username string, chatID int64, session configSession) {
 	// AI hisoblash vaqtida parallel so'rovlarni to'xtatish
 	if !h.startProcessing(userID) {
-		h.sendMessage(chatID, t(h.getUserLang(userID), "⏳ Oldingi so'rov yakunlanmoqda, iltimos kuting.", "⏳ Предыдущий запрос обрабатывается, подождите."))
+		h.sendMessage(chatID, tr(h.getUserLang(userID), "common.busy"))
 		return
 	}
 	defer h.clearWaitingMessage(userID)
 	defer h.endProcessing(userID)
 
 	lang := h.getUserLang(userID)
-	ko := t(lang, "ko'rsatilmagan", "не указано")
+	ko := tr(lang, "config.not_specified")
 
 	var summary string
 	monitorInfo := ""
@@ -269,7 +272,7 @@ func (h *BotHandler) finishConfigSession(ctx context.Context, userID int64, user
 	gpuDisabled := isGPUDisabled(session.GPUBrand)
 	gpuSummary := nonEmpty(session.GPUBrand, ko)
 	if gpuDisabled {
-		gpuSummary = t(lang, "Kerak emas", "Не нужно")
+		gpuSummary = tr(lang, "config.not_needed")
 	}
 
 	if session.NeedMonitor {
@@ -349,7 +352,7 @@ Endi shu talablar bo'yicha optimal konfiguratsiyani tanlab beraman...`,
 	}
 
 	h.sendMessage(chatID, summary)
-	waitMsg, err := h.sendMessageWithResp(chatID, t(lang, "⏳ Iltimos, javobni kuting.", "⏳ Пожалуйста, подождите."))
+	waitMsg, err := h.sendMessageWithResp(chatID, tr(lang, "common.wait_answer"))
 	if err == nil {
 		h.setWaitingMessage(userID, chatID, waitMsg.MessageID)
 	}
@@ -373,42 +376,37 @@ Endi shu talablar bo'yicha optimal konfiguratsiyani tanlab beraman...`,
 		peripheralsPrompt = "Peripherals: KERAK EMAS. Ro'yxatga umuman qo'shmang!"
 	}
 
+	// Monitor va periferiya qoldiqdan xohish/budjet/GPU bo'yicha oldindan tanlanadi - AI aynan shularni yozadi
+	bundle := h.configPeripheralBundle(ctx, userID, &session)
+	if lines := peripheralPromptLines(bundle, usecase.PartMonitor); session.NeedMonitor && lines != "" {
+		monitorPrompt = "Monitor: MAJBURIY KIRITILSIN! AYNAN shu model va narxni yoz (almashtirma):\n" + lines
+	}
+	if lines := peripheralPromptLines(bundle, usecase.PartKeyboard, usecase.PartMouse, usecase.PartHeadset); session.NeedPeripherals && lines != "" {
+		peripheralsPrompt = "Peripherals: KERAK. AYNAN quyidagi modellarni shu narxlar bilan ro'yxatga qo'sh (almashtirma):\n" + lines
+	}
+
 	budgetValue := parseBudgetUSD(session.Budget)
 	minBudget := budgetValue - 100
 	if minBudget < 0 {
 		minBudget = 0
 	}
 	maxBudget := budgetValue + 100
-	budgetPrompt := t(lang,
-		"Jami narx budjetga maksimal yaqin bo'lsin. Budjetdan katta farq qoldirma (kerak bo'lsa +100$ gacha ruxsat).\n",
-		"Итоговая цена должна быть максимально близка к бюджету. Большой остаток не оставляй (допускается +100$).\n",
-	)
+	budgetPrompt := tr(lang, "config.prompt.budget_close")
 	if budgetValue > 0 {
-		budgetPrompt = fmt.Sprintf(t(lang,
-			"Jami narx %.0f$-%.0f$ oralig'ida bo'lsin. Budjetga maksimal yaqinlashtir (kerak bo'lsa +100$ gacha ruxsat).\n",
-			"Итоговая цена должна быть в пределах %.0f$-%.0f$. Максимально приблизь к бюджету (допускается +100$).\n",
-		), minBudget, maxBudget)
+		budgetPrompt = tr(lang, "config.prompt.budget_range",
+			"min", fmt.Sprintf("%.0f", minBudget), "max", fmt.Sprintf("%.0f", maxBudget))
 	}
 	if gpuDisabled {
-		budgetPrompt += t(lang,
-			"GPU kerak emas bo'lsa, CPU/RAM/SSD/MB/PSU ni yuqori darajaga ko'tar va budjetni shu yerga sarfla.\n",
-			"Если GPU не нужен, усили CPU/RAM/SSD/MB/PSU и расходуй бюджет на эти компоненты.\n",
-		)
+		budgetPrompt += tr(lang, "config.prompt.no_gpu_budget")
 	}
 
 	gpuPreference := nonEmpty(session.GPUBrand, "aniqlanmagan")
 	gpuLine := "• GPU: [Model] - [Price]$\n"
-	gpuPrompt := t(lang,
-		"GPU: CSV dan mos modelni tanla. Agar brand ko'rsatilgan bo'lsa, shunga mos GPU tanla.\n",
-		"GPU: Выбери модель из CSV. Если указан бренд, подбери соответствующую видеокарту.\n",
-	)
+	gpuPrompt := tr(lang, "config.prompt.gpu_pick")
 	if gpuDisabled {
-		gpuPreference = t(lang, "kerak emas", "не нужно")
-		gpuLine = t(lang, "• GPU: Integrated Graphics (CPU) - 0$\n", "• GPU: Встроенная графика (CPU) - 0$\n")
-		gpuPrompt = t(lang,
-			"GPU: KERAK EMAS. Diskret GPU qo'shma. GPU qatorida faqat integrated graphics (CPU) va 0$ yoz.\n",
-			"GPU: НЕ НУЖНО. Дискретную видеокарту не добавляй. В строке GPU укажи только встроенную графику (CPU) и 0$.\n",
-		)
+		gpuPreference = tr(lang, "config.prompt.not_needed")
+		gpuLine = tr(lang, "config.prompt.integrated_line")
+		gpuPrompt = tr(lang, "config.prompt.no_gpu")
 	}
 
 	prompt := fmt.Sprintf(
@@ -455,23 +453,29 @@ Endi shu talablar bo'yicha optimal konfiguratsiyani tanlab beraman...`,
 		monitorPrompt,
 		peripheralsPrompt,
 	)
-	if lang == "ru" {
-		prompt = "Отвечай только на русском языке.\n" + prompt
-	}
+	prompt = aiLanguageInstruction(lang) + prompt
 
 	// MAXSUS: Konfiguratsiya uchun alohida AI funksiyasini chaqirish
 	response, err := h.chatUseCase.ProcessConfigMessage(ctx, userID, username, prompt)
 	if err != nil {
 		log.Printf("Konfiguratsiya javobi xatosi: %v", err)
-		h.sendMessage(chatID, t(lang, "❌ Konfiguratsiya uchun javob tayyorlashda xatolik yuz berdi. Qayta urinib ko'ring yoki /configuratsiya ni qaytadan bosing.", "❌ Ошибка при подготовке конфигурации. Попробуйте снова или нажмите /configuratsiya."))
+		h.sendMessage(chatID, tr(lang, "config.generate_failed"))
 		return
 	}
 
 	response = h.ensureConfigBudgetCoverage(ctx, userID, username, prompt, response, budgetValue, lang)
+	response, violations := h.ensureConfigCompatibility(ctx, userID, username, prompt, response)
 	response = h.applyCurrencyPreference(response)
 	response = h.ensureMonitorLineWithCSV(ctx, response, &session)
 	response = sanitizeConfigResponse(response)
 	h.sendMessage(chatID, response)
+	if notes := compatNotes(lang, violations); notes != "" {
+		h.sendMessage(chatID, notes)
+	}
+	if bundle != nil && bundle.HzCapped && session.NeedMonitor {
+		h.sendMessage(chatID, tr(lang, "peripherals.hz_capped", "hz", bundle.MonitorCapHz))
+	}
+	h.sendInstallmentCalculator(chatID, lang, extractTotalPrice(response))
 
 	// Feedback uchun kontekstni saqlash va tugmalarni yuborish
 	offerID := h.saveFeedback(userID, feedbackInfo{
@@ -490,6 +494,10 @@ Endi shu talablar bo'yicha optimal konfiguratsiyani tanlab beraman...`,
 			GPU:     gpuSummary,
 		},
 	})
+	// Ustada nom berilgan - yig'ma darhol "Saqlanganlar"ga tushadi
+	if info, ok := h.getFeedbackByID(offerID); ok {
+		h.saveBuildFromOffer(ctx, userID, chatID, info, username)
+	}
 	h.sendConfigFeedbackPrompt(chatID, userID, offerID)
 	// 5 daqiqadan so'ng mijoz buyurtma bermasa eslatma yuborish
 	h.scheduleConfigReminder(userID, chatID, response)
@@ -684,7 +692,7 @@ func (h *BotHandler) setConfigMessageID(userID, chatID int64, msgID int) {
 
 func (h *BotHandler) askConfigName(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "📝 Konfiguratsiyaga nom bering.", "📝 Дайте имя конфигурации.")
+	text := tr(lang, "config.ask.name")
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
 		edit := tgbotapi.NewEditMessageText(chat, mid, text)
 		if _, err := h.bot.Send(edit); err == nil {
@@ -701,7 +709,7 @@ func (h *BotHandler) askConfigName(userID, chatID int64) {
 
 func (h *BotHandler) askConfigType(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🛠️ PC yig'ishni boshlaymiz! Qaysi turdagi PC kerak?", "🛠️ Начнём сборку ПК! Какой тип нужен?")
+	text := tr(lang, "config.ask.type")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("Office", "cfg_type_office"),
@@ -714,7 +722,7 @@ func (h *BotHandler) askConfigType(userID, chatID int64) {
 			tgbotapi.NewInlineKeyboardButtonData("Server", "cfg_type_server"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Boshqa", "Другое"), "cfg_type_other"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.delete.other"), "cfg_type_other"),
 		),
 	)
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
@@ -737,7 +745,7 @@ func (h *BotHandler) askConfigType(userID, chatID int64) {
 
 func (h *BotHandler) askConfigBudget(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "💰 Budjetni kiriting (masalan: 800$, 10 000 000 so'm).", "💰 Укажите бюджет (например: 800$, 10 000 000 сум).")
+	text := tr(lang, "config.ask.budget")
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chat, mid, text, tgbotapi.InlineKeyboardMarkup{})
 		if _, err := h.bot.Send(edit); err == nil {
@@ -753,12 +761,12 @@ func (h *BotHandler) askConfigBudget(userID, chatID int64) {
 
 func (h *BotHandler) askConfigCPU(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🧠 Qaysi protsessor turini tanlaysiz?", "🧠 Какой тип процессора выберете?")
+	text := tr(lang, "config.ask.cpu_type")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("Intel", "cfg_cpu_intel"),
 			tgbotapi.NewInlineKeyboardButtonData("AMD", "cfg_cpu_amd"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Boshqa", "Другое"), "cfg_cpu_other"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.delete.other"), "cfg_cpu_other"),
 		),
 	)
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
@@ -777,7 +785,7 @@ func (h *BotHandler) askConfigCPU(userID, chatID int64) {
 
 func (h *BotHandler) promptConfigCPUText(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🧠 Protsessor modelini yozing (masalan: Ryzen 5 5600 yoki i5-13400F).", "🧠 Напишите модель процессора (например: Ryzen 5 5600 или i5-13400F).")
+	text := tr(lang, "config.ask.cpu_model")
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chat, mid, text, tgbotapi.InlineKeyboardMarkup{})
 		h.bot.Send(edit)
@@ -792,7 +800,7 @@ func (h *BotHandler) promptConfigCPUText(userID, chatID int64) {
 
 func (h *BotHandler) promptConfigTypeText(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🛠️ PC turini yozing (Office/Gaming/Developer/Design/Montaj/Server yoki boshqasi).", "🛠️ Напишите тип ПК (Office/Gaming/Developer/Design/Montaj/Server или другой).")
+	text := tr(lang, "config.ask.type_text")
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chat, mid, text, tgbotapi.InlineKeyboardMarkup{})
 		h.bot.Send(edit)
@@ -807,7 +815,7 @@ func (h *BotHandler) promptConfigTypeText(userID, chatID int64) {
 
 func (h *BotHandler) askConfigStorage(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "💾 Xotira turini tanlang:", "💾 Выберите тип накопителя:")
+	text := tr(lang, "config.ask.storage_pick")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("SSD", "cfg_storage_ssd"),
@@ -831,12 +839,12 @@ func (h *BotHandler) askConfigStorage(userID, chatID int64) {
 
 func (h *BotHandler) askConfigGPU(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🎮 Grafik karta turini tanlang:", "🎮 Выберите тип видеокарты:")
+	text := tr(lang, "config.ask.gpu_pick")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("RTX", "cfg_gpu_rtx"),
 			tgbotapi.NewInlineKeyboardButtonData("AMD", "cfg_gpu_amd"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Kerak emas", "Не нужно"), "cfg_gpu_none"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.not_needed"), "cfg_gpu_none"),
 		),
 	)
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
@@ -855,11 +863,11 @@ func (h *BotHandler) askConfigGPU(userID, chatID int64) {
 
 func (h *BotHandler) askConfigMonitor(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🖥️ Monitor kerakmi?", "🖥️ Нужен монитор?")
+	text := tr(lang, "config.ask.monitor")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "✅ Ha", "✅ Да"), "cfg_monitor_yes"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "❌ Yo'q", "❌ Нет"), "cfg_monitor_no"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.yes"), "cfg_monitor_yes"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.no"), "cfg_monitor_no"),
 		),
 	)
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
@@ -878,7 +886,7 @@ func (h *BotHandler) askConfigMonitor(userID, chatID int64) {
 
 func (h *BotHandler) askConfigMonitorHz(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🔄 Qancha Hz kerak?", "🔄 Какая частота нужна?")
+	text := tr(lang, "config.ask.hz")
 
 	// PC Type ga qarab Hz variantlarini tanlash
 	h.configMu.Lock()
@@ -961,7 +969,7 @@ func (h *BotHandler) askConfigMonitorHz(userID, chatID int64) {
 
 func (h *BotHandler) askConfigMonitorDisplay(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "📺 Qanday display turi?", "📺 Какой тип дисплея?")
+	text := tr(lang, "config.ask.display")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("IPS", "cfg_monitor_display_ips"),
@@ -991,11 +999,11 @@ func (h *BotHandler) askConfigMonitorDisplay(userID, chatID int64) {
 
 func (h *BotHandler) askConfigPeripherals(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🖱️ Peripherals kerakmi? (sichqoncha, klaviatura, quloqchin)", "🖱️ Нужна периферия? (мышь, клавиатура, наушники)")
+	text := tr(lang, "config.ask.peripherals")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "✅ Ha", "✅ Да"), "cfg_peripherals_yes"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "❌ Yo'q", "❌ Нет"), "cfg_peripherals_no"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.yes"), "cfg_peripherals_yes"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "purchase.no"), "cfg_peripherals_no"),
 		),
 	)
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
@@ -1246,10 +1254,10 @@ func (h *BotHandler) ensureConfigBudgetCoverage(ctx context.Context, userID int6
 		return response
 	}
 
-	retryNote := fmt.Sprintf(t(lang,
-		"⚠️ Budjetga mos emas: Jami %.2f$ (talab: %.2f$-%.2f$). Budjetga maksimal yaqinlashtir.\n",
-		"⚠️ Не соответствует бюджету: Итого %.2f$ (норма: %.2f$-%.2f$). Максимально приблизь к бюджету.\n",
-	), totalVal, minTarget, maxTarget)
+	retryNote := tr(lang, "config.prompt.budget_retry",
+		"total", fmt.Sprintf("%.2f", totalVal),
+		"min", fmt.Sprintf("%.2f", minTarget),
+		"max", fmt.Sprintf("%.2f", maxTarget))
 
 	retryPrompt := prompt + "\n\n" + retryNote
 	updated, err := h.chatUseCase.ProcessConfigMessage(ctx, userID, username, retryPrompt)
@@ -1329,6 +1337,7 @@ func (h *BotHandler) cancelConfigSession(userID int64) {
 		delete(h.configSessions, userID)
 	}
 	h.configMu.Unlock()
+	h.leaveConversation(userID, convFlowConfig)
 }
 
 func (h *BotHandler) hasConfigSession(userID int64) bool {
@@ -1340,11 +1349,11 @@ func (h *BotHandler) hasConfigSession(userID int64) bool {
 
 func (h *BotHandler) askConfigColor(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🎨 Rangini tanlang:", "🎨 Выберите цвет:")
+	text := tr(lang, "config.ask.color_pick")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "⚫ Qora", "⚫ Чёрный"), "cfg_color_black"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "⚪ Oq", "⚪ Белый"), "cfg_color_white"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.color.black_button"), "cfg_color_black"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.color.white_button"), "cfg_color_white"),
 		),
 	)
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
@@ -1363,14 +1372,14 @@ func (h *BotHandler) askConfigColor(userID, chatID int64) {
 
 func (h *BotHandler) askConfigCPUCooler(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "❄️ CPU sovutgichini tanlang:", "❄️ Выберите процессорный охладитель:")
+	text := tr(lang, "config.ask.cooler_pick")
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("💨 Air", "cfg_cooler_air"),
 			tgbotapi.NewInlineKeyboardButtonData("💧 Liquid", "cfg_cooler_liquid"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🌀 Boshqa", "🌀 Другое"), "cfg_cooler_other"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.cooler.other"), "cfg_cooler_other"),
 		),
 	)
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
@@ -1389,7 +1398,7 @@ func (h *BotHandler) askConfigCPUCooler(userID, chatID int64) {
 
 func (h *BotHandler) promptConfigColorText(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "🎨 Rangini yozing:", "🎨 Напишите цвет:")
+	text := tr(lang, "config.ask.color_text")
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chat, mid, text, tgbotapi.InlineKeyboardMarkup{})
 		h.bot.Send(edit)
@@ -1404,7 +1413,7 @@ func (h *BotHandler) promptConfigColorText(userID, chatID int64) {
 
 func (h *BotHandler) promptConfigCPUCoolerText(userID, chatID int64) {
 	lang := h.getUserLang(userID)
-	text := t(lang, "❄️ CPU sovutgichini yozing:", "❄️ Напишите охладитель:")
+	text := tr(lang, "config.ask.cooler_text")
 	if chat, mid, ok := h.getConfigMessageInfo(userID); ok {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chat, mid, text, tgbotapi.InlineKeyboardMarkup{})
 		h.bot.Send(edit)
@@ -0,0 +1,163 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// Budjet optimizatori: konfiguratsiya ostidagi "🧮" tugmasi shu budjet va maqsad uchun qoldiqdagi
+// qismlardan eng yuqori ballli 3 ta mos yig'mani ko'rsatadi; tanlangan variant oddiy konfiguratsiya
+// kabi saqlanadi (buyurtma, tahlil, almashtirish tugmalari bilan).
+
+const optimizerVariants = 3
+
+// configBudgetUSD konfiguratsiya budjeti dollarda: so'mda yozilgan bo'lsa kurs bo'yicha,
+// aniqlanmasa konfiguratsiya jami narxi
+func (h *BotHandler) configBudgetUSD(info feedbackInfo) float64 {
+	budget := parseBudgetUSD(info.Spec.Budget)
+	if budget > 50000 {
+		if _, rate := h.getCurrencySettings(); rate > 0 {
+			budget /= rate
+		}
+	}
+	if budget <= 0 {
+		budget = parseBudgetUSD(extractTotalPrice(info.ConfigText))
+	}
+	return budget
+}
+
+// optimizedConfigText variantni standart konfiguratsiya matni ko'rinishida yozadi
+// ("• CPU: nom - narx$", "Overall price") - tahlil va buyurtma oqimlari shu formatni o'qiydi
+func (h *BotHandler) optimizedConfigText(lang string, spec configSpec, alt usecase.OptimizedBuild) string {
+	price := func(p entity.Product) float64 {
+		return h.configBuilder.priceUSD(&p)
+	}
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "optimizer.title", "purpose", nonEmpty(spec.PCType, alt.Build.Purpose), "score", fmt.Sprintf("%.1f", alt.Score)))
+	sb.WriteString("\n\n")
+	line := func(label string, p *entity.Product) {
+		if p == nil || strings.TrimSpace(p.Name) == "" {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("• %s: %s - %.0f$\n", label, p.Name, price(*p)))
+	}
+	b := alt.Build
+	line("CPU", &b.CPU)
+	line("Motherboard", &b.Motherboard)
+	line("RAM", &b.RAM)
+	if strings.TrimSpace(b.GPU.Name) != "" {
+		line("GPU", &b.GPU)
+	} else {
+		sb.WriteString(fmt.Sprintf("• GPU: %s - 0$\n", tr(lang, "optimizer.igpu")))
+	}
+	line("SSD", &b.SSD)
+	line("PSU", &b.PSU)
+	line("CPU Cooler", b.Cooler)
+	line("Case", b.Case)
+	sb.WriteString(fmt.Sprintf("\nOverall price: %.0f$", alt.PriceUSD))
+	return sb.String()
+}
+
+// optimizerOverview 3 variantning qisqa ro'yxati: ball, narx va 1-variantga nisbatan farq
+func optimizerOverview(lang string, budget float64, purpose string, alts []usecase.OptimizedBuild) string {
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "optimizer.header", "budget", fmt.Sprintf("%.0f", budget), "purpose", purpose))
+	for i, alt := range alts {
+		sb.WriteString("\n\n")
+		sb.WriteString(tr(lang, "optimizer.variant", "n", i+1, "score", fmt.Sprintf("%.1f", alt.Score), "price", fmt.Sprintf("%.0f", alt.PriceUSD)))
+		if i == 0 {
+			sb.WriteString(" — " + tr(lang, "optimizer.best"))
+		} else {
+			sb.WriteString("\n" + tr(lang, "optimizer.delta", "score", fmt.Sprintf("%+.1f", alt.ScoreDelta), "price", fmt.Sprintf("%+.0f", alt.PriceDelta)))
+		}
+		b := alt.Build
+		gpu := b.GPU.Name
+		if strings.TrimSpace(gpu) == "" {
+			gpu = tr(lang, "optimizer.igpu")
+		}
+		sb.WriteString(fmt.Sprintf("\n• CPU: %s\n• GPU: %s\n• RAM: %s\n• SSD: %s", b.CPU.Name, gpu, b.RAM.Name, b.SSD.Name))
+		if len(alt.Violations) > 0 {
+			sb.WriteString("\n⚠️ " + alt.Violations[0].Message(lang))
+		}
+	}
+	return sb.String()
+}
+
+// handleConfigOptimize "🧮" tugmasi: konfiguratsiya budjeti va maqsadi bo'yicha 3 ta variant
+func (h *BotHandler) handleConfigOptimize(ctx context.Context, userID, chatID int64, offerID string) {
+	lang := h.getUserLang(userID)
+	info, ok := h.getFeedbackByID(offerID)
+	if !ok {
+		info, ok = h.getLatestFeedback(userID)
+	}
+	if !ok || h.configBuilder == nil {
+		h.sendMessage(chatID, tr(lang, "optimizer.expired"))
+		return
+	}
+	budget := h.configBudgetUSD(info)
+	if budget <= 0 {
+		h.sendMessage(chatID, tr(lang, "optimizer.no_budget"))
+		return
+	}
+
+	req := h.configBuilder.optimizeRequest(budget, info.Spec.PCType, info.Spec.CPU, info.Spec.GPU)
+	req.Branch = h.branchStockFor(userID)
+	req.Bench = h.benchmarks()
+	alts, err := h.configBuilder.BuildAlternatives(ctx, req, optimizerVariants)
+	if err != nil {
+		log.Printf("optimizer catalog error: %v", err)
+	}
+	if len(alts) == 0 {
+		h.sendMessage(chatID, tr(lang, "optimizer.none", "budget", fmt.Sprintf("%.0f", budget)))
+		return
+	}
+
+	var buttons []tgbotapi.InlineKeyboardButton
+	for i, alt := range alts {
+		variant := feedbackInfo{
+			OfferID:    newUUID(),
+			Summary:    fmt.Sprintf("Optimizator %d-variant. Maqsad: %s, Budjet: %s", i+1, info.Spec.PCType, info.Spec.Budget),
+			ConfigText: h.optimizedConfigText(lang, info.Spec, alt),
+			Username:   info.Username,
+			Phone:      info.Phone,
+			ChatID:     chatID,
+			Spec:       info.Spec,
+		}
+		// Tanlanmaguncha "oxirgi taklif" o'zgarmaydi - faqat ID bo'yicha saqlanadi
+		h.feedbackMu.Lock()
+		h.feedbackByID[variant.OfferID] = variant
+		h.feedbackMu.Unlock()
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(tr(lang, "optimizer.pick", "n", i+1), "cfg_opt_pick|"+variant.OfferID))
+	}
+	msg := tgbotapi.NewMessage(chatID, h.applyCurrencyPreference(optimizerOverview(lang, budget, nonEmpty(info.Spec.PCType, req.Purpose), alts)))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons)
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("optimizer variants send error: %v", err)
+	}
+}
+
+// handleConfigOptimizePick tanlangan variant yangi konfiguratsiya sifatida yuboriladi
+func (h *BotHandler) handleConfigOptimizePick(ctx context.Context, userID, chatID int64, variantID string) {
+	lang := h.getUserLang(userID)
+	info, ok := h.getFeedbackByID(variantID)
+	if !ok || strings.TrimSpace(info.ConfigText) == "" {
+		h.sendMessage(chatID, tr(lang, "optimizer.expired"))
+		return
+	}
+	offerID := h.saveFeedback(userID, info)
+	response := h.applyCurrencyPreference(info.ConfigText)
+	h.sendMessage(chatID, response)
+	violations := usecase.NewCompatibilityChecker().Check(h.catalogBuild(ctx, userID, info.ConfigText, info.Spec.PCType))
+	if notes := compatNotes(lang, violations); notes != "" {
+		h.sendMessage(chatID, notes)
+	}
+	h.sendInstallmentCalculator(chatID, lang, extractTotalPrice(response))
+	h.sendConfigFeedbackPrompt(chatID, userID, offerID)
+	h.scheduleConfigReminder(userID, chatID, response)
+}
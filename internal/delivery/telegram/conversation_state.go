@@ -0,0 +1,649 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
+)
+
+// Suhbat holatlari (conversation state machine).
+//
+// Har bir user bir vaqtning o'zida faqat BITTA jarayonda (flow) bo'ladi:
+// admin login, profil, konfiguratsiya, buyurtma va h.k. Jarayon nomi va
+// ichki bosqichi (state) shu yerda saqlanadi; matnli xabarlar holatga qarab
+// tegishli handlerga yo'naltiriladi. Yangi jarayon boshlansa eskisi bekor
+// qilinadi, muddati o'tgan jarayonlar avtomatik tozalanadi.
+
+type convFlow string
+
+const (
+	convFlowAdminLogin       convFlow = "admin_login"
+	convFlowProfile          convFlow = "profile"
+	convFlowConfig           convFlow = "config"
+	convFlowOrder            convFlow = "order"
+	convFlowComponentChange  convFlow = "component_change"
+	convFlowCurrencyRate     convFlow = "currency_rate"
+	convFlowReminderInput    convFlow = "reminder_input"
+	convFlowAddProduct       convFlow = "add_product"
+	convFlowSearch           convFlow = "search"
+	convFlowImportAuto       convFlow = "import_auto"
+	convFlowSheetMasterSetup convFlow = "sheetmaster_setup"
+	convFlowSticker          convFlow = "sticker"
+	convFlowUserHistory      convFlow = "user_history"
+	convFlowOrderEdit        convFlow = "order_edit"
+	convFlowUpgrade          convFlow = "upgrade"
+	convFlowPSU              convFlow = "psu"
+	convFlowPeripherals      convFlow = "peripherals"
+	convFlowLaptop           convFlow = "laptop"
+	convFlowBuildRename      convFlow = "build_rename"
+	convFlowWarrantyClaim    convFlow = "warranty_claim"
+	convFlowCourier          convFlow = "courier"
+)
+
+// conversationState - userning joriy jarayoni va bosqichi
+type conversationState struct {
+	Flow      convFlow
+	State     string
+	ChatID    int64
+	EnteredAt time.Time
+	UpdatedAt time.Time
+}
+
+// conversationInput - jarayon handleriga uzatiladigan xabar
+type conversationInput struct {
+	UserID   int64
+	Username string
+	Text     string
+	ChatID   int64
+	Msg      *tgbotapi.Message
+}
+
+// conversationFlow - jarayonning deklarativ tavsifi.
+//
+// Handle - matnli xabarni qayta ishlaydi (false qaytarsa xabar keyingi
+// bosqichga, ya'ni oddiy chatga o'tadi). nil bo'lsa jarayon matnni
+// o'zi qabul qilmaydi (masalan sticker yoki inline tanlov).
+// Cancel - jarayon ma'lumotlarini tozalaydi (/cancel, timeout yoki boshqa
+// jarayon boshlanganda). Active - jarayon ma'lumotlari hali mavjudligini
+// tekshiradi; nil bo'lsa holatning o'zi yetarli. StateOf - joriy bosqich nomi.
+// CancelOnCommand - bitta javob kutayotgan jarayon: user boshqa komanda
+// yuborsa jarayon bekor bo'ladi va keyingi matnni "yutib" yubormaydi.
+// AcceptsPhoto - rasm (izohi bilan) ham Handle ga yuboriladi.
+type conversationFlow struct {
+	Name            convFlow
+	Timeout         time.Duration
+	CancelOnCommand bool
+	AcceptsPhoto    bool
+	Handle          func(h *BotHandler, ctx context.Context, in conversationInput) bool
+	Cancel          func(h *BotHandler, userID int64)
+	Active          func(h *BotHandler, userID int64) bool
+	StateOf         func(h *BotHandler, userID int64) string
+}
+
+// conversationFlows init() da to'ldiriladi: handlerlar jadvalga qayta murojaat
+// qilgani uchun package-level initializer initialization cycle beradi.
+var conversationFlows map[convFlow]conversationFlow
+
+func init() {
+	conversationFlows = map[convFlow]conversationFlow{
+		convFlowAdminLogin: {
+			Name:    convFlowAdminLogin,
+			Timeout: 5 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				if in.Msg == nil {
+					return false
+				}
+				h.handlePasswordInput(ctx, in.Msg)
+				return true
+			},
+		},
+		convFlowProfile: {
+			Name:    convFlowProfile,
+			Timeout: 24 * time.Hour,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleProfileInput(in.UserID, in.Text, in.ChatID, in.Msg)
+			},
+			Cancel: func(h *BotHandler, userID int64) {
+				h.clearProfilePrompt(userID)
+			},
+		},
+		convFlowConfig: {
+			Name:    convFlowConfig,
+			Timeout: 2 * time.Hour,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				h.handleConfigFlow(ctx, in.UserID, in.Username, in.Text, in.ChatID, in.Msg)
+				return true
+			},
+			Cancel: func(h *BotHandler, userID int64) {
+				h.cancelConfigSession(userID)
+				h.cancelConfigReminder(userID)
+			},
+			Active: func(h *BotHandler, userID int64) bool { return h.hasConfigSession(userID) },
+			StateOf: func(h *BotHandler, userID int64) string {
+				h.configMu.RLock()
+				defer h.configMu.RUnlock()
+				if sess, ok := h.configSessions[userID]; ok && sess != nil {
+					return sess.Stage.String()
+				}
+				return ""
+			},
+		},
+		convFlowOrder: {
+			Name:    convFlowOrder,
+			Timeout: 2 * time.Hour,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				h.handleOrderFlow(ctx, in.UserID, in.Username, in.Text, in.ChatID, in.Msg)
+				return true
+			},
+			Cancel: func(h *BotHandler, userID int64) {
+				h.releaseReservedInventory(userID)
+				h.clearOrderFormMessages(userID, 0, 0)
+				h.clearOrderSession(userID)
+			},
+			Active: func(h *BotHandler, userID int64) bool { return h.hasOrderSession(userID) },
+			StateOf: func(h *BotHandler, userID int64) string {
+				h.orderMu.RLock()
+				defer h.orderMu.RUnlock()
+				if sess, ok := h.orderSessions[userID]; ok && sess != nil {
+					return sess.Stage.String()
+				}
+				return ""
+			},
+		},
+		convFlowComponentChange: {
+			Name:    convFlowComponentChange,
+			Timeout: 30 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				h.handleChangeRequest(ctx, in.UserID, in.Username, in.Text, in.ChatID)
+				return true
+			},
+			Cancel: func(h *BotHandler, userID int64) { h.popPendingChange(userID) },
+			Active: func(h *BotHandler, userID int64) bool { return h.hasPendingChange(userID) },
+		},
+		convFlowCurrencyRate: {
+			Name:    convFlowCurrencyRate,
+			Timeout: 10 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleAdminCurrencyInput(ctx, in.Msg)
+			},
+		},
+		convFlowReminderInput: {
+			Name:    convFlowReminderInput,
+			Timeout: 30 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleAdminReminderInput(ctx, in.Msg)
+			},
+			Cancel: func(h *BotHandler, userID int64) { h.clearReminderInputState(userID) },
+			Active: func(h *BotHandler, userID int64) bool {
+				_, ok := h.getReminderInputState(userID)
+				return ok
+			},
+			StateOf: func(h *BotHandler, userID int64) string {
+				if st, ok := h.getReminderInputState(userID); ok && st != nil {
+					if st.stage == reminderStageNeedMessages {
+						return "need_messages"
+					}
+					return "need_count"
+				}
+				return ""
+			},
+		},
+		convFlowAddProduct: {
+			Name:    convFlowAddProduct,
+			Timeout: 30 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleAddProductQuantityInput(ctx, in.Msg)
+			},
+			Cancel: func(h *BotHandler, userID int64) { h.clearAddProductState(userID) },
+			Active: func(h *BotHandler, userID int64) bool {
+				_, ok := h.getAddProductState(userID)
+				return ok
+			},
+			StateOf: func(h *BotHandler, userID int64) string {
+				if st, ok := h.getAddProductState(userID); ok {
+					if st.Stage == addProductStageNeedQty {
+						return "need_qty"
+					}
+					return "need_select"
+				}
+				return ""
+			},
+		},
+		convFlowSearch: {
+			Name:    convFlowSearch,
+			Timeout: 10 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleSearchInput(ctx, in.Msg)
+			},
+		},
+		convFlowImportAuto: {
+			Name:    convFlowImportAuto,
+			Timeout: 10 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleImportAutoInput(ctx, in.Msg)
+			},
+			Cancel: func(h *BotHandler, userID int64) { h.clearImportAutoInput(userID) },
+			Active: func(h *BotHandler, userID int64) bool { return h.isAwaitingImportAutoInput(userID) },
+		},
+		convFlowSheetMasterSetup: {
+			Name:    convFlowSheetMasterSetup,
+			Timeout: 30 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleSheetMasterSetupInput(ctx, in.Msg)
+			},
+			Cancel: func(h *BotHandler, userID int64) { h.clearSheetMasterSetupState(userID) },
+			Active: func(h *BotHandler, userID int64) bool {
+				_, ok := h.getSheetMasterSetupState(userID)
+				return ok
+			},
+			StateOf: func(h *BotHandler, userID int64) string {
+				if st, ok := h.getSheetMasterSetupState(userID); ok && st != nil {
+					switch st.stage {
+					case sheetMasterStageNeedAPIKey:
+						return "need_api_key"
+					case sheetMasterStageNeedFileID:
+						return "need_file_id"
+					}
+					return "need_base_url"
+				}
+				return ""
+			},
+		},
+		convFlowSticker: {
+			Name:    convFlowSticker,
+			Timeout: 10 * time.Minute,
+		},
+		convFlowUserHistory: {
+			Name:    convFlowUserHistory,
+			Timeout: 10 * time.Minute,
+		},
+		convFlowOrderEdit: {
+			Name:    convFlowOrderEdit,
+			Timeout: 15 * time.Minute,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleOrderEditInput(in)
+			},
+		},
+		convFlowUpgrade: {
+			Name:            convFlowUpgrade,
+			Timeout:         15 * time.Minute,
+			CancelOnCommand: true,
+			AcceptsPhoto:    true,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleUpgradeInput(ctx, in)
+			},
+		},
+		convFlowPSU: {
+			Name:            convFlowPSU,
+			Timeout:         15 * time.Minute,
+			CancelOnCommand: true,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handlePSUInput(ctx, in)
+			},
+		},
+		convFlowPeripherals: {
+			Name:            convFlowPeripherals,
+			Timeout:         15 * time.Minute,
+			CancelOnCommand: true,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handlePeripheralsInput(ctx, in)
+			},
+		},
+		convFlowLaptop: {
+			Name:            convFlowLaptop,
+			Timeout:         15 * time.Minute,
+			CancelOnCommand: true,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleLaptopInput(ctx, in)
+			},
+		},
+		convFlowBuildRename: {
+			Name:            convFlowBuildRename,
+			Timeout:         10 * time.Minute,
+			CancelOnCommand: true,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleSavedBuildInput(in)
+			},
+			Cancel: func(h *BotHandler, userID int64) {
+				h.clearBuildRename(userID)
+			},
+			Active: func(h *BotHandler, userID int64) bool {
+				_, ok := h.pendingBuildRename(userID)
+				return ok
+			},
+		},
+		convFlowWarrantyClaim: {
+			Name:            convFlowWarrantyClaim,
+			Timeout:         30 * time.Minute,
+			CancelOnCommand: true,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleWarrantyInput(ctx, in)
+			},
+			Cancel: func(h *BotHandler, userID int64) {
+				h.clearWarrantyClaim(userID)
+			},
+			Active: func(h *BotHandler, userID int64) bool {
+				_, ok := h.pendingWarrantyClaim(userID)
+				return ok
+			},
+		},
+		convFlowCourier: {
+			Name:            convFlowCourier,
+			Timeout:         30 * time.Minute,
+			CancelOnCommand: true,
+			AcceptsPhoto:    true,
+			Handle: func(h *BotHandler, ctx context.Context, in conversationInput) bool {
+				return h.handleCourierInput(ctx, in)
+			},
+			Cancel: func(h *BotHandler, userID int64) {
+				h.clearCourierAwait(userID)
+			},
+			Active: func(h *BotHandler, userID int64) bool {
+				return h.hasCourierAwait(userID)
+			},
+		},
+	}
+}
+
+// conversationFlowTitle jarayonning lokalizatsiya qilingan nomi (conv.flow.<nom>)
+func conversationFlowTitle(lang string, flow convFlow) string {
+	return tr(lang, "conv.flow."+string(flow))
+}
+
+func lookupConversationFlow(flow convFlow) (conversationFlow, bool) {
+	def, ok := conversationFlows[flow]
+	return def, ok
+}
+
+func (s *conversationState) expired(now time.Time) bool {
+	if s == nil {
+		return false
+	}
+	def, ok := lookupConversationFlow(s.Flow)
+	if !ok || def.Timeout <= 0 {
+		return false
+	}
+	return now.Sub(s.UpdatedAt) > def.Timeout
+}
+
+// enterConversation userni yangi jarayonga o'tkazadi. Agar user boshqa
+// jarayonda bo'lsa, o'sha jarayon bekor qilinadi.
+func (h *BotHandler) enterConversation(userID int64, flow convFlow, state string, chatID int64) {
+	now := time.Now()
+	h.convMu.Lock()
+	if h.convStates == nil {
+		h.convStates = make(map[int64]*conversationState)
+	}
+	prev := h.convStates[userID]
+	if prev != nil && prev.Flow == flow {
+		prev.State = state
+		if chatID != 0 {
+			prev.ChatID = chatID
+		}
+		prev.UpdatedAt = now
+		h.convMu.Unlock()
+		return
+	}
+	h.convStates[userID] = &conversationState{
+		Flow:      flow,
+		State:     state,
+		ChatID:    chatID,
+		EnteredAt: now,
+		UpdatedAt: now,
+	}
+	h.convMu.Unlock()
+
+	if prev != nil {
+		log.Printf("[conv] user=%d flow %s -> %s", userID, prev.Flow, flow)
+		if def, ok := lookupConversationFlow(prev.Flow); ok && def.Cancel != nil {
+			def.Cancel(h, userID)
+		}
+	}
+}
+
+// leaveConversation jarayonni yakunlaydi (faqat joriy jarayon mos kelsa).
+func (h *BotHandler) leaveConversation(userID int64, flow convFlow) {
+	h.convMu.Lock()
+	if cur, ok := h.convStates[userID]; ok && cur.Flow == flow {
+		delete(h.convStates, userID)
+	}
+	h.convMu.Unlock()
+}
+
+// currentConversation joriy holat nusxasini qaytaradi (muddati o'tgan bo'lsa ham).
+func (h *BotHandler) currentConversation(userID int64) (conversationState, bool) {
+	h.convMu.RLock()
+	defer h.convMu.RUnlock()
+	cur, ok := h.convStates[userID]
+	if !ok || cur == nil {
+		return conversationState{}, false
+	}
+	return *cur, true
+}
+
+// conversationStateIn user berilgan jarayonda bo'lsa uning bosqichini qaytaradi.
+func (h *BotHandler) conversationStateIn(userID int64, flow convFlow) (string, bool) {
+	cur, ok := h.currentConversation(userID)
+	if !ok || cur.Flow != flow || cur.expired(time.Now()) {
+		return "", false
+	}
+	return cur.State, true
+}
+
+func (h *BotHandler) touchConversation(userID int64) {
+	h.convMu.Lock()
+	if cur, ok := h.convStates[userID]; ok && cur != nil {
+		cur.UpdatedAt = time.Now()
+	}
+	h.convMu.Unlock()
+}
+
+// cancelConversation joriy jarayonni bekor qiladi va uning holatini qaytaradi.
+func (h *BotHandler) cancelConversation(userID int64) (conversationState, bool) {
+	h.convMu.Lock()
+	cur, ok := h.convStates[userID]
+	if ok {
+		delete(h.convStates, userID)
+	}
+	h.convMu.Unlock()
+	if !ok || cur == nil {
+		return conversationState{}, false
+	}
+	if def, found := lookupConversationFlow(cur.Flow); found && def.Cancel != nil {
+		def.Cancel(h, userID)
+	}
+	return *cur, true
+}
+
+// conversationAcceptsPhoto joriy jarayon rasm kutayotgan bo'lsa true
+func (h *BotHandler) conversationAcceptsPhoto(userID int64) bool {
+	cur, ok := h.currentConversation(userID)
+	if !ok {
+		return false
+	}
+	def, found := lookupConversationFlow(cur.Flow)
+	return found && def.AcceptsPhoto
+}
+
+// cancelConversationOnCommand komanda kelganda bitta javob kutayotgan
+// jarayonni (CancelOnCommand) bekor qiladi. true - jarayon bekor qilindi.
+func (h *BotHandler) cancelConversationOnCommand(userID int64) bool {
+	cur, ok := h.currentConversation(userID)
+	if !ok {
+		return false
+	}
+	if def, found := lookupConversationFlow(cur.Flow); !found || !def.CancelOnCommand {
+		return false
+	}
+	_, canceled := h.cancelConversation(userID)
+	return canceled
+}
+
+// dispatchConversation matnli xabarni joriy jarayon handleriga yo'naltiradi.
+// true qaytarsa xabar qayta ishlangan.
+func (h *BotHandler) dispatchConversation(ctx context.Context, in conversationInput) bool {
+	cur, ok := h.currentConversation(in.UserID)
+	if !ok {
+		return false
+	}
+	def, found := lookupConversationFlow(cur.Flow)
+	if !found {
+		h.leaveConversation(in.UserID, cur.Flow)
+		return false
+	}
+	if def.Active != nil && !def.Active(h, in.UserID) {
+		// Jarayon ma'lumotlari boshqa joyda yopilgan - holatni ham tozalaymiz
+		h.leaveConversation(in.UserID, cur.Flow)
+		return false
+	}
+	if cur.expired(time.Now()) {
+		h.cancelConversation(in.UserID)
+		lang := h.getUserLang(in.UserID)
+		h.sendMessage(in.ChatID, tr(lang, "conv.expired", "flow", conversationFlowTitle(lang, cur.Flow)))
+		return true
+	}
+	if def.Handle == nil {
+		return false
+	}
+	h.touchConversation(in.UserID)
+	return def.Handle(h, ctx, in)
+}
+
+// sweepExpiredConversations muddati o'tgan jarayonlarni bekor qiladi.
+func (h *BotHandler) sweepExpiredConversations(now time.Time) int {
+	var expired []int64
+	h.convMu.RLock()
+	for userID, cur := range h.convStates {
+		if cur.expired(now) {
+			expired = append(expired, userID)
+		}
+	}
+	h.convMu.RUnlock()
+
+	for _, userID := range expired {
+		if cur, ok := h.cancelConversation(userID); ok {
+			log.Printf("♻️ Jarayon tozalandi: userID=%d flow=%s (timeout)", userID, cur.Flow)
+		}
+	}
+	return len(expired)
+}
+
+// handleCancelCommand - /cancel: istalgan jarayonni bekor qilish
+func (h *BotHandler) handleCancelCommand(ctx context.Context, message *tgbotapi.Message) {
+	_ = ctx
+	userID := message.From.ID
+	lang := h.getUserLang(userID)
+
+	cur, ok := h.cancelConversation(userID)
+	if !ok {
+		h.sendMessage(message.Chat.ID, tr(lang, "conv.cancel_none"))
+		return
+	}
+	h.deleteCommandMessage(message)
+	h.sendMessage(message.Chat.ID, tr(lang, "conv.canceled", "flow", conversationFlowTitle(lang, cur.Flow)))
+}
+
+// handleStateCommand - /state [userID]: userning joriy jarayonini ko'rsatish (admin)
+func (h *BotHandler) handleStateCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	arg := strings.TrimSpace(message.CommandArguments())
+	if arg == "" {
+		h.sendMessage(message.Chat.ID, h.buildConversationStatesText(time.Now()))
+		return
+	}
+	target, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		h.sendMessage(message.Chat.ID, "❌ Noto'g'ri format. Masalan: /state 123456789")
+		return
+	}
+	h.sendMessage(message.Chat.ID, h.describeConversation(target, time.Now()))
+}
+
+func (h *BotHandler) describeConversation(userID int64, now time.Time) string {
+	cur, ok := h.currentConversation(userID)
+	if !ok {
+		return fmt.Sprintf("👤 %d: faol jarayon yo'q.", userID)
+	}
+	def, _ := lookupConversationFlow(cur.Flow)
+	state := cur.State
+	if def.StateOf != nil {
+		if live := def.StateOf(h, userID); live != "" {
+			state = live
+		}
+	}
+	if state == "" {
+		state = "-"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("👤 User: %d\n", userID))
+	sb.WriteString(fmt.Sprintf("Jarayon: %s (%s)\n", cur.Flow, conversationFlowTitle(i18n.DefaultLang, cur.Flow)))
+	sb.WriteString(fmt.Sprintf("Bosqich: %s\n", state))
+	if cur.ChatID != 0 {
+		sb.WriteString(fmt.Sprintf("Chat: %d\n", cur.ChatID))
+	}
+	sb.WriteString(fmt.Sprintf("Boshlangan: %s\n", cur.EnteredAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("Oxirgi faollik: %s\n", cur.UpdatedAt.Format("2006-01-02 15:04:05")))
+	if def.Timeout > 0 {
+		left := def.Timeout - now.Sub(cur.UpdatedAt)
+		if left <= 0 {
+			sb.WriteString("Muddati: tugagan\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("Muddati: %s qoldi\n", left.Round(time.Second)))
+		}
+	}
+	if def.Active != nil && !def.Active(h, userID) {
+		sb.WriteString("⚠️ Jarayon ma'lumotlari topilmadi (eskirgan holat)\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func (h *BotHandler) buildConversationStatesText(now time.Time) string {
+	h.convMu.RLock()
+	states := make([]conversationState, 0, len(h.convStates))
+	ids := make(map[convFlow][]int64)
+	for userID, cur := range h.convStates {
+		if cur == nil {
+			continue
+		}
+		states = append(states, *cur)
+		ids[cur.Flow] = append(ids[cur.Flow], userID)
+	}
+	h.convMu.RUnlock()
+
+	if len(states) == 0 {
+		return "🧭 Faol jarayonlar yo'q.\n\nUser bo'yicha: /state <userID>"
+	}
+	flows := make([]string, 0, len(ids))
+	for flow := range ids {
+		flows = append(flows, string(flow))
+	}
+	sort.Strings(flows)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🧭 Faol jarayonlar: %d\n\n", len(states)))
+	for _, flow := range flows {
+		users := ids[convFlow(flow)]
+		sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+		parts := make([]string, 0, len(users))
+		for _, id := range users {
+			parts = append(parts, strconv.FormatInt(id, 10))
+		}
+		sb.WriteString(fmt.Sprintf("• %s: %s\n", flow, strings.Join(parts, ", ")))
+	}
+	sb.WriteString("\nUser bo'yicha: /state <userID>")
+	return sb.String()
+}
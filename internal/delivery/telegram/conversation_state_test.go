@@ -0,0 +1,98 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConversationEnterLeave(t *testing.T) {
+	handler := &BotHandler{}
+
+	handler.setAwaitingSearch(1, true)
+	if !handler.isAwaitingSearch(1) {
+		t.Fatalf("search holati o'rnatilmadi")
+	}
+	// Boshqa jarayonning leave chaqiruvi joriy holatga ta'sir qilmasligi kerak
+	handler.setAwaitingCurrencyRate(1, false)
+	if !handler.isAwaitingSearch(1) {
+		t.Fatalf("boshqa jarayon search holatini o'chirib yubordi")
+	}
+	handler.setAwaitingSearch(1, false)
+	if _, ok := handler.currentConversation(1); ok {
+		t.Fatalf("holat tozalanmadi")
+	}
+}
+
+func TestConversationReplaceCancelsPrevious(t *testing.T) {
+	handler := &BotHandler{
+		configSessions: make(map[int64]*configSession),
+	}
+
+	handler.startConfigSession(7)
+	if !handler.hasConfigSession(7) {
+		t.Fatalf("config sessiya yaratilmadi")
+	}
+	if stage, ok := handler.conversationStateIn(7, convFlowConfig); !ok || stage != "need_name" {
+		t.Fatalf("kutilgan config/need_name, olindi %q ok=%v", stage, ok)
+	}
+
+	// Yangi jarayon eski konfiguratsiyani bekor qiladi
+	handler.setAwaitingPassword(7, true)
+	if handler.hasConfigSession(7) {
+		t.Fatalf("config sessiya bekor qilinmadi")
+	}
+	if !handler.isAwaitingPassword(7) {
+		t.Fatalf("admin login holati o'rnatilmadi")
+	}
+
+	if cur, ok := handler.cancelConversation(7); !ok || cur.Flow != convFlowAdminLogin {
+		t.Fatalf("cancel noto'g'ri holat qaytardi: %+v ok=%v", cur, ok)
+	}
+	if handler.isAwaitingPassword(7) {
+		t.Fatalf("cancel dan keyin holat qoldi")
+	}
+}
+
+func TestConversationExpiry(t *testing.T) {
+	handler := &BotHandler{}
+
+	handler.setStickerAwait(3, stickerSlot("login"))
+	if slot, ok := handler.getStickerAwait(3); !ok || slot != "login" {
+		t.Fatalf("sticker holati noto'g'ri: %q ok=%v", slot, ok)
+	}
+
+	handler.convMu.Lock()
+	handler.convStates[3].UpdatedAt = time.Now().Add(-time.Hour)
+	handler.convMu.Unlock()
+
+	if _, ok := handler.getStickerAwait(3); ok {
+		t.Fatalf("muddati o'tgan holat faol deb qaytdi")
+	}
+	if n := handler.sweepExpiredConversations(time.Now()); n != 1 {
+		t.Fatalf("kutilgan 1 ta tozalash, olindi %d", n)
+	}
+	if _, ok := handler.currentConversation(3); ok {
+		t.Fatalf("sweep holatni o'chirmadi")
+	}
+}
+
+func TestConversationCancelOnCommand(t *testing.T) {
+	handler := &BotHandler{}
+
+	handler.awaitUpgrade(5, 50)
+	if stage, ok := handler.conversationStateIn(5, convFlowUpgrade); !ok || stage != "need_description" {
+		t.Fatalf("upgrade holati noto'g'ri: %q ok=%v", stage, ok)
+	}
+	if !handler.cancelConversationOnCommand(5) {
+		t.Fatalf("komanda upgrade kutishini bekor qilmadi")
+	}
+	if _, ok := handler.currentConversation(5); ok {
+		t.Fatalf("komandadan keyin holat qoldi")
+	}
+
+	// Ko'p bosqichli jarayonlar komandada saqlanadi
+	handler.setAwaitingSearch(5, true)
+	if handler.cancelConversationOnCommand(5) || !handler.isAwaitingSearch(5) {
+		t.Fatalf("search jarayoni komandada bekor bo'ldi")
+	}
+}
@@ -0,0 +1,532 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/geo"
+)
+
+// Kuryerlar: admin Telegram foydalanuvchini kuryer qiladi va buyurtma kartasidan unga
+// yetkazishni biriktiradi. Kuryer shaxsiy chatda vazifa (manzil, telefon, xarita) oladi va
+// "Oldim" / "Yetkazildi" / "Yetkazilmadi" tugmalari bilan buyurtma holatini o'zgartiradi.
+// Yetkazilgach rasm (isbot) yuborishi mumkin.
+
+const (
+	couriersFile = "data/couriers.json"
+	// orderEventCourier - kuryer biriktirildi/yetkaza olmadi (timeline yozuvi)
+	orderEventCourier = "courier"
+	// orderEventProof - yetkazish isboti (Note - rasm file_id)
+	orderEventProof = "proof"
+)
+
+type courier struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type courierSettings struct {
+	Couriers []courier `json:"couriers"`
+}
+
+// courierAwait kuryerdan kutilayotgan kiritish: isbot rasmi yoki yetkaza olmaslik sababi
+// (convFlowCourier). Status - tugma bosilgandagi buyurtma holati.
+type courierAwait struct {
+	OrderID string
+	Kind    string // "proof" | "fail"
+	Status  string
+}
+
+func (h *BotHandler) loadCouriersFromDisk() {
+	b, err := os.ReadFile(couriersFile)
+	if err != nil {
+		return
+	}
+	var cfg courierSettings
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Printf("couriers parse failed: %v", err)
+		return
+	}
+	h.courierMu.Lock()
+	h.couriers = cfg.Couriers
+	h.courierMu.Unlock()
+}
+
+func (h *BotHandler) setCouriers(list []courier) error {
+	dir := filepath.Dir(couriersFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(courierSettings{Couriers: list}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(couriersFile, b, 0o600); err != nil {
+		return err
+	}
+	h.courierMu.Lock()
+	h.couriers = list
+	h.courierMu.Unlock()
+	return nil
+}
+
+func (h *BotHandler) getCouriers() []courier {
+	h.courierMu.RLock()
+	defer h.courierMu.RUnlock()
+	return append([]courier(nil), h.couriers...)
+}
+
+func (h *BotHandler) findCourier(userID int64) (courier, bool) {
+	for _, c := range h.getCouriers() {
+		if c.UserID == userID {
+			return c, true
+		}
+	}
+	return courier{}, false
+}
+
+func (h *BotHandler) courierName(userID int64) string {
+	if c, ok := h.findCourier(userID); ok && c.Name != "" {
+		return c.Name
+	}
+	if name := h.getCachedUsername(userID); name != "" {
+		return name
+	}
+	return strconv.FormatInt(userID, 10)
+}
+
+func (h *BotHandler) setCourierAwait(userID, chatID int64, await courierAwait) {
+	h.courierMu.Lock()
+	if h.courierAwaits == nil {
+		h.courierAwaits = make(map[int64]courierAwait)
+	}
+	h.courierAwaits[userID] = await
+	h.courierMu.Unlock()
+	h.enterConversation(userID, convFlowCourier, await.Kind, chatID)
+}
+
+func (h *BotHandler) popCourierAwait(userID int64, kind string) (courierAwait, bool) {
+	if state, ok := h.conversationStateIn(userID, convFlowCourier); !ok || state != kind {
+		return courierAwait{}, false
+	}
+	h.courierMu.Lock()
+	await, ok := h.courierAwaits[userID]
+	if ok && await.Kind == kind {
+		delete(h.courierAwaits, userID)
+	}
+	h.courierMu.Unlock()
+	if !ok || await.Kind != kind {
+		return courierAwait{}, false
+	}
+	h.leaveConversation(userID, convFlowCourier)
+	return await, true
+}
+
+func (h *BotHandler) hasCourierAwait(userID int64) bool {
+	h.courierMu.RLock()
+	defer h.courierMu.RUnlock()
+	_, ok := h.courierAwaits[userID]
+	return ok
+}
+
+func (h *BotHandler) clearCourierAwait(userID int64) {
+	h.courierMu.Lock()
+	delete(h.courierAwaits, userID)
+	h.courierMu.Unlock()
+}
+
+// courierMapLink xarita havolasi: koordinata bo'lsa nuqta, aks holda manzil bo'yicha qidiruv
+func courierMapLink(location string) string {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return ""
+	}
+	if p, ok := geo.ParsePoint(location); ok {
+		return fmt.Sprintf("https://www.google.com/maps?q=%.5f,%.5f", p.Lat, p.Lon)
+	}
+	return "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(location)
+}
+
+// courierActive kuryerga biriktirilgan va hali yopilmagan buyurtma
+func courierActive(ord orderStatusInfo) bool {
+	return ord.CourierID != 0 && (ord.Status == "processing" || ord.Status == "ready_delivery" || ord.Status == "onway")
+}
+
+// courierTasks kuryerning ochiq vazifalari (eskilari birinchi)
+func (h *BotHandler) courierTasks(courierID int64) []orderStatusInfo {
+	var tasks []orderStatusInfo
+	for _, ord := range h.listRecentOrders(500) {
+		if ord.CourierID == courierID && courierActive(ord) {
+			tasks = append(tasks, ord)
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].CreatedAt.Before(tasks[j].CreatedAt) })
+	return tasks
+}
+
+func courierTaskText(lang string, ord orderStatusInfo) string {
+	loc := nonEmpty(normalizeLocationText(ord.Location), "-")
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "courier.task_header", "order_id", ord.OrderID) + "\n")
+	sb.WriteString(tr(lang, "courier.task_status", "status", statusLabel(ord.Status, lang)) + "\n")
+	sb.WriteString(tr(lang, "courier.task_address", "address", loc) + "\n")
+	if link := courierMapLink(ord.Location); link != "" && link != loc {
+		sb.WriteString(tr(lang, "courier.task_map", "link", link) + "\n")
+	}
+	sb.WriteString(tr(lang, "courier.task_phone", "phone", nonEmpty(ord.Phone, "-")) + "\n")
+	if ord.Username != "" {
+		sb.WriteString(tr(lang, "courier.task_customer", "username", ord.Username) + "\n")
+	}
+	if ord.Total != "" {
+		sb.WriteString(tr(lang, "courier.task_total", "total", ord.Total, "payment", paymentStatusLabel(ord.PaymentStatus, lang)) + "\n")
+	}
+	if detail := nonEmpty(ord.StatusSummary, formatOrderStatusSummary(ord.Summary)); detail != "" {
+		sb.WriteString("\n" + detail)
+	}
+	return sb.String()
+}
+
+func courierTaskKeyboard(lang string, ord orderStatusInfo) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	if ord.Status != "onway" {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(tr(lang, "courier.btn_picked"), "cour_pick|"+ord.OrderID))
+	}
+	row = append(row,
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "courier.btn_delivered"), "cour_done|"+ord.OrderID),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "courier.btn_failed"), "cour_fail|"+ord.OrderID),
+	)
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+func (h *BotHandler) sendCourierTask(courierID int64, ord orderStatusInfo) error {
+	lang := h.getUserLang(courierID)
+	msg := tgbotapi.NewMessage(courierID, courierTaskText(lang, ord))
+	msg.ReplyMarkup = courierTaskKeyboard(lang, ord)
+	msg.DisableWebPagePreview = true
+	_, err := h.sendAndLog(msg)
+	return err
+}
+
+// sendCourierTasks /tasks - kuryerning ochiq vazifalari
+func (h *BotHandler) sendCourierTasks(courierID, chatID int64) {
+	lang := h.getUserLang(courierID)
+	tasks := h.courierTasks(courierID)
+	if len(tasks) == 0 {
+		h.sendMessage(chatID, tr(lang, "courier.no_tasks"))
+		return
+	}
+	h.sendMessage(chatID, trPlural(lang, "courier.tasks_header", len(tasks)))
+	for _, ord := range tasks {
+		if err := h.sendCourierTask(courierID, ord); err != nil {
+			log.Printf("courier task send failed courier=%d order=%s: %v", courierID, ord.OrderID, err)
+		}
+	}
+}
+
+// courierPickerKeyboard admin buyurtma kartasidan kuryer tanlaydi
+func (h *BotHandler) courierPickerKeyboard(orderID string, current int64) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, c := range h.getCouriers() {
+		label := "🛵 " + h.courierName(c.UserID)
+		if c.UserID == current {
+			label = "✅ " + h.courierName(c.UserID)
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("ordcour_set:%s:%d", orderID, c.UserID)),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func (h *BotHandler) handleCourierPickerCallback(chatID int64, orderID string) {
+	info, ok := h.getOrderStatus(orderID)
+	if !ok {
+		h.sendMessage(chatID, "❌ Buyurtma topilmadi.")
+		return
+	}
+	if len(h.getCouriers()) == 0 {
+		h.sendMessage(chatID, "🛵 Kuryerlar yo'q. Qo'shish: /courier add <user_id> [ism]")
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("🛵 %s buyurtmasi uchun kuryerni tanlang:", orderID))
+	msg.ReplyMarkup = h.courierPickerKeyboard(orderID, info.CourierID)
+	if sent, err := h.sendAndLog(msg); err == nil {
+		h.trackAdminMessage(chatID, sent.MessageID)
+	}
+}
+
+// assignCourier buyurtmani kuryerga biriktiradi va unga vazifa yuboradi
+func (h *BotHandler) assignCourier(chatID int64, orderID string, courierID int64) {
+	info, ok := h.getOrderStatus(orderID)
+	if !ok {
+		h.sendMessage(chatID, "❌ Buyurtma topilmadi.")
+		return
+	}
+	if _, ok := h.findCourier(courierID); !ok {
+		h.sendMessage(chatID, "❌ Kuryer topilmadi.")
+		return
+	}
+	if strings.EqualFold(info.Delivery, "pickup") {
+		h.sendMessage(chatID, "🏬 Bu buyurtma olib ketish uchun. Kuryer kerak emas.")
+		return
+	}
+	prev := info.CourierID
+	// Faqat kuryer maydoni yoziladi va faqat holat biz ko'rgandek qolgan bo'lsa:
+	// shu orada admin/mijoz o'zgartirgan holat eski nusxa bilan bosib ketilmaydi
+	info, ok = h.updateOrderIf(orderID, func(ctx context.Context) (bool, error) {
+		return h.orderStore.SetCourierIfStatus(ctx, orderID, info.Status, courierID)
+	})
+	if !ok {
+		h.sendMessage(chatID, fmt.Sprintf("⚠️ %s buyurtmasi holati o'zgardi, qaytadan urinib ko'ring.", orderID))
+		return
+	}
+	name := h.courierName(courierID)
+	h.recordOrderEvent(orderID, orderEventCourier, name)
+	if info.Status == "processing" && h.compareAndSetOrderStatus(orderID, "processing", "ready_delivery", "kuryer: "+name) {
+		info.Status = "ready_delivery"
+		h.notifyOrderStatusChange(info, "ready_delivery")
+	}
+
+	if err := h.sendCourierTask(courierID, info); err != nil {
+		log.Printf("courier task send failed courier=%d order=%s: %v", courierID, orderID, err)
+		h.sendMessage(chatID, fmt.Sprintf("⚠️ %s biriktirildi, lekin unga xabar yuborib bo'lmadi (botni /start qilmagan bo'lishi mumkin).", name))
+		return
+	}
+	if prev != 0 && prev != courierID {
+		h.sendMessage(prev, tr(h.getUserLang(prev), "courier.unassigned", "order_id", orderID))
+	}
+	h.sendMessage(chatID, fmt.Sprintf("✅ %s buyurtmasi %s ga biriktirildi.", orderID, name))
+}
+
+// courierOrder kuryer bosgan tugma uchun buyurtma (boshqa kuryerniki bo'lsa false)
+func (h *BotHandler) courierOrder(courierID, chatID int64, orderID string) (orderStatusInfo, bool) {
+	lang := h.getUserLang(courierID)
+	info, ok := h.getOrderStatus(orderID)
+	if !ok || info.CourierID != courierID {
+		h.sendMessage(chatID, tr(lang, "courier.not_yours", "order_id", orderID))
+		return orderStatusInfo{}, false
+	}
+	if !courierActive(info) {
+		h.sendMessage(chatID, tr(lang, "courier.closed", "order_id", orderID, "status", statusLabel(info.Status, lang)))
+		return orderStatusInfo{}, false
+	}
+	return info, true
+}
+
+// handleCourierAction "Oldim" / "Yetkazildi" / "Yetkazilmadi" tugmalari
+func (h *BotHandler) handleCourierAction(courierID, chatID int64, action, orderID string, srcMsg *tgbotapi.Message) {
+	info, ok := h.courierOrder(courierID, chatID, orderID)
+	if !ok {
+		return
+	}
+	lang := h.getUserLang(courierID)
+	name := h.courierName(courierID)
+	switch action {
+	case "pick":
+		h.setOrderStatusNote(orderID, "onway", "kuryer: "+name)
+		info.Status = "onway"
+		h.notifyOrderStatusChange(info, "onway")
+		h.notifyOrderChannel(info, fmt.Sprintf("🛵 Kuryer buyurtmani oldi\nOrderID: %s\nKuryer: %s", orderID, name))
+		if srcMsg != nil && h.bot != nil {
+			edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, srcMsg.MessageID, courierTaskText(lang, info), courierTaskKeyboard(lang, info))
+			edit.DisableWebPagePreview = true
+			if _, err := h.bot.Send(edit); err != nil {
+				log.Printf("courier task edit failed: %v", err)
+			}
+		}
+	case "done":
+		h.setOrderStatusNote(orderID, "delivered", "kuryer: "+name)
+		info.Status = "delivered"
+		h.notifyOrderStatusChange(info, "delivered")
+		h.notifyOrderChannel(info, fmt.Sprintf("✅ Kuryer yetkazdi\nOrderID: %s\nKuryer: %s", orderID, name))
+		h.clearCourierTaskButtons(chatID, srcMsg, lang, info)
+		h.setCourierAwait(courierID, chatID, courierAwait{OrderID: orderID, Kind: "proof", Status: "delivered"})
+		h.sendMessage(chatID, tr(lang, "courier.proof_prompt", "order_id", orderID))
+	case "fail":
+		h.setCourierAwait(courierID, chatID, courierAwait{OrderID: orderID, Kind: "fail", Status: info.Status})
+		h.sendMessage(chatID, tr(lang, "courier.fail_prompt", "order_id", orderID))
+	}
+}
+
+func (h *BotHandler) clearCourierTaskButtons(chatID int64, srcMsg *tgbotapi.Message, lang string, info orderStatusInfo) {
+	if srcMsg == nil || h.bot == nil {
+		return
+	}
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, srcMsg.MessageID, courierTaskText(lang, info), tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+	edit.DisableWebPagePreview = true
+	if _, err := h.bot.Send(edit); err != nil {
+		log.Printf("courier task edit failed: %v", err)
+	}
+}
+
+// handleCourierInput kuryer yuborgan isbot rasmi yoki yetkaza olmaslik sababi (convFlowCourier)
+func (h *BotHandler) handleCourierInput(ctx context.Context, in conversationInput) bool {
+	_ = ctx
+	courierID := in.UserID
+	if _, ok := h.findCourier(courierID); !ok {
+		return false
+	}
+	lang := h.getUserLang(courierID)
+
+	if message := in.Msg; message != nil && len(message.Photo) > 0 {
+		await, ok := h.popCourierAwait(courierID, "proof")
+		if !ok {
+			return false
+		}
+		info, found := h.getOrderStatus(await.OrderID)
+		if !found {
+			return true
+		}
+		photo := message.Photo[len(message.Photo)-1]
+		h.recordOrderEvent(await.OrderID, orderEventProof, photo.FileID)
+		chatID, threadID := h.orderChannel(info.Branch)
+		if chatID != 0 {
+			caption := fmt.Sprintf("📸 Yetkazish isboti\nOrderID: %s\nKuryer: %s", await.OrderID, h.courierName(courierID))
+			if err := h.sendPhotoID(chatID, photo.FileID, caption, threadID); err != nil {
+				log.Printf("courier proof forward failed order=%s: %v", await.OrderID, err)
+			}
+		}
+		h.sendMessage(in.ChatID, tr(lang, "courier.proof_saved", "order_id", await.OrderID))
+		return true
+	}
+
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		return false
+	}
+	await, ok := h.popCourierAwait(courierID, "fail")
+	if !ok {
+		return false
+	}
+	info, ok := h.courierOrder(courierID, in.ChatID, await.OrderID)
+	if !ok {
+		return true
+	}
+	// Tugma bosilgandan beri buyurtma boshqa holatga o'tgan bo'lsa (masalan admin qayta
+	// biriktirgan yoki yetkazildi deb belgilangan) - uni ready_delivery ga qaytarmaymiz
+	if info.Status != await.Status {
+		h.sendMessage(in.ChatID, tr(lang, "courier.fail_stale", "order_id", await.OrderID, "status", statusLabel(info.Status, lang)))
+		return true
+	}
+	name := h.courierName(courierID)
+	info.CourierID = 0
+	info.Status = "ready_delivery"
+	h.saveOrderStatus(await.OrderID, info)
+	h.recordOrderEvent(await.OrderID, orderEventCourier, fmt.Sprintf("%s yetkaza olmadi: %s", name, text))
+	h.notifyOrderChannel(info, fmt.Sprintf("⚠️ Kuryer yetkaza olmadi\nOrderID: %s\nKuryer: %s\nSabab: %s\n\nQayta biriktiring: /orders", await.OrderID, name, text))
+	h.sendMessage(in.ChatID, tr(lang, "courier.failed", "order_id", await.OrderID))
+	return true
+}
+
+func (h *BotHandler) couriersText() string {
+	list := h.getCouriers()
+	if len(list) == 0 {
+		return "🛵 Kuryerlar yo'q."
+	}
+	var sb strings.Builder
+	sb.WriteString("🛵 Kuryerlar:\n")
+	for _, c := range list {
+		sb.WriteString(fmt.Sprintf("• %s (%d) - %d ta ochiq vazifa\n", h.courierName(c.UserID), c.UserID, len(h.courierTasks(c.UserID))))
+	}
+	return sb.String()
+}
+
+const couriersUsage = `Foydalanish:
+/courier add <user_id> [ism] - kuryer qo'shish (u botga /start bosgan bo'lishi kerak)
+/courier remove <user_id> - o'chirish
+Buyurtmani biriktirish: /orders -> buyurtma -> "🛵 Kuryer tayinlash".
+Kuryer o'z vazifalarini /tasks bilan ko'radi.`
+
+// handleCourierCommand /courier - admin kuryerlarni boshqaradi; kuryer uchun vazifalar ro'yxati
+func (h *BotHandler) handleCourierCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	userID := message.From.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.handleTasksCommand(ctx, message)
+		return
+	}
+
+	args := splitPromoArgs(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(chatID, h.couriersText()+"\n"+couriersUsage)
+		return
+	}
+	if len(args) < 2 {
+		h.sendMessage(chatID, "❌ Noto'g'ri format.\n"+couriersUsage)
+		return
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(args[1]), 10, 64)
+	if err != nil || id <= 0 {
+		h.sendMessage(chatID, "❌ Noto'g'ri user_id.\n"+couriersUsage)
+		return
+	}
+
+	list := h.getCouriers()
+	idx := -1
+	for i := range list {
+		if list[i].UserID == id {
+			idx = i
+			break
+		}
+	}
+	var reply string
+	switch strings.ToLower(args[0]) {
+	case "add":
+		c := courier{UserID: id, Name: strings.Join(args[2:], " ")}
+		if idx >= 0 {
+			list[idx] = c
+		} else {
+			list = append(list, c)
+		}
+		reply = fmt.Sprintf("✅ %s kuryer sifatida saqlandi.", h.courierNameOr(c))
+	case "remove", "delete":
+		if idx < 0 {
+			h.sendMessage(chatID, "❌ Kuryer topilmadi.")
+			return
+		}
+		if n := len(h.courierTasks(id)); n > 0 {
+			h.sendMessage(chatID, fmt.Sprintf("❌ Kuryerda %d ta ochiq vazifa bor. Avval boshqa kuryerga biriktiring.", n))
+			return
+		}
+		reply = fmt.Sprintf("🗑 %s kuryerlar ro'yxatidan o'chirildi.", h.courierName(id))
+		list = append(list[:idx], list[idx+1:]...)
+	default:
+		h.sendMessage(chatID, couriersUsage)
+		return
+	}
+	if err := h.setCouriers(list); err != nil {
+		log.Printf("couriers save failed: %v", err)
+		h.sendMessage(chatID, "❌ Kuryerlarni saqlashda xatolik.")
+		return
+	}
+	h.sendMessage(chatID, reply+"\n\n"+h.couriersText())
+}
+
+func (h *BotHandler) courierNameOr(c courier) string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return h.courierName(c.UserID)
+}
+
+// handleTasksCommand /tasks - kuryer vazifalari
+func (h *BotHandler) handleTasksCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	if _, ok := h.findCourier(userID); !ok {
+		h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "courier.not_courier"))
+		return
+	}
+	h.sendCourierTasks(userID, message.Chat.ID)
+}
@@ -0,0 +1,109 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestCourierTaskCard - kuryer vazifasida manzil, xarita havolasi va tugmalar holatga mos
+func TestCourierTaskCard(t *testing.T) {
+	if got := courierMapLink("lat: 41.311, lon: 69.279"); got != "https://www.google.com/maps?q=41.31100,69.27900" {
+		t.Fatalf("pin link = %q", got)
+	}
+	if got := courierMapLink("Chilonzor 5-kvartal, 12-uy"); got != "https://www.google.com/maps/search/?api=1&query=Chilonzor+5-kvartal%2C+12-uy" {
+		t.Fatalf("address link = %q", got)
+	}
+
+	ord := orderStatusInfo{
+		OrderID:   "A12",
+		Status:    "ready_delivery",
+		Location:  "Chilonzor 5-kvartal, 12-uy",
+		Phone:     "+998901234567",
+		Total:     "450$",
+		CourierID: 77,
+	}
+	text := courierTaskText("en", ord)
+	for _, want := range []string{"A12", "Address: Chilonzor 5-kvartal, 12-uy", "maps/search", "Phone: +998901234567", "Total: 450$"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("%q yo'q:\n%s", want, text)
+		}
+	}
+	if kb := courierTaskKeyboard("en", ord); len(kb.InlineKeyboard[0]) != 3 {
+		t.Fatalf("ready_delivery: 3 ta tugma kutilgan")
+	}
+	ord.Status = "onway"
+	if kb := courierTaskKeyboard("en", ord); len(kb.InlineKeyboard[0]) != 2 || *kb.InlineKeyboard[0][0].CallbackData != "cour_done|A12" {
+		t.Fatalf("onway: faqat Yetkazildi/Yetkazilmadi qolishi kerak")
+	}
+	if !courierActive(ord) {
+		t.Fatalf("onway buyurtma kuryer uchun ochiq")
+	}
+	ord.Status = "delivered"
+	if courierActive(ord) {
+		t.Fatalf("delivered buyurtma yopiq")
+	}
+}
+
+// TestCourierFailStale - eski "yetkaza olmadim" kutishi boshqa holatdagi buyurtmani ready_delivery ga qaytarmaydi
+func TestCourierFailStale(t *testing.T) {
+	h := &BotHandler{
+		orderStore:    newMemoryStore(),
+		orderStatuses: make(map[string]orderStatusInfo),
+		couriers:      []courier{{UserID: 8, Name: "Ali"}},
+	}
+	const orderID = "01012026-05"
+	h.saveOrderStatus(orderID, orderStatusInfo{OrderID: orderID, UserID: 7, CourierID: 8, Status: "onway"})
+
+	h.handleCourierAction(8, 80, "fail", orderID, nil)
+	if state, ok := h.conversationStateIn(8, convFlowCourier); !ok || state != "fail" {
+		t.Fatalf("kuryer jarayoni: %q ok=%v", state, ok)
+	}
+	// Admin shu orada buyurtmani boshqa holatga o'tkazdi
+	info, _ := h.getOrderStatus(orderID)
+	info.Status = "processing"
+	h.saveOrderStatus(orderID, info)
+
+	if !h.dispatchConversation(context.Background(), conversationInput{UserID: 8, Text: "mijoz javob bermadi", ChatID: 80}) {
+		t.Fatalf("sabab qayta ishlanmadi")
+	}
+	if got, _ := h.getOrderStatus(orderID); got.Status != "processing" || got.CourierID != 8 {
+		t.Fatalf("eski kutish buyurtmani o'zgartirdi: %+v", got)
+	}
+	if h.hasCourierAwait(8) {
+		t.Fatalf("kutish tozalanmadi")
+	}
+
+	// Komanda kelsa kutish bekor bo'ladi va keyingi matn sabab bo'lmaydi
+	h.handleCourierAction(8, 80, "fail", orderID, nil)
+	h.cancelConversationOnCommand(8)
+	if h.dispatchConversation(context.Background(), conversationInput{UserID: 8, Text: "salom", ChatID: 80}) {
+		t.Fatalf("bekor qilingan kutish matnni oldi")
+	}
+}
+
+// TestAssignCourierStatusGuard - kuryer biriktirish faqat kuryer maydonini yozadi va
+// processing -> ready_delivery o'tishini store'dagi holat bo'yicha tekshiradi
+func TestAssignCourierStatusGuard(t *testing.T) {
+	h := &BotHandler{
+		orderStore:    newMemoryStore(),
+		orderStatuses: make(map[string]orderStatusInfo),
+		couriers:      []courier{{UserID: 8, Name: "Ali"}},
+	}
+	const orderID = "01012026-07"
+	h.saveOrderStatus(orderID, orderStatusInfo{OrderID: orderID, UserID: 7, Status: "processing", Location: "Chilonzor 9"})
+
+	h.assignCourier(1, orderID, 8)
+	if got, _ := h.getOrderStatus(orderID); got.Status != "ready_delivery" || got.CourierID != 8 || got.Location != "Chilonzor 9" {
+		t.Fatalf("biriktirish: %+v", got)
+	}
+
+	// Kesh eski: store'da buyurtma allaqachon bekor qilingan
+	const stale = "01012026-08"
+	h.saveOrderStatus(stale, orderStatusInfo{OrderID: stale, UserID: 7, Status: "processing"})
+	_ = h.orderStore.UpdateStatus(context.Background(), stale, "canceled")
+	h.assignCourier(1, stale, 8)
+	if got, _ := h.getOrderStatus(stale); got.Status != "canceled" || got.CourierID != 0 {
+		t.Fatalf("bekor qilingan buyurtma qayta ochildi: %+v", got)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/exrate"
 )
 
 var dollarPricePattern = regexp.MustCompile(`(?i)(\$\s*[0-9][0-9\s.,]*|[0-9][0-9\s.,]*\s*\$|[0-9][0-9\s.,]*\s*usd)`)
@@ -13,16 +16,17 @@ var dollarPricePattern = regexp.MustCompile(`(?i)(\$\s*[0-9][0-9\s.,]*|[0-9][0-9
 func (h *BotHandler) setCurrencyMode(mode string, rate float64) {
 	h.currencyMu.Lock()
 	h.currencyMode = strings.ToLower(strings.TrimSpace(mode))
+	h.currencyMu.Unlock()
 	if rate > 0 {
-		h.currencyRate = rate
+		// recordCurrencyRate o'zi saqlaydi
+		h.setManualCurrencyRate(rate)
+		return
 	}
-	h.currencyMu.Unlock()
+	h.persistCurrencySettings()
 }
 
 func (h *BotHandler) setCurrencyRate(rate float64) {
-	h.currencyMu.Lock()
-	h.currencyRate = rate
-	h.currencyMu.Unlock()
+	h.setManualCurrencyRate(rate)
 }
 
 func (h *BotHandler) getCurrencySettings() (string, float64) {
@@ -32,19 +36,16 @@ func (h *BotHandler) getCurrencySettings() (string, float64) {
 }
 
 func (h *BotHandler) setAwaitingCurrencyRate(userID int64, awaiting bool) {
-	h.currencyMu.Lock()
 	if awaiting {
-		h.currencyAwait[userID] = true
+		h.enterConversation(userID, convFlowCurrencyRate, "need_rate", 0)
 	} else {
-		delete(h.currencyAwait, userID)
+		h.leaveConversation(userID, convFlowCurrencyRate)
 	}
-	h.currencyMu.Unlock()
 }
 
 func (h *BotHandler) isAwaitingCurrencyRate(userID int64) bool {
-	h.currencyMu.RLock()
-	defer h.currencyMu.RUnlock()
-	return h.currencyAwait[userID]
+	_, ok := h.conversationStateIn(userID, convFlowCurrencyRate)
+	return ok
 }
 
 func parseDollarAmount(text string) (float64, bool) {
@@ -83,10 +84,70 @@ func formatUZS(v float64) string {
 	return out
 }
 
+// currencySnapshot valyuta rejimi va kursning bir lahzadagi nusxasi.
+// Buyurtma yaratilganda olinadi, shunda keyingi kurs o'zgarishi eski buyurtma summasini o'zgartirmaydi.
+type currencySnapshot struct {
+	Mode string
+	Rate float64
+}
+
+func (h *BotHandler) currencySnapshot() currencySnapshot {
+	mode, rate := h.getCurrencySettings()
+	return currencySnapshot{Mode: mode, Rate: rate}
+}
+
+// orderCurrency buyurtma uchun snapshot: joriy rejim, kurs esa buyurtmadagi (bo'lmasa joriy)
+func (h *BotHandler) orderCurrency(info orderStatusInfo) currencySnapshot {
+	snap := h.currencySnapshot()
+	if info.CurrencyRate > 0 {
+		snap.Rate = info.CurrencyRate
+	}
+	return snap
+}
+
 // applyCurrencyPreference - agar admin SUM rejimini tanlagan bo'lsa, $ narxlarni so'mga o'girish
 func (h *BotHandler) applyCurrencyPreference(text string) string {
-	mode, rate := h.getCurrencySettings()
-	if strings.ToLower(mode) != "sum" || rate <= 0 {
+	return h.currencySnapshot().apply(text)
+}
+
+// formatTotalForDisplay ensures we don't double-convert totals that already contain so'm equivalents.
+func (h *BotHandler) formatTotalForDisplay(total string) string {
+	return h.currencySnapshot().formatTotal(total)
+}
+
+// formatOrderTotal buyurtma jami summasi, buyurtma yaratilgandagi kurs bilan
+func (h *BotHandler) formatOrderTotal(info orderStatusInfo) string {
+	return h.orderCurrency(info).formatTotal(info.Total)
+}
+
+// orderTotalMoney buyurtma jami summasi Money ko'rinishida (eski buyurtmalar uchun Total matnidan)
+func orderTotalMoney(info orderStatusInfo) (entity.Money, bool) {
+	if info.TotalMoney.Amount > 0 {
+		return info.TotalMoney, true
+	}
+	return parseTotalMoney(info.Total)
+}
+
+// parseTotalMoney "1200$ (~15 000 000 so'm)" kabi matndan birinchi valyutali summani oladi
+func parseTotalMoney(total string) (entity.Money, bool) {
+	amount, symbol, ok := parseTotalAmount(total)
+	if !ok {
+		return entity.Money{}, false
+	}
+	cur := entity.ParseCurrency(symbol)
+	if cur == "" {
+		return entity.Money{}, false
+	}
+	return entity.NewMoney(amount, cur), true
+}
+
+// converter snapshot kursi bilan valyuta o'girish
+func (s currencySnapshot) converter() entity.Converter {
+	return exrate.FixedConverter(s.Rate)
+}
+
+func (s currencySnapshot) apply(text string) string {
+	if strings.ToLower(s.Mode) != "sum" || s.Rate <= 0 {
 		return text
 	}
 
@@ -95,18 +156,17 @@ func (h *BotHandler) applyCurrencyPreference(text string) string {
 		if !ok {
 			return match
 		}
-		converted := amt * rate
+		converted := amt * s.Rate
 		return fmt.Sprintf("%s (~%s)", strings.TrimSpace(match), formatUZS(converted))
 	}
 
 	return dollarPricePattern.ReplaceAllStringFunc(text, convert)
 }
 
-// formatTotalForDisplay ensures we don't double-convert totals that already contain so'm equivalents.
-func (h *BotHandler) formatTotalForDisplay(total string) string {
+func (s currencySnapshot) formatTotal(total string) string {
 	lower := strings.ToLower(total)
 	if strings.Contains(lower, "~") || strings.Contains(lower, "so'm") || strings.Contains(lower, "сум") {
 		return total
 	}
-	return h.applyCurrencyPreference(total)
+	return s.apply(total)
 }
@@ -0,0 +1,288 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/exrate"
+)
+
+// USD -> so'm kursi: manba (qo'lda / HTTP / fayl), jadval bo'yicha yangilash,
+// tarix va eskirish ogohlantirishi. Holat data/currency_rate.json da saqlanadi.
+
+const (
+	defaultCurrencyRefreshInterval = time.Hour
+	minCurrencyRefreshInterval     = 5 * time.Minute
+	defaultCurrencyMaxAge          = 36 * time.Hour
+	maxCurrencyRateHistory         = 200
+)
+
+// currencyRateFile - var, testlar vaqtinchalik papkaga yo'naltirishi uchun
+var currencyRateFile = "data/currency_rate.json"
+
+type currencyRateState struct {
+	Mode      string        `json:"mode"`
+	Rate      float64       `json:"rate"`
+	Source    string        `json:"source,omitempty"`
+	UpdatedAt time.Time     `json:"updated_at,omitempty"`
+	History   []exrate.Rate `json:"history,omitempty"`
+}
+
+// newRateSourceFromEnv CURRENCY_RATE_SOURCE: manual (default) | cbu | http | file
+func newRateSourceFromEnv() exrate.Source {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("CURRENCY_RATE_SOURCE"))) {
+	case "cbu":
+		return exrate.NewHTTPSource(exrate.DefaultCBUURL, exrate.DefaultCBUPath)
+	case "http":
+		url := strings.TrimSpace(os.Getenv("CURRENCY_RATE_URL"))
+		if url == "" {
+			log.Printf("exrate: CURRENCY_RATE_URL bo'sh, qo'lda kiritish rejimi")
+			return exrate.NewManualSource()
+		}
+		return exrate.NewHTTPSource(url, os.Getenv("CURRENCY_RATE_JSON_PATH"))
+	case "file":
+		path := strings.TrimSpace(os.Getenv("CURRENCY_RATE_FILE"))
+		if path == "" {
+			log.Printf("exrate: CURRENCY_RATE_FILE bo'sh, qo'lda kiritish rejimi")
+			return exrate.NewManualSource()
+		}
+		return exrate.NewFileSource(path, os.Getenv("CURRENCY_RATE_JSON_PATH"))
+	default:
+		return exrate.NewManualSource()
+	}
+}
+
+func currencyRefreshInterval() time.Duration {
+	if raw := strings.TrimSpace(os.Getenv("CURRENCY_RATE_REFRESH_MINUTES")); raw != "" {
+		if mins, err := strconv.Atoi(raw); err == nil && mins > 0 {
+			if d := time.Duration(mins) * time.Minute; d > minCurrencyRefreshInterval {
+				return d
+			}
+			return minCurrencyRefreshInterval
+		}
+	}
+	return defaultCurrencyRefreshInterval
+}
+
+// currencyMaxAge shundan eski kurs eskirgan hisoblanadi (CURRENCY_RATE_MAX_AGE_HOURS)
+func currencyMaxAge() time.Duration {
+	if raw := strings.TrimSpace(os.Getenv("CURRENCY_RATE_MAX_AGE_HOURS")); raw != "" {
+		if hours, err := strconv.Atoi(raw); err == nil && hours > 0 {
+			return time.Duration(hours) * time.Hour
+		}
+	}
+	return defaultCurrencyMaxAge
+}
+
+func (h *BotHandler) loadCurrencySettingsFromDisk() {
+	b, err := os.ReadFile(currencyRateFile)
+	if err != nil {
+		return
+	}
+	var st currencyRateState
+	if err := json.Unmarshal(b, &st); err != nil {
+		log.Printf("currency settings parse failed: %v", err)
+		return
+	}
+	h.currencyMu.Lock()
+	if mode := strings.ToLower(strings.TrimSpace(st.Mode)); mode != "" {
+		h.currencyMode = mode
+	}
+	if st.Rate > 0 {
+		h.currencyRate = st.Rate
+		h.currencyRateAt = st.UpdatedAt
+		h.currencyRateSource = st.Source
+	}
+	h.currencyRateHistory = st.History
+	h.currencyMu.Unlock()
+
+	if manual, ok := h.rateSource.(*exrate.ManualSource); ok && st.Rate > 0 && st.Source == exrate.ManualName {
+		manual.Set(st.Rate, st.UpdatedAt)
+	}
+}
+
+func (h *BotHandler) persistCurrencySettings() {
+	h.currencyMu.RLock()
+	st := currencyRateState{
+		Mode:      h.currencyMode,
+		Rate:      h.currencyRate,
+		Source:    h.currencyRateSource,
+		UpdatedAt: h.currencyRateAt,
+		History:   append([]exrate.Rate(nil), h.currencyRateHistory...),
+	}
+	h.currencyMu.RUnlock()
+
+	dir := filepath.Dir(currencyRateFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			log.Printf("currency settings save failed: %v", err)
+			return
+		}
+	}
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		log.Printf("currency settings save failed: %v", err)
+		return
+	}
+	if err := os.WriteFile(currencyRateFile, b, 0o600); err != nil {
+		log.Printf("currency settings save failed: %v", err)
+	}
+}
+
+// recordCurrencyRate yangi kursni qabul qiladi; avvalgisidan yangi bo'lmasa e'tiborsiz qoldiradi.
+// Tarixga faqat qiymat o'zgarganda yoziladi.
+func (h *BotHandler) recordCurrencyRate(r exrate.Rate) bool {
+	if r.Value <= 0 {
+		return false
+	}
+	if r.FetchedAt.IsZero() {
+		r.FetchedAt = time.Now()
+	}
+	h.currencyMu.Lock()
+	if !h.currencyRateAt.IsZero() && !r.FetchedAt.After(h.currencyRateAt) {
+		h.currencyMu.Unlock()
+		return false
+	}
+	h.currencyRate = r.Value
+	h.currencyRateAt = r.FetchedAt
+	h.currencyRateSource = r.Source
+	h.currencyStaleAlerted = false
+	if n := len(h.currencyRateHistory); n == 0 || h.currencyRateHistory[n-1].Value != r.Value {
+		h.currencyRateHistory = append(h.currencyRateHistory, r)
+		if len(h.currencyRateHistory) > maxCurrencyRateHistory {
+			h.currencyRateHistory = h.currencyRateHistory[len(h.currencyRateHistory)-maxCurrencyRateHistory:]
+		}
+	}
+	h.currencyMu.Unlock()
+	h.persistCurrencySettings()
+	return true
+}
+
+// setManualCurrencyRate admin /val orqali kiritgan kurs
+func (h *BotHandler) setManualCurrencyRate(value float64) {
+	now := time.Now()
+	if manual, ok := h.rateSource.(*exrate.ManualSource); ok {
+		manual.Set(value, now)
+	}
+	h.recordCurrencyRate(exrate.Rate{Value: value, Source: exrate.ManualName, FetchedAt: now})
+}
+
+// refreshCurrencyRate manbadan kursni o'qib, yangi bo'lsa saqlaydi
+func (h *BotHandler) refreshCurrencyRate(ctx context.Context) error {
+	if h.rateSource == nil {
+		return nil
+	}
+	r, err := h.rateSource.Fetch(ctx)
+	if err == exrate.ErrNoRate && h.rateSource.Name() == exrate.ManualName {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if h.recordCurrencyRate(r) {
+		log.Printf("[exrate] 1$ = %.2f so'm (%s)", r.Value, r.Source)
+	}
+	return nil
+}
+
+// currencyRateInUse kurs haqiqatda ishlatiladimi (so'm rejimi yoki onlayn to'lov)
+func (h *BotHandler) currencyRateInUse() bool {
+	mode, _ := h.getCurrencySettings()
+	return mode == "sum" || len(h.payments.Names()) > 0
+}
+
+// checkCurrencyStaleness kurs eskirgan bo'lsa active-orders ga bir marta ogohlantirish yuboradi
+func (h *BotHandler) checkCurrencyStaleness(now time.Time) bool {
+	if !h.currencyRateInUse() {
+		return false
+	}
+	h.currencyMu.Lock()
+	rate, at, source := h.currencyRate, h.currencyRateAt, h.currencyRateSource
+	stale := rate <= 0 || at.IsZero() || now.Sub(at) > currencyMaxAge()
+	alert := stale && !h.currencyStaleAlerted
+	if alert {
+		h.currencyStaleAlerted = true
+	}
+	h.currencyMu.Unlock()
+	if alert {
+		log.Printf("[exrate] kurs eskirgan: rate=%.2f at=%s", rate, at)
+		h.notifyActiveOrders(fmt.Sprintf("⚠️ Valyuta kursi eskirgan!\nJoriy kurs: %s\nManba: %s\nYangilanish: %s\n\nKursni yangilang: /val",
+			currencyRateLabel(rate), nonEmpty(source, "-"), nonEmpty(formatOptionalTime(at), "hech qachon")))
+	}
+	return stale
+}
+
+// startCurrencyRateRefresher jadval bo'yicha yangilash va eskirishni tekshirish
+func (h *BotHandler) startCurrencyRateRefresher(ctx context.Context) {
+	run := func() {
+		fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		if err := h.refreshCurrencyRate(fetchCtx); err != nil {
+			log.Printf("[exrate] refresh failed: %v", err)
+		}
+		h.checkCurrencyStaleness(time.Now())
+	}
+	run()
+
+	ticker := time.NewTicker(currencyRefreshInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+func currencyRateLabel(rate float64) string {
+	if rate <= 0 {
+		return "kiritilmagan"
+	}
+	return fmt.Sprintf("1$ = %.2f so'm", rate)
+}
+
+// currencyStatusText /val uchun kurs holati va oxirgi o'zgarishlar
+func (h *BotHandler) currencyStatusText() string {
+	h.currencyMu.RLock()
+	mode, rate, at, source := h.currencyMode, h.currencyRate, h.currencyRateAt, h.currencyRateSource
+	history := append([]exrate.Rate(nil), h.currencyRateHistory...)
+	h.currencyMu.RUnlock()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("💱 Rejim: %s\nKurs: %s\n", nonEmpty(mode, "usd"), currencyRateLabel(rate)))
+	if h.rateSource != nil {
+		sb.WriteString(fmt.Sprintf("Manba: %s", h.rateSource.Name()))
+		if source != "" && source != h.rateSource.Name() {
+			sb.WriteString(fmt.Sprintf(" (oxirgisi: %s)", source))
+		}
+		sb.WriteString("\n")
+	}
+	if !at.IsZero() {
+		sb.WriteString(fmt.Sprintf("Yangilangan: %s", formatOptionalTime(at.In(time.Local))))
+		if time.Since(at) > currencyMaxAge() {
+			sb.WriteString(" ⚠️ eskirgan")
+		}
+		sb.WriteString("\n")
+	}
+	if len(history) > 1 {
+		sb.WriteString("\nOxirgi o'zgarishlar:\n")
+		start := len(history) - 5
+		if start < 0 {
+			start = 0
+		}
+		for i := len(history) - 1; i >= start; i-- {
+			r := history[i]
+			sb.WriteString(fmt.Sprintf("• %s - %.2f (%s)\n", r.FetchedAt.In(time.Local).Format("2006-01-02 15:04"), r.Value, r.Source))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package telegram
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/exrate"
+)
+
+// TestCurrencyRateRefreshAndOrderSnapshot - fayl manbasidan yangilash, tarix,
+// eskirish va buyurtma summasi yaratilgandagi kurs bilan ko'rsatilishi
+func TestCurrencyRateRefreshAndOrderSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	prev := currencyRateFile
+	currencyRateFile = filepath.Join(dir, "currency_rate.json")
+	defer func() { currencyRateFile = prev }()
+
+	ratePath := filepath.Join(dir, "rate.txt")
+	_ = os.WriteFile(ratePath, []byte("12500"), 0o600)
+	h := &BotHandler{currencyMode: "sum", rateSource: exrate.NewFileSource(ratePath, "")}
+	ctx := context.Background()
+
+	if err := h.refreshCurrencyRate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	order := orderStatusInfo{Total: "100$", CurrencyRate: h.currencySnapshot().Rate}
+
+	_ = os.WriteFile(ratePath, []byte("13000"), 0o600)
+	if err := h.refreshCurrencyRate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.formatOrderTotal(order); !strings.Contains(got, "1 250 000 so'm") {
+		t.Fatalf("buyurtma eski kurs bilan ko'rsatilishi kerak: %s", got)
+	}
+	if got := h.formatTotalForDisplay("100$"); !strings.Contains(got, "1 300 000 so'm") {
+		t.Fatalf("yangi kurs: %s", got)
+	}
+	if len(h.currencyRateHistory) != 2 {
+		t.Fatalf("tarix: %+v", h.currencyRateHistory)
+	}
+
+	// Qayta yuklashda kurs va tarix tiklanadi
+	restored := &BotHandler{currencyMode: "usd", rateSource: exrate.NewManualSource()}
+	restored.loadCurrencySettingsFromDisk()
+	if mode, rate := restored.getCurrencySettings(); mode != "sum" || rate != 13000 || len(restored.currencyRateHistory) != 2 {
+		t.Fatalf("tiklash: mode=%s rate=%.0f history=%d", mode, rate, len(restored.currencyRateHistory))
+	}
+
+	if h.checkCurrencyStaleness(time.Now()) {
+		t.Fatalf("yangi kurs eskirgan deb topilmasligi kerak")
+	}
+	if !h.checkCurrencyStaleness(time.Now().Add(currencyMaxAge()+time.Hour)) || !h.currencyStaleAlerted {
+		t.Fatalf("eskirish ogohlantirishi ishlamadi")
+	}
+}
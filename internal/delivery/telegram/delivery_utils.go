@@ -14,15 +14,15 @@ func deliveryDisplay(delivery, lang string) string {
 
 	switch normalized {
 	case "pickup":
-		return t(lang, "Olib ketish", "Самовывоз")
+		return tr(lang, "delivery.method.pickup")
 	case "courier", "yandex go", "yandexgo", "yandex":
-		return t(lang, "Dostavka (Yandex Go)", "Доставка (Yandex Go)")
+		return tr(lang, "delivery.method.courier")
 	default:
 		if strings.Contains(normalized, "yandex") {
-			return t(lang, "Dostavka (Yandex Go)", "Доставка (Yandex Go)")
+			return tr(lang, "delivery.method.courier")
 		}
 		if strings.Contains(normalized, "courier") {
-			return t(lang, "Dostavka (Yandex Go)", "Доставка (Yandex Go)")
+			return tr(lang, "delivery.method.courier")
 		}
 		return raw
 	}
@@ -0,0 +1,512 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/geo"
+)
+
+// Yetkazish hududlari: admin poligon (GeoJSON) yoki do'kon atrofidagi radius bilan belgilaydi,
+// har hududga narx, bepul yetkazish chegarasi va taxminiy vaqt. Mijoz lokatsiyasi (pin yoki
+// manzil matni) hududga moslanadi va yetkazish narxi buyurtma jamisiga qo'shiladi.
+
+const deliveryZonesFile = "data/delivery_zones.json"
+
+// deliveryZone bitta hudud; nom - identifikator
+type deliveryZone struct {
+	Name     string        `json:"name"`
+	Polygons []geo.Polygon `json:"polygons,omitempty"`
+	Center   *geo.Point    `json:"center,omitempty"`
+	RadiusKm float64       `json:"radius_km,omitempty"`
+	Keywords []string      `json:"keywords,omitempty"` // matn manzil uchun (tuman, mahalla nomlari)
+	Fee      entity.Money  `json:"fee"`
+	FreeFrom entity.Money  `json:"free_from,omitempty"` // shu summadan boshlab bepul (0 - yo'q)
+	ETA      string        `json:"eta,omitempty"`
+}
+
+type deliveryZoneSettings struct {
+	Zones []deliveryZone `json:"zones"`
+}
+
+// deliveryQuote buyurtma uchun hisoblangan yetkazish narxi
+type deliveryQuote struct {
+	Zone     string
+	Fee      entity.Money // bepul bo'lsa 0
+	Free     bool         // bepul yetkazish chegarasidan oshdi
+	ETA      string
+	Subtotal entity.Money
+	Total    entity.Money // Subtotal + Fee (jami noma'lum bo'lsa 0)
+}
+
+func (h *BotHandler) loadDeliveryZonesFromDisk() {
+	b, err := os.ReadFile(deliveryZonesFile)
+	if err != nil {
+		return
+	}
+	var cfg deliveryZoneSettings
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Printf("delivery zones parse failed: %v", err)
+		return
+	}
+	h.setDeliveryZones(cfg.Zones)
+}
+
+func saveDeliveryZonesFile(path string, zones []deliveryZone) error {
+	dir := filepath.Dir(path)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(deliveryZoneSettings{Zones: zones}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+func (h *BotHandler) setDeliveryZones(zones []deliveryZone) {
+	clean := make([]deliveryZone, 0, len(zones))
+	for _, z := range zones {
+		z.Name = strings.TrimSpace(z.Name)
+		if z.Name != "" {
+			clean = append(clean, z)
+		}
+	}
+	h.deliveryZoneMu.Lock()
+	h.deliveryZones = clean
+	h.deliveryZoneMu.Unlock()
+}
+
+func (h *BotHandler) getDeliveryZones() []deliveryZone {
+	h.deliveryZoneMu.RLock()
+	defer h.deliveryZoneMu.RUnlock()
+	return append([]deliveryZone(nil), h.deliveryZones...)
+}
+
+// containsPoint nuqta poligon yoki radius ichidami
+func (z deliveryZone) containsPoint(p geo.Point) bool {
+	for _, pg := range z.Polygons {
+		if pg.Contains(p) {
+			return true
+		}
+	}
+	return z.Center != nil && z.RadiusKm > 0 && geo.DistanceKm(*z.Center, p) <= z.RadiusKm
+}
+
+// matchesAddress matn manzilda hudud nomi yoki kalit so'zi bormi
+func (z deliveryZone) matchesAddress(address string) bool {
+	lower := strings.ToLower(address)
+	for _, kw := range append([]string{z.Name}, z.Keywords...) {
+		kw = strings.ToLower(strings.TrimSpace(kw))
+		if len([]rune(kw)) >= 3 && strings.Contains(lower, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDeliveryZone lokatsiyani hududga moslaydi: koordinata bo'lsa geometriya bo'yicha,
+// aks holda manzil matnidagi kalit so'zlar bo'yicha. Bir nechta mos kelsa ro'yxatdagi birinchisi.
+func matchDeliveryZone(zones []deliveryZone, location string) (deliveryZone, bool) {
+	if p, ok := geo.ParsePoint(location); ok {
+		for _, z := range zones {
+			if z.containsPoint(p) {
+				return z, true
+			}
+		}
+		return deliveryZone{}, false
+	}
+	for _, z := range zones {
+		if z.matchesAddress(location) {
+			return z, true
+		}
+	}
+	return deliveryZone{}, false
+}
+
+// quoteDelivery sessiya manzili bo'yicha yetkazish narxi (hudud topilmasa nil)
+func (h *BotHandler) quoteDelivery(session *orderSession) *deliveryQuote {
+	if session == nil {
+		return nil
+	}
+	subtotal, hasSubtotal := parseTotalMoney(h.checkoutTotal(session))
+	return h.quoteDeliveryAt(session.Location, subtotal, hasSubtotal, h.currencySnapshot().converter())
+}
+
+// checkoutDeliveryQuote buyurtma yozilayotganda hudud narxini yakuniy (band qilingan
+// aksiyalardan keyingi) jami bo'yicha qayta hisoblaydi: sessiyadagi taklif promo
+// qo'llanishi yoki limit tugashidan oldingi summaga asoslangan bo'lishi mumkin
+func (h *BotHandler) checkoutDeliveryQuote(session *orderSession, total entity.Money, conv entity.Converter) *deliveryQuote {
+	if session == nil || session.Zone == nil {
+		return nil
+	}
+	if q := h.quoteDeliveryAt(session.Location, total, total.Amount > 0, conv); q != nil {
+		return q
+	}
+	return session.Zone
+}
+
+// quoteDeliveryAt manzil va yetkazishsiz jami bo'yicha hudud narxi (hudud topilmasa nil)
+func (h *BotHandler) quoteDeliveryAt(location string, subtotal entity.Money, hasSubtotal bool, conv entity.Converter) *deliveryQuote {
+	if strings.TrimSpace(location) == "" {
+		return nil
+	}
+	zone, ok := matchDeliveryZone(h.getDeliveryZones(), location)
+	if !ok {
+		return nil
+	}
+	q := &deliveryQuote{Zone: zone.Name, Fee: zone.Fee, ETA: zone.ETA}
+	if hasSubtotal {
+		q.Subtotal = subtotal
+		if zone.FreeFrom.Amount > 0 {
+			if inFreeCurrency, err := entity.Sum(conv, zone.FreeFrom.Currency, subtotal); err == nil && inFreeCurrency.Amount >= zone.FreeFrom.Amount {
+				q.Free = true
+				q.Fee = entity.Money{Currency: zone.Fee.Currency}
+			}
+		}
+		if total, err := entity.Sum(conv, subtotal.Currency, subtotal, q.Fee); err == nil {
+			q.Total = total
+		} else {
+			log.Printf("delivery total failed zone=%s: %v", zone.Name, err)
+		}
+	}
+	return q
+}
+
+func deliveryFeeText(lang string, q *deliveryQuote) string {
+	if q.Fee.IsZero() {
+		return tr(lang, "delivery.free")
+	}
+	return q.Fee.String()
+}
+
+// deliveryZoneLines buyurtma formasi uchun hudud, narx va taxminiy vaqt qatorlari
+func deliveryZoneLines(lang string, q *deliveryQuote) string {
+	line := tr(lang, "delivery.zone_line", "zone", q.Zone, "fee", deliveryFeeText(lang, q))
+	if q.ETA != "" {
+		line += "\n" + tr(lang, "delivery.eta_line", "eta", q.ETA)
+	}
+	return line
+}
+
+// deliveryConfirmPrompt "Dostavka" tanlanganda rozilik so'rovi
+func (h *BotHandler) deliveryConfirmPrompt(lang string, session *orderSession) string {
+	if session != nil && session.Zone != nil {
+		q := session.Zone
+		total := "-"
+		if !q.Total.IsZero() {
+			total = q.Total.String()
+		}
+		prompt := tr(lang, "delivery.zone_confirm", "zone", q.Zone, "fee", deliveryFeeText(lang, q), "total", total)
+		if q.ETA != "" {
+			prompt += "\n" + tr(lang, "delivery.eta_line", "eta", q.ETA)
+		}
+		return prompt
+	}
+	if len(h.getDeliveryZones()) > 0 {
+		return tr(lang, "delivery.out_of_zone")
+	}
+	return tr(lang, "delivery.yandex_confirm")
+}
+
+// deliveryFeeLabel admin xabarlari uchun narx (0 - bepul)
+func deliveryFeeLabel(fee entity.Money) string {
+	if fee.IsZero() {
+		return "bepul"
+	}
+	return fee.String()
+}
+
+// deliveryFeeCell hisobot uchun (hududsiz buyurtmada bo'sh)
+func deliveryFeeCell(ord orderStatusInfo) string {
+	if ord.DeliveryZone == "" {
+		return ""
+	}
+	return deliveryFeeLabel(ord.DeliveryFee)
+}
+
+// deliveryAdminLabel guruhdagi "Yetkazish" qatori
+func deliveryAdminLabel(session *orderSession) string {
+	if session != nil && session.Zone != nil {
+		return fmt.Sprintf("Dostavka (%s hududi)", session.Zone.Zone)
+	}
+	return "Dostavka (Yandex Go)"
+}
+
+// parseZoneMoney "30000", "30000so'm", "3$", "0" - valyuta ko'rsatilmasa so'm
+func parseZoneMoney(s string) (entity.Money, bool) {
+	cur := entity.ParseCurrency(s)
+	if cur == "" {
+		cur = entity.CurrencyUZS
+	}
+	clean := strings.ToLower(strings.TrimSpace(s))
+	clean = strings.TrimRight(clean, "$€₽")
+	for _, suffix := range []string{"so'm", "som", "sum", "uzs", "usd", "eur", "rub"} {
+		clean = strings.TrimSpace(strings.TrimSuffix(clean, suffix))
+	}
+	clean = strings.ReplaceAll(strings.ReplaceAll(clean, " ", ""), ",", ".")
+	v, err := strconv.ParseFloat(clean, 64)
+	if err != nil || v < 0 {
+		return entity.Money{}, false
+	}
+	return entity.NewMoney(v, cur), true
+}
+
+// zonesFromGeoJSON GeoJSON obyektlarini hududlarga aylantiradi.
+// Xossalar: name, fee, free_from, eta, keywords, currency; Point uchun radius_km.
+func zonesFromGeoJSON(data []byte) ([]deliveryZone, error) {
+	features, err := geo.ParseFeatures(data)
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]deliveryZone, 0, len(features))
+	for i, f := range features {
+		z := deliveryZone{
+			Name:     nonEmpty(f.Prop("name", "title", "zone"), fmt.Sprintf("Hudud %d", i+1)),
+			Polygons: f.Polygons,
+			Center:   f.Center,
+			RadiusKm: f.RadiusKm,
+			ETA:      f.Prop("eta"),
+		}
+		cur := strings.ToUpper(f.Prop("currency"))
+		for key, dst := range map[string]*entity.Money{"fee": &z.Fee, "free_from": &z.FreeFrom} {
+			raw := f.Prop(key)
+			if raw == "" {
+				continue
+			}
+			if cur != "" && entity.ParseCurrency(raw) == "" {
+				raw += " " + cur
+			}
+			m, ok := parseZoneMoney(raw)
+			if !ok {
+				return nil, fmt.Errorf("%s: noto'g'ri %s qiymati %q", z.Name, key, f.Prop(key))
+			}
+			*dst = m
+		}
+		if kw := f.Prop("keywords"); kw != "" {
+			for _, k := range strings.Split(kw, ",") {
+				if k = strings.TrimSpace(k); k != "" {
+					z.Keywords = append(z.Keywords, k)
+				}
+			}
+		}
+		zones = append(zones, z)
+	}
+	return zones, nil
+}
+
+// mergeDeliveryZones nomi bir xil hududlar almashtiriladi, yangilari oxiriga qo'shiladi
+func mergeDeliveryZones(current, incoming []deliveryZone) []deliveryZone {
+	out := append([]deliveryZone(nil), current...)
+	for _, z := range incoming {
+		replaced := false
+		for i := range out {
+			if strings.EqualFold(out[i].Name, z.Name) {
+				out[i] = z
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			out = append(out, z)
+		}
+	}
+	return out
+}
+
+func (h *BotHandler) storeDeliveryZones(zones []deliveryZone) error {
+	if err := saveDeliveryZonesFile(deliveryZonesFile, zones); err != nil {
+		return err
+	}
+	h.setDeliveryZones(zones)
+	return nil
+}
+
+// importDeliveryZones admin yuborgan .geojson faylidan hududlarni yuklaydi
+func (h *BotHandler) importDeliveryZones(chatID int64, doc *tgbotapi.Document) {
+	data, err := h.downloadFile(doc.FileID)
+	if err != nil {
+		log.Printf("geojson download error: %v", err)
+		h.sendMessage(chatID, "❌ Faylni yuklashda xatolik yuz berdi.")
+		return
+	}
+	zones, err := zonesFromGeoJSON(data)
+	if err != nil {
+		h.sendMessage(chatID, fmt.Sprintf("❌ GeoJSON o'qilmadi: %v", err))
+		return
+	}
+	if err := h.storeDeliveryZones(mergeDeliveryZones(h.getDeliveryZones(), zones)); err != nil {
+		log.Printf("delivery zones save failed: %v", err)
+		h.sendMessage(chatID, "❌ Hududlarni saqlashda xatolik.")
+		return
+	}
+	h.sendMessage(chatID, fmt.Sprintf("✅ %d ta hudud yuklandi.\n\n%s", len(zones), h.deliveryZonesText()))
+}
+
+func (h *BotHandler) deliveryZonesText() string {
+	zones := h.getDeliveryZones()
+	if len(zones) == 0 {
+		return "🗺 Yetkazish hududlari yo'q (Yandex Go, narxni operator aytadi)."
+	}
+	var sb strings.Builder
+	sb.WriteString("🗺 Yetkazish hududlari:\n")
+	for _, z := range zones {
+		shape := fmt.Sprintf("%d poligon", len(z.Polygons))
+		if len(z.Polygons) == 0 && z.Center != nil {
+			shape = fmt.Sprintf("%.1f km radius (%.5f,%.5f)", z.RadiusKm, z.Center.Lat, z.Center.Lon)
+		}
+		sb.WriteString(fmt.Sprintf("• %s - %s, narx %s", z.Name, shape, z.Fee))
+		if z.FreeFrom.Amount > 0 {
+			sb.WriteString(fmt.Sprintf(", %s dan bepul", z.FreeFrom))
+		}
+		if z.ETA != "" {
+			sb.WriteString(", " + z.ETA)
+		}
+		if len(z.Keywords) > 0 {
+			sb.WriteString(fmt.Sprintf(" [%s]", strings.Join(z.Keywords, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+const deliveryZonesUsage = `Foydalanish:
+/zone radius <nom> <lat>,<lon> <km> <narx> [bepul_chegara] - do'kon atrofidagi hudud
+/zone fee <nom> <narx> [bepul_chegara] - narxni o'zgartirish (masalan 30000 yoki 3$)
+/zone eta <nom> <matn> - taxminiy vaqt ("1-2 soat")
+/zone keywords <nom> <so'z,so'z> - matn manzil uchun kalit so'zlar
+/zone remove <nom> - o'chirish
+Poligonlar: .geojson faylni yuboring (xossalar: name, fee, free_from, eta, keywords).
+Bo'shliqli nomlar qo'shtirnoqda: "Yunusobod tumani"`
+
+// handleZoneCommand /zone - yetkazish hududlarini boshqarish
+func (h *BotHandler) handleZoneCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(chatID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+	args := splitPromoArgs(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(chatID, h.deliveryZonesText()+"\n"+deliveryZonesUsage)
+		return
+	}
+	if len(args) < 2 {
+		h.sendMessage(chatID, "❌ Noto'g'ri format.\n"+deliveryZonesUsage)
+		return
+	}
+
+	zones := h.getDeliveryZones()
+	name := args[1]
+	idx := -1
+	for i := range zones {
+		if strings.EqualFold(zones[i].Name, name) {
+			idx = i
+			break
+		}
+	}
+	needZone := func() bool {
+		if idx < 0 {
+			h.sendMessage(chatID, fmt.Sprintf("❌ %q hududi topilmadi.", name))
+			return false
+		}
+		return true
+	}
+	parseFees := func(rest []string, z *deliveryZone) bool {
+		fee, ok := parseZoneMoney(rest[0])
+		if !ok {
+			h.sendMessage(chatID, "❌ Noto'g'ri narx.\n"+deliveryZonesUsage)
+			return false
+		}
+		z.Fee = fee
+		z.FreeFrom = entity.Money{}
+		if len(rest) > 1 {
+			free, ok := parseZoneMoney(rest[1])
+			if !ok {
+				h.sendMessage(chatID, "❌ Noto'g'ri bepul chegara.\n"+deliveryZonesUsage)
+				return false
+			}
+			z.FreeFrom = free
+		}
+		return true
+	}
+
+	var reply string
+	switch strings.ToLower(args[0]) {
+	case "radius":
+		if len(args) < 5 {
+			h.sendMessage(chatID, "❌ Noto'g'ri format.\n"+deliveryZonesUsage)
+			return
+		}
+		center, okP := geo.ParsePoint(args[2])
+		km, errK := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(args[3]), "km"), 64)
+		if !okP || errK != nil || km <= 0 {
+			h.sendMessage(chatID, "❌ Noto'g'ri koordinata yoki radius.\n"+deliveryZonesUsage)
+			return
+		}
+		z := deliveryZone{Name: name, Center: &center, RadiusKm: km}
+		if idx >= 0 {
+			z.Keywords, z.ETA = zones[idx].Keywords, zones[idx].ETA
+		}
+		if !parseFees(args[4:], &z) {
+			return
+		}
+		zones = mergeDeliveryZones(zones, []deliveryZone{z})
+		reply = fmt.Sprintf("✅ %s hududi saqlandi.", name)
+	case "fee":
+		if len(args) < 3 || !needZone() {
+			if len(args) < 3 {
+				h.sendMessage(chatID, "❌ Noto'g'ri format.\n"+deliveryZonesUsage)
+			}
+			return
+		}
+		if !parseFees(args[2:], &zones[idx]) {
+			return
+		}
+		reply = fmt.Sprintf("✅ %s narxi: %s.", zones[idx].Name, zones[idx].Fee)
+	case "eta":
+		if !needZone() {
+			return
+		}
+		zones[idx].ETA = strings.TrimSpace(strings.Join(args[2:], " "))
+		reply = fmt.Sprintf("✅ %s taxminiy vaqti: %s.", zones[idx].Name, nonEmpty(zones[idx].ETA, "-"))
+	case "keywords":
+		if !needZone() {
+			return
+		}
+		zones[idx].Keywords = nil
+		for _, k := range strings.Split(strings.Join(args[2:], " "), ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				zones[idx].Keywords = append(zones[idx].Keywords, k)
+			}
+		}
+		reply = fmt.Sprintf("✅ %s kalit so'zlari yangilandi.", zones[idx].Name)
+	case "remove", "del":
+		if !needZone() {
+			return
+		}
+		zones = append(zones[:idx], zones[idx+1:]...)
+		reply = fmt.Sprintf("🗑 %s hududi o'chirildi.", name)
+	default:
+		h.sendMessage(chatID, deliveryZonesUsage)
+		return
+	}
+
+	if err := h.storeDeliveryZones(zones); err != nil {
+		log.Printf("delivery zones save failed: %v", err)
+		h.sendMessage(chatID, "❌ Hududlarni saqlashda xatolik.")
+		return
+	}
+	h.sendMessage(chatID, reply+"\n\n"+h.deliveryZonesText())
+}
@@ -0,0 +1,73 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// TestDeliveryZoneMatchAndFee - GeoJSON hududlari pin va matn manzilga moslanadi,
+// bepul yetkazish chegarasi va jami buyurtma formasida ko'rinadi
+func TestDeliveryZoneMatchAndFee(t *testing.T) {
+	zones, err := zonesFromGeoJSON([]byte(`{
+  "type": "FeatureCollection",
+  "features": [
+    {"type": "Feature", "properties": {"name": "Markaz", "fee": "5$", "free_from": "1000$", "eta": "1-2 soat"},
+     "geometry": {"type": "Polygon", "coordinates": [[[69.20, 41.28], [69.30, 41.28], [69.30, 41.34], [69.20, 41.34], [69.20, 41.28]]]}},
+    {"type": "Feature", "properties": {"name": "Chilonzor", "fee": 30000, "keywords": ["Qatortol"], "radius_km": 4},
+     "geometry": {"type": "Point", "coordinates": [69.20, 41.27]}}
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("zonesFromGeoJSON: %v", err)
+	}
+	if zones[1].Fee.String() != "30 000 so'm" {
+		t.Fatalf("fee = %s", zones[1].Fee)
+	}
+
+	if z, ok := matchDeliveryZone(zones, "https://www.google.com/maps?q=41.30000,69.25000"); !ok || z.Name != "Markaz" {
+		t.Fatalf("pin Markaz ga mos kelishi kerak: %+v %v", z, ok)
+	}
+	if z, ok := matchDeliveryZone(zones, "Qatortol ko'chasi 5-uy"); !ok || z.Name != "Chilonzor" {
+		t.Fatalf("matn manzil Chilonzor ga mos kelishi kerak: %+v %v", z, ok)
+	}
+	if _, ok := matchDeliveryZone(zones, "https://www.google.com/maps?q=39.65420,66.95970"); ok {
+		t.Fatalf("Samarqand hech bir hududga kirmaydi")
+	}
+
+	h := &BotHandler{}
+	h.setDeliveryZones(zones)
+	session := &orderSession{
+		ChatID:    7,
+		Location:  "https://www.google.com/maps?q=41.30000,69.25000",
+		ConfigTxt: "• CPU: Ryzen 5 - 400$\nOverall price: 400$",
+	}
+	q := h.quoteDelivery(session)
+	if q == nil || q.Fee.String() != "5.00$" || q.Total.String() != "405.00$" {
+		t.Fatalf("quote: %+v", q)
+	}
+	session.Zone = q
+	session.Delivery = "courier"
+	form := renderOrderForm(session, "en", "")
+	for _, want := range []string{"Zone: Markaz · delivery 5.00$", "Estimated delivery: 1-2 soat", "Total with delivery: 405.00$"} {
+		if !strings.Contains(form, want) {
+			t.Fatalf("%q yo'q:\n%s", want, form)
+		}
+	}
+
+	session.ConfigTxt = "• GPU: RTX 4080 - 1200$\nOverall price: 1200$"
+	if q := h.quoteDelivery(session); q == nil || !q.Free || !q.Fee.IsZero() || q.Total.String() != "1200.00$" {
+		t.Fatalf("1000$ dan oshganda bepul bo'lishi kerak: %+v", q)
+	}
+
+	// Buyurtma yozilayotganda chegirmali jami chegaradan tushsa, sessiyadagi bepul taklif amal qilmaydi
+	session.Zone = h.quoteDelivery(session)
+	q = h.checkoutDeliveryQuote(session, entity.Money{Amount: 95000, Currency: "USD"}, nil)
+	if q == nil || q.Free || q.Fee.String() != "5.00$" || q.Total.String() != "955.00$" {
+		t.Fatalf("chegirmali 950$ uchun yetkazish pullik bo'lishi kerak: %+v", q)
+	}
+	if q := h.checkoutDeliveryQuote(session, entity.Money{Amount: 110000, Currency: "USD"}, nil); q == nil || !q.Free {
+		t.Fatalf("chegirmali 1100$ uchun bepul bo'lishi kerak: %+v", q)
+	}
+}
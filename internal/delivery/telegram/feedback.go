@@ -13,21 +13,22 @@ func (h *BotHandler) sendConfigFeedbackPrompt(chatID int64, userID int64, offerI
 		offerID = newUUID()
 	}
 	lang := h.getUserLang(userID)
-	msg := tgbotapi.NewMessage(chatID, t(lang,
-		"✅ Konfiguratsiya tayyor!\n\nKonfiguratsiya yoqdimi? Savollaringiz bo'lsa menga yozishingiz mumkin va men darhol javob beraman. Agar juda zarur bo'lsa, @Ingame_support ga murojaat qiling 😊",
-		"✅ Конфигурация готова!\n\nПонравилась конфигурация? Если есть вопросы, можете написать мне и я сразу отвечу. Если очень нужно, обращайтесь к @Ingame_support 😊",
-	))
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "config.feedback.ready"))
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "✅ Zakaz beraman", "✅ Заказать"), "cfg_fb_yes|"+offerID),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "👎 Yo'q", "👎 Нет"), "cfg_fb_no|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.feedback.order"), "cfg_fb_yes|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.feedback.no"), "cfg_fb_no|"+offerID),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "📊 Analyze PC", "📊 Анализ ПК"), "cfg_analyze_pc|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.analyze_button"), "cfg_analyze_pc|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "optimizer.button"), "cfg_opt|"+offerID),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🔄 Komponentni almashtirish", "🔄 Заменить компонент"), "cfg_fb_change|"+offerID),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🗑️ Komponentni o'chirish", "🗑️ Удалить компонент"), "cfg_fb_delete|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.feedback.change"), "cfg_fb_change|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.feedback.delete"), "cfg_fb_delete|"+offerID),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "builds.save_button"), "bld_save|"+offerID),
 		),
 	)
 	if _, err := h.sendAndLog(msg); err != nil {
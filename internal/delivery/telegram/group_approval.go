@@ -125,15 +125,15 @@ func (h *BotHandler) handleGroupMessage(ctx context.Context, message *tgbotapi.M
 
 	lang := h.getUserLang(targetInfo.UserID)
 	adminMsg := fmt.Sprintf("%s\n%s",
-		t(lang, "🔔 Admindan javob:", "🔔 Ответ админа:"),
+		tr(lang, "approval.admin_reply"),
 		message.Text,
 	)
-	adminMsg = fmt.Sprintf("%s\n\n%s", adminMsg, t(lang, "Rasmiylashtiramizmi?", "Оформляем?"))
+	adminMsg = fmt.Sprintf("%s\n\n%s", adminMsg, tr(lang, "approval.confirm_prompt"))
 
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Ha ✅", "Да ✅"), "order_yes"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Yo'q ❌", "Нет ❌"), "order_no"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "approval.yes"), "order_yes"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "approval.no"), "order_no"),
 		),
 	)
 
@@ -154,7 +154,7 @@ func (h *BotHandler) handleGroupMessage(ctx context.Context, message *tgbotapi.M
 	})
 	h.clearGroup1PendingApproval(message.ReplyToMessage.MessageID)
 
-	h.sendMessage(message.Chat.ID, t(lang, "📨 Foydalanuvchiga yuborildi.", "📨 Отправлено пользователю."))
+	h.sendMessage(message.Chat.ID, tr(lang, "approval.sent_to_user"))
 }
 
 func extractOrderIDFromText(text string) string {
@@ -67,16 +67,13 @@ func (h *BotHandler) handleHisobotCommand(ctx context.Context, message *tgbotapi
 }
 
 func buildHisobotMenu(lang string) (string, *tgbotapi.InlineKeyboardMarkup) {
-	text := t(lang,
-		"📑 *Hisobot*\n\nQaysi turini ko'rmoqchisiz?",
-		"📑 *Отчёт*\n\nКакой тип отчёта показать?",
-	)
+	text := tr(lang, "report.menu")
 	kb := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "📅 Kunlik hisobot", "📅 Дневной отчёт"), "hisobot_mode|day|0"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "report.daily_button"), "hisobot_mode|day|0"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🗓 Oylik hisobot", "🗓 Месячный отчёт"), "hisobot_mode|month|0"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "report.monthly_button"), "hisobot_mode|month|0"),
 		),
 	)
 	return text, &kb
@@ -121,7 +118,7 @@ func (h *BotHandler) handleHisobotModeCallback(ctx context.Context, chatID int64
 		text, kb, err = h.buildHisobotMonthsList(ctx, lang, page)
 	}
 	if err != nil {
-		h.sendMessage(chatID, t(lang, "❌ Hisobotni tayyorlashda xatolik. Qayta urinib ko'ring.", "❌ Ошибка при формировании отчёта. Попробуйте ещё раз."))
+		h.sendMessage(chatID, tr(lang, "report.failed"))
 		return
 	}
 	h.editOrSendHisobotMessage(chatID, text, kb, srcMsg)
@@ -136,7 +133,7 @@ func (h *BotHandler) handleHisobotDayCallback(ctx context.Context, chatID int64,
 	lang := h.getUserLang(adminID)
 	text, kb, xlsxBytes, filename, caption, err := h.buildHisobotDayReportPayload(ctx, lang, date, page)
 	if err != nil {
-		h.sendMessage(chatID, t(lang, "❌ Sana noto'g'ri. Iltimos, qayta tanlang.", "❌ Неверная дата. Пожалуйста, выберите снова."))
+		h.sendMessage(chatID, tr(lang, "report.bad_date"))
 		return
 	}
 	h.editOrSendHisobotMessage(chatID, text, kb, srcMsg)
@@ -154,7 +151,7 @@ func (h *BotHandler) handleHisobotMonthCallback(ctx context.Context, chatID int6
 	lang := h.getUserLang(adminID)
 	text, kb, xlsxBytes, filename, caption, err := h.buildHisobotMonthReportPayload(ctx, lang, ym, page)
 	if err != nil {
-		h.sendMessage(chatID, t(lang, "❌ Oy formati noto'g'ri. Iltimos, qayta tanlang.", "❌ Неверный формат месяца. Пожалуйста, выберите снова."))
+		h.sendMessage(chatID, tr(lang, "report.bad_month"))
 		return
 	}
 	h.editOrSendHisobotMessage(chatID, text, kb, srcMsg)
@@ -191,10 +188,10 @@ func (h *BotHandler) buildHisobotDaysList(ctx context.Context, lang string, page
 		return "", nil, err
 	}
 	if len(days) == 0 {
-		text := t(lang, "📑 *Kunlik hisobot*\n\nHali hisobot mavjud kunlar yo'q.", "📑 *Дневной отчёт*\n\nПока нет дней с отчётом.")
+		text := tr(lang, "report.daily_empty")
 		kb := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "hisobot_menu"),
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "hisobot_menu"),
 			),
 		)
 		return text, &kb, nil
@@ -225,24 +222,24 @@ func (h *BotHandler) buildHisobotDaysList(ctx context.Context, lang string, page
 
 	var nav []tgbotapi.InlineKeyboardButton
 	if page < totalPages-1 {
-		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Eski", "⬅️ Старее"), fmt.Sprintf("hisobot_mode|day|%d", page+1)))
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(tr(lang, "report.older"), fmt.Sprintf("hisobot_mode|day|%d", page+1)))
 	}
 	if page > 0 {
-		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(t(lang, "Yangiroq ➡️", "Новее ➡️"), fmt.Sprintf("hisobot_mode|day|%d", page-1)))
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(tr(lang, "report.newer"), fmt.Sprintf("hisobot_mode|day|%d", page-1)))
 	}
 	if len(nav) > 0 {
 		rows = append(rows, nav)
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "hisobot_menu"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "hisobot_menu"),
 	))
 
 	kb := tgbotapi.NewInlineKeyboardMarkup(rows...)
 	text := fmt.Sprintf("%s\n%s\n\n%s (%d/%d)",
-		t(lang, "📑 *Kunlik hisobot*", "📑 *Дневной отчёт*"),
-		t(lang, "Qaysi sanani ko'rishni istaysiz?", "Какую дату показать?"),
-		t(lang, "Sahifa", "Страница"),
+		tr(lang, "report.daily_title"),
+		tr(lang, "report.pick_date"),
+		tr(lang, "report.page"),
 		page+1,
 		totalPages,
 	)
@@ -255,10 +252,10 @@ func (h *BotHandler) buildHisobotMonthsList(ctx context.Context, lang string, pa
 		return "", nil, err
 	}
 	if len(months) == 0 {
-		text := t(lang, "📑 *Oylik hisobot*\n\nHali hisobot mavjud oylar yo'q.", "📑 *Месячный отчёт*\n\nПока нет месяцев с отчётом.")
+		text := tr(lang, "report.monthly_empty")
 		kb := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "hisobot_menu"),
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "hisobot_menu"),
 			),
 		)
 		return text, &kb, nil
@@ -288,24 +285,24 @@ func (h *BotHandler) buildHisobotMonthsList(ctx context.Context, lang string, pa
 
 	var nav []tgbotapi.InlineKeyboardButton
 	if page < totalPages-1 {
-		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Eski", "⬅️ Старее"), fmt.Sprintf("hisobot_mode|month|%d", page+1)))
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(tr(lang, "report.older"), fmt.Sprintf("hisobot_mode|month|%d", page+1)))
 	}
 	if page > 0 {
-		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(t(lang, "Yangiroq ➡️", "Новее ➡️"), fmt.Sprintf("hisobot_mode|month|%d", page-1)))
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(tr(lang, "report.newer"), fmt.Sprintf("hisobot_mode|month|%d", page-1)))
 	}
 	if len(nav) > 0 {
 		rows = append(rows, nav)
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), "hisobot_menu"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), "hisobot_menu"),
 	))
 
 	kb := tgbotapi.NewInlineKeyboardMarkup(rows...)
 	text := fmt.Sprintf("%s\n%s\n\n%s (%d/%d)",
-		t(lang, "📑 *Oylik hisobot*", "📑 *Месячный отчёт*"),
-		t(lang, "Qaysi oyni ko'rishni istaysiz?", "Какой месяц показать?"),
-		t(lang, "Sahifa", "Страница"),
+		tr(lang, "report.monthly_title"),
+		tr(lang, "report.pick_month"),
+		tr(lang, "report.page"),
 		page+1,
 		totalPages,
 	)
@@ -328,19 +325,19 @@ func (h *BotHandler) buildHisobotDayReportPayload(ctx context.Context, lang stri
 	stats := computeHisobotStats(orders)
 
 	var sb strings.Builder
-	sb.WriteString(t(lang, "📑 *Kunlik hisobot*\n", "📑 *Дневной отчёт*\n"))
+	sb.WriteString(tr(lang, "report.daily_header"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString(fmt.Sprintf("📅 %s: *%s* (%s)\n\n", t(lang, "Sana", "Дата"), start.Format("2006-01-02"), hisobotTZName()))
-	sb.WriteString(fmt.Sprintf("🧩 %s: *%d*\n", t(lang, "Sotilgan komponentlar", "Продано компонентов"), stats.ComponentsSold))
-	sb.WriteString(fmt.Sprintf("🛒 %s: *%d*\n", t(lang, "Buyurtmalar", "Заказы"), stats.TotalOrders))
-	sb.WriteString(fmt.Sprintf("🟡 %s: *%d*\n", t(lang, "Active (processing/ready)", "Активные (processing/ready)"), stats.ActiveOrders))
-	sb.WriteString(fmt.Sprintf("🛠 %s: *%d*\n", t(lang, "Jarayonda (pickup/onway)", "В процессе (pickup/onway)"), stats.InProgress))
-	sb.WriteString(fmt.Sprintf("🏁 %s: *%d*\n", t(lang, "Yakunlangan (delivered)", "Завершённые (delivered)"), stats.Delivered))
-	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", t(lang, "Bekor qilingan", "Отменённые"), stats.Canceled))
+	sb.WriteString(fmt.Sprintf("📅 %s: *%s* (%s)\n\n", tr(lang, "report.date"), start.Format("2006-01-02"), hisobotTZName()))
+	sb.WriteString(fmt.Sprintf("🧩 %s: *%d*\n", tr(lang, "report.components_sold"), stats.ComponentsSold))
+	sb.WriteString(fmt.Sprintf("🛒 %s: *%d*\n", tr(lang, "report.orders"), stats.TotalOrders))
+	sb.WriteString(fmt.Sprintf("🟡 %s: *%d*\n", tr(lang, "report.active"), stats.ActiveOrders))
+	sb.WriteString(fmt.Sprintf("🛠 %s: *%d*\n", tr(lang, "report.in_progress"), stats.InProgress))
+	sb.WriteString(fmt.Sprintf("🏁 %s: *%d*\n", tr(lang, "report.delivered"), stats.Delivered))
+	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", tr(lang, "report.canceled"), stats.Canceled))
 
 	kb := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), fmt.Sprintf("hisobot_mode|day|%d", maxInt(page, 0))),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), fmt.Sprintf("hisobot_mode|day|%d", maxInt(page, 0))),
 		),
 	)
 	xlsxBytes, xlsxErr := buildHisobotXLSX(
@@ -383,19 +380,19 @@ func (h *BotHandler) buildHisobotMonthReportPayload(ctx context.Context, lang st
 	stats := computeHisobotStats(orders)
 
 	var sb strings.Builder
-	sb.WriteString(t(lang, "📑 *Oylik hisobot*\n", "📑 *Месячный отчёт*\n"))
+	sb.WriteString(tr(lang, "report.monthly_header"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString(fmt.Sprintf("🗓 %s: *%s* (%s)\n\n", t(lang, "Oy", "Месяц"), start.Format("2006-01"), hisobotTZName()))
-	sb.WriteString(fmt.Sprintf("🧩 %s: *%d*\n", t(lang, "Sotilgan komponentlar", "Продано компонентов"), stats.ComponentsSold))
-	sb.WriteString(fmt.Sprintf("🛒 %s: *%d*\n", t(lang, "Buyurtmalar", "Заказы"), stats.TotalOrders))
-	sb.WriteString(fmt.Sprintf("🟡 %s: *%d*\n", t(lang, "Active (processing/ready)", "Активные (processing/ready)"), stats.ActiveOrders))
-	sb.WriteString(fmt.Sprintf("🛠 %s: *%d*\n", t(lang, "Jarayonda (pickup/onway)", "В процессе (pickup/onway)"), stats.InProgress))
-	sb.WriteString(fmt.Sprintf("🏁 %s: *%d*\n", t(lang, "Yakunlangan (delivered)", "Завершённые (delivered)"), stats.Delivered))
-	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", t(lang, "Bekor qilingan", "Отменённые"), stats.Canceled))
+	sb.WriteString(fmt.Sprintf("🗓 %s: *%s* (%s)\n\n", tr(lang, "report.month"), start.Format("2006-01"), hisobotTZName()))
+	sb.WriteString(fmt.Sprintf("🧩 %s: *%d*\n", tr(lang, "report.components_sold"), stats.ComponentsSold))
+	sb.WriteString(fmt.Sprintf("🛒 %s: *%d*\n", tr(lang, "report.orders"), stats.TotalOrders))
+	sb.WriteString(fmt.Sprintf("🟡 %s: *%d*\n", tr(lang, "report.active"), stats.ActiveOrders))
+	sb.WriteString(fmt.Sprintf("🛠 %s: *%d*\n", tr(lang, "report.in_progress"), stats.InProgress))
+	sb.WriteString(fmt.Sprintf("🏁 %s: *%d*\n", tr(lang, "report.delivered"), stats.Delivered))
+	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", tr(lang, "report.canceled"), stats.Canceled))
 
 	kb := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "⬅️ Orqaga", "⬅️ Назад"), fmt.Sprintf("hisobot_mode|month|%d", maxInt(page, 0))),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), fmt.Sprintf("hisobot_mode|month|%d", maxInt(page, 0))),
 		),
 	)
 	xlsxBytes, xlsxErr := buildHisobotXLSX(
@@ -734,6 +731,11 @@ func buildHisobotXLSX(period string, stats hisobotStats, orders []orderStatusInf
 		"Location",
 		"Delivery",
 		"Total",
+		"Installment",
+		"Discount",
+		"DeliveryZone",
+		"DeliveryFee",
+		"Branch",
 		"ComponentsCount",
 		"Components",
 		"Summary",
@@ -765,6 +767,11 @@ func buildHisobotXLSX(period string, stats hisobotStats, orders []orderStatusInf
 			ord.Location,
 			ord.Delivery,
 			ord.Total,
+			ord.Installment,
+			promoDiscountLabel(ord.Lines, ord.PromoCode),
+			ord.DeliveryZone,
+			deliveryFeeCell(ord),
+			ord.Branch,
 			len(components),
 			strings.Join(components, ", "),
 			strings.TrimSpace(ord.Summary),
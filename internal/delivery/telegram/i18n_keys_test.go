@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
+)
+
+var trKeyPattern = regexp.MustCompile(`\btr(?:Plural)?\([^,]+,\s*"([a-z0-9_.]+)"`)
+
+// TestI18nKeysExist - kodda ishlatilgan har bir tr/trPlural kaliti barcha
+// kataloglarda mavjud bo'lishi kerak.
+func TestI18nKeysExist(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := map[string]string{}
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range trKeyPattern.FindAllStringSubmatch(string(data), -1) {
+			if strings.HasSuffix(m[1], ".") {
+				continue // "order.status."+status kabi dinamik prefiks
+			}
+			used[m[1]] = f
+		}
+	}
+	// Dinamik kalitlar
+	for flow := range conversationFlows {
+		used["conv.flow."+string(flow)] = "conversation_state.go"
+	}
+	for _, status := range []string{"processing", "ready_delivery", "ready_pickup", "onway", "delivered", "canceled", "unknown"} {
+		used["order.status."+status] = "order_status.go"
+		if status != "unknown" {
+			used["order.push."+status] = "order_tracking.go"
+		}
+	}
+	for _, status := range []string{"unpaid", "paid", "refunded"} {
+		used["payment.status."+status] = "payments.go"
+	}
+	for _, kind := range []string{"monitor", "keyboard", "mouse", "headset"} {
+		used["peripherals.kind."+kind] = "peripheral_advisor.go"
+	}
+	for _, miss := range []string{"connection", "switch", "dpi", "color", "hz", "panel"} {
+		used["peripherals.miss."+miss] = "peripheral_advisor.go"
+	}
+	for _, kind := range []string{"laptop", "prebuilt"} {
+		used["laptop.header."+kind] = "system_advisor.go"
+		used["laptop.none."+kind] = "system_advisor.go"
+	}
+	for _, miss := range []string{"weight", "ram", "screen"} {
+		used["laptop.miss."+miss] = "system_advisor.go"
+	}
+	for _, row := range []string{"cpu", "gpu", "ram", "storage", "screen", "weight", "battery", "price", "score"} {
+		used["laptop.row."+row] = "system_advisor.go"
+	}
+	if len(used) == 0 {
+		t.Fatalf("hech qanday kalit topilmadi")
+	}
+
+	for _, lang := range i18n.Supported {
+		cat, ok := i18n.Default().Catalog(lang)
+		if !ok {
+			t.Fatalf("%s katalogi yo'q", lang)
+		}
+		for key, file := range used {
+			if _, ok := cat[key]; !ok {
+				t.Errorf("%s: %q kaliti katalogda yo'q (%s)", lang, key, file)
+			}
+		}
+	}
+}
+
+// TestCustomerTextsInEnglish - ingliz tilidagi mijoz matnlari ruschaga tushib qolmasligi kerak
+func TestCustomerTextsInEnglish(t *testing.T) {
+	cases := []struct{ got, want string }{
+		{deliveryDisplay("pickup", "en"), "Pickup"},
+		{deliveryDisplay("yandex_go", "en"), "Delivery (Yandex Go)"},
+		{formatSelectedProductReply("RTX 4060", "en"), "✅ In stock: RTX 4060"},
+		{formatAgo(3*time.Hour, "en"), "3 h"},
+		{tr("en", "delivery.choice.prompt"), "How would you like to receive your order?"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("got %q, want %q", c.got, c.want)
+		}
+	}
+}
@@ -60,6 +60,7 @@ func (h *BotHandler) stopImportAutoLocked() {
 }
 
 func (h *BotHandler) beginImportAutoInput(userID, chatID int64) {
+	h.enterConversation(userID, convFlowImportAuto, "need_interval", chatID)
 	h.importAutoMu.Lock()
 	h.importAutoInput[userID] = &importAutoInputState{chatID: chatID}
 	h.importAutoMu.Unlock()
@@ -69,6 +70,7 @@ func (h *BotHandler) clearImportAutoInput(userID int64) {
 	h.importAutoMu.Lock()
 	delete(h.importAutoInput, userID)
 	h.importAutoMu.Unlock()
+	h.leaveConversation(userID, convFlowImportAuto)
 }
 
 func (h *BotHandler) isAwaitingImportAutoInput(userID int64) bool {
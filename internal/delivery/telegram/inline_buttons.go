@@ -40,7 +40,7 @@ func (h *BotHandler) keepAnalyzePCButtonOnly(cq *tgbotapi.CallbackQuery, offerID
 	if cq == nil || cq.Message == nil || cq.Message.Chat == nil {
 		return
 	}
-	btn := tgbotapi.NewInlineKeyboardButtonData(t(lang, "📊 Analyze PC", "📊 Анализ ПК"), "cfg_analyze_pc|"+offerID)
+	btn := tgbotapi.NewInlineKeyboardButtonData(tr(lang, "config.analyze_button"), "cfg_analyze_pc|"+offerID)
 	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(btn))
 	edit := tgbotapi.NewEditMessageReplyMarkup(cq.Message.Chat.ID, cq.Message.MessageID, markup)
 	if _, err := h.bot.Request(edit); err != nil {
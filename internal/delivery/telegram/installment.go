@@ -0,0 +1,394 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Muddatli to'lov (nasiya) rejalari: admin sozlaydi, mijoz konfiguratsiya/savatda
+// kalkulyatorni ko'radi va checkout paytida rejani tanlaydi.
+
+const (
+	installmentPlansFile = "data/installment_plans.json"
+	maxInstallmentMonths = 36
+)
+
+// installmentPlan bitta reja; muddat (oy) reja identifikatori ham
+type installmentPlan struct {
+	Months        int     `json:"months"`
+	MarkupPercent float64 `json:"markup_percent"`
+	DownPercent   float64 `json:"down_percent"`
+}
+
+type installmentSettings struct {
+	Plans []installmentPlan `json:"plans"`
+}
+
+// installmentQuote reja bo'yicha hisob-kitob (jami summa valyutasida)
+type installmentQuote struct {
+	Plan    installmentPlan
+	Total   entity.Money // ustama bilan
+	Down    entity.Money
+	Monthly entity.Money
+}
+
+func (h *BotHandler) loadInstallmentPlansFromDisk() {
+	b, err := os.ReadFile(installmentPlansFile)
+	if err != nil {
+		return
+	}
+	var cfg installmentSettings
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Printf("installment plans parse failed: %v", err)
+		return
+	}
+	h.setInstallmentPlans(cfg.Plans)
+}
+
+func saveInstallmentPlansFile(path string, plans []installmentPlan) error {
+	dir := filepath.Dir(path)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(installmentSettings{Plans: plans}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+func (h *BotHandler) setInstallmentPlans(plans []installmentPlan) {
+	clean := make([]installmentPlan, 0, len(plans))
+	for _, p := range plans {
+		if validInstallmentPlan(p) {
+			clean = append(clean, p)
+		}
+	}
+	sort.Slice(clean, func(i, j int) bool { return clean[i].Months < clean[j].Months })
+	h.installmentMu.Lock()
+	h.installmentPlans = clean
+	h.installmentMu.Unlock()
+}
+
+func (h *BotHandler) getInstallmentPlans() []installmentPlan {
+	h.installmentMu.RLock()
+	defer h.installmentMu.RUnlock()
+	return append([]installmentPlan(nil), h.installmentPlans...)
+}
+
+func (h *BotHandler) findInstallmentPlan(months int) (installmentPlan, bool) {
+	for _, p := range h.getInstallmentPlans() {
+		if p.Months == months {
+			return p, true
+		}
+	}
+	return installmentPlan{}, false
+}
+
+func validInstallmentPlan(p installmentPlan) bool {
+	return p.Months > 0 && p.Months <= maxInstallmentMonths &&
+		p.MarkupPercent >= 0 && p.MarkupPercent <= 100 &&
+		p.DownPercent >= 0 && p.DownPercent < 100
+}
+
+// parseTotalAmount matndagi birinchi narxni (summa, valyuta) ko'rinishida qaytaradi
+func parseTotalAmount(total string) (float64, string, bool) {
+	for _, m := range priceWithCurrencyRegex.FindAllString(total, -1) {
+		if c := canonicalCurrencyFromMatch(m); c != "" {
+			if v, ok := parseAmountFromPriceMatch(m); ok && v > 0 {
+				return v, c, true
+			}
+		}
+	}
+	return 0, "", false
+}
+
+// quoteInstallment ustama jami summaga qo'shiladi, boshlang'ich to'lov undan olinadi,
+// qolgani oylarga teng bo'linadi. Hammasi Money bilan, valyuta qoidasi bo'yicha yaxlitlanadi:
+// ko'rsatilgan boshlang'ich to'lov to'lovga yuboriladigan installmentDownPayment bilan bir xil
+func quoteInstallment(plan installmentPlan, amount entity.Money) installmentQuote {
+	total := amount.Mul(1 + plan.MarkupPercent/100)
+	down := installmentDownPayment(plan, amount)
+	rest := entity.Money{Amount: total.Amount - down.Amount, Currency: total.Currency}
+	return installmentQuote{
+		Plan:    plan,
+		Total:   total,
+		Down:    down,
+		Monthly: rest.Mul(1 / float64(plan.Months)),
+	}
+}
+
+// installmentDownPayment buyurtma jamidan (ustama bilan) boshlang'ich to'lov
+func installmentDownPayment(plan installmentPlan, total entity.Money) entity.Money {
+	return total.Mul((1 + plan.MarkupPercent/100) * plan.DownPercent / 100)
+}
+
+func (h *BotHandler) formatInstallmentAmount(m entity.Money) string {
+	if m.Currency == entity.CurrencyUSD {
+		return h.formatTotalForDisplay(m.String())
+	}
+	return m.String()
+}
+
+// installmentQuotes jami summa uchun barcha rejalar hisobi (reja yoki narx bo'lmasa nil)
+func (h *BotHandler) installmentQuotes(total string) []installmentQuote {
+	plans := h.getInstallmentPlans()
+	if len(plans) == 0 {
+		return nil
+	}
+	amount, ok := parseTotalMoney(total)
+	if !ok {
+		return nil
+	}
+	quotes := make([]installmentQuote, 0, len(plans))
+	for _, p := range plans {
+		quotes = append(quotes, quoteInstallment(p, amount))
+	}
+	return quotes
+}
+
+// installmentCalculatorText konfiguratsiya/savat ostida ko'rsatiladigan kalkulyator
+func (h *BotHandler) installmentCalculatorText(lang, total string) string {
+	quotes := h.installmentQuotes(total)
+	if len(quotes) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "installment.calc_header", "total", strings.TrimSpace(total)))
+	for _, q := range quotes {
+		sb.WriteString("\n")
+		sb.WriteString(tr(lang, "installment.calc_line",
+			"months", q.Plan.Months,
+			"down", h.formatInstallmentAmount(q.Down),
+			"monthly", h.formatInstallmentAmount(q.Monthly),
+			"total", h.formatInstallmentAmount(q.Total),
+		))
+	}
+	return sb.String()
+}
+
+func (h *BotHandler) sendInstallmentCalculator(chatID int64, lang, total string) {
+	if text := h.installmentCalculatorText(lang, total); text != "" {
+		h.sendMessage(chatID, text)
+	}
+}
+
+// cartInstallmentTotal savatdagi barcha mahsulotlar jami narxi (kalkulyator uchun)
+func cartInstallmentTotal(items []cartItem) string {
+	var texts []string
+	for _, it := range items {
+		texts = append(texts, nonEmpty(strings.TrimSpace(it.Text), it.Title))
+	}
+	return sumPriceLines(strings.Join(texts, "\n"))
+}
+
+// installmentAdminLabel admin xabarlari, /hisobot va order store uchun
+func (h *BotHandler) installmentAdminLabel(plan installmentPlan, total string) string {
+	label := fmt.Sprintf("%d oy, ustama %s%%, boshlang'ich %s%%", plan.Months, formatPercent(plan.MarkupPercent), formatPercent(plan.DownPercent))
+	amount, ok := parseTotalMoney(total)
+	if !ok {
+		return label
+	}
+	q := quoteInstallment(plan, amount)
+	return fmt.Sprintf("%s: boshlang'ich %s, oyiga %s, jami %s", label,
+		h.formatInstallmentAmount(q.Down),
+		h.formatInstallmentAmount(q.Monthly),
+		h.formatInstallmentAmount(q.Total),
+	)
+}
+
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// orderSessionTotal checkout davomida rejalarni hisoblash uchun taxminiy jami
+func orderSessionTotal(session *orderSession) string {
+	for _, text := range []string{session.ConfigTxt, session.Summary} {
+		if total := extractTotalPrice(text); total != "" {
+			return total
+		}
+		if total := sumPriceLines(text); total != "" {
+			return total
+		}
+	}
+	return ""
+}
+
+// needsInstallmentChoice - rejalar bor va jami aniq bo'lsa checkout'da tanlov so'raladi
+func (h *BotHandler) needsInstallmentChoice(session *orderSession) bool {
+	return len(h.installmentQuotes(h.checkoutTotal(session))) > 0
+}
+
+func (h *BotHandler) installmentChoiceKeyboard(lang string, session *orderSession) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(tr(lang, "installment.full_button"), "inst|0")),
+	}
+	for _, q := range h.installmentQuotes(h.checkoutTotal(session)) {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			tr(lang, "installment.plan_button", "months", q.Plan.Months, "monthly", h.formatInstallmentAmount(q.Monthly)),
+			fmt.Sprintf("inst|%d", q.Plan.Months),
+		)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func (h *BotHandler) sendInstallmentChoice(userID int64) {
+	h.orderMu.RLock()
+	session, ok := h.orderSessions[userID]
+	h.orderMu.RUnlock()
+	if !ok {
+		return
+	}
+	lang := h.getUserLang(userID)
+	kb := h.installmentChoiceKeyboard(lang, session)
+	h.sendOrderForm(userID, tr(lang, "installment.choose"), &kb)
+}
+
+// handleInstallmentCallback inst|<oy> - checkout'da reja tanlash (0 - to'liq to'lov)
+func (h *BotHandler) handleInstallmentCallback(userID, chatID int64, data string) {
+	lang := h.getUserLang(userID)
+	months, err := strconv.Atoi(strings.TrimPrefix(data, "inst|"))
+	if err != nil || months < 0 {
+		return
+	}
+	var plan installmentPlan
+	if months > 0 {
+		p, ok := h.findInstallmentPlan(months)
+		if !ok {
+			h.sendMessage(chatID, tr(lang, "installment.unavailable"))
+			h.sendInstallmentChoice(userID)
+			return
+		}
+		plan = p
+	}
+
+	h.orderMu.Lock()
+	session, ok := h.orderSessions[userID]
+	if ok {
+		if session.Stage != orderStageNeedInstallment {
+			h.orderMu.Unlock()
+			return
+		}
+		session.Installment = plan
+		session.Stage = orderStageNeedDeliveryChoice
+		h.orderSessions[userID] = session
+	}
+	h.orderMu.Unlock()
+	if !ok {
+		h.sendMessage(chatID, "Buyurtma ma'lumotlari topilmadi. /configuratsiya ni qayta bosing.")
+		return
+	}
+	h.sendDeliveryChoiceForm(userID)
+}
+
+// sendDeliveryChoiceForm order formasida yetkazish usulini so'raydi
+func (h *BotHandler) sendDeliveryChoiceForm(userID int64) {
+	lang := h.getUserLang(userID)
+	kb := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "delivery.choice.pickup"), "delivery_pickup"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "delivery.choice.courier"), "delivery_courier"),
+		),
+	)
+	h.sendOrderForm(userID, tr(lang, "delivery.choice.prompt"), &kb)
+}
+
+// handleInstallmentCommand /installment [add <oy> <ustama%> <boshlang'ich%> | remove <oy>]
+func (h *BotHandler) handleInstallmentCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+	usage := "Foydalanish:\n/installment add 12 15 20 - 12 oy, 15% ustama, 20% boshlang'ich to'lov\n/installment remove 12 - rejani o'chirish"
+	args := strings.Fields(message.CommandArguments())
+	if len(args) == 0 {
+		plans := h.getInstallmentPlans()
+		if len(plans) == 0 {
+			h.sendMessage(message.Chat.ID, "💳 Muddatli to'lov rejalari yo'q.\n\n"+usage)
+			return
+		}
+		var sb strings.Builder
+		sb.WriteString("💳 Muddatli to'lov rejalari:\n")
+		for _, p := range plans {
+			sb.WriteString(fmt.Sprintf("• %d oy - ustama %s%%, boshlang'ich %s%%\n", p.Months, formatPercent(p.MarkupPercent), formatPercent(p.DownPercent)))
+		}
+		sb.WriteString("\n" + usage)
+		h.sendMessage(message.Chat.ID, sb.String())
+		return
+	}
+
+	plans := h.getInstallmentPlans()
+	var reply string
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) != 4 {
+			h.sendMessage(message.Chat.ID, "❌ Noto'g'ri format.\n"+usage)
+			return
+		}
+		months, errM := strconv.Atoi(args[1])
+		markup, errP := strconv.ParseFloat(strings.TrimSuffix(args[2], "%"), 64)
+		down, errD := strconv.ParseFloat(strings.TrimSuffix(args[3], "%"), 64)
+		plan := installmentPlan{Months: months, MarkupPercent: markup, DownPercent: down}
+		if errM != nil || errP != nil || errD != nil || !validInstallmentPlan(plan) {
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Noto'g'ri qiymat. Muddat 1-%d oy, ustama 0-100%%, boshlang'ich 0-99%%.", maxInstallmentMonths))
+			return
+		}
+		replaced := false
+		for i := range plans {
+			if plans[i].Months == months {
+				plans[i] = plan
+				replaced = true
+			}
+		}
+		if !replaced {
+			plans = append(plans, plan)
+		}
+		reply = fmt.Sprintf("✅ Reja saqlandi: %d oy, ustama %s%%, boshlang'ich %s%%.", months, formatPercent(markup), formatPercent(down))
+	case "remove", "del":
+		if len(args) != 2 {
+			h.sendMessage(message.Chat.ID, "❌ Noto'g'ri format.\n"+usage)
+			return
+		}
+		months, err := strconv.Atoi(args[1])
+		if err != nil {
+			h.sendMessage(message.Chat.ID, "❌ Noto'g'ri muddat.\n"+usage)
+			return
+		}
+		kept := plans[:0]
+		for _, p := range plans {
+			if p.Months != months {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == len(plans) {
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ %d oylik reja topilmadi.", months))
+			return
+		}
+		plans = kept
+		reply = fmt.Sprintf("🗑️ %d oylik reja o'chirildi.", months)
+	default:
+		h.sendMessage(message.Chat.ID, usage)
+		return
+	}
+
+	if err := saveInstallmentPlansFile(installmentPlansFile, plans); err != nil {
+		log.Printf("installment plans save failed: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Sozlamani saqlashda xatolik yuz berdi.")
+		return
+	}
+	h.setInstallmentPlans(plans)
+	h.sendMessage(message.Chat.ID, reply)
+}
@@ -0,0 +1,57 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// TestInstallmentQuoteAndCheckoutChoice - kalkulyator ustama/boshlang'ich to'lovni hisoblaydi,
+// checkout'da tanlangan reja sessiyaga yoziladi va keyin yetkazish so'raladi
+func TestInstallmentQuoteAndCheckoutChoice(t *testing.T) {
+	h := &BotHandler{
+		userLang:      map[int64]string{7: "en"},
+		orderSessions: make(map[int64]*orderSession),
+	}
+	h.setInstallmentPlans([]installmentPlan{
+		{Months: 12, MarkupPercent: 10, DownPercent: 20},
+		{Months: 6, MarkupPercent: 5, DownPercent: 0},
+		{Months: 0, MarkupPercent: 5}, // noto'g'ri reja tashlab yuboriladi
+	})
+	if plans := h.getInstallmentPlans(); len(plans) != 2 || plans[0].Months != 6 {
+		t.Fatalf("rejalar: %+v", plans)
+	}
+
+	label := h.installmentAdminLabel(installmentPlan{Months: 12, MarkupPercent: 10, DownPercent: 20}, "1200$")
+	for _, want := range []string{"boshlang'ich 264.00$", "oyiga 88.00$", "jami 1320.00$"} {
+		if !strings.Contains(label, want) {
+			t.Fatalf("%q yo'q: %s", want, label)
+		}
+	}
+	if calc := h.installmentCalculatorText("en", "1200$"); !strings.Contains(calc, "12 mo: 264.00$ down, 88.00$/month") {
+		t.Fatalf("kalkulyator:\n%s", calc)
+	}
+	// Ko'rsatilgan boshlang'ich to'lov to'lovga yuboriladigan summa bilan bir xil (butun so'm)
+	plan := installmentPlan{Months: 12, MarkupPercent: 7.5, DownPercent: 15}
+	total := entity.NewMoney(15432117, entity.CurrencyUZS)
+	if q := quoteInstallment(plan, total); q.Down != installmentDownPayment(plan, total) || q.Down.String() != "2 488 429 so'm" {
+		t.Fatalf("boshlang'ich: %s, to'lov %s", q.Down, installmentDownPayment(plan, total))
+	}
+	charged := installmentDownPayment(installmentPlan{Months: 12, MarkupPercent: 10, DownPercent: 20}, total)
+	if calc := h.installmentCalculatorText("en", "15 432 117 so'm"); !strings.Contains(calc, "12 mo: "+charged.String()+" down") {
+		t.Fatalf("so'mdagi kalkulyator:\n%s", calc)
+	}
+
+	session := &orderSession{Stage: orderStageNeedLocation, ChatID: 7, ConfigTxt: "• CPU: Ryzen 5 - 1200$\nOverall price: 1200$"}
+	if !h.needsInstallmentChoice(session) {
+		t.Fatalf("rejalar bor, tanlov so'ralishi kerak")
+	}
+	session.Stage = orderStageNeedInstallment
+	h.orderSessions[7] = session
+	h.handleInstallmentCallback(7, 7, "inst|12")
+	got := h.orderSessions[7]
+	if got.Installment.Months != 12 || got.Stage != orderStageNeedDeliveryChoice {
+		t.Fatalf("sessiya: months=%d stage=%s", got.Installment.Months, got.Stage)
+	}
+}
@@ -1,22 +1,59 @@
 package telegram
 
+import (
+	"context"
+	"log"
+
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
+)
+
+// localeOverridesDir - admin tomonidan tahrirlangan kataloglar (<lang>.json),
+// embedded kataloglar ustiga yuklanadi
+const localeOverridesDir = "data/locales"
+
+func loadLocaleOverridesFromDisk() {
+	n, err := i18n.Default().LoadOverridesDir(localeOverridesDir)
+	if err != nil {
+		log.Printf("[i18n] locale overrides load failed dir=%s err=%v", localeOverridesDir, err)
+		return
+	}
+	if n > 0 {
+		log.Printf("[i18n] %d ta locale override yuklandi (%s)", n, localeOverridesDir)
+	}
+}
+
 // Language helpers
 func (h *BotHandler) setUserLang(userID int64, lang string) {
+	lang = i18n.Normalize(lang)
 	h.langMu.Lock()
-	defer h.langMu.Unlock()
-	if lang != "ru" {
-		lang = "uz"
-	}
+	prev, existed := h.userLang[userID]
 	h.userLang[userID] = lang
+	h.langMu.Unlock()
+	if existed && prev == lang {
+		return
+	}
+	h.persistUser("lang", func(ctx context.Context, store UserStore) error {
+		return store.SetLang(ctx, userID, lang)
+	})
+}
+
+// hasUserLang user tilni o'zi tanlaganmi (yoki avval aniqlanganmi)
+func (h *BotHandler) hasUserLang(userID int64) bool {
+	h.ensureUserLoaded(userID)
+	h.langMu.RLock()
+	defer h.langMu.RUnlock()
+	_, ok := h.userLang[userID]
+	return ok
 }
 
 func (h *BotHandler) getUserLang(userID int64) string {
+	h.ensureUserLoaded(userID)
 	h.langMu.RLock()
 	defer h.langMu.RUnlock()
 	if lang, ok := h.userLang[userID]; ok {
 		return lang
 	}
-	return "uz"
+	return i18n.DefaultLang
 }
 
 // Cache helper methods for worker pool
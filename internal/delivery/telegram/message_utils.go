@@ -11,18 +11,18 @@ import (
 // formatAgo - vaqt farqini foydalanuvchiga qulay ko'rinishda qaytarish
 func formatAgo(d time.Duration, lang string) string {
 	if d < time.Minute {
-		return t(lang, "hozir", "только что")
+		return tr(lang, "time.ago.now")
 	}
 	if d < time.Hour {
 		min := int(d.Minutes())
-		return fmt.Sprintf("%d %s", min, t(lang, "daq.", "мин"))
+		return fmt.Sprintf("%d %s", min, tr(lang, "time.ago.minutes"))
 	}
 	if d < 24*time.Hour {
 		h := int(d.Hours())
-		return fmt.Sprintf("%d %s", h, t(lang, "soat", "ч"))
+		return fmt.Sprintf("%d %s", h, tr(lang, "time.ago.hours"))
 	}
 	day := int(d.Hours() / 24)
-	return fmt.Sprintf("%d %s", day, t(lang, "kun", "д"))
+	return fmt.Sprintf("%d %s", day, tr(lang, "time.ago.days"))
 }
 
 // formatClock - oxirgi aktiv vaqtni "15:04" yoki sana bilan qaytaradi
@@ -0,0 +1,445 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Mijoz tomonidan buyurtmani bekor qilish / manzilni o'zgartirish / olib ketish <-> yetkazish.
+// Faqat "processing" holatida va grace window ichida; undan keyin faqat admin o'zgartiradi.
+
+const (
+	orderSettingsFile         = "data/order_settings.json"
+	defaultOrderGraceInterval = 15 * time.Minute
+	maxOrderGraceInterval     = 24 * time.Hour
+
+	// orderEventEdit - mijoz o'zgartirishi (Note - nima o'zgargani)
+	orderEventEdit = "edit"
+	// orderNoteByCustomer - holat yozuvi mijoz tomonidan qilinganini bildiradi
+	orderNoteByCustomer = "customer"
+)
+
+type orderSettings struct {
+	GraceMinutes *int `json:"grace_minutes,omitempty"`
+}
+
+func (h *BotHandler) loadOrderSettingsFromDisk() {
+	b, err := os.ReadFile(orderSettingsFile)
+	if err != nil {
+		return
+	}
+	var cfg orderSettings
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Printf("order settings parse failed: %v", err)
+		return
+	}
+	if cfg.GraceMinutes != nil && *cfg.GraceMinutes >= 0 {
+		h.orderGraceMu.Lock()
+		h.orderGrace = time.Duration(*cfg.GraceMinutes) * time.Minute
+		h.orderGraceSet = true
+		h.orderGraceMu.Unlock()
+	}
+}
+
+func saveOrderSettingsFile(path string, cfg orderSettings) error {
+	dir := filepath.Dir(path)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+// orderGraceWindow mijoz buyurtmani o'zgartira oladigan vaqt
+// (data/order_settings.json > ORDER_GRACE_MINUTES > 15 daqiqa)
+func (h *BotHandler) orderGraceWindow() time.Duration {
+	h.orderGraceMu.RLock()
+	grace, set := h.orderGrace, h.orderGraceSet
+	h.orderGraceMu.RUnlock()
+	if set {
+		return grace
+	}
+	if raw := strings.TrimSpace(os.Getenv("ORDER_GRACE_MINUTES")); raw != "" {
+		if mins, err := strconv.Atoi(raw); err == nil && mins >= 0 {
+			return time.Duration(mins) * time.Minute
+		}
+	}
+	return defaultOrderGraceInterval
+}
+
+// customerCanModifyOrder mijoz buyurtmani hali o'zgartira oladimi
+func customerCanModifyOrder(info orderStatusInfo, grace time.Duration, now time.Time) bool {
+	if info.Status != "processing" {
+		return false
+	}
+	if info.CreatedAt.IsZero() {
+		return false
+	}
+	return now.Sub(info.CreatedAt) <= grace
+}
+
+// customerOrderActionRows /myorders tafsilotidagi mijoz tugmalari
+func (h *BotHandler) customerOrderActionRows(info orderStatusInfo, lang string, page int) [][]tgbotapi.InlineKeyboardButton {
+	if !customerCanModifyOrder(info, h.orderGraceWindow(), time.Now()) {
+		return nil
+	}
+	switchLabel := tr(lang, "order.change.to_pickup")
+	if strings.EqualFold(info.Delivery, "pickup") {
+		switchLabel = tr(lang, "order.change.to_delivery")
+	}
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "order.change.address_button"), fmt.Sprintf("myord|addr|%s|%d", info.OrderID, page)),
+			tgbotapi.NewInlineKeyboardButtonData(switchLabel, fmt.Sprintf("myord|switch|%s|%d", info.OrderID, page)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "order.change.cancel_button"), fmt.Sprintf("myord|cancel|%s|%d", info.OrderID, page)),
+		),
+	}
+	return rows
+}
+
+// loadCustomerOrder egasi va grace window'ni tekshiradi; xato bo'lsa userga yozadi
+func (h *BotHandler) loadCustomerOrder(chatID, userID int64, orderID string) (orderStatusInfo, bool) {
+	lang := h.getUserLang(userID)
+	info, ok := h.getOrderStatus(orderID)
+	if !ok || info.UserID != userID {
+		h.sendMessage(chatID, tr(lang, "myorders.not_found"))
+		return orderStatusInfo{}, false
+	}
+	if !customerCanModifyOrder(info, h.orderGraceWindow(), time.Now()) {
+		h.sendMessage(chatID, tr(lang, "order.change.locked"))
+		return orderStatusInfo{}, false
+	}
+	return info, true
+}
+
+// cancelOrderByCustomer buyurtmani bekor qiladi va zaxirani omborga qaytaradi
+func (h *BotHandler) cancelOrderByCustomer(chatID, userID int64, orderID string) bool {
+	info, ok := h.loadCustomerOrder(chatID, userID, orderID)
+	if !ok {
+		return false
+	}
+	lang := h.getUserLang(userID)
+
+	// Admin shu orada holatni o'zgartirgan bo'lsa bekor qilinmaydi va zaxira qaytarilmaydi
+	if !h.compareAndSetOrderStatus(orderID, "processing", "canceled", orderNoteByCustomer) {
+		h.sendMessage(chatID, tr(lang, "order.change.locked"))
+		return false
+	}
+	h.restockOrderItems(info)
+	h.releasePromoUses(orderPromoIDs(info.Lines))
+
+	notice := fmt.Sprintf("⚠️ Mijoz buyurtmani bekor qildi\nOrderID: %s\nUsername: @%s\nTelefon: %s\nJami: %s\n\n%s",
+		orderID,
+		nonEmpty(info.Username, "nomalum"),
+		nonEmpty(info.Phone, "ko'rsatilmagan"),
+		nonEmpty(h.formatOrderTotal(info), "-"),
+		nonEmpty(info.Summary, info.StatusSummary),
+	)
+	if info.PaymentStatus == "paid" {
+		notice += fmt.Sprintf("\n\n💳 Buyurtma to'langan — pulni qaytaring: /refund %s", orderID)
+	}
+	// Active orderdagi "Tayyor/Bekor" tugmalarini olib tashlaymiz
+	if info.ActiveChatID != 0 && info.ActiveMessageID != 0 {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(info.ActiveChatID, info.ActiveMessageID, notice, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+		if _, err := h.sendAndLog(edit); err != nil {
+			log.Printf("customer cancel edit failed order=%s err=%v", orderID, err)
+			h.notifyOrderChannel(info, notice)
+		}
+	} else {
+		h.notifyOrderChannel(info, notice)
+	}
+
+	h.sendMessage(chatID, tr(lang, "order.change.canceled", "order_id", orderID))
+	return true
+}
+
+// restockOrderItems buyurtma uchun ayirilgan mahsulotlarni omborga qaytaradi
+// (sendOrderToGroup2 dagi syncInventoryAfterOrder/rezerv bilan bir xil ro'yxat)
+func (h *BotHandler) restockOrderItems(info orderStatusInfo) {
+	items := extractOrderItemNames(info.Summary)
+	if len(items) == 0 {
+		items = extractConfigItemNames(info.Summary)
+	}
+	if len(items) == 0 && strings.TrimSpace(info.Config) != "" {
+		items = extractConfigItemNames(info.Config)
+	}
+	if len(items) == 0 {
+		return
+	}
+	stock := h.branchStockKey(info.Branch)
+	go func(orderID string, items []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		if updated, _, err := h.adjustInventoryItems(ctx, items, 1, stock); err != nil {
+			log.Printf("[inventory] order release failed order=%s err=%v", orderID, err)
+		} else if updated > 0 {
+			log.Printf("[inventory] order release order=%s items=%d", orderID, updated)
+		}
+	}(info.OrderID, items)
+}
+
+// notifyActiveOrders active-orders topikiga xabar
+func (h *BotHandler) notifyActiveOrders(text string) {
+	if h.activeOrdersChatID == 0 {
+		return
+	}
+	if _, err := h.sendText(h.activeOrdersChatID, text, "", nil, h.activeOrdersThreadID); err != nil {
+		log.Printf("active orders notify failed: %v", err)
+	}
+}
+
+// switchOrderDelivery olib ketish <-> yetkazish
+func (h *BotHandler) switchOrderDelivery(chatID, userID int64, orderID string) {
+	info, ok := h.loadCustomerOrder(chatID, userID, orderID)
+	if !ok {
+		return
+	}
+	lang := h.getUserLang(userID)
+	newDelivery := "pickup"
+	if strings.EqualFold(info.Delivery, "pickup") {
+		newDelivery = "courier"
+		// Yetkazish uchun manzil kerak
+		if strings.TrimSpace(normalizeLocationText(info.Location)) == "" || info.Location == "ko'rsatilmagan" {
+			h.beginOrderAddressChange(chatID, userID, orderID, newDelivery)
+			return
+		}
+	}
+	updated, res := h.applyCustomerOrderChange(info, info.Location, newDelivery)
+	done := tr(lang, "order.change.delivery_done", "order_id", orderID, "delivery", deliveryDisplay(newDelivery, lang))
+	h.sendMessage(chatID, h.orderChangeReply(updated, res, lang, done))
+}
+
+// beginOrderAddressChange manzil kiritish jarayonini boshlaydi.
+// State: "<orderID>" yoki "<orderID>|courier" (manzildan keyin yetkazishga o'tish)
+func (h *BotHandler) beginOrderAddressChange(chatID, userID int64, orderID, newDelivery string) {
+	if _, ok := h.loadCustomerOrder(chatID, userID, orderID); !ok {
+		return
+	}
+	state := orderID
+	if newDelivery != "" {
+		state += "|" + newDelivery
+	}
+	h.enterConversation(userID, convFlowOrderEdit, state, chatID)
+	lang := h.getUserLang(userID)
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "order.change.address_prompt"))
+	msg.ReplyMarkup = h.locationRequestKeyboard(chatID)
+	_, _ = h.sendAndLog(msg)
+}
+
+// handleOrderEditInput manzil (lokatsiya yoki matn) qabul qiladi
+func (h *BotHandler) handleOrderEditInput(in conversationInput) bool {
+	state, ok := h.conversationStateIn(in.UserID, convFlowOrderEdit)
+	if !ok {
+		return false
+	}
+	lang := h.getUserLang(in.UserID)
+	orderID, newDelivery, _ := strings.Cut(state, "|")
+
+	if isBackCommand(in.Text) {
+		h.leaveConversation(in.UserID, convFlowOrderEdit)
+		h.hideReplyKeyboard(in.ChatID)
+		h.sendMessage(in.ChatID, tr(lang, "order.change.address_aborted"))
+		return true
+	}
+
+	locText := strings.TrimSpace(in.Text)
+	if in.Msg != nil && in.Msg.Location != nil {
+		locText = fmt.Sprintf("https://www.google.com/maps?q=%.5f,%.5f", in.Msg.Location.Latitude, in.Msg.Location.Longitude)
+	}
+	if locText == "" {
+		h.sendMessage(in.ChatID, tr(lang, "order.change.address_prompt"))
+		return true
+	}
+
+	h.leaveConversation(in.UserID, convFlowOrderEdit)
+	h.hideReplyKeyboard(in.ChatID)
+	info, ok := h.loadCustomerOrder(in.ChatID, in.UserID, orderID)
+	if !ok {
+		return true
+	}
+	if newDelivery == "" {
+		newDelivery = info.Delivery
+	}
+	updated, res := h.applyCustomerOrderChange(info, locText, newDelivery)
+	h.sendMessage(in.ChatID, h.orderChangeReply(updated, res, lang, tr(lang, "order.change.address_done", "order_id", orderID)))
+	return true
+}
+
+// orderChangeResult - mijoz o'zgartirishi natijasi
+type orderChangeResult int
+
+const (
+	orderChangeNone     orderChangeResult = iota // hech narsa o'zgarmadi
+	orderChangeSaved                             // saqlandi, narx o'zgarmadi
+	orderChangeRepriced                          // saqlandi, yetkazish narxi (va jami) o'zgardi
+	orderChangeLocked                            // holat shu orada "processing" dan o'zgargan
+	orderChangePaid                              // to'langan buyurtma narxi o'zgarardi - adminga yuborildi
+)
+
+// applyCustomerOrderChange manzil/yetkazish turini saqlaydi, yetkazish narxini qayta
+// hisoblaydi, tarixga yozadi va active-orders topikini xabardor qiladi.
+// Faqat shu maydonlar yoziladi va faqat holat hali "processing" bo'lsa (admin o'zgartirgan
+// holatni eski nusxa bilan bosib ketmaslik uchun). To'langan buyurtmada narx o'zgarsa
+// o'zgartirish saqlanmaydi, so'rov adminga yuboriladi.
+func (h *BotHandler) applyCustomerOrderChange(info orderStatusInfo, location, delivery string) (orderStatusInfo, orderChangeResult) {
+	updated := info
+	var changes []string
+	if loc := normalizeLocationText(location); loc != "" && loc != normalizeLocationText(info.Location) {
+		updated.Location = loc
+		changes = append(changes, "Manzil: "+loc)
+	}
+	if delivery != "" && !strings.EqualFold(delivery, info.Delivery) {
+		updated.Delivery = delivery
+		changes = append(changes, "Yetkazish: "+deliveryDisplay(delivery, "uz"))
+	}
+	if len(changes) == 0 {
+		return info, orderChangeNone
+	}
+	repriced := h.requoteOrderDelivery(&updated)
+	if repriced && info.PaymentStatus == "paid" && updated.DeliveryFee != info.DeliveryFee {
+		h.recordOrderEvent(info.OrderID, orderEventEdit, "⚠️ To'langan, adminga yuborildi: "+strings.Join(changes, "; "))
+		h.notifyOrderChannel(info, fmt.Sprintf("⚠️ To'langan buyurtmani o'zgartirish so'rovi (yetkazish narxi o'zgaradi)\nOrderID: %s\nUsername: @%s\nTelefon: %s\n%s\nYangi yetkazish narxi: %s (hozir: %s)",
+			info.OrderID,
+			nonEmpty(info.Username, "nomalum"),
+			nonEmpty(info.Phone, "ko'rsatilmagan"),
+			strings.Join(changes, "\n"),
+			deliveryFeeLabel(updated.DeliveryFee),
+			deliveryFeeLabel(info.DeliveryFee),
+		))
+		return info, orderChangePaid
+	}
+
+	saved, ok := h.updateOrderIf(info.OrderID, func(ctx context.Context) (bool, error) {
+		return h.orderStore.UpdateDeliveryIfStatus(ctx, info.OrderID, "processing", deliveryUpdateOf(updated))
+	})
+	if !ok {
+		return info, orderChangeLocked
+	}
+	if repriced {
+		changes = append(changes, fmt.Sprintf("Yetkazish narxi: %s, jami: %s", deliveryFeeLabel(saved.DeliveryFee), nonEmpty(h.formatOrderTotal(saved), "-")))
+	}
+	h.recordOrderEvent(info.OrderID, orderEventEdit, strings.Join(changes, "; "))
+
+	h.notifyOrderChannel(saved, fmt.Sprintf("✏️ Mijoz buyurtmani o'zgartirdi\nOrderID: %s\nUsername: @%s\nTelefon: %s\n%s",
+		saved.OrderID,
+		nonEmpty(saved.Username, "nomalum"),
+		nonEmpty(saved.Phone, "ko'rsatilmagan"),
+		strings.Join(changes, "\n"),
+	))
+	if repriced {
+		return saved, orderChangeRepriced
+	}
+	return saved, orderChangeSaved
+}
+
+// orderChangeReply o'zgartirish natijasiga ko'ra mijozga javob; done - muvaffaqiyat matni
+func (h *BotHandler) orderChangeReply(updated orderStatusInfo, res orderChangeResult, lang, done string) string {
+	switch res {
+	case orderChangeLocked:
+		return tr(lang, "order.change.locked")
+	case orderChangePaid:
+		return tr(lang, "order.change.paid_forwarded", "order_id", updated.OrderID)
+	case orderChangeRepriced:
+		return done + "\n" + h.orderRepricedLine(updated, lang)
+	}
+	return done
+}
+
+// orderRepricedLine mijozga yangi yetkazish narxi va jami
+func (h *BotHandler) orderRepricedLine(info orderStatusInfo, lang string) string {
+	fee := tr(lang, "delivery.free")
+	if !info.DeliveryFee.IsZero() {
+		fee = info.DeliveryFee.String()
+	}
+	return tr(lang, "order.change.repriced", "fee", fee, "total", nonEmpty(h.formatOrderTotal(info), "-"))
+}
+
+// requoteOrderDelivery yetkazish turi/manzil o'zgargach hudud narxini qayta hisoblaydi:
+// eski narx Total dan ayiriladi, yangisi (olib ketishda - 0) qo'shiladi. true - narx o'zgardi.
+func (h *BotHandler) requoteOrderDelivery(info *orderStatusInfo) bool {
+	snap := h.orderCurrency(*info)
+	conv := snap.converter()
+	total, hasTotal := orderTotalMoney(*info)
+	subtotal := total
+	if hasTotal && !info.DeliveryFee.IsZero() {
+		sub, err := entity.Sum(conv, total.Currency, total, info.DeliveryFee.Mul(-1))
+		if err != nil {
+			log.Printf("order %s delivery requote failed: %v", info.OrderID, err)
+			return false
+		}
+		subtotal = sub
+	}
+
+	var q *deliveryQuote
+	if strings.EqualFold(info.Delivery, "courier") {
+		q = h.quoteDeliveryAt(info.Location, subtotal, hasTotal, conv)
+	}
+	zone, fee := "", entity.Money{}
+	if q != nil {
+		zone, fee = q.Zone, q.Fee
+	}
+	if zone == info.DeliveryZone && fee == info.DeliveryFee {
+		return false
+	}
+	info.DeliveryZone, info.DeliveryFee = zone, fee
+	if !hasTotal {
+		return true
+	}
+	newTotal, err := entity.Sum(conv, subtotal.Currency, subtotal, fee)
+	if err != nil {
+		log.Printf("order %s delivery fee not added: %v", info.OrderID, err)
+		newTotal = subtotal
+	}
+	info.TotalMoney = newTotal
+	info.Total = snap.formatTotal(newTotal.String())
+	if info.InstallmentPlan.Months > 0 {
+		info.InstallmentDown = installmentDownPayment(info.InstallmentPlan, newTotal)
+	}
+	return true
+}
+
+// handleOrderGraceCommand /order_grace [daqiqa] - admin grace window'ni sozlaydi
+func (h *BotHandler) handleOrderGraceCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+	arg := strings.TrimSpace(message.CommandArguments())
+	if arg == "" {
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("⏱ Mijoz buyurtmani o'zgartira oladigan vaqt: %d daqiqa.\nO'zgartirish: /order_grace 30 (0 - o'chirish)", int(h.orderGraceWindow().Minutes())))
+		return
+	}
+	mins, err := strconv.Atoi(arg)
+	if err != nil || mins < 0 || time.Duration(mins)*time.Minute > maxOrderGraceInterval {
+		h.sendMessage(message.Chat.ID, "❌ Noto'g'ri qiymat. 0 dan 1440 gacha daqiqa kiriting. Masalan: /order_grace 30")
+		return
+	}
+	if err := saveOrderSettingsFile(orderSettingsFile, orderSettings{GraceMinutes: &mins}); err != nil {
+		log.Printf("order settings save failed: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Sozlamani saqlashda xatolik yuz berdi.")
+		return
+	}
+	h.orderGraceMu.Lock()
+	h.orderGrace = time.Duration(mins) * time.Minute
+	h.orderGraceSet = true
+	h.orderGraceMu.Unlock()
+	h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Grace window: %d daqiqa.", mins))
+}
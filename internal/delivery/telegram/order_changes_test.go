@@ -0,0 +1,172 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/storage"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// TestCustomerCancelWithinGrace - grace window ichida mijoz bekor qila oladi,
+// tarixda "mijoz tomonidan" belgisi bilan ko'rinadi; muddat o'tgach tugmalar yo'q
+func TestCustomerCancelWithinGrace(t *testing.T) {
+	promos := storage.NewMemoryPromotionRepository("")
+	_ = promos.Save(context.Background(), entity.Promotion{ID: "vip", Kind: entity.PromoPercent, Value: 5, Scope: entity.ScopeAll, Code: "VIP5", MaxUses: 1, Uses: 1})
+	h := &BotHandler{
+		orderStore:       newMemoryStore(),
+		orderStatuses:    make(map[string]orderStatusInfo),
+		userLang:         map[int64]string{7: "en"},
+		orderGrace:       10 * time.Minute,
+		orderGraceSet:    true,
+		promotionUseCase: usecase.NewPromotionUseCase(promos, nil),
+	}
+	const fresh, old = "01012026-01", "01012026-02"
+	h.saveOrderStatus(fresh, orderStatusInfo{UserID: 7, UserChat: 7, Status: "processing", Delivery: "courier", CreatedAt: time.Now(),
+		PromoCode: "VIP5", Lines: []entity.QuotedLine{{Promos: []string{"vip"}}}})
+	h.saveOrderStatus(old, orderStatusInfo{UserID: 7, UserChat: 7, Status: "processing", Delivery: "courier", CreatedAt: time.Now().Add(-time.Hour)})
+
+	if _, kb, _ := h.buildMyOrderDetail(7, old, 0); len(kb.InlineKeyboard) != 1 {
+		t.Fatalf("muddati o'tgan buyurtmada faqat orqaga tugmasi bo'lishi kerak: %d qator", len(kb.InlineKeyboard))
+	}
+	if h.cancelOrderByCustomer(7, 7, old) {
+		t.Fatalf("grace window'dan keyin bekor qilinmasligi kerak")
+	}
+	if h.cancelOrderByCustomer(7, 8, fresh) {
+		t.Fatalf("boshqa user bekor qila olmasligi kerak")
+	}
+	if !h.cancelOrderByCustomer(7, 7, fresh) {
+		t.Fatalf("grace window ichida bekor qilish ishlamadi")
+	}
+	if info, _ := h.getOrderStatus(fresh); info.Status != "canceled" {
+		t.Fatalf("status: %q", info.Status)
+	}
+	// Bekor qilingan buyurtmaning promo kod foydalanishi qaytariladi
+	if list, _ := promos.List(context.Background()); list[0].Uses != 0 {
+		t.Fatalf("promo foydalanishi qaytarilmadi: %d", list[0].Uses)
+	}
+	text, _, _ := h.buildMyOrderDetail(7, fresh, 0)
+	if !strings.Contains(text, "(by customer)") {
+		t.Fatalf("timeline'da mijoz belgisi yo'q:\n%s", text)
+	}
+}
+
+// TestCustomerCancelLosesToAdminStatus - admin holatni o'zgartirib ulgurgan bo'lsa
+// (kesh hali "processing"), mijoz bekor qilishi o'tmaydi va holat saqlanib qoladi
+func TestCustomerCancelLosesToAdminStatus(t *testing.T) {
+	h := &BotHandler{
+		orderStore:    newMemoryStore(),
+		orderStatuses: make(map[string]orderStatusInfo),
+		userLang:      map[int64]string{7: "en"},
+		orderGrace:    10 * time.Minute,
+		orderGraceSet: true,
+	}
+	const orderID = "01012026-03"
+	h.saveOrderStatus(orderID, orderStatusInfo{OrderID: orderID, UserID: 7, UserChat: 7, Status: "processing", Delivery: "courier", CreatedAt: time.Now()})
+	if err := h.orderStore.UpdateStatus(context.Background(), orderID, "ready_delivery"); err != nil {
+		t.Fatal(err)
+	}
+
+	if h.cancelOrderByCustomer(7, 7, orderID) {
+		t.Fatalf("admin o'zgartirgan buyurtma bekor qilinmasligi kerak")
+	}
+	if ord, ok, _ := h.orderStore.Get(context.Background(), orderID); !ok || ord.Status != "ready_delivery" {
+		t.Fatalf("store holati: %+v", ord)
+	}
+	if info, _ := h.getOrderStatus(orderID); info.Status != "ready_delivery" {
+		t.Fatalf("kesh yangilanmadi: %q", info.Status)
+	}
+	if h.cancelOrderByCustomer(7, 7, orderID) {
+		t.Fatalf("takroriy bekor qilish ham o'tmasligi kerak")
+	}
+}
+
+// TestCustomerOrderChangeRequotesDelivery - olib ketishga o'tsa yetkazish narxi jamidan
+// ayiriladi, boshqa hududdagi manzilga yetkazishda yangi narx qo'shiladi
+func TestCustomerOrderChangeRequotesDelivery(t *testing.T) {
+	usd := func(v float64) entity.Money { return entity.NewMoney(v, entity.CurrencyUSD) }
+	h := &BotHandler{
+		orderStore:    newMemoryStore(),
+		orderStatuses: make(map[string]orderStatusInfo),
+		userLang:      map[int64]string{7: "en"},
+		orderGrace:    10 * time.Minute,
+		orderGraceSet: true,
+		deliveryZones: []deliveryZone{
+			{Name: "Chilonzor", Fee: usd(5)},
+			{Name: "Sergeli", Fee: usd(8)},
+		},
+	}
+	const orderID = "01012026-04"
+	h.saveOrderStatus(orderID, orderStatusInfo{
+		OrderID: orderID, UserID: 7, UserChat: 7, Status: "processing", Delivery: "courier",
+		Location: "Chilonzor 9", Total: "105$", TotalMoney: usd(105),
+		DeliveryZone: "Chilonzor", DeliveryFee: usd(5), CreatedAt: time.Now(),
+	})
+
+	h.switchOrderDelivery(7, 7, orderID)
+	info, _ := h.getOrderStatus(orderID)
+	if info.Delivery != "pickup" || !info.DeliveryFee.IsZero() || info.DeliveryZone != "" || info.TotalMoney != usd(100) {
+		t.Fatalf("olib ketish: %+v", info)
+	}
+
+	info, res := h.applyCustomerOrderChange(info, "Sergeli 3", "courier")
+	if res != orderChangeRepriced || info.DeliveryZone != "Sergeli" || info.DeliveryFee != usd(8) || info.TotalMoney != usd(108) {
+		t.Fatalf("yangi hudud: res=%v %+v", res, info)
+	}
+	if saved, _ := h.getOrderStatus(orderID); saved.TotalMoney != usd(108) {
+		t.Fatalf("saqlangan jami: %v", saved.TotalMoney)
+	}
+}
+
+// TestCustomerOrderChangeKeepsAdminStatus - mijoz eski nusxa bilan o'zgartirsa, admin
+// shu orada qo'ygan holat qaytib "processing" ga tushmaydi; to'langan buyurtmada narx
+// o'zgaradigan o'zgartirish saqlanmaydi
+func TestCustomerOrderChangeKeepsAdminStatus(t *testing.T) {
+	usd := func(v float64) entity.Money { return entity.NewMoney(v, entity.CurrencyUSD) }
+	h := &BotHandler{
+		orderStore:    newMemoryStore(),
+		orderStatuses: make(map[string]orderStatusInfo),
+		userLang:      map[int64]string{7: "en"},
+		orderGrace:    10 * time.Minute,
+		orderGraceSet: true,
+		deliveryZones: []deliveryZone{
+			{Name: "Chilonzor", Fee: usd(5)},
+			{Name: "Sergeli", Fee: usd(8)},
+		},
+	}
+	base := orderStatusInfo{
+		UserID: 7, UserChat: 7, Status: "processing", Delivery: "courier",
+		Location: "Chilonzor 9", Total: "105$", TotalMoney: usd(105),
+		DeliveryZone: "Chilonzor", DeliveryFee: usd(5), CreatedAt: time.Now(),
+	}
+
+	const stale = "01012026-05"
+	base.OrderID = stale
+	h.saveOrderStatus(stale, base)
+	snapshot, _ := h.getOrderStatus(stale)
+	h.setOrderStatus(stale, "onway")
+	if _, res := h.applyCustomerOrderChange(snapshot, "Chilonzor 12", ""); res != orderChangeLocked {
+		t.Fatalf("holat o'zgargan buyurtma: res=%v", res)
+	}
+	if got, _ := h.getOrderStatus(stale); got.Status != "onway" || got.Location != "Chilonzor 9" {
+		t.Fatalf("admin holati bosib ketildi: %+v", got)
+	}
+
+	const paid = "01012026-06"
+	base.OrderID, base.PaymentStatus = paid, "paid"
+	h.saveOrderStatus(paid, base)
+	info, _ := h.getOrderStatus(paid)
+	if _, res := h.applyCustomerOrderChange(info, "Sergeli 3", ""); res != orderChangePaid {
+		t.Fatalf("to'langan buyurtma narxi: res=%v", res)
+	}
+	if got, _ := h.getOrderStatus(paid); got.TotalMoney != usd(105) || got.Location != "Chilonzor 9" {
+		t.Fatalf("to'langan buyurtma o'zgardi: %+v", got)
+	}
+	// Narx o'zgarmaydigan manzil o'zgarishi to'langan buyurtmada ham saqlanadi
+	if _, res := h.applyCustomerOrderChange(info, "Chilonzor 12", ""); res != orderChangeSaved {
+		t.Fatalf("bir hududdagi manzil: res=%v", res)
+	}
+}
@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"context"
+	"log"
 	"regexp"
 	"sort"
 	"strings"
@@ -12,10 +13,14 @@ func (h *BotHandler) saveOrderStatus(orderID string, info orderStatusInfo) {
 	if info.OrderID == "" {
 		info.OrderID = orderID
 	}
+	prev, existed := h.getOrderStatus(orderID)
 	h.orderStatusMu.Lock()
 	h.orderStatuses[orderID] = info
 	h.orderStatusMu.Unlock()
 	_ = h.orderStore.Save(context.Background(), info)
+	if !existed || prev.Status != info.Status {
+		h.recordOrderEvent(orderID, info.Status, "")
+	}
 	h.scheduleAboutUserSheetSync("order")
 }
 
@@ -35,12 +40,53 @@ func (h *BotHandler) getOrderStatus(orderID string) (orderStatusInfo, bool) {
 }
 
 func (h *BotHandler) setOrderStatus(orderID, status string) {
+	h.setOrderStatusNote(orderID, status, "")
+}
+
+// setOrderStatusNote holatni o'zgartiradi; note timeline yozuviga qo'shiladi
+func (h *BotHandler) setOrderStatusNote(orderID, status, note string) {
+	prev, _ := h.getOrderStatus(orderID)
 	h.orderStatusMu.Lock()
 	info := h.orderStatuses[orderID]
 	info.Status = status
 	h.orderStatuses[orderID] = info
 	h.orderStatusMu.Unlock()
 	_ = h.orderStore.UpdateStatus(context.Background(), orderID, status)
+	if prev.Status != status {
+		h.recordOrderEvent(orderID, status, note)
+	}
+}
+
+// compareAndSetOrderStatus holat hali from bo'lsa to ga o'tkazadi (store'da bitta atomar
+// amal); false - holatni boshqa jarayon allaqachon o'zgartirgan
+func (h *BotHandler) compareAndSetOrderStatus(orderID, from, to, note string) bool {
+	_, ok := h.updateOrderIf(orderID, func(ctx context.Context) (bool, error) {
+		return h.orderStore.CompareAndSetStatus(ctx, orderID, from, to)
+	})
+	if ok {
+		h.recordOrderEvent(orderID, to, note)
+	}
+	return ok
+}
+
+// updateOrderIf store'dagi shartli (atomar) yangilashni bajaradi va keshni store'dagi
+// yangi yozuv bilan almashtiradi. Butun yozuvni eski nusxadan qayta yozmaslik uchun
+// ishlatiladi; false - shart bajarilmadi (holat o'zgargan) yoki xato
+func (h *BotHandler) updateOrderIf(orderID string, update func(ctx context.Context) (bool, error)) (orderStatusInfo, bool) {
+	ctx := context.Background()
+	h.orderStatusMu.Lock()
+	defer h.orderStatusMu.Unlock()
+	ok, err := update(ctx)
+	if err != nil {
+		log.Printf("order %s update failed: %v", orderID, err)
+		return orderStatusInfo{}, false
+	}
+	fresh, found, err := h.orderStore.Get(ctx, orderID)
+	if err != nil || !found {
+		return fresh, ok
+	}
+	h.orderStatuses[orderID] = fresh
+	return fresh, ok
 }
 
 func (h *BotHandler) findOrderByID(orderID string) *orderStatusInfo {
@@ -102,20 +148,10 @@ func (h *BotHandler) listRecentOrders(limit int) []orderStatusInfo {
 
 func statusLabel(status, lang string) string {
 	switch status {
-	case "processing":
-		return t(lang, "Qabul qilindi", "Принят")
-	case "ready_delivery":
-		return t(lang, "Tayyor (dostavka)", "Готов (доставка)")
-	case "ready_pickup":
-		return t(lang, "Tayyor (pickup)", "Готов (самовывоз)")
-	case "onway":
-		return t(lang, "Yo'lga chiqdi", "В пути")
-	case "delivered":
-		return t(lang, "Yakunlandi", "Доставлен")
-	case "canceled":
-		return t(lang, "Bekor qilindi", "Отменён")
+	case "processing", "ready_delivery", "ready_pickup", "onway", "delivered", "canceled":
+		return tr(lang, "order.status."+status)
 	default:
-		return t(lang, "Jarayonda", "В обработке")
+		return tr(lang, "order.status.unknown")
 	}
 }
 
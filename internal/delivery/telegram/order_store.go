@@ -3,6 +3,7 @@ package telegram
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net"
@@ -10,9 +11,11 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
 )
 
 func buildPostgresDSNFromEnv() string {
@@ -54,30 +57,82 @@ func buildPostgresDSNFromEnv() string {
 type OrderStore interface {
 	Save(ctx context.Context, ord orderStatusInfo) error
 	UpdateStatus(ctx context.Context, orderID, status string) error
+	// CompareAndSetStatus holat hali from bo'lsa uni to ga atomar o'tkazadi; false - holat boshqa
+	CompareAndSetStatus(ctx context.Context, orderID, from, to string) (bool, error)
+	// UpdateDeliveryIfStatus faqat yetkazish maydonlarini (va ularga bog'liq jamini) yozadi,
+	// holat hali status bo'lsa; false - holat o'zgargan
+	UpdateDeliveryIfStatus(ctx context.Context, orderID, status string, upd orderDeliveryUpdate) (bool, error)
+	// SetCourierIfStatus kuryerni biriktiradi, holat hali status bo'lsa
+	SetCourierIfStatus(ctx context.Context, orderID, status string, courierID int64) (bool, error)
+	// SetPaymentStatus faqat to'lov holatini yozadi
+	SetPaymentStatus(ctx context.Context, orderID, paymentStatus string) error
 	Get(ctx context.Context, orderID string) (orderStatusInfo, bool, error)
 	ListByUser(ctx context.Context, userID int64) ([]orderStatusInfo, error)
 	ListRecent(ctx context.Context, limit int) ([]orderStatusInfo, error)
 	DeleteByUser(ctx context.Context, userID int64) error
+	// Holatlar tarixi (timeline) va ETA yozuvlari
+	AddEvent(ctx context.Context, ev orderStatusEvent) error
+	ListEvents(ctx context.Context, orderID string) ([]orderStatusEvent, error)
 }
 
-// memoryStore fallback (server ish davomida)
+// orderDeliveryUpdate - mijoz o'zgartira oladigan maydonlar: manzil, yetkazish turi, narxi
+// va shunga ko'ra qayta hisoblangan jami / boshlang'ich to'lov
+type orderDeliveryUpdate struct {
+	Location        string
+	Delivery        string
+	DeliveryZone    string
+	DeliveryFee     entity.Money
+	Total           string
+	TotalMoney      entity.Money
+	InstallmentDown entity.Money
+}
+
+func (u orderDeliveryUpdate) applyTo(ord *orderStatusInfo) {
+	ord.Location = u.Location
+	ord.Delivery = u.Delivery
+	ord.DeliveryZone = u.DeliveryZone
+	ord.DeliveryFee = u.DeliveryFee
+	ord.Total = u.Total
+	ord.TotalMoney = u.TotalMoney
+	ord.InstallmentDown = u.InstallmentDown
+}
+
+func deliveryUpdateOf(ord orderStatusInfo) orderDeliveryUpdate {
+	return orderDeliveryUpdate{
+		Location:        ord.Location,
+		Delivery:        ord.Delivery,
+		DeliveryZone:    ord.DeliveryZone,
+		DeliveryFee:     ord.DeliveryFee,
+		Total:           ord.Total,
+		TotalMoney:      ord.TotalMoney,
+		InstallmentDown: ord.InstallmentDown,
+	}
+}
+
+// memoryStore fallback (server ish davomida); mu data va events ikkalasini himoyalaydi
 type memoryStore struct {
-	data map[string]orderStatusInfo
+	mu     sync.RWMutex
+	data   map[string]orderStatusInfo
+	events map[string][]orderStatusEvent
 }
 
 func newMemoryStore() *memoryStore {
-	return &memoryStore{data: make(map[string]orderStatusInfo)}
+	return &memoryStore{data: make(map[string]orderStatusInfo), events: make(map[string][]orderStatusEvent)}
 }
 
 func (m *memoryStore) Save(_ context.Context, ord orderStatusInfo) error {
 	if ord.CreatedAt.IsZero() {
 		ord.CreatedAt = time.Now()
 	}
+	m.mu.Lock()
 	m.data[ord.OrderID] = ord
+	m.mu.Unlock()
 	return nil
 }
 
 func (m *memoryStore) UpdateStatus(_ context.Context, orderID, status string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	ord, ok := m.data[orderID]
 	if !ok {
 		return nil
@@ -87,12 +142,62 @@ func (m *memoryStore) UpdateStatus(_ context.Context, orderID, status string) er
 	return nil
 }
 
+func (m *memoryStore) CompareAndSetStatus(_ context.Context, orderID, from, to string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ord, ok := m.data[orderID]
+	if !ok || ord.Status != from {
+		return false, nil
+	}
+	ord.Status = to
+	m.data[orderID] = ord
+	return true, nil
+}
+
+func (m *memoryStore) UpdateDeliveryIfStatus(_ context.Context, orderID, status string, upd orderDeliveryUpdate) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ord, ok := m.data[orderID]
+	if !ok || ord.Status != status {
+		return false, nil
+	}
+	upd.applyTo(&ord)
+	m.data[orderID] = ord
+	return true, nil
+}
+
+func (m *memoryStore) SetCourierIfStatus(_ context.Context, orderID, status string, courierID int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ord, ok := m.data[orderID]
+	if !ok || ord.Status != status {
+		return false, nil
+	}
+	ord.CourierID = courierID
+	m.data[orderID] = ord
+	return true, nil
+}
+
+func (m *memoryStore) SetPaymentStatus(_ context.Context, orderID, paymentStatus string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if ord, ok := m.data[orderID]; ok {
+		ord.PaymentStatus = paymentStatus
+		m.data[orderID] = ord
+	}
+	return nil
+}
+
 func (m *memoryStore) Get(_ context.Context, orderID string) (orderStatusInfo, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	ord, ok := m.data[orderID]
 	return ord, ok, nil
 }
 
 func (m *memoryStore) ListByUser(_ context.Context, userID int64) ([]orderStatusInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	var res []orderStatusInfo
 	for _, v := range m.data {
 		if v.UserID == userID {
@@ -103,10 +208,12 @@ func (m *memoryStore) ListByUser(_ context.Context, userID int64) ([]orderStatus
 }
 
 func (m *memoryStore) ListRecent(_ context.Context, limit int) ([]orderStatusInfo, error) {
+	m.mu.RLock()
 	var res []orderStatusInfo
 	for _, v := range m.data {
 		res = append(res, v)
 	}
+	m.mu.RUnlock()
 	// simple order by CreatedAt desc
 	sort.Slice(res, func(i, j int) bool { return res[i].CreatedAt.After(res[j].CreatedAt) })
 	if limit > 0 && len(res) > limit {
@@ -116,14 +223,35 @@ func (m *memoryStore) ListRecent(_ context.Context, limit int) ([]orderStatusInf
 }
 
 func (m *memoryStore) DeleteByUser(_ context.Context, userID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for k, v := range m.data {
 		if v.UserID == userID {
 			delete(m.data, k)
+			delete(m.events, k)
 		}
 	}
 	return nil
 }
 
+func (m *memoryStore) AddEvent(_ context.Context, ev orderStatusEvent) error {
+	if ev.CreatedAt.IsZero() {
+		ev.CreatedAt = time.Now()
+	}
+	m.mu.Lock()
+	m.events[ev.OrderID] = append(m.events[ev.OrderID], ev)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *memoryStore) ListEvents(_ context.Context, orderID string) ([]orderStatusEvent, error) {
+	m.mu.RLock()
+	res := append([]orderStatusEvent(nil), m.events[orderID]...)
+	m.mu.RUnlock()
+	sort.SliceStable(res, func(i, j int) bool { return res[i].CreatedAt.Before(res[j].CreatedAt) })
+	return res, nil
+}
+
 // postgresStore persistent saqlash
 type postgresStore struct {
 	db *sql.DB
@@ -167,17 +295,110 @@ CREATE TABLE IF NOT EXISTS orders (
 	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS location TEXT`); err != nil {
 		return nil, fmt.Errorf("alter orders add location: %w", err)
 	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_status TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add payment_status: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS installment TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add installment: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency_rate DOUBLE PRECISION NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add currency_rate: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_amount BIGINT NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add total_amount: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_currency TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add total_currency: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add promo_code: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS lines TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add lines: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_zone TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add delivery_zone: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_fee_amount BIGINT NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add delivery_fee_amount: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_fee_currency TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add delivery_fee_currency: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS branch TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add branch: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS courier_id BIGINT NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add courier_id: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS installment_months INTEGER NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add installment_months: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS installment_markup DOUBLE PRECISION NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add installment_markup: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS installment_down_percent DOUBLE PRECISION NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add installment_down_percent: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS installment_down_amount BIGINT NOT NULL DEFAULT 0`); err != nil {
+		return nil, fmt.Errorf("alter orders add installment_down_amount: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS installment_down_currency TEXT NOT NULL DEFAULT ''`); err != nil {
+		return nil, fmt.Errorf("alter orders add installment_down_currency: %w", err)
+	}
+
+	eventsSchema := `
+CREATE TABLE IF NOT EXISTS order_status_events (
+	id BIGSERIAL PRIMARY KEY,
+	order_id TEXT NOT NULL,
+	status TEXT NOT NULL,
+	note TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_order_status_events_order ON order_status_events (order_id, created_at);
+`
+	if _, err := db.Exec(eventsSchema); err != nil {
+		return nil, fmt.Errorf("create order_status_events table: %w", err)
+	}
 
 	return &postgresStore{db: db}, nil
 }
 
+const orderSelectColumns = `order_id, user_id, user_chat, username, phone, location, summary, status_summary, total, delivery, status, is_single, created_at, payment_status, installment, currency_rate, total_amount, total_currency, promo_code, lines, delivery_zone, delivery_fee_amount, delivery_fee_currency, branch, courier_id, installment_months, installment_markup, installment_down_percent, installment_down_amount, installment_down_currency`
+
+func scanOrderRow(scan func(dest ...interface{}) error) (orderStatusInfo, error) {
+	var ord orderStatusInfo
+	var isSingle sql.NullBool
+	var lines string
+	if err := scan(&ord.OrderID, &ord.UserID, &ord.UserChat, &ord.Username, &ord.Phone, &ord.Location, &ord.Summary, &ord.StatusSummary, &ord.Total, &ord.Delivery, &ord.Status, &isSingle, &ord.CreatedAt, &ord.PaymentStatus, &ord.Installment, &ord.CurrencyRate, &ord.TotalMoney.Amount, &ord.TotalMoney.Currency, &ord.PromoCode, &lines, &ord.DeliveryZone, &ord.DeliveryFee.Amount, &ord.DeliveryFee.Currency, &ord.Branch, &ord.CourierID, &ord.InstallmentPlan.Months, &ord.InstallmentPlan.MarkupPercent, &ord.InstallmentPlan.DownPercent, &ord.InstallmentDown.Amount, &ord.InstallmentDown.Currency); err != nil {
+		return orderStatusInfo{}, err
+	}
+	if isSingle.Valid {
+		ord.IsSingleItem = isSingle.Bool
+	}
+	if lines != "" {
+		if err := json.Unmarshal([]byte(lines), &ord.Lines); err != nil {
+			log.Printf("order %s lines parse failed: %v", ord.OrderID, err)
+		}
+	}
+	return ord, nil
+}
+
 func (p *postgresStore) Save(ctx context.Context, ord orderStatusInfo) error {
 	if ord.CreatedAt.IsZero() {
 		ord.CreatedAt = time.Now()
 	}
+	lines := ""
+	if len(ord.Lines) > 0 {
+		b, err := json.Marshal(ord.Lines)
+		if err != nil {
+			return fmt.Errorf("marshal order lines: %w", err)
+		}
+		lines = string(b)
+	}
 	_, err := p.db.ExecContext(ctx, `
-	INSERT INTO orders (order_id, user_id, user_chat, username, phone, location, summary, status_summary, total, delivery, status, is_single)
-	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+	INSERT INTO orders (order_id, user_id, user_chat, username, phone, location, summary, status_summary, total, delivery, status, is_single, payment_status, installment, currency_rate, total_amount, total_currency, promo_code, lines, delivery_zone, delivery_fee_amount, delivery_fee_currency, branch, courier_id, installment_months, installment_markup, installment_down_percent, installment_down_amount, installment_down_currency)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29)
 	ON CONFLICT (order_id) DO UPDATE SET
 		location=EXCLUDED.location,
 		summary=EXCLUDED.summary,
@@ -185,8 +406,25 @@ func (p *postgresStore) Save(ctx context.Context, ord orderStatusInfo) error {
 		total=EXCLUDED.total,
 		delivery=EXCLUDED.delivery,
 		status=EXCLUDED.status,
-		is_single=EXCLUDED.is_single
-	`, ord.OrderID, ord.UserID, ord.UserChat, ord.Username, ord.Phone, ord.Location, ord.Summary, ord.StatusSummary, ord.Total, ord.Delivery, ord.Status, ord.IsSingleItem)
+		is_single=EXCLUDED.is_single,
+		payment_status=EXCLUDED.payment_status,
+		installment=EXCLUDED.installment,
+		currency_rate=EXCLUDED.currency_rate,
+		total_amount=EXCLUDED.total_amount,
+		total_currency=EXCLUDED.total_currency,
+		promo_code=EXCLUDED.promo_code,
+		lines=EXCLUDED.lines,
+		delivery_zone=EXCLUDED.delivery_zone,
+		delivery_fee_amount=EXCLUDED.delivery_fee_amount,
+		delivery_fee_currency=EXCLUDED.delivery_fee_currency,
+		branch=EXCLUDED.branch,
+		courier_id=EXCLUDED.courier_id,
+		installment_months=EXCLUDED.installment_months,
+		installment_markup=EXCLUDED.installment_markup,
+		installment_down_percent=EXCLUDED.installment_down_percent,
+		installment_down_amount=EXCLUDED.installment_down_amount,
+		installment_down_currency=EXCLUDED.installment_down_currency
+	`, ord.OrderID, ord.UserID, ord.UserChat, ord.Username, ord.Phone, ord.Location, ord.Summary, ord.StatusSummary, ord.Total, ord.Delivery, ord.Status, ord.IsSingleItem, ord.PaymentStatus, ord.Installment, ord.CurrencyRate, ord.TotalMoney.Amount, ord.TotalMoney.Currency, ord.PromoCode, lines, ord.DeliveryZone, ord.DeliveryFee.Amount, ord.DeliveryFee.Currency, ord.Branch, ord.CourierID, ord.InstallmentPlan.Months, ord.InstallmentPlan.MarkupPercent, ord.InstallmentPlan.DownPercent, ord.InstallmentDown.Amount, ord.InstallmentDown.Currency)
 	return err
 }
 
@@ -195,30 +433,61 @@ func (p *postgresStore) UpdateStatus(ctx context.Context, orderID, status string
 	return err
 }
 
+func (p *postgresStore) CompareAndSetStatus(ctx context.Context, orderID, from, to string) (bool, error) {
+	res, err := p.db.ExecContext(ctx, `UPDATE orders SET status=$1 WHERE order_id=$2 AND status=$3`, to, orderID, from)
+	return rowsChanged(res, err)
+}
+
+func (p *postgresStore) UpdateDeliveryIfStatus(ctx context.Context, orderID, status string, upd orderDeliveryUpdate) (bool, error) {
+	res, err := p.db.ExecContext(ctx, `
+	UPDATE orders SET location=$1, delivery=$2, delivery_zone=$3, delivery_fee_amount=$4, delivery_fee_currency=$5,
+		total=$6, total_amount=$7, total_currency=$8, installment_down_amount=$9, installment_down_currency=$10
+	WHERE order_id=$11 AND status=$12`,
+		upd.Location, upd.Delivery, upd.DeliveryZone, upd.DeliveryFee.Amount, upd.DeliveryFee.Currency,
+		upd.Total, upd.TotalMoney.Amount, upd.TotalMoney.Currency, upd.InstallmentDown.Amount, upd.InstallmentDown.Currency,
+		orderID, status)
+	return rowsChanged(res, err)
+}
+
+func (p *postgresStore) SetCourierIfStatus(ctx context.Context, orderID, status string, courierID int64) (bool, error) {
+	res, err := p.db.ExecContext(ctx, `UPDATE orders SET courier_id=$1 WHERE order_id=$2 AND status=$3`, courierID, orderID, status)
+	return rowsChanged(res, err)
+}
+
+func (p *postgresStore) SetPaymentStatus(ctx context.Context, orderID, paymentStatus string) error {
+	_, err := p.db.ExecContext(ctx, `UPDATE orders SET payment_status=$1 WHERE order_id=$2`, paymentStatus, orderID)
+	return err
+}
+
+// rowsChanged shartli UPDATE natijasi: true - bitta qator yangilandi
+func rowsChanged(res sql.Result, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
 func (p *postgresStore) Get(ctx context.Context, orderID string) (orderStatusInfo, bool, error) {
 	row := p.db.QueryRowContext(ctx, `
-	SELECT order_id, user_id, user_chat, username, phone, location, summary, status_summary, total, delivery, status, is_single, created_at
+	SELECT `+orderSelectColumns+`
 	FROM orders WHERE order_id=$1`, orderID)
-	var ord orderStatusInfo
-	var isSingle sql.NullBool
-	var created time.Time
-	err := row.Scan(&ord.OrderID, &ord.UserID, &ord.UserChat, &ord.Username, &ord.Phone, &ord.Location, &ord.Summary, &ord.StatusSummary, &ord.Total, &ord.Delivery, &ord.Status, &isSingle, &created)
+	ord, err := scanOrderRow(row.Scan)
 	if err == sql.ErrNoRows {
 		return orderStatusInfo{}, false, nil
 	}
 	if err != nil {
 		return orderStatusInfo{}, false, err
 	}
-	if isSingle.Valid {
-		ord.IsSingleItem = isSingle.Bool
-	}
-	ord.CreatedAt = created
 	return ord, true, nil
 }
 
 func (p *postgresStore) ListByUser(ctx context.Context, userID int64) ([]orderStatusInfo, error) {
 	rows, err := p.db.QueryContext(ctx, `
-	SELECT order_id, user_id, user_chat, username, phone, location, summary, status_summary, total, delivery, status, is_single, created_at
+	SELECT `+orderSelectColumns+`
 	FROM orders WHERE user_id=$1 ORDER BY created_at DESC`, userID)
 	if err != nil {
 		return nil, err
@@ -227,16 +496,10 @@ func (p *postgresStore) ListByUser(ctx context.Context, userID int64) ([]orderSt
 
 	var res []orderStatusInfo
 	for rows.Next() {
-		var ord orderStatusInfo
-		var isSingle sql.NullBool
-		var created time.Time
-		if err := rows.Scan(&ord.OrderID, &ord.UserID, &ord.UserChat, &ord.Username, &ord.Phone, &ord.Location, &ord.Summary, &ord.StatusSummary, &ord.Total, &ord.Delivery, &ord.Status, &isSingle, &created); err != nil {
+		ord, err := scanOrderRow(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
-		if isSingle.Valid {
-			ord.IsSingleItem = isSingle.Bool
-		}
-		ord.CreatedAt = created
 		res = append(res, ord)
 	}
 	return res, nil
@@ -244,7 +507,7 @@ func (p *postgresStore) ListByUser(ctx context.Context, userID int64) ([]orderSt
 
 func (p *postgresStore) ListRecent(ctx context.Context, limit int) ([]orderStatusInfo, error) {
 	rows, err := p.db.QueryContext(ctx, `
-	SELECT order_id, user_id, user_chat, username, phone, location, summary, status_summary, total, delivery, status, is_single, created_at
+	SELECT `+orderSelectColumns+`
 	FROM orders ORDER BY created_at DESC LIMIT $1`, limit)
 	if err != nil {
 		return nil, err
@@ -252,26 +515,53 @@ func (p *postgresStore) ListRecent(ctx context.Context, limit int) ([]orderStatu
 	defer rows.Close()
 	var res []orderStatusInfo
 	for rows.Next() {
-		var ord orderStatusInfo
-		var isSingle sql.NullBool
-		var created time.Time
-		if err := rows.Scan(&ord.OrderID, &ord.UserID, &ord.UserChat, &ord.Username, &ord.Phone, &ord.Location, &ord.Summary, &ord.StatusSummary, &ord.Total, &ord.Delivery, &ord.Status, &isSingle, &created); err != nil {
+		ord, err := scanOrderRow(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
-		if isSingle.Valid {
-			ord.IsSingleItem = isSingle.Bool
-		}
-		ord.CreatedAt = created
 		res = append(res, ord)
 	}
 	return res, nil
 }
 
 func (p *postgresStore) DeleteByUser(ctx context.Context, userID int64) error {
+	if _, err := p.db.ExecContext(ctx, `DELETE FROM order_status_events WHERE order_id IN (SELECT order_id FROM orders WHERE user_id = $1)`, userID); err != nil {
+		return err
+	}
 	_, err := p.db.ExecContext(ctx, `DELETE FROM orders WHERE user_id = $1`, userID)
 	return err
 }
 
+func (p *postgresStore) AddEvent(ctx context.Context, ev orderStatusEvent) error {
+	if ev.CreatedAt.IsZero() {
+		ev.CreatedAt = time.Now()
+	}
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO order_status_events (order_id, status, note, created_at)
+	VALUES ($1,$2,$3,$4)
+	`, ev.OrderID, ev.Status, ev.Note, ev.CreatedAt)
+	return err
+}
+
+func (p *postgresStore) ListEvents(ctx context.Context, orderID string) ([]orderStatusEvent, error) {
+	rows, err := p.db.QueryContext(ctx, `
+	SELECT order_id, status, note, created_at
+	FROM order_status_events WHERE order_id=$1 ORDER BY created_at, id`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []orderStatusEvent
+	for rows.Next() {
+		var ev orderStatusEvent
+		if err := rows.Scan(&ev.OrderID, &ev.Status, &ev.Note, &ev.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, ev)
+	}
+	return res, rows.Err()
+}
+
 // newOrderStoreFromEnv DSN berilsa Postgres, aks holda memory
 func newOrderStoreFromEnv() (OrderStore, error) {
 	dsn := os.Getenv("POSTGRES_DSN")
@@ -0,0 +1,35 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestMemoryStoreConcurrent - buyurtma va timeline yozuvlari parallel yozilganda yo'qolmaydi
+func TestMemoryStoreConcurrent(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			orderID := fmt.Sprintf("01012026-%02d", i)
+			_ = store.Save(ctx, orderStatusInfo{OrderID: orderID, UserID: 7, Status: "processing"})
+			_ = store.AddEvent(ctx, orderStatusEvent{OrderID: "shared", Status: "processing"})
+			_ = store.UpdateStatus(ctx, orderID, "delivered")
+			_, _ = store.ListEvents(ctx, "shared")
+			_, _ = store.ListRecent(ctx, 5)
+		}(i)
+	}
+	wg.Wait()
+
+	if events, _ := store.ListEvents(ctx, "shared"); len(events) != 20 {
+		t.Fatalf("timeline yozuvlari: %d", len(events))
+	}
+	if orders, _ := store.ListByUser(ctx, 7); len(orders) != 20 {
+		t.Fatalf("buyurtmalar: %d", len(orders))
+	}
+}
@@ -0,0 +1,365 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// /myorders - mijoz uchun buyurtmalar tarixi, tafsilot, ETA va holatlar timeline'i
+
+const (
+	myOrdersPageSize = 5
+	// orderEventETA - admin kiritgan taxminiy yetkazish vaqti (timeline yozuvi)
+	orderEventETA = "eta"
+)
+
+// recordOrderEvent buyurtma timeline'iga yozuv qo'shadi
+func (h *BotHandler) recordOrderEvent(orderID, status, note string) {
+	if h.orderStore == nil || strings.TrimSpace(orderID) == "" || strings.TrimSpace(status) == "" {
+		return
+	}
+	ev := orderStatusEvent{OrderID: orderID, Status: status, Note: strings.TrimSpace(note), CreatedAt: time.Now()}
+	if err := h.orderStore.AddEvent(context.Background(), ev); err != nil {
+		log.Printf("order event save failed order=%s status=%s err=%v", orderID, status, err)
+	}
+}
+
+func (h *BotHandler) listOrderEvents(orderID string) []orderStatusEvent {
+	if h.orderStore == nil {
+		return nil
+	}
+	events, err := h.orderStore.ListEvents(context.Background(), orderID)
+	if err != nil {
+		log.Printf("order events load failed order=%s err=%v", orderID, err)
+		return nil
+	}
+	return events
+}
+
+// latestOrderETA oxirgi kiritilgan ETA (bo'lmasa ok=false)
+func latestOrderETA(events []orderStatusEvent) (orderStatusEvent, bool) {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Status == orderEventETA && events[i].Note != "" {
+			return events[i], true
+		}
+	}
+	return orderStatusEvent{}, false
+}
+
+// isAwaitingETA admin "Yo'lga chiqdi" ni bosgan, lekin vaqtni hali yozmagan
+func (h *BotHandler) isAwaitingETA(orderID string) bool {
+	h.pendingETAMu.RLock()
+	defer h.pendingETAMu.RUnlock()
+	for _, id := range h.pendingETAs {
+		if id == orderID {
+			return true
+		}
+	}
+	for _, id := range h.pendingETAChat {
+		if id == orderID {
+			return true
+		}
+	}
+	return false
+}
+
+// orderPushEnabled user buyurtma holati xabarlarini o'chirmaganmi
+func (h *BotHandler) orderPushEnabled(userID int64) bool {
+	if h.userStore == nil {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	rec, ok, err := h.userStore.Get(ctx, userID)
+	if err != nil || !ok {
+		return true
+	}
+	return !rec.OrderPushOff
+}
+
+func (h *BotHandler) setOrderPushEnabled(userID int64, enabled bool) error {
+	if h.userStore == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	return h.userStore.SetOrderPush(ctx, userID, enabled)
+}
+
+// notifyOrderStatusChange holat o'zgargani haqida mijozga avtomatik xabar
+func (h *BotHandler) notifyOrderStatusChange(order orderStatusInfo, newStatus string) bool {
+	if order.UserChat == 0 {
+		return false
+	}
+	if !h.orderPushEnabled(order.UserID) {
+		return false
+	}
+	lang := h.getUserLang(order.UserID)
+	var text string
+	switch newStatus {
+	case "processing", "ready_delivery", "ready_pickup", "onway", "delivered", "canceled":
+		text = tr(lang, "order.push."+newStatus, "order_id", order.OrderID)
+	default:
+		text = tr(lang, "order.push.changed", "order_id", order.OrderID, "status", statusLabel(newStatus, lang))
+	}
+	text += "\n\n" + tr(lang, "order.push.hint")
+	if _, err := h.sendText(order.UserChat, text, "", nil, 0); err != nil {
+		log.Printf("order status push failed order=%s user=%d err=%v", order.OrderID, order.UserID, err)
+		return false
+	}
+	return true
+}
+
+func (h *BotHandler) handleMyOrdersCommand(ctx context.Context, message *tgbotapi.Message) {
+	if message == nil || message.From == nil {
+		return
+	}
+	text, kb := h.buildMyOrdersPage(message.From.ID, 0)
+	msg := tgbotapi.NewMessage(message.Chat.ID, text)
+	msg.ReplyMarkup = kb
+	_, _ = h.sendAndLog(msg)
+}
+
+// userOrdersSorted userning buyurtmalari, yangilari birinchi
+func (h *BotHandler) userOrdersSorted(userID int64) []orderStatusInfo {
+	orders := h.listOrdersByUser(userID)
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].CreatedAt.After(orders[j].CreatedAt) })
+	return orders
+}
+
+func (h *BotHandler) buildMyOrdersPage(userID int64, page int) (string, tgbotapi.InlineKeyboardMarkup) {
+	lang := h.getUserLang(userID)
+	orders := h.userOrdersSorted(userID)
+
+	pages := (len(orders) + myOrdersPageSize - 1) / myOrdersPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var sb strings.Builder
+	if len(orders) == 0 {
+		sb.WriteString(tr(lang, "order.none"))
+	} else {
+		sb.WriteString(trPlural(lang, "order.list_header", len(orders)))
+		if pages > 1 {
+			sb.WriteString("\n" + tr(lang, "myorders.page", "page", strconv.Itoa(page+1), "pages", strconv.Itoa(pages)))
+		}
+		start := page * myOrdersPageSize
+		end := start + myOrdersPageSize
+		if end > len(orders) {
+			end = len(orders)
+		}
+		for _, ord := range orders[start:end] {
+			title := orderTitle(ord)
+			if r := []rune(title); len(r) > 28 {
+				title = string(r[:28]) + "…"
+			}
+			label := fmt.Sprintf("%s %s · %s", orderStatusIcon(ord.Status), title, statusLabel(ord.Status, lang))
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("myord|view|%s|%d", ord.OrderID, page)),
+			))
+		}
+		var nav []tgbotapi.InlineKeyboardButton
+		if page > 0 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("⬅️", fmt.Sprintf("myord|page|%d", page-1)))
+		}
+		if page < pages-1 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("➡️", fmt.Sprintf("myord|page|%d", page+1)))
+		}
+		if len(nav) > 0 {
+			rows = append(rows, nav)
+		}
+	}
+
+	pushLabel, pushArg := tr(lang, "myorders.push_off"), "off"
+	if !h.orderPushEnabled(userID) {
+		pushLabel, pushArg = tr(lang, "myorders.push_on"), "on"
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(pushLabel, fmt.Sprintf("myord|push|%s|%d", pushArg, page)),
+	))
+	return sb.String(), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func orderStatusIcon(status string) string {
+	switch status {
+	case "processing":
+		return "⏳"
+	case "ready_delivery", "ready_pickup":
+		return "📦"
+	case "onway":
+		return "🚚"
+	case "delivered":
+		return "✅"
+	case "canceled":
+		return "❌"
+	default:
+		return "🧾"
+	}
+}
+
+func (h *BotHandler) buildMyOrderDetail(userID int64, orderID string, page int) (string, tgbotapi.InlineKeyboardMarkup, bool) {
+	lang := h.getUserLang(userID)
+	backRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), fmt.Sprintf("myord|page|%d", page)),
+	)
+	info, ok := h.getOrderStatus(orderID)
+	if !ok || info.UserID != userID {
+		return tr(lang, "myorders.not_found"), tgbotapi.NewInlineKeyboardMarkup(backRow), false
+	}
+	actions := h.customerOrderActionRows(info, lang, page)
+	rows := append(h.orderPaymentRows(info, lang), actions...)
+	kb := tgbotapi.NewInlineKeyboardMarkup(append(rows, backRow)...)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🧾 %s\n", orderTitle(info)))
+	sb.WriteString(fmt.Sprintf("🆔 OrderID: %s\n", info.OrderID))
+	if !info.CreatedAt.IsZero() {
+		sb.WriteString(tr(lang, "myorders.created", "time", info.CreatedAt.In(time.Local).Format("2006-01-02 15:04")) + "\n")
+	}
+	sb.WriteString(tr(lang, "myorders.status", "status", orderStatusIcon(info.Status)+" "+statusLabel(info.Status, lang)) + "\n")
+	if info.Total != "" {
+		sb.WriteString(tr(lang, "myorders.total", "total", h.formatOrderTotal(info)) + "\n")
+	}
+	if info.PaymentStatus != "" || len(h.payments.Names()) > 0 {
+		sb.WriteString(tr(lang, "myorders.payment", "status", paymentStatusLabel(info.PaymentStatus, lang)) + "\n")
+	}
+	if info.Delivery != "" {
+		sb.WriteString(tr(lang, "myorders.delivery", "delivery", deliveryDisplay(info.Delivery, lang)) + "\n")
+	}
+
+	events := h.listOrderEvents(orderID)
+	switch {
+	case info.Status == "delivered" || info.Status == "canceled":
+	case h.isAwaitingETA(orderID):
+		sb.WriteString(tr(lang, "myorders.eta_pending") + "\n")
+	default:
+		if eta, ok := latestOrderETA(events); ok {
+			sb.WriteString(tr(lang, "myorders.eta", "eta", eta.Note, "time", formatClock(eta.CreatedAt)) + "\n")
+		}
+	}
+
+	if len(events) > 0 {
+		sb.WriteString("\n" + tr(lang, "myorders.timeline") + "\n")
+		for _, ev := range events {
+			ts := ev.CreatedAt.In(time.Local).Format("01-02 15:04")
+			switch ev.Status {
+			case orderEventETA:
+				sb.WriteString(fmt.Sprintf("• %s — ⏱ %s\n", ts, ev.Note))
+			case orderEventEdit:
+				sb.WriteString(fmt.Sprintf("• %s — ✏️ %s\n", ts, ev.Note))
+			case orderEventPayment:
+				sb.WriteString(fmt.Sprintf("• %s — 💳 %s\n", ts, ev.Note))
+			case orderEventCourier:
+				// kuryer biriktirish - ichki yozuv, mijozga ko'rsatilmaydi
+			case orderEventProof:
+				sb.WriteString(fmt.Sprintf("• %s — 📸 %s\n", ts, tr(lang, "courier.timeline_proof")))
+			default:
+				line := fmt.Sprintf("• %s — %s %s", ts, orderStatusIcon(ev.Status), statusLabel(ev.Status, lang))
+				if ev.Note == orderNoteByCustomer {
+					line += " (" + tr(lang, "order.change.by_customer") + ")"
+				}
+				sb.WriteString(line + "\n")
+			}
+		}
+	}
+
+	if detail := nonEmpty(info.Summary, info.StatusSummary); strings.TrimSpace(detail) != "" {
+		sb.WriteString(fmt.Sprintf("\n%s:\n%s", tr(lang, "order.details_label"), detail))
+	}
+	if len(actions) > 0 {
+		left := h.orderGraceWindow() - time.Since(info.CreatedAt)
+		sb.WriteString("\n\n" + tr(lang, "order.change.window", "minutes", strconv.Itoa(int(left.Minutes())+1)))
+	}
+	return sb.String(), kb, true
+}
+
+// handleMyOrdersCallback myord|page|N, myord|view|ID|N, myord|push|on/off|N
+func (h *BotHandler) handleMyOrdersCallback(chatID, userID int64, data string, srcMsg *tgbotapi.Message) {
+	parts := strings.Split(data, "|")
+	if len(parts) < 3 {
+		return
+	}
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	var text string
+	var kb tgbotapi.InlineKeyboardMarkup
+	switch parts[1] {
+	case "page":
+		text, kb = h.buildMyOrdersPage(userID, atoi(parts[2]))
+	case "view":
+		page := 0
+		if len(parts) > 3 {
+			page = atoi(parts[3])
+		}
+		text, kb, _ = h.buildMyOrderDetail(userID, parts[2], page)
+	case "cancel", "addr", "switch", "cancel_ok":
+		if len(parts) < 4 {
+			return
+		}
+		orderID, page := parts[2], atoi(parts[3])
+		switch parts[1] {
+		case "cancel":
+			// Tasdiqlash so'raymiz
+			lang := h.getUserLang(userID)
+			text = tr(lang, "order.change.cancel_confirm", "order_id", orderID)
+			kb = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "order.change.cancel_yes"), fmt.Sprintf("myord|cancel_ok|%s|%d", orderID, page)),
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "common.back"), fmt.Sprintf("myord|view|%s|%d", orderID, page)),
+			))
+		case "cancel_ok":
+			h.cancelOrderByCustomer(chatID, userID, orderID)
+			text, kb, _ = h.buildMyOrderDetail(userID, orderID, page)
+		case "addr":
+			h.beginOrderAddressChange(chatID, userID, orderID, "")
+			return
+		case "switch":
+			h.switchOrderDelivery(chatID, userID, orderID)
+			text, kb, _ = h.buildMyOrderDetail(userID, orderID, page)
+		}
+	case "push":
+		enabled := parts[2] == "on"
+		lang := h.getUserLang(userID)
+		if err := h.setOrderPushEnabled(userID, enabled); err != nil {
+			log.Printf("order push toggle failed user=%d err=%v", userID, err)
+			h.sendMessage(chatID, tr(lang, "myorders.push_error"))
+			return
+		}
+		page := 0
+		if len(parts) > 3 {
+			page = atoi(parts[3])
+		}
+		text, kb = h.buildMyOrdersPage(userID, page)
+		if enabled {
+			text = tr(lang, "myorders.push_enabled") + "\n\n" + text
+		} else {
+			text = tr(lang, "myorders.push_disabled") + "\n\n" + text
+		}
+	default:
+		return
+	}
+
+	if srcMsg != nil && srcMsg.MessageID != 0 {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, srcMsg.MessageID, text, kb)
+		if _, err := h.sendAndLog(edit); err == nil {
+			return
+		}
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = kb
+	_, _ = h.sendAndLog(msg)
+}
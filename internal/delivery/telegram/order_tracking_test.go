@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMyOrderDetailTimeline - holat o'zgarishlari va ETA timeline'da ko'rinadi,
+// boshqa userning buyurtmasi esa ochilmaydi
+func TestMyOrderDetailTimeline(t *testing.T) {
+	h := &BotHandler{
+		orderStore:     newMemoryStore(),
+		orderStatuses:  make(map[string]orderStatusInfo),
+		pendingETAs:    make(map[int64]string),
+		pendingETAChat: make(map[string]string),
+		userLang:       map[int64]string{7: "en"},
+	}
+	const orderID = "01012026-01"
+	h.saveOrderStatus(orderID, orderStatusInfo{UserID: 7, UserChat: 7, Status: "processing", Delivery: "courier", CreatedAt: time.Now()})
+	h.setOrderStatus(orderID, "ready_delivery")
+	h.setOrderStatus(orderID, "ready_delivery") // takroriy holat yozilmaydi
+	h.setOrderStatus(orderID, "onway")
+
+	h.setPendingETA(99, orderID, 0, 0)
+	text, _, ok := h.buildMyOrderDetail(7, orderID, 0)
+	if !ok || !strings.Contains(text, "being estimated") {
+		t.Fatalf("ETA kutilmoqda ko'rinmadi:\n%s", text)
+	}
+
+	h.clearETA(orderID, 99, 0, 0)
+	h.recordOrderEvent(orderID, orderEventETA, "30 min")
+	text, _, _ = h.buildMyOrderDetail(7, orderID, 0)
+	if !strings.Contains(text, "Estimated arrival: 30 min") {
+		t.Fatalf("ETA ko'rinmadi:\n%s", text)
+	}
+	if events := h.listOrderEvents(orderID); len(events) != 4 {
+		t.Fatalf("timeline yozuvlari: %d (kutilgan 4)", len(events))
+	}
+
+	if _, _, ok := h.buildMyOrderDetail(8, orderID, 0); ok {
+		t.Fatalf("boshqa user buyurtmasi ochilmasligi kerak")
+	}
+}
@@ -31,21 +31,21 @@ func (h *BotHandler) handleOrderReadyCallback(chatID int64, threadID int, orderI
 	readyAlready := info.Status == "ready_delivery" || info.Status == "ready_pickup"
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("%s\n🆔 OrderID: %s\n", t(lang, "✅ Buyurtma tayyor!", "✅ Заказ готов!"), orderID))
+	sb.WriteString(fmt.Sprintf("%s\n🆔 OrderID: %s\n", tr(lang, "order.ready.header"), orderID))
 	if pickup {
 		status = "ready_pickup"
-		sb.WriteString(t(lang, "📍 Olib ketish: punktdan olib ketishingiz mumkin.", "📍 Самовывоз: можете забрать в пункте.") + "\n")
+		sb.WriteString(tr(lang, "order.ready.pickup") + "\n")
 	} else {
-		sb.WriteString(t(lang, "🚚 Yetkazish: tayyor.", "🚚 Доставка: готов.") + "\n")
+		sb.WriteString(tr(lang, "order.ready.courier") + "\n")
 	}
 
 	if info.Total != "" {
-		sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "💰 Jami", "💰 Итого"), h.formatTotalForDisplay(info.Total)))
+		sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "order.total_label"), h.formatOrderTotal(info)))
 	}
 
 	detail := nonEmpty(info.Summary, info.StatusSummary)
 	if detail != "" {
-		sb.WriteString(fmt.Sprintf("%s:\n%s\n", t(lang, "🧾 Tafsilotlar", "🧾 Детали"), detail))
+		sb.WriteString(fmt.Sprintf("%s:\n%s\n", tr(lang, "order.receipt_label"), detail))
 	}
 
 	if !readyAlready {
@@ -61,7 +61,7 @@ func (h *BotHandler) handleOrderReadyCallback(chatID int64, threadID int, orderI
 		nonEmpty(info.Phone, "ko'rsatilmagan"),
 		loc,
 		nonEmpty(deliveryDisplay(info.Delivery, "uz"), "-"),
-		nonEmpty(h.formatTotalForDisplay(info.Total), "-"),
+		nonEmpty(h.formatOrderTotal(info), "-"),
 		nonEmpty(info.Summary, info.StatusSummary),
 	)
 
@@ -95,7 +95,7 @@ func (h *BotHandler) handleOrderReadyCallback(chatID int64, threadID int, orderI
 			nonEmpty(info.Phone, "ko'rsatilmagan"),
 			loc,
 			nonEmpty(deliveryDisplay(info.Delivery, "uz"), "-"),
-			nonEmpty(h.applyCurrencyPreference(info.Total), "-"),
+			nonEmpty(h.orderCurrency(info).apply(info.Total), "-"),
 			grpSummary,
 		)
 		if _, err := h.sendText(h.group3ChatID, msg, "", nil, h.group3ThreadID); err != nil {
@@ -159,20 +159,23 @@ func (h *BotHandler) handleOrderCancelCallback(chatID int64, threadID int, order
 	if !canceledAlready {
 		var sb strings.Builder
 		sb.WriteString(fmt.Sprintf("%s\n🆔 OrderID: %s\n\n%s",
-			t(lang, "❌ Buyurtmangiz bekor qilindi.", "❌ Ваш заказ отменён."),
+			tr(lang, "order.canceled_notice"),
 			orderID,
-			t(lang, "🙏 Uzr, omborda hozirgina shu mahsulotimiz sotilib ketdi. 😔", "🙏 К сожалению, товар только что закончился на складе. 😔"),
+			tr(lang, "order.out_of_stock"),
 		))
 
 		detail := nonEmpty(info.Summary, info.StatusSummary)
 		if strings.TrimSpace(detail) != "" {
-			sb.WriteString(fmt.Sprintf("\n\n%s:\n%s", t(lang, "🧾 Tafsilotlar", "🧾 Детали"), detail))
+			sb.WriteString(fmt.Sprintf("\n\n%s:\n%s", tr(lang, "order.receipt_label"), detail))
 		}
 
 		h.sendMessage(info.UserChat, sb.String())
 	}
 
 	h.setOrderStatus(orderID, "canceled")
+	if !canceledAlready {
+		h.releasePromoUses(orderPromoIDs(info.Lines))
+	}
 
 	loc := nonEmpty(normalizeLocationText(info.Location), "ko'rsatilmagan")
 	editText := fmt.Sprintf("❌ Bekor qilingan buyurtma\nOrderID: %s\nUsername: @%s\nTelefon: %s\nManzil:\n%s\nYetkazish: %s\nJami: %s\n\n%s\n\nSabab: omborda qolmadi.",
@@ -181,7 +184,7 @@ func (h *BotHandler) handleOrderCancelCallback(chatID int64, threadID int, order
 		nonEmpty(info.Phone, "ko'rsatilmagan"),
 		loc,
 		nonEmpty(deliveryDisplay(info.Delivery, "uz"), "-"),
-		nonEmpty(h.formatTotalForDisplay(info.Total), "-"),
+		nonEmpty(h.formatOrderTotal(info), "-"),
 		nonEmpty(info.Summary, info.StatusSummary),
 	)
 
@@ -251,6 +254,7 @@ func (h *BotHandler) handleGroup2Message(ctx context.Context, message *tgbotapi.
 
 	// Muvaffaqiyatli bo'lsa, holatni tozalaymiz
 	h.clearETA(orderID, adminID, message.Chat.ID, threadID)
+	h.recordOrderEvent(orderID, orderEventETA, duration)
 
 	confirmMsg, _ := h.sendText(message.Chat.ID, fmt.Sprintf("🚚 Foydalanuvchiga yo'lga chiqdi (%s) deb yuborildi.", duration), "", nil, threadID)
 
@@ -264,7 +268,7 @@ func (h *BotHandler) handleGroup2Message(ctx context.Context, message *tgbotapi.
 			nonEmpty(info.Phone, "ko'rsatilmagan"),
 			loc,
 			nonEmpty(deliveryDisplay(info.Delivery, "uz"), "-"),
-			nonEmpty(h.formatTotalForDisplay(info.Total), "-"),
+			nonEmpty(h.formatOrderTotal(info), "-"),
 			grpSummary,
 		)
 		if _, err := h.sendText(h.group3ChatID, msg, "", nil, h.group3ThreadID); err != nil {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
 )
 
 // Order session helpers
@@ -34,6 +35,12 @@ func (h *BotHandler) startOrderSession(userID int64, info pendingApproval) {
 			session.Stage = orderStageNeedLocation
 		}
 	}
+	// Mijoz tanlagan filial: zaxira va buyurtma guruhi shu filial bo'yicha
+	if b, ok := h.preferredBranch(userID); ok {
+		session.Branch = &b
+		session.ReservedStock = b.stockKey()
+	}
+	h.enterConversation(userID, convFlowOrder, session.Stage.String(), info.UserChat)
 	h.orderMu.Lock()
 	h.orderSessions[userID] = session
 	h.orderMu.Unlock()
@@ -50,7 +57,7 @@ func (h *BotHandler) startOrderSession(userID int64, info pendingApproval) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
-	updated, reservedItems, err := h.adjustInventoryItems(ctx, items, -1)
+	updated, reservedItems, err := h.adjustInventoryItems(ctx, items, -1, session.ReservedStock)
 	if err != nil {
 		log.Printf("[inventory] config reserve failed user=%d err=%v", userID, err)
 		return
@@ -77,6 +84,7 @@ func (h *BotHandler) clearOrderSession(userID int64) {
 		delete(h.orderSessions, userID)
 	}
 	h.orderMu.Unlock()
+	h.leaveConversation(userID, convFlowOrder)
 
 	if ok && session != nil && session.ChatID != 0 {
 		// Reply keyboardni yashiramiz (telefon/location bosqichi tugagach)
@@ -112,10 +120,12 @@ func (h *BotHandler) stashOrderCleanupLocked(userID int64, session *orderSession
 
 func (h *BotHandler) releaseReservedInventory(userID int64) {
 	var items []string
+	stock := ""
 	h.orderMu.Lock()
 	session, ok := h.orderSessions[userID]
 	if ok && session != nil && session.InventoryReserved && len(session.ReservedItems) > 0 {
 		items = append(items, session.ReservedItems...)
+		stock = session.ReservedStock
 		session.InventoryReserved = false
 		session.ReservedItems = nil
 		h.orderSessions[userID] = session
@@ -129,7 +139,7 @@ func (h *BotHandler) releaseReservedInventory(userID int64) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
-	if updated, _, err := h.adjustInventoryItems(ctx, items, 1); err != nil {
+	if updated, _, err := h.adjustInventoryItems(ctx, items, 1, stock); err != nil {
 		log.Printf("[inventory] config release failed user=%d err=%v", userID, err)
 	} else if updated > 0 {
 		log.Printf("[inventory] config release user=%d items=%d", userID, updated)
@@ -177,7 +187,7 @@ func (h *BotHandler) handleOrderCommand(ctx context.Context, message *tgbotapi.M
 	lang := h.getUserLang(userID)
 	orders := h.listOrdersByUser(userID)
 	if len(orders) == 0 {
-		h.sendMessage(message.Chat.ID, t(lang, "Sizda hali buyurtmalar yo'q.", "У вас пока нет заказов."))
+		h.sendMessage(message.Chat.ID, tr(lang, "order.none"))
 		return
 	}
 	// Har bir buyurtma nomini tugma ko'rinishida chiqaramiz
@@ -192,9 +202,9 @@ func (h *BotHandler) handleOrderCommand(ctx context.Context, message *tgbotapi.M
 	}
 	// Barchasini tozalash tugmasi
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(t(lang, "🗑️ Barchasini tozalash", "🗑️ Очистить все"), "order_clear_all"),
+		tgbotapi.NewInlineKeyboardButtonData(tr(lang, "order.clear_all"), "order_clear_all"),
 	))
-	msg := tgbotapi.NewMessage(message.Chat.ID, t(lang, "🧾 Buyurtmalaringiz:", "🧾 Ваши заказы:"))
+	msg := tgbotapi.NewMessage(message.Chat.ID, trPlural(lang, "order.list_header", len(orders)))
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
 	_, _ = h.sendAndLog(msg)
 }
@@ -231,6 +241,11 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 		case orderStageNeedDeliveryConfirm:
 			h.sendMessage(chatID, "👍 Qabul qilindi! Dostavka narxiga rozimisiz?")
 			h.sendDeliveryConfirm(chatID)
+		case orderStageNeedInstallment:
+			h.sendMessage(chatID, "👍 Qabul qilindi! To'lov turini tanlang.")
+			h.sendInstallmentChoice(userID)
+		case orderStageNeedBranch:
+			h.sendBranchChoiceForm(userID, "")
 		}
 		return
 	}
@@ -240,13 +255,13 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 		session.Name = strings.TrimSpace(text)
 		if session.Name == "" {
 			lang := h.getUserLang(userID)
-			h.sendOrderForm(userID, t(lang, "Iltimos, to'liq ismingizni yozing.", "Пожалуйста, укажите полное имя."), nil)
+			h.sendOrderForm(userID, tr(lang, "checkout.ask_full_name"), nil)
 			return
 		}
 		// ✅ Ism validatsiyasi qo'shildi
 		if !validateName(session.Name) {
 			lang := h.getUserLang(userID)
-			h.sendOrderForm(userID, t(lang, "Noto'g'ri ism formati. Iltimos, ismingizni faqat harflar bilan kiriting (kamida 2 ta harf).", "Неверный формат имени. Укажите имя только буквами (минимум 2 буквы)."), nil)
+			h.sendOrderForm(userID, tr(lang, "checkout.invalid_name"), nil)
 			return
 		}
 		h.setProfile(userID, userProfile{Name: session.Name})
@@ -275,7 +290,7 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 		}
 		// ✅ Telefon validatsiyasi qo'shildi
 		if !validatePhoneNumber(phone) {
-			h.sendOrderForm(userID, t(h.getUserLang(userID), "Noto'g'ri telefon raqami! Kamida 7 ta raqam bo'lishi kerak. Masalan: +998901234567", "Неверный номер телефона! Минимум 7 цифр. Например: +998901234567"), nil)
+			h.sendOrderForm(userID, tr(h.getUserLang(userID), "profile.invalid_phone"), nil)
 			return
 		}
 		oldMsg := session.MessageID
@@ -303,27 +318,37 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 			return
 		}
 		session.Location = locText
+		session.Zone = h.quoteDelivery(session)
 		session.Stage = orderStageNeedDeliveryChoice
+		// Muddatli to'lov rejalari bo'lsa, avval to'lov turini so'raymiz
+		askInstallment := h.needsInstallmentChoice(session)
+		if askInstallment {
+			session.Stage = orderStageNeedInstallment
+		}
 		h.orderMu.Lock()
 		h.orderSessions[userID] = session
 		h.orderMu.Unlock()
 		h.hideReplyKeyboard(chatID)
-		kb := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(t(h.getUserLang(userID), "🏬 Olib ketaman", "🏬 Самовывоз"), "delivery_pickup"),
-				tgbotapi.NewInlineKeyboardButtonData(t(h.getUserLang(userID), "🚚 Dostavka", "🚚 Доставка"), "delivery_courier"),
-			),
-		)
-		h.sendOrderForm(userID, t(h.getUserLang(userID), "Mahsulotni qanday olasiz?", "Как хотите получить заказ?"), &kb)
+		if askInstallment {
+			h.sendInstallmentChoice(userID)
+		} else {
+			h.sendDeliveryChoiceForm(userID)
+		}
 		h.deleteUserMessage(chatID, msg)
 		return
+	case orderStageNeedInstallment:
+		// Kutamiz (inst| callbacklari bilan)
+		h.orderMu.Lock()
+		h.orderSessions[userID] = session
+		h.orderMu.Unlock()
+		return
 	case orderStageNeedDeliveryChoice:
 		// Kutamiz (callbacklar bilan)
 		h.orderMu.Lock()
 		h.orderSessions[userID] = session
 		h.orderMu.Unlock()
 		return
-	case orderStageNeedDeliveryConfirm:
+	case orderStageNeedDeliveryConfirm, orderStageNeedBranch:
 		// Kutamiz (callbacklar bilan)
 		h.orderMu.Lock()
 		h.orderSessions[userID] = session
@@ -349,12 +374,22 @@ func (h *BotHandler) handleOrderBack(userID, chatID int64, msg *tgbotapi.Message
 		session.Stage = orderStageNeedName
 	case orderStageNeedLocation:
 		session.Stage = orderStageNeedPhone
+	case orderStageNeedInstallment:
+		session.Stage = orderStageNeedLocation
+		session.Installment = installmentPlan{}
 	case orderStageNeedDeliveryChoice:
 		session.Stage = orderStageNeedLocation
+		if h.needsInstallmentChoice(session) {
+			session.Stage = orderStageNeedInstallment
+		}
 		session.Delivery = ""
 	case orderStageNeedDeliveryConfirm:
 		session.Stage = orderStageNeedDeliveryChoice
 		session.Delivery = ""
+	case orderStageNeedBranch:
+		session.Stage = orderStageNeedDeliveryChoice
+		session.Delivery = ""
+		session.PickupNote = ""
 	}
 	// Force new form send
 	session.MessageID = 0
@@ -364,19 +399,15 @@ func (h *BotHandler) handleOrderBack(userID, chatID int64, msg *tgbotapi.Message
 	lang := h.getUserLang(userID)
 	switch session.Stage {
 	case orderStageNeedName:
-		h.sendOrderForm(userID, t(lang, "📝 Iltimos, to'liq ismingizni yozing.", "📝 Пожалуйста, укажите полное имя."), nil)
+		h.sendOrderForm(userID, tr(lang, "checkout.ask_name"), nil)
 	case orderStageNeedPhone:
 		h.sendOrderForm(userID, "📞 Telefon raqamingizni yuboring.", nil)
 	case orderStageNeedLocation:
 		h.sendOrderForm(userID, "📍 Lokatsiyani yuboring yoki manzilni yozing.", nil)
+	case orderStageNeedInstallment:
+		h.sendInstallmentChoice(userID)
 	case orderStageNeedDeliveryChoice:
-		kb := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(t(lang, "🏬 Olib ketaman", "🏬 Самовывоз"), "delivery_pickup"),
-				tgbotapi.NewInlineKeyboardButtonData(t(lang, "🚚 Dostavka", "🚚 Доставка"), "delivery_courier"),
-			),
-		)
-		h.sendOrderForm(userID, t(lang, "Mahsulotni qanday olasiz?", "Как хотите получить заказ?"), &kb)
+		h.sendDeliveryChoiceForm(userID)
 	}
 
 	if msg != nil && oldMsg != 0 {
@@ -389,22 +420,25 @@ func (h *BotHandler) handleOrderViewCallback(chatID, userID int64, orderID strin
 	lang := h.getUserLang(userID)
 	info, ok := h.getOrderStatus(orderID)
 	if !ok {
-		h.sendMessage(chatID, t(lang, "❌ Buyurtma topilmadi.", "❌ Заказ не найден."))
+		h.sendMessage(chatID, tr(lang, "myorders.not_found"))
 		return
 	}
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("🧾 OrderID: %s\n", orderID))
-	sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "📌 Holat", "📌 Статус"), statusLabel(info.Status, lang)))
+	sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "myorders.status_label"), statusLabel(info.Status, lang)))
 	if info.Total != "" {
-		sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "💰 Jami", "💰 Итого"), info.Total))
+		sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "order.total_label"), info.Total))
 	}
 	if info.Delivery != "" {
-		sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "🚚 Yetkazish", "🚚 Доставка"), deliveryDisplay(info.Delivery, lang)))
+		sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "myorders.delivery_label"), deliveryDisplay(info.Delivery, lang)))
+	}
+	if info.PaymentStatus != "" || len(h.payments.Names()) > 0 {
+		sb.WriteString(tr(lang, "myorders.payment", "status", paymentStatusLabel(info.PaymentStatus, lang)) + "\n")
 	}
 	if strings.TrimSpace(info.Summary) != "" {
-		sb.WriteString(fmt.Sprintf("\n%s:\n%s", t(lang, "📝 Tafsilotlar", "📝 Детали"), info.Summary))
+		sb.WriteString(fmt.Sprintf("\n%s:\n%s", tr(lang, "order.details_label"), info.Summary))
 	} else if strings.TrimSpace(info.StatusSummary) != "" {
-		sb.WriteString(fmt.Sprintf("\n%s:\n%s", t(lang, "📝 Tafsilotlar", "📝 Детали"), info.StatusSummary))
+		sb.WriteString(fmt.Sprintf("\n%s:\n%s", tr(lang, "order.details_label"), info.StatusSummary))
 	}
 	h.sendMessage(chatID, sb.String())
 }
@@ -414,10 +448,10 @@ func (h *BotHandler) handleOrderClearAll(chatID, userID int64, msg *tgbotapi.Mes
 	lang := h.getUserLang(userID)
 	if err := h.clearOrdersForUser(userID); err != nil {
 		log.Printf("order clear all error user=%d err=%v", userID, err)
-		h.sendMessage(chatID, t(lang, "❌ Buyurtmalarni tozalab bo'lmadi.", "❌ Не удалось очистить заказы."))
+		h.sendMessage(chatID, tr(lang, "myorders.clear_failed"))
 		return
 	}
-	text := t(lang, "🗑️ Buyurtmalar tozalandi.", "🗑️ Заказы очищены.")
+	text := tr(lang, "myorders.cleared")
 	if msg != nil {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, msg.MessageID, text, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
 		if _, err := h.bot.Send(edit); err != nil {
@@ -450,20 +484,20 @@ func (h *BotHandler) handleDeliveryChoice(ctx context.Context, userID int64, cho
 	}
 
 	if choice == "pickup" {
-		lang := h.getUserLang(userID)
-		h.sendOrderForm(userID, t(lang, "✅ Buyurtmangiz qabul qilindi. Tayyor bo'lganda admin sizga bog'lanadi.", "✅ Заказ принят. Как будет готов, с вами свяжется админ."), nil)
-		h.sendOrderToGroup2(userID, session, "Olib ketish", "")
-		h.sendStickerIfConfigured(chatID, stickerSlotOrderPlaced)
-		h.clearOrderSession(userID)
+		// Filial ko'p bo'lsa avval olib ketish punkti so'raladi
+		if h.askPickupBranch(userID, session) {
+			return
+		}
+		h.finishPickupOrder(userID, chatID, session)
 	} else {
 		lang := h.getUserLang(userID)
 		kb := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(t(lang, "Ha ✅", "Да ✅"), "delivery_confirm_yes"),
-				tgbotapi.NewInlineKeyboardButtonData(t(lang, "Yo'q ❌", "Нет ❌"), "delivery_confirm_no"),
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "approval.yes"), "delivery_confirm_yes"),
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "approval.no"), "delivery_confirm_no"),
 			),
 		)
-		h.sendOrderForm(userID, t(lang, "🚚 Yetkazib berish Yandex Go orqali amalga oshiriladi. Operator sizga aniq narxni aytadi. Rozimisiz?", "🚚 Доставка будет осуществлена через Yandex Go. Оператор сообщит вам точную стоимость. Согласны?"), &kb)
+		h.sendOrderForm(userID, h.deliveryConfirmPrompt(lang, session), &kb)
 	}
 }
 
@@ -478,11 +512,15 @@ func (h *BotHandler) handleDeliveryConfirm(ctx context.Context, userID int64, ag
 
 	if !agree {
 		h.sendOrderForm(userID, "Unda buyurtmani olib ketish punktidan olib keting.", nil)
-		session.Delivery = "pickup"
 		h.orderMu.Lock()
+		session.Delivery = "pickup"
+		session.PickupNote = "Dostavka narxiga rozilik bermadi"
 		h.orderSessions[userID] = session
 		h.orderMu.Unlock()
-		h.sendOrderToGroup2(userID, session, "Olib ketish", "Dostavka narxiga rozilik bermadi")
+		if h.askPickupBranch(userID, session) {
+			return
+		}
+		h.sendOrderToGroup2(userID, session, "Olib ketish", session.PickupNote)
 		h.sendStickerIfConfigured(chatID, stickerSlotOrderPlaced)
 		h.clearOrderSession(userID)
 		return
@@ -490,10 +528,14 @@ func (h *BotHandler) handleDeliveryConfirm(ctx context.Context, userID int64, ag
 
 	// Ha bo'lsa
 	lang := h.getUserLang(userID)
-	h.sendOrderForm(userID, t(lang, "✅ Buyurtmangiz qabul qilindi. Tayyor bo'lganda admin sizga bog'lanadi.", "✅ Заказ принят. Как будет готов, с вами свяжется админ."), nil)
+	h.sendOrderForm(userID, tr(lang, "order.accepted_admin_contact"), nil)
 
-	if h.activeOrdersChatID != 0 {
-		h.sendOrderToGroup2(userID, session, "Dostavka (Yandex Go)", "Rozilik berildi")
+	branchID := ""
+	if session.Branch != nil {
+		branchID = session.Branch.ID
+	}
+	if orderChatID, _ := h.orderChannel(branchID); orderChatID != 0 {
+		h.sendOrderToGroup2(userID, session, deliveryAdminLabel(session), "Rozilik berildi")
 		h.sendStickerIfConfigured(chatID, stickerSlotOrderPlaced)
 	}
 	h.clearOrderSession(userID)
@@ -503,7 +545,7 @@ func (h *BotHandler) handleDeliveryConfirm(ctx context.Context, userID int64, ag
 func (h *BotHandler) sendPhoneRequest(chatID int64) {
 	lang := h.getUserLang(chatID)
 	kb := h.phoneRequestKeyboard(chatID)
-	msg := tgbotapi.NewMessage(chatID, t(lang, "📞 Telefon raqamingizni yuboring (yoki button bosib jo'nating).", "📞 Отправьте номер телефона (или нажмите кнопку)."))
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "profile.phone_prompt"))
 	msg.ReplyMarkup = kb
 	_, _ = h.sendAndLog(msg)
 }
@@ -511,7 +553,7 @@ func (h *BotHandler) sendPhoneRequest(chatID int64) {
 // sendPhonePrompt - telefon so'rovi va msgID qaytaradi (profil oqimi uchun)
 func (h *BotHandler) sendPhonePrompt(chatID int64, lang string) int {
 	kb := h.phoneRequestKeyboard(chatID)
-	msg := tgbotapi.NewMessage(chatID, t(lang, "📞 Telefon raqamingizni yuboring (yoki button bosib jo'nating).", "📞 Отправьте номер телефона (или нажмите кнопку)."))
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "profile.phone_prompt"))
 	msg.ReplyMarkup = kb
 	if sent, err := h.sendAndLog(msg); err == nil {
 		return sent.MessageID
@@ -521,8 +563,8 @@ func (h *BotHandler) sendPhonePrompt(chatID int64, lang string) int {
 
 func (h *BotHandler) phoneRequestKeyboard(chatID int64) tgbotapi.ReplyKeyboardMarkup {
 	lang := h.getUserLang(chatID)
-	btn := tgbotapi.NewKeyboardButtonContact(t(lang, "📞 Telefon raqamni jo'natish", "📞 Отправить номер телефона"))
-	back := tgbotapi.NewKeyboardButton(t(lang, "⬅️ Orqaga", "⬅️ Назад"))
+	btn := tgbotapi.NewKeyboardButtonContact(tr(lang, "profile.phone_button"))
+	back := tgbotapi.NewKeyboardButton(tr(lang, "common.back"))
 	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(btn),
 		tgbotapi.NewKeyboardButtonRow(back),
@@ -535,15 +577,15 @@ func (h *BotHandler) phoneRequestKeyboard(chatID int64) tgbotapi.ReplyKeyboardMa
 func (h *BotHandler) sendLocationRequest(chatID int64) {
 	lang := h.getUserLang(chatID)
 	kb := h.locationRequestKeyboard(chatID)
-	msg := tgbotapi.NewMessage(chatID, t(lang, "📍 Lokatsiyangizni yuboring yoki manzilni matn ko'rinishida yozing.", "📍 Отправьте геолокацию или адрес текстом."))
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "checkout.ask_location"))
 	msg.ReplyMarkup = kb
 	_, _ = h.sendAndLog(msg)
 }
 
 func (h *BotHandler) locationRequestKeyboard(chatID int64) tgbotapi.ReplyKeyboardMarkup {
 	lang := h.getUserLang(chatID)
-	locBtn := tgbotapi.NewKeyboardButtonLocation(t(lang, "📍 Lokatsiyani yuborish", "📍 Отправить локацию"))
-	back := tgbotapi.NewKeyboardButton(t(lang, "⬅️ Orqaga", "⬅️ Назад"))
+	locBtn := tgbotapi.NewKeyboardButtonLocation(tr(lang, "checkout.location_button"))
+	back := tgbotapi.NewKeyboardButton(tr(lang, "common.back"))
 	kb := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(locBtn),
 		tgbotapi.NewKeyboardButtonRow(back),
@@ -557,24 +599,27 @@ func (h *BotHandler) sendDeliveryChoice(chatID int64) {
 	lang := h.getUserLang(chatID)
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🏬 Olib ketaman", "🏬 Самовывоз"), "delivery_pickup"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🚚 Dostavka", "🚚 Доставка"), "delivery_courier"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "delivery.choice.pickup"), "delivery_pickup"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "delivery.choice.courier"), "delivery_courier"),
 		),
 	)
-	msg := tgbotapi.NewMessage(chatID, t(lang, "Mahsulotni qanday olasiz?", "Как хотите получить заказ?"))
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "delivery.choice.prompt"))
 	msg.ReplyMarkup = markup
 	_, _ = h.sendAndLog(msg)
 }
 
 func (h *BotHandler) sendDeliveryConfirm(chatID int64) {
 	lang := h.getUserLang(chatID)
+	h.orderMu.RLock()
+	session := h.orderSessions[chatID]
+	h.orderMu.RUnlock()
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Ha ✅", "Да ✅"), "delivery_confirm_yes"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "Yo'q ❌", "Нет ❌"), "delivery_confirm_no"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "approval.yes"), "delivery_confirm_yes"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "approval.no"), "delivery_confirm_no"),
 		),
 	)
-	msg := tgbotapi.NewMessage(chatID, t(lang, "🚚 Yetkazib berish Yandex Go orqali amalga oshiriladi. Operator sizga aniq narxni aytadi. Rozimisiz?", "🚚 Доставка будет осуществлена через Yandex Go. Оператор сообщит вам точную стоимость. Согласны?"))
+	msg := tgbotapi.NewMessage(chatID, h.deliveryConfirmPrompt(lang, session))
 	msg.ReplyMarkup = markup
 	_, _ = h.sendAndLog(msg)
 }
@@ -591,17 +636,24 @@ func (h *BotHandler) sendOrderForm(userID int64, prompt string, kb *tgbotapi.Inl
 	if strings.TrimSpace(prompt) == "" {
 		switch sess.Stage {
 		case orderStageNeedName:
-			prompt = t(lang, "📝 Iltimos, to'liq ismingizni yozing.", "📝 Пожалуйста, укажите полное имя.")
+			prompt = tr(lang, "checkout.ask_name")
 		case orderStageNeedPhone:
-			prompt = t(lang, "📞 Telefon raqamingizni yuboring.", "📞 Отправьте номер телефона.")
+			prompt = tr(lang, "checkout.ask_phone")
 		case orderStageNeedLocation:
-			prompt = t(lang, "📍 Lokatsiyani yuboring yoki manzilni yozing.", "📍 Отправьте локацию или укажите адрес.")
+			prompt = tr(lang, "checkout.ask_address")
 		case orderStageNeedDeliveryChoice:
-			prompt = t(lang, "Mahsulotni qanday olasiz?", "Как хотите получить заказ?")
+			prompt = tr(lang, "delivery.choice.prompt")
 		case orderStageNeedDeliveryConfirm:
-			prompt = t(lang, "🚚 Yetkazib berish Yandex Go orqali amalga oshiriladi. Operator sizga aniq narxni aytadi. Rozimisiz?", "🚚 Доставка будет осуществлена через Yandex Go. Оператор сообщит вам точную стоимость. Согласны?")
+			prompt = h.deliveryConfirmPrompt(lang, sess)
+		case orderStageNeedInstallment:
+			prompt = tr(lang, "installment.choose")
+		case orderStageNeedBranch:
+			prompt = tr(lang, "branch.choose")
 		}
 	}
+	if sess.PromoCode == "" && sess.Stage != orderStageNeedName && h.promotionUseCase != nil && h.promotionUseCase.HasCodes(context.Background()) {
+		prompt += "\n\n" + tr(lang, "promo.form_hint")
+	}
 	text := renderOrderForm(sess, lang, prompt)
 	var inlineKB *tgbotapi.InlineKeyboardMarkup
 	if kb != nil {
@@ -620,7 +672,7 @@ func (h *BotHandler) sendOrderForm(userID int64, prompt string, kb *tgbotapi.Inl
 
 	// Orqaga tugmasi (reply keyboard bo'lmasa)
 	if sess.Stage != orderStageNeedName && replyKB == nil {
-		backBtn := tgbotapi.NewInlineKeyboardButtonData(t(lang, "❌ Yopish", "❌ Закрыть"), "order_close")
+		backBtn := tgbotapi.NewInlineKeyboardButtonData(tr(lang, "checkout.close"), "order_close")
 		if inlineKB == nil {
 			tmp := tgbotapi.NewInlineKeyboardMarkup(
 				tgbotapi.NewInlineKeyboardRow(backBtn),
@@ -664,12 +716,27 @@ func (h *BotHandler) sendOrderForm(userID int64, prompt string, kb *tgbotapi.Inl
 
 func renderOrderForm(sess *orderSession, lang, prompt string) string {
 	var sb strings.Builder
-	sb.WriteString(t(lang, "🧾 Buyurtma ma'lumotlari:\n", "🧾 Данные заказа:\n"))
-	sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "Ism", "Имя"), nonEmpty(sess.Name, t(lang, "kiritilmagan", "не указано"))))
-	sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "Telefon", "Телефон"), nonEmpty(sess.Phone, t(lang, "kiritilmagan", "не указан"))))
-	sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "Manzil", "Адрес"), nonEmpty(sess.Location, t(lang, "kiritilmagan", "не указан"))))
+	sb.WriteString(tr(lang, "checkout.summary_header"))
+	sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "checkout.name_label"), nonEmpty(sess.Name, tr(lang, "checkout.name_missing"))))
+	sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "checkout.phone_label"), nonEmpty(sess.Phone, tr(lang, "checkout.value_missing"))))
+	sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "checkout.address_label"), nonEmpty(sess.Location, tr(lang, "checkout.value_missing"))))
+	if sess.Zone != nil {
+		sb.WriteString(deliveryZoneLines(lang, sess.Zone) + "\n")
+	}
+	if sess.Installment.Months > 0 {
+		sb.WriteString(tr(lang, "installment.form_line", "months", sess.Installment.Months) + "\n")
+	}
 	if sess.Delivery != "" {
-		sb.WriteString(fmt.Sprintf("%s: %s\n", t(lang, "Yetkazish", "Доставка"), deliveryDisplay(sess.Delivery, lang)))
+		sb.WriteString(fmt.Sprintf("%s: %s\n", tr(lang, "checkout.delivery_label"), deliveryDisplay(sess.Delivery, lang)))
+		if sess.Delivery == "pickup" && sess.Branch != nil {
+			sb.WriteString(tr(lang, "branch.form_line", "branch", sess.Branch.label()) + "\n")
+		}
+		if sess.Delivery == "courier" && sess.Zone != nil && !sess.Zone.Total.IsZero() {
+			sb.WriteString(tr(lang, "delivery.total_line", "total", sess.Zone.Total.String()) + "\n")
+		}
+	}
+	if sess.PromoCode != "" {
+		sb.WriteString(tr(lang, "promo.form_line", "code", sess.PromoCode) + "\n")
 	}
 	if prompt != "" {
 		sb.WriteString("\n")
@@ -832,7 +899,39 @@ func (h *BotHandler) sendOrderToGroup2(userID int64, session *orderSession, deli
 		totalPrice = sumPriceLines(specBlock)
 	}
 
-	totalPrice = h.formatTotalForDisplay(totalPrice)
+	// Aksiyalar: katalog narxi bo'yicha qayta hisoblanadi, chegirma bo'lsa jami shundan.
+	// Foydalanish limiti shu yerda atomar band qilinadi; buyurtma yozilmasa qaytariladi
+	promo := h.redeemCheckoutPromo(session, h.checkoutQuote(session))
+	if !promo.Discount.IsZero() {
+		totalPrice = promo.Total.String()
+	}
+	promoCode := ""
+	if session.PromoCode != "" && promo.Code == strings.ToUpper(session.PromoCode) && len(promo.Applied) > 0 {
+		promoCode = promo.Code
+	}
+
+	// Kurs snapshot: keyingi kurs o'zgarishlari bu buyurtma summasiga ta'sir qilmaydi
+	currency := h.currencySnapshot()
+	totalPrice = currency.formatTotal(totalPrice)
+	totalMoney, _ := parseTotalMoney(totalPrice)
+	// Yetkazish hududi narxi jamiga qo'shiladi
+	deliveryZone := ""
+	var deliveryFee entity.Money
+	var zoneQuote *deliveryQuote
+	if session.Delivery == "courier" {
+		zoneQuote = h.checkoutDeliveryQuote(session, totalMoney, currency.converter())
+	}
+	if zoneQuote != nil {
+		deliveryZone, deliveryFee = zoneQuote.Zone, zoneQuote.Fee
+		if !deliveryFee.IsZero() && totalMoney.Amount > 0 {
+			if sum, err := entity.Sum(currency.converter(), totalMoney.Currency, totalMoney, deliveryFee); err == nil {
+				totalMoney = sum
+				totalPrice = currency.formatTotal(sum.String())
+			} else {
+				log.Printf("delivery fee not added to order total: %v", err)
+			}
+		}
+	}
 	// PC konfiguratsiya orderlarni aniqlash: ConfigTxt mavjud bo'lsa, bu konfiguratsiyadan kelgan
 	isConfig := strings.TrimSpace(session.ConfigTxt) != ""
 	var displaySummary string
@@ -879,18 +978,44 @@ func (h *BotHandler) sendOrderToGroup2(userID int64, session *orderSession, deli
 	if totalPrice != "" {
 		orderText += fmt.Sprintf("\nJami: %s", totalPrice)
 	}
+	branchID, branchStock := "", ""
+	if session.Branch != nil {
+		branchID, branchStock = session.Branch.ID, session.Branch.stockKey()
+		orderText += fmt.Sprintf("\n🏬 Filial: %s", session.Branch.label())
+	}
+	if deliveryZone != "" {
+		orderText += fmt.Sprintf("\n🚚 Yetkazish narxi: %s (%s hududi)", deliveryFeeLabel(deliveryFee), deliveryZone)
+		if zoneQuote.ETA != "" {
+			orderText += fmt.Sprintf(", taxminan %s", zoneQuote.ETA)
+		}
+	}
+	if !promo.Discount.IsZero() {
+		orderText += fmt.Sprintf("\n🏷 Chegirma: -%s (asl narx %s)", promo.Discount, promo.Original)
+		if promoCode != "" {
+			orderText += fmt.Sprintf(", promo kod %s", promoCode)
+		}
+	}
+	installment := ""
+	var installmentDown entity.Money
+	if session.Installment.Months > 0 {
+		installment = h.installmentAdminLabel(session.Installment, totalPrice)
+		installmentDown = installmentDownPayment(session.Installment, totalMoney)
+		orderText += fmt.Sprintf("\n📅 Muddatli to'lov: %s", installment)
+	}
 	orderText += fmt.Sprintf("\n\n%s", displaySummary)
 
-	// Logistika (group_4/Topic 8) uchun - faqat active orders uchun
-	if h.activeOrdersChatID != 0 {
+	// Logistika (group_4/Topic 8) uchun - faqat active orders uchun; filial guruhi bo'lsa o'sha yerga
+	orderChatID, orderThreadID := h.orderChannel(branchID)
+	if orderChatID != 0 {
 		markup := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
 				tgbotapi.NewInlineKeyboardButtonData("✅ Tayyor", "order_ready|"+orderID),
 				tgbotapi.NewInlineKeyboardButtonData("❌ Bekor qilish", "order_cancel|"+orderID),
 			),
 		)
-		if msg, err := h.sendText(h.activeOrdersChatID, orderText, "", markup, h.activeOrdersThreadID); err != nil {
+		if msg, err := h.sendText(orderChatID, orderText, "", markup, orderThreadID); err != nil {
 			log.Printf("Group order message send error: %v", err)
+			h.releasePromoUses(promo.Applied)
 		} else {
 			// Order status va mapping saqlash
 			h.saveOrderStatus(orderID, orderStatusInfo{
@@ -906,18 +1031,33 @@ func (h *BotHandler) sendOrderToGroup2(userID int64, session *orderSession, deli
 				IsSingleItem:    isSingle,
 				Delivery:        session.Delivery,
 				Total:           totalPrice,
+				Installment:     installment,
+				InstallmentPlan: session.Installment,
+				InstallmentDown: installmentDown,
+				CurrencyRate:    currency.Rate,
+				TotalMoney:      totalMoney,
+				PromoCode:       promoCode,
+				Lines:           promo.Lines,
+				DeliveryZone:    deliveryZone,
+				DeliveryFee:     deliveryFee,
+				Branch:          branchID,
 				Status:          "processing",
 				ActiveChatID:    msg.Chat.ID,
-				ActiveThreadID:  h.activeOrdersThreadID,
+				ActiveThreadID:  orderThreadID,
 				ActiveMessageID: msg.MessageID,
 				CreatedAt:       time.Now(),
 			})
 			skipInventory := isConfig && session != nil && session.InventoryReserved
-			h.syncInventoryAfterOrder(displaySummary, skipInventory)
-			// Agar logistika kanali group_2 bo'lsa, reply uchun mapping ham shu yerda saqlanadi
-			if h.group2ChatID != 0 &&
-				h.activeOrdersChatID == h.group2ChatID &&
-				(h.group2ThreadID == 0 || h.activeOrdersThreadID == h.group2ThreadID) {
+			h.syncInventoryAfterOrder(displaySummary, branchStock, skipInventory)
+			h.offerOrderPayment(session.ChatID, userID, orderID)
+			if isConfig {
+				h.sendOrderSpecSheet(userID, msg.Chat.ID, orderThreadID, msg.MessageID, orderID, session.ConfigTxt, totalPrice)
+			}
+			// Agar logistika kanali group_2 yoki filial guruhi bo'lsa, reply/tugmalar uchun mapping shu yerda saqlanadi
+			toGroup2 := h.group2ChatID != 0 &&
+				orderChatID == h.group2ChatID &&
+				(h.group2ThreadID == 0 || orderThreadID == h.group2ThreadID)
+			if toGroup2 || orderChatID != h.activeOrdersChatID {
 				h.saveGroupThread(msg.MessageID, groupThreadInfo{
 					UserID:    userID,
 					UserChat:  session.ChatID,
@@ -926,11 +1066,13 @@ func (h *BotHandler) sendOrderToGroup2(userID int64, session *orderSession, deli
 					Config:    session.ConfigTxt,
 					OrderID:   orderID,
 					ChatID:    msg.Chat.ID,
-					ThreadID:  h.activeOrdersThreadID,
+					ThreadID:  orderThreadID,
 					CreatedAt: time.Now(),
 				})
 			}
 		}
+	} else {
+		h.releasePromoUses(promo.Applied)
 	}
 
 	// NOTE: Topic 4 va Topic 6 ga yuborish approvaldan OLDIN bo'lishi kerak
@@ -938,10 +1080,7 @@ func (h *BotHandler) sendOrderToGroup2(userID int64, session *orderSession, deli
 	// Demak faqat Topic 8 (active orders) uchun
 	if session != nil && session.ChatID != 0 && shouldNotifyAfterHours(time.Now()) {
 		lang := h.getUserLang(userID)
-		h.sendMessage(session.ChatID, t(lang,
-			"🕒 Hozir ish vaqti tugagan. Iltimos, ertalabgacha kuting — adminlar imkon qadar tez javob berishadi.",
-			"🕒 Сейчас вне рабочего времени. Пожалуйста, дождитесь утра — админы ответят при первой возможности.",
-		))
+		h.sendMessage(session.ChatID, tr(lang, "checkout.after_hours"))
 	}
 
 	// Order to'liq rasmiylashtirilib tugadi:
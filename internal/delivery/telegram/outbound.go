@@ -0,0 +1,62 @@
+package telegram
+
+import (
+	"context"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Chiquvchi navbat: ko'p foydalanuvchiga birdaniga ketadigan xabarlar (obuna xabarnomalari)
+// bitta goroutine orqali, Telegram flood limitiga tushmaslik uchun oraliq bilan yuboriladi.
+
+const (
+	outboundQueueSize = 1000
+	outboundInterval  = 50 * time.Millisecond // Anti-flood (~20 xabar/s)
+)
+
+type outboundQueue struct {
+	messages chan tgbotapi.Chattable
+}
+
+func newOutboundQueue() *outboundQueue {
+	return &outboundQueue{messages: make(chan tgbotapi.Chattable, outboundQueueSize)}
+}
+
+// run navbatdagi xabarlarni ketma-ket yuboradi
+func (q *outboundQueue) run(ctx context.Context, h *BotHandler) {
+	ticker := time.NewTicker(outboundInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-q.messages:
+			if _, err := h.sendAndLog(msg); err != nil {
+				log.Printf("outbound send failed chat=%d: %v", chattableChatID(msg), err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// enqueueOutbound xabarni navbatga qo'yadi; navbat bo'lmasa darhol yuboradi.
+// Navbat to'lgan bo'lsa xabar tashlanadi (false).
+func (h *BotHandler) enqueueOutbound(msg tgbotapi.Chattable) bool {
+	if h.outbound == nil {
+		_, err := h.sendAndLog(msg)
+		return err == nil
+	}
+	select {
+	case h.outbound.messages <- msg:
+		return true
+	default:
+		log.Printf("outbound queue full, dropped message chat=%d", chattableChatID(msg))
+		return false
+	}
+}
@@ -0,0 +1,219 @@
+package telegram
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/payment"
+)
+
+// PaymentStore buyurtma invoice'larini saqlash uchun
+type PaymentStore interface {
+	Save(ctx context.Context, inv payment.Invoice) error
+	Get(ctx context.Context, invoiceID string) (payment.Invoice, bool, error)
+	// GetByExternal provayder tranzaksiya ID si bo'yicha (Payme Perform/Cancel faqat shuni yuboradi)
+	GetByExternal(ctx context.Context, provider, externalID string) (payment.Invoice, bool, error)
+	ListByOrder(ctx context.Context, orderID string) ([]payment.Invoice, error)
+}
+
+// memoryPaymentStore fallback (server ish davomida)
+type memoryPaymentStore struct {
+	mu   sync.RWMutex
+	data map[string]payment.Invoice
+}
+
+func newMemoryPaymentStore() *memoryPaymentStore {
+	return &memoryPaymentStore{data: make(map[string]payment.Invoice)}
+}
+
+func (m *memoryPaymentStore) Save(_ context.Context, inv payment.Invoice) error {
+	m.mu.Lock()
+	m.data[inv.ID] = inv
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *memoryPaymentStore) Get(_ context.Context, invoiceID string) (payment.Invoice, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	inv, ok := m.data[invoiceID]
+	return inv, ok, nil
+}
+
+func (m *memoryPaymentStore) GetByExternal(_ context.Context, provider, externalID string) (payment.Invoice, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, inv := range m.data {
+		if inv.Provider == provider && inv.ExternalID != "" && inv.ExternalID == externalID {
+			return inv, true, nil
+		}
+	}
+	return payment.Invoice{}, false, nil
+}
+
+func (m *memoryPaymentStore) ListByOrder(_ context.Context, orderID string) ([]payment.Invoice, error) {
+	m.mu.RLock()
+	var res []payment.Invoice
+	for _, inv := range m.data {
+		if inv.OrderID == orderID {
+			res = append(res, inv)
+		}
+	}
+	m.mu.RUnlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].CreatedAt.Before(res[j].CreatedAt) })
+	return res, nil
+}
+
+// postgresPaymentStore persistent saqlash
+type postgresPaymentStore struct {
+	db *sql.DB
+}
+
+func newPostgresPaymentStore(dsn string) (*postgresPaymentStore, error) {
+	db, err := openPostgresWithRetry(dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(5)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(30 * time.Minute)
+
+	schema := `
+CREATE TABLE IF NOT EXISTS payments (
+	invoice_id TEXT PRIMARY KEY,
+	order_id TEXT NOT NULL,
+	user_id BIGINT NOT NULL,
+	chat_id BIGINT NOT NULL,
+	provider TEXT NOT NULL,
+	amount BIGINT NOT NULL,
+	currency TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL,
+	external_id TEXT NOT NULL DEFAULT '',
+	pay_url TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	paid_at TIMESTAMPTZ,
+	canceled_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_payments_order ON payments (order_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payments_external ON payments (provider, external_id);
+`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("create payments table: %w", err)
+	}
+	return &postgresPaymentStore{db: db}, nil
+}
+
+const paymentSelectColumns = `invoice_id, order_id, user_id, chat_id, provider, amount, currency, description, status, external_id, pay_url, created_at, updated_at, paid_at, canceled_at`
+
+func scanInvoice(scan func(dest ...interface{}) error) (payment.Invoice, error) {
+	var inv payment.Invoice
+	var status string
+	var paidAt, canceledAt sql.NullTime
+	if err := scan(&inv.ID, &inv.OrderID, &inv.UserID, &inv.ChatID, &inv.Provider, &inv.Amount, &inv.Currency, &inv.Description, &status, &inv.ExternalID, &inv.PayURL, &inv.CreatedAt, &inv.UpdatedAt, &paidAt, &canceledAt); err != nil {
+		return payment.Invoice{}, err
+	}
+	inv.Status = payment.Status(status)
+	if paidAt.Valid {
+		inv.PaidAt = paidAt.Time
+	}
+	if canceledAt.Valid {
+		inv.CanceledAt = canceledAt.Time
+	}
+	return inv, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func (p *postgresPaymentStore) Save(ctx context.Context, inv payment.Invoice) error {
+	if inv.CreatedAt.IsZero() {
+		inv.CreatedAt = time.Now()
+	}
+	if inv.UpdatedAt.IsZero() {
+		inv.UpdatedAt = inv.CreatedAt
+	}
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO payments (`+paymentSelectColumns+`)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)
+	ON CONFLICT (invoice_id) DO UPDATE SET
+		status=EXCLUDED.status,
+		external_id=EXCLUDED.external_id,
+		pay_url=EXCLUDED.pay_url,
+		updated_at=EXCLUDED.updated_at,
+		paid_at=EXCLUDED.paid_at,
+		canceled_at=EXCLUDED.canceled_at
+	`, inv.ID, inv.OrderID, inv.UserID, inv.ChatID, inv.Provider, inv.Amount, inv.Currency, inv.Description, string(inv.Status), inv.ExternalID, inv.PayURL, inv.CreatedAt, inv.UpdatedAt, nullTime(inv.PaidAt), nullTime(inv.CanceledAt))
+	return err
+}
+
+func (p *postgresPaymentStore) Get(ctx context.Context, invoiceID string) (payment.Invoice, bool, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+paymentSelectColumns+` FROM payments WHERE invoice_id=$1`, invoiceID)
+	inv, err := scanInvoice(row.Scan)
+	if err == sql.ErrNoRows {
+		return payment.Invoice{}, false, nil
+	}
+	if err != nil {
+		return payment.Invoice{}, false, err
+	}
+	return inv, true, nil
+}
+
+func (p *postgresPaymentStore) GetByExternal(ctx context.Context, provider, externalID string) (payment.Invoice, bool, error) {
+	if strings.TrimSpace(externalID) == "" {
+		return payment.Invoice{}, false, nil
+	}
+	row := p.db.QueryRowContext(ctx, `SELECT `+paymentSelectColumns+` FROM payments WHERE provider=$1 AND external_id=$2 ORDER BY created_at DESC LIMIT 1`, provider, externalID)
+	inv, err := scanInvoice(row.Scan)
+	if err == sql.ErrNoRows {
+		return payment.Invoice{}, false, nil
+	}
+	if err != nil {
+		return payment.Invoice{}, false, err
+	}
+	return inv, true, nil
+}
+
+func (p *postgresPaymentStore) ListByOrder(ctx context.Context, orderID string) ([]payment.Invoice, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT `+paymentSelectColumns+` FROM payments WHERE order_id=$1 ORDER BY created_at`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []payment.Invoice
+	for rows.Next() {
+		inv, err := scanInvoice(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, inv)
+	}
+	return res, rows.Err()
+}
+
+// newPaymentStoreFromEnv DSN berilsa Postgres, aks holda memory
+func newPaymentStoreFromEnv() (PaymentStore, error) {
+	dsn := strings.TrimSpace(os.Getenv("POSTGRES_DSN"))
+	if dsn == "" {
+		dsn = buildPostgresDSNFromEnv()
+	}
+	if strings.TrimSpace(dsn) == "" {
+		return newMemoryPaymentStore(), nil
+	}
+	store, err := newPostgresPaymentStore(dsn)
+	if err != nil {
+		log.Printf("payment store: Postgres ulanmadi, memoryStore ga qaytdi: %v", err)
+		return newMemoryPaymentStore(), nil
+	}
+	return store, nil
+}
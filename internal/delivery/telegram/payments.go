@@ -0,0 +1,628 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/payment"
+)
+
+// Onlayn to'lov: buyurtma jami summasidan invoice, provayder callbacklari,
+// buyurtmadagi to'lov holati va refund (zaxirani omborga qaytarish bilan).
+
+const (
+	// orderEventPayment - to'lov/refund timeline yozuvi
+	orderEventPayment = "payment"
+	// paymentCallbackPath - merchant callbacklari: POST /payments/<provider>
+	paymentCallbackPath    = "/payments/"
+	defaultPaymentCurrency = "UZS"
+)
+
+func paymentCurrencyFromEnv() string {
+	if cur := strings.ToUpper(strings.TrimSpace(os.Getenv("PAYMENT_CURRENCY"))); cur != "" {
+		return cur
+	}
+	return defaultPaymentCurrency
+}
+
+// newPaymentRegistryFromEnv kaliti berilgan provayderlarni yoqadi; hech biri bo'lmasa nil
+func newPaymentRegistryFromEnv() *payment.Registry {
+	var providers []payment.Provider
+	if token := strings.TrimSpace(os.Getenv("TELEGRAM_PAYMENT_TOKEN")); token != "" {
+		providers = append(providers, payment.NewTelegramProvider(token, paymentCurrencyFromEnv()))
+	}
+	if sid, mid, key := os.Getenv("CLICK_SERVICE_ID"), os.Getenv("CLICK_MERCHANT_ID"), os.Getenv("CLICK_SECRET_KEY"); strings.TrimSpace(sid) != "" && strings.TrimSpace(mid) != "" && strings.TrimSpace(key) != "" {
+		providers = append(providers, payment.NewClickProvider(sid, mid, key, os.Getenv("CLICK_RETURN_URL")))
+	}
+	if mid, key := os.Getenv("PAYME_MERCHANT_ID"), os.Getenv("PAYME_KEY"); strings.TrimSpace(mid) != "" && strings.TrimSpace(key) != "" {
+		p := payment.NewPaymeProvider(mid, key)
+		p.CheckoutURL = strings.TrimSpace(os.Getenv("PAYME_CHECKOUT_URL"))
+		providers = append(providers, p)
+	}
+	if fake, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("PAYMENT_FAKE"))); fake {
+		// Imzosiz fake callback istalgan buyurtmani "to'langan" qila oladi
+		if secret := strings.TrimSpace(os.Getenv("PAYMENT_FAKE_SECRET")); secret != "" {
+			providers = append(providers, payment.NewFakeProvider(secret))
+		} else {
+			log.Printf("payments: PAYMENT_FAKE_SECRET bo'sh - fake provayder yoqilmadi")
+		}
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+	reg := payment.NewRegistry(providers...)
+	log.Printf("payments: provayderlar yoqildi: %s", strings.Join(reg.Names(), ", "))
+	return reg
+}
+
+func paymentProviderTitle(name string) string {
+	switch name {
+	case payment.TelegramName:
+		return "Telegram"
+	case payment.ClickName:
+		return "Click"
+	case payment.PaymeName:
+		return "Payme"
+	case payment.FakeName:
+		return "Test"
+	default:
+		return name
+	}
+}
+
+// orderPaymentAmount buyurtma jami summasini (muddatli to'lovda - boshlang'ich to'lovni)
+// to'lov valyutasining eng kichik birligiga o'giradi (kurs - buyurtma yaratilgandagi snapshot)
+func (h *BotHandler) orderPaymentAmount(info orderStatusInfo) (int64, error) {
+	total, ok := orderTotalMoney(info)
+	if !ok {
+		return 0, fmt.Errorf("total has no price: %q", info.Total)
+	}
+	if info.InstallmentPlan.Months > 0 || info.Installment != "" {
+		// Qolgani oyma-oy do'konda to'lanadi; boshlang'ich to'lovsiz reja onlayn to'lanmaydi
+		if info.InstallmentDown.Amount <= 0 {
+			return 0, errors.New("installment order has no online down payment")
+		}
+		total = info.InstallmentDown
+	}
+	// Yaxlitlash maqsad valyuta qoidasi bo'yicha (UZS - butun so'm)
+	amount, err := h.orderCurrency(info).converter().Convert(total, h.paymentCurrency)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert %s to %s: %w", total.Currency, h.paymentCurrency, err)
+	}
+	return amount.Amount, nil
+}
+
+// formatPaymentAmount eng kichik birlikdagi summani ko'rsatish uchun
+func formatPaymentAmount(amount int64, currency string) string {
+	switch strings.ToUpper(currency) {
+	case "UZS":
+		s := strconv.FormatInt(amount/100, 10)
+		var parts []string
+		for len(s) > 3 {
+			parts = append([]string{s[len(s)-3:]}, parts...)
+			s = s[:len(s)-3]
+		}
+		parts = append([]string{s}, parts...)
+		return strings.Join(parts, " ") + " so'm"
+	case "USD":
+		return fmt.Sprintf("%d.%02d$", amount/100, amount%100)
+	default:
+		return fmt.Sprintf("%d.%02d %s", amount/100, amount%100, currency)
+	}
+}
+
+func paymentStatusLabel(status, lang string) string {
+	switch payment.Status(status) {
+	case payment.StatusPaid, payment.StatusRefunded:
+		return tr(lang, "payment.status."+status)
+	default:
+		return tr(lang, "payment.status.unpaid")
+	}
+}
+
+// adminPaymentLabel admin order detail uchun
+func adminPaymentLabel(status string) string {
+	switch payment.Status(status) {
+	case payment.StatusPaid:
+		return "✅ To'langan"
+	case payment.StatusRefunded:
+		return "↩️ Qaytarilgan"
+	default:
+		return "❌ To'lanmagan"
+	}
+}
+
+// orderPayable mijozga "To'lash" tugmalarini ko'rsatish mumkinmi
+func (h *BotHandler) orderPayable(info orderStatusInfo) bool {
+	if len(h.payments.Names()) == 0 || info.PaymentStatus != "" {
+		return false
+	}
+	switch info.Status {
+	case "canceled", "delivered":
+		return false
+	}
+	_, err := h.orderPaymentAmount(info)
+	return err == nil
+}
+
+// orderPaymentRows provayderlar bo'yicha "To'lash" tugmalari
+func (h *BotHandler) orderPaymentRows(info orderStatusInfo, lang string) [][]tgbotapi.InlineKeyboardButton {
+	if !h.orderPayable(info) {
+		return nil
+	}
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, name := range h.payments.Names() {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "payment.pay_with", "provider", paymentProviderTitle(name)), "pay|"+name+"|"+info.OrderID),
+		))
+	}
+	return rows
+}
+
+// offerOrderPayment buyurtma rasmiylashtirilgandan keyin onlayn to'lovni taklif qiladi
+func (h *BotHandler) offerOrderPayment(chatID, userID int64, orderID string) {
+	info, ok := h.getOrderStatus(orderID)
+	if !ok || chatID == 0 {
+		return
+	}
+	lang := h.getUserLang(userID)
+	rows := h.orderPaymentRows(info, lang)
+	if len(rows) == 0 {
+		return
+	}
+	amount, _ := h.orderPaymentAmount(info)
+	key := "payment.offer"
+	if info.InstallmentPlan.Months > 0 {
+		key = "payment.offer_down"
+	}
+	msg := tgbotapi.NewMessage(chatID, tr(lang, key, "amount", formatPaymentAmount(amount, h.paymentCurrency), "months", info.InstallmentPlan.Months))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	_, _ = h.sendAndLog(msg)
+}
+
+// createOrderInvoice buyurtma uchun yangi invoice yaratadi va saqlaydi
+func (h *BotHandler) createOrderInvoice(ctx context.Context, info orderStatusInfo, providerName string) (payment.Invoice, error) {
+	provider, ok := h.payments.Get(providerName)
+	if !ok {
+		return payment.Invoice{}, fmt.Errorf("payment provider %q is not enabled", providerName)
+	}
+	if h.paymentStore == nil {
+		return payment.Invoice{}, errors.New("payment store is not configured")
+	}
+	amount, err := h.orderPaymentAmount(info)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+	lang := h.getUserLang(info.UserID)
+	now := time.Now()
+	inv := payment.Invoice{
+		ID:          info.OrderID + "-" + uuid.NewString()[:8],
+		OrderID:     info.OrderID,
+		UserID:      info.UserID,
+		ChatID:      info.UserChat,
+		Amount:      amount,
+		Currency:    h.paymentCurrency,
+		Description: tr(lang, "payment.invoice_description", "order_id", info.OrderID),
+		Status:      payment.StatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := provider.CreateInvoice(ctx, &inv); err != nil {
+		return payment.Invoice{}, err
+	}
+	if err := h.paymentStore.Save(ctx, inv); err != nil {
+		return payment.Invoice{}, err
+	}
+	return inv, nil
+}
+
+// handlePayCallback "pay|<provider>|<orderID>" - mijoz to'lash tugmasini bosdi
+func (h *BotHandler) handlePayCallback(ctx context.Context, chatID, userID int64, data string) {
+	parts := strings.Split(data, "|")
+	if len(parts) != 3 {
+		return
+	}
+	providerName, orderID := parts[1], parts[2]
+	lang := h.getUserLang(userID)
+	info, ok := h.getOrderStatus(orderID)
+	if !ok || info.UserID != userID {
+		h.sendMessage(chatID, tr(lang, "myorders.not_found"))
+		return
+	}
+	if info.PaymentStatus == string(payment.StatusPaid) {
+		h.sendMessage(chatID, tr(lang, "payment.already_paid"))
+		return
+	}
+	if !h.orderPayable(info) {
+		h.sendMessage(chatID, tr(lang, "payment.unavailable"))
+		return
+	}
+	inv, err := h.createOrderInvoice(ctx, info, providerName)
+	if err != nil {
+		log.Printf("payment invoice create failed order=%s provider=%s err=%v", orderID, providerName, err)
+		h.sendMessage(chatID, tr(lang, "payment.error"))
+		return
+	}
+
+	if providerName == payment.TelegramName {
+		h.sendTelegramInvoice(chatID, inv, lang)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, tr(lang, "payment.link")+"\n"+formatPaymentAmount(inv.Amount, inv.Currency))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonURL(tr(lang, "payment.open_button"), inv.PayURL),
+	))
+	_, _ = h.sendAndLog(msg)
+}
+
+func (h *BotHandler) sendTelegramInvoice(chatID int64, inv payment.Invoice, lang string) {
+	p, ok := h.payments.Get(payment.TelegramName)
+	tp, isTelegram := p.(*payment.TelegramProvider)
+	if !ok || !isTelegram {
+		return
+	}
+	title := tr(lang, "payment.invoice_title", "order_id", inv.OrderID)
+	cfg := tgbotapi.NewInvoice(chatID, title, inv.Description, inv.ID, tp.Token, "", inv.Currency,
+		[]tgbotapi.LabeledPrice{{Label: title, Amount: int(inv.Amount)}})
+	if _, err := h.sendAndLog(cfg); err != nil {
+		log.Printf("telegram invoice send failed invoice=%s err=%v", inv.ID, err)
+		h.sendMessage(chatID, tr(lang, "payment.error"))
+	}
+}
+
+// handlePreCheckoutQuery Telegram to'lovdan oldin invoice va summani tasdiqlashni so'raydi
+func (h *BotHandler) handlePreCheckoutQuery(ctx context.Context, q *tgbotapi.PreCheckoutQuery) {
+	params := url.Values{}
+	params.Set("invoice_payload", q.InvoicePayload)
+	params.Set("total_amount", strconv.Itoa(q.TotalAmount))
+	params.Set("currency", q.Currency)
+	_, _, err := h.applyPaymentCallback(ctx, payment.TelegramName, payment.CallbackRequest{Params: params})
+
+	answer := tgbotapi.PreCheckoutConfig{PreCheckoutQueryID: q.ID, OK: err == nil}
+	if err != nil {
+		log.Printf("pre-checkout rejected payload=%s err=%v", q.InvoicePayload, err)
+		lang := ""
+		if q.From != nil {
+			lang = h.getUserLang(q.From.ID)
+		}
+		answer.ErrorMessage = tr(lang, "payment.checkout_failed")
+	}
+	if h.bot == nil {
+		return
+	}
+	if _, err := h.bot.Request(answer); err != nil {
+		log.Printf("pre-checkout answer failed: %v", err)
+	}
+}
+
+// handleSuccessfulPayment Telegram to'lov muvaffaqiyatli yakunlangani haqidagi xabar
+func (h *BotHandler) handleSuccessfulPayment(ctx context.Context, message *tgbotapi.Message) {
+	sp := message.SuccessfulPayment
+	params := url.Values{}
+	params.Set("invoice_payload", sp.InvoicePayload)
+	params.Set("total_amount", strconv.Itoa(sp.TotalAmount))
+	params.Set("currency", sp.Currency)
+	params.Set("telegram_payment_charge_id", sp.TelegramPaymentChargeID)
+	if _, _, err := h.applyPaymentCallback(ctx, payment.TelegramName, payment.CallbackRequest{Params: params}); err != nil {
+		log.Printf("telegram payment apply failed payload=%s charge=%s err=%v", sp.InvoicePayload, sp.TelegramPaymentChargeID, err)
+	}
+}
+
+// applyPaymentCallback callbackni tekshiradi, invoice holatini yangilaydi va
+// holat o'zgargan bo'lsa buyurtmaga qo'llaydi
+func (h *BotHandler) applyPaymentCallback(ctx context.Context, providerName string, req payment.CallbackRequest) (payment.Invoice, payment.Callback, error) {
+	provider, ok := h.payments.Get(providerName)
+	if !ok || h.paymentStore == nil {
+		return payment.Invoice{}, payment.Callback{}, payment.ErrUnknownInvoice
+	}
+	cb, err := provider.VerifyCallback(ctx, req)
+	if err != nil {
+		return payment.Invoice{}, cb, err
+	}
+
+	h.paymentMu.Lock()
+	defer h.paymentMu.Unlock()
+
+	var inv payment.Invoice
+	var found bool
+	if cb.InvoiceID != "" {
+		inv, found, err = h.paymentStore.Get(ctx, cb.InvoiceID)
+	} else {
+		inv, found, err = h.paymentStore.GetByExternal(ctx, providerName, cb.ExternalID)
+	}
+	if err != nil {
+		return payment.Invoice{}, cb, err
+	}
+	if !found || inv.Provider != providerName {
+		return payment.Invoice{}, cb, payment.ErrUnknownInvoice
+	}
+
+	// Tasdiqlashdan (pre-checkout, prepare/complete) oldin buyurtma hali ochiqligini
+	// tekshiramiz. Telegram successful_payment - pul allaqachon yechilgan, uni rad etib
+	// bo'lmaydi; bunday to'lov onInvoiceStatusChange da qaytarish uchun belgilanadi.
+	if inv.Status == payment.StatusPending &&
+		(cb.Status == payment.StatusPending || (cb.Status == payment.StatusPaid && providerName != payment.TelegramName)) {
+		if err := h.orderAcceptsPayment(inv.OrderID); err != nil {
+			return inv, cb, err
+		}
+	}
+
+	prev := inv.Status
+	changed, applyErr := payment.Apply(&inv, cb, time.Now())
+	if changed {
+		if err := h.paymentStore.Save(ctx, inv); err != nil {
+			return inv, cb, err
+		}
+	}
+	if applyErr != nil {
+		return inv, cb, applyErr
+	}
+	if prev != inv.Status {
+		h.onInvoiceStatusChange(ctx, inv)
+	}
+	return inv, cb, nil
+}
+
+// orderAcceptsPayment bekor qilingan yoki boshqa invoice bilan to'langan buyurtmaga
+// to'lov tasdiqlanmaydi
+func (h *BotHandler) orderAcceptsPayment(orderID string) error {
+	info, ok := h.getOrderStatus(orderID)
+	switch {
+	case !ok:
+		return payment.ErrUnknownInvoice
+	case info.Status == "canceled":
+		return payment.ErrInvalidState
+	case info.PaymentStatus == string(payment.StatusPaid):
+		return payment.ErrAlreadyPaid
+	}
+	return nil
+}
+
+// cancelSiblingInvoices buyurtma to'langach uning boshqa kutilayotgan invoice'larini
+// bekor qiladi (ikkinchi marta to'lab yubormaslik uchun). paymentMu ushlangan holda chaqiriladi.
+func (h *BotHandler) cancelSiblingInvoices(ctx context.Context, paid payment.Invoice) {
+	invoices, err := h.paymentStore.ListByOrder(ctx, paid.OrderID)
+	if err != nil {
+		log.Printf("payment sibling list failed order=%s err=%v", paid.OrderID, err)
+		return
+	}
+	now := time.Now()
+	for _, inv := range invoices {
+		if inv.ID == paid.ID || inv.Status != payment.StatusPending {
+			continue
+		}
+		if changed, err := payment.Apply(&inv, payment.Callback{Status: payment.StatusCanceled}, now); err != nil || !changed {
+			continue
+		}
+		if err := h.paymentStore.Save(ctx, inv); err != nil {
+			log.Printf("payment sibling cancel failed invoice=%s err=%v", inv.ID, err)
+		}
+	}
+}
+
+// onInvoiceStatusChange to'lov/refund natijasini buyurtmaga yozadi va xabar beradi
+func (h *BotHandler) onInvoiceStatusChange(ctx context.Context, inv payment.Invoice) {
+	info, ok := h.getOrderStatus(inv.OrderID)
+	if !ok {
+		log.Printf("payment for unknown order invoice=%s order=%s", inv.ID, inv.OrderID)
+		return
+	}
+	amount := formatPaymentAmount(inv.Amount, inv.Currency)
+	provider := paymentProviderTitle(inv.Provider)
+	lang := h.getUserLang(info.UserID)
+
+	switch inv.Status {
+	case payment.StatusPaid:
+		if err := h.orderAcceptsPayment(inv.OrderID); err != nil {
+			// Yopilgan yoki allaqachon to'langan buyurtmaga pul tushdi - buyurtmani o'zgartirmaymiz
+			h.recordOrderEvent(inv.OrderID, orderEventPayment, fmt.Sprintf("⚠️ %s · %s (qaytarish kerak)", amount, provider))
+			h.notifyOrderChannel(info, fmt.Sprintf("⚠️ Yopilgan yoki to'langan buyurtmaga to'lov keldi\nOrderID: %s\nInvoice: %s\nSumma: %s\nProvayder: %s\n\nPulni provayder kabinetidan qaytaring.", inv.OrderID, inv.ID, amount, provider))
+			return
+		}
+		h.cancelSiblingInvoices(ctx, inv)
+		info = h.setOrderPaymentStatus(inv.OrderID, payment.StatusPaid, info)
+		h.recordOrderEvent(inv.OrderID, orderEventPayment, fmt.Sprintf("%s · %s", amount, provider))
+		h.notifyOrderChannel(info, fmt.Sprintf("💳 To'lov qabul qilindi\nOrderID: %s\nSumma: %s\nProvayder: %s", inv.OrderID, amount, provider))
+		if info.UserChat != 0 {
+			h.sendMessage(info.UserChat, tr(lang, "payment.paid", "order_id", inv.OrderID))
+		}
+	case payment.StatusRefunded:
+		info = h.setOrderPaymentStatus(inv.OrderID, payment.StatusRefunded, info)
+		h.recordOrderEvent(inv.OrderID, orderEventPayment, fmt.Sprintf("↩️ %s · %s", amount, provider))
+		info = h.cancelRefundedOrder(info)
+		h.notifyOrderChannel(info, fmt.Sprintf("↩️ To'lov qaytarildi\nOrderID: %s\nSumma: %s\nProvayder: %s", inv.OrderID, amount, provider))
+		if info.UserChat != 0 {
+			h.sendMessage(info.UserChat, tr(lang, "payment.refunded", "order_id", inv.OrderID))
+		}
+	}
+}
+
+// setOrderPaymentStatus faqat to'lov holatini yozadi (buyurtmaning boshqa maydonlari
+// eski nusxadan qayta yozilmaydi) va yangilangan yozuvni qaytaradi
+func (h *BotHandler) setOrderPaymentStatus(orderID string, status payment.Status, info orderStatusInfo) orderStatusInfo {
+	fresh, ok := h.updateOrderIf(orderID, func(ctx context.Context) (bool, error) {
+		return true, h.orderStore.SetPaymentStatus(ctx, orderID, string(status))
+	})
+	if !ok || fresh.OrderID == "" {
+		info.PaymentStatus = string(status)
+		return info
+	}
+	return fresh
+}
+
+// cancelRefundedOrder pul qaytarilgan buyurtmani bekor qiladi. Holat CAS bilan o'zgaradi:
+// zaxira faqat bekor qilishni aynan shu chaqiruv bajargan bo'lsa qaytariladi
+// (bekor qilingan buyurtmada zaxira allaqachon qaytarilgan)
+func (h *BotHandler) cancelRefundedOrder(info orderStatusInfo) orderStatusInfo {
+	for attempt := 0; attempt < 3 && info.Status != "canceled"; attempt++ {
+		if h.compareAndSetOrderStatus(info.OrderID, info.Status, "canceled", "refund") {
+			h.restockOrderItems(info)
+			h.releasePromoUses(orderPromoIDs(info.Lines))
+			info.Status = "canceled"
+			h.notifyOrderStatusChange(info, "canceled")
+			return info
+		}
+		// Holat shu orada o'zgardi - yangisini o'qib qayta urinamiz
+		fresh, ok := h.getOrderStatus(info.OrderID)
+		if !ok {
+			break
+		}
+		info = fresh
+	}
+	return info
+}
+
+// refundOrderPayment buyurtmaning to'langan invoice'ini qaytaradi.
+// manual=true - provayder API qaytarmaydi, pulni kabinetdan qo'lda qaytarish kerak
+func (h *BotHandler) refundOrderPayment(ctx context.Context, orderID string) (inv payment.Invoice, manual bool, err error) {
+	if h.paymentStore == nil {
+		return inv, false, payment.ErrUnknownInvoice
+	}
+	h.paymentMu.Lock()
+	defer h.paymentMu.Unlock()
+
+	invoices, err := h.paymentStore.ListByOrder(ctx, orderID)
+	if err != nil {
+		return inv, false, err
+	}
+	found := false
+	for i := len(invoices) - 1; i >= 0; i-- {
+		if invoices[i].Status == payment.StatusPaid {
+			inv, found = invoices[i], true
+			break
+		}
+	}
+	if !found {
+		return inv, false, payment.ErrUnknownInvoice
+	}
+	if provider, ok := h.payments.Get(inv.Provider); ok {
+		if err := provider.Refund(ctx, &inv); err != nil {
+			if !errors.Is(err, payment.ErrManualRefund) {
+				return inv, false, err
+			}
+			manual = true
+		}
+	} else {
+		manual = true
+	}
+
+	if _, err := payment.Apply(&inv, payment.Callback{Status: payment.StatusRefunded}, time.Now()); err != nil {
+		return inv, manual, err
+	}
+	if err := h.paymentStore.Save(ctx, inv); err != nil {
+		return inv, manual, err
+	}
+	h.onInvoiceStatusChange(ctx, inv)
+	return inv, manual, nil
+}
+
+// handleRefundCommand /refund <OrderID> - admin to'lovni qaytaradi
+func (h *BotHandler) handleRefundCommand(ctx context.Context, message *tgbotapi.Message) {
+	h.refundOrderAsAdmin(ctx, message.Chat.ID, message.From.ID, strings.TrimSpace(message.CommandArguments()))
+}
+
+func (h *BotHandler) refundOrderAsAdmin(ctx context.Context, chatID, adminID int64, orderID string) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, adminID)
+	if !isAdmin {
+		h.sendMessage(chatID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+	if orderID == "" {
+		h.sendMessage(chatID, "Foydalanish: /refund <OrderID>")
+		return
+	}
+	inv, manual, err := h.refundOrderPayment(ctx, orderID)
+	if errors.Is(err, payment.ErrUnknownInvoice) {
+		h.sendMessage(chatID, "❌ Bu buyurtma uchun to'langan invoice topilmadi.")
+		return
+	}
+	if err != nil {
+		log.Printf("refund failed order=%s err=%v", orderID, err)
+		h.sendMessage(chatID, "❌ Refund xatosi: "+err.Error())
+		return
+	}
+	text := fmt.Sprintf("↩️ To'lov qaytarildi: %s (%s)\nOrderID: %s\nZaxira omborga qaytarildi.",
+		formatPaymentAmount(inv.Amount, inv.Currency), paymentProviderTitle(inv.Provider), orderID)
+	if manual {
+		text += "\n⚠️ Pulni provayder kabinetidan qo'lda qaytaring."
+	}
+	h.sendMessage(chatID, text)
+}
+
+// startPaymentCallbackServer merchant callbacklari uchun HTTP server (PAYMENT_CALLBACK_ADDR, masalan ":8085")
+func (h *BotHandler) startPaymentCallbackServer(ctx context.Context) {
+	addr := strings.TrimSpace(os.Getenv("PAYMENT_CALLBACK_ADDR"))
+	if addr == "" || len(h.payments.Names()) == 0 {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle(paymentCallbackPath, h.paymentCallbackHandler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	log.Printf("payments: callback server %s%s<provider>", addr, paymentCallbackPath)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("payments: callback server error: %v", err)
+	}
+}
+
+func (h *BotHandler) paymentCallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.Trim(strings.TrimPrefix(r.URL.Path, paymentCallbackPath), "/")
+		provider, ok := h.payments.Get(name)
+		// Telegram to'lovlari bot update'lari orqali keladi
+		if !ok || name == payment.TelegramName {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		params := r.URL.Query()
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			if form, err := url.ParseQuery(string(body)); err == nil {
+				for k, v := range form {
+					params[k] = v
+				}
+			}
+		}
+
+		inv, cb, err := h.applyPaymentCallback(r.Context(), name, payment.CallbackRequest{Params: params, Header: r.Header, Body: body})
+		if err != nil {
+			log.Printf("payment callback provider=%s invoice=%s method=%s err=%v", name, cb.InvoiceID, cb.Method, err)
+		}
+		if responder, ok := provider.(payment.Responder); ok {
+			code, resp := responder.Respond(cb, inv, err)
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(code)
+			_, _ = w.Write(resp)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("OK"))
+	})
+}
@@ -0,0 +1,206 @@
+package telegram
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/payment"
+)
+
+// TestFakePaymentMarksOrderPaidAndRefunds - invoice jami summadan yaratiladi,
+// callback buyurtmani "paid" qiladi, refund esa bekor qiladi
+func TestFakePaymentMarksOrderPaidAndRefunds(t *testing.T) {
+	ctx := context.Background()
+	fake := payment.NewFakeProvider("s3cret")
+	h := &BotHandler{
+		orderStore:      newMemoryStore(),
+		orderStatuses:   make(map[string]orderStatusInfo),
+		userLang:        map[int64]string{7: "en"},
+		paymentStore:    newMemoryPaymentStore(),
+		payments:        payment.NewRegistry(fake),
+		paymentCurrency: "UZS",
+		currencyMode:    "sum",
+		currencyRate:    12500,
+	}
+	const orderID = "01012026-01"
+	h.saveOrderStatus(orderID, orderStatusInfo{UserID: 7, UserChat: 7, Status: "processing", Total: "100$", CreatedAt: time.Now()})
+
+	info, _ := h.getOrderStatus(orderID)
+	inv, err := h.createOrderInvoice(ctx, info, payment.FakeName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.Amount != 1250000*100 || inv.PayURL == "" {
+		t.Fatalf("invoice: %+v", inv)
+	}
+
+	callback := func(amount int64, status payment.Status, sign string) error {
+		params := url.Values{}
+		params.Set("invoice_id", inv.ID)
+		params.Set("amount", strconv.FormatInt(amount, 10))
+		params.Set("status", string(status))
+		params.Set("sign", sign)
+		_, _, err := h.applyPaymentCallback(ctx, payment.FakeName, payment.CallbackRequest{Params: params})
+		return err
+	}
+	if err := callback(inv.Amount, payment.StatusPaid, "bad"); err != payment.ErrInvalidSignature {
+		t.Fatalf("imzosiz callback o'tmasligi kerak: %v", err)
+	}
+	if err := callback(100, payment.StatusPaid, fake.Sign(inv.ID, 100, payment.StatusPaid)); err != payment.ErrAmountMismatch {
+		t.Fatalf("boshqa summa qabul qilinmasligi kerak: %v", err)
+	}
+	if err := callback(inv.Amount, payment.StatusPaid, fake.Sign(inv.ID, inv.Amount, payment.StatusPaid)); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := h.getOrderStatus(orderID); info.PaymentStatus != "paid" {
+		t.Fatalf("to'lov holati: %q", info.PaymentStatus)
+	}
+
+	if _, manual, err := h.refundOrderPayment(ctx, orderID); err != nil || manual {
+		t.Fatalf("refund: manual=%v err=%v", manual, err)
+	}
+	info, _ = h.getOrderStatus(orderID)
+	if info.PaymentStatus != "refunded" || info.Status != "canceled" {
+		t.Fatalf("refunddan keyin: payment=%q status=%q", info.PaymentStatus, info.Status)
+	}
+	if got := fake.Refunded(); len(got) != 1 || got[0] != inv.ID {
+		t.Fatalf("provayder refund chaqirilmadi: %v", got)
+	}
+}
+
+// TestRefundCancelsFreshStatus - refund keshdagi eski nusxani qayta yozmaydi: holat
+// store'dagi joriy holatdan CAS bilan canceled ga o'tadi, boshqa maydonlar saqlanadi
+func TestRefundCancelsFreshStatus(t *testing.T) {
+	h := &BotHandler{
+		orderStore:    newMemoryStore(),
+		orderStatuses: make(map[string]orderStatusInfo),
+		userLang:      map[int64]string{7: "en"},
+	}
+	const orderID = "01012026-03"
+	h.saveOrderStatus(orderID, orderStatusInfo{UserID: 7, UserChat: 7, Status: "processing", PaymentStatus: "paid", Location: "Chilonzor 9", CreatedAt: time.Now()})
+	// Boshqa jarayon kuryer biriktirdi va holatni o'zgartirdi; kesh hali eski
+	ctx := context.Background()
+	_, _ = h.orderStore.SetCourierIfStatus(ctx, orderID, "processing", 8)
+	_ = h.orderStore.UpdateStatus(ctx, orderID, "ready_delivery")
+
+	h.onInvoiceStatusChange(ctx, payment.Invoice{ID: "inv-1", OrderID: orderID, Status: payment.StatusRefunded, Currency: "UZS"})
+	got, _ := h.getOrderStatus(orderID)
+	if got.Status != "canceled" || got.PaymentStatus != "refunded" || got.CourierID != 8 {
+		t.Fatalf("refunddan keyin: %+v", got)
+	}
+	canceledEvents := func() int {
+		events, _ := h.orderStore.ListEvents(ctx, orderID)
+		n := 0
+		for _, ev := range events {
+			if ev.Status == "canceled" {
+				n++
+			}
+		}
+		return n
+	}
+	if n := canceledEvents(); n != 1 {
+		t.Fatalf("canceled yozuvi %d marta", n)
+	}
+
+	// Takroriy refund xabari bekor qilingan buyurtmani qayta bekor qilmaydi
+	h.onInvoiceStatusChange(ctx, payment.Invoice{ID: "inv-1", OrderID: orderID, Status: payment.StatusRefunded, Currency: "UZS"})
+	if n := canceledEvents(); n != 1 {
+		t.Fatalf("takroriy refund: canceled yozuvi %d marta", n)
+	}
+}
+
+// TestPaymentRejectsClosedOrders - to'langan buyurtmaning boshqa invoice'lari bekor bo'ladi,
+// bekor qilingan buyurtmaga prepare/complete tasdiqlanmaydi
+func TestPaymentRejectsClosedOrders(t *testing.T) {
+	ctx := context.Background()
+	fake := payment.NewFakeProvider("s3cret")
+	h := &BotHandler{
+		orderStore:      newMemoryStore(),
+		orderStatuses:   make(map[string]orderStatusInfo),
+		userLang:        map[int64]string{7: "en"},
+		paymentStore:    newMemoryPaymentStore(),
+		payments:        payment.NewRegistry(fake),
+		paymentCurrency: "UZS",
+		currencyMode:    "sum",
+		currencyRate:    12500,
+	}
+	const paidID, canceledID = "01012026-01", "01012026-02"
+	h.saveOrderStatus(paidID, orderStatusInfo{UserID: 7, UserChat: 7, Status: "processing", Total: "100$", CreatedAt: time.Now()})
+	h.saveOrderStatus(canceledID, orderStatusInfo{UserID: 7, UserChat: 7, Status: "processing", Total: "100$", CreatedAt: time.Now()})
+
+	info, _ := h.getOrderStatus(paidID)
+	first, err := h.createOrderInvoice(ctx, info, payment.FakeName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := h.createOrderInvoice(ctx, info, payment.FakeName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	callback := func(inv payment.Invoice, status payment.Status) error {
+		params := url.Values{}
+		params.Set("invoice_id", inv.ID)
+		params.Set("amount", strconv.FormatInt(inv.Amount, 10))
+		params.Set("status", string(status))
+		params.Set("sign", fake.Sign(inv.ID, inv.Amount, status))
+		_, _, err := h.applyPaymentCallback(ctx, payment.FakeName, payment.CallbackRequest{Params: params})
+		return err
+	}
+	if err := callback(first, payment.StatusPaid); err != nil {
+		t.Fatal(err)
+	}
+	if got, _, _ := h.paymentStore.Get(ctx, second.ID); got.Status != payment.StatusCanceled {
+		t.Fatalf("ikkinchi invoice bekor qilinmadi: %q", got.Status)
+	}
+	if err := callback(second, payment.StatusPending); err == nil {
+		t.Fatalf("to'langan buyurtmaga ikkinchi to'lov tasdiqlandi")
+	}
+
+	info, _ = h.getOrderStatus(canceledID)
+	inv, err := h.createOrderInvoice(ctx, info, payment.FakeName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info.Status = "canceled"
+	h.saveOrderStatus(canceledID, info)
+	if err := callback(inv, payment.StatusPending); err != payment.ErrInvalidState {
+		t.Fatalf("bekor qilingan buyurtma prepare: %v", err)
+	}
+	if err := callback(inv, payment.StatusPaid); err != payment.ErrInvalidState {
+		t.Fatalf("bekor qilingan buyurtma complete: %v", err)
+	}
+	if got, _ := h.getOrderStatus(canceledID); got.PaymentStatus != "" {
+		t.Fatalf("bekor qilingan buyurtma to'langan deb belgilandi: %q", got.PaymentStatus)
+	}
+}
+
+// TestInstallmentOrderChargesDownPayment - muddatli buyurtmada onlayn faqat boshlang'ich to'lov olinadi
+func TestInstallmentOrderChargesDownPayment(t *testing.T) {
+	h := &BotHandler{
+		payments:        payment.NewRegistry(payment.NewFakeProvider("s3cret")),
+		paymentCurrency: "USD",
+	}
+	plan := installmentPlan{Months: 12, MarkupPercent: 10, DownPercent: 20}
+	total := entity.NewMoney(1000, entity.CurrencyUSD)
+	info := orderStatusInfo{
+		OrderID:         "01012026-03",
+		Status:          "processing",
+		Total:           "1000$",
+		TotalMoney:      total,
+		InstallmentPlan: plan,
+		InstallmentDown: installmentDownPayment(plan, total),
+	}
+	if amount, err := h.orderPaymentAmount(info); err != nil || amount != 22000 {
+		t.Fatalf("boshlang'ich to'lov: %d err=%v", amount, err)
+	}
+
+	info.InstallmentPlan.DownPercent = 0
+	info.InstallmentDown = entity.Money{}
+	if h.orderPayable(info) {
+		t.Fatalf("boshlang'ich to'lovsiz muddatli buyurtma onlayn to'lanmasligi kerak")
+	}
+}
@@ -3,6 +3,7 @@ package telegram
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
@@ -14,32 +15,29 @@ func FormatPCAnalytics(build *entity.PCBuild, analytics *entity.PCAnalytics, lan
 
 	// Header
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString(t(lang, "📊 PROFESSIONAL PC TAHLIL\n", "📊 ПРОФЕССИОНАЛЬНЫЙ АНАЛИЗ ПК\n"))
+	sb.WriteString(tr(lang, "analysis.title"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 
 	// Build Info
-	sb.WriteString(fmt.Sprintf(t(lang, "💻 KONFIGURATSIYA: %s\n", "💻 КОНФИГУРАЦИЯ: %s\n"), build.Purpose))
+	sb.WriteString(tr(lang, "analysis.build", "purpose", build.Purpose))
 	if build.ColorScheme != "" {
-		sb.WriteString(fmt.Sprintf(t(lang, "🎨 Rang: %s\n", "🎨 Цвет: %s\n"), build.ColorScheme))
+		sb.WriteString(tr(lang, "analysis.color", "color", build.ColorScheme))
 	}
-	sb.WriteString(fmt.Sprintf(t(lang, "💰 Narx: $%.2f\n\n", "💰 Цена: $%.2f\n\n"), resolveBuildPrice(build)))
+	sb.WriteString(tr(lang, "analysis.price", "price", fmt.Sprintf("%.2f", resolveBuildPrice(build))))
 
 	// Overall Score
-	sb.WriteString(fmt.Sprintf(t(lang, "⭐ UMUMIY REYTING: %.1f/10.0\n", "⭐ ОБЩИЙ РЕЙТИНГ: %.1f/10.0\n"), analytics.OverallScore))
+	sb.WriteString(tr(lang, "analysis.overall", "score", fmt.Sprintf("%.1f", analytics.OverallScore)))
 	sb.WriteString(formatScoreBar(analytics.OverallScore, lang))
 	sb.WriteString("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 
 	requestedUseCase := resolveRequestedUseCase(build, analytics)
 	if shouldShowFPS(requestedUseCase, analytics) {
 		// FPS Section
-		sb.WriteString(t(lang, "🎮 O'YINLARDA (FPS)\n", "🎮 В ИГРАХ (FPS)\n"))
+		sb.WriteString(tr(lang, "analysis.section.fps"))
 		sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 
-		games := []string{"CS2", "Cyberpunk 2077", "Red Dead Redemption 2", "GTA 5", "PUBG", "Fortnite", "Forza Horizon 5"}
-		for _, gameName := range games {
-			if fps, ok := analytics.FPS[gameName]; ok {
-				sb.WriteString(formatFPSLine(gameName, fps))
-			}
+		for _, gameName := range fpsGameOrder(analytics.FPS) {
+			sb.WriteString(formatFPSLine(gameName, analytics.FPS[gameName]))
 		}
 		sb.WriteString("\n")
 	} else {
@@ -49,53 +47,81 @@ func FormatPCAnalytics(build *entity.PCBuild, analytics *entity.PCAnalytics, lan
 	}
 
 	// Temperature Section
-	sb.WriteString(t(lang, "🌡️ TEMPERATURA\n", "🌡️ ТЕМПЕРАТУРА\n"))
+	sb.WriteString(tr(lang, "analysis.section.temps"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	sb.WriteString(formatTemperature("CPU", analytics.CPUTemp, lang))
 	sb.WriteString(formatTemperature("GPU", analytics.GPUTemp, lang))
 	sb.WriteString("\n")
 
 	// Bottleneck Section
-	sb.WriteString(t(lang, "⚖️ MUVOZANAT (BOTTLENECK)\n", "⚖️ УЗКИЕ МЕСТА (BOTTLENECK)\n"))
+	sb.WriteString(tr(lang, "analysis.section.bottleneck"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	sb.WriteString(formatBottleneck(analytics.Bottleneck, lang))
 	sb.WriteString("\n")
 
 	// Power Section
-	sb.WriteString(t(lang, "⚡ QUVVAT SARFI & PSU\n", "⚡ ПОТРЕБЛЕНИЕ & БП\n"))
+	sb.WriteString(tr(lang, "analysis.section.power"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	sb.WriteString(formatPower(analytics.PowerConsumption, lang))
 	sb.WriteString("\n")
 
 	// Storage Speed
-	sb.WriteString(t(lang, "💾 STORAGE TEZLIGI\n", "💾 СКОРОСТЬ НАКОПИТЕЛЯ\n"))
+	sb.WriteString(tr(lang, "analysis.section.storage"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	sb.WriteString(formatStorageSpeed(analytics.StorageSpeed, lang))
 	sb.WriteString("\n")
 
 	// Use Case Match
-	sb.WriteString(t(lang, "🎯 MAQSADGA MOS KELISH\n", "🎯 СООТВЕТСТВИЕ ЦЕЛИ\n"))
+	sb.WriteString(tr(lang, "analysis.section.purpose"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	sb.WriteString(formatUseCaseMatch(analytics.UseCaseMatch, lang))
 	sb.WriteString("\n")
 
 	// Upgrade Path
 	if len(analytics.UpgradePath) > 0 {
-		sb.WriteString(t(lang, "📈 UPGRADE TAVSIYALARI\n", "📈 РЕКОМЕНДАЦИИ ПО АПГРЕЙДУ\n"))
+		sb.WriteString(tr(lang, "analysis.section.upgrades"))
 		sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 		sb.WriteString(formatUpgrades(analytics.UpgradePath, lang))
 		sb.WriteString("\n")
 	}
 
+	// AI xulosasi (raqamlar yuqorida hisoblangan)
+	if summary := strings.TrimSpace(analytics.Summary); summary != "" {
+		sb.WriteString(tr(lang, "analysis.section.summary"))
+		sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		sb.WriteString(summary + "\n\n")
+	}
+
 	// Footer
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString(t(lang, "✅ Tahlil yakunlandi!\n", "✅ Анализ завершён!\n"))
-	sb.WriteString(t(lang, "📞 Savollar bo'lsa, adminga yozing: @Ingame_support\n", "📞 Если есть вопросы, пишите админу: @Ingame_support\n"))
+	sb.WriteString(tr(lang, "analysis.done"))
+	sb.WriteString(tr(lang, "analysis.support"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 
 	return sb.String()
 }
 
+// fpsGameOrder avval mashhur o'yinlar, keyin benchmark bazasidagi qolganlari alifbo bo'yicha
+func fpsGameOrder(fps map[string]entity.FPSData) []string {
+	preferred := []string{"CS2", "Cyberpunk 2077", "Red Dead Redemption 2", "GTA 5", "PUBG", "Fortnite", "Forza Horizon 5"}
+	order := make([]string, 0, len(fps))
+	seen := map[string]bool{}
+	for _, name := range preferred {
+		if _, ok := fps[name]; ok {
+			order = append(order, name)
+			seen[name] = true
+		}
+	}
+	var rest []string
+	for name := range fps {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
+}
+
 // formatScoreBar reyting uchun progress bar
 func formatScoreBar(score float64, lang string) string {
 	filled := int(score)
@@ -113,13 +139,13 @@ func formatScoreBar(score float64, lang string) string {
 	// Rating description
 	desc := ""
 	if score >= 9.0 {
-		desc = t(lang, "🏆 A'lo darajada!", "🏆 Отлично!")
+		desc = tr(lang, "analysis.grade.excellent")
 	} else if score >= 7.0 {
-		desc = t(lang, "✨ Juda yaxshi!", "✨ Очень хорошо!")
+		desc = tr(lang, "analysis.grade.very_good")
 	} else if score >= 5.0 {
-		desc = t(lang, "👍 Yaxshi", "👍 Хорошо")
+		desc = tr(lang, "analysis.grade.good")
 	} else {
-		desc = t(lang, "⚠️ O'rtacha", "⚠️ Средне")
+		desc = tr(lang, "analysis.grade.average")
 	}
 
 	return fmt.Sprintf("%s %s\n", bar, desc)
@@ -155,10 +181,10 @@ func formatTemperature(component string, temp entity.TemperatureData, lang strin
 		statusIcon = "🔥"
 	}
 
-	result := fmt.Sprintf(t(lang, "🌡️ %s: Idle %d°C │ Load %d°C │ %s %s\n", "🌡️ %s: Простой %d°C │ Нагрузка %d°C │ %s %s\n"),
-		component, temp.Idle, temp.Load, temp.Status, statusIcon)
+	result := tr(lang, "analysis.temp_line",
+		"component", component, "idle", temp.Idle, "load", temp.Load, "status", temp.Status, "icon", statusIcon)
 
-	result += fmt.Sprintf(t(lang, "   Sovutish: %s\n", "   Охлаждение: %s\n"), temp.CoolerType)
+	result += tr(lang, "analysis.cooling", "cooler", temp.CoolerType)
 
 	if temp.Warning != "" {
 		result += fmt.Sprintf("   %s\n", temp.Warning)
@@ -170,40 +196,32 @@ func formatTemperature(component string, temp entity.TemperatureData, lang strin
 // formatBottleneck bottleneck tahlili
 func formatBottleneck(b entity.BottleneckAnalysis, lang string) string {
 	if !b.HasBottleneck {
-		return t(lang, "✅ Bottleneck YO'Q\n", "✅ Узких мест нет\n") +
+		return tr(lang, "analysis.no_bottleneck") +
 			"   " + b.Description + "\n"
 	}
 
-	return fmt.Sprintf(t(lang, "⚠️ %s BOTTLENECK (%.0f%%)\n", "⚠️ %s BOTTLENECK (%.0f%%)\n"), b.BottleneckType, b.Percentage) +
+	return tr(lang, "analysis.bottleneck", "type", b.BottleneckType, "percent", fmt.Sprintf("%.0f", b.Percentage)) +
 		"   " + b.Description + "\n" +
-		t(lang, "   💡 Tavsiya: ", "   💡 Рекомендация: ") + b.Recommendation + "\n"
+		tr(lang, "analysis.tip") + b.Recommendation + "\n"
 }
 
 // formatPower quvvat sarfi
 func formatPower(p entity.PowerData, lang string) string {
+	if p.PSUWattage == 0 {
+		return tr(lang, "analysis.power.recommend",
+			"total", p.TotalWattage, "transient", p.TransientWattage,
+			"recommended", p.RecommendedWattage, "tier", p.RecommendedTier, "advice", p.Recommendation)
+	}
+
 	if !p.IsAdequate {
-		return fmt.Sprintf(t(lang, `🔴 DIQQAT: PSU KUCHSIZ!
-⚡ Tizim talabi: ~%dW
-🔌 Sizning PSU: %dW
-❌ YETMAYDI! Kamida %dW tavsiya etiladi.
-💡 %s
-`, `🔴 ВНИМАНИЕ: БП СЛАБЫЙ!
-⚡ Требование системы: ~%dW
-🔌 Ваш БП: %dW
-❌ НЕ ХВАТАЕТ! Рекомендуется минимум %dW.
-💡 %s
-`), p.TotalWattage, p.PSUWattage, int(float64(p.TotalWattage)*1.25), p.Recommendation)
-	}
-
-	return fmt.Sprintf(t(lang, `✅ QUVVAT YETARLI
-⚡ Tizim talabi: ~%dW
-🔌 Sizning PSU: %dW (Zaxira: %.0fW)
-💡 %s
-`, `✅ МОЩНОСТИ ДОСТАТОЧНО
-⚡ Требование системы: ~%dW
-🔌 Ваш БП: %dW (Запас: %.0fW)
-💡 %s
-`), p.TotalWattage, p.PSUWattage, p.HeadRoom, p.Recommendation)
+		return tr(lang, "analysis.power.weak",
+			"total", p.TotalWattage, "transient", p.TransientWattage,
+			"psu", p.PSUWattage, "minimum", p.MinimumWattage, "advice", p.Recommendation)
+	}
+
+	return tr(lang, "analysis.power.ok",
+		"total", p.TotalWattage, "transient", p.TransientWattage,
+		"psu", p.PSUWattage, "headroom", fmt.Sprintf("%.0f", p.HeadRoom), "advice", p.Recommendation)
 }
 
 // formatStorageSpeed storage tezligi
@@ -222,17 +240,17 @@ func formatStorageSpeed(s entity.StorageSpeedData, lang string) string {
 		ratingIcon = "⚠️"
 	}
 
-	return fmt.Sprintf(t(lang, "💾 Turi: %s %s\n", "💾 Тип: %s %s\n"), s.Type, ratingIcon) +
-		fmt.Sprintf(t(lang, "   Read: %d MB/s │ Write: %d MB/s\n", "   Чтение: %d MB/s │ Запись: %d MB/s\n"), s.ReadSpeed, s.WriteSpeed)
+	return tr(lang, "analysis.storage_type", "type", s.Type, "icon", ratingIcon) +
+		tr(lang, "analysis.storage_speed", "read", s.ReadSpeed, "write", s.WriteSpeed)
 }
 
 // formatUseCaseMatch maqsadga mos kelish
 func formatUseCaseMatch(u entity.UseCaseMatchData, lang string) string {
 	result := ""
 	if strings.TrimSpace(u.RequestedUseCase) != "" {
-		result += fmt.Sprintf(t(lang, "🎯 So'ralgan: %s\n", "🎯 Запрошено: %s\n"), localizeUseCase(lang, u.RequestedUseCase))
+		result += tr(lang, "analysis.requested", "use_case", localizeUseCase(lang, u.RequestedUseCase))
 	}
-	result += fmt.Sprintf(t(lang, "🎯 Eng mos: %s\n\n", "🎯 Лучше всего: %s\n\n"), localizeUseCase(lang, u.BestFor))
+	result += tr(lang, "analysis.best_for", "use_case", localizeUseCase(lang, u.BestFor))
 
 	order := []string{"Gaming", "Developer", "Design", "Server", "Office"}
 	for _, useCase := range order {
@@ -253,7 +271,7 @@ func formatUseCaseMatch(u entity.UseCaseMatchData, lang string) string {
 	}
 
 	if len(u.Limitations) > 0 {
-		result += "\n" + t(lang, "⚠️ Cheklovlar:\n", "⚠️ Ограничения:\n")
+		result += "\n" + tr(lang, "analysis.limits")
 		for _, limit := range u.Limitations {
 			result += fmt.Sprintf("• %s\n", limit)
 		}
@@ -278,9 +296,9 @@ func formatUpgrades(upgrades []entity.UpgradeSuggestion, lang string) string {
 		}
 
 		result += fmt.Sprintf("%d. %s %s → %s\n", i+1, priorityIcon, u.Component, u.SuggestedSpec)
-		result += fmt.Sprintf(t(lang, "   Hozir: %s\n", "   Сейчас: %s\n"), u.CurrentSpec)
-		result += fmt.Sprintf(t(lang, "   Foyda: %s\n", "   Польза: %s\n"), u.Benefit)
-		result += fmt.Sprintf(t(lang, "   Narx: ~$%.0f\n\n", "   Цена: ~$%.0f\n\n"), u.EstimatedCost)
+		result += tr(lang, "analysis.upgrade.current", "spec", u.CurrentSpec)
+		result += tr(lang, "analysis.upgrade.benefit", "benefit", u.Benefit)
+		result += tr(lang, "analysis.upgrade.cost", "cost", fmt.Sprintf("%.0f", u.EstimatedCost))
 	}
 
 	return result
@@ -304,7 +322,10 @@ func resolveBuildPrice(build *entity.PCBuild) float64 {
 	if build == nil {
 		return 0
 	}
-	componentTotal := build.GetTotalPrice()
+	componentTotal := 0.0
+	if sum, err := build.GetTotalPrice(nil, entity.CurrencyUSD); err == nil {
+		componentTotal = sum.Major()
+	}
 	if build.Budget > 0 && (componentTotal == 0 || math.Abs(build.Budget-componentTotal) >= 0.5) {
 		return build.Budget
 	}
@@ -334,7 +355,7 @@ func formatWorkloadSection(useCase string, analytics *entity.PCAnalytics, lang s
 	var sb strings.Builder
 	sb.WriteString(workloadHeader(lang, useCase))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString(fmt.Sprintf(t(lang, "⭐ Baho: %.1f/10 (%s)\n", "⭐ Оценка: %.1f/10 (%s)\n"), score.Score, score.Description))
+	sb.WriteString(tr(lang, "analysis.workload_score", "score", fmt.Sprintf("%.1f", score.Score), "description", score.Description))
 
 	for _, line := range score.Strengths {
 		sb.WriteString(fmt.Sprintf("✅ %s\n", line))
@@ -349,15 +370,15 @@ func formatWorkloadSection(useCase string, analytics *entity.PCAnalytics, lang s
 func workloadHeader(lang, useCase string) string {
 	switch normalizeUseCaseKey(useCase) {
 	case "Developer":
-		return t(lang, "🧑‍💻 DEVELOPER ISH YUKLAMASI\n", "🧑‍💻 НАГРУЗКА ДЛЯ РАЗРАБОТКИ\n")
+		return tr(lang, "analysis.workload.developer")
 	case "Design":
-		return t(lang, "🎨 DIZAYN/MONTAJ YUKLAMASI\n", "🎨 НАГРУЗКА ДЛЯ ДИЗАЙНА/МОНТАЖА\n")
+		return tr(lang, "analysis.workload.design")
 	case "Server":
-		return t(lang, "🖥️ SERVER YUKLAMASI\n", "🖥️ СЕРВЕРНАЯ НАГРУЗКА\n")
+		return tr(lang, "analysis.workload.server")
 	case "Office":
-		return t(lang, "💼 OFFICE YUKLAMASI\n", "💼 ОФИСНАЯ НАГРУЗКА\n")
+		return tr(lang, "analysis.workload.office")
 	default:
-		return t(lang, "🧩 ISH YUKLAMASI\n", "🧩 НАГРУЗКА\n")
+		return tr(lang, "analysis.workload.generic")
 	}
 }
 
@@ -0,0 +1,255 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// Periferiya to'plami: /peripherals dan keyin mijoz budjet va xohishlarini yozadi (simsiz,
+// switch turi, DPI, rang, Hz/panel, videokartasi), usecase.BuildPeripheralBundle qoldiqdan
+// to'plam tanlaydi va u bitta tugma bilan savatga qo'shiladi. Konfigurator ham monitor va
+// periferiyani shu tanlov bilan AI ga aniq model qilib beradi.
+
+// peripheralOffer mijozga oxirgi ko'rsatilgan to'plam (savat tugmasi shu ID bilan ishlaydi)
+type peripheralOffer struct {
+	ID     string
+	Bundle *usecase.PeripheralBundle
+}
+
+// handlePeripheralsCommand /peripherals [tavsif] - tavsif bo'lsa darhol tanlaydi, aks holda so'raydi
+func (h *BotHandler) handlePeripheralsCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	if args := strings.TrimSpace(message.CommandArguments()); args != "" {
+		h.advisePeripherals(ctx, userID, message.Chat.ID, args)
+		return
+	}
+	h.awaitPeripherals(userID, message.Chat.ID)
+	h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "peripherals.prompt"))
+}
+
+// handlePeripheralsInput /peripherals dan keyingi matn (convFlowPeripherals)
+func (h *BotHandler) handlePeripheralsInput(ctx context.Context, in conversationInput) bool {
+	if _, ok := h.conversationStateIn(in.UserID, convFlowPeripherals); !ok {
+		return false
+	}
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		return false
+	}
+	h.leaveConversation(in.UserID, convFlowPeripherals)
+	h.advisePeripherals(ctx, in.UserID, in.ChatID, text)
+	return true
+}
+
+// awaitPeripherals mijozdan tavsif kutadi
+func (h *BotHandler) awaitPeripherals(userID, chatID int64) {
+	h.enterConversation(userID, convFlowPeripherals, "need_description", chatID)
+}
+
+// advisePeripherals tavsifdan to'plam tanlaydi va savat tugmasi bilan yuboradi
+func (h *BotHandler) advisePeripherals(ctx context.Context, userID, chatID int64, text string) {
+	lang := h.getUserLang(userID)
+	if h.configBuilder == nil {
+		h.sendMessage(chatID, tr(lang, "peripherals.unavailable"))
+		return
+	}
+	req := usecase.ParsePeripheralRequest(text, h.benchmarks())
+	req.Branch = h.branchStockFor(userID)
+	bundle, err := h.configBuilder.PeripheralBundle(ctx, req)
+	if err != nil {
+		log.Printf("peripheral bundle catalog error: %v", err)
+		h.sendMessage(chatID, tr(lang, "peripherals.unavailable"))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, h.applyCurrencyPreference(peripheralBundleText(lang, bundle, req.GPU)))
+	if len(bundle.Picks) > 0 {
+		offer := peripheralOffer{ID: newUUID()[:8], Bundle: bundle}
+		h.peripheralMu.Lock()
+		if h.peripheralOffers == nil {
+			h.peripheralOffers = make(map[int64]peripheralOffer)
+		}
+		h.peripheralOffers[userID] = offer
+		h.peripheralMu.Unlock()
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "peripherals.add"), "peri_cart|"+offer.ID),
+		))
+	}
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("peripheral bundle send error: %v", err)
+	}
+}
+
+// peripheralBundleText GPU bo'yicha monitor chegarasi, tanlangan mahsulotlar va jami
+func peripheralBundleText(lang string, b *usecase.PeripheralBundle, gpu entity.Product) string {
+	var sb strings.Builder
+	if b.BudgetUSD > 0 {
+		sb.WriteString(tr(lang, "peripherals.header_budget", "budget", fmt.Sprintf("%.0f", b.BudgetUSD)))
+	} else {
+		sb.WriteString(tr(lang, "peripherals.header"))
+	}
+	if b.MonitorCapHz > 0 && strings.TrimSpace(gpu.Name) != "" {
+		sb.WriteString("\n" + tr(lang, "peripherals.monitor_cap", "gpu", gpu.Name, "resolution", b.MonitorCapRes, "hz", b.MonitorCapHz))
+	}
+	if b.HzCapped {
+		sb.WriteString("\n" + tr(lang, "peripherals.hz_capped", "hz", b.MonitorCapHz))
+	}
+
+	if len(b.Picks) == 0 {
+		sb.WriteString("\n\n" + tr(lang, "peripherals.none"))
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	for _, pick := range b.Picks {
+		sb.WriteString(fmt.Sprintf("\n• %s: %s — %.0f$", tr(lang, "peripherals.kind."+pick.Kind), pick.Product.Name, pick.PriceUSD))
+		if len(pick.Misses) > 0 {
+			labels := make([]string, 0, len(pick.Misses))
+			for _, m := range pick.Misses {
+				labels = append(labels, tr(lang, "peripherals.miss."+m))
+			}
+			sb.WriteString("\n   " + tr(lang, "peripherals.misses", "prefs", strings.Join(labels, ", ")))
+		}
+		if pick.OverBudget {
+			sb.WriteString("\n   " + tr(lang, "peripherals.over_budget", "allotted", fmt.Sprintf("%.0f", pick.Allotted)))
+		}
+	}
+	if len(b.Missing) > 0 {
+		labels := make([]string, 0, len(b.Missing))
+		for _, kind := range b.Missing {
+			labels = append(labels, tr(lang, "peripherals.kind."+kind))
+		}
+		sb.WriteString("\n\n" + tr(lang, "peripherals.missing", "kinds", strings.Join(labels, ", ")))
+	}
+	sb.WriteString("\n\n" + tr(lang, "peripherals.total", "total", fmt.Sprintf("%.0f", b.TotalUSD)))
+	return sb.String()
+}
+
+// peripheralCartText to'plam savatda konfiguratsiya formatida ("• Monitor: nom - narx$", "Overall price")
+func peripheralCartText(b *usecase.PeripheralBundle) string {
+	var sb strings.Builder
+	for _, pick := range b.Picks {
+		sb.WriteString(fmt.Sprintf("• %s: %s - %.0f$\n", peripheralLineLabel(pick.Kind), pick.Product.Name, pick.PriceUSD))
+	}
+	sb.WriteString(fmt.Sprintf("Overall price: %.0f$", b.TotalUSD))
+	return sb.String()
+}
+
+// peripheralLineLabel konfiguratsiya matnidagi qator nomi (AI formati bilan bir xil)
+func peripheralLineLabel(kind string) string {
+	switch kind {
+	case usecase.PartMonitor:
+		return "Monitor"
+	case usecase.PartKeyboard:
+		return "Klaviatura"
+	case usecase.PartMouse:
+		return "Sichqoncha"
+	case usecase.PartHeadset:
+		return "Quloqchin"
+	}
+	return kind
+}
+
+// handlePeripheralsCartCallback peri_cart|<offerID> - to'plamni savatga qo'shadi
+func (h *BotHandler) handlePeripheralsCartCallback(userID, chatID int64, data string, msg *tgbotapi.Message) {
+	lang := h.getUserLang(userID)
+	id := strings.TrimPrefix(data, "peri_cart|")
+	h.peripheralMu.Lock()
+	offer, ok := h.peripheralOffers[userID]
+	h.peripheralMu.Unlock()
+	if !ok || offer.ID != id || offer.Bundle == nil {
+		h.sendMessage(chatID, tr(lang, "peripherals.expired"))
+		return
+	}
+	title := tr(lang, "peripherals.cart_title")
+	h.addToCart(userID, cartItem{Title: title, Text: peripheralCartText(offer.Bundle)})
+
+	reply := tgbotapi.NewMessage(chatID, tr(lang, "peripherals.added", "title", title))
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🛒 Savatcha", "cart_open"),
+	))
+	if msg != nil {
+		reply.ReplyToMessageID = msg.MessageID
+	}
+	if _, err := h.sendAndLog(reply); err != nil {
+		log.Printf("peripheral cart reply failed: %v", err)
+	}
+}
+
+// configPeripheralBundle konfigurator uchun to'plam: budjet ulushi, rang/Hz/panel xohishi va
+// optimizator shu budjetga tanlaydigan videokarta bo'yicha. Monitor ham, periferiya ham kerak
+// bo'lmasa yoki katalog o'qilmasa nil.
+func (h *BotHandler) configPeripheralBundle(ctx context.Context, userID int64, session *configSession) *usecase.PeripheralBundle {
+	if h.configBuilder == nil || session == nil {
+		return nil
+	}
+	var kinds []string
+	if session.NeedMonitor {
+		kinds = append(kinds, usecase.PartMonitor)
+	}
+	if session.NeedPeripherals {
+		kinds = append(kinds, usecase.PartKeyboard, usecase.PartMouse, usecase.PartHeadset)
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	budget := parseBudgetUSD(session.Budget)
+	req := usecase.PeripheralRequest{
+		BudgetUSD: usecase.PeripheralBudget(budget, kinds),
+		Kinds:     kinds,
+		Prefs: usecase.PeripheralPrefs{
+			Color:        usecase.NormalizePeripheralColor(session.Color),
+			MonitorHz:    usecase.ParseHz(session.MonitorHz),
+			MonitorPanel: session.MonitorDisplay,
+		},
+		Purpose: session.PCType,
+		Branch:  h.branchStockFor(userID),
+		Bench:   h.benchmarks(),
+	}
+	switch {
+	case isGPUDisabled(session.GPUBrand):
+		req.GPU = entity.Product{Name: "Integrated Graphics"}
+	case session.NeedMonitor && budget > 0:
+		opt := h.configBuilder.optimizeRequest(budget, session.PCType, session.CPUBrand, session.GPUBrand)
+		if alts, err := h.configBuilder.BuildAlternatives(ctx, opt, 1); err == nil && len(alts) > 0 {
+			req.GPU = alts[0].Build.GPU
+		}
+	}
+	bundle, err := h.configBuilder.PeripheralBundle(ctx, req)
+	if err != nil {
+		log.Printf("config peripheral bundle error: %v", err)
+		return nil
+	}
+	for _, pick := range bundle.Picks {
+		switch pick.Kind {
+		case usecase.PartKeyboard:
+			session.Keyboard = pick.Product.Name
+		case usecase.PartMouse:
+			session.Mouse = pick.Product.Name
+		case usecase.PartHeadset:
+			session.Headphones = pick.Product.Name
+		}
+	}
+	return bundle
+}
+
+// peripheralPromptLines to'plamdagi tanlangan turlar AI formatidagi qatorlar ("• Klaviatura: nom - narx$")
+func peripheralPromptLines(b *usecase.PeripheralBundle, kinds ...string) string {
+	if b == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, pick := range b.Picks {
+		for _, kind := range kinds {
+			if pick.Kind == kind {
+				sb.WriteString(fmt.Sprintf("• %s: %s - %.0f$\n", peripheralLineLabel(kind), pick.Product.Name, pick.PriceUSD))
+			}
+		}
+	}
+	return sb.String()
+}
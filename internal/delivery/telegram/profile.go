@@ -1,23 +1,23 @@
 package telegram
 
 import (
+	"context"
 	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func (h *BotHandler) setProfileStage(userID int64, stage string) {
-	h.profileMu.Lock()
-	defer h.profileMu.Unlock()
 	if stage == "" {
-		delete(h.profileStage, userID)
+		h.leaveConversation(userID, convFlowProfile)
 		return
 	}
-	h.profileStage[userID] = stage
+	h.enterConversation(userID, convFlowProfile, stage, 0)
 }
 
 func (h *BotHandler) getProfileStage(userID int64) string {
-	h.profileMu.RLock()
-	defer h.profileMu.RUnlock()
-	return h.profileStage[userID]
+	stage, _ := h.conversationStateIn(userID, convFlowProfile)
+	return stage
 }
 
 func (h *BotHandler) setProfile(userID int64, upd userProfile) {
@@ -41,11 +41,15 @@ func (h *BotHandler) setProfile(userID int64, upd userProfile) {
 	h.profiles[userID] = prof
 	h.profileMu.Unlock()
 	if changed {
+		h.persistUser("profile", func(ctx context.Context, store UserStore) error {
+			return store.SetProfile(ctx, userID, prof.Name, prof.Phone)
+		})
 		h.scheduleAboutUserSheetSync("profile")
 	}
 }
 
 func (h *BotHandler) getProfile(userID int64) (userProfile, bool) {
+	h.ensureUserLoaded(userID)
 	h.profileMu.RLock()
 	defer h.profileMu.RUnlock()
 	prof, ok := h.profiles[userID]
@@ -80,12 +84,69 @@ func (h *BotHandler) clearProfilePrompt(userID int64) {
 	delete(h.profileMeta, userID)
 }
 
+// handleProfileInput profil to'ldirish bosqichidagi (ism/telefon) xabarni qayta ishlaydi
+func (h *BotHandler) handleProfileInput(userID int64, text string, chatID int64, msg *tgbotapi.Message) bool {
+	lang := h.getUserLang(userID)
+	switch h.getProfileStage(userID) {
+	case "need_name":
+		name := strings.TrimSpace(text)
+		if name == "" || !validateName(name) {
+			h.sendMessage(chatID, tr(lang, "profile.invalid_name"))
+			return true
+		}
+		h.setProfile(userID, userProfile{Name: name})
+		h.setProfileStage(userID, "need_phone")
+		if pid := h.getProfilePrompt(userID); pid != 0 {
+			h.deleteMessage(chatID, pid)
+			h.clearProfilePrompt(userID)
+		}
+		npid := h.sendPhonePrompt(chatID, lang)
+		if npid != 0 {
+			h.setProfilePrompt(userID, npid)
+		}
+		h.deleteUserMessage(chatID, msg)
+		return true
+	case "need_phone":
+		phone := ""
+		if msg != nil && msg.Contact != nil && msg.Contact.PhoneNumber != "" {
+			phone = msg.Contact.PhoneNumber
+		} else {
+			phone = strings.TrimSpace(text)
+		}
+		if !validatePhoneNumber(phone) {
+			h.sendMessage(chatID, tr(lang, "profile.invalid_phone"))
+			h.sendPhoneRequest(chatID)
+			return true
+		}
+		h.setProfile(userID, userProfile{Phone: phone})
+		h.setProfileStage(userID, "")
+		if pid := h.getProfilePrompt(userID); pid != 0 {
+			h.deleteMessage(chatID, pid)
+			h.clearProfilePrompt(userID)
+		}
+		h.deleteUserMessage(chatID, msg)
+		// Shaxsiy salomlashuv
+		if prof, ok := h.getProfile(userID); ok {
+			h.sendGreeting(chatID, lang, prof.Name)
+		} else {
+			h.sendGreeting(chatID, lang, "")
+		}
+		return true
+	}
+	return false
+}
+
 // Profilni to'ldirishga chaqirish
 func (h *BotHandler) maybeAskProfile(userID, chatID int64, lang string) {
+	// Til qayta tanlansa eski so'rov xabarini olib tashlaymiz
+	if pid := h.getProfilePrompt(userID); pid != 0 {
+		h.deleteMessage(chatID, pid)
+		h.clearProfilePrompt(userID)
+	}
 	prof, ok := h.getProfile(userID)
 	if !ok || strings.TrimSpace(prof.Name) == "" {
 		h.setProfileStage(userID, "need_name")
-		if sent, err := h.sendMessageWithResp(chatID, t(lang, "Ismingizni yozing.", "Напишите ваше имя.")); err == nil {
+		if sent, err := h.sendMessageWithResp(chatID, tr(lang, "profile.ask_name")); err == nil {
 			h.setProfilePrompt(userID, sent.MessageID)
 		}
 		return
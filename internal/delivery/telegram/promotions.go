@@ -0,0 +1,459 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// Aksiyalar: admin /promo bilan boshqaradi, mijoz checkout paytida /promo KOD yuboradi.
+// Hisob-kitob usecase.PromotionUseCase da; bu yerda faqat savat/checkout/buyurtmaga ulash.
+
+// promoQuote matn bo'yicha aksiyalar hisob-kitobi (usecase ulanmagan bo'lsa bo'sh quote)
+func (h *BotHandler) promoQuote(text, code string) (entity.PriceQuote, error) {
+	if h.promotionUseCase == nil || strings.TrimSpace(text) == "" {
+		return entity.PriceQuote{}, nil
+	}
+	return h.promotionUseCase.Quote(context.Background(), text, code)
+}
+
+// orderSessionPromoText checkout'dagi mahsulotlar matni (orderSessionTotal bilan bir xil tartib)
+func orderSessionPromoText(session *orderSession) string {
+	if session == nil {
+		return ""
+	}
+	if strings.TrimSpace(session.ConfigTxt) != "" {
+		return session.ConfigTxt
+	}
+	return session.Summary
+}
+
+// checkoutQuote sessiya promo kodi bilan; kod endi yaroqsiz bo'lsa avtomatik aksiyalar bilan
+func (h *BotHandler) checkoutQuote(session *orderSession) entity.PriceQuote {
+	text := orderSessionPromoText(session)
+	quote, err := h.promoQuote(text, session.PromoCode)
+	if err != nil && session.PromoCode != "" {
+		log.Printf("promo code %q dropped at checkout: %v", session.PromoCode, err)
+		quote, err = h.promoQuote(text, "")
+	}
+	if err != nil {
+		log.Printf("promo quote failed: %v", err)
+		return entity.PriceQuote{}
+	}
+	return quote
+}
+
+// redeemCheckoutPromo aksiyalar foydalanishini buyurtma yozilishidan oldin band qiladi.
+// Limit shu orada tugagan bo'lsa (boshqa mijoz oxirgi foydalanishni oldi) narx tugagan
+// aksiyalarsiz qayta hisoblanadi
+func (h *BotHandler) redeemCheckoutPromo(session *orderSession, quote entity.PriceQuote) entity.PriceQuote {
+	if h.promotionUseCase == nil {
+		return quote
+	}
+	for attempt := 0; attempt < 3 && len(quote.Applied) > 0; attempt++ {
+		err := h.promotionUseCase.Redeem(context.Background(), quote)
+		if err == nil {
+			return quote
+		}
+		if !errors.Is(err, usecase.ErrPromoCodeExhausted) {
+			log.Printf("promo redeem failed: %v", err)
+			return quote
+		}
+		log.Printf("promo limit reached at checkout, requoting: %v", err)
+		quote = h.checkoutQuote(session)
+	}
+	return quote
+}
+
+// releasePromoUses bekor qilingan (yoki yozilmay qolgan) buyurtma aksiyalari
+// foydalanishini qaytaradi
+func (h *BotHandler) releasePromoUses(ids []string) {
+	if h.promotionUseCase == nil || len(ids) == 0 {
+		return
+	}
+	if err := h.promotionUseCase.Release(context.Background(), ids); err != nil {
+		log.Printf("promo release failed ids=%v: %v", ids, err)
+	}
+}
+
+// orderPromoIDs buyurtma qatorlarida qo'llangan aksiyalar (takrorlarsiz)
+func orderPromoIDs(lines []entity.QuotedLine) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, l := range lines {
+		for _, id := range l.Promos {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// checkoutTotal muddatli to'lov va buyurtma uchun jami: aksiya bo'lsa chegirmali summa
+func (h *BotHandler) checkoutTotal(session *orderSession) string {
+	if q := h.checkoutQuote(session); !q.Discount.IsZero() {
+		return q.Total.String()
+	}
+	return orderSessionTotal(session)
+}
+
+// cartTotal savat jami summasi (aksiyalar bilan)
+func (h *BotHandler) cartTotal(items []cartItem) (string, entity.PriceQuote) {
+	var texts []string
+	for _, it := range items {
+		texts = append(texts, nonEmpty(strings.TrimSpace(it.Text), it.Title))
+	}
+	quote, err := h.promoQuote(strings.Join(texts, "\n"), "")
+	if err == nil && !quote.Discount.IsZero() {
+		return quote.Total.String(), quote
+	}
+	return cartInstallmentTotal(items), entity.PriceQuote{}
+}
+
+// cartSummaryText savat ostidagi aksiya qatori va muddatli to'lov kalkulyatori
+func (h *BotHandler) cartSummaryText(lang string, items []cartItem) string {
+	total, quote := h.cartTotal(items)
+	var parts []string
+	if !quote.Discount.IsZero() {
+		parts = append(parts, tr(lang, "promo.cart_line", "discount", quote.Discount.String(), "total", quote.Total.String()))
+	}
+	if calc := h.installmentCalculatorText(lang, total); calc != "" {
+		parts = append(parts, calc)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// promoDiscountLabel admin xabarlari va hisobot uchun chegirma (bo'sh - chegirma yo'q)
+func promoDiscountLabel(lines []entity.QuotedLine, code string) string {
+	var parts []string
+	for _, l := range lines {
+		if l.Discount.IsZero() {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s → %s (%s)", l.Name, l.Price, l.Final, strings.Join(l.Promos, ",")))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	label := strings.Join(parts, "; ")
+	if code != "" {
+		label = "kod " + code + "; " + label
+	}
+	return label
+}
+
+func (h *BotHandler) handlePromoCommand(ctx context.Context, message *tgbotapi.Message) {
+	if h.isAdminActive(message.From.ID) {
+		h.handleAdminPromoCommand(ctx, message)
+		return
+	}
+	h.handleUserPromoCode(ctx, message.From.ID, message.Chat.ID, message.CommandArguments())
+}
+
+// handleUserPromoCode checkout davomida promo kodni tekshirib sessiyaga yozadi
+func (h *BotHandler) handleUserPromoCode(ctx context.Context, userID, chatID int64, code string) {
+	lang := h.getUserLang(userID)
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		h.sendMessage(chatID, tr(lang, "promo.usage"))
+		return
+	}
+	h.orderMu.RLock()
+	session, ok := h.orderSessions[userID]
+	h.orderMu.RUnlock()
+	if !ok || h.promotionUseCase == nil {
+		h.sendMessage(chatID, tr(lang, "promo.no_order"))
+		return
+	}
+
+	quote, err := h.promotionUseCase.Quote(ctx, orderSessionPromoText(session), code)
+	switch {
+	case errors.Is(err, usecase.ErrPromoCodeUnknown):
+		h.sendMessage(chatID, tr(lang, "promo.unknown"))
+		return
+	case errors.Is(err, usecase.ErrPromoCodeInactive):
+		h.sendMessage(chatID, tr(lang, "promo.inactive"))
+		return
+	case errors.Is(err, usecase.ErrPromoCodeExhausted):
+		h.sendMessage(chatID, tr(lang, "promo.exhausted"))
+		return
+	case errors.Is(err, usecase.ErrPromoNotApplicable):
+		h.sendMessage(chatID, tr(lang, "promo.not_applicable"))
+		return
+	case err != nil:
+		log.Printf("promo code check failed user=%d: %v", userID, err)
+		h.sendMessage(chatID, tr(lang, "promo.error"))
+		return
+	}
+
+	h.orderMu.Lock()
+	if s, ok := h.orderSessions[userID]; ok {
+		s.PromoCode = code
+	}
+	h.orderMu.Unlock()
+	// Bepul yetkazish chegarasi chegirmali jamiga bog'liq
+	if session.Zone != nil {
+		zone := h.quoteDelivery(session)
+		h.orderMu.Lock()
+		session.Zone = zone
+		h.orderMu.Unlock()
+	}
+	h.sendMessage(chatID, tr(lang, "promo.applied", "code", code, "discount", quote.Discount.String(), "total", quote.Total.String()))
+}
+
+const promoAdminUsage = `Foydalanish:
+/promo add <id> <percent|fixed> <qiymat> <qamrov> [code=KOD] [from=2026-11-01] [to=2026-11-30] [limit=100]
+  qamrov: all | product="RTX 4060" | category=GPU | brand=AMD | bundle=CPU+Motherboard
+  qiymat: 10 (foiz) yoki 50$ / 500000so'm (fixed)
+/promo off <id> | /promo on <id> - o'chirish/yoqish
+/promo remove <id> - butunlay o'chirish`
+
+func (h *BotHandler) handleAdminPromoCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	if h.promotionUseCase == nil {
+		h.sendMessage(chatID, "❌ Aksiyalar moduli ulanmagan.")
+		return
+	}
+	args := splitPromoArgs(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(chatID, h.promotionsListText(ctx)+"\n\n"+promoAdminUsage)
+		return
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		promo, err := parsePromoArgs(args[1:])
+		if err != nil {
+			h.sendMessage(chatID, "❌ "+err.Error()+"\n\n"+promoAdminUsage)
+			return
+		}
+		if existing, ok := h.findPromotion(ctx, promo.ID); ok {
+			promo.Uses = existing.Uses
+		}
+		if err := h.promotionUseCase.Save(ctx, promo); err != nil {
+			h.sendMessage(chatID, fmt.Sprintf("❌ Aksiya saqlanmadi: %v", err))
+			return
+		}
+		h.sendMessage(chatID, "✅ Aksiya saqlandi:\n"+promotionLine(promo, time.Now()))
+	case "off", "on":
+		if len(args) != 2 {
+			h.sendMessage(chatID, promoAdminUsage)
+			return
+		}
+		promo, ok := h.findPromotion(ctx, args[1])
+		if !ok {
+			h.sendMessage(chatID, fmt.Sprintf("❌ %s aksiyasi topilmadi.", args[1]))
+			return
+		}
+		promo.Disabled = strings.EqualFold(args[0], "off")
+		if err := h.promotionUseCase.Save(ctx, promo); err != nil {
+			h.sendMessage(chatID, fmt.Sprintf("❌ Aksiya saqlanmadi: %v", err))
+			return
+		}
+		h.sendMessage(chatID, "✅ "+promotionLine(promo, time.Now()))
+	case "remove", "del":
+		if len(args) != 2 {
+			h.sendMessage(chatID, promoAdminUsage)
+			return
+		}
+		if err := h.promotionUseCase.Delete(ctx, args[1]); err != nil {
+			h.sendMessage(chatID, fmt.Sprintf("❌ %s aksiyasi topilmadi.", args[1]))
+			return
+		}
+		h.sendMessage(chatID, fmt.Sprintf("🗑️ %s aksiyasi o'chirildi.", args[1]))
+	default:
+		h.sendMessage(chatID, promoAdminUsage)
+	}
+}
+
+func (h *BotHandler) findPromotion(ctx context.Context, id string) (entity.Promotion, bool) {
+	list, err := h.promotionUseCase.List(ctx)
+	if err != nil {
+		return entity.Promotion{}, false
+	}
+	for _, p := range list {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return entity.Promotion{}, false
+}
+
+func (h *BotHandler) promotionsListText(ctx context.Context) string {
+	list, err := h.promotionUseCase.List(ctx)
+	if err != nil {
+		return fmt.Sprintf("❌ Aksiyalarni o'qib bo'lmadi: %v", err)
+	}
+	if len(list) == 0 {
+		return "🏷 Aksiyalar yo'q."
+	}
+	now := time.Now()
+	var sb strings.Builder
+	sb.WriteString("🏷 Aksiyalar:\n")
+	for _, p := range list {
+		sb.WriteString(promotionLine(p, now) + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// promotionLine admin ro'yxati uchun bitta aksiya
+func promotionLine(p entity.Promotion, now time.Time) string {
+	state := "✅"
+	switch {
+	case p.Disabled:
+		state = "⏸"
+	case p.Exhausted():
+		state = "🔚"
+	case !p.ActiveAt(now):
+		state = "🕒"
+	}
+	value := formatPercent(p.Value) + "%"
+	if p.Kind == entity.PromoFixed {
+		value = entity.NewMoney(p.Value, nonEmpty(p.Currency, entity.CurrencyUSD)).String()
+	}
+	scope := string(p.Scope)
+	switch p.Scope {
+	case entity.ScopeBundle:
+		scope += "=" + strings.Join(p.Bundle, "+")
+	case entity.ScopeProduct, entity.ScopeCategory, entity.ScopeBrand:
+		scope += "=" + p.Target
+	}
+	line := fmt.Sprintf("%s %s: -%s, %s", state, p.ID, value, scope)
+	if p.Code != "" {
+		line += ", kod " + p.Code
+	}
+	if !p.StartsAt.IsZero() || !p.EndsAt.IsZero() {
+		line += fmt.Sprintf(", %s — %s", nonEmpty(formatOptionalTime(p.StartsAt), "…"), nonEmpty(formatOptionalTime(p.EndsAt), "…"))
+	}
+	if p.MaxUses > 0 {
+		line += fmt.Sprintf(", %d/%d", p.Uses, p.MaxUses)
+	} else if p.Uses > 0 {
+		line += fmt.Sprintf(", %d marta", p.Uses)
+	}
+	return line
+}
+
+// parsePromoArgs "/promo add" argumentlari: id, tur, qiymat, qamrov va ixtiyoriy key=value lar
+func parsePromoArgs(args []string) (entity.Promotion, error) {
+	if len(args) < 4 {
+		return entity.Promotion{}, errors.New("Noto'g'ri format.")
+	}
+	promo := entity.Promotion{ID: args[0], Kind: entity.PromotionKind(strings.ToLower(args[1]))}
+
+	rawValue := strings.TrimSpace(args[2])
+	value, ok := parsePromoValue(rawValue)
+	if !ok {
+		return promo, fmt.Errorf("Noto'g'ri qiymat: %s", rawValue)
+	}
+	promo.Value = value
+	if promo.Kind == entity.PromoFixed {
+		promo.Currency = nonEmpty(entity.ParseCurrency(rawValue), entity.CurrencyUSD)
+	}
+
+	scopeKey, scopeVal, _ := strings.Cut(args[3], "=")
+	promo.Scope = entity.PromotionScope(strings.ToLower(scopeKey))
+	if promo.Scope == entity.ScopeBundle {
+		for _, c := range strings.Split(scopeVal, "+") {
+			if c = strings.TrimSpace(c); c != "" {
+				promo.Bundle = append(promo.Bundle, c)
+			}
+		}
+	} else {
+		promo.Target = strings.TrimSpace(scopeVal)
+	}
+
+	for _, opt := range args[4:] {
+		key, val, found := strings.Cut(opt, "=")
+		if !found {
+			return promo, fmt.Errorf("Noma'lum parametr: %s", opt)
+		}
+		switch strings.ToLower(key) {
+		case "code":
+			promo.Code = strings.ToUpper(strings.TrimSpace(val))
+		case "from":
+			t, err := parsePromoDate(val, false)
+			if err != nil {
+				return promo, err
+			}
+			promo.StartsAt = t
+		case "to":
+			t, err := parsePromoDate(val, true)
+			if err != nil {
+				return promo, err
+			}
+			promo.EndsAt = t
+		case "limit":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return promo, fmt.Errorf("Noto'g'ri limit: %s", val)
+			}
+			promo.MaxUses = n
+		default:
+			return promo, fmt.Errorf("Noma'lum parametr: %s", key)
+		}
+	}
+	return promo, nil
+}
+
+// parsePromoDate "2026-11-30" yoki "2026-11-30T18:00"; faqat sana bo'lsa "to" shu kun oxirigacha
+func parsePromoDate(s string, endOfDay bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.ParseInLocation("2006-01-02T15:04", s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Noto'g'ri sana: %s (YYYY-MM-DD)", s)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// parsePromoValue "10", "10%", "50$", "500 000so'm" dan son
+func parsePromoValue(s string) (float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimRight(s, "%$€₽")
+	for _, suffix := range []string{"so'm", "som", "sum", "uzs", "usd", "eur", "rub"} {
+		s = strings.TrimSuffix(s, suffix)
+	}
+	s = strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), ",", ".")
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil && v > 0
+}
+
+// splitPromoArgs bo'shliq bo'yicha ajratadi, "..." ichidagi bo'shliqlar saqlanadi
+func splitPromoArgs(s string) []string {
+	var out []string
+	var cur strings.Builder
+	inQuote := false
+	flush := func() {
+		if cur.Len() > 0 {
+			out = append(out, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			inQuote = !inQuote
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuote:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return out
+}
@@ -0,0 +1,121 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// "Qanday quvvat bloki kerak" maslahatchisi: /psu dan keyin mijoz komponentlarini yozadi,
+// quvvat modeli (usecase.ComputePowerBudget) bo'yicha minimal/tavsiya quvvat, 80 PLUS darajasi
+// va qoldiqdagi mos bloklar narxi bilan ko'rsatiladi. AI chaqirilmaydi.
+
+const psuOptionsLimit = 3
+
+// handlePSUCommand /psu [tavsif] - tavsif bo'lsa darhol hisoblaydi, aks holda so'raydi
+func (h *BotHandler) handlePSUCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	if args := strings.TrimSpace(message.CommandArguments()); args != "" {
+		h.advisePSU(ctx, userID, message.Chat.ID, args)
+		return
+	}
+	h.awaitPSU(userID, message.Chat.ID)
+	h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "psu.prompt"))
+}
+
+// handlePSUInput /psu dan keyingi matn (convFlowPSU)
+func (h *BotHandler) handlePSUInput(ctx context.Context, in conversationInput) bool {
+	if _, ok := h.conversationStateIn(in.UserID, convFlowPSU); !ok {
+		return false
+	}
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		return false
+	}
+	h.leaveConversation(in.UserID, convFlowPSU)
+	h.advisePSU(ctx, in.UserID, in.ChatID, text)
+	return true
+}
+
+// awaitPSU mijozdan tavsif kutadi
+func (h *BotHandler) awaitPSU(userID, chatID int64) {
+	h.enterConversation(userID, convFlowPSU, "need_description", chatID)
+}
+
+// advisePSU tavsifdan quvvat budjetini hisoblaydi va mos bloklarni yuboradi
+func (h *BotHandler) advisePSU(ctx context.Context, userID, chatID int64, text string) {
+	lang := h.getUserLang(userID)
+	bench := h.benchmarks()
+	build, ok := usecase.ParsePCDescription(text, bench)
+	if !ok || build.CPU.Name == "" {
+		h.sendMessage(chatID, tr(lang, "psu.not_recognized"))
+		h.awaitPSU(userID, chatID)
+		return
+	}
+	budget := usecase.ComputePowerBudget(build, bench)
+
+	var options []entity.Product
+	price := func(p entity.Product) float64 { return p.Price.Major() }
+	if h.configBuilder != nil {
+		price = func(p entity.Product) float64 { return h.configBuilder.priceUSD(&p) }
+	}
+	if h.productUseCase != nil {
+		products, err := h.productUseCase.GetAll(ctx)
+		if err != nil {
+			log.Printf("psu advisor catalog error: %v", err)
+		}
+		options = usecase.SuitablePSUs(products, budget, h.branchStockFor(userID), price, psuOptionsLimit)
+	}
+	h.sendMessage(chatID, h.applyCurrencyPreference(psuAdviceText(lang, build, budget, options, price)))
+}
+
+// psuAdviceText quvvat hisobi, tavsiya, mijoz blokiga baho va qoldiqdagi variantlar
+func psuAdviceText(lang string, build *entity.PCBuild, b usecase.PowerBudget, options []entity.Product, price func(entity.Product) float64) string {
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "psu.header"))
+	if build.CPU.Name != "" {
+		sb.WriteString(fmt.Sprintf("\n• CPU (%s): %d W", build.CPU.Name, b.CPU))
+	}
+	if b.GPU > 0 {
+		sb.WriteString(fmt.Sprintf("\n• GPU (%s): %d W", build.GPU.Name, b.GPU))
+	}
+	sb.WriteString("\n" + tr(lang, "psu.platform", "watts", b.Board+b.Memory+b.Storage))
+	sb.WriteString("\n" + tr(lang, "psu.cooling", "watts", b.Cooling+b.Peripherals))
+	sb.WriteString("\n\n" + tr(lang, "psu.sustained", "watts", b.Sustained))
+	sb.WriteString("\n" + tr(lang, "psu.transient", "watts", b.Transient))
+	sb.WriteString("\n\n" + tr(lang, "psu.minimum", "watts", b.Minimum))
+	sb.WriteString("\n" + tr(lang, "psu.recommended", "watts", b.Recommended, "tier", b.Efficiency))
+	if b.ATX3 {
+		sb.WriteString("\n" + tr(lang, "psu.atx3"))
+	}
+
+	if watts := usecase.PartAttrs(usecase.PartPSU, build.PSU).Watts; watts > 0 {
+		key := "psu.yours_ok"
+		switch b.Check(watts) {
+		case usecase.PSUUndersized:
+			key = "psu.yours_undersized"
+		case usecase.PSULowHeadroom:
+			key = "psu.yours_low"
+		}
+		sb.WriteString("\n\n" + tr(lang, key, "watts", watts, "min", b.Minimum, "recommended", b.Recommended))
+	}
+
+	if len(options) == 0 {
+		sb.WriteString("\n\n" + tr(lang, "psu.none"))
+		return sb.String()
+	}
+	sb.WriteString("\n\n" + tr(lang, "psu.options"))
+	for _, p := range options {
+		line := fmt.Sprintf("\n• %s — %.0f$", p.Name, price(p))
+		if tier := usecase.PSUEfficiency(p); tier != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(tier)) {
+			line += " (" + tier + ")"
+		}
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
@@ -13,6 +13,7 @@ import (
 
 // Admin eslatma sozlash jarayonini boshlash
 func (h *BotHandler) beginReminderInput(userID, chatID int64) {
+	h.enterConversation(userID, convFlowReminderInput, "need_count", chatID)
 	h.reminderMu.Lock()
 	h.reminderInput[userID] = &reminderInputState{stage: reminderStageNeedCount, chatID: chatID}
 	current := len(h.reminderTemplates)
@@ -42,6 +43,7 @@ func (h *BotHandler) clearReminderInputState(userID int64) {
 	h.reminderMu.Lock()
 	delete(h.reminderInput, userID)
 	h.reminderMu.Unlock()
+	h.leaveConversation(userID, convFlowReminderInput)
 }
 
 func (h *BotHandler) setReminderTemplates(msgs []string) {
@@ -240,12 +242,12 @@ func (h *BotHandler) fireConfigReminder(userID, chatID int64, configText string)
 	}
 
 	summary := formatOrderStatusSummary(configText)
-	msg := t(lang, "Konfiguratsiyangiz tayyor! ", "Ваша сборка готова! ") + offer
+	msg := tr(lang, "reminder.config_ready") + offer
 	if summary != "" {
-		label := t(lang, "Oxirgi taklif:", "Последняя подборка:")
+		label := tr(lang, "reminder.last_offer")
 		msg += "\n\n" + label + "\n" + summary
 	}
-	msg += "\n\n" + t(lang, "Buyurtma beramizmi? Tugmadan tanlang 👇", "Запустим оформление? Нажмите кнопку ниже 👇")
+	msg += "\n\n" + tr(lang, "reminder.order_prompt")
 
 	h.setLastSuggestion(userID, configText)
 	h.savePendingApproval(userID, pendingApproval{
@@ -258,8 +260,8 @@ func (h *BotHandler) fireConfigReminder(userID, chatID int64, configText string)
 
 	kb := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "✅ Zakaz beraman", "✅ Оформить"), "order_yes"),
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "❌ Hozir emas", "❌ Не сейчас"), "order_no"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "reminder.order_yes"), "order_yes"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "reminder.order_no"), "order_no"),
 		),
 	)
 	msgObj := tgbotapi.NewMessage(chatID, msg)
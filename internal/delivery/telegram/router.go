@@ -15,6 +15,11 @@ func (h *BotHandler) Start(ctx context.Context) error {
 	go h.cleanupSessions(ctx)
 	go h.cache.cleanup(ctx)
 	go h.ensureAboutUserSheetOnStart(ctx)
+	go h.startPaymentCallbackServer(ctx)
+	go h.startCurrencyRateRefresher(ctx)
+	if h.outbound != nil {
+		go h.outbound.run(ctx, h)
+	}
 
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
@@ -38,6 +43,10 @@ func (h *BotHandler) Start(ctx context.Context) error {
 				go h.handleCallback(ctx, update.CallbackQuery)
 				continue
 			}
+			if update.PreCheckoutQuery != nil {
+				go h.handlePreCheckoutQuery(ctx, update.PreCheckoutQuery)
+				continue
+			}
 			if update.Message == nil {
 				continue
 			}
@@ -63,6 +72,10 @@ func (h *BotHandler) handleMessage(ctx context.Context, message *tgbotapi.Messag
 	if h.group1ChatID != 0 {
 		h.maybeSendLiveFeed(userID, username, message.Chat.ID)
 	}
+	if message.SuccessfulPayment != nil {
+		h.handleSuccessfulPayment(ctx, message)
+		return
+	}
 
 	if message.ViaBot != nil && message.ViaBot.ID == h.bot.Self.ID {
 		if h.handleAddProductInlineSelectionMessage(ctx, message) {
@@ -87,11 +100,31 @@ func (h *BotHandler) handleMessage(ctx context.Context, message *tgbotapi.Messag
 		h.handleDocumentMessage(ctx, message)
 		return
 	}
+	// Rasm faqat uni kutayotgan jarayonga (upgrade surati, kuryer isboti) boradi
+	if len(message.Photo) > 0 && message.Chat != nil && message.Chat.IsPrivate() {
+		if h.conversationAcceptsPhoto(userID) {
+			h.dispatchConversation(ctx, conversationInput{
+				UserID:   userID,
+				Username: username,
+				Text:     message.Caption,
+				ChatID:   message.Chat.ID,
+				Msg:      message,
+			})
+			return
+		}
+	}
+	// /cancel har qanday jarayonda ishlaydi (parol kutish ham)
+	if message.IsCommand() && extractCommand(message) == "cancel" {
+		h.handleCommand(ctx, message)
+		return
+	}
 	if h.isAwaitingPassword(userID) {
 		h.handlePasswordInput(ctx, message)
 		return
 	}
 	if message.IsCommand() || strings.HasPrefix(strings.TrimSpace(message.Text), "/") {
+		// Bitta javob kutayotgan jarayon komandadan keyingi matnni olmasin
+		h.cancelConversationOnCommand(userID)
 		h.handleCommand(ctx, message)
 		return
 	}
@@ -145,16 +178,25 @@ func (h *BotHandler) handleMessage(ctx context.Context, message *tgbotapi.Messag
 
 func (h *BotHandler) touchLastSeen(userID int64, username string) bool {
 	now := time.Now()
+	h.ensureUserLoaded(userID)
 	h.lastSeenMu.Lock()
-	_, existed := h.lastSeen[userID]
+	prev, existed := h.lastSeen[userID]
 	h.lastSeen[userID] = now
 	h.lastSeenMu.Unlock()
 
-	if trim := strings.TrimSpace(username); trim != "" {
+	trim := strings.TrimSpace(username)
+	nameChanged := false
+	if trim != "" {
 		h.nameMu.Lock()
+		nameChanged = h.lastName[userID] != trim
 		h.lastName[userID] = trim
 		h.nameMu.Unlock()
 	}
+	if !existed || nameChanged || now.Sub(prev) >= userTouchPersistInterval {
+		h.persistUser("last_seen", func(ctx context.Context, store UserStore) error {
+			return store.Touch(ctx, userID, trim, now)
+		})
+	}
 	return !existed
 }
 
@@ -0,0 +1,564 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Saqlangan konfiguratsiyalar: har bir mijozning nomlangan yig'malari diskda saqlanadi.
+// Konfiguratsiya ustasi tugagach yig'ma avtomatik saqlanadi, boshqa takliflar "💾" tugmasi
+// bilan. Har yig'maning /start build_<id> havolasi bor - uni ochgan istalgan foydalanuvchi
+// yig'mani ko'radi, buyurtma beradi yoki o'ziga nusxa oladi.
+
+const (
+	savedBuildsFile = "data/saved_builds.json"
+
+	maxSavedBuilds     = 30
+	savedBuildDeepLink = "build_"
+)
+
+type savedBuild struct {
+	ID      string         `json:"id"`
+	Name    string         `json:"name"`
+	OfferID string         `json:"offer_id,omitempty"` // qaysi taklifdan saqlangan (takroriy saqlashga qarshi)
+	Text    string         `json:"text"`               // konfiguratsiya matni: buyurtma va tahlil shu matnni o'qiydi
+	Spec    configSpec     `json:"spec"`
+	Build   entity.PCBuild `json:"build"` // katalog bo'yicha aniqlangan komponentlar
+}
+
+type savedBuildSettings struct {
+	Builds []savedBuild `json:"builds"`
+}
+
+var errTooManyBuilds = errors.New("too many saved builds")
+
+func (h *BotHandler) loadSavedBuildsFromDisk() {
+	b, err := os.ReadFile(savedBuildsFile)
+	if err != nil {
+		return
+	}
+	var cfg savedBuildSettings
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Printf("saved builds parse failed: %v", err)
+		return
+	}
+	h.savedBuildsMu.Lock()
+	h.savedBuilds = cfg.Builds
+	h.savedBuildsMu.Unlock()
+}
+
+func (h *BotHandler) saveSavedBuildsLocked() error {
+	dir := filepath.Dir(savedBuildsFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(savedBuildSettings{Builds: h.savedBuilds}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(savedBuildsFile, b, 0o600)
+}
+
+// userSavedBuilds mijoz yig'malari saqlangan tartibda (ro'yxatdagi raqam shu tartib bo'yicha)
+func (h *BotHandler) userSavedBuilds(userID int64) []savedBuild {
+	h.savedBuildsMu.RLock()
+	defer h.savedBuildsMu.RUnlock()
+	var out []savedBuild
+	for _, b := range h.savedBuilds {
+		if b.Build.UserID == userID {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (h *BotHandler) findSavedBuild(id string) (savedBuild, bool) {
+	h.savedBuildsMu.RLock()
+	defer h.savedBuildsMu.RUnlock()
+	for _, b := range h.savedBuilds {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return savedBuild{}, false
+}
+
+// savedBuildByIndex "/build_delete 2" kabi komandalardagi 1 dan boshlanadigan raqam
+func (h *BotHandler) savedBuildByIndex(userID int64, arg string) (savedBuild, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	builds := h.userSavedBuilds(userID)
+	if err != nil || n < 1 || n > len(builds) {
+		return savedBuild{}, false
+	}
+	return builds[n-1], true
+}
+
+// addSavedBuild taklifni mijoz yig'malariga qo'shadi; shu taklif avval saqlangan bo'lsa o'shani qaytaradi
+func (h *BotHandler) addSavedBuild(ctx context.Context, userID int64, username string, info feedbackInfo) (savedBuild, bool, error) {
+	// Katalog bo'yicha aniqlash sekin bo'lishi mumkin - qulfdan tashqarida
+	build := h.catalogBuild(ctx, userID, info.ConfigText, info.Spec.PCType)
+	if build == nil {
+		build = &entity.PCBuild{Purpose: normalizePurposeLabel(info.Spec.PCType)}
+	}
+	if budget := h.configBudgetUSD(info); budget > 0 {
+		build.Budget = budget
+	}
+	lang := h.getUserLang(userID)
+
+	h.savedBuildsMu.Lock()
+	defer h.savedBuildsMu.Unlock()
+	count := 0
+	for _, b := range h.savedBuilds {
+		if b.Build.UserID != userID {
+			continue
+		}
+		if info.OfferID != "" && b.OfferID == info.OfferID {
+			return b, true, nil
+		}
+		count++
+	}
+	if count >= maxSavedBuilds {
+		return savedBuild{}, false, errTooManyBuilds
+	}
+	id := strings.ReplaceAll(newUUID(), "-", "")[:10]
+	build.ID = id
+	build.UserID = userID
+	build.Username = username
+	build.CreatedAt = time.Now()
+	rec := savedBuild{
+		ID:      id,
+		Name:    nonEmpty(strings.TrimSpace(info.Spec.Name), tr(lang, "builds.default_name", "n", count+1)),
+		OfferID: info.OfferID,
+		Text:    info.ConfigText,
+		Spec:    info.Spec,
+		Build:   *build,
+	}
+	h.savedBuilds = append(h.savedBuilds, rec)
+	if err := h.saveSavedBuildsLocked(); err != nil {
+		h.savedBuilds = h.savedBuilds[:len(h.savedBuilds)-1]
+		return savedBuild{}, false, err
+	}
+	return rec, false, nil
+}
+
+// updateSavedBuild faqat egasining yig'masini o'zgartiradi; fn nil bo'lsa o'chiradi
+func (h *BotHandler) updateSavedBuild(userID int64, id string, fn func(*savedBuild)) (savedBuild, bool, error) {
+	h.savedBuildsMu.Lock()
+	defer h.savedBuildsMu.Unlock()
+	for i, b := range h.savedBuilds {
+		if b.ID != id || b.Build.UserID != userID {
+			continue
+		}
+		prev := append([]savedBuild(nil), h.savedBuilds...)
+		if fn == nil {
+			h.savedBuilds = append(h.savedBuilds[:i], h.savedBuilds[i+1:]...)
+		} else {
+			fn(&h.savedBuilds[i])
+			b = h.savedBuilds[i]
+		}
+		if err := h.saveSavedBuildsLocked(); err != nil {
+			h.savedBuilds = prev
+			return savedBuild{}, false, err
+		}
+		return b, true, nil
+	}
+	return savedBuild{}, false, nil
+}
+
+// buildShareLink t.me havolasi; bot nomi noma'lum bo'lsa komanda ko'rinishida
+func (h *BotHandler) buildShareLink(id string) string {
+	if h.bot != nil && h.bot.Self.UserName != "" {
+		return fmt.Sprintf("https://t.me/%s?start=%s%s", h.bot.Self.UserName, savedBuildDeepLink, id)
+	}
+	return "/start " + savedBuildDeepLink + id
+}
+
+// saveBuildFromOffer "💾 Saqlash" tugmasi va konfiguratsiya ustasi oxiri
+func (h *BotHandler) saveBuildFromOffer(ctx context.Context, userID, chatID int64, info feedbackInfo, username string) {
+	lang := h.getUserLang(userID)
+	rec, existed, err := h.addSavedBuild(ctx, userID, username, info)
+	switch {
+	case errors.Is(err, errTooManyBuilds):
+		h.sendMessage(chatID, tr(lang, "builds.limit", "max", maxSavedBuilds))
+	case err != nil:
+		log.Printf("saved build store failed user=%d: %v", userID, err)
+		h.sendMessage(chatID, tr(lang, "builds.save_failed"))
+	case existed:
+		h.sendMessage(chatID, tr(lang, "builds.already", "name", rec.Name))
+	default:
+		h.sendMessage(chatID, tr(lang, "builds.saved", "name", rec.Name, "link", h.buildShareLink(rec.ID)))
+	}
+}
+
+// handleBuildSaveCallback bld_save|<offerID>
+func (h *BotHandler) handleBuildSaveCallback(ctx context.Context, cq *tgbotapi.CallbackQuery, offerID string) {
+	userID := cq.From.ID
+	chatID := cq.Message.Chat.ID
+	info, ok := h.getFeedbackByID(offerID)
+	if !ok || strings.TrimSpace(info.ConfigText) == "" {
+		h.sendMessage(chatID, tr(h.getUserLang(userID), "builds.not_found"))
+		return
+	}
+	h.saveBuildFromOffer(ctx, userID, chatID, info, nonEmpty(cq.From.UserName, cq.From.FirstName))
+}
+
+// handleBuildsCommand /builds - saqlangan yig'malar ro'yxati
+func (h *BotHandler) handleBuildsCommand(ctx context.Context, message *tgbotapi.Message) {
+	_ = ctx
+	userID := message.From.ID
+	lang := h.getUserLang(userID)
+	builds := h.userSavedBuilds(userID)
+	if len(builds) == 0 {
+		h.sendMessage(message.Chat.ID, tr(lang, "builds.empty"))
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "builds.header"))
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, b := range builds {
+		sb.WriteString("\n" + tr(lang, "builds.item",
+			"n", i+1,
+			"name", b.Name,
+			"total", nonEmpty(extractTotalPrice(b.Text), "—"),
+			"date", b.Build.CreatedAt.Format("02.01.2006"),
+		))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d) %s", i+1, b.Name), "bld_view|"+b.ID),
+		))
+	}
+	sb.WriteString("\n\n" + tr(lang, "builds.usage"))
+	msg := tgbotapi.NewMessage(message.Chat.ID, h.applyCurrencyPreference(sb.String()))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("saved builds list send failed: %v", err)
+	}
+}
+
+// showSavedBuild yig'mani ko'rsatadi; ko'ruvchi uchun yangi taklif ochiladi, shu sabab
+// buyurtma va tahlil tugmalari oddiy konfiguratsiyadagidek ishlaydi
+func (h *BotHandler) showSavedBuild(viewer *tgbotapi.User, chatID int64, b savedBuild) {
+	lang := h.getUserLang(viewer.ID)
+	owner := b.Build.UserID == viewer.ID
+	offerID := h.saveFeedback(viewer.ID, feedbackInfo{
+		Summary:    fmt.Sprintf("Saqlangan konfiguratsiya %s (%s)", b.Name, b.ID),
+		ConfigText: b.Text,
+		Username:   nonEmpty(viewer.UserName, viewer.FirstName),
+		ChatID:     chatID,
+		Spec:       b.Spec,
+	})
+
+	header := tr(lang, "builds.view_header", "name", b.Name)
+	if !owner {
+		header = tr(lang, "builds.shared_header", "name", b.Name, "owner", nonEmpty(b.Build.Username, "—"))
+	}
+	text := header + "\n\n" + h.applyCurrencyPreference(b.Text) + "\n\n" + tr(lang, "builds.share_link", "link", h.buildShareLink(b.ID))
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "builds.order"), "cfg_fb_yes|"+offerID),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "builds.analyze"), "cfg_analyze_pc|"+offerID),
+		),
+	}
+	if owner {
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "builds.rename"), "bld_ren|"+b.ID),
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "builds.delete"), "bld_del|"+b.ID),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(tr(lang, "builds.compare"), "bld_cmp|"+b.ID),
+			),
+		)
+	} else {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "builds.copy"), "bld_copy|"+b.ID),
+		))
+	}
+	if link := h.buildShareLink(b.ID); strings.HasPrefix(link, "https://") {
+		share := "https://t.me/share/url?url=" + url.QueryEscape(link) + "&text=" + url.QueryEscape(b.Name)
+		rows[len(rows)-1] = append(rows[len(rows)-1], tgbotapi.NewInlineKeyboardButtonURL(tr(lang, "builds.share"), share))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("saved build send failed id=%s: %v", b.ID, err)
+	}
+}
+
+// handleBuildDeepLink /start build_<id>
+func (h *BotHandler) handleBuildDeepLink(message *tgbotapi.Message, arg string) bool {
+	if !strings.HasPrefix(arg, savedBuildDeepLink) {
+		return false
+	}
+	b, ok := h.findSavedBuild(strings.TrimPrefix(arg, savedBuildDeepLink))
+	if !ok {
+		h.sendMessage(message.Chat.ID, tr(h.getUserLang(message.From.ID), "builds.not_found"))
+		return true
+	}
+	h.showSavedBuild(message.From, message.Chat.ID, b)
+	return true
+}
+
+// handleBuildRenameCommand /build_rename <raqam> <yangi nom>
+func (h *BotHandler) handleBuildRenameCommand(ctx context.Context, message *tgbotapi.Message) {
+	_ = ctx
+	userID := message.From.ID
+	lang := h.getUserLang(userID)
+	parts := strings.SplitN(strings.TrimSpace(message.CommandArguments()), " ", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		h.sendMessage(message.Chat.ID, tr(lang, "builds.usage_rename"))
+		return
+	}
+	b, ok := h.savedBuildByIndex(userID, parts[0])
+	if !ok {
+		h.sendMessage(message.Chat.ID, tr(lang, "builds.not_found"))
+		return
+	}
+	h.renameSavedBuild(userID, message.Chat.ID, b.ID, parts[1])
+}
+
+func (h *BotHandler) renameSavedBuild(userID, chatID int64, id, name string) {
+	lang := h.getUserLang(userID)
+	name = strings.TrimSpace(name)
+	if r := []rune(name); len(r) > 64 {
+		name = string(r[:64])
+	}
+	b, ok, err := h.updateSavedBuild(userID, id, func(b *savedBuild) { b.Name = name })
+	switch {
+	case err != nil:
+		log.Printf("saved build rename failed id=%s: %v", id, err)
+		h.sendMessage(chatID, tr(lang, "builds.save_failed"))
+	case !ok:
+		h.sendMessage(chatID, tr(lang, "builds.not_found"))
+	default:
+		h.sendMessage(chatID, tr(lang, "builds.renamed", "name", b.Name))
+	}
+}
+
+// handleBuildDeleteCommand /build_delete <raqam>
+func (h *BotHandler) handleBuildDeleteCommand(ctx context.Context, message *tgbotapi.Message) {
+	_ = ctx
+	userID := message.From.ID
+	b, ok := h.savedBuildByIndex(userID, message.CommandArguments())
+	if !ok {
+		h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "builds.usage_delete"))
+		return
+	}
+	h.deleteSavedBuild(userID, message.Chat.ID, b.ID)
+}
+
+func (h *BotHandler) deleteSavedBuild(userID, chatID int64, id string) {
+	lang := h.getUserLang(userID)
+	b, ok, err := h.updateSavedBuild(userID, id, nil)
+	switch {
+	case err != nil:
+		log.Printf("saved build delete failed id=%s: %v", id, err)
+		h.sendMessage(chatID, tr(lang, "builds.save_failed"))
+	case !ok:
+		h.sendMessage(chatID, tr(lang, "builds.not_found"))
+	default:
+		h.sendMessage(chatID, tr(lang, "builds.deleted", "name", b.Name))
+	}
+}
+
+// handleBuildCompareCommand /build_compare <raqam> <raqam>
+func (h *BotHandler) handleBuildCompareCommand(ctx context.Context, message *tgbotapi.Message) {
+	_ = ctx
+	userID := message.From.ID
+	lang := h.getUserLang(userID)
+	args := strings.Fields(message.CommandArguments())
+	if len(args) != 2 {
+		h.sendMessage(message.Chat.ID, tr(lang, "builds.usage_compare"))
+		return
+	}
+	a, okA := h.savedBuildByIndex(userID, args[0])
+	b, okB := h.savedBuildByIndex(userID, args[1])
+	if !okA || !okB || a.ID == b.ID {
+		h.sendMessage(message.Chat.ID, tr(lang, "builds.usage_compare"))
+		return
+	}
+	h.sendMessage(message.Chat.ID, h.compareBuildsText(lang, a, b))
+}
+
+// compareBuildsText ikki yig'ma yonma-yon: komponentlar, narx va benchmark ko'rsatkichlari
+func (h *BotHandler) compareBuildsText(lang string, a, b savedBuild) string {
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "builds.compare_header", "a", a.Name, "b", b.Name))
+	sb.WriteString("\n")
+	part := func(p *entity.Product) string {
+		if p == nil || strings.TrimSpace(p.Name) == "" {
+			return "—"
+		}
+		return p.Name
+	}
+	rows := []struct {
+		label string
+		a, b  string
+	}{
+		{"CPU", part(&a.Build.CPU), part(&b.Build.CPU)},
+		{"Motherboard", part(&a.Build.Motherboard), part(&b.Build.Motherboard)},
+		{"RAM", part(&a.Build.RAM), part(&b.Build.RAM)},
+		{"GPU", part(&a.Build.GPU), part(&b.Build.GPU)},
+		{"SSD", part(&a.Build.SSD), part(&b.Build.SSD)},
+		{"PSU", part(&a.Build.PSU), part(&b.Build.PSU)},
+		{"CPU Cooler", part(a.Build.Cooler), part(b.Build.Cooler)},
+		{"Case", part(a.Build.Case), part(b.Build.Case)},
+	}
+	for _, r := range rows {
+		if r.a == "—" && r.b == "—" {
+			continue
+		}
+		if strings.EqualFold(r.a, r.b) {
+			sb.WriteString(fmt.Sprintf("\n• %s: %s (%s)", r.label, r.a, tr(lang, "builds.same")))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n• %s:\n   A: %s\n   B: %s", r.label, r.a, r.b))
+	}
+
+	sb.WriteString("\n\n" + tr(lang, "builds.compare_total",
+		"a", nonEmpty(extractTotalPrice(a.Text), "—"),
+		"b", nonEmpty(extractTotalPrice(b.Text), "—"),
+	))
+	analyzer := h.newPCAnalyzer()
+	ra := analyzer.Compute(&a.Build, lang)
+	rb := analyzer.Compute(&b.Build, lang)
+	// Ikkalasi ham A ning maqsadi bo'yicha baholanadi
+	purpose := ra.UseCaseMatch.RequestedUseCase
+	scoreFor := func(r *entity.PCAnalytics) string {
+		return fmt.Sprintf("%.1f", r.UseCaseMatch.Matches[purpose].Score)
+	}
+	sb.WriteString("\n" + tr(lang, "builds.compare_score", "purpose", purpose, "a", scoreFor(ra), "b", scoreFor(rb)))
+	sb.WriteString("\n" + tr(lang, "builds.compare_fps", "a", averageFPS1080(ra), "b", averageFPS1080(rb)))
+	sb.WriteString("\n" + tr(lang, "builds.compare_power", "a", ra.PowerConsumption.TotalWattage, "b", rb.PowerConsumption.TotalWattage))
+	sb.WriteString("\n" + tr(lang, "builds.compare_bottleneck", "a", ra.Bottleneck.BottleneckType, "b", rb.Bottleneck.BottleneckType))
+	return h.applyCurrencyPreference(sb.String())
+}
+
+func averageFPS1080(r *entity.PCAnalytics) int {
+	if r == nil || len(r.FPS) == 0 {
+		return 0
+	}
+	total := 0
+	for _, f := range r.FPS {
+		total += f.FPS1080p
+	}
+	return total / len(r.FPS)
+}
+
+// handleSavedBuildCallback bld_<view|ren|del|cmp|cmp2|copy>|<id>[|<id2>]
+func (h *BotHandler) handleSavedBuildCallback(ctx context.Context, cq *tgbotapi.CallbackQuery) {
+	userID := cq.From.ID
+	chatID := cq.Message.Chat.ID
+	lang := h.getUserLang(userID)
+	parts := strings.Split(cq.Data, "|")
+	if len(parts) < 2 {
+		return
+	}
+	if parts[0] == "bld_save" {
+		h.handleBuildSaveCallback(ctx, cq, parts[1])
+		return
+	}
+	b, ok := h.findSavedBuild(parts[1])
+	if !ok {
+		h.sendMessage(chatID, tr(lang, "builds.not_found"))
+		return
+	}
+	switch parts[0] {
+	case "bld_view":
+		h.showSavedBuild(cq.From, chatID, b)
+	case "bld_ren":
+		if b.Build.UserID != userID {
+			return
+		}
+		h.savedBuildsMu.Lock()
+		if h.buildRenames == nil {
+			h.buildRenames = make(map[int64]string)
+		}
+		h.buildRenames[userID] = b.ID
+		h.savedBuildsMu.Unlock()
+		h.enterConversation(userID, convFlowBuildRename, "need_name", chatID)
+		h.sendMessage(chatID, tr(lang, "builds.rename_prompt", "name", b.Name))
+	case "bld_del":
+		h.deleteSavedBuild(userID, chatID, b.ID)
+	case "bld_cmp":
+		var rows [][]tgbotapi.InlineKeyboardButton
+		for i, other := range h.userSavedBuilds(userID) {
+			if other.ID == b.ID {
+				continue
+			}
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d) %s", i+1, other.Name), "bld_cmp2|"+b.ID+"|"+other.ID),
+			))
+		}
+		if len(rows) == 0 {
+			h.sendMessage(chatID, tr(lang, "builds.compare_need_two"))
+			return
+		}
+		msg := tgbotapi.NewMessage(chatID, tr(lang, "builds.compare_pick", "name", b.Name))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+		if _, err := h.sendAndLog(msg); err != nil {
+			log.Printf("saved build compare pick send failed: %v", err)
+		}
+	case "bld_cmp2":
+		if len(parts) != 3 || b.Build.UserID != userID {
+			return
+		}
+		other, ok := h.findSavedBuild(parts[2])
+		if !ok {
+			h.sendMessage(chatID, tr(lang, "builds.not_found"))
+			return
+		}
+		h.sendMessage(chatID, h.compareBuildsText(lang, b, other))
+	case "bld_copy":
+		info := feedbackInfo{ConfigText: b.Text, Spec: b.Spec}
+		info.Spec.Name = b.Name
+		h.saveBuildFromOffer(ctx, userID, chatID, info, nonEmpty(cq.From.UserName, cq.From.FirstName))
+	}
+}
+
+// handleSavedBuildInput "✏️" tugmasidan keyingi yangi nom (convFlowBuildRename)
+func (h *BotHandler) handleSavedBuildInput(in conversationInput) bool {
+	if _, ok := h.conversationStateIn(in.UserID, convFlowBuildRename); !ok {
+		return false
+	}
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		return false
+	}
+	id, ok := h.pendingBuildRename(in.UserID)
+	h.leaveConversation(in.UserID, convFlowBuildRename)
+	h.clearBuildRename(in.UserID)
+	if !ok {
+		return false
+	}
+	h.renameSavedBuild(in.UserID, in.ChatID, id, text)
+	return true
+}
+
+func (h *BotHandler) pendingBuildRename(userID int64) (string, bool) {
+	h.savedBuildsMu.RLock()
+	defer h.savedBuildsMu.RUnlock()
+	id, ok := h.buildRenames[userID]
+	return id, ok
+}
+
+func (h *BotHandler) clearBuildRename(userID int64) {
+	h.savedBuildsMu.Lock()
+	delete(h.buildRenames, userID)
+	h.savedBuildsMu.Unlock()
+}
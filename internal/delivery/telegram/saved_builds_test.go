@@ -0,0 +1,78 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSavedBuilds - takliflardan saqlash (takrorsiz), faqat egasi o'zgartiradi, diskdan qayta yuklash
+func TestSavedBuilds(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	h := &BotHandler{}
+	office := feedbackInfo{
+		OfferID:    "offer-1",
+		ConfigText: "• CPU: Intel Core i3-12100 - 120$\n• RAM: Kingston 16GB DDR4 - 40$\n• SSD: Samsung 980 500GB NVMe - 50$\nOverall price: 210$",
+		Spec:       configSpec{Name: "Ofis", PCType: "Office"},
+	}
+	gaming := feedbackInfo{
+		OfferID:    "offer-2",
+		ConfigText: "• CPU: AMD Ryzen 5 7600 - 200$\n• GPU: MSI GeForce RTX 4060 - 300$\n• RAM: Kingston 32GB DDR5 - 100$\nOverall price: 600$",
+		Spec:       configSpec{PCType: "Gaming"},
+	}
+	a, existed, err := h.addSavedBuild(ctx, 7, "ali", office)
+	if err != nil || existed || a.Name != "Ofis" || a.Build.CPU.Name != "Intel Core i3-12100" {
+		t.Fatalf("saqlash: %+v %v %v", a, existed, err)
+	}
+	if again, existed, _ := h.addSavedBuild(ctx, 7, "ali", office); !existed || again.ID != a.ID {
+		t.Fatalf("takroriy saqlash yangi yozuv ochdi: %+v", again)
+	}
+	b, _, _ := h.addSavedBuild(ctx, 7, "ali", gaming)
+	if b.Name == "" || len(h.userSavedBuilds(7)) != 2 {
+		t.Fatalf("ikkinchi yig'ma: %+v", h.userSavedBuilds(7))
+	}
+
+	if _, ok, _ := h.updateSavedBuild(8, a.ID, nil); ok {
+		t.Fatal("begona foydalanuvchi o'chira olmasligi kerak")
+	}
+	if got, ok, err := h.updateSavedBuild(7, b.ID, func(s *savedBuild) { s.Name = "O'yin" }); !ok || err != nil || got.Name != "O'yin" {
+		t.Fatalf("nom o'zgarmadi: %+v %v", got, err)
+	}
+
+	cmp := h.compareBuildsText("uz", a, b)
+	for _, want := range []string{"Intel Core i3-12100", "AMD Ryzen 5 7600", "210$", "600$"} {
+		if !strings.Contains(cmp, want) {
+			t.Errorf("solishtirishda %q yo'q:\n%s", want, cmp)
+		}
+	}
+
+	restored := &BotHandler{}
+	restored.loadSavedBuildsFromDisk()
+	if got, ok := restored.savedBuildByIndex(7, "2"); !ok || got.Name != "O'yin" {
+		t.Fatalf("diskdan yuklanmadi: %+v", restored.savedBuilds)
+	}
+	if _, ok := restored.findSavedBuild(a.ID); !ok {
+		t.Fatal("havola bo'yicha topilmadi")
+	}
+}
+
+func TestSavedBuildRenameExpires(t *testing.T) {
+	h := &BotHandler{buildRenames: map[int64]string{9: "b1"}}
+	h.enterConversation(9, convFlowBuildRename, "need_name", 90)
+	if id, ok := h.pendingBuildRename(9); !ok || id != "b1" {
+		t.Fatalf("rename kutilmayapti: %q ok=%v", id, ok)
+	}
+
+	h.convMu.Lock()
+	h.convStates[9].UpdatedAt = time.Now().Add(-time.Hour)
+	h.convMu.Unlock()
+	if h.handleSavedBuildInput(conversationInput{UserID: 9, Text: "yangi nom", ChatID: 90}) {
+		t.Fatalf("muddati o'tgan rename keyingi matnni oldi")
+	}
+	h.sweepExpiredConversations(time.Now())
+	if _, ok := h.pendingBuildRename(9); ok {
+		t.Fatalf("timeout rename ni tozalamadi")
+	}
+}
@@ -75,12 +75,64 @@ func (h *BotHandler) sendText(chatID int64, text string, parseMode string, reply
 	return &sent, nil
 }
 
+// sendPhotoID Telegram'dagi rasmni (file_id) caption bilan yuboradi; forum topic qo'llab-quvvatlanadi.
+func (h *BotHandler) sendPhotoID(chatID int64, fileID, caption string, threadOverride int) error {
+	if h.bot == nil {
+		return fmt.Errorf("telegram bot is nil")
+	}
+	threadID := threadOverride
+	if threadID == 0 {
+		threadID = h.threadIDForChat(chatID)
+	}
+	if threadID > 0 {
+		params := make(tgbotapi.Params)
+		params.AddNonZero64("chat_id", chatID)
+		params.AddNonZero("message_thread_id", threadID)
+		params.AddNonEmpty("photo", fileID)
+		params.AddNonEmpty("caption", caption)
+		_, err := h.bot.MakeRequest("sendPhoto", params)
+		return err
+	}
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileID(fileID))
+	photo.Caption = caption
+	_, err := h.sendAndLog(photo)
+	return err
+}
+
+// sendDocumentBytes faylni hujjat sifatida yuboradi; forum topic va reply qo'llab-quvvatlanadi.
+func (h *BotHandler) sendDocumentBytes(chatID int64, file tgbotapi.FileBytes, caption string, threadOverride, replyTo int) error {
+	if h.bot == nil {
+		return fmt.Errorf("telegram bot is nil")
+	}
+	threadID := threadOverride
+	if threadID == 0 {
+		threadID = h.threadIDForChat(chatID)
+	}
+	if threadID > 0 {
+		params := make(tgbotapi.Params)
+		params.AddNonZero64("chat_id", chatID)
+		params.AddNonZero("message_thread_id", threadID)
+		params.AddNonZero("reply_to_message_id", replyTo)
+		params.AddNonEmpty("caption", caption)
+		_, err := h.bot.UploadFiles("sendDocument", params, []tgbotapi.RequestFile{{Name: "document", Data: file}})
+		return err
+	}
+	doc := tgbotapi.NewDocument(chatID, file)
+	doc.Caption = caption
+	doc.ReplyToMessageID = replyTo
+	_, err := h.sendAndLog(doc)
+	return err
+}
+
 func (h *BotHandler) sendAndLog(msg tgbotapi.Chattable) (tgbotapi.Message, error) {
 	if h.bot == nil {
 		return tgbotapi.Message{}, fmt.Errorf("telegram bot is nil")
 	}
 	sent, err := h.bot.Send(msg)
 	if err != nil {
+		if isBotBlockedError(err) {
+			h.markUserBlocked(chattableChatID(msg))
+		}
 		return sent, err
 	}
 	h.logOutgoingFromChattable(msg, sent)
@@ -27,6 +27,8 @@ type sheetMasterCellEdit struct {
 type inventoryAdjustment struct {
 	Name  string
 	Delta int
+	// Branch filial qoldiq ustuni ("Stock: Chilonzor"); bo'sh bo'lsa umumiy ustun
+	Branch string
 }
 
 var (
@@ -34,7 +36,7 @@ var (
 	inventoryQtyHeaders  = []string{"количество", "qty", "quantity", "soni", "qolgan", "остаток", "stock", "count"}
 )
 
-func (h *BotHandler) syncInventoryAfterOrder(summary string, skip bool) {
+func (h *BotHandler) syncInventoryAfterOrder(summary, branch string, skip bool) {
 	if skip {
 		return
 	}
@@ -59,7 +61,7 @@ func (h *BotHandler) syncInventoryAfterOrder(summary string, skip bool) {
 			if selectedID != 0 {
 				cfg.FileID = strconv.FormatUint(uint64(selectedID), 10)
 			}
-			if updated, edits, err := sheetMasterDecrementStockViaAPI(ctx, cfg, items); err == nil {
+			if updated, edits, err := sheetMasterDecrementStockViaAPI(ctx, cfg, items, branch); err == nil {
 				if updated > 0 {
 					if len(edits) > 0 {
 						if fileID, parseErr := strconv.ParseUint(cfg.FileID, 10, 64); parseErr == nil && fileID > 0 {
@@ -81,7 +83,7 @@ func (h *BotHandler) syncInventoryAfterOrder(summary string, skip bool) {
 			}
 		}
 
-		updated, fileID, edits, err := sheetMasterDecrementStockInDB(ctx, selectedID, items)
+		updated, fileID, edits, err := sheetMasterDecrementStockInDB(ctx, selectedID, items, branch)
 		if err != nil {
 			log.Printf("[inventory] db update failed: %v", err)
 			return
@@ -157,8 +159,8 @@ func (h *BotHandler) adjustInventoryByName(ctx context.Context, item string, del
 	return updated, nil
 }
 
-func (h *BotHandler) adjustInventoryItems(ctx context.Context, items []string, delta int) (int, []string, error) {
-	adjustments := buildInventoryAdjustments(items, delta)
+func (h *BotHandler) adjustInventoryItems(ctx context.Context, items []string, delta int, branch string) (int, []string, error) {
+	adjustments := buildInventoryAdjustments(items, delta, branch)
 	if len(adjustments) == 0 {
 		return 0, nil, nil
 	}
@@ -369,7 +371,7 @@ func isConfigPlaceholderName(name string) bool {
 	return false
 }
 
-func buildInventoryAdjustments(items []string, delta int) []inventoryAdjustment {
+func buildInventoryAdjustments(items []string, delta int, branch string) []inventoryAdjustment {
 	if delta == 0 || len(items) == 0 {
 		return nil
 	}
@@ -379,13 +381,13 @@ func buildInventoryAdjustments(items []string, delta int) []inventoryAdjustment
 		if item == "" {
 			continue
 		}
-		adjustments = append(adjustments, inventoryAdjustment{Name: item, Delta: delta})
+		adjustments = append(adjustments, inventoryAdjustment{Name: item, Delta: delta, Branch: branch})
 	}
 	return adjustments
 }
 
-func sheetMasterDecrementStockInDB(ctx context.Context, fileID uint, items []string) (int, uint, []sheetMasterCellEdit, error) {
-	adjustments := buildInventoryAdjustments(items, -1)
+func sheetMasterDecrementStockInDB(ctx context.Context, fileID uint, items []string, branch string) (int, uint, []sheetMasterCellEdit, error) {
+	adjustments := buildInventoryAdjustments(items, -1, branch)
 	updated, updatedFileID, edits, _, err := sheetMasterAdjustStockInDB(ctx, fileID, adjustments)
 	return updated, updatedFileID, edits, err
 }
@@ -480,8 +482,8 @@ WHERE id = $2`, nextState, file.ID); err != nil {
 	return len(edits), file.ID, edits, updatedNames, nil
 }
 
-func sheetMasterDecrementStockViaAPI(ctx context.Context, cfg sheetMasterConfig, items []string) (int, []sheetMasterCellEdit, error) {
-	adjustments := buildInventoryAdjustments(items, -1)
+func sheetMasterDecrementStockViaAPI(ctx context.Context, cfg sheetMasterConfig, items []string, branch string) (int, []sheetMasterCellEdit, error) {
+	adjustments := buildInventoryAdjustments(items, -1, branch)
 	updated, edits, _, err := sheetMasterAdjustStockViaAPI(ctx, cfg, adjustments)
 	return updated, edits, err
 }
@@ -500,13 +502,18 @@ func sheetMasterAdjustStockViaAPI(ctx context.Context, cfg sheetMasterConfig, ad
 	if schema.UsedRange == nil || strings.TrimSpace(schema.UsedRange.A1) == "" {
 		return 0, nil, nil, fmt.Errorf("used range not available")
 	}
-	startRow, startCol, endRow, _, ok := sheetMasterParseRangeA1(schema.UsedRange.A1)
+	startRow, startCol, endRow, endCol, ok := sheetMasterParseRangeA1(schema.UsedRange.A1)
 	if !ok {
 		return 0, nil, nil, fmt.Errorf("invalid used range: %s", schema.UsedRange.A1)
 	}
-	rangeA1 := fmt.Sprintf("A1:C%d", endRow+1)
+	// Filial ustunlari C dan keyin bo'lishi mumkin
+	lastCol := "C"
+	if endCol > 2 {
+		lastCol = sheetMasterColToLabel(endCol)
+	}
+	rangeA1 := fmt.Sprintf("A1:%s%d", lastCol, endRow+1)
 	if startRow > 0 || startCol > 0 {
-		rangeA1 = fmt.Sprintf("A%d:C%d", startRow+1, endRow+1)
+		rangeA1 = fmt.Sprintf("A%d:%s%d", startRow+1, lastCol, endRow+1)
 	}
 
 	cells, err := sheetMasterGetCells(ctx, cfg, rangeA1)
@@ -576,7 +583,7 @@ func sheetMasterPrepareInventoryState(stateJSON []byte) (map[string]any, map[str
 }
 
 func sheetMasterBuildInventoryEdits(items []string, grid map[int]map[int]string, headerRow, nameCol, qtyCol int) []sheetMasterCellEdit {
-	adjustments := buildInventoryAdjustments(items, -1)
+	adjustments := buildInventoryAdjustments(items, -1, "")
 	return sheetMasterBuildInventoryAdjustEdits(adjustments, grid, headerRow, nameCol, qtyCol)
 }
 
@@ -615,32 +622,55 @@ func sheetMasterBuildInventoryAdjustEditsWithNames(adjustments []inventoryAdjust
 		if row < 0 {
 			continue
 		}
-		qtyRaw := strings.TrimSpace(grid[row][qtyCol])
-		qty, ok := parseInventoryQuantity(qtyRaw)
-		if !ok {
-			continue
-		}
-		newQty := qty + adj.Delta
-		if adj.Delta < 0 && qty <= 0 {
-			continue
-		}
-		if newQty < 0 {
-			newQty = 0
+		// Filial tanlangan bo'lsa - uning ustuni, umumiy "Stock" ustuni bo'lsa u ham
+		cols := []int{qtyCol}
+		if adj.Branch != "" {
+			if branchCol := sheetMasterFindBranchQtyCol(grid, headerRow, adj.Branch); branchCol >= 0 && branchCol != qtyCol {
+				cols = []int{branchCol}
+				if branch, ok := inventoryStockBranch(grid[headerRow][qtyCol]); ok && branch == "" {
+					cols = append(cols, qtyCol)
+				}
+			}
 		}
-		if newQty == qty {
+		changed := false
+		for i, col := range cols {
+			qtyRaw := strings.TrimSpace(grid[row][col])
+			qty, ok := parseInventoryQuantity(qtyRaw)
+			if !ok {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+			newQty := qty + adj.Delta
+			if adj.Delta < 0 && qty <= 0 {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+			if newQty < 0 {
+				newQty = 0
+			}
+			if newQty == qty {
+				continue
+			}
+			gridRow := grid[row]
+			if gridRow == nil {
+				gridRow = map[int]string{}
+				grid[row] = gridRow
+			}
+			gridRow[col] = strconv.Itoa(newQty)
+			edits = append(edits, sheetMasterCellEdit{
+				Row:   row,
+				Col:   col,
+				Value: strconv.Itoa(newQty),
+			})
+			changed = true
+		}
+		if !changed {
 			continue
 		}
-		gridRow := grid[row]
-		if gridRow == nil {
-			gridRow = map[int]string{}
-			grid[row] = gridRow
-		}
-		gridRow[qtyCol] = strconv.Itoa(newQty)
-		edits = append(edits, sheetMasterCellEdit{
-			Row:   row,
-			Col:   qtyCol,
-			Value: strconv.Itoa(newQty),
-		})
 		if raw, ok := rowRaw[row]; ok {
 			name := strings.TrimSpace(raw)
 			if name != "" {
@@ -771,15 +801,26 @@ func sheetMasterFindInventoryHeader(grid map[int]map[int]string) (headerRow, nam
 		}
 		nameCol = -1
 		qtyCol = -1
-		for col, raw := range cols {
+		branchQtyCol := -1
+		for _, col := range sortedColKeys(cols) {
+			raw := cols[col]
 			norm := normalizeHeaderValue(raw)
 			if nameCol == -1 && containsAny(norm, inventoryNameHeaders) {
 				nameCol = col
 			}
+			if branch, ok := inventoryStockBranch(raw); ok && branch != "" {
+				if branchQtyCol == -1 {
+					branchQtyCol = col
+				}
+				continue
+			}
 			if qtyCol == -1 && containsAny(norm, inventoryQtyHeaders) {
 				qtyCol = col
 			}
 		}
+		if qtyCol == -1 {
+			qtyCol = branchQtyCol
+		}
 		if nameCol >= 0 && qtyCol >= 0 {
 			return row, nameCol, qtyCol, true
 		}
@@ -787,6 +828,37 @@ func sheetMasterFindInventoryHeader(grid map[int]map[int]string) (headerRow, nam
 	return 0, 0, 1, false
 }
 
+// inventoryStockBranch "Stock: Chilonzor" -> ("chilonzor", true), "Soni" -> ("", true)
+func inventoryStockBranch(raw string) (string, bool) {
+	lower := strings.ToLower(strings.TrimSpace(raw))
+	for _, kw := range inventoryQtyHeaders {
+		idx := strings.Index(lower, kw)
+		if idx < 0 {
+			continue
+		}
+		rest := lower[idx+len(kw):]
+		if cut := strings.IndexAny(rest, ":(-–—/|"); cut >= 0 {
+			return strings.Join(strings.Fields(strings.Trim(rest[cut:], " :-–—()[]/|")), " "), true
+		}
+		return "", true
+	}
+	return "", false
+}
+
+// sheetMasterFindBranchQtyCol sarlavhadan filial qoldiq ustunini topadi (-1 - yo'q)
+func sheetMasterFindBranchQtyCol(grid map[int]map[int]string, headerRow int, branch string) int {
+	branch = strings.ToLower(strings.Join(strings.Fields(branch), " "))
+	if branch == "" {
+		return -1
+	}
+	for col, raw := range grid[headerRow] {
+		if name, ok := inventoryStockBranch(raw); ok && name == branch {
+			return col
+		}
+	}
+	return -1
+}
+
 func normalizeHeaderValue(s string) string {
 	s = strings.ToLower(strings.TrimSpace(s))
 	s = strings.NewReplacer("_", " ", "-", " ", "—", " ", "–", " ", ".", " ").Replace(s)
@@ -826,6 +898,15 @@ func sortedKeys(m map[int]map[int]string) []int {
 	return keys
 }
 
+func sortedColKeys(m map[int]string) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
 func findInventoryRow(itemNorm string, rowNames map[int]string) int {
 	if itemNorm == "" {
 		return -1
@@ -131,6 +131,7 @@ func (h *BotHandler) resolveSheetMasterConfig() (sheetMasterConfig, error) {
 }
 
 func (h *BotHandler) beginSheetMasterSetup(userID, chatID int64, pendingAction string) {
+	h.enterConversation(userID, convFlowSheetMasterSetup, "need_base_url", chatID)
 	h.sheetMasterSetupMu.Lock()
 	h.sheetMasterSetup[userID] = &sheetMasterSetupState{
 		stage:         sheetMasterStageNeedBaseURL,
@@ -162,6 +163,7 @@ func (h *BotHandler) clearSheetMasterSetupState(userID int64) {
 	h.sheetMasterSetupMu.Lock()
 	delete(h.sheetMasterSetup, userID)
 	h.sheetMasterSetupMu.Unlock()
+	h.leaveConversation(userID, convFlowSheetMasterSetup)
 }
 
 func (h *BotHandler) handleDBSetCommand(ctx context.Context, message *tgbotapi.Message) {
@@ -66,9 +66,7 @@ func (h *BotHandler) handleSmartTextMessage(ctx context.Context, userID int64, u
 	switch intent {
 	case IntentPCBuildRequest:
 		// PC YIG'ISH SO'ROVI - /configuratsiya ga yo'naltirish
-		h.sendMessage(chatID, t(lang,
-			"To'liq PC konfiguratsiyasi uchun /configuratsiya komandasini bosing 😊\n\nU yerda sizga professional maslahat berib, budjet va maqsadingizga mos PC tuzib beraman!",
-			"Для полной конфигурации ПК нажмите /configuratsiya 😊\n\nТам я дам вам профессиональную консультацию и соберу ПК по вашему бюджету и целям!"))
+		h.sendMessage(chatID, tr(lang, "chat.config_hint"))
 		return
 
 	case IntentProductSearch:
@@ -140,9 +138,9 @@ func (h *BotHandler) handleNormalAIChat(ctx context.Context, userID int64, usern
 			h.bot.Request(del)
 		}
 		warn := h.incProcessingWarn(userID)
-		newText := t(lang, "⏳ Javob tayyorlayapman. Iltimos, bir oz kuting.", "⏳ Готовлю ответ. Пожалуйста, подождите.")
+		newText := tr(lang, "chat.preparing")
 		if warn >= 2 {
-			newText = t(lang, "⏳ Hali javob tayyor emas. Iltimos, kuting — tez orada yuboraman.", "⏳ Ответ ещё не готов. Пожалуйста, подождите — скоро отправлю.")
+			newText = tr(lang, "chat.still_preparing")
 		}
 		if waitMsg, ok := h.getWaitingMessage(userID); ok {
 			edit := tgbotapi.NewEditMessageText(waitMsg.ChatID, waitMsg.MessageID, newText)
@@ -166,11 +164,11 @@ func (h *BotHandler) handleNormalAIChat(ctx context.Context, userID int64, usern
 
 	// Submit to worker pool for parallel processing
 	if !h.startProcessing(userID) {
-		h.sendMessage(chatID, t(lang, "⏳ Oldingi so'rov yakunlanmoqda, iltimos kuting.", "⏳ Предыдущий запрос обрабатывается, подождите."))
+		h.sendMessage(chatID, tr(lang, "common.busy"))
 		return
 	}
 
-	waitMsg, err := h.sendMessageWithResp(chatID, t(lang, "⏳ Iltimos, javobni kuting.", "⏳ Пожалуйста, подождите."))
+	waitMsg, err := h.sendMessageWithResp(chatID, tr(lang, "common.wait_answer"))
 	if err == nil {
 		h.setWaitingMessage(userID, chatID, waitMsg.MessageID)
 	}
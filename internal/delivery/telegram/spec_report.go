@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// Xususiyatlar hisoboti: importda nom/ustunlardan aniqlanmagan majburiy xususiyatlar (admin uchun)
+
+const specReportLimit = 35
+
+// specKeyLabels - hisobotda kanonik kalitlarning admin uchun nomi
+var specKeyLabels = map[string]string{
+	entity.SpecSocket:      "soket",
+	entity.SpecChipset:     "chipset",
+	entity.SpecMemoryType:  "DDR turi",
+	entity.SpecCores:       "yadrolar",
+	entity.SpecGPUChip:     "GPU chip",
+	entity.SpecVRAM:        "VRAM",
+	entity.SpecCapacity:    "hajm",
+	entity.SpecSpeed:       "chastota",
+	entity.SpecInterface:   "interfeys",
+	entity.SpecWattage:     "quvvat",
+	entity.SpecEfficiency:  "80+ sertifikat",
+	entity.SpecScreenSize:  "diagonal",
+	entity.SpecRefreshRate: "Hz",
+	entity.SpecPanel:       "matritsa",
+}
+
+func specLabels(keys []string) string {
+	labels := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if l, ok := specKeyLabels[k]; ok {
+			labels = append(labels, l)
+		} else {
+			labels = append(labels, k)
+		}
+	}
+	return strings.Join(labels, ", ")
+}
+
+// formatSpecReport hisobot matni; kind bo'sh bo'lmasa faqat shu tur
+func formatSpecReport(report usecase.SpecReport, kind string) string {
+	var sb strings.Builder
+	sb.WriteString("🧾 Xususiyatlar hisoboti\n")
+	sb.WriteString(fmt.Sprintf("Tekshirildi: %d, to'liq: %d, kamchilik: %d\n", report.Checked, report.Complete, len(report.Gaps)))
+	if len(report.Gaps) == 0 {
+		sb.WriteString("\n✅ Barcha komponentlarning asosiy xususiyatlari aniqlangan.")
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	shown, skipped := 0, 0
+	lastKind := ""
+	for _, gap := range report.Gaps {
+		if kind != "" && gap.Kind != kind {
+			continue
+		}
+		if shown >= specReportLimit {
+			skipped++
+			continue
+		}
+		if gap.Kind != lastKind {
+			sb.WriteString(fmt.Sprintf("\n📂 %s:\n", strings.ToUpper(gap.Kind)))
+			lastKind = gap.Kind
+		}
+		sb.WriteString(fmt.Sprintf("• %s — %s\n", gap.Name, specLabels(gap.Missing)))
+		shown++
+	}
+	if skipped > 0 {
+		sb.WriteString(fmt.Sprintf("\n... yana %d ta (/specs <tur> bilan filtrlang: cpu, gpu, ram, storage, psu, monitor, motherboard)\n", skipped))
+	}
+	sb.WriteString("\n💡 Excel'ga ustun qo'shing (Socket, VRAM, Capacity, Wattage...) yoki qiymatni mahsulot nomiga yozing va katalogni qayta yuklang.")
+	return sb.String()
+}
+
+// handleSpecsCommand /specs [tur] - xususiyatlari aniqlanmagan mahsulotlar
+func (h *BotHandler) handleSpecsCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+	products, err := h.productUseCase.GetAll(ctx)
+	if err != nil || len(products) == 0 {
+		h.sendMessage(message.Chat.ID, "❌ Katalog topilmadi. Excel fayl yuklang.")
+		return
+	}
+	kind := strings.ToLower(strings.TrimSpace(message.CommandArguments()))
+	h.sendMessage(message.Chat.ID, formatSpecReport(usecase.CatalogSpecReport(products), kind))
+}
+
+// specGapsNote katalog yuklangandan keyingi xabarga qo'shimcha (kamchilik bo'lmasa bo'sh)
+func (h *BotHandler) specGapsNote(ctx context.Context) string {
+	products, err := h.productUseCase.GetAll(ctx)
+	if err != nil {
+		return ""
+	}
+	report := usecase.CatalogSpecReport(products)
+	if len(report.Gaps) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\n⚠️ %d ta mahsulotning asosiy xususiyatlari aniqlanmadi — /specs", len(report.Gaps))
+}
@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/specsheet"
+)
+
+const specSheetBrand = "InGame"
+
+// buildSpecSheet konfiguratsiya va tahlildan spec varaq ma'lumotlarini tayyorlaydi.
+// total bo'sh bo'lsa komponentlar narxidan hisoblanadi (buyurtmada chegirma/kurs bilan tayyor jami beriladi).
+func buildSpecSheet(build *entity.PCBuild, analytics *entity.PCAnalytics, total, lang string, now time.Time) specsheet.Sheet {
+	sheet := specsheet.Sheet{
+		Brand:      specSheetBrand,
+		Title:      tr(lang, "sheet.title"),
+		Subtitle:   build.Purpose,
+		Date:       now.Format("02.01.2006"),
+		TotalLabel: tr(lang, "sheet.total"),
+		Total:      total,
+		Footer:     tr(lang, "sheet.footer", "date", now.Format("02.01.2006 15:04")),
+	}
+	for _, part := range []struct {
+		label string
+		p     *entity.Product
+	}{
+		{"CPU", &build.CPU}, {"GPU", &build.GPU}, {"Motherboard", &build.Motherboard}, {"RAM", &build.RAM},
+		{"SSD", &build.SSD}, {"HDD", build.HDD}, {"PSU", &build.PSU}, {"Case", build.Case},
+		{"Cooler", build.Cooler}, {"Monitor", build.Monitor},
+	} {
+		if part.p == nil || part.p.Name == "" {
+			continue
+		}
+		row := specsheet.Row{Label: part.label, Value: part.p.Name}
+		if !part.p.Price.IsZero() {
+			row.Price = part.p.Price.String()
+		}
+		sheet.Components = append(sheet.Components, row)
+	}
+	if sheet.Total == "" {
+		if price := resolveBuildPrice(build); price > 0 {
+			sheet.Total = entity.NewMoney(price, entity.CurrencyUSD).String()
+		}
+	}
+	if analytics == nil {
+		return sheet
+	}
+
+	bottleneck := tr(lang, "sheet.balanced")
+	if analytics.Bottleneck.HasBottleneck {
+		bottleneck = fmt.Sprintf("%s %.0f%%", analytics.Bottleneck.BottleneckType, analytics.Bottleneck.Percentage)
+	}
+	sheet.Stats = []specsheet.Row{
+		{Label: tr(lang, "sheet.score"), Value: fmt.Sprintf("%.1f/10", analytics.OverallScore)},
+		{Label: tr(lang, "sheet.bottleneck"), Value: bottleneck},
+		{Label: tr(lang, "sheet.cpu_temp"), Value: fmt.Sprintf("%d°C", analytics.CPUTemp.Load)},
+	}
+	if analytics.GPUTemp.Load > 0 {
+		sheet.Stats = append(sheet.Stats, specsheet.Row{Label: tr(lang, "sheet.gpu_temp"), Value: fmt.Sprintf("%d°C", analytics.GPUTemp.Load)})
+	}
+	if s := analytics.StorageSpeed; s.Type != "" {
+		sheet.Stats = append(sheet.Stats, specsheet.Row{Label: tr(lang, "sheet.storage"), Value: fmt.Sprintf("%s, %d MB/s", s.Type, s.ReadSpeed)})
+	}
+	if analytics.BootTime.BootTime > 0 {
+		sheet.Stats = append(sheet.Stats, specsheet.Row{Label: tr(lang, "sheet.boot"), Value: tr(lang, "sheet.seconds", "n", analytics.BootTime.BootTime)})
+	}
+
+	if shouldShowFPS(resolveRequestedUseCase(build, analytics), analytics) {
+		sheet.ChartTitle = tr(lang, "sheet.fps")
+		sheet.Legend = [2]string{"1080p", "1440p"}
+		for _, name := range fpsGameOrder(analytics.FPS) {
+			fps := analytics.FPS[name]
+			sheet.Bars = append(sheet.Bars, specsheet.Bar{Label: name, Primary: fps.FPS1080p, Secondary: fps.FPS1440p})
+		}
+	}
+	if p := analytics.PowerConsumption; p.PSUWattage > 0 {
+		sheet.Power = &specsheet.Power{
+			Title:    tr(lang, "sheet.power"),
+			LoadW:    p.TotalWattage,
+			PSUW:     p.PSUWattage,
+			Caption:  tr(lang, "sheet.power_caption", "load", p.TotalWattage, "psu", p.PSUWattage),
+			Headroom: tr(lang, "sheet.headroom"),
+		}
+	}
+	return sheet
+}
+
+// sendSpecSheet PDF spec varaqni hujjat qilib yuboradi
+func (h *BotHandler) sendSpecSheet(chatID int64, threadID, replyTo int, filename string, build *entity.PCBuild, analytics *entity.PCAnalytics, total, lang string) {
+	if build == nil {
+		return
+	}
+	data, err := specsheet.RenderPDF(buildSpecSheet(build, analytics, total, lang, time.Now()))
+	if err != nil {
+		log.Printf("spec sheet render failed: %v", err)
+		return
+	}
+	file := tgbotapi.FileBytes{Name: filename, Bytes: data}
+	if err := h.sendDocumentBytes(chatID, file, tr(lang, "sheet.caption"), threadID, replyTo); err != nil {
+		log.Printf("spec sheet send failed chat=%d: %v", chatID, err)
+	}
+}
+
+// sendOrderSpecSheet konfiguratsiya buyurtmasi uchun spec varaqni admin xabariga javob qilib biriktiradi.
+// Tahlil benchmark bazasidan hisoblanadi (AI chaqirilmaydi).
+func (h *BotHandler) sendOrderSpecSheet(userID, chatID int64, threadID, replyTo int, orderID, configText, total string) {
+	build := h.catalogBuild(context.Background(), userID, configText, "")
+	if build == nil || build.CPU.Name == "" {
+		return
+	}
+	analytics := h.newPCAnalyzer().Compute(build, "uz")
+	h.sendSpecSheet(chatID, threadID, replyTo, "spec_"+orderID+".pdf", build, analytics, total, "uz")
+}
+
+// handleSpecSheetCallback "PDF hisobot" tugmasi: oxirgi konfiguratsiya uchun spec varaqni qayta yuboradi
+func (h *BotHandler) handleSpecSheetCallback(ctx context.Context, userID, chatID int64) {
+	lang := h.getUserLang(userID)
+	var build *entity.PCBuild
+	if info, ok := h.getLatestFeedback(userID); ok {
+		build = h.catalogBuild(ctx, userID, info.ConfigText, info.Spec.PCType)
+	}
+	if build == nil || build.CPU.Name == "" {
+		h.sendMessage(chatID, tr(lang, "sheet.not_found"))
+		return
+	}
+	analytics := h.newPCAnalyzer().Compute(build, lang)
+	h.sendSpecSheet(chatID, 0, 0, "spec.pdf", build, analytics, "", lang)
+}
@@ -53,10 +53,7 @@ func (h *BotHandler) handleStatsCommand(ctx context.Context, message *tgbotapi.M
 
 	dayStart, dayEnd, dayLabel, err := parseStatsDayRange(message.CommandArguments(), time.Now())
 	if err != nil {
-		h.sendMessage(message.Chat.ID, t(lang,
-			"❌ Sana formati noto'g'ri. Misol: /stats 2026-01-01 yoki /stats bugun yoki /stats kecha",
-			"❌ Неверный формат даты. Пример: /stats 2026-01-01 или /stats today или /stats yesterday",
-		))
+		h.sendMessage(message.Chat.ID, tr(lang, "stats.bad_date"))
 		return
 	}
 
@@ -95,30 +92,30 @@ func (h *BotHandler) handleStatsCommand(ctx context.Context, message *tgbotapi.M
 	group3Like := readyPickup + onway
 
 	var sb strings.Builder
-	sb.WriteString(t(lang, "📊 *Buyurtma statistikasi*\n", "📊 *Статистика заказов*\n"))
+	sb.WriteString(tr(lang, "stats.header"))
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
 
 	if h.group1ChatID != 0 {
-		sb.WriteString(fmt.Sprintf("🖥️ %s: *%d*\n", t(lang, "PC konfiguratsiya (admin javobini kutyapti)", "Конфигурации ПК (ждут ответа админа)"), h.countGroup1PendingApprovals()))
+		sb.WriteString(fmt.Sprintf("🖥️ %s: *%d*\n", tr(lang, "stats.pending_configs"), h.countGroup1PendingApprovals()))
 	}
 
-	sb.WriteString(fmt.Sprintf("🟡 %s: *%d*\n", t(lang, "Active orders (processing/ready)", "Активные заказы (processing/ready)"), activeOrders))
-	sb.WriteString(fmt.Sprintf("✅ %s: *%d*\n", t(lang, "Group 3 (tasdiqlangan)", "Group 3 (подтверждённые)"), group3Like))
-	sb.WriteString(fmt.Sprintf("🏁 %s: *%d*\n", t(lang, "Yakunlangan (delivered)", "Завершённые (delivered)"), delivered))
-	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", t(lang, "Bekor qilingan", "Отменённые"), canceled))
+	sb.WriteString(fmt.Sprintf("🟡 %s: *%d*\n", tr(lang, "stats.active"), activeOrders))
+	sb.WriteString(fmt.Sprintf("✅ %s: *%d*\n", tr(lang, "stats.group3"), group3Like))
+	sb.WriteString(fmt.Sprintf("🏁 %s: *%d*\n", tr(lang, "report.delivered"), delivered))
+	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", tr(lang, "report.canceled"), canceled))
 
 	sb.WriteString("\n")
 	sb.WriteString(fmt.Sprintf("📅 %s: *%s* (%s)\n",
-		t(lang, "Sana", "Дата"),
+		tr(lang, "report.date"),
 		dayLabel,
 		time.Local.String(),
 	))
-	sb.WriteString(fmt.Sprintf("🧩 %s: *%d*\n", t(lang, "Sotilgan komponentlar", "Продано компонентов"), dayComponents))
-	sb.WriteString(fmt.Sprintf("🛒 %s: *%d*\n", t(lang, "Buyurtmalar (shu kunda)", "Заказов (за день)"), dayOrders))
-	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", t(lang, "Bekor qilingan (shu kunda)", "Отменено (за день)"), dayCanceled))
+	sb.WriteString(fmt.Sprintf("🧩 %s: *%d*\n", tr(lang, "report.components_sold"), dayComponents))
+	sb.WriteString(fmt.Sprintf("🛒 %s: *%d*\n", tr(lang, "stats.day_orders"), dayOrders))
+	sb.WriteString(fmt.Sprintf("❌ %s: *%d*\n", tr(lang, "stats.day_canceled"), dayCanceled))
 
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("%s: *%d*\n", t(lang, "Jami buyurtmalar", "Всего заказов"), len(orders)))
+	sb.WriteString(fmt.Sprintf("%s: *%d*\n", tr(lang, "stats.total_orders"), len(orders)))
 
 	h.sendMessageMarkdown(message.Chat.ID, sb.String())
 }
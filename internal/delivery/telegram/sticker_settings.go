@@ -168,21 +168,16 @@ func (h *BotHandler) sendStickerIfConfigured(chatID int64, slot stickerSlot) {
 }
 
 func (h *BotHandler) setStickerAwait(adminID int64, slot stickerSlot) {
-	h.stickerMu.Lock()
 	if slot == "" {
-		delete(h.stickerAwait, adminID)
-		h.stickerMu.Unlock()
+		h.leaveConversation(adminID, convFlowSticker)
 		return
 	}
-	h.stickerAwait[adminID] = slot
-	h.stickerMu.Unlock()
+	h.enterConversation(adminID, convFlowSticker, string(slot), 0)
 }
 
 func (h *BotHandler) getStickerAwait(adminID int64) (stickerSlot, bool) {
-	h.stickerMu.RLock()
-	slot, ok := h.stickerAwait[adminID]
-	h.stickerMu.RUnlock()
-	return slot, ok
+	state, ok := h.conversationStateIn(adminID, convFlowSticker)
+	return stickerSlot(state), ok
 }
 
 func (h *BotHandler) clearStickerAwait(adminID int64) {
@@ -192,9 +187,9 @@ func (h *BotHandler) clearStickerAwait(adminID int64) {
 func stickerSlotLabel(lang string, slot stickerSlot) string {
 	switch slot {
 	case stickerSlotLogin:
-		return t(lang, "login (kirish)", "вход")
+		return tr(lang, "sticker.event.login")
 	case stickerSlotOrderPlaced:
-		return t(lang, "buyurtma rasmiylashtirilganda", "оформление заказа")
+		return tr(lang, "sticker.event.order")
 	default:
 		return string(slot)
 	}
@@ -219,20 +214,20 @@ func (h *BotHandler) buildStickerMenu(lang string) (string, tgbotapi.InlineKeybo
 
 	text := fmt.Sprintf(
 		"%s\n\n🔔 %s: %s\n👋 %s: %s\n🧾 %s: %s\n\n%s",
-		t(lang, "🧩 Sticker sozlamalari", "🧩 Настройки стикеров"),
-		t(lang, "Holat", "Статус"),
+		tr(lang, "sticker.header"),
+		tr(lang, "sticker.status"),
 		stickerEnabledMark(cfg.isEnabled()),
-		t(lang, "Login", "Вход"),
+		tr(lang, "sticker.login"),
 		stickerStatusMark(cfg.Login),
-		t(lang, "Buyurtma", "Заказ"),
+		tr(lang, "sticker.order"),
 		stickerStatusMark(cfg.OrderPlaced),
-		t(lang, "Nimani sozlamoqchisiz?", "Что настроить?"),
+		tr(lang, "sticker.ask"),
 	)
 
-	toggleText := t(lang, "🔴 O‘chirish", "🔴 Выключить")
+	toggleText := tr(lang, "sticker.turn_off")
 	toggleData := "sticker_enabled|0"
 	if !cfg.isEnabled() {
-		toggleText = t(lang, "🟢 Yoqish", "🟢 Включить")
+		toggleText = tr(lang, "sticker.turn_on")
 		toggleData = "sticker_enabled|1"
 	}
 
@@ -241,10 +236,10 @@ func (h *BotHandler) buildStickerMenu(lang string) (string, tgbotapi.InlineKeybo
 			tgbotapi.NewInlineKeyboardButtonData(toggleText, toggleData),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "👋 Login sticker", "👋 Стикер входа"), "sticker_set|login"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "sticker.login_button"), "sticker_set|login"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🧾 Buyurtma sticker", "🧾 Стикер заказа"), "sticker_set|order_placed"),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "sticker.order_button"), "sticker_set|order_placed"),
 		),
 	)
 	return text, kb
@@ -255,12 +250,12 @@ func (h *BotHandler) buildStickerSlotPrompt(lang string, slot stickerSlot) (stri
 
 	text := fmt.Sprintf(
 		"%s: %s\n%s: %s\n\n%s\n\n%s",
-		t(lang, "Tanlandi", "Выбрано"),
+		tr(lang, "sticker.selected"),
 		stickerSlotLabel(lang, slot),
-		t(lang, "Hozirgi", "Текущий"),
+		tr(lang, "sticker.current"),
 		stickerStatusMark(fileID),
-		t(lang, "Endi qaysi sticker tashlashni istasangiz shu sticker'ni shu yerga yuboring.", "Теперь отправьте сюда стикер, который нужно отправлять."),
-		t(lang, "Bekor qilish: /cancel", "Отмена: /cancel"),
+		tr(lang, "sticker.send_prompt"),
+		tr(lang, "sticker.cancel_hint"),
 	)
 
 	if strings.TrimSpace(fileID) == "" {
@@ -269,7 +264,7 @@ func (h *BotHandler) buildStickerSlotPrompt(lang string, slot stickerSlot) (stri
 
 	kb := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(t(lang, "🚫 Sticker OFF", "🚫 Стикер OFF"), "sticker_clear|"+string(slot)),
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "sticker.off"), "sticker_clear|"+string(slot)),
 		),
 	)
 	return text, &kb
@@ -313,7 +308,7 @@ func (h *BotHandler) handleStickerEnabledCallback(ctx context.Context, chatID in
 	if srcMsg != nil && srcMsg.MessageID != 0 {
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, srcMsg.MessageID, text, kb)
 		if _, err := h.bot.Send(edit); err != nil {
-			h.sendMessage(chatID, t(lang, "✅ Saqlandi.", "✅ Сохранено."))
+			h.sendMessage(chatID, tr(lang, "sticker.saved_short"))
 		}
 		return
 	}
@@ -367,7 +362,7 @@ func (h *BotHandler) handleStickerClearCallback(ctx context.Context, chatID int6
 	h.setStickerAwait(adminID, slot)
 	lang := h.getUserLang(adminID)
 	baseText, kb := h.buildStickerSlotPrompt(lang, slot)
-	text := fmt.Sprintf("%s\n\n%s", t(lang, "✅ Sticker o‘chirildi.", "✅ Стикер выключен."), baseText)
+	text := fmt.Sprintf("%s\n\n%s", tr(lang, "sticker.disabled"), baseText)
 
 	if srcMsg != nil && srcMsg.MessageID != 0 {
 		markup := tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}
@@ -376,7 +371,7 @@ func (h *BotHandler) handleStickerClearCallback(ctx context.Context, chatID int6
 		}
 		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, srcMsg.MessageID, text, markup)
 		if _, err := h.bot.Send(edit); err != nil {
-			h.sendMessage(chatID, t(lang, "✅ Sticker o‘chirildi.", "✅ Стикер выключен."))
+			h.sendMessage(chatID, tr(lang, "sticker.disabled"))
 		}
 		return
 	}
@@ -415,7 +410,7 @@ func (h *BotHandler) handleStickerSetupInput(ctx context.Context, message *tgbot
 		if cmd == "cancel" {
 			h.clearStickerAwait(adminID)
 			h.deleteCommandMessage(message)
-			h.sendMessage(message.Chat.ID, t(lang, "❌ Bekor qilindi.", "❌ Отмена."))
+			h.sendMessage(message.Chat.ID, tr(lang, "sticker.canceled"))
 			return true
 		}
 		// Any other command cancels sticker setup and continues as usual.
@@ -428,32 +423,29 @@ func (h *BotHandler) handleStickerSetupInput(ctx context.Context, message *tgbot
 		if lower == "cancel" || lower == "bekor" || lower == "otmena" {
 			h.clearStickerAwait(adminID)
 			h.deleteUserMessage(message.Chat.ID, message)
-			h.sendMessage(message.Chat.ID, t(lang, "❌ Bekor qilindi.", "❌ Отмена."))
+			h.sendMessage(message.Chat.ID, tr(lang, "sticker.canceled"))
 			return true
 		}
 	}
 
 	if message.Sticker == nil {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf(
-			t(lang, "Iltimos, %s uchun sticker yuboring.\n\nBekor qilish: /cancel", "Пожалуйста, отправьте стикер для события: %s.\n\nОтмена: /cancel"),
-			stickerSlotLabel(lang, slot),
-		))
+		h.sendMessage(message.Chat.ID, tr(lang, "sticker.send_for", "event", stickerSlotLabel(lang, slot)))
 		return true
 	}
 
 	fileID := strings.TrimSpace(message.Sticker.FileID)
 	if fileID == "" {
-		h.sendMessage(message.Chat.ID, t(lang, "❌ Sticker file_id topilmadi. Qayta yuboring.", "❌ Не удалось получить file_id стикера. Отправьте ещё раз."))
+		h.sendMessage(message.Chat.ID, tr(lang, "sticker.no_file_id"))
 		return true
 	}
 
 	if err := h.setStickerForSlot(slot, fileID); err != nil {
-		h.sendMessage(message.Chat.ID, t(lang, "❌ Saqlashda xatolik. Qayta urinib ko'ring.", "❌ Ошибка сохранения. Попробуйте ещё раз."))
+		h.sendMessage(message.Chat.ID, tr(lang, "sticker.save_failed"))
 		return true
 	}
 
 	h.clearStickerAwait(adminID)
 	h.deleteUserMessage(message.Chat.ID, message)
-	h.sendMessage(message.Chat.ID, t(lang, "✅ Sticker saqlandi.", "✅ Стикер сохранён."))
+	h.sendMessage(message.Chat.ID, tr(lang, "sticker.saved"))
 	return true
 }
@@ -0,0 +1,426 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Mahsulot obunalari ("menga xabar bering"): mahsulot omborga qaytganda yoki narxi
+// belgilangan summadan tushganda mijozga xabar ketadi. Har katalog yangilanishidan keyin
+// (UploadCatalog -> onCatalogUpdated) tekshiriladi, xabarlar foydalanuvchi bo'yicha
+// jamlanib chiquvchi navbat orqali yuboriladi.
+// Importda mahsulot ID si yangilanadi, shuning uchun obuna mahsulot nomiga bog'lanadi.
+
+const (
+	stockAlertsFile = "data/stock_alerts.json"
+
+	stockAlertStock = "stock"
+	stockAlertPrice = "price"
+
+	maxStockAlertsPerUser = 20
+)
+
+type stockAlert struct {
+	ID        string       `json:"id"`
+	UserID    int64        `json:"user_id"`
+	Product   string       `json:"product"`
+	Kind      string       `json:"kind"`
+	Below     entity.Money `json:"below,omitempty"`
+	Branch    string       `json:"branch,omitempty"` // filial qoldiq kaliti (bo'sh - umumiy qoldiq)
+	Notified  bool         `json:"notified,omitempty"`
+	LastPrice entity.Money `json:"last_price,omitempty"` // oxirgi xabar qilingan narx
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+var errTooManyAlerts = errors.New("too many alerts")
+
+type stockAlertSettings struct {
+	Alerts []stockAlert `json:"alerts"`
+}
+
+// stockAlertHit bitta obuna bo'yicha yuboriladigan xabar
+type stockAlertHit struct {
+	Alert   stockAlert
+	Product entity.Product
+}
+
+func (h *BotHandler) loadStockAlertsFromDisk() {
+	b, err := os.ReadFile(stockAlertsFile)
+	if err != nil {
+		return
+	}
+	var cfg stockAlertSettings
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Printf("stock alerts parse failed: %v", err)
+		return
+	}
+	h.stockAlertMu.Lock()
+	h.stockAlerts = cfg.Alerts
+	h.stockAlertMu.Unlock()
+}
+
+func (h *BotHandler) saveStockAlertsLocked() error {
+	dir := filepath.Dir(stockAlertsFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(stockAlertSettings{Alerts: h.stockAlerts}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(stockAlertsFile, b, 0o600)
+}
+
+func (h *BotHandler) userStockAlerts(userID int64) []stockAlert {
+	h.stockAlertMu.Lock()
+	defer h.stockAlertMu.Unlock()
+	var out []stockAlert
+	for _, a := range h.stockAlerts {
+		if a.UserID == userID {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// addStockAlert obuna qo'shadi; shu mahsulot va turdagi eski obuna yangilanadi
+func (h *BotHandler) addStockAlert(a stockAlert) error {
+	h.stockAlertMu.Lock()
+	defer h.stockAlertMu.Unlock()
+	count := 0
+	for i, old := range h.stockAlerts {
+		if old.UserID != a.UserID {
+			continue
+		}
+		if old.Kind == a.Kind && normalizeAlertName(old.Product) == normalizeAlertName(a.Product) {
+			a.ID = old.ID
+			h.stockAlerts[i] = a
+			return h.saveStockAlertsLocked()
+		}
+		count++
+	}
+	if count >= maxStockAlertsPerUser {
+		return errTooManyAlerts
+	}
+	h.stockAlerts = append(h.stockAlerts, a)
+	return h.saveStockAlertsLocked()
+}
+
+// removeStockAlert foydalanuvchining obunasini o'chiradi
+func (h *BotHandler) removeStockAlert(userID int64, id string) (stockAlert, bool) {
+	h.stockAlertMu.Lock()
+	defer h.stockAlertMu.Unlock()
+	for i, a := range h.stockAlerts {
+		if a.ID == id && a.UserID == userID {
+			h.stockAlerts = append(h.stockAlerts[:i], h.stockAlerts[i+1:]...)
+			if err := h.saveStockAlertsLocked(); err != nil {
+				log.Printf("stock alerts save failed: %v", err)
+			}
+			return a, true
+		}
+	}
+	return stockAlert{}, false
+}
+
+func normalizeAlertName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// evaluateStockAlerts obunalarni yangi katalog bilan solishtiradi.
+// Xabar bir marta ketadi: mahsulot yana tugasa (narx chegaradan oshsa) obuna qayta "qurollanadi";
+// narx obunasi narx yana tushsa qayta xabar beradi.
+func evaluateStockAlerts(alerts []stockAlert, products []entity.Product, conv entity.Converter) ([]stockAlert, []stockAlertHit) {
+	byName := make(map[string][]entity.Product, len(products))
+	for _, p := range products {
+		key := normalizeAlertName(p.Name)
+		byName[key] = append(byName[key], p)
+	}
+
+	updated := make([]stockAlert, len(alerts))
+	var hits []stockAlertHit
+	for i, a := range alerts {
+		updated[i] = a
+		p, ok := alertProduct(byName[normalizeAlertName(a.Product)], a.Branch)
+		if !ok {
+			continue
+		}
+		switch a.Kind {
+		case stockAlertStock:
+			if p.StockAt(a.Branch) <= 0 {
+				updated[i].Notified = false
+				continue
+			}
+			if !a.Notified {
+				updated[i].Notified = true
+				hits = append(hits, stockAlertHit{Alert: updated[i], Product: p})
+			}
+		case stockAlertPrice:
+			price, ok := alertPriceIn(p.Price, a.Below.Currency, conv)
+			if !ok {
+				continue
+			}
+			if price.Amount > a.Below.Amount {
+				updated[i].Notified = false
+				updated[i].LastPrice = entity.Money{}
+				continue
+			}
+			if !a.Notified || price.Amount < a.LastPrice.Amount {
+				updated[i].Notified = true
+				updated[i].LastPrice = price
+				hits = append(hits, stockAlertHit{Alert: updated[i], Product: p})
+			}
+		}
+	}
+	return updated, hits
+}
+
+// alertProduct bir xil nomli mahsulotlardan obuna filialida qoldig'i borini tanlaydi
+// (filialsiz obuna uchun umumiy qoldiq); hech birida bo'lmasa birinchisi
+func alertProduct(list []entity.Product, branch string) (entity.Product, bool) {
+	if len(list) == 0 {
+		return entity.Product{}, false
+	}
+	for _, p := range list {
+		if p.StockAt(branch) > 0 {
+			return p, true
+		}
+	}
+	return list[0], true
+}
+
+// alertPriceIn mahsulot narxini obuna valyutasiga keltiradi
+func alertPriceIn(price entity.Money, currency string, conv entity.Converter) (entity.Money, bool) {
+	if price.IsZero() || currency == "" {
+		return entity.Money{}, false
+	}
+	if strings.EqualFold(price.Currency, currency) {
+		return price, true
+	}
+	if conv == nil {
+		return entity.Money{}, false
+	}
+	converted, err := conv.Convert(price, currency)
+	if err != nil {
+		return entity.Money{}, false
+	}
+	return converted, true
+}
+
+// onCatalogUpdated katalog yangilangach obunalarni tekshiradi va xabarlarni navbatga qo'yadi
+func (h *BotHandler) onCatalogUpdated(ctx context.Context, products []entity.Product) {
+	h.stockAlertMu.Lock()
+	if len(h.stockAlerts) == 0 {
+		h.stockAlertMu.Unlock()
+		return
+	}
+	prev := make(map[string]stockAlert, len(h.stockAlerts))
+	for _, a := range h.stockAlerts {
+		prev[a.ID] = a
+	}
+	updated, hits := evaluateStockAlerts(h.stockAlerts, products, h.currencySnapshot().converter())
+	h.stockAlerts = updated
+	if err := h.saveStockAlertsLocked(); err != nil {
+		log.Printf("stock alerts save failed: %v", err)
+	}
+	h.stockAlertMu.Unlock()
+
+	if len(hits) == 0 {
+		return
+	}
+	byUser := make(map[int64][]stockAlertHit)
+	var order []int64
+	for _, hit := range hits {
+		if _, ok := byUser[hit.Alert.UserID]; !ok {
+			order = append(order, hit.Alert.UserID)
+		}
+		byUser[hit.Alert.UserID] = append(byUser[hit.Alert.UserID], hit)
+	}
+	var dropped []stockAlertHit
+	for _, userID := range order {
+		if !h.enqueueOutbound(h.stockAlertMessage(userID, byUser[userID])) {
+			dropped = append(dropped, byUser[userID]...)
+		}
+	}
+	if len(dropped) > 0 {
+		h.rearmStockAlerts(dropped, prev)
+	}
+	log.Printf("[alerts] %d ta obuna ishladi, %d ta foydalanuvchiga xabar, %d ta obuna navbatga sig'madi", len(hits), len(order), len(dropped))
+}
+
+// rearmStockAlerts navbatga qo'yilmagan xabarlar obunalarini oldingi holatiga qaytaradi:
+// keyingi katalog yangilanishida xabar qayta yuboriladi. Shu orada obuna o'zgargan
+// (o'chirilgan yoki yangi baholangan) bo'lsa tegilmaydi
+func (h *BotHandler) rearmStockAlerts(hits []stockAlertHit, prev map[string]stockAlert) {
+	h.stockAlertMu.Lock()
+	defer h.stockAlertMu.Unlock()
+	for _, hit := range hits {
+		for i, a := range h.stockAlerts {
+			if a.ID != hit.Alert.ID || a.Notified != hit.Alert.Notified || a.LastPrice != hit.Alert.LastPrice {
+				continue
+			}
+			h.stockAlerts[i].Notified = prev[a.ID].Notified
+			h.stockAlerts[i].LastPrice = prev[a.ID].LastPrice
+		}
+	}
+	if err := h.saveStockAlertsLocked(); err != nil {
+		log.Printf("stock alerts save failed: %v", err)
+	}
+}
+
+// stockAlertMessage bitta foydalanuvchi uchun jamlangan xabar va obunani bekor qilish tugmalari
+func (h *BotHandler) stockAlertMessage(userID int64, hits []stockAlertHit) tgbotapi.MessageConfig {
+	lang := h.getUserLang(userID)
+	snap := h.currencySnapshot()
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "alert.header") + "\n")
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, hit := range hits {
+		price := snap.apply(hit.Product.Price.String())
+		switch hit.Alert.Kind {
+		case stockAlertStock:
+			sb.WriteString("\n" + tr(lang, "alert.back_in_stock", "product", hit.Product.Name, "price", price))
+		case stockAlertPrice:
+			sb.WriteString("\n" + tr(lang, "alert.price_drop", "product", hit.Product.Name, "price", price, "target", hit.Alert.Below.String()))
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "alert.btn_unsubscribe", "product", truncateInlineLabel(hit.Product.Name, 32)), "alert_off|"+hit.Alert.ID),
+		))
+	}
+	msg := tgbotapi.NewMessage(userID, sb.String())
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return msg
+}
+
+// parseAlertTarget oxirgi so'z(lar)dan narx chegarasi: "RTX 4070 550$" -> ("RTX 4070", 550$).
+// Valyuta ko'rsatilmagan son mahsulot nomining qismi hisoblanadi.
+func parseAlertTarget(args string) (string, entity.Money, bool) {
+	fields := strings.Fields(args)
+	if len(fields) < 2 {
+		return strings.TrimSpace(args), entity.Money{}, false
+	}
+	last := fields[len(fields)-1]
+	cut := len(fields) - 1
+	if !strings.ContainsAny(last, "0123456789") && len(fields) >= 3 && strings.ContainsAny(fields[len(fields)-2], "0123456789") {
+		last = fields[len(fields)-2] + last
+		cut = len(fields) - 2
+	}
+	if entity.ParseCurrency(last) == "" || !strings.ContainsAny(last, "0123456789") {
+		return strings.TrimSpace(args), entity.Money{}, false
+	}
+	below, ok := parseZoneMoney(strings.TrimLeft(last, "<≤="))
+	if !ok || below.IsZero() {
+		return strings.TrimSpace(args), entity.Money{}, false
+	}
+	name := strings.TrimSpace(strings.TrimRight(strings.Join(fields[:cut], " "), " <≤="))
+	return name, below, name != ""
+}
+
+// handleNotifyCommand /notify <mahsulot> [narx] - omborga kelishi yoki narx tushishiga obuna
+func (h *BotHandler) handleNotifyCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	chatID := message.Chat.ID
+	lang := h.getUserLang(userID)
+
+	query, below, isPrice := parseAlertTarget(message.CommandArguments())
+	if query == "" {
+		h.sendMessage(chatID, tr(lang, "alert.usage"))
+		return
+	}
+	var products []entity.Product
+	if h.productUseCase != nil {
+		products, _ = h.productUseCase.Search(ctx, query)
+	}
+	if len(products) == 0 {
+		h.sendMessage(chatID, tr(lang, "alert.not_found", "query", query))
+		return
+	}
+	p := products[0]
+	branch := h.branchStockFor(userID)
+
+	a := stockAlert{
+		ID:        strconv.FormatInt(time.Now().UnixNano(), 36),
+		UserID:    userID,
+		Product:   p.Name,
+		Kind:      stockAlertStock,
+		Branch:    branch,
+		CreatedAt: time.Now(),
+	}
+	if isPrice {
+		a.Kind = stockAlertPrice
+		a.Below = below
+		if price, ok := alertPriceIn(p.Price, below.Currency, h.currencySnapshot().converter()); ok && price.Amount <= below.Amount {
+			h.sendMessage(chatID, tr(lang, "alert.price_already", "product", p.Name, "price", price.String()))
+			return
+		}
+	} else if p.StockAt(branch) > 0 {
+		h.sendMessage(chatID, tr(lang, "alert.in_stock_now", "product", p.Name))
+		return
+	}
+
+	if err := h.addStockAlert(a); err != nil {
+		if errors.Is(err, errTooManyAlerts) {
+			h.sendMessage(chatID, tr(lang, "alert.too_many", "max", maxStockAlertsPerUser))
+			return
+		}
+		log.Printf("stock alerts save failed: %v", err)
+		h.sendMessage(chatID, tr(lang, "alert.save_failed"))
+		return
+	}
+	if isPrice {
+		h.sendMessage(chatID, tr(lang, "alert.subscribed_price", "product", p.Name, "target", below.String()))
+		return
+	}
+	h.sendMessage(chatID, tr(lang, "alert.subscribed_stock", "product", p.Name))
+}
+
+// handleAlertsCommand /alerts - obunalar ro'yxati va bekor qilish tugmalari
+func (h *BotHandler) handleAlertsCommand(message *tgbotapi.Message) {
+	userID := message.From.ID
+	lang := h.getUserLang(userID)
+	alerts := h.userStockAlerts(userID)
+	if len(alerts) == 0 {
+		h.sendMessage(message.Chat.ID, tr(lang, "alert.list_empty"))
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "alert.list_header") + "\n")
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, a := range alerts {
+		if a.Kind == stockAlertPrice {
+			sb.WriteString("\n" + tr(lang, "alert.list_price", "product", a.Product, "target", a.Below.String()))
+		} else {
+			sb.WriteString("\n" + tr(lang, "alert.list_stock", "product", a.Product))
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "alert.btn_unsubscribe", "product", truncateInlineLabel(a.Product, 32)), "alert_off|"+a.ID),
+		))
+	}
+	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("alerts list send failed: %v", err)
+	}
+}
+
+// handleAlertOffCallback alert_off|<id>
+func (h *BotHandler) handleAlertOffCallback(userID, chatID int64, id string) {
+	lang := h.getUserLang(userID)
+	a, ok := h.removeStockAlert(userID, id)
+	if !ok {
+		h.sendMessage(chatID, tr(lang, "alert.already_removed"))
+		return
+	}
+	h.sendMessage(chatID, tr(lang, "alert.removed", "product", a.Product))
+}
@@ -0,0 +1,104 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/infrastructure/exrate"
+)
+
+// TestEvaluateStockAlerts - obuna bir marta ishlaydi, mahsulot tugasa/narx oshsa qayta qurollanadi
+func TestEvaluateStockAlerts(t *testing.T) {
+	alerts := []stockAlert{
+		{ID: "a", UserID: 1, Product: "RTX 4070 Super", Kind: stockAlertStock},
+		{ID: "b", UserID: 1, Product: "Ryzen 5 7600", Kind: stockAlertPrice, Below: entity.NewMoney(2500000, entity.CurrencyUZS)},
+		{ID: "c", UserID: 2, Product: "rtx 4070  super", Kind: stockAlertStock, Branch: "chilonzor"},
+	}
+	conv := exrate.FixedConverter(12500)
+	catalog := func(gpuStock, chilonzor int, cpuPrice float64) []entity.Product {
+		return []entity.Product{
+			{Name: "RTX 4070 Super", Price: entity.NewMoney(650, entity.CurrencyUSD), Stock: gpuStock, StockByBranch: map[string]int{"chilonzor": chilonzor}},
+			{Name: "Ryzen 5 7600", Price: entity.NewMoney(cpuPrice, entity.CurrencyUSD), Stock: 3},
+		}
+	}
+
+	alerts, hits := evaluateStockAlerts(alerts, catalog(0, 0, 210), conv)
+	if len(hits) != 0 {
+		t.Fatalf("hech narsa ishlamasligi kerak: %+v", hits)
+	}
+
+	alerts, hits = evaluateStockAlerts(alerts, catalog(4, 0, 199), conv)
+	if len(hits) != 2 || hits[0].Alert.ID != "a" || hits[1].Alert.ID != "b" {
+		t.Fatalf("a (stock) va b (199$ = 2 487 500 so'm) kutilgan: %+v", hits)
+	}
+
+	// Takroriy import - qayta xabar yo'q; narx yana tushsa - xabar
+	alerts, hits = evaluateStockAlerts(alerts, catalog(4, 0, 199), conv)
+	if len(hits) != 0 {
+		t.Fatalf("takroriy xabar: %+v", hits)
+	}
+	alerts, hits = evaluateStockAlerts(alerts, catalog(4, 2, 180), conv)
+	if len(hits) != 2 || hits[0].Alert.ID != "b" || hits[1].Alert.ID != "c" {
+		t.Fatalf("b (arzonroq) va c (filialga keldi) kutilgan: %+v", hits)
+	}
+
+	// Tugab, yana kelsa - yana xabar
+	alerts, _ = evaluateStockAlerts(alerts, catalog(0, 0, 180), conv)
+	if _, hits = evaluateStockAlerts(alerts, catalog(1, 0, 180), conv); len(hits) != 1 || hits[0].Alert.ID != "a" {
+		t.Fatalf("qayta kelganda a kutilgan: %+v", hits)
+	}
+}
+
+// TestStockAlertBranchAndDelivery - bir xil nomli mahsulotlardan obuna filialidagisi olinadi;
+// navbatga sig'magan xabar obunani "xabar berilgan" qilmaydi va keyingi importda qayta ketadi
+func TestStockAlertBranchAndDelivery(t *testing.T) {
+	t.Chdir(t.TempDir())
+	gpu := func(chilonzor int) []entity.Product {
+		return []entity.Product{
+			{Name: "RTX 4070 Super", Price: entity.NewMoney(650, entity.CurrencyUSD), Stock: 5, StockByBranch: map[string]int{"yunusobod": 5}},
+			{Name: "RTX 4070 Super", Price: entity.NewMoney(640, entity.CurrencyUSD), Stock: chilonzor, StockByBranch: map[string]int{"chilonzor": chilonzor}},
+		}
+	}
+	alert := stockAlert{ID: "c", UserID: 2, Product: "RTX 4070 Super", Kind: stockAlertStock, Branch: "chilonzor"}
+	if _, hits := evaluateStockAlerts([]stockAlert{alert}, gpu(0), nil); len(hits) != 0 {
+		t.Fatalf("filialda yo'q, boshqa filial qoldig'i hisoblanmasligi kerak: %+v", hits)
+	}
+	if _, hits := evaluateStockAlerts([]stockAlert{alert}, gpu(2), nil); len(hits) != 1 || hits[0].Product.StockAt("chilonzor") != 2 {
+		t.Fatalf("filialdagi mahsulot kutilgan: %+v", hits)
+	}
+
+	h := &BotHandler{
+		stockAlerts: []stockAlert{alert},
+		userLang:    map[int64]string{2: "en"},
+		outbound:    &outboundQueue{messages: make(chan tgbotapi.Chattable)}, // to'la navbat
+	}
+	h.onCatalogUpdated(context.Background(), gpu(2))
+	if h.stockAlerts[0].Notified {
+		t.Fatalf("yuborilmagan xabar obunani yopdi")
+	}
+	h.outbound = &outboundQueue{messages: make(chan tgbotapi.Chattable, 1)}
+	h.onCatalogUpdated(context.Background(), gpu(2))
+	if !h.stockAlerts[0].Notified || len(h.outbound.messages) != 1 {
+		t.Fatalf("qayta yuborilmadi: notified=%v queued=%d", h.stockAlerts[0].Notified, len(h.outbound.messages))
+	}
+}
+
+func TestParseAlertTarget(t *testing.T) {
+	cases := []struct {
+		in, name, below string
+		price           bool
+	}{
+		{"RTX 4070", "RTX 4070", "", false},
+		{"RTX 4070 550$", "RTX 4070", "550.00$", true},
+		{"RTX 4070 < 550 $", "RTX 4070", "550.00$", true},
+		{"Ryzen 5 7600 2500000 so'm", "Ryzen 5 7600", "2 500 000 so'm", true},
+	}
+	for _, c := range cases {
+		name, below, ok := parseAlertTarget(c.in)
+		if name != c.name || ok != c.price || (ok && below.String() != c.below) {
+			t.Fatalf("parseAlertTarget(%q) = %q %s %v", c.in, name, below, ok)
+		}
+	}
+}
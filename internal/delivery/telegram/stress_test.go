@@ -12,17 +12,16 @@ import (
 // TestStressMultipleConcurrentUsers - Multiple users parallel testlar
 func TestStressMultipleConcurrentUsers(t *testing.T) {
 	handler := &BotHandler{
-		configSessions:   make(map[int64]*configSession),
-		feedbacks:        make(map[int64]feedbackInfo),
-		configReminded:   make(map[int64]bool),
-		configReminder:   make(map[int64]*time.Timer),
-		reminderInput:    make(map[int64]*reminderInputState),
-		pendingChange:    make(map[int64]changeRequest),
-		orderSessions:    make(map[int64]*orderSession),
-		orderCleanup:     make(map[int64]orderFormCleanup),
-		awaitingPassword: make(map[int64]bool),
-		chatStore:        newMemoryChatStore(),
-		cache:            newResponseCache(defaultCacheTTL, defaultMaxCacheSize),
+		configSessions: make(map[int64]*configSession),
+		feedbacks:      make(map[int64]feedbackInfo),
+		configReminded: make(map[int64]bool),
+		configReminder: make(map[int64]*time.Timer),
+		reminderInput:  make(map[int64]*reminderInputState),
+		pendingChange:  make(map[int64]changeRequest),
+		orderSessions:  make(map[int64]*orderSession),
+		orderCleanup:   make(map[int64]orderFormCleanup),
+		chatStore:      newMemoryChatStore(),
+		cache:          newResponseCache(defaultCacheTTL, defaultMaxCacheSize),
 	}
 
 	var wg sync.WaitGroup
@@ -0,0 +1,297 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// Noutbuk va tayyor kompyuter tanlash: /laptop dan keyin mijoz budjet, maqsad va ko'chmalikni
+// yozadi, usecase.RankSystems qoldiqdagi tizimlarni xususiyatlari bo'yicha saralaydi va eng
+// yaxshi 2-3 tasi jadval ko'rinishida taqqoslanadi; har biri tugma bilan savatga qo'shiladi.
+
+// laptopCompareLimit jadvalda taqqoslanadigan tizimlar soni
+const laptopCompareLimit = 3
+
+// laptopCellWidth jadval ustuni kengligi (telefonda sig'ishi uchun)
+const laptopCellWidth = 11
+
+// laptopOffer mijozga oxirgi ko'rsatilgan reyting (savat tugmalari shu ID bilan ishlaydi)
+type laptopOffer struct {
+	ID    string
+	Picks []usecase.SystemPick
+}
+
+// handleLaptopCommand /laptop [tavsif] - tavsif bo'lsa darhol saralaydi, aks holda so'raydi
+func (h *BotHandler) handleLaptopCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	if args := strings.TrimSpace(message.CommandArguments()); args != "" {
+		h.adviseLaptops(ctx, userID, message.Chat.ID, args)
+		return
+	}
+	h.awaitLaptop(userID, message.Chat.ID)
+	h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "laptop.prompt"))
+}
+
+// handleLaptopInput /laptop dan keyingi matn (convFlowLaptop)
+func (h *BotHandler) handleLaptopInput(ctx context.Context, in conversationInput) bool {
+	if _, ok := h.conversationStateIn(in.UserID, convFlowLaptop); !ok {
+		return false
+	}
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		return false
+	}
+	h.leaveConversation(in.UserID, convFlowLaptop)
+	h.adviseLaptops(ctx, in.UserID, in.ChatID, text)
+	return true
+}
+
+// awaitLaptop mijozdan tavsif kutadi
+func (h *BotHandler) awaitLaptop(userID, chatID int64) {
+	h.enterConversation(userID, convFlowLaptop, "need_description", chatID)
+}
+
+// adviseLaptops tavsifdan filtrlar oladi, reytingni taqqoslash jadvali va savat tugmalari bilan yuboradi
+func (h *BotHandler) adviseLaptops(ctx context.Context, userID, chatID int64, text string) {
+	lang := h.getUserLang(userID)
+	if h.configBuilder == nil {
+		h.sendMessage(chatID, tr(lang, "laptop.unavailable"))
+		return
+	}
+	req := usecase.ParseSystemRequest(text)
+	if req.Kind == "" {
+		req.Kind = usecase.PartLaptop
+	}
+	req.Branch = h.branchStockFor(userID)
+	req.Bench = h.benchmarks()
+	picks, err := h.configBuilder.RecommendSystems(ctx, req)
+	if err != nil {
+		log.Printf("laptop ranking catalog error: %v", err)
+		h.sendMessage(chatID, tr(lang, "laptop.unavailable"))
+		return
+	}
+	if len(picks) == 0 {
+		h.sendMessage(chatID, tr(lang, "laptop.none."+req.Kind))
+		return
+	}
+	if len(picks) > laptopCompareLimit {
+		picks = picks[:laptopCompareLimit]
+	}
+
+	msg := tgbotapi.NewMessage(chatID, h.applyCurrencyPreference(laptopCompareText(lang, req, picks)))
+	msg.ParseMode = tgbotapi.ModeHTML
+	offer := laptopOffer{ID: newUUID()[:8], Picks: picks}
+	h.laptopMu.Lock()
+	if h.laptopOffers == nil {
+		h.laptopOffers = make(map[int64]laptopOffer)
+	}
+	h.laptopOffers[userID] = offer
+	h.laptopMu.Unlock()
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(picks))
+	for i := range picks {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			tr(lang, "laptop.add", "n", i+1), fmt.Sprintf("lap_cart|%s|%d", offer.ID, i)))
+	}
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("laptop compare send error: %v", err)
+	}
+}
+
+// laptopCompareText filtrlar, raqamlangan ro'yxat va 2-3 tizim uchun <pre> jadval (HTML)
+func laptopCompareText(lang string, req usecase.SystemRequest, picks []usecase.SystemPick) string {
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "laptop.header."+req.Kind))
+	var filters []string
+	if req.Purpose != "" {
+		filters = append(filters, req.Purpose)
+	}
+	if req.BudgetUSD > 0 {
+		filters = append(filters, tr(lang, "laptop.filter_budget", "budget", fmt.Sprintf("%.0f", req.BudgetUSD)))
+	}
+	if req.MaxWeightKg > 0 && req.Kind == usecase.PartLaptop {
+		filters = append(filters, tr(lang, "laptop.filter_weight", "kg", formatKg(req.MaxWeightKg)))
+	}
+	if req.MinRAMGB > 0 {
+		filters = append(filters, fmt.Sprintf("%dGB+ RAM", req.MinRAMGB))
+	}
+	if len(filters) > 0 {
+		sb.WriteString("\n" + html.EscapeString(strings.Join(filters, " · ")))
+	}
+	if picks[0].OverBudget {
+		sb.WriteString("\n\n" + tr(lang, "laptop.over_budget"))
+	}
+
+	sb.WriteString("\n")
+	for i, pick := range picks {
+		sb.WriteString(fmt.Sprintf("\n%d. %s — %.0f$", i+1, html.EscapeString(pick.Product.Name), pick.PriceUSD))
+		if len(pick.Misses) > 0 {
+			labels := make([]string, 0, len(pick.Misses))
+			for _, m := range pick.Misses {
+				labels = append(labels, tr(lang, "laptop.miss."+m))
+			}
+			sb.WriteString("\n   " + tr(lang, "laptop.misses", "filters", strings.Join(labels, ", ")))
+		}
+	}
+	if len(picks) > 1 {
+		sb.WriteString("\n\n<pre>" + html.EscapeString(laptopCompareTable(lang, picks)) + "</pre>")
+	} else {
+		sb.WriteString("\n\n" + html.EscapeString(usecase.SystemSummary(picks[0].Specs)))
+	}
+	sb.WriteString("\n" + tr(lang, "laptop.score_note"))
+	return sb.String()
+}
+
+// laptopCompareTable qator - xususiyat, ustun - tizim; hammasida noma'lum qatorlar tushiriladi.
+// Narx qatorida "$" yo'q (sarlavhada USD): valyuta almashtirish ustunlarni surib yubormasin.
+func laptopCompareTable(lang string, picks []usecase.SystemPick) string {
+	rows := []struct {
+		key  string
+		cell func(s usecase.SystemPick) string
+	}{
+		{"cpu", func(p usecase.SystemPick) string { return shortCPU(p.Specs.CPU) }},
+		{"gpu", func(p usecase.SystemPick) string { return p.Specs.GPU }},
+		{"ram", func(p usecase.SystemPick) string { return gbCell(p.Specs.RAMGB) }},
+		{"storage", func(p usecase.SystemPick) string {
+			if p.Specs.StorageGB == 0 {
+				return ""
+			}
+			return usecase.FormatStorage(p.Specs.StorageGB)
+		}},
+		{"screen", func(p usecase.SystemPick) string {
+			if p.Specs.ScreenIn == 0 {
+				return ""
+			}
+			cell := strconv.FormatFloat(p.Specs.ScreenIn, 'f', -1, 64) + `"`
+			if p.Specs.RefreshHz > 0 {
+				cell += " " + strconv.Itoa(p.Specs.RefreshHz) + "Hz"
+			}
+			return cell
+		}},
+		{"weight", func(p usecase.SystemPick) string {
+			if p.Specs.WeightKg == 0 {
+				return ""
+			}
+			return formatKg(p.Specs.WeightKg) + "kg"
+		}},
+		{"battery", func(p usecase.SystemPick) string {
+			if p.Specs.BatteryWh == 0 {
+				return ""
+			}
+			return strconv.Itoa(p.Specs.BatteryWh) + "Wh"
+		}},
+		{"price", func(p usecase.SystemPick) string { return fmt.Sprintf("%.0f", p.PriceUSD) }},
+		{"score", func(p usecase.SystemPick) string { return fmt.Sprintf("%.1f/10", p.Score) }},
+	}
+
+	labelWidth := 0
+	for _, r := range rows {
+		if w := utf8.RuneCountInString(tr(lang, "laptop.row."+r.key)); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	line := func(label string, cells []string) string {
+		var sb strings.Builder
+		sb.WriteString(padCell(label, labelWidth))
+		for _, c := range cells {
+			if c == "" {
+				c = "—"
+			}
+			sb.WriteString(" " + padCell(c, laptopCellWidth))
+		}
+		return strings.TrimRight(sb.String(), " ")
+	}
+
+	header := make([]string, len(picks))
+	for i := range picks {
+		header[i] = "#" + strconv.Itoa(i+1)
+	}
+	lines := []string{line("", header)}
+	for _, r := range rows {
+		cells := make([]string, len(picks))
+		known := false
+		for i, p := range picks {
+			cells[i] = r.cell(p)
+			known = known || cells[i] != ""
+		}
+		if known {
+			lines = append(lines, line(tr(lang, "laptop.row."+r.key), cells))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shortCPU jadval uchun: "Core i7-13620H" -> "i7-13620H", "Ryzen 7 7840HS" -> "R7 7840HS"
+func shortCPU(cpu string) string {
+	switch {
+	case strings.HasPrefix(cpu, "Core Ultra "):
+		return "U" + strings.TrimPrefix(cpu, "Core Ultra ")
+	case strings.HasPrefix(cpu, "Ryzen ") && !strings.HasPrefix(cpu, "Ryzen AI"):
+		return "R" + strings.TrimPrefix(cpu, "Ryzen ")
+	}
+	return strings.TrimPrefix(cpu, "Core ")
+}
+
+func gbCell(gb int) string {
+	if gb == 0 {
+		return ""
+	}
+	return strconv.Itoa(gb) + "GB"
+}
+
+func formatKg(kg float64) string {
+	return strconv.FormatFloat(kg, 'f', -1, 64)
+}
+
+// padCell matnni kenglikka to'ldiradi, uzunini qisqartiradi (kirill harflari ham bitta belgi)
+func padCell(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		r := []rune(s)
+		return string(r[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+// handleLaptopCartCallback lap_cart|<offerID>|<index> - tanlangan tizimni savatga qo'shadi
+func (h *BotHandler) handleLaptopCartCallback(userID, chatID int64, data string, msg *tgbotapi.Message) {
+	lang := h.getUserLang(userID)
+	parts := strings.Split(strings.TrimPrefix(data, "lap_cart|"), "|")
+	h.laptopMu.Lock()
+	offer, ok := h.laptopOffers[userID]
+	h.laptopMu.Unlock()
+	idx := -1
+	if len(parts) == 2 {
+		idx, _ = strconv.Atoi(parts[1])
+	}
+	if !ok || len(parts) != 2 || offer.ID != parts[0] || idx < 0 || idx >= len(offer.Picks) {
+		h.sendMessage(chatID, tr(lang, "laptop.expired"))
+		return
+	}
+	pick := offer.Picks[idx]
+	title := pick.Product.Name
+	h.addToCart(userID, cartItem{
+		Title: title,
+		Text:  fmt.Sprintf("• %s - %.0f$\n%s\nOverall price: %.0f$", title, pick.PriceUSD, usecase.SystemSummary(pick.Specs), pick.PriceUSD),
+	})
+
+	reply := tgbotapi.NewMessage(chatID, tr(lang, "laptop.added", "title", title))
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🛒 Savatcha", "cart_open"),
+	))
+	if msg != nil {
+		reply.ReplyToMessageID = msg.MessageID
+	}
+	if _, err := h.sendAndLog(reply); err != nil {
+		log.Printf("laptop cart reply failed: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+func TestLaptopCompareText(t *testing.T) {
+	usd := func(v float64) entity.Money { return entity.NewMoney(v, entity.CurrencyUSD) }
+	products := []entity.Product{
+		{Name: `ASUS TUF Gaming F15 i7-13620H/16GB/512GB SSD/RTX 4060 8GB/15.6" 144Hz/2.2kg`, Category: "Laptop", Price: usd(1150), Stock: 1},
+		{Name: `Lenovo IdeaPad <Slim 5> Ryzen 7 7730U/16GB/1TB SSD/14"/1.4kg/56Wh`, Category: "Laptop", Price: usd(750), Stock: 1},
+	}
+	req := usecase.SystemRequest{Kind: usecase.PartLaptop, BudgetUSD: 1200, Purpose: "Gaming"}
+	picks := usecase.RankSystems(products, req)
+	text := laptopCompareText("uz", req, picks)
+
+	if !strings.Contains(text, "<pre>") || !strings.Contains(text, "&lt;Slim 5&gt;") {
+		t.Fatalf("HTML: %s", text)
+	}
+	table := laptopCompareTable("uz", picks)
+	lines := strings.Split(table, "\n")
+	if !strings.Contains(lines[1], "i7-13620H") || !strings.Contains(lines[1], "R7 7730U") {
+		t.Fatalf("CPU qatori: %q", lines[1])
+	}
+	// Narx qatorida "$" yo'q - valyuta almashtirish jadvalni buzmaydi
+	for _, line := range lines {
+		if strings.HasPrefix(line, tr("uz", "laptop.row.price")) && strings.Contains(strings.TrimPrefix(line, tr("uz", "laptop.row.price")), "$") {
+			t.Fatalf("narx qatori: %q", line)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package telegram
 
-import "time"
+import (
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
 
 type configStage int
 
@@ -19,6 +23,36 @@ const (
 	configStageNeedPeripherals
 )
 
+func (s configStage) String() string {
+	switch s {
+	case configStageNeedName:
+		return "need_name"
+	case configStageNeedType:
+		return "need_type"
+	case configStageNeedBudget:
+		return "need_budget"
+	case configStageNeedColor:
+		return "need_color"
+	case configStageNeedCPU:
+		return "need_cpu"
+	case configStageNeedCPUCooler:
+		return "need_cpu_cooler"
+	case configStageNeedStorage:
+		return "need_storage"
+	case configStageNeedGPU:
+		return "need_gpu"
+	case configStageNeedMonitor:
+		return "need_monitor"
+	case configStageNeedMonitorHz:
+		return "need_monitor_hz"
+	case configStageNeedMonitorDisplay:
+		return "need_monitor_display"
+	case configStageNeedPeripherals:
+		return "need_peripherals"
+	}
+	return "unknown"
+}
+
 type configSession struct {
 	Stage           configStage
 	Name            string
@@ -98,8 +132,30 @@ const (
 	orderStageNeedLocation
 	orderStageNeedDeliveryChoice
 	orderStageNeedDeliveryConfirm
+	orderStageNeedInstallment
+	orderStageNeedBranch
 )
 
+func (s orderStage) String() string {
+	switch s {
+	case orderStageNeedName:
+		return "need_name"
+	case orderStageNeedPhone:
+		return "need_phone"
+	case orderStageNeedLocation:
+		return "need_location"
+	case orderStageNeedDeliveryChoice:
+		return "need_delivery_choice"
+	case orderStageNeedDeliveryConfirm:
+		return "need_delivery_confirm"
+	case orderStageNeedInstallment:
+		return "need_installment"
+	case orderStageNeedBranch:
+		return "need_branch"
+	}
+	return "unknown"
+}
+
 type orderSession struct {
 	Stage     orderStage
 	Name      string
@@ -112,9 +168,20 @@ type orderSession struct {
 	ChatID    int64
 	MessageID int
 	FromCart  bool // savatchadan rasmiylashtirilgan (checkout_all) order
+	// Installment tanlangan muddatli to'lov rejasi (Months == 0 - to'liq to'lov)
+	Installment installmentPlan
+	// PromoCode /promo orqali qo'llangan kod (bo'sh - faqat avtomatik aksiyalar)
+	PromoCode string
+	// Zone manzil mos kelgan yetkazish hududi va narxi (nil - hudud topilmadi)
+	Zone *deliveryQuote
+	// Branch olib ketish punkti / buyurtma yuboriladigan filial (nil - filial tanlanmagan)
+	Branch *storeBranch
+	// PickupNote filial tanlangach guruhga yuboriladigan izoh
+	PickupNote string
 
 	InventoryReserved bool
 	ReservedItems     []string
+	ReservedStock     string // zaxira qaysi filial qoldig'idan olingan ("" - umumiy)
 	FormMessageIDs    []int
 }
 
@@ -149,6 +216,26 @@ type orderStatusInfo struct {
 	ETAPromptThread int
 	ETAPromptMsgID  int
 	CreatedAt       time.Time
+	PaymentStatus   string              // bo'sh - to'lanmagan; aks holda payment.Status
+	Installment     string              // muddatli to'lov rejasi matni (bo'sh - to'liq to'lov)
+	InstallmentPlan installmentPlan     // tanlangan reja (Months == 0 - to'liq to'lov)
+	InstallmentDown entity.Money        // boshlang'ich to'lov - onlayn faqat shu olinadi
+	CurrencyRate    float64             // buyurtma yaratilgandagi USD->so'm kursi (0 - noma'lum)
+	TotalMoney      entity.Money        // Total ning aniq qiymati (bo'sh - eski buyurtma, Total matnidan olinadi)
+	PromoCode       string              // qo'llangan promo kod
+	Lines           []entity.QuotedLine // har qator: asl narx, chegirma, aksiyalar
+	DeliveryZone    string              // yetkazish hududi (bo'sh - hududsiz)
+	DeliveryFee     entity.Money        // Total ichidagi yetkazish narxi
+	Branch          string              // filial ID (bo'sh - umumiy active orders kanali)
+	CourierID       int64               // biriktirilgan kuryer (0 - kuryer yo'q)
+}
+
+// orderStatusEvent buyurtma timeline yozuvi (holat o'zgarishi yoki ETA)
+type orderStatusEvent struct {
+	OrderID   string
+	Status    string // orderEventETA bo'lsa Note - taxminiy yetkazish vaqti
+	Note      string
+	CreatedAt time.Time
 }
 
 type adminMenuMessage struct {
@@ -0,0 +1,286 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/generative-ai-go/genai"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/constants"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/usecase"
+)
+
+// PC upgrade maslahatchisi: /upgrade dan keyin mijoz hozirgi kompyuterini matn yoki rasm
+// (stiker, skrinshot) bilan yuboradi; rasm AI orqali komponentlar ro'yxatiga o'giriladi,
+// zaif bo'g'in va qoldiqdagi mos upgrade'lar narxi bilan ko'rsatiladi, har biri bitta tugma
+// bilan savatga qo'shiladi.
+
+const upgradeOptionsLimit = 4
+
+// upgradeOffer mijozga oxirgi ko'rsatilgan takliflar (savat tugmasi shu ID bilan ishlaydi)
+type upgradeOffer struct {
+	ID      string
+	Options []usecase.UpgradeOption
+}
+
+const upgradePhotoPrompt = `Rasmda kompyuter xususiyatlari (stiker, skrinshot, quti yoki hujjat) bor. ` +
+	`Undan komponent modellarini o'qib, FAQAT quyidagi qatorlarni qaytar (aniqlanmaganini yozma):
+CPU: <model>
+GPU: <model>
+RAM: <hajm va DDR turi>
+Storage: <hajm va turi>
+PSU: <quvvat W>
+Motherboard: <model>
+Agar rasmda kompyuter xususiyatlari bo'lmasa, faqat NONE deb yoz.`
+
+// handleUpgradeCommand /upgrade [tavsif] - tavsif bo'lsa darhol tahlil, aks holda so'raydi
+func (h *BotHandler) handleUpgradeCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	if args := strings.TrimSpace(message.CommandArguments()); args != "" {
+		h.adviseUpgrade(ctx, userID, message.Chat.ID, args)
+		return
+	}
+	h.awaitUpgrade(userID, message.Chat.ID)
+	h.sendMessage(message.Chat.ID, tr(h.getUserLang(userID), "upgrade.prompt"))
+}
+
+// handleUpgradeInput /upgrade dan keyingi matn yoki rasm (convFlowUpgrade)
+func (h *BotHandler) handleUpgradeInput(ctx context.Context, in conversationInput) bool {
+	if _, ok := h.conversationStateIn(in.UserID, convFlowUpgrade); !ok {
+		return false
+	}
+	message := in.Msg
+	text := strings.TrimSpace(in.Text)
+	if message != nil && len(message.Photo) > 0 {
+		h.leaveConversation(in.UserID, convFlowUpgrade)
+		described, err := h.describePCPhoto(ctx, message.Photo[len(message.Photo)-1].FileID)
+		if err != nil {
+			log.Printf("upgrade photo read failed user=%d: %v", in.UserID, err)
+			key := "upgrade.photo_failed"
+			if h.geminiClient == nil {
+				key = "upgrade.photo_unavailable"
+			}
+			h.sendMessage(in.ChatID, tr(h.getUserLang(in.UserID), key))
+			h.awaitUpgrade(in.UserID, in.ChatID)
+			return true
+		}
+		// Izohda maqsad ("o'yin uchun") yoki qo'shimcha qism bo'lishi mumkin
+		text = strings.TrimSpace(message.Caption + "\n" + described)
+	}
+	if text == "" {
+		return false
+	}
+	h.leaveConversation(in.UserID, convFlowUpgrade)
+	h.adviseUpgrade(ctx, in.UserID, in.ChatID, text)
+	return true
+}
+
+// awaitUpgrade mijozdan tavsif yoki rasm kutadi (tavsif o'qilmasa qayta ham)
+func (h *BotHandler) awaitUpgrade(userID, chatID int64) {
+	h.enterConversation(userID, convFlowUpgrade, "need_description", chatID)
+}
+
+// describePCPhoto rasmdagi xususiyatlarni "CPU: ...\nGPU: ..." ko'rinishidagi matnga o'giradi
+func (h *BotHandler) describePCPhoto(ctx context.Context, fileID string) (string, error) {
+	if h.geminiClient == nil {
+		return "", fmt.Errorf("ai client not configured")
+	}
+	data, err := h.downloadFile(fileID)
+	if err != nil {
+		return "", fmt.Errorf("download photo: %w", err)
+	}
+	model := h.geminiClient.GenerativeModel(constants.GeminiModelName)
+	model.SetTemperature(0.1)
+	resp, err := model.GenerateContent(ctx, genai.ImageData("jpeg", data), genai.Text(upgradePhotoPrompt))
+	if err != nil {
+		return "", fmt.Errorf("read photo: %w", err)
+	}
+	var sb strings.Builder
+	if resp != nil && len(resp.Candidates) > 0 && resp.Candidates[0].Content != nil {
+		for _, part := range resp.Candidates[0].Content.Parts {
+			if t, ok := part.(genai.Text); ok {
+				sb.WriteString(string(t))
+			}
+		}
+	}
+	out := strings.TrimSpace(sb.String())
+	if out == "" || strings.EqualFold(out, "NONE") {
+		return "", fmt.Errorf("no specs on photo")
+	}
+	return out, nil
+}
+
+// adviseUpgrade tavsifni tahlil qiladi va takliflarni savat tugmalari bilan yuboradi
+func (h *BotHandler) adviseUpgrade(ctx context.Context, userID, chatID int64, text string) {
+	lang := h.getUserLang(userID)
+	bench := h.benchmarks()
+	build, ok := usecase.ParsePCDescription(text, bench)
+	if !ok {
+		h.sendMessage(chatID, tr(lang, "upgrade.not_recognized"))
+		h.awaitUpgrade(userID, chatID)
+		return
+	}
+	build.UserID = userID
+
+	var products []entity.Product
+	if h.productUseCase != nil {
+		all, err := h.productUseCase.GetAll(ctx)
+		if err != nil {
+			log.Printf("upgrade catalog error: %v", err)
+		}
+		products = all
+	}
+	req := usecase.UpgradeRequest{
+		Branch: h.branchStockFor(userID),
+		Bench:  bench,
+		Lang:   lang,
+		Limit:  upgradeOptionsLimit,
+	}
+	if h.configBuilder != nil {
+		req.Price = func(p entity.Product) float64 { return h.configBuilder.priceUSD(&p) }
+	}
+	advice := usecase.AdviseUpgrades(build, products, req)
+
+	offer := upgradeOffer{ID: newUUID()[:8], Options: advice.Options}
+	h.upgradeMu.Lock()
+	if h.upgradeOffers == nil {
+		h.upgradeOffers = make(map[int64]upgradeOffer)
+	}
+	h.upgradeOffers[userID] = offer
+	h.upgradeMu.Unlock()
+
+	msg := tgbotapi.NewMessage(chatID, h.applyCurrencyPreference(upgradeAdviceText(lang, advice)))
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i := range advice.Options {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr(lang, "upgrade.add", "n", i+1), fmt.Sprintf("upg_cart|%s|%d", offer.ID, i)),
+		))
+	}
+	if len(rows) > 0 {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("upgrade advice send error: %v", err)
+	}
+}
+
+// upgradeAdviceText hozirgi yig'ma, zaif bo'g'in va takliflar ro'yxati
+func upgradeAdviceText(lang string, advice *usecase.UpgradeAdvice) string {
+	var sb strings.Builder
+	sb.WriteString(tr(lang, "upgrade.current"))
+	b := advice.Current
+	for _, part := range []struct {
+		label string
+		p     entity.Product
+	}{
+		{"CPU", b.CPU}, {"GPU", b.GPU}, {"Motherboard", b.Motherboard}, {"RAM", b.RAM}, {"SSD", b.SSD}, {"PSU", b.PSU},
+	} {
+		if name := strings.TrimSpace(part.p.Name); name != "" {
+			sb.WriteString(fmt.Sprintf("\n• %s: %s", part.label, name))
+		}
+	}
+	sb.WriteString("\n\n" + tr(lang, "upgrade.score", "purpose", b.Purpose, "score", fmt.Sprintf("%.1f", advice.Score)))
+	sb.WriteString("\n" + tr(lang, "upgrade.weakest", "part", upgradePartLabel(advice.Weakest)))
+	if advice.Analytics != nil && advice.Analytics.Bottleneck.HasBottleneck {
+		sb.WriteString("\n" + advice.Analytics.Bottleneck.Description)
+	}
+
+	if len(advice.Options) == 0 {
+		sb.WriteString("\n\n" + tr(lang, "upgrade.none"))
+		return sb.String()
+	}
+	sb.WriteString("\n\n" + tr(lang, "upgrade.options"))
+	for i, opt := range advice.Options {
+		s := opt.Suggestion
+		sb.WriteString("\n\n" + tr(lang, "upgrade.option",
+			"n", i+1,
+			"component", s.Component,
+			"current", nonEmpty(s.CurrentSpec, "—"),
+			"suggested", s.SuggestedSpec,
+			"price", fmt.Sprintf("%.0f", opt.PriceUSD),
+			"priority", tr(lang, "upgrade.priority."+strings.ToLower(s.Priority)),
+		))
+		sb.WriteString("\n   " + s.Benefit)
+		for _, r := range opt.Requires {
+			sb.WriteString("\n" + tr(lang, "upgrade.requires", "name", r.Name))
+		}
+		if opt.MinPSUWatts > 0 {
+			sb.WriteString("\n" + tr(lang, "upgrade.min_psu", "watts", opt.MinPSUWatts))
+		}
+		if len(opt.Violations) > 0 {
+			sb.WriteString("\n   ⚠️ " + opt.Violations[0].Message(lang))
+		}
+	}
+	return sb.String()
+}
+
+func upgradePartLabel(kind string) string {
+	switch kind {
+	case usecase.PartCPU:
+		return "CPU"
+	case usecase.PartGPU:
+		return "GPU"
+	case usecase.PartRAM:
+		return "RAM"
+	case usecase.PartStorage:
+		return "SSD"
+	case usecase.PartPSU:
+		return "PSU"
+	}
+	return kind
+}
+
+// upgradeCartText taklif savatda konfiguratsiya formatida ("• GPU: nom - narx$", "Overall price")
+func (h *BotHandler) upgradeCartText(opt usecase.UpgradeOption) string {
+	price := func(p entity.Product) float64 {
+		if h.configBuilder != nil {
+			return h.configBuilder.priceUSD(&p)
+		}
+		return p.Price.Major()
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("• %s: %s - %.0f$\n", upgradePartLabel(opt.Kind), opt.Product.Name, price(opt.Product)))
+	for _, r := range opt.Requires {
+		sb.WriteString(fmt.Sprintf("• %s: %s - %.0f$\n", upgradePartLabel(usecase.SpecKind(r.Category, r.Name)), r.Name, price(r)))
+	}
+	sb.WriteString(fmt.Sprintf("Overall price: %.0f$", opt.PriceUSD))
+	return sb.String()
+}
+
+// handleUpgradeCartCallback upg_cart|<offerID>|<n> - taklifni (kerakli qismlari bilan) savatga qo'shadi
+func (h *BotHandler) handleUpgradeCartCallback(userID, chatID int64, data string, msg *tgbotapi.Message) {
+	lang := h.getUserLang(userID)
+	parts := strings.Split(strings.TrimPrefix(data, "upg_cart|"), "|")
+	if len(parts) != 2 {
+		return
+	}
+	idx, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+	h.upgradeMu.Lock()
+	offer, ok := h.upgradeOffers[userID]
+	h.upgradeMu.Unlock()
+	if !ok || offer.ID != parts[0] || idx < 0 || idx >= len(offer.Options) {
+		h.sendMessage(chatID, tr(lang, "upgrade.expired"))
+		return
+	}
+	opt := offer.Options[idx]
+	title := "Upgrade: " + opt.Product.Name
+	h.addToCart(userID, cartItem{Title: title, Text: h.upgradeCartText(opt)})
+
+	reply := tgbotapi.NewMessage(chatID, tr(lang, "upgrade.added", "title", title))
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🛒 Savatcha", "cart_open"),
+	))
+	if msg != nil {
+		reply.ReplyToMessageID = msg.MessageID
+	}
+	if _, err := h.sendAndLog(reply); err != nil {
+		log.Printf("upgrade cart reply failed: %v", err)
+	}
+}
@@ -23,20 +23,16 @@ type userSearchStore interface {
 }
 
 func (h *BotHandler) setAwaitingUserHistory(userID int64, awaiting bool) {
-	h.userHistoryMu.Lock()
 	if awaiting {
-		h.userHistoryAwait[userID] = true
+		h.enterConversation(userID, convFlowUserHistory, "need_user", 0)
 	} else {
-		delete(h.userHistoryAwait, userID)
+		h.leaveConversation(userID, convFlowUserHistory)
 	}
-	h.userHistoryMu.Unlock()
 }
 
 func (h *BotHandler) isAwaitingUserHistory(userID int64) bool {
-	h.userHistoryMu.RLock()
-	awaiting := h.userHistoryAwait[userID]
-	h.userHistoryMu.RUnlock()
-	return awaiting
+	_, ok := h.conversationStateIn(userID, convFlowUserHistory)
+	return ok
 }
 
 func (h *BotHandler) handleUserChatHistoryInlineQuery(ctx context.Context, query *tgbotapi.InlineQuery) {
@@ -79,7 +75,7 @@ func (h *BotHandler) handleUserChatHistoryInlineQuery(ctx context.Context, query
 
 		descParts := []string{fmt.Sprintf("ID:%d", entry.UserID)}
 		if !entry.LastSeen.IsZero() {
-			descParts = append(descParts, fmt.Sprintf("%s: %s", t(lang, "oxirgi", "посл."), formatAgo(now.Sub(entry.LastSeen), lang)))
+			descParts = append(descParts, fmt.Sprintf("%s: %s", tr(lang, "time.ago.last"), formatAgo(now.Sub(entry.LastSeen), lang)))
 		}
 		result.Description = strings.Join(descParts, " | ")
 		results = append(results, result)
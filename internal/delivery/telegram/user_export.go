@@ -21,7 +21,10 @@ type userExportRow struct {
 	Phone                  string
 	Location               string
 	Lang                   string
+	FirstSeen              time.Time
 	LastSeen               time.Time
+	MarketingConsent       string
+	Blocked                bool
 	OrdersCount            int
 	LastOrderID            string
 	LastOrderStatus        string
@@ -94,47 +97,27 @@ func (h *BotHandler) buildUserExportRows() []userExportRow {
 		return row
 	}
 
-	h.lastSeenMu.RLock()
-	h.nameMu.RLock()
-	for id, ts := range h.lastSeen {
-		row := getRow(id)
+	for _, rec := range h.listUserRecords() {
+		row := getRow(rec.UserID)
 		if row == nil {
 			continue
 		}
-		row.LastSeen = ts
-		if row.Username == "" {
-			row.Username = strings.TrimSpace(h.lastName[id])
-		}
-	}
-	h.nameMu.RUnlock()
-	h.lastSeenMu.RUnlock()
-
-	h.profileMu.RLock()
-	for id, prof := range h.profiles {
-		row := getRow(id)
-		if row == nil {
-			continue
-		}
-		if row.Name == "" {
-			row.Name = strings.TrimSpace(prof.Name)
-		}
-		if row.Phone == "" {
-			row.Phone = strings.TrimSpace(prof.Phone)
-		}
-	}
-	h.profileMu.RUnlock()
-
-	h.langMu.RLock()
-	for id, lang := range h.userLang {
-		row := getRow(id)
-		if row == nil {
-			continue
-		}
-		if row.Lang == "" {
-			row.Lang = strings.TrimSpace(lang)
+		row.Username = strings.TrimSpace(rec.Username)
+		row.Name = strings.TrimSpace(rec.Name)
+		row.Phone = strings.TrimSpace(rec.Phone)
+		row.Lang = strings.TrimSpace(rec.Lang)
+		row.FirstSeen = rec.FirstSeen
+		row.LastSeen = rec.LastSeen
+		row.Blocked = rec.Blocked
+		switch {
+		case rec.ConsentAt.IsZero():
+			row.MarketingConsent = ""
+		case rec.MarketingConsent:
+			row.MarketingConsent = "yes"
+		default:
+			row.MarketingConsent = "no"
 		}
 	}
-	h.langMu.RUnlock()
 
 	const maxOrdersForExport = 10000
 	for _, ord := range h.listRecentOrders(maxOrdersForExport) {
@@ -253,6 +236,9 @@ func userExportHeaders() []string {
 		"Last Order At",
 		"Last Order Summary",
 		"Last Order Status Summary",
+		"First Seen",
+		"Marketing Consent",
+		"Blocked",
 	}
 }
 
@@ -274,6 +260,9 @@ func userExportRowValues(row userExportRow) []interface{} {
 		formatExportTime(row.LastOrderAt),
 		row.LastOrderSummary,
 		row.LastOrderStatusSummary,
+		formatExportTime(row.FirstSeen),
+		row.MarketingConsent,
+		row.Blocked,
 	}
 }
 
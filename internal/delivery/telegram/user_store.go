@@ -0,0 +1,312 @@
+package telegram
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// userRecord foydalanuvchi haqidagi doimiy ma'lumotlar (til, profil, faollik)
+type userRecord struct {
+	UserID           int64
+	Username         string
+	Lang             string
+	Name             string
+	Phone            string
+	FirstSeen        time.Time
+	LastSeen         time.Time
+	MarketingConsent bool
+	ConsentAt        time.Time // nol bo'lsa - user hali javob bermagan
+	Blocked          bool
+	BlockedAt        time.Time
+	OrderPushOff     bool // buyurtma holati push xabarlaridan voz kechgan
+}
+
+// UserStore foydalanuvchilarni saqlash va olish uchun
+type UserStore interface {
+	Get(ctx context.Context, userID int64) (userRecord, bool, error)
+	List(ctx context.Context) ([]userRecord, error)
+	// Touch first_seen/last_seen/username ni yangilaydi (yozuv bo'lmasa yaratadi)
+	Touch(ctx context.Context, userID int64, username string, at time.Time) error
+	SetLang(ctx context.Context, userID int64, lang string) error
+	// SetProfile bo'sh maydonlarni o'zgartirmaydi
+	SetProfile(ctx context.Context, userID int64, name, phone string) error
+	SetMarketingConsent(ctx context.Context, userID int64, consent bool) error
+	SetBlocked(ctx context.Context, userID int64, blocked bool) error
+	SetOrderPush(ctx context.Context, userID int64, enabled bool) error
+}
+
+// memoryUserStore fallback (server ish davomida)
+type memoryUserStore struct {
+	mu   sync.RWMutex
+	data map[int64]userRecord
+}
+
+func newMemoryUserStore() *memoryUserStore {
+	return &memoryUserStore{data: make(map[int64]userRecord)}
+}
+
+func (m *memoryUserStore) Get(_ context.Context, userID int64) (userRecord, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	rec, ok := m.data[userID]
+	return rec, ok, nil
+}
+
+func (m *memoryUserStore) List(_ context.Context) ([]userRecord, error) {
+	m.mu.RLock()
+	res := make([]userRecord, 0, len(m.data))
+	for _, rec := range m.data {
+		res = append(res, rec)
+	}
+	m.mu.RUnlock()
+	sortUserRecords(res)
+	return res, nil
+}
+
+func (m *memoryUserStore) update(userID int64, fn func(rec *userRecord)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec, ok := m.data[userID]
+	if !ok {
+		rec = userRecord{UserID: userID, FirstSeen: time.Now()}
+	}
+	fn(&rec)
+	m.data[userID] = rec
+}
+
+func (m *memoryUserStore) Touch(_ context.Context, userID int64, username string, at time.Time) error {
+	m.update(userID, func(rec *userRecord) {
+		if at.Before(rec.FirstSeen) {
+			rec.FirstSeen = at
+		}
+		if at.After(rec.LastSeen) {
+			rec.LastSeen = at
+		}
+		if u := strings.TrimSpace(username); u != "" {
+			rec.Username = u
+		}
+		rec.Blocked = false
+		rec.BlockedAt = time.Time{}
+	})
+	return nil
+}
+
+func (m *memoryUserStore) SetLang(_ context.Context, userID int64, lang string) error {
+	m.update(userID, func(rec *userRecord) { rec.Lang = lang })
+	return nil
+}
+
+func (m *memoryUserStore) SetProfile(_ context.Context, userID int64, name, phone string) error {
+	m.update(userID, func(rec *userRecord) {
+		if n := strings.TrimSpace(name); n != "" {
+			rec.Name = n
+		}
+		if p := strings.TrimSpace(phone); p != "" {
+			rec.Phone = p
+		}
+	})
+	return nil
+}
+
+func (m *memoryUserStore) SetMarketingConsent(_ context.Context, userID int64, consent bool) error {
+	m.update(userID, func(rec *userRecord) {
+		rec.MarketingConsent = consent
+		rec.ConsentAt = time.Now()
+	})
+	return nil
+}
+
+func (m *memoryUserStore) SetBlocked(_ context.Context, userID int64, blocked bool) error {
+	m.update(userID, func(rec *userRecord) {
+		rec.Blocked = blocked
+		if blocked {
+			rec.BlockedAt = time.Now()
+		} else {
+			rec.BlockedAt = time.Time{}
+		}
+	})
+	return nil
+}
+
+func (m *memoryUserStore) SetOrderPush(_ context.Context, userID int64, enabled bool) error {
+	m.update(userID, func(rec *userRecord) { rec.OrderPushOff = !enabled })
+	return nil
+}
+
+// postgresUserStore persistent saqlash
+type postgresUserStore struct {
+	db *sql.DB
+}
+
+func newPostgresUserStore(dsn string) (*postgresUserStore, error) {
+	db, err := openPostgresWithRetry(dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(30 * time.Minute)
+
+	schema := `
+CREATE TABLE IF NOT EXISTS users (
+	user_id BIGINT PRIMARY KEY,
+	username TEXT NOT NULL DEFAULT '',
+	lang TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL DEFAULT '',
+	phone TEXT NOT NULL DEFAULT '',
+	first_seen TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	last_seen TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	marketing_consent BOOLEAN NOT NULL DEFAULT FALSE,
+	consent_at TIMESTAMPTZ,
+	blocked BOOLEAN NOT NULL DEFAULT FALSE,
+	blocked_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_users_last_seen ON users (last_seen DESC);
+`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("create users table: %w", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE users ADD COLUMN IF NOT EXISTS order_push_off BOOLEAN NOT NULL DEFAULT FALSE`); err != nil {
+		return nil, fmt.Errorf("alter users add order_push_off: %w", err)
+	}
+
+	return &postgresUserStore{db: db}, nil
+}
+
+const userSelectColumns = `user_id, username, lang, name, phone, first_seen, last_seen, marketing_consent, consent_at, blocked, blocked_at, order_push_off`
+
+func scanUserRecord(scan func(dest ...interface{}) error) (userRecord, error) {
+	var rec userRecord
+	var consentAt, blockedAt sql.NullTime
+	if err := scan(&rec.UserID, &rec.Username, &rec.Lang, &rec.Name, &rec.Phone, &rec.FirstSeen, &rec.LastSeen, &rec.MarketingConsent, &consentAt, &rec.Blocked, &blockedAt, &rec.OrderPushOff); err != nil {
+		return userRecord{}, err
+	}
+	if consentAt.Valid {
+		rec.ConsentAt = consentAt.Time
+	}
+	if blockedAt.Valid {
+		rec.BlockedAt = blockedAt.Time
+	}
+	return rec, nil
+}
+
+func (p *postgresUserStore) Get(ctx context.Context, userID int64) (userRecord, bool, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+userSelectColumns+` FROM users WHERE user_id=$1`, userID)
+	rec, err := scanUserRecord(row.Scan)
+	if err == sql.ErrNoRows {
+		return userRecord{}, false, nil
+	}
+	if err != nil {
+		return userRecord{}, false, err
+	}
+	return rec, true, nil
+}
+
+func (p *postgresUserStore) List(ctx context.Context) ([]userRecord, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT `+userSelectColumns+` FROM users ORDER BY last_seen DESC, user_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []userRecord
+	for rows.Next() {
+		rec, err := scanUserRecord(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, rec)
+	}
+	return res, rows.Err()
+}
+
+func (p *postgresUserStore) Touch(ctx context.Context, userID int64, username string, at time.Time) error {
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO users (user_id, username, first_seen, last_seen)
+	VALUES ($1, $2, $3, $3)
+	ON CONFLICT (user_id) DO UPDATE SET
+		username = COALESCE(NULLIF(EXCLUDED.username, ''), users.username),
+		first_seen = LEAST(users.first_seen, EXCLUDED.first_seen),
+		last_seen = GREATEST(users.last_seen, EXCLUDED.last_seen),
+		blocked = FALSE,
+		blocked_at = NULL
+	`, userID, strings.TrimSpace(username), at)
+	return err
+}
+
+func (p *postgresUserStore) SetLang(ctx context.Context, userID int64, lang string) error {
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO users (user_id, lang) VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET lang = EXCLUDED.lang
+	`, userID, lang)
+	return err
+}
+
+func (p *postgresUserStore) SetProfile(ctx context.Context, userID int64, name, phone string) error {
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO users (user_id, name, phone) VALUES ($1, $2, $3)
+	ON CONFLICT (user_id) DO UPDATE SET
+		name = COALESCE(NULLIF(EXCLUDED.name, ''), users.name),
+		phone = COALESCE(NULLIF(EXCLUDED.phone, ''), users.phone)
+	`, userID, strings.TrimSpace(name), strings.TrimSpace(phone))
+	return err
+}
+
+func (p *postgresUserStore) SetMarketingConsent(ctx context.Context, userID int64, consent bool) error {
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO users (user_id, marketing_consent, consent_at) VALUES ($1, $2, NOW())
+	ON CONFLICT (user_id) DO UPDATE SET marketing_consent = EXCLUDED.marketing_consent, consent_at = NOW()
+	`, userID, consent)
+	return err
+}
+
+func (p *postgresUserStore) SetBlocked(ctx context.Context, userID int64, blocked bool) error {
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO users (user_id, blocked, blocked_at) VALUES ($1, $2, CASE WHEN $2 THEN NOW() END)
+	ON CONFLICT (user_id) DO UPDATE SET blocked = EXCLUDED.blocked, blocked_at = EXCLUDED.blocked_at
+	`, userID, blocked)
+	return err
+}
+
+func (p *postgresUserStore) SetOrderPush(ctx context.Context, userID int64, enabled bool) error {
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO users (user_id, order_push_off) VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET order_push_off = EXCLUDED.order_push_off
+	`, userID, !enabled)
+	return err
+}
+
+func sortUserRecords(list []userRecord) {
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].LastSeen.Equal(list[j].LastSeen) {
+			return list[i].LastSeen.After(list[j].LastSeen)
+		}
+		return list[i].UserID < list[j].UserID
+	})
+}
+
+// newUserStoreFromEnv DSN berilsa Postgres, aks holda memory
+func newUserStoreFromEnv() (UserStore, error) {
+	dsn := strings.TrimSpace(os.Getenv("POSTGRES_DSN"))
+	if dsn == "" {
+		dsn = buildPostgresDSNFromEnv()
+	}
+	if strings.TrimSpace(dsn) == "" {
+		return newMemoryUserStore(), nil
+	}
+	store, err := newPostgresUserStore(dsn)
+	if err != nil {
+		log.Printf("user store: Postgres ulanmadi, memoryStore ga qaytdi: %v", err)
+		return newMemoryUserStore(), nil
+	}
+	return store, nil
+}
@@ -0,0 +1,45 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestUserStoreRestoresCache - restartdan keyin til va profil DB dan tiklanadi
+func TestUserStoreRestoresCache(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryUserStore()
+	const userID = int64(42)
+
+	seen := time.Now().Add(-time.Hour)
+	if err := store.Touch(ctx, userID, "ali", seen); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.SetLang(ctx, userID, "ru")
+	_ = store.SetProfile(ctx, userID, "Ali", "")
+	_ = store.SetProfile(ctx, userID, "", "+998901234567")
+	_ = store.SetBlocked(ctx, userID, true)
+	// User qayta yozsa blok olib tashlanadi
+	_ = store.Touch(ctx, userID, "", seen.Add(time.Minute))
+
+	rec, ok, _ := store.Get(ctx, userID)
+	if !ok || rec.Name != "Ali" || rec.Phone != "+998901234567" || rec.Username != "ali" {
+		t.Fatalf("yozuv noto'g'ri: %+v", rec)
+	}
+	if rec.Blocked || !rec.LastSeen.Equal(seen.Add(time.Minute)) {
+		t.Fatalf("touch noto'g'ri: %+v", rec)
+	}
+
+	h := &BotHandler{userStore: store}
+	h.loadUsersFromStore()
+	if !h.hasUserLang(userID) || h.getUserLang(userID) != "ru" {
+		t.Fatalf("til tiklanmadi: %q", h.getUserLang(userID))
+	}
+	if prof, ok := h.getProfile(userID); !ok || prof.Name != "Ali" {
+		t.Fatalf("profil tiklanmadi: %+v", prof)
+	}
+	if got := h.broadcastRecipients(); len(got) != 1 || got[0] != userID {
+		t.Fatalf("broadcast: %v", got)
+	}
+}
@@ -0,0 +1,271 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// last_seen ni DB ga har xabarda emas, shu oraliqda bir marta yozamiz
+const userTouchPersistInterval = time.Minute
+
+// persistUser userStore ga yozishni fon rejimida bajaradi (chat log kabi)
+func (h *BotHandler) persistUser(what string, fn func(ctx context.Context, store UserStore) error) {
+	if h == nil || h.userStore == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := fn(ctx, h.userStore); err != nil {
+			log.Printf("[users] %s save failed: %v", what, err)
+		}
+	}()
+}
+
+// loadUsersFromStore start paytida til/profil/faollik keshini DB dan tiklaydi
+func (h *BotHandler) loadUsersFromStore() {
+	if h.userStore == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	list, err := h.userStore.List(ctx)
+	if err != nil {
+		log.Printf("[users] load failed: %v", err)
+		return
+	}
+	for _, rec := range list {
+		h.mergeUserRecord(rec)
+	}
+	h.usersMu.Lock()
+	h.usersHydrated = true
+	h.usersMu.Unlock()
+	if len(list) > 0 {
+		log.Printf("[users] %d ta foydalanuvchi yuklandi", len(list))
+	}
+}
+
+// ensureUserLoaded kesh to'liq yuklanmagan bo'lsa (DB start paytida ishlamagan),
+// userni bir marta DB dan o'qib keshga qo'shadi
+func (h *BotHandler) ensureUserLoaded(userID int64) {
+	if h == nil || h.userStore == nil || userID == 0 {
+		return
+	}
+	h.usersMu.Lock()
+	if h.usersHydrated || h.usersLoaded[userID] {
+		h.usersMu.Unlock()
+		return
+	}
+	if h.usersLoaded == nil {
+		h.usersLoaded = make(map[int64]bool)
+	}
+	h.usersLoaded[userID] = true
+	h.usersMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	rec, ok, err := h.userStore.Get(ctx, userID)
+	if err != nil {
+		log.Printf("[users] get %d failed: %v", userID, err)
+		h.usersMu.Lock()
+		delete(h.usersLoaded, userID)
+		h.usersMu.Unlock()
+		return
+	}
+	if ok {
+		h.mergeUserRecord(rec)
+	}
+}
+
+// mergeUserRecord DB yozuvini keshga qo'shadi; keshdagi yangi qiymatlar ustun
+func (h *BotHandler) mergeUserRecord(rec userRecord) {
+	if rec.UserID == 0 {
+		return
+	}
+	if !rec.LastSeen.IsZero() {
+		h.lastSeenMu.Lock()
+		if h.lastSeen == nil {
+			h.lastSeen = make(map[int64]time.Time)
+		}
+		if prev, ok := h.lastSeen[rec.UserID]; !ok || rec.LastSeen.After(prev) {
+			h.lastSeen[rec.UserID] = rec.LastSeen
+		}
+		h.lastSeenMu.Unlock()
+	}
+	if u := strings.TrimSpace(rec.Username); u != "" {
+		h.nameMu.Lock()
+		if h.lastName == nil {
+			h.lastName = make(map[int64]string)
+		}
+		if h.lastName[rec.UserID] == "" {
+			h.lastName[rec.UserID] = u
+		}
+		h.nameMu.Unlock()
+	}
+	if rec.Lang != "" {
+		h.langMu.Lock()
+		if h.userLang == nil {
+			h.userLang = make(map[int64]string)
+		}
+		if _, ok := h.userLang[rec.UserID]; !ok {
+			h.userLang[rec.UserID] = rec.Lang
+		}
+		h.langMu.Unlock()
+	}
+	if rec.Name != "" || rec.Phone != "" {
+		h.profileMu.Lock()
+		if h.profiles == nil {
+			h.profiles = make(map[int64]userProfile)
+		}
+		prof := h.profiles[rec.UserID]
+		if prof.Name == "" {
+			prof.Name = rec.Name
+		}
+		if prof.Phone == "" {
+			prof.Phone = rec.Phone
+		}
+		h.profiles[rec.UserID] = prof
+		h.profileMu.Unlock()
+	}
+}
+
+// listUserRecords barcha foydalanuvchilar (DB + hali yozilmagan kesh)
+func (h *BotHandler) listUserRecords() []userRecord {
+	byID := make(map[int64]userRecord)
+	if h.userStore != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		list, err := h.userStore.List(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("[users] list failed: %v", err)
+		}
+		for _, rec := range list {
+			byID[rec.UserID] = rec
+		}
+	}
+
+	// Kesh DB dan yangiroq bo'lishi mumkin (yozuvlar fon rejimida)
+	h.lastSeenMu.RLock()
+	h.nameMu.RLock()
+	for id, ts := range h.lastSeen {
+		rec, ok := byID[id]
+		if !ok {
+			rec = userRecord{UserID: id, FirstSeen: ts}
+		}
+		if ts.After(rec.LastSeen) {
+			rec.LastSeen = ts
+		}
+		if name := strings.TrimSpace(h.lastName[id]); name != "" && rec.Username == "" {
+			rec.Username = name
+		}
+		byID[id] = rec
+	}
+	h.nameMu.RUnlock()
+	h.lastSeenMu.RUnlock()
+
+	h.langMu.RLock()
+	for id, lang := range h.userLang {
+		if rec, ok := byID[id]; ok {
+			rec.Lang = lang
+			byID[id] = rec
+		}
+	}
+	h.langMu.RUnlock()
+
+	h.profileMu.RLock()
+	for id, prof := range h.profiles {
+		if rec, ok := byID[id]; ok {
+			if strings.TrimSpace(prof.Name) != "" {
+				rec.Name = strings.TrimSpace(prof.Name)
+			}
+			if strings.TrimSpace(prof.Phone) != "" {
+				rec.Phone = strings.TrimSpace(prof.Phone)
+			}
+			byID[id] = rec
+		}
+	}
+	h.profileMu.RUnlock()
+
+	out := make([]userRecord, 0, len(byID))
+	for _, rec := range byID {
+		out = append(out, rec)
+	}
+	sortUserRecords(out)
+	return out
+}
+
+// broadcastRecipients bloklamagan va reklamadan voz kechmagan userlar
+func (h *BotHandler) broadcastRecipients() []int64 {
+	records := h.listUserRecords()
+	ids := make([]int64, 0, len(records))
+	for _, rec := range records {
+		if rec.Blocked || (!rec.ConsentAt.IsZero() && !rec.MarketingConsent) {
+			continue
+		}
+		ids = append(ids, rec.UserID)
+	}
+	return ids
+}
+
+// markUserBlocked user botni bloklagan (403) - broadcast va push'lardan chiqariladi
+func (h *BotHandler) markUserBlocked(userID int64) {
+	if userID <= 0 {
+		return
+	}
+	log.Printf("[users] %d botni bloklagan", userID)
+	h.persistUser("blocked", func(ctx context.Context, store UserStore) error {
+		return store.SetBlocked(ctx, userID, true)
+	})
+}
+
+// isBotBlockedError Telegram "bot was blocked by the user" (403) xatosi
+func isBotBlockedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == 403 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "bot was blocked by the user") || strings.Contains(msg, "user is deactivated")
+}
+
+// chattableChatID xabar qaysi chatga ketayotganini qaytaradi (topilmasa 0)
+func chattableChatID(msg tgbotapi.Chattable) int64 {
+	switch m := msg.(type) {
+	case tgbotapi.MessageConfig:
+		return m.ChatID
+	case tgbotapi.PhotoConfig:
+		return m.ChatID
+	case tgbotapi.DocumentConfig:
+		return m.ChatID
+	case tgbotapi.StickerConfig:
+		return m.ChatID
+	case tgbotapi.LocationConfig:
+		return m.ChatID
+	}
+	return 0
+}
+
+// handleMarketingConsentCommand /subscribe va /unsubscribe
+func (h *BotHandler) handleMarketingConsentCommand(message *tgbotapi.Message, consent bool) {
+	if message == nil || message.From == nil {
+		return
+	}
+	userID := message.From.ID
+	lang := h.getUserLang(userID)
+	h.persistUser("consent", func(ctx context.Context, store UserStore) error {
+		return store.SetMarketingConsent(ctx, userID, consent)
+	})
+	if consent {
+		h.sendMessage(message.Chat.ID, tr(lang, "marketing.subscribed"))
+	} else {
+		h.sendMessage(message.Chat.ID, tr(lang, "marketing.unsubscribed"))
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
 )
 
 // helper to generate UUID string if needed elsewhere
@@ -12,11 +13,27 @@ func newUUID() string {
 	return uuid.New().String()
 }
 
-func t(lang, uz, ru string) string {
-	if lang == "ru" {
-		return ru
+// tr - katalogdan kalit bo'yicha matn; args - "nom", qiymat juftliklari ({nom} uchun)
+func tr(lang, key string, args ...any) string {
+	return i18n.T(lang, key, args...)
+}
+
+// trPlural - n ga mos plural shakl ({count} avtomatik)
+func trPlural(lang, key string, n int, args ...any) string {
+	return i18n.Plural(lang, key, n, args...)
+}
+
+// aiLanguageInstruction - AI javobi tilini belgilovchi prompt prefiksi
+func aiLanguageInstruction(lang string) string {
+	switch i18n.Normalize(lang) {
+	case i18n.LangRu:
+		return "Отвечай только на русском языке.\n"
+	case i18n.LangEn:
+		return "Answer only in English.\n"
+	case i18n.LangUzCyrl:
+		return "Faqat o'zbek tilida, kirill alifbosida javob ber.\n"
 	}
-	return uz
+	return ""
 }
 
 func nonEmpty(val, fallback string) string {
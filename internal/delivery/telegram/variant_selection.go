@@ -56,13 +56,7 @@ func formatSelectedProductReply(item, lang string) string {
 	}
 	price := bestPriceFromLine(item)
 	if price != "" {
-		return t(lang,
-			fmt.Sprintf("✅ Bizda bor: %s\nJami: %s", item, price),
-			fmt.Sprintf("✅ В наличии: %s\nИтого: %s", item, price),
-		)
+		return tr(lang, "variant.in_stock_total", "item", item, "price", price)
 	}
-	return t(lang,
-		fmt.Sprintf("✅ Bizda bor: %s", item),
-		fmt.Sprintf("✅ В наличии: %s", item),
-	)
+	return tr(lang, "variant.in_stock", "item", item)
 }
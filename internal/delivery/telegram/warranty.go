@@ -0,0 +1,652 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Kafolat va servis: ombor xodimi (admin) yetkazilgan buyurtma qatorlariga seriya raqamini
+// kiritadi, kafolat muddati kategoriya bo'yicha (oy) belgilanadi va yetkazilgan kundan
+// hisoblanadi. Mijoz /warranty orqali amaldagi kafolatlarini ko'radi va servis so'rovi
+// ochadi; so'rov admin servis topic'iga boradi va o'z holat mashinasi bo'yicha yuradi.
+
+const (
+	warrantyFile          = "data/warranty.json"
+	defaultWarrantyMonths = 12
+)
+
+// Servis so'rovi holatlari
+const (
+	ticketOpen     = "open"
+	ticketReview   = "in_review"
+	ticketRepair   = "in_repair"
+	ticketReady    = "ready"
+	ticketClosed   = "closed"
+	ticketRejected = "rejected"
+)
+
+// ticketTransitions ruxsat etilgan o'tishlar (buyurtma holatlari kabi faqat oldinga)
+var ticketTransitions = map[string][]string{
+	ticketOpen:   {ticketReview, ticketRejected},
+	ticketReview: {ticketRepair, ticketReady, ticketRejected},
+	ticketRepair: {ticketReady},
+	ticketReady:  {ticketClosed},
+}
+
+// ticketAdminLabels admin tugmalari va kartasi uchun
+var ticketAdminLabels = map[string]string{
+	ticketOpen:     "🆕 Ochiq",
+	ticketReview:   "🔍 Ko'rikda",
+	ticketRepair:   "🔧 Ta'mirda",
+	ticketReady:    "📦 Tayyor, olib ketish mumkin",
+	ticketClosed:   "✅ Yopildi",
+	ticketRejected: "❌ Rad etildi",
+}
+
+func canTicketTransition(from, to string) bool {
+	for _, next := range ticketTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type ticketEvent struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+type serviceTicket struct {
+	ID        string        `json:"id"`
+	UserID    int64         `json:"user_id"`
+	Username  string        `json:"username,omitempty"`
+	OrderID   string        `json:"order_id"`
+	Line      int           `json:"line"`
+	Product   string        `json:"product"`
+	Serial    string        `json:"serial,omitempty"`
+	Until     time.Time     `json:"until"`
+	Problem   string        `json:"problem"`
+	Status    string        `json:"status"`
+	History   []ticketEvent `json:"history,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+func (t serviceTicket) closed() bool {
+	return len(ticketTransitions[t.Status]) == 0
+}
+
+type warrantySettings struct {
+	Periods         map[string]int            `json:"periods,omitempty"` // kategoriya (kichik harf) -> oy
+	DefaultMonths   int                       `json:"default_months"`
+	Serials         map[string]map[int]string `json:"serials,omitempty"` // buyurtma -> qator -> seriya raqami
+	ServiceChatID   int64                     `json:"service_chat_id,omitempty"`
+	ServiceThreadID int                       `json:"service_thread_id,omitempty"`
+	Tickets         []serviceTicket           `json:"tickets,omitempty"`
+	NextTicket      int                       `json:"next_ticket,omitempty"`
+}
+
+// periodFor kategoriya kafolati (oy); kategoriya sozlanmagan bo'lsa umumiy muddat
+func (s warrantySettings) periodFor(category string) int {
+	if months, ok := s.Periods[strings.ToLower(strings.TrimSpace(category))]; ok {
+		return months
+	}
+	return s.DefaultMonths
+}
+
+// warrantyClaim mijoz servis so'rovi uchun muammo tavsifini yozmoqda
+type warrantyClaim struct {
+	OrderID string
+	Line    int
+}
+
+// warrantyRecord buyurtma qatori bo'yicha kafolat
+type warrantyRecord struct {
+	OrderID string
+	Line    int // 1 dan boshlanadi
+	Product string
+	Serial  string
+	Until   time.Time
+}
+
+func (h *BotHandler) loadWarrantyFromDisk() {
+	cfg := warrantySettings{DefaultMonths: defaultWarrantyMonths}
+	if b, err := os.ReadFile(warrantyFile); err == nil {
+		if err := json.Unmarshal(b, &cfg); err != nil {
+			log.Printf("warranty parse failed: %v", err)
+			cfg = warrantySettings{DefaultMonths: defaultWarrantyMonths}
+		}
+	}
+	h.warrantyMu.Lock()
+	h.warranty = cfg
+	h.warrantyMu.Unlock()
+}
+
+func (h *BotHandler) saveWarrantyLocked() error {
+	dir := filepath.Dir(warrantyFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(h.warranty, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(warrantyFile, b, 0o600)
+}
+
+// updateWarranty sozlamani o'zgartirib diskka yozadi
+func (h *BotHandler) updateWarranty(fn func(cfg *warrantySettings)) error {
+	h.warrantyMu.Lock()
+	defer h.warrantyMu.Unlock()
+	fn(&h.warranty)
+	return h.saveWarrantyLocked()
+}
+
+func (h *BotHandler) warrantySnapshot() warrantySettings {
+	h.warrantyMu.RLock()
+	defer h.warrantyMu.RUnlock()
+	return h.warranty
+}
+
+// serviceChannel servis so'rovlari boradigan chat/topic (sozlanmagan bo'lsa admin guruhi)
+func (h *BotHandler) serviceChannel() (int64, int) {
+	cfg := h.warrantySnapshot()
+	if cfg.ServiceChatID != 0 {
+		return cfg.ServiceChatID, cfg.ServiceThreadID
+	}
+	return h.group1ChatID, h.group1ThreadID
+}
+
+func (h *BotHandler) isServiceChat(chatID int64) bool {
+	target, _ := h.serviceChannel()
+	return chatID != 0 && chatID == target
+}
+
+// deliveredAt buyurtma yetkazilgan vaqt (timeline'dan; topilmasa yaratilgan vaqt)
+func (h *BotHandler) deliveredAt(ord orderStatusInfo) time.Time {
+	var at time.Time
+	for _, ev := range h.listOrderEvents(ord.OrderID) {
+		if ev.Status == "delivered" {
+			at = ev.CreatedAt
+		}
+	}
+	if at.IsZero() {
+		return ord.CreatedAt
+	}
+	return at
+}
+
+// warrantyRecordsFor yetkazilgan buyurtma qatorlari bo'yicha kafolatlar (muddati 0 bo'lgan qatorlarsiz)
+func warrantyRecordsFor(ord orderStatusInfo, delivered time.Time, cfg warrantySettings) []warrantyRecord {
+	if ord.Status != "delivered" {
+		return nil
+	}
+	var out []warrantyRecord
+	for i, line := range ord.Lines {
+		months := cfg.periodFor(line.Category)
+		if months <= 0 {
+			continue
+		}
+		out = append(out, warrantyRecord{
+			OrderID: ord.OrderID,
+			Line:    i + 1,
+			Product: line.Name,
+			Serial:  cfg.Serials[ord.OrderID][i+1],
+			Until:   delivered.AddDate(0, months, 0),
+		})
+	}
+	return out
+}
+
+// activeWarranties mijozning amaldagi kafolatlari (tugashi yaqinlari birinchi)
+func (h *BotHandler) activeWarranties(userID int64, now time.Time) []warrantyRecord {
+	cfg := h.warrantySnapshot()
+	var out []warrantyRecord
+	for _, ord := range h.listOrdersByUser(userID) {
+		if ord.Status != "delivered" {
+			continue
+		}
+		for _, rec := range warrantyRecordsFor(ord, h.deliveredAt(ord), cfg) {
+			if rec.Until.After(now) {
+				out = append(out, rec)
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Until.Before(out[j].Until) })
+	return out
+}
+
+func (h *BotHandler) findWarranty(userID int64, orderID string, line int) (warrantyRecord, bool) {
+	for _, rec := range h.activeWarranties(userID, time.Now()) {
+		if rec.OrderID == orderID && rec.Line == line {
+			return rec, true
+		}
+	}
+	return warrantyRecord{}, false
+}
+
+func (h *BotHandler) userTickets(userID int64) []serviceTicket {
+	h.warrantyMu.RLock()
+	defer h.warrantyMu.RUnlock()
+	var out []serviceTicket
+	for _, t := range h.warranty.Tickets {
+		if t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (h *BotHandler) getTicket(id string) (serviceTicket, bool) {
+	h.warrantyMu.RLock()
+	defer h.warrantyMu.RUnlock()
+	for _, t := range h.warranty.Tickets {
+		if strings.EqualFold(t.ID, id) {
+			return t, true
+		}
+	}
+	return serviceTicket{}, false
+}
+
+func ticketStatusLabel(status, lang string) string {
+	return tr(lang, "ticket.status."+status)
+}
+
+// handleWarrantyCustomer /warranty - mijozning kafolatlari va servis so'rovlari
+func (h *BotHandler) handleWarrantyCustomer(message *tgbotapi.Message) {
+	userID := message.From.ID
+	lang := h.getUserLang(userID)
+	records := h.activeWarranties(userID, time.Now())
+	tickets := h.userTickets(userID)
+	if len(records) == 0 && len(tickets) == 0 {
+		h.sendMessage(message.Chat.ID, tr(lang, "warranty.none"))
+		return
+	}
+
+	var sb strings.Builder
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(records) > 0 {
+		sb.WriteString(tr(lang, "warranty.header") + "\n")
+		for _, rec := range records {
+			sb.WriteString("\n" + tr(lang, "warranty.item",
+				"product", rec.Product,
+				"order_id", rec.OrderID,
+				"serial", nonEmpty(rec.Serial, "—"),
+				"until", rec.Until.Format("2006-01-02")))
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+				tr(lang, "warranty.btn_service", "product", truncateInlineLabel(rec.Product, 32)),
+				fmt.Sprintf("wty_claim|%s|%d", rec.OrderID, rec.Line),
+			)))
+		}
+	}
+	if len(tickets) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(tr(lang, "ticket.list_header") + "\n")
+		for _, t := range tickets {
+			sb.WriteString("\n" + tr(lang, "ticket.list_item", "id", t.ID, "product", t.Product, "status", ticketStatusLabel(t.Status, lang)))
+		}
+	}
+	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
+	if len(rows) > 0 {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+	if _, err := h.sendAndLog(msg); err != nil {
+		log.Printf("warranty list send failed: %v", err)
+	}
+}
+
+// handleWarrantyClaimCallback wty_claim|<orderID>|<line> - muammo tavsifini so'raydi
+func (h *BotHandler) handleWarrantyClaimCallback(userID, chatID int64, data string) {
+	lang := h.getUserLang(userID)
+	parts := strings.Split(strings.TrimPrefix(data, "wty_claim|"), "|")
+	if len(parts) != 2 {
+		return
+	}
+	line, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+	rec, ok := h.findWarranty(userID, parts[0], line)
+	if !ok {
+		h.sendMessage(chatID, tr(lang, "warranty.expired"))
+		return
+	}
+	h.warrantyMu.Lock()
+	if h.warrantyClaims == nil {
+		h.warrantyClaims = make(map[int64]warrantyClaim)
+	}
+	h.warrantyClaims[userID] = warrantyClaim{OrderID: rec.OrderID, Line: rec.Line}
+	h.warrantyMu.Unlock()
+	h.enterConversation(userID, convFlowWarrantyClaim, "need_problem", chatID)
+	h.sendMessage(chatID, tr(lang, "warranty.describe", "product", rec.Product))
+}
+
+// handleWarrantyInput mijoz yozgan muammo tavsifidan servis so'rovi ochadi (convFlowWarrantyClaim)
+func (h *BotHandler) handleWarrantyInput(ctx context.Context, in conversationInput) bool {
+	_ = ctx
+	if _, ok := h.conversationStateIn(in.UserID, convFlowWarrantyClaim); !ok {
+		return false
+	}
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		return false
+	}
+	userID := in.UserID
+	claim, ok := h.pendingWarrantyClaim(userID)
+	h.leaveConversation(userID, convFlowWarrantyClaim)
+	h.clearWarrantyClaim(userID)
+	if !ok {
+		return false
+	}
+
+	lang := h.getUserLang(userID)
+	rec, ok := h.findWarranty(userID, claim.OrderID, claim.Line)
+	if !ok {
+		h.sendMessage(in.ChatID, tr(lang, "warranty.expired"))
+		return true
+	}
+	now := time.Now()
+	t := serviceTicket{
+		UserID:    userID,
+		Username:  in.Username,
+		OrderID:   rec.OrderID,
+		Line:      rec.Line,
+		Product:   rec.Product,
+		Serial:    rec.Serial,
+		Until:     rec.Until,
+		Problem:   text,
+		Status:    ticketOpen,
+		History:   []ticketEvent{{Status: ticketOpen, At: now}},
+		CreatedAt: now,
+	}
+	if err := h.updateWarranty(func(cfg *warrantySettings) {
+		cfg.NextTicket++
+		t.ID = fmt.Sprintf("S%04d", cfg.NextTicket)
+		cfg.Tickets = append(cfg.Tickets, t)
+	}); err != nil {
+		log.Printf("warranty save failed: %v", err)
+		h.sendMessage(in.ChatID, tr(lang, "ticket.save_failed"))
+		return true
+	}
+
+	chatID, threadID := h.serviceChannel()
+	if chatID != 0 {
+		if _, err := h.sendText(chatID, ticketAdminText(t), "", ticketKeyboard(t), threadID); err != nil {
+			log.Printf("service ticket route failed ticket=%s: %v", t.ID, err)
+		}
+	}
+	h.sendMessage(in.ChatID, tr(lang, "ticket.created", "id", t.ID))
+	return true
+}
+
+func (h *BotHandler) pendingWarrantyClaim(userID int64) (warrantyClaim, bool) {
+	h.warrantyMu.RLock()
+	defer h.warrantyMu.RUnlock()
+	claim, ok := h.warrantyClaims[userID]
+	return claim, ok
+}
+
+func (h *BotHandler) clearWarrantyClaim(userID int64) {
+	h.warrantyMu.Lock()
+	delete(h.warrantyClaims, userID)
+	h.warrantyMu.Unlock()
+}
+
+func ticketAdminText(t serviceTicket) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🛠 Servis so'rovi %s\n", t.ID))
+	sb.WriteString(fmt.Sprintf("Holat: %s\n", ticketAdminLabels[t.Status]))
+	sb.WriteString(fmt.Sprintf("Mijoz: %s (%d)\n", nonEmpty(t.Username, "-"), t.UserID))
+	sb.WriteString(fmt.Sprintf("Buyurtma: %s, %d-qator\n", t.OrderID, t.Line))
+	sb.WriteString(fmt.Sprintf("Mahsulot: %s\n", t.Product))
+	sb.WriteString(fmt.Sprintf("Seriya: %s\n", nonEmpty(t.Serial, "kiritilmagan")))
+	sb.WriteString(fmt.Sprintf("Kafolat: %s gacha\n", t.Until.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("\nMuammo:\n%s", t.Problem))
+	return sb.String()
+}
+
+func ticketKeyboard(t serviceTicket) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, next := range ticketTransitions[t.Status] {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(ticketAdminLabels[next], fmt.Sprintf("tkt_%s:%s", next, t.ID)))
+	}
+	if len(row) == 0 {
+		return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// handleTicketStatusChange tkt_<status>:<id> - admin servis so'rovi holatini o'zgartiradi
+func (h *BotHandler) handleTicketStatusChange(chatID int64, ticketID, newStatus string, srcMsg *tgbotapi.Message) {
+	var t serviceTicket
+	var found, allowed bool
+	err := h.updateWarranty(func(cfg *warrantySettings) {
+		for i := range cfg.Tickets {
+			if cfg.Tickets[i].ID != ticketID {
+				continue
+			}
+			found = true
+			if !canTicketTransition(cfg.Tickets[i].Status, newStatus) {
+				t = cfg.Tickets[i]
+				return
+			}
+			allowed = true
+			cfg.Tickets[i].Status = newStatus
+			cfg.Tickets[i].History = append(cfg.Tickets[i].History, ticketEvent{Status: newStatus, At: time.Now()})
+			t = cfg.Tickets[i]
+			return
+		}
+	})
+	if !found {
+		h.sendMessage(chatID, "❌ Servis so'rovi topilmadi.")
+		return
+	}
+	if !allowed {
+		h.sendMessage(chatID, fmt.Sprintf("⚠️ %s: %s holatidan %s ga o'tib bo'lmaydi.", t.ID, ticketAdminLabels[t.Status], ticketAdminLabels[newStatus]))
+		return
+	}
+	if err != nil {
+		log.Printf("warranty save failed: %v", err)
+	}
+
+	if srcMsg != nil && h.bot != nil {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, srcMsg.MessageID, ticketAdminText(t), ticketKeyboard(t))
+		if _, err := h.bot.Send(edit); err != nil {
+			log.Printf("ticket edit failed: %v", err)
+		}
+	}
+	lang := h.getUserLang(t.UserID)
+	h.sendMessage(t.UserID, tr(lang, "ticket.status_changed", "id", t.ID, "product", t.Product, "status", ticketStatusLabel(t.Status, lang)))
+}
+
+const warrantyUsage = `Foydalanish:
+/warranty period <kategoriya|default> <oy> - kafolat muddati (0 - kafolatsiz)
+/warranty topic [chat_id] [thread_id] - servis so'rovlari guruhi (argumentsiz - shu chat)
+/serial <OrderID> - buyurtma qatorlari va seriya raqamlari
+/serial <OrderID> <qator> <seriya> - seriya raqamini kiritish
+/tickets - ochiq servis so'rovlari`
+
+func (h *BotHandler) warrantyText() string {
+	cfg := h.warrantySnapshot()
+	var sb strings.Builder
+	sb.WriteString("🛡 Kafolat muddatlari:\n")
+	sb.WriteString(fmt.Sprintf("• Umumiy: %d oy\n", cfg.DefaultMonths))
+	cats := make([]string, 0, len(cfg.Periods))
+	for c := range cfg.Periods {
+		cats = append(cats, c)
+	}
+	sort.Strings(cats)
+	for _, c := range cats {
+		sb.WriteString(fmt.Sprintf("• %s: %d oy\n", c, cfg.Periods[c]))
+	}
+	chatID, threadID := h.serviceChannel()
+	sb.WriteString(fmt.Sprintf("\n🛠 Servis guruhi: %d", chatID))
+	if threadID != 0 {
+		sb.WriteString(fmt.Sprintf(" (topic %d)", threadID))
+	}
+	return sb.String()
+}
+
+// handleWarrantyCommand /warranty - admin kafolat sozlamalari; mijoz uchun kafolatlar ro'yxati
+func (h *BotHandler) handleWarrantyCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.handleWarrantyCustomer(message)
+		return
+	}
+
+	args := splitPromoArgs(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(chatID, h.warrantyText()+"\n\n"+warrantyUsage)
+		return
+	}
+	var reply string
+	var update func(cfg *warrantySettings)
+	switch strings.ToLower(args[0]) {
+	case "period":
+		if len(args) < 3 {
+			h.sendMessage(chatID, "❌ Noto'g'ri format.\n"+warrantyUsage)
+			return
+		}
+		months, err := strconv.Atoi(args[len(args)-1])
+		if err != nil || months < 0 || months > 120 {
+			h.sendMessage(chatID, "❌ Muddat 0-120 oy oralig'ida bo'lsin.")
+			return
+		}
+		category := strings.ToLower(strings.Join(args[1:len(args)-1], " "))
+		if category == "default" {
+			update = func(cfg *warrantySettings) { cfg.DefaultMonths = months }
+			reply = fmt.Sprintf("✅ Umumiy kafolat: %d oy.", months)
+		} else {
+			update = func(cfg *warrantySettings) {
+				if cfg.Periods == nil {
+					cfg.Periods = make(map[string]int)
+				}
+				cfg.Periods[category] = months
+			}
+			reply = fmt.Sprintf("✅ %s kafolati: %d oy.", category, months)
+		}
+	case "topic":
+		target, thread := chatID, 0
+		if len(args) > 1 {
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				h.sendMessage(chatID, "❌ Noto'g'ri chat_id.\n"+warrantyUsage)
+				return
+			}
+			target = id
+		}
+		if len(args) > 2 {
+			th, err := strconv.Atoi(args[2])
+			if err != nil {
+				h.sendMessage(chatID, "❌ Noto'g'ri thread_id.\n"+warrantyUsage)
+				return
+			}
+			thread = th
+		}
+		update = func(cfg *warrantySettings) { cfg.ServiceChatID, cfg.ServiceThreadID = target, thread }
+		reply = fmt.Sprintf("✅ Servis so'rovlari %d guruhiga yuboriladi.", target)
+	default:
+		h.sendMessage(chatID, warrantyUsage)
+		return
+	}
+	if err := h.updateWarranty(update); err != nil {
+		log.Printf("warranty save failed: %v", err)
+		h.sendMessage(chatID, "❌ Kafolat sozlamalarini saqlashda xatolik.")
+		return
+	}
+	h.sendMessage(chatID, reply+"\n\n"+h.warrantyText())
+}
+
+// handleSerialCommand /serial <OrderID> [qator seriya] - ombor xodimi seriya raqamlarini kiritadi
+func (h *BotHandler) handleSerialCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(chatID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+	args := strings.Fields(message.CommandArguments())
+	if len(args) == 0 {
+		h.sendMessage(chatID, warrantyUsage)
+		return
+	}
+	ord, ok := h.getOrderStatus(args[0])
+	if !ok {
+		h.sendMessage(chatID, "❌ Buyurtma topilmadi.")
+		return
+	}
+	if len(ord.Lines) == 0 {
+		h.sendMessage(chatID, "❌ Buyurtmada mahsulot qatorlari saqlanmagan (eski buyurtma).")
+		return
+	}
+	if len(args) >= 3 {
+		line, err := strconv.Atoi(args[1])
+		if err != nil || line < 1 || line > len(ord.Lines) {
+			h.sendMessage(chatID, fmt.Sprintf("❌ Qator raqami 1-%d oralig'ida bo'lsin.", len(ord.Lines)))
+			return
+		}
+		serial := strings.Join(args[2:], " ")
+		if err := h.updateWarranty(func(cfg *warrantySettings) {
+			if cfg.Serials == nil {
+				cfg.Serials = make(map[string]map[int]string)
+			}
+			if cfg.Serials[ord.OrderID] == nil {
+				cfg.Serials[ord.OrderID] = make(map[int]string)
+			}
+			cfg.Serials[ord.OrderID][line] = serial
+		}); err != nil {
+			log.Printf("warranty save failed: %v", err)
+			h.sendMessage(chatID, "❌ Seriya raqamini saqlashda xatolik.")
+			return
+		}
+	}
+
+	cfg := h.warrantySnapshot()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🔢 %s seriya raqamlari:\n", ord.OrderID))
+	for i, line := range ord.Lines {
+		months := cfg.periodFor(line.Category)
+		sb.WriteString(fmt.Sprintf("%d. %s — %s (%d oy)\n", i+1, line.Name, nonEmpty(cfg.Serials[ord.OrderID][i+1], "—"), months))
+	}
+	h.sendMessage(chatID, sb.String())
+}
+
+// handleTicketsCommand /tickets - yopilmagan servis so'rovlari
+func (h *BotHandler) handleTicketsCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.handleWarrantyCustomer(message)
+		return
+	}
+	cfg := h.warrantySnapshot()
+	sent := 0
+	for _, t := range cfg.Tickets {
+		if t.closed() {
+			continue
+		}
+		if _, err := h.sendText(chatID, ticketAdminText(t), "", ticketKeyboard(t), 0); err != nil {
+			log.Printf("ticket send failed: %v", err)
+		}
+		sent++
+	}
+	if sent == 0 {
+		h.sendMessage(chatID, "🛠 Ochiq servis so'rovlari yo'q.")
+	}
+}
@@ -0,0 +1,85 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// TestWarrantyRecords - muddat kategoriya bo'yicha, yetkazilgan kundan; 0 oy - kafolatsiz
+func TestWarrantyRecords(t *testing.T) {
+	cfg := warrantySettings{
+		DefaultMonths: 12,
+		Periods:       map[string]int{"gpu": 36, "cable": 0},
+		Serials:       map[string]map[int]string{"A7": {1: "SN-GPU-1"}},
+	}
+	ord := orderStatusInfo{
+		OrderID: "A7",
+		Status:  "delivered",
+		Lines: []entity.QuotedLine{
+			{PriceLine: entity.PriceLine{Name: "RTX 4070", Category: "GPU"}},
+			{PriceLine: entity.PriceLine{Name: "Ryzen 5 7600", Category: "CPU"}},
+			{PriceLine: entity.PriceLine{Name: "HDMI", Category: "Cable"}},
+		},
+	}
+	delivered := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	recs := warrantyRecordsFor(ord, delivered, cfg)
+	if len(recs) != 2 {
+		t.Fatalf("2 ta kafolat kutilgan: %+v", recs)
+	}
+	if recs[0].Serial != "SN-GPU-1" || recs[0].Line != 1 || !recs[0].Until.Equal(delivered.AddDate(3, 0, 0)) {
+		t.Fatalf("GPU: %+v", recs[0])
+	}
+	if recs[1].Serial != "" || recs[1].Line != 2 || !recs[1].Until.Equal(delivered.AddDate(1, 0, 0)) {
+		t.Fatalf("CPU: %+v", recs[1])
+	}
+
+	ord.Status = "onway"
+	if recs := warrantyRecordsFor(ord, delivered, cfg); len(recs) != 0 {
+		t.Fatalf("yetkazilmagan buyurtmada kafolat yo'q")
+	}
+}
+
+func TestTicketTransitions(t *testing.T) {
+	for _, c := range []struct {
+		from, to string
+		ok       bool
+	}{
+		{ticketOpen, ticketReview, true},
+		{ticketOpen, ticketReady, false},
+		{ticketReview, ticketRepair, true},
+		{ticketRepair, ticketReady, true},
+		{ticketReady, ticketClosed, true},
+		{ticketClosed, ticketOpen, false},
+		{ticketRejected, ticketReview, false},
+	} {
+		if got := canTicketTransition(c.from, c.to); got != c.ok {
+			t.Fatalf("%s -> %s = %v", c.from, c.to, got)
+		}
+	}
+	if !(serviceTicket{Status: ticketRejected}).closed() || (serviceTicket{Status: ticketReady}).closed() {
+		t.Fatalf("closed() noto'g'ri")
+	}
+	kb := ticketKeyboard(serviceTicket{ID: "S0001", Status: ticketReview})
+	if len(kb.InlineKeyboard[0]) != 3 || *kb.InlineKeyboard[0][0].CallbackData != "tkt_in_repair:S0001" {
+		t.Fatalf("ko'rikdagi so'rov tugmalari: %+v", kb.InlineKeyboard)
+	}
+}
+
+// TestWarrantyClaimClearedByCommand - komanda kelganda kutilayotgan da'vo o'chadi va keyingi matn ticket ochmaydi
+func TestWarrantyClaimClearedByCommand(t *testing.T) {
+	h := &BotHandler{warrantyClaims: map[int64]warrantyClaim{4: {OrderID: "A7", Line: 1}}}
+	h.enterConversation(4, convFlowWarrantyClaim, "need_problem", 40)
+
+	if !h.cancelConversationOnCommand(4) {
+		t.Fatalf("komanda da'voni bekor qilmadi")
+	}
+	if _, ok := h.pendingWarrantyClaim(4); ok {
+		t.Fatalf("da'vo komandadan keyin qoldi")
+	}
+	if h.handleWarrantyInput(context.Background(), conversationInput{UserID: 4, Text: "ekran yonmayapti", ChatID: 40}) {
+		t.Fatalf("bekor qilingan da'vo matnni oldi")
+	}
+}
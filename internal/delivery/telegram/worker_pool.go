@@ -143,9 +143,7 @@ func (wp *workerPool) processMessageWithTimeout(req *messageRequest) {
 
 	// Process message with AI - minimal prompt, main logic in chat_usecase
 	prompt := req.text
-	if req.lang == "ru" {
-		prompt = "Отвечай только на русском языке.\n" + prompt
-	}
+	prompt = aiLanguageInstruction(req.lang) + prompt
 
 	// Show typing indicator before AI request
 	if wp.handler.bot != nil {
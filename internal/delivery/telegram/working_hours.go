@@ -62,13 +62,7 @@ func envHour(key string, def int) int {
 
 func adminApprovalWaitMessage(lang string, now time.Time) string {
 	if shouldNotifyAfterHours(now) {
-		return t(lang,
-			"Konfiguratsiya admin tasdiqlashiga yuborildi. Hozir ish vaqti tugagan — iltimos, ertalabgacha kuting. Adminlar imkon qadar tez javob berishadi.",
-			"Конфигурация отправлена на утверждение администратору. Сейчас вне рабочего времени — пожалуйста, дождитесь утра. Админы ответят при первой возможности.",
-		)
+		return tr(lang, "approval.sent_after_hours")
 	}
-	return t(lang,
-		"Konfiguratsiya admin tasdiqlashiga yuborildi. Admin javobidan keyin rasmiylashtirishni davom ettirasiz.",
-		"Конфигурация отправлена на утверждение администратору. После ответа админа продолжите оформление.",
-	)
+	return tr(lang, "approval.sent")
 }
@@ -0,0 +1,209 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ISO 4217 valyuta kodlari
+const (
+	CurrencyUSD = "USD"
+	CurrencyUZS = "UZS"
+	CurrencyEUR = "EUR"
+	CurrencyRUB = "RUB"
+)
+
+var (
+	ErrCurrencyMismatch    = errors.New("money: currency mismatch")
+	ErrUnsupportedCurrency = errors.New("money: unsupported currency conversion")
+)
+
+// Money pul summasi: eng kichik birlikda (cent, tiyin) butun son + ISO valyuta kodi.
+// Float faqat kirish (parse) va chiqishda (format) ishlatiladi, hisob-kitob butun sonlarda.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// CurrencyRule valyuta uchun yaxlitlash qoidasi
+type CurrencyRule struct {
+	Exponent int   // 1 birlik = 10^Exponent minor birlik
+	Step     int64 // yaxlitlash qadami (minor birlikda)
+}
+
+// currencyRules - qoidalar aniq: UZS da tiyin muomalada yo'q, shuning uchun butun so'mgacha
+var currencyRules = map[string]CurrencyRule{
+	CurrencyUSD: {Exponent: 2, Step: 1},
+	CurrencyEUR: {Exponent: 2, Step: 1},
+	CurrencyRUB: {Exponent: 2, Step: 1},
+	CurrencyUZS: {Exponent: 2, Step: 100},
+}
+
+// RuleFor valyuta qoidasi (noma'lum valyuta - 2 xona, 1 minor qadam)
+func RuleFor(currency string) CurrencyRule {
+	if r, ok := currencyRules[strings.ToUpper(currency)]; ok {
+		return r
+	}
+	return CurrencyRule{Exponent: 2, Step: 1}
+}
+
+func (r CurrencyRule) scale() float64 {
+	return math.Pow10(r.Exponent)
+}
+
+// round minor birlikdagi qiymatni qadamga yaxlitlaydi (yarmi noldan uzoqqa)
+func (r CurrencyRule) round(minor float64) int64 {
+	step := float64(r.Step)
+	if step <= 0 {
+		step = 1
+	}
+	return int64(math.Round(minor/step) * step)
+}
+
+// NewMoney asosiy birlikdagi summadan (12.5 USD, 150000 UZS) valyuta qoidasi bo'yicha yaxlitlab yaratadi
+func NewMoney(major float64, currency string) Money {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	rule := RuleFor(currency)
+	return Money{Amount: rule.round(major * rule.scale()), Currency: currency}
+}
+
+// Major asosiy birlikdagi qiymat (faqat ko'rsatish/taqqoslash uchun)
+func (m Money) Major() float64 {
+	return float64(m.Amount) / RuleFor(m.Currency).scale()
+}
+
+func (m Money) IsZero() bool { return m.Amount == 0 }
+
+// Add bir xil valyutadagi summalarni qo'shadi; bo'sh (nol) Money har qanday valyutaga mos
+func (m Money) Add(o Money) (Money, error) {
+	switch {
+	case o.Amount == 0 && o.Currency == "":
+		return m, nil
+	case m.Amount == 0 && m.Currency == "":
+		return o, nil
+	case !strings.EqualFold(m.Currency, o.Currency):
+		return m, fmt.Errorf("%w: %s + %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Mul summani koeffitsientga ko'paytiradi va valyuta qoidasi bo'yicha yaxlitlaydi
+func (m Money) Mul(k float64) Money {
+	return Money{Amount: RuleFor(m.Currency).round(float64(m.Amount) * k), Currency: m.Currency}
+}
+
+// String ko'rsatish formati: "1299.99$", "15 000 000 so'm", "49.90 EUR"
+func (m Money) String() string {
+	switch m.Currency {
+	case CurrencyUSD:
+		return strconv.FormatFloat(m.Major(), 'f', 2, 64) + "$"
+	case CurrencyUZS:
+		return groupThousands(m.Amount/int64(RuleFor(CurrencyUZS).scale())) + " so'm"
+	case "":
+		return strconv.FormatFloat(m.Major(), 'f', 2, 64)
+	default:
+		return strconv.FormatFloat(m.Major(), 'f', 2, 64) + " " + m.Currency
+	}
+}
+
+func groupThousands(n int64) string {
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	s := strconv.FormatInt(n, 10)
+	var parts []string
+	for len(s) > 3 {
+		parts = append([]string{s[len(s)-3:]}, parts...)
+		s = s[:len(s)-3]
+	}
+	parts = append([]string{s}, parts...)
+	out := strings.Join(parts, " ")
+	if neg {
+		return "-" + out
+	}
+	return out
+}
+
+// currencyMarkers - valyuta belgilari (raqamga yopishgan holda ham) va so'zlari.
+// So'zlar butun token bo'yicha solishtiriladi ("сумма", "consumer", "Europe" valyuta emas);
+// "*" bilan tugagani qo'shimchali shakllarni ham qamraydi (so'mgacha, рублей, dollars)
+var currencyMarkers = []struct {
+	code    string
+	symbols string
+	words   []string
+}{
+	{CurrencyUSD, "$", []string{"usd", "dollar*", "доллар*"}},
+	{CurrencyEUR, "€", []string{"eur", "euro", "euros", "евро"}},
+	{CurrencyRUB, "₽", []string{"rub", "ruble", "rubles", "rubl", "руб", "рубл*"}},
+	{CurrencyUZS, "", []string{"so'm*", "сўм*", "сум", "uzs", "sum", "soum", "som"}},
+}
+
+// ParseCurrency narx matnidagi belgi yoki so'zdan ISO kodni aniqlaydi ("" - topilmadi)
+func ParseCurrency(text string) string {
+	lower := strings.ToLower(text)
+	tokens := currencyTokens(lower)
+	for _, m := range currencyMarkers {
+		if m.symbols != "" && strings.ContainsAny(lower, m.symbols) {
+			return m.code
+		}
+		for _, word := range m.words {
+			prefix, isPrefix := strings.CutSuffix(word, "*")
+			for _, tok := range tokens {
+				if tok == word || (isPrefix && strings.HasPrefix(tok, prefix)) {
+					return m.code
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// currencyTokens matnni harf (va apostrof) ketma-ketliklariga bo'ladi: "100so’mdan" -> ["so'mdan"]
+func currencyTokens(lower string) []string {
+	lower = strings.NewReplacer("’", "'", "ʻ", "'", "ʼ", "'", "`", "'").Replace(lower)
+	fields := strings.FieldsFunc(lower, func(r rune) bool {
+		return r != '\'' && !unicode.IsLetter(r)
+	})
+	tokens := fields[:0]
+	for _, f := range fields {
+		if f = strings.Trim(f, "'"); f != "" {
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
+
+// Converter valyutalar orasida o'girish (kurs servisi orqali)
+type Converter interface {
+	Convert(m Money, to string) (Money, error)
+}
+
+// Sum summalarni bitta valyutaga keltirib qo'shadi; conv nil bo'lsa faqat bir xil valyuta qabul qilinadi
+func Sum(conv Converter, currency string, items ...Money) (Money, error) {
+	total := Money{Currency: strings.ToUpper(currency)}
+	for _, it := range items {
+		if it.IsZero() {
+			continue
+		}
+		if !strings.EqualFold(it.Currency, total.Currency) {
+			if conv == nil {
+				return total, fmt.Errorf("%w: %s -> %s", ErrUnsupportedCurrency, it.Currency, total.Currency)
+			}
+			converted, err := conv.Convert(it, total.Currency)
+			if err != nil {
+				return total, err
+			}
+			it = converted
+		}
+		var err error
+		if total, err = total.Add(it); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
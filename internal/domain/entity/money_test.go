@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewMoneyRounding(t *testing.T) {
+	tests := []struct {
+		major    float64
+		currency string
+		want     Money
+	}{
+		{12.345, "USD", Money{Amount: 1235, Currency: CurrencyUSD}},
+		{12.344, "usd", Money{Amount: 1234, Currency: CurrencyUSD}},
+		{49.9, " eur ", Money{Amount: 4990, Currency: CurrencyEUR}},
+		{99.999, "RUB", Money{Amount: 10000, Currency: CurrencyRUB}},
+		// UZS butun so'mgacha yaxlitlanadi
+		{150000.49, "UZS", Money{Amount: 15000000, Currency: CurrencyUZS}},
+		{150000.5, "UZS", Money{Amount: 15000100, Currency: CurrencyUZS}},
+		{-10.5, "UZS", Money{Amount: -1100, Currency: CurrencyUZS}},
+		{1.005, "XYZ", Money{Amount: 100, Currency: "XYZ"}},
+	}
+	for _, tt := range tests {
+		if got := NewMoney(tt.major, tt.currency); got != tt.want {
+			t.Errorf("NewMoney(%v, %q) = %+v, kutilgan %+v", tt.major, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMajor(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want float64
+	}{
+		{Money{Amount: 129999, Currency: CurrencyUSD}, 1299.99},
+		{Money{Amount: 15000000, Currency: CurrencyUZS}, 150000},
+		{Money{Amount: 4990, Currency: CurrencyEUR}, 49.9},
+		{Money{}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Major(); got != tt.want {
+			t.Errorf("%+v.Major() = %v, kutilgan %v", tt.m, got, tt.want)
+		}
+	}
+}
+
+// rateConverter - test uchun qat'iy kurs: 1 USD = 12 500 UZS
+type rateConverter struct{}
+
+func (rateConverter) Convert(m Money, to string) (Money, error) {
+	switch {
+	case m.Currency == CurrencyUSD && to == CurrencyUZS:
+		return NewMoney(m.Major()*12500, to), nil
+	case m.Currency == CurrencyUZS && to == CurrencyUSD:
+		return NewMoney(m.Major()/12500, to), nil
+	}
+	return m, ErrUnsupportedCurrency
+}
+
+func TestSum(t *testing.T) {
+	usd := func(v float64) Money { return NewMoney(v, CurrencyUSD) }
+	uzs := func(v float64) Money { return NewMoney(v, CurrencyUZS) }
+	tests := []struct {
+		name     string
+		conv     Converter
+		currency string
+		items    []Money
+		want     Money
+		wantErr  error
+	}{
+		{"bo'sh", nil, "usd", nil, Money{Currency: CurrencyUSD}, nil},
+		{"bir valyuta", nil, CurrencyUSD, []Money{usd(10.1), usd(0.2), {}}, usd(10.3), nil},
+		{"nol boshqa valyutada", nil, CurrencyUSD, []Money{usd(5), {Currency: CurrencyUZS}}, usd(5), nil},
+		{"kurssiz aralash", nil, CurrencyUSD, []Money{usd(5), uzs(12500)}, Money{}, ErrUnsupportedCurrency},
+		{"kurs bilan", rateConverter{}, CurrencyUZS, []Money{usd(2), uzs(1000)}, uzs(26000), nil},
+		{"o'girib bo'lmaydi", rateConverter{}, CurrencyUSD, []Money{NewMoney(1, CurrencyEUR)}, Money{}, ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		got, err := Sum(tt.conv, tt.currency, tt.items...)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: xato %v, kutilgan %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: Sum = %+v, %v; kutilgan %+v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"1299.99$", CurrencyUSD},
+		{"$15", CurrencyUSD},
+		{"12 USD", CurrencyUSD},
+		{"5 dollars", CurrencyUSD},
+		{"100 долларов", CurrencyUSD},
+		{"49.90 EUR", CurrencyEUR},
+		{"€10", CurrencyEUR},
+		{"20 euro", CurrencyEUR},
+		{"1000₽", CurrencyRUB},
+		{"1000 руб.", CurrencyRUB},
+		{"1000 рублей", CurrencyRUB},
+		{"500 RUB", CurrencyRUB},
+		{"15 000 000 so'm", CurrencyUZS},
+		{"15 000 000 so’m", CurrencyUZS},
+		{"15 000 000 soʻm", CurrencyUZS},
+		{"1 000 000 so'mgacha", CurrencyUZS},
+		{"1 000 000 сум", CurrencyUZS},
+		{"1 000 000 сўм", CurrencyUZS},
+		{"150000UZS", CurrencyUZS},
+		{"150 000 sum", CurrencyUZS},
+		{"Narx (som)", CurrencyUZS},
+		{"100$ (1 250 000 so'm)", CurrencyUSD},
+		// so'z ichidagi bo'laklar valyuta emas
+		{"сумма заказа", ""},
+		{"сумка для ноутбука", ""},
+		{"Consumer grade SSD", ""},
+		{"Europe warranty", ""},
+		{"Rubber feet", ""},
+		{"Blossom RGB", ""},
+		{"Samsung Odyssey", ""},
+		{"1500", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseCurrency(tt.text); got != tt.want {
+			t.Errorf("ParseCurrency(%q) = %q, kutilgan %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -63,6 +63,9 @@ type PCAnalytics struct {
 
 	// Use case match
 	UseCaseMatch UseCaseMatchData `json:"use_case_match"`
+
+	// AI yozgan qisqa xulosa (raqamlar benchmark bazasidan hisoblanadi)
+	Summary string `json:"summary,omitempty"`
 }
 
 // FPSData o'yin uchun FPS ma'lumotlari
@@ -95,12 +98,17 @@ type BottleneckAnalysis struct {
 
 // PowerData quvvat sarfi
 type PowerData struct {
-	TotalWattage   int     `json:"total_wattage"`  // Umumiy quvvat (W)
-	PSUWattage     int     `json:"psu_wattage"`    // PSU quvvati (W)
-	PSUEfficiency  string  `json:"psu_efficiency"` // 80+ Bronze, Gold, Platinum
-	HeadRoom       float64 `json:"headroom"`       // Qolgan zaxira (%)
-	IsAdequate     bool    `json:"is_adequate"`    // PSU yetarlimi?
-	Recommendation string  `json:"recommendation,omitempty"`
+	TotalWattage       int     `json:"total_wattage"`       // Uzoq yuklamadagi iste'mol (W)
+	TransientWattage   int     `json:"transient_wattage"`   // GPU sakrashlari bilan qisqa cho'qqi (W)
+	PSUWattage         int     `json:"psu_wattage"`         // PSU quvvati (W)
+	PSUEfficiency      string  `json:"psu_efficiency"`      // 80+ Bronze, Gold, Platinum
+	MinimumWattage     int     `json:"minimum_wattage"`     // Bundan kuchsiz blok xavfli (W)
+	RecommendedWattage int     `json:"recommended_wattage"` // Zaxira bilan tavsiya (W)
+	RecommendedTier    string  `json:"recommended_tier"`    // Tavsiya etilgan 80 PLUS darajasi
+	ATX3               bool    `json:"atx3,omitempty"`      // ATX 3.x blok ma'qul
+	HeadRoom           float64 `json:"headroom"`            // Qolgan zaxira (W)
+	IsAdequate         bool    `json:"is_adequate"`         // PSU yetarlimi?
+	Recommendation     string  `json:"recommendation,omitempty"`
 }
 
 // UpgradeSuggestion upgrade tavsiyasi
@@ -152,31 +160,25 @@ type UseCaseScore struct {
 	Weaknesses  []string `json:"weaknesses"`  // Zaif tomonlar
 }
 
-// GetTotalPrice umumiy narxni hisoblash
-func (pc *PCBuild) GetTotalPrice() float64 {
-	total := 0.0
-
-	total += pc.CPU.Price
-	total += pc.GPU.Price
-	total += pc.RAM.Price
-	total += pc.SSD.Price
-	total += pc.Motherboard.Price
-	total += pc.PSU.Price
-
-	if pc.HDD != nil {
-		total += pc.HDD.Price
+// GetTotalPrice umumiy narxni currency valyutasida hisoblash.
+// Boshqa valyutadagi komponentlar conv orqali o'giriladi (conv nil bo'lsa xato).
+func (pc *PCBuild) GetTotalPrice(conv Converter, currency string) (Money, error) {
+	prices := []Money{
+		pc.CPU.Price,
+		pc.GPU.Price,
+		pc.RAM.Price,
+		pc.SSD.Price,
+		pc.Motherboard.Price,
+		pc.PSU.Price,
 	}
-	if pc.Case != nil {
-		total += pc.Case.Price
-	}
-	if pc.Cooler != nil {
-		total += pc.Cooler.Price
-	}
-	if pc.Monitor != nil {
-		total += pc.Monitor.Price
+
+	for _, opt := range []*Product{pc.HDD, pc.Case, pc.Cooler, pc.Monitor} {
+		if opt != nil {
+			prices = append(prices, opt.Price)
+		}
 	}
 
-	return total
+	return Sum(conv, currency, prices...)
 }
 
 // IsComplete barcha majburiy komponentlar to'liqmi?
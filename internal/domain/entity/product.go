@@ -1,18 +1,38 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Product mahsulot entity
 type Product struct {
 	ID          string
 	Name        string
 	Category    string
-	Price       float64
+	Price       Money
 	Description string
 	Stock       int
-	Specs       map[string]string // Texnik xususiyatlar
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// StockByBranch filial/ombor bo'yicha qoldiq (kalit - ombor ustuni yoki varag'i nomi)
+	StockByBranch map[string]int
+	Specs         map[string]string // Texnik xususiyatlar
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// StockAt filialdagi qoldiq; filial bo'sh yoki ombor ma'lumoti bo'lmasa umumiy qoldiq.
+// Filiallar bo'yicha ma'lumot bor-u, bu filial yo'q bo'lsa - u yerda mahsulot yo'q (0).
+func (p Product) StockAt(branch string) int {
+	branch = strings.ToLower(strings.TrimSpace(branch))
+	if branch == "" || len(p.StockByBranch) == 0 {
+		return p.Stock
+	}
+	for key, qty := range p.StockByBranch {
+		if strings.ToLower(strings.TrimSpace(key)) == branch {
+			return qty
+		}
+	}
+	return 0
 }
 
 // ProductCatalog mahsulotlar katalogi
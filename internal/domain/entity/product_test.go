@@ -0,0 +1,26 @@
+package entity
+
+import "testing"
+
+func TestProductStockAt(t *testing.T) {
+	plain := Product{Stock: 5}
+	if got := plain.StockAt("chilonzor"); got != 5 {
+		t.Fatalf("filial ma'lumotisiz: %d", got)
+	}
+
+	p := Product{Stock: 7, StockByBranch: map[string]int{"Chilonzor": 3, "yunusobod": 4}}
+	tests := []struct {
+		branch string
+		want   int
+	}{
+		{"", 7},
+		{"chilonzor", 3},
+		{" YUNUSOBOD ", 4},
+		{"sergeli", 0},
+	}
+	for _, tt := range tests {
+		if got := p.StockAt(tt.branch); got != tt.want {
+			t.Errorf("StockAt(%q) = %d, kutilgan %d", tt.branch, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// PromotionKind chegirma turi
+type PromotionKind string
+
+const (
+	PromoPercent PromotionKind = "percent" // Value - foiz
+	PromoFixed   PromotionKind = "fixed"   // Value - Currency dagi summa
+)
+
+// PromotionScope aksiya nimaga tegishli
+type PromotionScope string
+
+const (
+	ScopeAll      PromotionScope = "all"
+	ScopeProduct  PromotionScope = "product"  // Target - mahsulot nomi (yoki uning bir qismi)
+	ScopeCategory PromotionScope = "category" // Target - kategoriya (CPU, GPU, ...)
+	ScopeBrand    PromotionScope = "brand"    // Target - brend so'zi nomda (AMD, Intel, ...)
+	ScopeBundle   PromotionScope = "bundle"   // Bundle - birga olinishi kerak bo'lgan kategoriyalar
+)
+
+// Promotion chegirma qoidasi. Code bo'sh bo'lsa avtomatik, aks holda faqat promo kod bilan.
+type Promotion struct {
+	ID       string         `json:"id"`
+	Kind     PromotionKind  `json:"kind"`
+	Value    float64        `json:"value"`
+	Currency string         `json:"currency,omitempty"` // fixed uchun, bo'sh - USD
+	Scope    PromotionScope `json:"scope"`
+	Target   string         `json:"target,omitempty"`
+	Bundle   []string       `json:"bundle,omitempty"`
+	Code     string         `json:"code,omitempty"`
+	StartsAt time.Time      `json:"starts_at,omitempty"`
+	EndsAt   time.Time      `json:"ends_at,omitempty"`
+	MaxUses  int            `json:"max_uses,omitempty"` // 0 - cheklanmagan
+	Uses     int            `json:"uses"`
+	Disabled bool           `json:"disabled,omitempty"`
+}
+
+// ActiveAt vaqt oynasi, limit va yoqilganlik bo'yicha amal qiladimi
+func (p Promotion) ActiveAt(now time.Time) bool {
+	if p.Disabled || p.Exhausted() {
+		return false
+	}
+	if !p.StartsAt.IsZero() && now.Before(p.StartsAt) {
+		return false
+	}
+	if !p.EndsAt.IsZero() && !now.Before(p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// ErrPromotionExhausted foydalanish limiti tugagan aksiyani band qilishga urinish
+var ErrPromotionExhausted = errors.New("promotion usage limit reached")
+
+// Exhausted foydalanish limiti tugaganmi
+func (p Promotion) Exhausted() bool {
+	return p.MaxUses > 0 && p.Uses >= p.MaxUses
+}
+
+// MatchesCode promo kod mos keladimi (avtomatik aksiyalar har doim mos)
+func (p Promotion) MatchesCode(code string) bool {
+	return p.Code == "" || strings.EqualFold(p.Code, strings.TrimSpace(code))
+}
+
+// AppliesTo bitta qatorga tegishlimi (bundle bundan mustasno)
+func (p Promotion) AppliesTo(line PriceLine) bool {
+	target := strings.ToLower(strings.TrimSpace(p.Target))
+	switch p.Scope {
+	case ScopeAll:
+		return true
+	case ScopeProduct:
+		return target != "" && strings.Contains(strings.ToLower(line.Name), target)
+	case ScopeCategory:
+		return target != "" && strings.EqualFold(strings.TrimSpace(line.Category), target)
+	case ScopeBrand:
+		if target == "" {
+			return false
+		}
+		for _, w := range strings.FieldsFunc(strings.ToLower(line.Name), isNameSeparator) {
+			if w == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isNameSeparator(r rune) bool {
+	return r == ' ' || r == '-' || r == '/' || r == ',' || r == '(' || r == ')'
+}
+
+// PriceLine narxlanadigan qator (katalog narxi bilan)
+type PriceLine struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Price    Money  `json:"price"`
+	Catalog  bool   `json:"catalog,omitempty"` // katalogdan topilgan - aksiyalar faqat shularga
+}
+
+// QuotedLine qator narxi: asl narx, chegirma va qo'llangan aksiyalar
+type QuotedLine struct {
+	PriceLine
+	Discount Money    `json:"discount"`
+	Final    Money    `json:"final"`
+	Promos   []string `json:"promos,omitempty"`
+}
+
+// PriceQuote savat/konfiguratsiya uchun hisob-kitob
+type PriceQuote struct {
+	Lines    []QuotedLine `json:"lines"`
+	Original Money        `json:"original"`
+	Discount Money        `json:"discount"`
+	Total    Money        `json:"total"`
+	Code     string       `json:"code,omitempty"`
+	Applied  []string     `json:"applied,omitempty"` // qo'llangan aksiya ID lari
+}
+
+// ApplyPromotions qatorlarga aksiyalarni qo'llaydi:
+//   - har qatorga eng katta bitta qator-aksiyasi (aksiyalar qo'shilmaydi);
+//   - bundle aksiyasi kategoriyalar to'liq bo'lsa, shu qatorlarning qolgan narxidan,
+//     chegirma qatorlarga narxiga mutanosib taqsimlanadi;
+//   - fixed chegirma conv orqali qator valyutasiga o'giriladi, o'girib bo'lmasa qo'llanmaydi.
+//
+// Jami summa birinchi qator valyutasida.
+func ApplyPromotions(promos []Promotion, lines []PriceLine, code string, now time.Time, conv Converter) (PriceQuote, error) {
+	quote := PriceQuote{Code: strings.ToUpper(strings.TrimSpace(code))}
+	var active []Promotion
+	for _, p := range promos {
+		if p.ActiveAt(now) && p.MatchesCode(code) {
+			active = append(active, p)
+		}
+	}
+
+	applied := make(map[string]bool)
+	for _, line := range lines {
+		q := QuotedLine{PriceLine: line, Discount: Money{Currency: line.Price.Currency}, Final: line.Price}
+		if line.Catalog {
+			var best Money
+			bestID := ""
+			for _, p := range active {
+				if p.Scope == ScopeBundle || !p.AppliesTo(line) {
+					continue
+				}
+				if d, ok := promoDiscount(p, line.Price, conv); ok && d.Amount > best.Amount {
+					best, bestID = d, p.ID
+				}
+			}
+			if bestID != "" {
+				q.Discount = best
+				q.Final = Money{Amount: line.Price.Amount - best.Amount, Currency: line.Price.Currency}
+				q.Promos = append(q.Promos, bestID)
+				applied[bestID] = true
+			}
+		}
+		quote.Lines = append(quote.Lines, q)
+	}
+
+	for _, p := range active {
+		if p.Scope != ScopeBundle {
+			continue
+		}
+		if applyBundle(p, quote.Lines, conv) {
+			applied[p.ID] = true
+		}
+	}
+
+	currency := CurrencyUSD
+	if len(lines) > 0 {
+		currency = lines[0].Price.Currency
+	}
+	var originals, discounts, finals []Money
+	for _, q := range quote.Lines {
+		originals = append(originals, q.Price)
+		discounts = append(discounts, q.Discount)
+		finals = append(finals, q.Final)
+	}
+	var err error
+	if quote.Original, err = Sum(conv, currency, originals...); err != nil {
+		return quote, err
+	}
+	if quote.Discount, err = Sum(conv, currency, discounts...); err != nil {
+		return quote, err
+	}
+	if quote.Total, err = Sum(conv, currency, finals...); err != nil {
+		return quote, err
+	}
+	for _, p := range promos {
+		if applied[p.ID] {
+			quote.Applied = append(quote.Applied, p.ID)
+		}
+	}
+	return quote, nil
+}
+
+// promoDiscount bitta narx uchun chegirma (narxdan oshmaydi)
+func promoDiscount(p Promotion, price Money, conv Converter) (Money, bool) {
+	if price.Amount <= 0 || p.Value <= 0 {
+		return Money{}, false
+	}
+	var d Money
+	switch p.Kind {
+	case PromoPercent:
+		if p.Value > 100 {
+			return Money{}, false
+		}
+		d = price.Mul(p.Value / 100)
+	case PromoFixed:
+		cur := p.Currency
+		if cur == "" {
+			cur = CurrencyUSD
+		}
+		fixed, err := Sum(conv, price.Currency, NewMoney(p.Value, cur))
+		if err != nil {
+			return Money{}, false
+		}
+		d = fixed
+	default:
+		return Money{}, false
+	}
+	if d.Amount > price.Amount {
+		d.Amount = price.Amount
+	}
+	return d, d.Amount > 0
+}
+
+// applyBundle har bundle kategoriyasiga alohida katalog qatori topilsa chegirmani taqsimlaydi
+func applyBundle(p Promotion, lines []QuotedLine, conv Converter) bool {
+	if len(p.Bundle) < 2 || len(lines) == 0 {
+		return false
+	}
+	used := make(map[int]bool)
+	var idx []int
+	for _, cat := range p.Bundle {
+		found := -1
+		for i, l := range lines {
+			if !used[i] && l.Catalog && strings.EqualFold(strings.TrimSpace(l.Category), strings.TrimSpace(cat)) {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return false
+		}
+		used[found] = true
+		idx = append(idx, found)
+	}
+
+	currency := lines[idx[0]].Final.Currency
+	parts := make([]Money, len(idx))
+	for k, i := range idx {
+		m, err := Sum(conv, currency, lines[i].Final)
+		if err != nil {
+			return false
+		}
+		parts[k] = m
+	}
+	base, _ := Sum(nil, currency, parts...)
+	total, ok := promoDiscount(p, base, conv)
+	if !ok {
+		return false
+	}
+
+	// Mutanosib taqsimlash; yaxlitlash qoldig'i oxirgi qatorga. Ulushlar avval to'liq
+	// hisoblanadi: birortasi o'girilmasa bundle qatorlarni o'zgartirmasdan qo'llanmaydi
+	rule := RuleFor(currency)
+	remaining := total.Amount
+	shares := make([]Money, len(idx))
+	for k, i := range idx {
+		share := remaining
+		if k < len(idx)-1 {
+			share = rule.round(float64(total.Amount) * float64(parts[k].Amount) / float64(base.Amount))
+			if share > remaining {
+				share = remaining
+			}
+		}
+		remaining -= share
+		d := Money{Amount: share, Currency: currency}
+		if !strings.EqualFold(lines[i].Final.Currency, currency) {
+			converted, err := conv.Convert(d, lines[i].Final.Currency)
+			if err != nil {
+				return false
+			}
+			d = converted
+		}
+		if d.Amount > lines[i].Final.Amount {
+			d.Amount = lines[i].Final.Amount
+		}
+		shares[k] = d
+	}
+	for k, i := range idx {
+		lines[i].Discount.Amount += shares[k].Amount
+		lines[i].Final.Amount -= shares[k].Amount
+		lines[i].Promos = append(lines[i].Promos, p.ID)
+	}
+	return true
+}
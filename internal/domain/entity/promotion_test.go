@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// oneWayConverter faqat UZS -> USD o'giradi (1 USD = 12500 so'm)
+type oneWayConverter struct{}
+
+func (oneWayConverter) Convert(m Money, to string) (Money, error) {
+	if m.Currency == CurrencyUZS && to == CurrencyUSD {
+		return NewMoney(m.Major()/12500, CurrencyUSD), nil
+	}
+	return Money{}, fmt.Errorf("%w: %s -> %s", ErrUnsupportedCurrency, m.Currency, to)
+}
+
+// TestApplyBundleConversionFailure - ulushni qator valyutasiga o'girib bo'lmasa bundle
+// hech bir qatorni o'zgartirmaydi va qo'llangan deb hisoblanmaydi
+func TestApplyBundleConversionFailure(t *testing.T) {
+	promos := []Promotion{{ID: "combo", Kind: PromoFixed, Value: 30, Scope: ScopeBundle, Bundle: []string{"CPU", "Motherboard"}}}
+	lines := []PriceLine{
+		{Name: "AMD Ryzen 5 7600", Category: "CPU", Price: NewMoney(200, CurrencyUSD), Catalog: true},
+		{Name: "MSI B650 Tomahawk", Category: "Motherboard", Price: NewMoney(1250000, CurrencyUZS), Catalog: true},
+	}
+	quote, err := ApplyPromotions(promos, lines, "", time.Now(), oneWayConverter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quote.Applied) != 0 || !quote.Discount.IsZero() {
+		t.Fatalf("bundle qo'llanmasligi kerak: applied=%v discount=%s", quote.Applied, quote.Discount)
+	}
+	for _, l := range quote.Lines {
+		if l.Final != l.Price || len(l.Promos) != 0 {
+			t.Fatalf("qator o'zgardi: %+v", l)
+		}
+	}
+}
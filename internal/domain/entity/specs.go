@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+)
+
+// Product.Specs uchun kanonik kalitlar. Excel ustunlari har xil nomlanadi (Socket, Сокет, Soket...),
+// shu sabab o'qishda Spec() sinonimlar bo'yicha ham qidiradi.
+const (
+	SpecSocket          = "socket"
+	SpecChipset         = "chipset"
+	SpecMemoryType      = "memory_type"
+	SpecFormFactor      = "form_factor"
+	SpecPCIe            = "pcie"
+	SpecTDP             = "tdp"
+	SpecWattage         = "wattage"
+	SpecConnectors      = "connectors"
+	SpecLength          = "length_mm"
+	SpecHeight          = "height_mm"
+	SpecMaxGPULength    = "max_gpu_length_mm"
+	SpecMaxCoolerHeight = "max_cooler_height_mm"
+	SpecRadiator        = "radiator"
+	SpecCores           = "cores"
+	SpecThreads         = "threads"
+	SpecGPUChip         = "gpu_chip"
+	SpecVRAM            = "vram_gb"
+	SpecCapacity        = "capacity_gb"
+	SpecSpeed           = "speed_mhz"
+	SpecInterface       = "interface"
+	SpecEfficiency      = "efficiency"
+	SpecScreenSize      = "screen_in"
+	SpecRefreshRate     = "refresh_hz"
+	SpecPanel           = "panel"
+	SpecResolution      = "resolution"
+	// Noutbuk va tayyor kompyuterlar (butun tizim)
+	SpecCPU       = "cpu"
+	SpecRAMSize   = "ram_gb"
+	SpecStorageGB = "storage_gb"
+	SpecWeight    = "weight_kg"
+	SpecBattery   = "battery_wh"
+)
+
+// specAliases - Excel sarlavhalarida uchraydigan sinonimlar (kichik harfda)
+var specAliases = map[string][]string{
+	SpecSocket:          {"socket", "сокет", "soket"},
+	SpecChipset:         {"chipset", "чипсет"},
+	SpecMemoryType:      {"memory type", "memory_type", "ram type", "тип памяти", "xotira turi"},
+	SpecFormFactor:      {"form factor", "form_factor", "форм-фактор", "форм фактор"},
+	SpecPCIe:            {"pcie", "pci-e", "pci express"},
+	SpecTDP:             {"tdp", "tbp", "tgp"},
+	SpecWattage:         {"wattage", "мощность", "quvvat", "watt"},
+	SpecConnectors:      {"connectors", "разъем", "разъём", "ulagich"},
+	SpecLength:          {"length_mm", "length", "длина", "uzunlik"},
+	SpecHeight:          {"height_mm", "height", "высота", "balandlik"},
+	SpecMaxGPULength:    {"max_gpu_length_mm", "max gpu", "gpu length", "длина видеокарты", "videokarta uzunligi"},
+	SpecMaxCoolerHeight: {"max_cooler_height_mm", "cooler height", "высота кулера", "sovutgich balandligi"},
+	SpecRadiator:        {"radiator", "радиатор"},
+	SpecCores:           {"cores", "ядер", "ядра", "yadro"},
+	SpecThreads:         {"threads", "потоков", "потоки", "oqim"},
+	SpecGPUChip:         {"gpu_chip", "gpu chip", "графический процессор", "chip"},
+	SpecVRAM:            {"vram_gb", "vram", "видеопамят", "video memory"},
+	SpecCapacity:        {"capacity_gb", "capacity", "объем", "объём", "hajm", "sig'im"},
+	SpecSpeed:           {"speed_mhz", "frequency", "частота", "chastota"},
+	SpecInterface:       {"interface", "интерфейс"},
+	SpecEfficiency:      {"efficiency", "сертификат", "80 plus", "80+"},
+	SpecScreenSize:      {"screen_in", "diagonal", "диагональ", "screen size", "ekran"},
+	SpecRefreshRate:     {"refresh_hz", "refresh", "hz", "герц", "частота обновления"},
+	SpecPanel:           {"panel", "матрица", "matritsa"},
+	SpecResolution:      {"resolution", "разрешение", "ruxsat"},
+	SpecCPU:             {"cpu", "processor", "процессор", "protsessor"},
+	SpecRAMSize:         {"ram_gb", "ram", "озу", "оперативная память", "operativ xotira"},
+	SpecStorageGB:       {"storage_gb", "storage", "ssd", "накопитель", "disk"},
+	SpecWeight:          {"weight_kg", "weight", "вес", "og'irlik", "ogirlik"},
+	SpecBattery:         {"battery_wh", "battery", "батарея", "аккумулятор", "batareya"},
+}
+
+// Spec kanonik kalit yoki uning sinonimi bo'yicha qiymat; topilmasa bo'sh satr.
+// Aniq kalit birinchi, keyin sarlavhada sinonim qatnashgan ustun olinadi.
+func (p Product) Spec(key string) string {
+	if len(p.Specs) == 0 {
+		return ""
+	}
+	if v, ok := p.Specs[key]; ok {
+		return strings.TrimSpace(v)
+	}
+	aliases := specAliases[key]
+	for header, v := range p.Specs {
+		h := strings.ToLower(strings.TrimSpace(header))
+		for _, alias := range aliases {
+			if h == alias {
+				return strings.TrimSpace(v)
+			}
+		}
+	}
+	headers := make([]string, 0, len(p.Specs))
+	for header := range p.Specs {
+		headers = append(headers, header)
+	}
+	sort.Strings(headers)
+	for _, header := range headers {
+		h := strings.ToLower(header)
+		for _, alias := range aliases {
+			if strings.Contains(h, alias) {
+				return strings.TrimSpace(p.Specs[header])
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// PromotionRepository aksiyalar bilan ishlash uchun interface
+type PromotionRepository interface {
+	// List barcha aksiyalarni olish
+	List(ctx context.Context) ([]entity.Promotion, error)
+
+	// Save aksiyani saqlash (bir xil ID bo'lsa almashtiriladi)
+	Save(ctx context.Context, promo entity.Promotion) error
+
+	// Delete aksiyani o'chirish
+	Delete(ctx context.Context, id string) error
+
+	// AddUses qo'llangan aksiyalar foydalanish sonini oshirish. Limit tekshiruvi va
+	// oshirish bitta atomar amal: birorta aksiya limiti tugagan bo'lsa hech biri
+	// oshirilmaydi va entity.ErrPromotionExhausted qaytadi
+	AddUses(ctx context.Context, ids []string) error
+
+	// ReleaseUses bekor qilingan buyurtma aksiyalari foydalanish sonini qaytarish
+	ReleaseUses(ctx context.Context, ids []string) error
+}
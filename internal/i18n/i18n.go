@@ -0,0 +1,384 @@
+// Package i18n - lokalizatsiya: har bir til uchun alohida katalog (locales/*.json),
+// kalitlar, plural shakllar va {placeholder} lar.
+//
+// Katalog formati:
+//
+//	{
+//	  "welcome.hello": "👋 Salom",
+//	  "cart.header": {"one": "{count} ta mahsulot", "other": "{count} ta mahsulot"}
+//	}
+//
+// Kalit topilmasa tilning fallback zanjiri bo'yicha qidiriladi
+// (masalan uz-Cyrl -> uz, en -> ru -> uz), oxirida kalitning o'zi qaytadi.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Qo'llab-quvvatlanadigan tillar
+const (
+	LangUz     = "uz"
+	LangUzCyrl = "uz-Cyrl"
+	LangRu     = "ru"
+	LangEn     = "en"
+
+	// DefaultLang - hech narsa topilmasa ishlatiladigan til (va katalog etaloni)
+	DefaultLang = LangUz
+)
+
+// Supported - barcha tillar (tanlov menyusidagi tartibda)
+var Supported = []string{LangUz, LangUzCyrl, LangRu, LangEn}
+
+// fallbacks - kalit tilda topilmasa qayerdan olinadi
+var fallbacks = map[string][]string{
+	LangUzCyrl: {LangUz},
+	LangEn:     {LangRu, LangUz},
+	LangRu:     {LangUz},
+}
+
+var displayNames = map[string]string{
+	LangUz:     "🇺🇿 O'zbekcha",
+	LangUzCyrl: "🇺🇿 Ўзбекча",
+	LangRu:     "🇷🇺 Русский",
+	LangEn:     "🇬🇧 English",
+}
+
+//go:embed locales/*.json
+var embedded embed.FS
+
+// Message - katalogdagi bitta yozuv: oddiy matn yoki plural shakllar
+type Message struct {
+	Text  string
+	Forms map[string]string // one, few, many, other
+}
+
+// UnmarshalJSON string yoki {"one": ..., "other": ...} ni qabul qiladi
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		m.Text = s
+		return nil
+	}
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("message must be string or plural object: %w", err)
+	}
+	for form := range forms {
+		if !validPluralForm(form) {
+			return fmt.Errorf("unknown plural form %q", form)
+		}
+	}
+	m.Forms = forms
+	return nil
+}
+
+// IsPlural plural shakllarga ega bo'lsa true
+func (m Message) IsPlural() bool { return len(m.Forms) > 0 }
+
+// Catalog - bitta til uchun kalit -> xabar
+type Catalog map[string]Message
+
+// Bundle - barcha til kataloglari
+type Bundle struct {
+	mu       sync.RWMutex
+	catalogs map[string]Catalog
+}
+
+// NewBundle bo'sh bundle
+func NewBundle() *Bundle {
+	return &Bundle{catalogs: make(map[string]Catalog)}
+}
+
+// Load dir ichidagi <lang>.json fayllarni o'qiydi
+func Load(fsys fs.FS, dir string) (*Bundle, error) {
+	b := NewBundle()
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		lang := strings.TrimSuffix(e.Name(), ".json")
+		if err := b.AddJSON(lang, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+	}
+	return b, nil
+}
+
+// AddJSON katalogni qo'shadi; mavjud kalitlar ustiga yoziladi
+func (b *Bundle) AddJSON(lang string, data []byte) error {
+	var cat Catalog
+	if err := json.Unmarshal(data, &cat); err != nil {
+		return err
+	}
+	lang = Normalize(lang)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	dst, ok := b.catalogs[lang]
+	if !ok {
+		dst = make(Catalog, len(cat))
+		b.catalogs[lang] = dst
+	}
+	for k, v := range cat {
+		dst[k] = v
+	}
+	return nil
+}
+
+// LoadOverridesDir diskdagi <lang>.json fayllarni embedded kataloglar ustiga qo'shadi.
+// Papka bo'lmasa xato qaytarmaydi.
+func (b *Bundle) LoadOverridesDir(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	loaded := 0
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return loaded, err
+		}
+		if err := b.AddJSON(strings.TrimSuffix(e.Name(), ".json"), data); err != nil {
+			return loaded, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		loaded++
+	}
+	return loaded, nil
+}
+
+// Catalog til katalogining nusxasi (testlar va tekshiruvlar uchun)
+func (b *Bundle) Catalog(lang string) (Catalog, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	cat, ok := b.catalogs[lang]
+	if !ok {
+		return nil, false
+	}
+	out := make(Catalog, len(cat))
+	for k, v := range cat {
+		out[k] = v
+	}
+	return out, true
+}
+
+// Langs yuklangan tillar ro'yxati
+func (b *Bundle) Langs() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]string, 0, len(b.catalogs))
+	for lang := range b.catalogs {
+		out = append(out, lang)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// lookup kalitni til va uning fallback zanjiri bo'yicha qidiradi
+func (b *Bundle) lookup(lang, key string) (Message, string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, l := range chain(lang) {
+		if cat, ok := b.catalogs[l]; ok {
+			if msg, ok := cat[key]; ok {
+				return msg, l, true
+			}
+		}
+	}
+	return Message{}, "", false
+}
+
+// Has kalit biror tilda mavjudmi
+func (b *Bundle) Has(key string) bool {
+	_, _, ok := b.lookup(DefaultLang, key)
+	return ok
+}
+
+// T oddiy xabar. args - "nom", qiymat juftliklari ({nom} o'rniga qo'yiladi).
+func (b *Bundle) T(lang, key string, args ...any) string {
+	msg, _, ok := b.lookup(Normalize(lang), key)
+	if !ok {
+		return key
+	}
+	text := msg.Text
+	if msg.IsPlural() {
+		text = msg.Forms["other"]
+	}
+	return format(text, args)
+}
+
+// Plural n ga mos shaklni tanlaydi; {count} avtomatik qo'yiladi.
+func (b *Bundle) Plural(lang, key string, n int, args ...any) string {
+	lang = Normalize(lang)
+	msg, found, ok := b.lookup(lang, key)
+	if !ok {
+		return key
+	}
+	args = append([]any{"count", n}, args...)
+	if !msg.IsPlural() {
+		return format(msg.Text, args)
+	}
+	form := PluralForm(found, n)
+	text, ok := msg.Forms[form]
+	if !ok {
+		text = msg.Forms["other"]
+	}
+	return format(text, args)
+}
+
+func format(text string, args []any) string {
+	if len(args) < 2 || !strings.Contains(text, "{") {
+		return text
+	}
+	pairs := make([]string, 0, len(args))
+	for i := 0; i+1 < len(args); i += 2 {
+		name, ok := args[i].(string)
+		if !ok {
+			continue
+		}
+		pairs = append(pairs, "{"+name+"}", toString(args[i+1]))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+func toString(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case int:
+		return strconv.Itoa(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case fmt.Stringer:
+		return x.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Placeholders matndagi {nom} lar ro'yxati (tartiblangan, takrorlarsiz)
+func Placeholders(text string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for {
+		start := strings.Index(text, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start:], "}")
+		if end < 0 {
+			break
+		}
+		name := text[start+1 : start+end]
+		if name != "" && !strings.ContainsAny(name, " \n{\"") && !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+		text = text[start+end+1:]
+	}
+	sort.Strings(out)
+	return out
+}
+
+func chain(lang string) []string {
+	out := []string{lang}
+	out = append(out, fallbacks[lang]...)
+	if lang != DefaultLang && !contains(out, DefaultLang) {
+		out = append(out, DefaultLang)
+	}
+	return out
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize til kodini qo'llab-quvvatlanadigan ko'rinishga keltiradi (noma'lum -> uz)
+func Normalize(lang string) string {
+	if l, ok := Detect(lang); ok {
+		return l
+	}
+	return DefaultLang
+}
+
+// Detect Telegram LanguageCode (IETF) dan tilni aniqlaydi
+func Detect(code string) (string, bool) {
+	c := strings.ToLower(strings.TrimSpace(code))
+	c = strings.ReplaceAll(c, "_", "-")
+	switch {
+	case c == "":
+		return "", false
+	case c == "uz-cyrl" || strings.HasPrefix(c, "uz-cyrl-") || c == "uzc":
+		return LangUzCyrl, true
+	case c == "uz" || strings.HasPrefix(c, "uz-"):
+		return LangUz, true
+	case c == "ru" || strings.HasPrefix(c, "ru-"):
+		return LangRu, true
+	case c == "en" || strings.HasPrefix(c, "en-"):
+		return LangEn, true
+	}
+	return "", false
+}
+
+// DisplayName til nomi (bayroq bilan)
+func DisplayName(lang string) string {
+	if name, ok := displayNames[Normalize(lang)]; ok {
+		return name
+	}
+	return lang
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultBundle *Bundle
+)
+
+// Default embedded kataloglardan yuklangan bundle
+func Default() *Bundle {
+	defaultOnce.Do(func() {
+		b, err := Load(embedded, "locales")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: embedded locales: %v", err))
+		}
+		defaultBundle = b
+	})
+	return defaultBundle
+}
+
+// T - Default().T qisqartmasi
+func T(lang, key string, args ...any) string {
+	return Default().T(lang, key, args...)
+}
+
+// Plural - Default().Plural qisqartmasi
+func Plural(lang, key string, n int, args ...any) string {
+	return Default().Plural(lang, key, n, args...)
+}
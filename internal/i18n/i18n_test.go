@@ -0,0 +1,144 @@
+package i18n
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestCatalogsComplete - har bir katalog etalon (uz) bilan bir xil kalitlarga,
+// plural shakllarga va placeholderlarga ega bo'lishi kerak.
+func TestCatalogsComplete(t *testing.T) {
+	b := Default()
+	ref, ok := b.Catalog(DefaultLang)
+	if !ok {
+		t.Fatalf("etalon katalog (%s) topilmadi", DefaultLang)
+	}
+
+	for _, lang := range Supported {
+		cat, ok := b.Catalog(lang)
+		if !ok {
+			t.Errorf("%s: katalog yo'q", lang)
+			continue
+		}
+		var missing, extra []string
+		for key := range ref {
+			if _, ok := cat[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		for key := range cat {
+			if _, ok := ref[key]; !ok {
+				extra = append(extra, key)
+			}
+		}
+		sort.Strings(missing)
+		sort.Strings(extra)
+		if len(missing) > 0 {
+			t.Errorf("%s: yetishmayotgan kalitlar: %v", lang, missing)
+		}
+		if len(extra) > 0 {
+			t.Errorf("%s: etalonda yo'q kalitlar: %v", lang, extra)
+		}
+
+		for key, refMsg := range ref {
+			msg, ok := cat[key]
+			if !ok {
+				continue
+			}
+			if refMsg.IsPlural() != msg.IsPlural() {
+				t.Errorf("%s: %s plural/oddiy turi etalonga mos emas", lang, key)
+				continue
+			}
+			if msg.IsPlural() {
+				for _, form := range RequiredPluralForms(lang) {
+					if _, ok := msg.Forms[form]; !ok {
+						t.Errorf("%s: %s uchun %q shakli yo'q", lang, key, form)
+					}
+				}
+			}
+			if want, got := placeholdersOf(refMsg), placeholdersOf(msg); !reflect.DeepEqual(want, got) {
+				t.Errorf("%s: %s placeholderlari %v, etalonda %v", lang, key, got, want)
+			}
+			if msg.Text == "" && !msg.IsPlural() {
+				t.Errorf("%s: %s bo'sh", lang, key)
+			}
+		}
+	}
+}
+
+func placeholdersOf(m Message) []string {
+	if !m.IsPlural() {
+		return Placeholders(m.Text)
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, text := range m.Forms {
+		for _, p := range Placeholders(text) {
+			if !seen[p] {
+				seen[p] = true
+				out = append(out, p)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestPluralAndFallback(t *testing.T) {
+	b := NewBundle()
+	if err := b.AddJSON("uz", []byte(`{"items": {"one": "{count} ta", "other": "{count} ta"}, "only.uz": "faqat uz"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddJSON("ru", []byte(`{"items": {"one": "{count} товар", "few": "{count} товара", "many": "{count} товаров", "other": "{count} товара"}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[int]string{1: "1 товар", 3: "3 товара", 5: "5 товаров", 11: "11 товаров", 21: "21 товар", 22: "22 товара"}
+	for n, want := range cases {
+		if got := b.Plural("ru", "items", n); got != want {
+			t.Errorf("ru %d: %q, kutilgan %q", n, got, want)
+		}
+	}
+	// en katalog yo'q -> ru -> uz
+	if got := b.Plural("en", "items", 2); got != "2 товара" {
+		t.Errorf("en fallback: %q", got)
+	}
+	if got := b.T("uz-Cyrl", "only.uz"); got != "faqat uz" {
+		t.Errorf("uz-Cyrl fallback: %q", got)
+	}
+	if got := b.T("ru", "no.such.key"); got != "no.such.key" {
+		t.Errorf("noma'lum kalit: %q", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	cases := map[string]string{"en-US": LangEn, "ru": LangRu, "uz": LangUz, "uz-Cyrl": LangUzCyrl, "UZ_cyrl": LangUzCyrl}
+	for code, want := range cases {
+		if got, ok := Detect(code); !ok || got != want {
+			t.Errorf("Detect(%q) = %q, %v", code, got, ok)
+		}
+	}
+	if _, ok := Detect("de"); ok {
+		t.Errorf("de aniqlanmasligi kerak")
+	}
+	if got := Normalize("de"); got != DefaultLang {
+		t.Errorf("Normalize(de) = %q", got)
+	}
+}
+
+func TestToCyrillic(t *testing.T) {
+	cases := map[string]string{
+		"O'zbekiston":                       "Ўзбекистон",
+		"Bekor qilish: /cancel":             "Бекор қилиш: /cancel",
+		"Jami %d ta mahsulot":               "Жами %d та маҳсулот",
+		"Ma'lumot: RTX 4070":                "Маълумот: RTX 4070",
+		"Yetkazib berish yo'q, choy shirin": "Етказиб бериш йўқ, чой ширин",
+		"eslatma":                           "эслатма",
+	}
+	for in, want := range cases {
+		if got := ToCyrillic(in); got != want {
+			t.Errorf("ToCyrillic(%q) = %q, kutilgan %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package i18n
+
+// CLDR plural kategoriyalari (butun sonlar uchun yetarli qismi)
+var pluralForms = []string{"one", "few", "many", "other"}
+
+func validPluralForm(form string) bool {
+	for _, f := range pluralForms {
+		if f == form {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredPluralForms til uchun katalogda bo'lishi shart bo'lgan shakllar
+func RequiredPluralForms(lang string) []string {
+	if Normalize(lang) == LangRu {
+		return []string{"one", "few", "many", "other"}
+	}
+	return []string{"one", "other"}
+}
+
+// PluralForm n uchun tilning plural kategoriyasi
+func PluralForm(lang string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch Normalize(lang) {
+	case LangRu:
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	default:
+		// uz, uz-Cyrl, en
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}
+}
@@ -0,0 +1,220 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+)
+
+// O'zbek lotin -> kirill transliteratsiyasi (katalogga ko'chirilmagan matnlar uchun).
+// Komandalar (/start), URL, fmt verb (%s), `kod`, raqamli va KATTA harfli
+// qisqartmalar (RTX, GB, 4070Ti) o'zgarishsiz qoladi.
+
+var latinToCyrillic = map[rune]string{
+	'a': "а", 'b': "б", 'd': "д", 'e': "е", 'f': "ф", 'g': "г", 'h': "ҳ",
+	'i': "и", 'j': "ж", 'k': "к", 'l': "л", 'm': "м", 'n': "н", 'o': "о",
+	'p': "п", 'q': "қ", 'r': "р", 's': "с", 't': "т", 'u': "у", 'v': "в",
+	'x': "х", 'y': "й", 'z': "з", 'c': "с", 'w': "в",
+}
+
+func isApostrophe(r rune) bool {
+	switch r {
+	case '\'', 'ʻ', 'ʼ', '‘', '’', '`':
+		return true
+	}
+	return false
+}
+
+func isLatinLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// ToCyrillic o'zbekcha lotin matnni kirillga o'giradi
+func ToCyrillic(text string) string {
+	if text == "" {
+		return text
+	}
+	runes := []rune(text)
+	var sb strings.Builder
+	sb.Grow(len(text) * 2)
+
+	inCode := false
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		// `kod` bloklari o'zgarmaydi
+		if r == '`' {
+			inCode = !inCode
+			sb.WriteRune(r)
+			i++
+			continue
+		}
+		if inCode {
+			sb.WriteRune(r)
+			i++
+			continue
+		}
+
+		// fmt verb: %s, %d, %.2f, %-10s ...
+		if r == '%' {
+			sb.WriteRune(r)
+			i++
+			for i < len(runes) && strings.ContainsRune("+-# 0123456789.*", runes[i]) {
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i < len(runes) && unicode.IsLetter(runes[i]) {
+				sb.WriteRune(runes[i])
+				i++
+			}
+			continue
+		}
+
+		// {placeholder}
+		if r == '{' {
+			if end := indexRune(runes[i:], '}'); end > 0 {
+				sb.WriteString(string(runes[i : i+end+1]))
+				i += end + 1
+				continue
+			}
+		}
+
+		// Komanda, URL, @username - bo'shliqqacha o'zgarmaydi
+		if (r == '/' || r == '@') && (i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '(') ||
+			hasPrefixFold(runes[i:], "http://") || hasPrefixFold(runes[i:], "https://") {
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				sb.WriteRune(runes[i])
+				i++
+			}
+			continue
+		}
+
+		if !isLatinLetter(r) {
+			sb.WriteRune(r)
+			i++
+			continue
+		}
+
+		// So'zni ajratamiz (harflar, raqamlar va so'z ichidagi tutuq belgisi)
+		j := i
+		for j < len(runes) {
+			c := runes[j]
+			if isLatinLetter(c) || unicode.IsDigit(c) || c == '_' {
+				j++
+				continue
+			}
+			if isApostrophe(c) && c != '`' && j+1 < len(runes) && j > i && isLatinLetter(runes[j-1]) &&
+				(isLatinLetter(runes[j+1]) || strings.ContainsRune("oOgG", runes[j-1])) {
+				j++
+				continue
+			}
+			if isApostrophe(c) && c != '`' && j > i && strings.ContainsRune("oOgG", runes[j-1]) {
+				// so'z oxiridagi o'/g' (masalan "bo'")
+				j++
+				continue
+			}
+			break
+		}
+		word := runes[i:j]
+		if keepVerbatim(word) {
+			sb.WriteString(string(word))
+		} else {
+			sb.WriteString(transliterateWord(word))
+		}
+		i = j
+	}
+	return sb.String()
+}
+
+func indexRune(rs []rune, target rune) int {
+	for i, r := range rs {
+		if r == target {
+			return i
+		}
+		if r == ' ' || r == '\n' {
+			return -1
+		}
+	}
+	return -1
+}
+
+func hasPrefixFold(rs []rune, prefix string) bool {
+	p := []rune(prefix)
+	if len(rs) < len(p) {
+		return false
+	}
+	return strings.EqualFold(string(rs[:len(p)]), prefix)
+}
+
+// keepVerbatim - raqamli, pastki chiziqli yoki qisqartma (RTX, GB) so'zlar
+func keepVerbatim(word []rune) bool {
+	upper := 0
+	letters := 0
+	for _, r := range word {
+		if unicode.IsDigit(r) || r == '_' {
+			return true
+		}
+		if isLatinLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 2 && upper == letters
+}
+
+func transliterateWord(word []rune) string {
+	var sb strings.Builder
+	n := len(word)
+	lower := func(k int) rune { return unicode.ToLower(word[k]) }
+	for k := 0; k < n; k++ {
+		r := word[k]
+		lr := lower(k)
+		upper := unicode.IsUpper(r)
+		next := rune(0)
+		if k+1 < n {
+			next = lower(k + 1)
+		}
+		out := ""
+		step := 1
+		switch {
+		case (lr == 'o' || lr == 'g') && k+1 < n && isApostrophe(word[k+1]):
+			if lr == 'o' {
+				out = "ў"
+			} else {
+				out = "ғ"
+			}
+			step = 2
+		case lr == 's' && next == 'h':
+			out, step = "ш", 2
+		case lr == 'c' && next == 'h':
+			out, step = "ч", 2
+		case lr == 'y' && next == 'o' && !(k+2 < n && isApostrophe(word[k+2])):
+			out, step = "ё", 2
+		case lr == 'y' && next == 'u':
+			out, step = "ю", 2
+		case lr == 'y' && next == 'a':
+			out, step = "я", 2
+		case lr == 'y' && next == 'e':
+			out, step = "е", 2
+		case lr == 'e' && k == 0:
+			out = "э"
+		case isApostrophe(r):
+			out = "ъ"
+		default:
+			if c, ok := latinToCyrillic[lr]; ok {
+				out = c
+			} else {
+				out = string(r)
+			}
+		}
+		if upper {
+			rs := []rune(out)
+			rs[0] = unicode.ToUpper(rs[0])
+			out = string(rs)
+		}
+		sb.WriteString(out)
+		k += step - 1
+	}
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package exrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Converter USD <-> UZS o'girish; kurs har chaqiruvda rate() dan olinadi
+// (joriy kurs yoki buyurtmaga yozib qo'yilgan snapshot). Natija maqsad valyuta qoidasi bo'yicha yaxlitlanadi.
+type Converter struct {
+	rate func() float64
+}
+
+func NewConverter(rate func() float64) *Converter {
+	return &Converter{rate: rate}
+}
+
+// FixedConverter aniq bir kurs bilan (masalan buyurtma snapshot'i)
+func FixedConverter(rate float64) *Converter {
+	return NewConverter(func() float64 { return rate })
+}
+
+func (c *Converter) Convert(m entity.Money, to string) (entity.Money, error) {
+	from := strings.ToUpper(m.Currency)
+	to = strings.ToUpper(to)
+	if from == to {
+		return m, nil
+	}
+	rate := 0.0
+	if c != nil && c.rate != nil {
+		rate = c.rate()
+	}
+	if rate <= 0 {
+		return m, fmt.Errorf("%w: %s -> %s", ErrNoRate, from, to)
+	}
+	switch {
+	case from == entity.CurrencyUSD && to == entity.CurrencyUZS:
+		return entity.NewMoney(m.Major()*rate, to), nil
+	case from == entity.CurrencyUZS && to == entity.CurrencyUSD:
+		return entity.NewMoney(m.Major()/rate, to), nil
+	}
+	return m, fmt.Errorf("%w: %s -> %s", entity.ErrUnsupportedCurrency, from, to)
+}
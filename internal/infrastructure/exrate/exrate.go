@@ -0,0 +1,110 @@
+// Package exrate USD -> UZS kursi manbalari (qo'lda, JSON HTTP endpoint, lokal fayl).
+//
+// Manba faqat kursni o'qiydi; jadval, tarix va eskirish nazorati chaqiruvchida.
+package exrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoRate    = errors.New("exrate: rate not available")
+	ErrBadFormat = errors.New("exrate: unexpected response format")
+)
+
+// Rate bitta o'qilgan kurs: 1 USD necha so'm
+type Rate struct {
+	Value     float64   `json:"value"`
+	Source    string    `json:"source"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// Source kurs manbai
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context) (Rate, error)
+}
+
+// ManualSource admin kiritgan kurs (/val); Fetch oxirgi kiritilgan qiymatni qaytaradi
+type ManualSource struct {
+	mu   sync.RWMutex
+	rate Rate
+}
+
+const ManualName = "manual"
+
+func NewManualSource() *ManualSource { return &ManualSource{} }
+
+func (s *ManualSource) Name() string { return ManualName }
+
+// Set yangi qo'lda kiritilgan kursni saqlaydi
+func (s *ManualSource) Set(value float64, at time.Time) {
+	s.mu.Lock()
+	s.rate = Rate{Value: value, Source: ManualName, FetchedAt: at}
+	s.mu.Unlock()
+}
+
+func (s *ManualSource) Fetch(_ context.Context) (Rate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.rate.Value <= 0 {
+		return Rate{}, ErrNoRate
+	}
+	return s.rate, nil
+}
+
+// ExtractPath JSON ichidan nuqta bilan ajratilgan yo'l bo'yicha kursni oladi.
+// Massiv indekslari raqam bilan yoziladi: "0.Rate" (CBU), "rates.UZS".
+// Qiymat son yoki son ko'rinishidagi satr bo'lishi mumkin ("12 650,33" ham).
+func ExtractPath(data []byte, path string) (float64, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrBadFormat, err)
+	}
+	for _, part := range strings.Split(strings.Trim(path, "."), ".") {
+		if part == "" {
+			continue
+		}
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[part]
+			if !ok {
+				return 0, fmt.Errorf("%w: key %q not found", ErrBadFormat, part)
+			}
+			v = next
+		case []interface{}:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return 0, fmt.Errorf("%w: bad index %q", ErrBadFormat, part)
+			}
+			v = node[idx]
+		default:
+			return 0, fmt.Errorf("%w: cannot descend into %q", ErrBadFormat, part)
+		}
+	}
+	var val float64
+	switch x := v.(type) {
+	case float64:
+		val = x
+	case string:
+		clean := strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(x), " ", ""), ",", ".")
+		f, err := strconv.ParseFloat(clean, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q is not a number", ErrBadFormat, x)
+		}
+		val = f
+	default:
+		return 0, fmt.Errorf("%w: value is %T", ErrBadFormat, v)
+	}
+	if val <= 0 {
+		return 0, ErrNoRate
+	}
+	return val, nil
+}
@@ -0,0 +1,77 @@
+package exrate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// TestSources - CBU formatidagi HTTP javob, JSON/oddiy fayl va bo'sh qo'lda kurs
+func TestSources(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"Ccy":"USD","Rate":"12650.33","Date":"17.10.2026"}]`))
+	}))
+	defer srv.Close()
+
+	r, err := NewHTTPSource(srv.URL, DefaultCBUPath).Fetch(ctx)
+	if err != nil || r.Value != 12650.33 || r.Source != HTTPName {
+		t.Fatalf("http: %+v err=%v", r, err)
+	}
+
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "rate.txt")
+	_ = os.WriteFile(plain, []byte("12 700\n"), 0o600)
+	if r, err := NewFileSource(plain, "").Fetch(ctx); err != nil || r.Value != 12700 {
+		t.Fatalf("plain file: %+v err=%v", r, err)
+	}
+	js := filepath.Join(dir, "rate.json")
+	_ = os.WriteFile(js, []byte(`{"rates":{"UZS":12800.5}}`), 0o600)
+	if r, err := NewFileSource(js, "rates.UZS").Fetch(ctx); err != nil || r.Value != 12800.5 {
+		t.Fatalf("json file: %+v err=%v", r, err)
+	}
+	if _, err := NewFileSource(js, "rates.EUR").Fetch(ctx); err == nil {
+		t.Fatalf("yo'q kalit xato berishi kerak")
+	}
+
+	if _, err := NewManualSource().Fetch(ctx); err != ErrNoRate {
+		t.Fatalf("bo'sh manual: %v", err)
+	}
+}
+
+// TestConverter - USD<->UZS o'girish va valyuta bo'yicha yaxlitlash qoidalari
+func TestConverter(t *testing.T) {
+	if m := entity.NewMoney(1499.999, entity.CurrencyUSD); m.Amount != 150000 {
+		t.Fatalf("USD cent yaxlitlash: %+v", m)
+	}
+	if m := entity.NewMoney(12650.5, entity.CurrencyUZS); m.Amount != 1265100 || m.String() != "12 651 so'm" {
+		t.Fatalf("UZS butun so'm: %+v %s", m, m)
+	}
+
+	conv := FixedConverter(12650.33)
+	uzs, err := conv.Convert(entity.NewMoney(100, entity.CurrencyUSD), entity.CurrencyUZS)
+	if err != nil || uzs.Amount != 126503300 {
+		t.Fatalf("USD->UZS: %+v %v", uzs, err)
+	}
+	usd, err := conv.Convert(entity.NewMoney(1265033, entity.CurrencyUZS), entity.CurrencyUSD)
+	if err != nil || usd.Amount != 10000 {
+		t.Fatalf("UZS->USD: %+v %v", usd, err)
+	}
+
+	total, err := entity.Sum(conv, entity.CurrencyUSD, entity.NewMoney(50, entity.CurrencyUSD), entity.NewMoney(632516.5, entity.CurrencyUZS))
+	if err != nil || total.String() != "100.00$" {
+		t.Fatalf("aralash yig'indi: %s %v", total, err)
+	}
+	if _, err := entity.Sum(nil, entity.CurrencyUSD, entity.NewMoney(1, entity.CurrencyUZS)); !errors.Is(err, entity.ErrUnsupportedCurrency) {
+		t.Fatalf("kurssiz o'girish xato berishi kerak: %v", err)
+	}
+	if _, err := FixedConverter(0).Convert(entity.NewMoney(1, entity.CurrencyUSD), entity.CurrencyUZS); !errors.Is(err, ErrNoRate) {
+		t.Fatalf("kurs yo'q: %v", err)
+	}
+}
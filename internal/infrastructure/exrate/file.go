@@ -0,0 +1,42 @@
+package exrate
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileSource lokal fayldan kurs (test va offline muhit uchun).
+// Fayl oddiy son ("12650.5") yoki JSON bo'lishi mumkin; JSON uchun Path ishlatiladi.
+type FileSource struct {
+	Path     string
+	JSONPath string
+}
+
+const FileName = "file"
+
+func NewFileSource(path, jsonPath string) *FileSource {
+	return &FileSource{Path: strings.TrimSpace(path), JSONPath: strings.TrimSpace(jsonPath)}
+}
+
+func (s *FileSource) Name() string { return FileName }
+
+func (s *FileSource) Fetch(_ context.Context) (Rate, error) {
+	b, err := os.ReadFile(s.Path)
+	if err != nil {
+		return Rate{}, err
+	}
+	raw := strings.TrimSpace(string(b))
+	val, err := strconv.ParseFloat(strings.ReplaceAll(raw, " ", ""), 64)
+	if err != nil {
+		if val, err = ExtractPath([]byte(raw), s.JSONPath); err != nil {
+			return Rate{}, err
+		}
+	}
+	if val <= 0 {
+		return Rate{}, ErrNoRate
+	}
+	return Rate{Value: val, Source: s.Name(), FetchedAt: time.Now()}, nil
+}
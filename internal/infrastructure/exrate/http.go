@@ -0,0 +1,65 @@
+package exrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPSource JSON qaytaradigan umumiy endpoint (masalan Markaziy bank:
+// https://cbu.uz/uz/arkhiv-kursov-valyut/json/USD/ , Path "0.Rate")
+type HTTPSource struct {
+	URL    string
+	Path   string
+	Client *http.Client
+}
+
+const HTTPName = "http"
+
+// DefaultCBUURL O'zbekiston Markaziy banki USD kursi
+const DefaultCBUURL = "https://cbu.uz/uz/arkhiv-kursov-valyut/json/USD/"
+
+// DefaultCBUPath DefaultCBUURL javobidagi kurs maydoni
+const DefaultCBUPath = "0.Rate"
+
+func NewHTTPSource(url, path string) *HTTPSource {
+	return &HTTPSource{
+		URL:    strings.TrimSpace(url),
+		Path:   strings.TrimSpace(path),
+		Client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (s *HTTPSource) Name() string { return HTTPName }
+
+func (s *HTTPSource) Fetch(ctx context.Context) (Rate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return Rate{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Rate{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Rate{}, fmt.Errorf("exrate: %s returned %s", s.URL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Rate{}, err
+	}
+	val, err := ExtractPath(body, s.Path)
+	if err != nil {
+		return Rate{}, err
+	}
+	return Rate{Value: val, Source: s.Name(), FetchedAt: time.Now()}, nil
+}
@@ -0,0 +1,85 @@
+// Package geo yetkazish hududlari uchun oddiy geometriya: masofa, poligon ichida nuqta,
+// lokatsiya matnidan koordinata ajratish va GeoJSON o'qish.
+package geo
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+)
+
+const earthRadiusKm = 6371.0
+
+// Point geografik nuqta (daraja)
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Valid koordinata chegaralari to'g'rimi
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+// DistanceKm ikki nuqta orasidagi masofa (haversine)
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Polygon birinchi halqa - tashqi chegara, qolganlari - teshiklar
+type Polygon [][]Point
+
+// Contains nuqta poligon ichidami (teshiklar hisobga olinadi)
+func (pg Polygon) Contains(p Point) bool {
+	if len(pg) == 0 || !inRing(pg[0], p) {
+		return false
+	}
+	for _, hole := range pg[1:] {
+		if inRing(hole, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// inRing ray casting; halqa yopiq yoki ochiq bo'lishi mumkin
+func inRing(ring []Point, p Point) bool {
+	if len(ring) < 3 {
+		return false
+	}
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+var (
+	reMapsQuery = regexp.MustCompile(`[?&](?:q|ll|query)=(-?[0-9]+(?:\.[0-9]+)?),\s*(-?[0-9]+(?:\.[0-9]+)?)`)
+	reLatLonTag = regexp.MustCompile(`(?i)lat:\s*(-?[0-9]+(?:\.[0-9]+)?)\s*,?\s*lon:\s*(-?[0-9]+(?:\.[0-9]+)?)`)
+	rePlainPair = regexp.MustCompile(`^\s*(-?[0-9]{1,2}\.[0-9]+)\s*[,; ]\s*(-?[0-9]{1,3}\.[0-9]+)\s*$`)
+)
+
+// ParsePoint lokatsiya matnidan koordinata: Google Maps havolasi, "lat: .. lon: .." yoki "41.31, 69.24"
+func ParsePoint(text string) (Point, bool) {
+	for _, re := range []*regexp.Regexp{reMapsQuery, reLatLonTag, rePlainPair} {
+		m := re.FindStringSubmatch(text)
+		if len(m) != 3 {
+			continue
+		}
+		lat, err1 := strconv.ParseFloat(m[1], 64)
+		lon, err2 := strconv.ParseFloat(m[2], 64)
+		if p := (Point{Lat: lat, Lon: lon}); err1 == nil && err2 == nil && p.Valid() {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
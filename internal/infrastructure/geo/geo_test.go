@@ -0,0 +1,68 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseFeaturesAndContains(t *testing.T) {
+	data := []byte(`{
+  "type": "FeatureCollection",
+  "features": [
+    {"type": "Feature", "properties": {"name": "Markaz", "fee": 20000},
+     "geometry": {"type": "Polygon", "coordinates": [
+       [[69.20, 41.28], [69.30, 41.28], [69.30, 41.34], [69.20, 41.34], [69.20, 41.28]],
+       [[69.24, 41.30], [69.26, 41.30], [69.26, 41.32], [69.24, 41.32], [69.24, 41.30]]
+     ]}},
+    {"type": "Feature", "properties": {"name": "Do'kon atrofi", "radius_km": 3, "keywords": ["Chilonzor", "Qatortol"]},
+     "geometry": {"type": "Point", "coordinates": [69.20, 41.27]}},
+    {"type": "Feature", "properties": {"name": "Bo'sh"}, "geometry": null}
+  ]
+}`)
+	features, err := ParseFeatures(data)
+	if err != nil {
+		t.Fatalf("ParseFeatures returned error: %v", err)
+	}
+	if len(features) != 2 {
+		t.Fatalf("features = %d, want 2", len(features))
+	}
+
+	zone := features[0]
+	if zone.Prop("name") != "Markaz" || zone.Prop("fee") != "20000" {
+		t.Fatalf("unexpected properties: %v", zone.Properties)
+	}
+	if !zone.Polygons[0].Contains(Point{Lat: 41.29, Lon: 69.22}) {
+		t.Fatalf("point inside polygon not matched")
+	}
+	if zone.Polygons[0].Contains(Point{Lat: 41.31, Lon: 69.25}) {
+		t.Fatalf("point inside hole matched")
+	}
+	if zone.Polygons[0].Contains(Point{Lat: 41.40, Lon: 69.25}) {
+		t.Fatalf("point outside polygon matched")
+	}
+
+	circle := features[1]
+	if circle.Center == nil || circle.RadiusKm != 3 || circle.Prop("keywords") != "Chilonzor,Qatortol" {
+		t.Fatalf("unexpected radius feature: %+v", circle)
+	}
+}
+
+func TestParsePointAndDistance(t *testing.T) {
+	cases := map[string]bool{
+		"https://www.google.com/maps?q=41.31100,69.27970": true,
+		"lat: 41.311 lon: 69.2797":                        true,
+		"41.311, 69.2797":                                 true,
+		"Chilonzor 9-kvartal, 12-uy":                      false,
+	}
+	for text, want := range cases {
+		if _, ok := ParsePoint(text); ok != want {
+			t.Fatalf("ParsePoint(%q) ok = %v, want %v", text, ok, want)
+		}
+	}
+
+	// Toshkent - Samarqand ~ 270 km
+	d := DistanceKm(Point{Lat: 41.2995, Lon: 69.2401}, Point{Lat: 39.6542, Lon: 66.9597})
+	if math.Abs(d-270) > 10 {
+		t.Fatalf("distance = %.1f km, want ~270", d)
+	}
+}
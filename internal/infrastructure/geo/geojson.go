@@ -0,0 +1,170 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrNoFeatures = errors.New("geojson: no polygon or point features")
+
+// Feature GeoJSON obyekti: poligon(lar) yoki markaz + radius, va xossalari
+type Feature struct {
+	Properties map[string]interface{}
+	Polygons   []Polygon
+	Center     *Point
+	RadiusKm   float64 // Point uchun properties.radius_km
+}
+
+// Prop xossa qiymati matn ko'rinishida (yo'q bo'lsa "")
+func (f Feature) Prop(keys ...string) string {
+	for _, k := range keys {
+		v, ok := f.Properties[k]
+		if !ok || v == nil {
+			continue
+		}
+		switch val := v.(type) {
+		case string:
+			if s := strings.TrimSpace(val); s != "" {
+				return s
+			}
+		case float64:
+			return strconv.FormatFloat(val, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(val)
+		case []interface{}:
+			var parts []string
+			for _, it := range val {
+				if s, ok := it.(string); ok && strings.TrimSpace(s) != "" {
+					parts = append(parts, strings.TrimSpace(s))
+				}
+			}
+			if len(parts) > 0 {
+				return strings.Join(parts, ",")
+			}
+		}
+	}
+	return ""
+}
+
+type rawObject struct {
+	Type        string                 `json:"type"`
+	Features    []rawObject            `json:"features"`
+	Geometry    *rawObject             `json:"geometry"`
+	Geometries  []rawObject            `json:"geometries"`
+	Properties  map[string]interface{} `json:"properties"`
+	Coordinates json.RawMessage        `json:"coordinates"`
+}
+
+// ParseFeatures FeatureCollection, Feature yoki yalang'och geometriyani o'qiydi.
+// Polygon/MultiPolygon - hudud, Point - properties.radius_km bilan aylana hudud.
+func ParseFeatures(data []byte) ([]Feature, error) {
+	var root rawObject
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("geojson: %w", err)
+	}
+	var out []Feature
+	switch root.Type {
+	case "FeatureCollection":
+		for i, f := range root.Features {
+			feat, err := parseFeature(f)
+			if err != nil {
+				return nil, fmt.Errorf("feature %d: %w", i+1, err)
+			}
+			out = append(out, feat)
+		}
+	case "Feature":
+		feat, err := parseFeature(root)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, feat)
+	default:
+		feat := Feature{Properties: map[string]interface{}{}}
+		if err := addGeometry(&feat, root); err != nil {
+			return nil, err
+		}
+		out = append(out, feat)
+	}
+
+	kept := out[:0]
+	for _, f := range out {
+		if len(f.Polygons) > 0 || (f.Center != nil && f.RadiusKm > 0) {
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, ErrNoFeatures
+	}
+	return kept, nil
+}
+
+func parseFeature(obj rawObject) (Feature, error) {
+	feat := Feature{Properties: obj.Properties}
+	if feat.Properties == nil {
+		feat.Properties = map[string]interface{}{}
+	}
+	if obj.Geometry == nil {
+		return feat, nil
+	}
+	if err := addGeometry(&feat, *obj.Geometry); err != nil {
+		return feat, err
+	}
+	if feat.Center != nil {
+		if r, err := strconv.ParseFloat(feat.Prop("radius_km", "radius"), 64); err == nil && r > 0 {
+			feat.RadiusKm = r
+		}
+	}
+	return feat, nil
+}
+
+func addGeometry(feat *Feature, g rawObject) error {
+	switch g.Type {
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &rings); err != nil {
+			return fmt.Errorf("polygon: %w", err)
+		}
+		feat.Polygons = append(feat.Polygons, toPolygon(rings))
+	case "MultiPolygon":
+		var polys [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polys); err != nil {
+			return fmt.Errorf("multipolygon: %w", err)
+		}
+		for _, rings := range polys {
+			feat.Polygons = append(feat.Polygons, toPolygon(rings))
+		}
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil || len(c) < 2 {
+			return fmt.Errorf("point: invalid coordinates")
+		}
+		feat.Center = &Point{Lat: c[1], Lon: c[0]}
+	case "GeometryCollection":
+		for _, sub := range g.Geometries {
+			if err := addGeometry(feat, sub); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("geojson: unsupported geometry %q", g.Type)
+	}
+	return nil
+}
+
+// toPolygon GeoJSON tartibi [lon, lat]
+func toPolygon(rings [][][]float64) Polygon {
+	pg := make(Polygon, 0, len(rings))
+	for _, ring := range rings {
+		pts := make([]Point, 0, len(ring))
+		for _, c := range ring {
+			if len(c) >= 2 {
+				pts = append(pts, Point{Lat: c[1], Lon: c[0]})
+			}
+		}
+		pg = append(pg, pts)
+	}
+	return pg
+}
@@ -93,11 +93,22 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 	var columnMap map[string]int
 
 	var header []string
+	var branchStockCols map[string]int
 
 	if hasHeader {
 		// Header row dan column mapping yaratish
 		header = rows[0]
 		columnMap = e.mapColumns(header)
+		// Filial ustunlari ("Stock: Chilonzor") umumiy qoldiq emas
+		var plainStock int
+		plainStock, branchStockCols = branchStockColumns(header)
+		if idx, ok := columnMap["stock"]; ok && isBranchStockCol(branchStockCols, idx) {
+			if plainStock >= 0 {
+				columnMap["stock"] = plainStock
+			} else {
+				delete(columnMap, "stock")
+			}
+		}
 		log.Printf("🗺️ Column mapping from header: %v", columnMap)
 	} else {
 		// Header yo'q - default mapping
@@ -135,12 +146,21 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 		}
 	}
 
+	// Narx valyutasi: katakdagi belgi ("$", "so'm") ustun, aks holda sarlavha ("Narx (so'm)"), aks holda USD
+	priceCurrency := entity.CurrencyUSD
+	if hasHeader && priceCol < len(header) {
+		if cur := entity.ParseCurrency(header[priceCol]); cur != "" {
+			priceCurrency = cur
+		}
+	}
+
 	categoryCol, hasCategory := columnMap["category"]
 	descriptionCol, hasDescription := columnMap["description"]
 	stockCol, hasStock := columnMap["stock"]
 
 	var products []entity.Product
 	now := time.Now()
+	warehouses := e.readWarehouseSheets(f)
 
 	sectionedByCategory := false
 	if hasHeader && !hasCategory {
@@ -196,14 +216,14 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 			}
 
 			// Narxni parse qilish
-			price, err := e.parsePrice(priceStr)
-			if err != nil || price == 0 {
+			price, err := e.parseMoney(priceStr, priceCurrency)
+			if err != nil || price.IsZero() {
 				log.Printf("⚠️ Row %d: Invalid price '%s' - skipping", i, priceStr)
 				continue
 			}
 
 			// Narx 0 yoki manfiy bo'lsa, o'tkazib yuborish
-			if price <= 0 {
+			if price.Amount <= 0 {
 				log.Printf("⚠️ Row %d: Invalid price '%s' (<= 0) - skipping", i+1, priceStr)
 				continue
 			}
@@ -250,6 +270,7 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 					}
 				}
 			}
+			e.fillBranchStock(&product, row, branchStockCols, warehouses, hasStock)
 
 			// Qo'shimcha ustunlarni specs ga qo'shish (header bo'lsa nomlarini ishlatamiz)
 			usedCols := map[int]struct{}{nameCol: {}, priceCol: {}}
@@ -262,6 +283,9 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 			if hasStock {
 				usedCols[stockCol] = struct{}{}
 			}
+			for _, idx := range branchStockCols {
+				usedCols[idx] = struct{}{}
+			}
 
 			if hasHeader {
 				for idx, raw := range row {
@@ -291,7 +315,7 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 				}
 			}
 
-			log.Printf("✅ Found: %s - $%.2f (category: %s)", product.Name, product.Price, product.Category)
+			log.Printf("✅ Found: %s - %s (category: %s)", product.Name, product.Price, product.Category)
 			products = append(products, product)
 		}
 	} else {
@@ -323,8 +347,8 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 				}
 
 				// Agar narx raqam bo'lmasa, skip
-				price, err := e.parsePrice(priceStr)
-				if err != nil || price == 0 {
+				price, err := e.parseMoney(priceStr, priceCurrency)
+				if err != nil || price.Amount <= 0 {
 					continue
 				}
 
@@ -347,7 +371,7 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 				// Kategoriyani aniqlash
 				product.Category = e.detectCategory(nameStr)
 
-				log.Printf("✅ Found: %s - $%.2f (category: %s)", product.Name, product.Price, product.Category)
+				log.Printf("✅ Found: %s - %s (category: %s)", product.Name, product.Price, product.Category)
 				products = append(products, product)
 			}
 		}
@@ -552,6 +576,12 @@ func (e *excelParser) mapColumns(header []string) map[string]int {
 		// Debug
 		log.Printf("🔍 Checking column %d: '%s'", i, colName)
 
+		// Filial qoldig'i ("Soni (Nomozgoh)") nom ustuni bilan adashmasin
+		if branch, ok := stockBranchFromHeader(col); ok && branch != "" {
+			columnMap["stock"] = i
+			continue
+		}
+
 		// Standart maydonlar uchun mapping - JUDA KO'P VARIANTLAR
 		switch {
 		// NAME variants
@@ -650,6 +680,19 @@ func (e *excelParser) parsePrice(priceStr string) (float64, error) {
 	return price, nil
 }
 
+// parseMoney narx katagini Money ga aylantiradi; valyuta katakdan, bo'lmasa fallback
+func (e *excelParser) parseMoney(priceStr string, fallback string) (entity.Money, error) {
+	amount, err := e.parsePrice(priceStr)
+	if err != nil {
+		return entity.Money{}, err
+	}
+	currency := entity.ParseCurrency(priceStr)
+	if currency == "" {
+		currency = fallback
+	}
+	return entity.NewMoney(amount, currency), nil
+}
+
 // detectCategory mahsulot nomidan kategoriyani aniqlash
 // MUHIM: Eng aniq belgilarni birinchi tekshiramiz!
 func (e *excelParser) detectCategory(name string) string {
@@ -786,6 +829,9 @@ func (e *excelParser) ConvertToCSV(ctx context.Context, data []byte, filename st
 		return "", fmt.Errorf("excel file is empty")
 	}
 
+	// Ombor varaqlari bo'lsa, filial qoldiqlari ustun sifatida qo'shiladi
+	rows = appendWarehouseColumns(rows, e.readWarehouseSheets(f))
+
 	// CSV buffer yaratish
 	var csvBuffer bytes.Buffer
 	csvWriter := csv.NewWriter(&csvBuffer)
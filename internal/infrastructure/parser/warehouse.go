@@ -0,0 +1,209 @@
+package parser
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Ko'p filialli ombor. Qoldiq ikki xil berilishi mumkin:
+//   - asosiy varaqda filial ustunlari: "Stock: Chilonzor", "Soni (Yunusobod)";
+//   - keyingi varaqlar: varaq nomi - filial, ichida "Nomi | Soni" jadvali.
+// Filial kaliti kichik harflarda saqlanadi.
+
+var (
+	stockHeaderWords     = []string{"stock", "soni", "miqdor", "количество", "qty", "quantity"}
+	warehouseNameHeaders = []string{"name", "nom", "название", "товар", "product", "mahsulot", "tovar"}
+)
+
+// stockBranchFromHeader "Stock: Chilonzor" -> ("chilonzor", true), "Stock" -> ("", true), boshqa -> ("", false).
+// Filial nomi faqat ajratuvchidan keyin olinadi: "Miqdori" oddiy qoldiq ustuni bo'lib qoladi.
+func stockBranchFromHeader(header string) (string, bool) {
+	lower := strings.ToLower(strings.TrimSpace(header))
+	for _, w := range stockHeaderWords {
+		idx := strings.Index(lower, w)
+		if idx < 0 {
+			continue
+		}
+		rest := lower[idx+len(w):]
+		if cut := strings.IndexAny(rest, ":(-–—/|"); cut >= 0 {
+			branch := strings.Trim(rest[cut:], " :-–—()[]/|")
+			return strings.Join(strings.Fields(branch), " "), true
+		}
+		return "", true
+	}
+	return "", false
+}
+
+// branchStockColumns sarlavhadagi oddiy qoldiq ustuni (-1 - yo'q) va filial ustunlari
+func branchStockColumns(header []string) (int, map[string]int) {
+	plain := -1
+	branches := make(map[string]int)
+	for i, col := range header {
+		branch, ok := stockBranchFromHeader(col)
+		if !ok {
+			continue
+		}
+		if branch == "" {
+			if plain < 0 {
+				plain = i
+			}
+			continue
+		}
+		branches[branch] = i
+	}
+	return plain, branches
+}
+
+func isBranchStockCol(cols map[string]int, idx int) bool {
+	for _, c := range cols {
+		if c == idx {
+			return true
+		}
+	}
+	return false
+}
+
+// fillBranchStock filial qoldiqlarini ustunlardan va ombor varaqlaridan to'ldiradi.
+// Umumiy "Stock" ustuni bo'lmasa, Stock - filiallar yig'indisi. Mahsulotda birorta
+// filial qiymati bo'lsa, qolgan ma'lum filiallar 0 bilan yoziladi.
+func (e *excelParser) fillBranchStock(p *entity.Product, row []string, cols map[string]int, warehouses map[string]map[string]int, hasPlainStock bool) {
+	stock := make(map[string]int)
+	for branch, idx := range cols {
+		if idx >= len(row) {
+			continue
+		}
+		if qty, err := e.parsePrice(strings.TrimSpace(row[idx])); err == nil {
+			stock[branch] = int(qty)
+		}
+	}
+	name := normalizeWarehouseName(p.Name)
+	for branch, items := range warehouses {
+		if qty, ok := items[name]; ok {
+			stock[branch] = qty
+		}
+	}
+	if len(stock) == 0 {
+		return
+	}
+	// Ma'lum filial ustuni/varag'ida qiymat bo'lmasa - u yerda qoldiq 0
+	for branch := range cols {
+		if _, ok := stock[branch]; !ok {
+			stock[branch] = 0
+		}
+	}
+	for branch := range warehouses {
+		if _, ok := stock[branch]; !ok {
+			stock[branch] = 0
+		}
+	}
+	p.StockByBranch = stock
+	if !hasPlainStock {
+		total := 0
+		for _, qty := range stock {
+			total += qty
+		}
+		p.Stock = total
+	}
+}
+
+func normalizeWarehouseName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// findWarehouseHeader dastlabki qatorlardan nom ustuni bor sarlavhani topadi
+func findWarehouseHeader(rows [][]string) (headerRow, nameCol int, ok bool) {
+	for r := 0; r < len(rows) && r < 5; r++ {
+		for c, cell := range rows[r] {
+			if contains(strings.ToLower(strings.TrimSpace(cell)), warehouseNameHeaders...) {
+				return r, c, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// readWarehouseSheets birinchidan keyingi varaqlardan filial qoldiqlarini o'qiydi:
+// filial -> mahsulot nomi (normallashgan) -> soni. Nom va soni ustuni bo'lmagan varaqlar o'tkazib yuboriladi.
+func (e *excelParser) readWarehouseSheets(f *excelize.File) map[string]map[string]int {
+	sheets := f.GetSheetList()
+	out := make(map[string]map[string]int)
+	for _, sheet := range sheets[1:] {
+		rows, err := f.GetRows(sheet)
+		if err != nil || len(rows) < 2 {
+			continue
+		}
+		headerRow, nameCol, ok := findWarehouseHeader(rows)
+		if !ok {
+			continue
+		}
+		qtyCol, _ := branchStockColumns(rows[headerRow])
+		if qtyCol < 0 {
+			continue
+		}
+		stock := make(map[string]int)
+		for _, row := range rows[headerRow+1:] {
+			if nameCol >= len(row) || qtyCol >= len(row) {
+				continue
+			}
+			name := normalizeWarehouseName(row[nameCol])
+			if name == "" {
+				continue
+			}
+			if qty, err := e.parsePrice(strings.TrimSpace(row[qtyCol])); err == nil {
+				stock[name] = int(qty)
+			}
+		}
+		if len(stock) > 0 {
+			out[strings.ToLower(strings.TrimSpace(sheet))] = stock
+			log.Printf("🏬 Warehouse sheet %q: %d items", sheet, len(stock))
+		}
+	}
+	return out
+}
+
+// appendWarehouseColumns CSV uchun: ombor varaqlarini asosiy jadvalga "Stock: <filial>" ustuni sifatida qo'shadi
+func appendWarehouseColumns(rows [][]string, warehouses map[string]map[string]int) [][]string {
+	if len(warehouses) == 0 {
+		return rows
+	}
+	headerRow, nameCol, ok := findWarehouseHeader(rows)
+	if !ok {
+		return rows
+	}
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	branches := make([]string, 0, len(warehouses))
+	for b := range warehouses {
+		branches = append(branches, b)
+	}
+	sort.Strings(branches)
+
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		padded := make([]string, width, width+len(branches))
+		copy(padded, row)
+		for _, b := range branches {
+			cell := ""
+			switch {
+			case i == headerRow:
+				cell = "Stock: " + b
+			case i > headerRow && nameCol < len(row):
+				if qty, ok := warehouses[b][normalizeWarehouseName(row[nameCol])]; ok {
+					cell = strconv.Itoa(qty)
+				}
+			}
+			padded = append(padded, cell)
+		}
+		out[i] = padded
+	}
+	return out
+}
@@ -0,0 +1,155 @@
+package payment
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ClickProvider Click merchant API (Prepare/Complete callbacklari, MD5 sign_string)
+type ClickProvider struct {
+	ServiceID  string
+	MerchantID string
+	SecretKey  string
+	ReturnURL  string
+	PayBaseURL string
+}
+
+const ClickName = "click"
+
+const defaultClickPayURL = "https://my.click.uz/services/pay"
+
+// Click action qiymatlari
+const (
+	clickActionPrepare  = "0"
+	clickActionComplete = "1"
+)
+
+func NewClickProvider(serviceID, merchantID, secretKey, returnURL string) *ClickProvider {
+	return &ClickProvider{
+		ServiceID:  strings.TrimSpace(serviceID),
+		MerchantID: strings.TrimSpace(merchantID),
+		SecretKey:  strings.TrimSpace(secretKey),
+		ReturnURL:  strings.TrimSpace(returnURL),
+	}
+}
+
+func (p *ClickProvider) Name() string { return ClickName }
+
+func (p *ClickProvider) CreateInvoice(_ context.Context, inv *Invoice) error {
+	base := p.PayBaseURL
+	if base == "" {
+		base = defaultClickPayURL
+	}
+	q := url.Values{}
+	q.Set("service_id", p.ServiceID)
+	q.Set("merchant_id", p.MerchantID)
+	q.Set("amount", formatMajor(inv.Amount))
+	q.Set("transaction_param", inv.ID)
+	if p.ReturnURL != "" {
+		q.Set("return_url", p.ReturnURL)
+	}
+	inv.Provider = p.Name()
+	inv.PayURL = base + "?" + q.Encode()
+	return nil
+}
+
+// clickSign md5(click_trans_id + service_id + secret + merchant_trans_id [+ merchant_prepare_id] + amount + action + sign_time)
+func (p *ClickProvider) clickSign(params url.Values) string {
+	var sb strings.Builder
+	sb.WriteString(params.Get("click_trans_id"))
+	sb.WriteString(params.Get("service_id"))
+	sb.WriteString(p.SecretKey)
+	sb.WriteString(params.Get("merchant_trans_id"))
+	if params.Get("action") == clickActionComplete {
+		sb.WriteString(params.Get("merchant_prepare_id"))
+	}
+	sb.WriteString(params.Get("amount"))
+	sb.WriteString(params.Get("action"))
+	sb.WriteString(params.Get("sign_time"))
+	sum := md5.Sum([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func (p *ClickProvider) VerifyCallback(_ context.Context, req CallbackRequest) (Callback, error) {
+	params := req.Params
+	if params.Get("click_trans_id") == "" || params.Get("merchant_trans_id") == "" {
+		return Callback{}, ErrBadRequest
+	}
+	cb := Callback{
+		InvoiceID:  params.Get("merchant_trans_id"),
+		ExternalID: params.Get("click_trans_id"),
+	}
+	want := p.clickSign(params)
+	got := strings.ToLower(strings.TrimSpace(params.Get("sign_string")))
+	if subtle.ConstantTimeCompare([]byte(want), []byte(got)) != 1 || params.Get("service_id") != p.ServiceID {
+		return cb, ErrInvalidSignature
+	}
+	amount, ok := parseMajor(params.Get("amount"))
+	if !ok || amount <= 0 {
+		return cb, ErrBadRequest
+	}
+	cb.Amount = amount
+
+	switch params.Get("action") {
+	case clickActionPrepare:
+		cb.Method = "prepare"
+		cb.Status = StatusPending
+	case clickActionComplete:
+		cb.Method = "complete"
+		cb.Status = StatusPaid
+		// error < 0 - Click to'lovni bekor qilgan
+		if code, err := strconv.Atoi(params.Get("error")); err == nil && code < 0 {
+			cb.Status = StatusCanceled
+		}
+	default:
+		return cb, ErrBadRequest
+	}
+	return cb, nil
+}
+
+// Refund - Click merchant API qaytarishni kabinet orqali qiladi
+func (p *ClickProvider) Refund(_ context.Context, _ *Invoice) error {
+	return ErrManualRefund
+}
+
+// Respond Click kutgan JSON javob
+func (p *ClickProvider) Respond(cb Callback, inv Invoice, err error) (int, []byte) {
+	code, note := 0, "Success"
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrInvalidSignature):
+		code, note = -1, "SIGN CHECK FAILED!"
+	case errors.Is(err, ErrAmountMismatch):
+		code, note = -2, "Incorrect parameter amount"
+	case errors.Is(err, ErrAlreadyPaid):
+		code, note = -4, "Already paid"
+	case errors.Is(err, ErrUnknownInvoice):
+		code, note = -5, "Transaction does not exist"
+	case errors.Is(err, ErrInvalidState):
+		code, note = -9, "Transaction cancelled"
+	default:
+		code, note = -8, "Error in request from click"
+	}
+	resp := map[string]interface{}{
+		"click_trans_id":    cb.ExternalID,
+		"merchant_trans_id": cb.InvoiceID,
+		"error":             code,
+		"error_note":        note,
+	}
+	// merchant_prepare_id butun son bo'lishi kerak: invoice yaratilgan vaqtdan olamiz
+	if cb.Method == "complete" {
+		resp["merchant_confirm_id"] = inv.CreatedAt.Unix()
+	} else {
+		resp["merchant_prepare_id"] = inv.CreatedAt.Unix()
+	}
+	b, _ := json.Marshal(resp)
+	return http.StatusOK, b
+}
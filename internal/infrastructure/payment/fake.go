@@ -0,0 +1,75 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FakeProvider lokal test va ishlab chiqish uchun: pul yechmaydi,
+// callback'ni qo'lda (yoki testdan) yuborish mumkin
+type FakeProvider struct {
+	Secret string // callback "sign" maydoni shu kalit bilan tekshiriladi; bo'sh bo'lsa har qanday callback rad etiladi
+
+	mu       sync.Mutex
+	refunded []string
+}
+
+const FakeName = "fake"
+
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{Secret: strings.TrimSpace(secret)}
+}
+
+func (p *FakeProvider) Name() string { return FakeName }
+
+func (p *FakeProvider) CreateInvoice(_ context.Context, inv *Invoice) error {
+	inv.Provider = p.Name()
+	inv.PayURL = "https://example.invalid/pay/" + inv.ID
+	return nil
+}
+
+// Sign fake callback imzosi: hex(hmac-sha256(secret, invoice_id|amount|status))
+func (p *FakeProvider) Sign(invoiceID string, amount int64, status Status) string {
+	mac := hmac.New(sha256.New, []byte(p.Secret))
+	mac.Write([]byte(invoiceID + "|" + strconv.FormatInt(amount, 10) + "|" + string(status)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCallback maydonlar: invoice_id, amount, status, ext_id, sign
+func (p *FakeProvider) VerifyCallback(_ context.Context, req CallbackRequest) (Callback, error) {
+	params := req.Params
+	amount, err := strconv.ParseInt(params.Get("amount"), 10, 64)
+	if params.Get("invoice_id") == "" || err != nil {
+		return Callback{}, ErrBadRequest
+	}
+	cb := Callback{
+		InvoiceID:  params.Get("invoice_id"),
+		ExternalID: params.Get("ext_id"),
+		Amount:     amount,
+		Status:     Status(params.Get("status")),
+		Method:     "fake",
+	}
+	if p.Secret == "" || !hmac.Equal([]byte(p.Sign(cb.InvoiceID, amount, cb.Status)), []byte(params.Get("sign"))) {
+		return cb, ErrInvalidSignature
+	}
+	return cb, nil
+}
+
+func (p *FakeProvider) Refund(_ context.Context, inv *Invoice) error {
+	p.mu.Lock()
+	p.refunded = append(p.refunded, inv.ID)
+	p.mu.Unlock()
+	return nil
+}
+
+// Refunded Refund chaqirilgan invoice ID lari
+func (p *FakeProvider) Refunded() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.refunded...)
+}
@@ -0,0 +1,187 @@
+package payment
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PaymeProvider Payme (Paycom) merchant API: checkout havolasi va JSON-RPC callbacklar
+type PaymeProvider struct {
+	MerchantID  string
+	Key         string // kassa kaliti (Basic auth paroli)
+	CheckoutURL string
+}
+
+const PaymeName = "payme"
+
+const defaultPaymeCheckoutURL = "https://checkout.paycom.uz"
+
+// Payme JSON-RPC xato kodlari
+const (
+	paymeErrAuth          = -32504
+	paymeErrParse         = -32700
+	paymeErrAmount        = -31001
+	paymeErrNotFound      = -31003
+	paymeErrCannotPerform = -31008
+	paymeErrAccount       = -31050
+)
+
+func NewPaymeProvider(merchantID, key string) *PaymeProvider {
+	return &PaymeProvider{MerchantID: strings.TrimSpace(merchantID), Key: strings.TrimSpace(key)}
+}
+
+func (p *PaymeProvider) Name() string { return PaymeName }
+
+// CreateInvoice checkout havolasi: base64("m=<merchant>;ac.order_id=<invoice>;a=<tiyin>")
+func (p *PaymeProvider) CreateInvoice(_ context.Context, inv *Invoice) error {
+	base := p.CheckoutURL
+	if base == "" {
+		base = defaultPaymeCheckoutURL
+	}
+	raw := fmt.Sprintf("m=%s;ac.order_id=%s;a=%d", p.MerchantID, inv.ID, inv.Amount)
+	inv.Provider = p.Name()
+	inv.PayURL = strings.TrimRight(base, "/") + "/" + base64.StdEncoding.EncodeToString([]byte(raw))
+	return nil
+}
+
+type paymeRequest struct {
+	Method string          `json:"method"`
+	ID     json.RawMessage `json:"id"`
+	Params struct {
+		ID      string `json:"id"`
+		Amount  int64  `json:"amount"`
+		Account struct {
+			OrderID string `json:"order_id"`
+		} `json:"account"`
+	} `json:"params"`
+}
+
+func (p *PaymeProvider) authorized(header string) bool {
+	want := "Basic " + base64.StdEncoding.EncodeToString([]byte("Paycom:"+p.Key))
+	return p.Key != "" && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(header)), []byte(want)) == 1
+}
+
+func (p *PaymeProvider) VerifyCallback(_ context.Context, req CallbackRequest) (Callback, error) {
+	var rpc paymeRequest
+	if err := json.Unmarshal(req.Body, &rpc); err != nil {
+		return Callback{}, ErrBadRequest
+	}
+	cb := Callback{
+		InvoiceID:  rpc.Params.Account.OrderID,
+		ExternalID: rpc.Params.ID,
+		Amount:     rpc.Params.Amount,
+		Method:     rpc.Method,
+		RequestID:  string(rpc.ID),
+	}
+	if !p.authorized(req.Header.Get("Authorization")) {
+		return cb, ErrInvalidSignature
+	}
+	switch rpc.Method {
+	case "CheckPerformTransaction", "CreateTransaction":
+		if cb.InvoiceID == "" {
+			return cb, ErrUnknownInvoice
+		}
+		cb.Status = StatusPending
+	case "PerformTransaction":
+		cb.Status = StatusPaid
+	case "CancelTransaction":
+		cb.Status = StatusCanceled
+	case "CheckTransaction":
+	default:
+		return cb, ErrBadRequest
+	}
+	return cb, nil
+}
+
+// Refund - Payme qaytarishni o'zi CancelTransaction orqali bildiradi
+func (p *PaymeProvider) Refund(_ context.Context, _ *Invoice) error {
+	return ErrManualRefund
+}
+
+func paymeState(inv Invoice) int {
+	switch inv.Status {
+	case StatusPaid:
+		return 2
+	case StatusRefunded:
+		return -2
+	case StatusCanceled, StatusFailed:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func unixMilli(inv Invoice, which string) int64 {
+	t := inv.CreatedAt
+	switch which {
+	case "perform":
+		t = inv.PaidAt
+	case "cancel":
+		t = inv.CanceledAt
+	}
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// Respond JSON-RPC javob
+func (p *PaymeProvider) Respond(cb Callback, inv Invoice, err error) (int, []byte) {
+	id := json.RawMessage(cb.RequestID)
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	if err != nil {
+		code := paymeErrCannotPerform
+		switch {
+		case errors.Is(err, ErrInvalidSignature):
+			code = paymeErrAuth
+		case errors.Is(err, ErrBadRequest):
+			code = paymeErrParse
+		case errors.Is(err, ErrAmountMismatch):
+			code = paymeErrAmount
+		case errors.Is(err, ErrUnknownInvoice):
+			code = paymeErrAccount
+			if cb.Method != "CheckPerformTransaction" && cb.Method != "CreateTransaction" {
+				code = paymeErrNotFound
+			}
+		}
+		b, _ := json.Marshal(map[string]interface{}{
+			"id": id,
+			"error": map[string]interface{}{
+				"code":    code,
+				"message": map[string]string{"uz": err.Error(), "ru": err.Error(), "en": err.Error()},
+			},
+		})
+		return http.StatusOK, b
+	}
+
+	var result map[string]interface{}
+	switch cb.Method {
+	case "CheckPerformTransaction":
+		result = map[string]interface{}{"allow": true}
+	case "CreateTransaction":
+		result = map[string]interface{}{"create_time": unixMilli(inv, "create"), "transaction": inv.ID, "state": paymeState(inv)}
+	case "PerformTransaction":
+		result = map[string]interface{}{"perform_time": unixMilli(inv, "perform"), "transaction": inv.ID, "state": paymeState(inv)}
+	case "CancelTransaction":
+		result = map[string]interface{}{"cancel_time": unixMilli(inv, "cancel"), "transaction": inv.ID, "state": paymeState(inv)}
+	default:
+		result = map[string]interface{}{
+			"create_time":  unixMilli(inv, "create"),
+			"perform_time": unixMilli(inv, "perform"),
+			"cancel_time":  unixMilli(inv, "cancel"),
+			"transaction":  inv.ID,
+			"state":        paymeState(inv),
+			"reason":       nil,
+		}
+	}
+	b, _ := json.Marshal(map[string]interface{}{"id": id, "result": result})
+	return http.StatusOK, b
+}
@@ -0,0 +1,233 @@
+// Package payment to'lov provayderlari (Telegram Payments, Click, Payme, fake)
+// uchun umumiy interfeys va invoice holatlari.
+//
+// Summalar har doim valyutaning eng kichik birligida (tiyin, cent) saqlanadi.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status invoice holati
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusPaid     Status = "paid"
+	StatusFailed   Status = "failed"
+	StatusCanceled Status = "canceled"
+	StatusRefunded Status = "refunded"
+)
+
+var (
+	ErrInvalidSignature = errors.New("payment: invalid signature")
+	ErrUnknownInvoice   = errors.New("payment: unknown invoice")
+	ErrAmountMismatch   = errors.New("payment: amount mismatch")
+	ErrAlreadyPaid      = errors.New("payment: invoice already paid")
+	ErrInvalidState     = errors.New("payment: invalid invoice state")
+	ErrBadRequest       = errors.New("payment: malformed callback")
+	// ErrManualRefund - provayder API orqali qaytarib bo'lmaydi, kabinetdan qo'lda qilinadi
+	ErrManualRefund = errors.New("payment: refund must be done manually in provider cabinet")
+)
+
+// Invoice bitta buyurtma uchun to'lov so'rovi
+type Invoice struct {
+	ID          string
+	OrderID     string
+	UserID      int64
+	ChatID      int64
+	Provider    string
+	Amount      int64 // eng kichik birlikda (tiyin/cent)
+	Currency    string
+	Description string
+	Status      Status
+	ExternalID  string // provayder tranzaksiya ID si
+	PayURL      string // merchant provayderlar uchun to'lov sahifasi
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	PaidAt      time.Time
+	CanceledAt  time.Time
+}
+
+// CallbackRequest provayderdan kelgan so'rov (HTTP callback yoki Telegram pre-checkout)
+type CallbackRequest struct {
+	Params url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Callback tekshirilgan callback natijasi
+type Callback struct {
+	InvoiceID  string // bo'sh bo'lsa ExternalID bo'yicha qidiriladi
+	ExternalID string
+	Amount     int64 // 0 - summa yuborilmagan
+	Currency   string
+	// Status - provayder so'ragan o'tish. StatusPending - faqat tekshiruv (prepare/pre-checkout),
+	// bo'sh - holatni o'zgartirmaydigan so'rov (masalan Payme CheckTransaction)
+	Status Status
+	// Method va RequestID - javobni provayder formatida qaytarish uchun
+	Method    string
+	RequestID string
+}
+
+// Provider to'lov provayderi
+type Provider interface {
+	Name() string
+	// CreateInvoice provayder tomonida invoice tayyorlaydi (PayURL/ExternalID to'ldiriladi)
+	CreateInvoice(ctx context.Context, inv *Invoice) error
+	// VerifyCallback imzoni tekshiradi va so'rovni Callback ga aylantiradi
+	VerifyCallback(ctx context.Context, req CallbackRequest) (Callback, error)
+	Refund(ctx context.Context, inv *Invoice) error
+}
+
+// Responder - merchant provayderlar callbackga o'z formatidagi javobni kutadi
+type Responder interface {
+	Respond(cb Callback, inv Invoice, err error) (int, []byte)
+}
+
+// Apply callbackni invoice'ga qo'llaydi. changed=true bo'lsa invoice saqlanishi kerak.
+// Takroriy callbacklar (masalan ikkinchi "paid") xatosiz, o'zgarishsiz qaytadi.
+func Apply(inv *Invoice, cb Callback, now time.Time) (bool, error) {
+	if cb.Amount != 0 && cb.Amount != inv.Amount {
+		return false, ErrAmountMismatch
+	}
+	if cb.Currency != "" && inv.Currency != "" && !strings.EqualFold(cb.Currency, inv.Currency) {
+		return false, ErrAmountMismatch
+	}
+
+	changed := false
+	if cb.ExternalID != "" && cb.ExternalID != inv.ExternalID && inv.Status == StatusPending {
+		inv.ExternalID = cb.ExternalID
+		changed = true
+	}
+
+	switch cb.Status {
+	case "":
+		return changed, nil
+	case StatusPending:
+		switch inv.Status {
+		case StatusPending:
+		case StatusPaid:
+			return changed, ErrAlreadyPaid
+		default:
+			return changed, ErrInvalidState
+		}
+	case StatusPaid:
+		switch inv.Status {
+		case StatusPaid:
+		case StatusPending:
+			inv.Status = StatusPaid
+			inv.PaidAt = now
+			changed = true
+		default:
+			return changed, ErrInvalidState
+		}
+	case StatusCanceled, StatusRefunded:
+		switch inv.Status {
+		case StatusPaid:
+			inv.Status = StatusRefunded
+			inv.CanceledAt = now
+			changed = true
+		case StatusPending, StatusFailed:
+			if cb.Status == StatusRefunded {
+				return changed, ErrInvalidState
+			}
+			inv.Status = StatusCanceled
+			inv.CanceledAt = now
+			changed = true
+		}
+	case StatusFailed:
+		if inv.Status == StatusPending {
+			inv.Status = StatusFailed
+			changed = true
+		}
+	default:
+		return changed, ErrBadRequest
+	}
+	if changed {
+		inv.UpdatedAt = now
+	}
+	return changed, nil
+}
+
+// Registry yoqilgan provayderlar ro'yxati (nil registry - hech biri yo'q)
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider)}
+	for _, p := range providers {
+		r.Register(p)
+	}
+	return r
+}
+
+func (r *Registry) Register(p Provider) {
+	if p == nil {
+		return
+	}
+	r.mu.Lock()
+	r.providers[p.Name()] = p
+	r.mu.Unlock()
+}
+
+func (r *Registry) Get(name string) (Provider, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Names tartiblangan provayder nomlari
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// formatMajor tiyin/cent -> "1234.50"
+func formatMajor(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+// parseMajor "1234.5" -> 123450
+func parseMajor(raw string) (int64, bool) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(raw), ".")
+	if len(frac) > 2 {
+		frac = frac[:2]
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || w < 0 {
+		return 0, false
+	}
+	f, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil || f < 0 {
+		return 0, false
+	}
+	return w*100 + f, true
+}
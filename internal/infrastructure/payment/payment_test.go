@@ -0,0 +1,97 @@
+package payment
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestClickCallbackLifecycle - imzo tekshiruvi, prepare/complete va takroriy callback
+func TestClickCallbackLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := NewClickProvider("111", "222", "secret", "")
+	inv := Invoice{ID: "PAY-1", Amount: 1500000, Currency: "UZS", Status: StatusPending, CreatedAt: time.Now()}
+	if err := p.CreateInvoice(ctx, &inv); err != nil || inv.PayURL == "" {
+		t.Fatalf("pay url: %q err=%v", inv.PayURL, err)
+	}
+
+	params := url.Values{
+		"click_trans_id":    {"987"},
+		"service_id":        {"111"},
+		"merchant_trans_id": {"PAY-1"},
+		"amount":            {"15000.00"},
+		"action":            {clickActionPrepare},
+		"sign_time":         {"2026-01-01 10:00:00"},
+	}
+	params.Set("sign_string", p.clickSign(params))
+	cb, err := p.VerifyCallback(ctx, CallbackRequest{Params: params})
+	if err != nil || cb.Status != StatusPending {
+		t.Fatalf("prepare: %+v err=%v", cb, err)
+	}
+	if _, err := Apply(&inv, cb, time.Now()); err != nil {
+		t.Fatalf("prepare apply: %v", err)
+	}
+
+	params.Set("action", clickActionComplete)
+	params.Set("merchant_prepare_id", "1")
+	params.Set("sign_string", p.clickSign(params))
+	cb, err = p.VerifyCallback(ctx, CallbackRequest{Params: params})
+	if err != nil || cb.Status != StatusPaid {
+		t.Fatalf("complete: %+v err=%v", cb, err)
+	}
+	if changed, err := Apply(&inv, cb, time.Now()); err != nil || !changed || inv.Status != StatusPaid {
+		t.Fatalf("complete apply: changed=%v status=%s err=%v", changed, inv.Status, err)
+	}
+	if changed, err := Apply(&inv, cb, time.Now()); err != nil || changed {
+		t.Fatalf("takroriy complete o'zgarishsiz bo'lishi kerak: changed=%v err=%v", changed, err)
+	}
+
+	params.Set("amount", "1.00")
+	if _, err := p.VerifyCallback(ctx, CallbackRequest{Params: params}); err != ErrInvalidSignature {
+		t.Fatalf("soxta summa imzodan o'tmasligi kerak: %v", err)
+	}
+	if _, err := Apply(&inv, Callback{Amount: 100, Status: StatusPaid}, time.Now()); err != ErrAmountMismatch {
+		t.Fatalf("summa tekshirilmadi: %v", err)
+	}
+}
+
+// TestPaymeAuthAndRefund - Basic auth va to'langandan keyin CancelTransaction = refund
+func TestPaymeAuthAndRefund(t *testing.T) {
+	ctx := context.Background()
+	p := NewPaymeProvider("merchant", "key")
+	inv := Invoice{ID: "PAY-2", Amount: 500000, Status: StatusPaid, CreatedAt: time.Now()}
+
+	body := []byte(`{"method":"CancelTransaction","params":{"id":"tx-1","reason":5},"id":7}`)
+	if _, err := p.VerifyCallback(ctx, CallbackRequest{Body: body, Header: http.Header{}}); err != ErrInvalidSignature {
+		t.Fatalf("auth talab qilinmadi: %v", err)
+	}
+	hdr := http.Header{}
+	hdr.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("Paycom:key")))
+	cb, err := p.VerifyCallback(ctx, CallbackRequest{Body: body, Header: hdr})
+	if err != nil || cb.ExternalID != "tx-1" || cb.Status != StatusCanceled {
+		t.Fatalf("cancel: %+v err=%v", cb, err)
+	}
+	if _, err := Apply(&inv, cb, time.Now()); err != nil || inv.Status != StatusRefunded {
+		t.Fatalf("refund: status=%s err=%v", inv.Status, err)
+	}
+	if code, resp := p.Respond(cb, inv, nil); code != http.StatusOK || len(resp) == 0 {
+		t.Fatalf("javob: %d %s", code, resp)
+	}
+}
+
+// TestFakeRequiresSecret - kalitsiz fake provayder imzosiz callbackni qabul qilmaydi
+func TestFakeRequiresSecret(t *testing.T) {
+	ctx := context.Background()
+	params := url.Values{"invoice_id": {"PAY-1"}, "amount": {"100"}, "status": {string(StatusPaid)}}
+	if _, err := NewFakeProvider("").VerifyCallback(ctx, CallbackRequest{Params: params}); err != ErrInvalidSignature {
+		t.Fatalf("kalitsiz callback: %v", err)
+	}
+	p := NewFakeProvider("s3cret")
+	params.Set("sign", p.Sign("PAY-1", 100, StatusPaid))
+	if _, err := p.VerifyCallback(ctx, CallbackRequest{Params: params}); err != nil {
+		t.Fatalf("to'g'ri imzo: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package payment
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// TelegramProvider Telegram Payments (sendInvoice) orqali to'lov.
+// Invoice xabarini delivery qatlami yuboradi; bu yerda payload va summa tekshiriladi.
+type TelegramProvider struct {
+	Token    string // BotFather bergan provider token
+	Currency string
+}
+
+const TelegramName = "telegram"
+
+func NewTelegramProvider(token, currency string) *TelegramProvider {
+	return &TelegramProvider{Token: strings.TrimSpace(token), Currency: strings.ToUpper(strings.TrimSpace(currency))}
+}
+
+func (p *TelegramProvider) Name() string { return TelegramName }
+
+func (p *TelegramProvider) CreateInvoice(_ context.Context, inv *Invoice) error {
+	if inv.Currency == "" {
+		inv.Currency = p.Currency
+	}
+	inv.Provider = p.Name()
+	return nil
+}
+
+// VerifyCallback pre-checkout yoki successful_payment maydonlarini qabul qiladi:
+// invoice_payload, total_amount, currency va (to'lovdan keyin) telegram_payment_charge_id
+func (p *TelegramProvider) VerifyCallback(_ context.Context, req CallbackRequest) (Callback, error) {
+	payload := strings.TrimSpace(req.Params.Get("invoice_payload"))
+	amount, err := strconv.ParseInt(req.Params.Get("total_amount"), 10, 64)
+	if payload == "" || err != nil || amount <= 0 {
+		return Callback{}, ErrBadRequest
+	}
+	cb := Callback{
+		InvoiceID: payload,
+		Amount:    amount,
+		Currency:  req.Params.Get("currency"),
+		Status:    StatusPending,
+		Method:    "pre_checkout",
+	}
+	if charge := strings.TrimSpace(req.Params.Get("telegram_payment_charge_id")); charge != "" {
+		cb.ExternalID = charge
+		cb.Status = StatusPaid
+		cb.Method = "successful_payment"
+	}
+	return cb, nil
+}
+
+// Refund - provider token orqali qilingan to'lovlar Bot API da qaytarilmaydi
+func (p *TelegramProvider) Refund(_ context.Context, _ *Invoice) error {
+	return ErrManualRefund
+}
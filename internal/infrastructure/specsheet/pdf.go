@@ -0,0 +1,112 @@
+package specsheet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"unicode/utf16"
+)
+
+// A4 o'lchami (pt)
+const (
+	pageWidthPt  = 595.28
+	pageHeightPt = 841.89
+)
+
+// RenderPDF varaqni bitta sahifali PDF qiladi: chizilgan rasm FlateDecode RGB tasvir
+// sifatida butun sahifaga joylanadi (tashqi kutubxona va shriftlarsiz)
+func RenderPDF(s Sheet) ([]byte, error) {
+	img, err := Render(s)
+	if err != nil {
+		return nil, err
+	}
+	pixels, err := compressRGB(img)
+	if err != nil {
+		return nil, err
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	content := fmt.Sprintf("q %.2f 0 0 %.2f 0 0 cm /Im0 Do Q", pageWidthPt, pageHeightPt)
+
+	var pdf pdfWriter
+	pdf.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	pdf.object("<< /Type /Catalog /Pages 2 0 R >>")
+	pdf.object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	pdf.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /XObject << /Im0 5 0 R >> >> /Contents 4 0 R >>",
+		pageWidthPt, pageHeightPt))
+	pdf.stream(fmt.Sprintf("<< /Length %d >>", len(content)), []byte(content))
+	pdf.stream(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+		w, h, len(pixels)), pixels)
+	pdf.object(fmt.Sprintf("<< /Title %s /Producer (specsheet) >>", pdfText(s.Brand+" - "+s.Title)))
+	return pdf.finish(), nil
+}
+
+func compressRGB(img *image.RGBA) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	b := img.Bounds()
+	row := make([]byte, 0, b.Dx()*3)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row = row[:0]
+		for x := b.Min.X; x < b.Max.X; x++ {
+			i := img.PixOffset(x, y)
+			row = append(row, img.Pix[i], img.Pix[i+1], img.Pix[i+2])
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, fmt.Errorf("compress image: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// pdfText satrni UTF-16BE hex string qiladi (kirill va boshqa belgilar uchun)
+func pdfText(s string) string {
+	var sb bytes.Buffer
+	sb.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&sb, "%04X", u)
+	}
+	sb.WriteString(">")
+	return sb.String()
+}
+
+// pdfWriter obyektlarni ketma-ket yozib, xref uchun offsetlarni yig'adi.
+// Obyekt raqamlari yozilish tartibida 1 dan boshlanadi.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (p *pdfWriter) begin() {
+	p.offsets = append(p.offsets, p.buf.Len())
+	fmt.Fprintf(&p.buf, "%d 0 obj\n", len(p.offsets))
+}
+
+func (p *pdfWriter) object(body string) {
+	p.begin()
+	p.buf.WriteString(body)
+	p.buf.WriteString("\nendobj\n")
+}
+
+func (p *pdfWriter) stream(dict string, data []byte) {
+	p.begin()
+	p.buf.WriteString(dict)
+	p.buf.WriteString("\nstream\n")
+	p.buf.Write(data)
+	p.buf.WriteString("\nendstream\nendobj\n")
+}
+
+// finish xref jadvali va trailer qo'shadi; oxirgi obyekt - Info lug'ati
+func (p *pdfWriter) finish() []byte {
+	xref := p.buf.Len()
+	fmt.Fprintf(&p.buf, "xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+	for _, off := range p.offsets {
+		fmt.Fprintf(&p.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&p.buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(p.offsets)+1, len(p.offsets), xref)
+	return p.buf.Bytes()
+}
@@ -0,0 +1,329 @@
+// Package specsheet PC konfiguratsiya uchun brendli spec varaq (PNG/PDF) chizadi.
+// Hamma narsa sof Go'da: matn Go shriftlari bilan rastrlanadi, PDF esa shu rasmni
+// bitta sahifaga joylaydi. Tarjima va ma'lumot tayyorlash chaqiruvchi tomonda.
+package specsheet
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// A4 150 DPI da
+const (
+	Width  = 1240
+	Height = 1754
+
+	margin    = 70
+	maxRows   = 11
+	maxStats  = 6
+	maxBars   = 7
+	rowHeight = 50
+)
+
+var (
+	colorInk    = color.RGBA{0x1b, 0x24, 0x32, 0xff}
+	colorMuted  = color.RGBA{0x6b, 0x75, 0x84, 0xff}
+	colorAccent = color.RGBA{0xff, 0x6a, 0x00, 0xff}
+	colorSoft   = color.RGBA{0xff, 0xc8, 0x9e, 0xff}
+	colorStripe = color.RGBA{0xf3, 0xf5, 0xf8, 0xff}
+	colorLine   = color.RGBA{0xd9, 0xde, 0xe5, 0xff}
+	colorGood   = color.RGBA{0x2e, 0xa0, 0x5a, 0xff}
+	colorWarn   = color.RGBA{0xf0, 0xa5, 0x00, 0xff}
+	colorBad    = color.RGBA{0xd6, 0x3a, 0x3a, 0xff}
+)
+
+// Row jadval qatori: komponent (Label - turi, Value - nomi, Price) yoki ko'rsatkich (Label/Value)
+type Row struct {
+	Label string
+	Value string
+	Price string
+}
+
+// Bar FPS diagrammasi ustuni; Secondary 0 bo'lsa faqat asosiy ustun chiziladi
+type Bar struct {
+	Label     string
+	Primary   int
+	Secondary int
+}
+
+// Power blok quvvati va yuklama; zaxira foizi shulardan hisoblanadi
+type Power struct {
+	Title    string
+	LoadW    int
+	PSUW     int
+	Caption  string
+	Headroom string // "Zaxira" yozuvi, foiz raqami o'zi qo'shiladi
+}
+
+// Sheet chizish uchun tayyor (tarjima qilingan) ma'lumotlar
+type Sheet struct {
+	Brand      string
+	Title      string
+	Subtitle   string
+	Date       string
+	Components []Row
+	TotalLabel string
+	Total      string
+	Stats      []Row
+	ChartTitle string
+	Legend     [2]string
+	Bars       []Bar
+	Power      *Power
+	Footer     string
+}
+
+type faces struct {
+	brand, title, heading, bold, text, small font.Face
+}
+
+var (
+	fontsOnce             sync.Once
+	regularFont, boldFont *opentype.Font
+	fontErr               error
+)
+
+// loadFaces shriftlarni bir marta parse qiladi; font.Face goroutine'lar orasida
+// bo'lishib bo'lmaydi, shuning uchun o'lchamlar har chizishda alohida yaratiladi
+func loadFaces() (faces, error) {
+	fontsOnce.Do(func() {
+		if regularFont, fontErr = opentype.Parse(goregular.TTF); fontErr != nil {
+			return
+		}
+		boldFont, fontErr = opentype.Parse(gobold.TTF)
+	})
+	if fontErr != nil {
+		return faces{}, fontErr
+	}
+	var err error
+	face := func(f *opentype.Font, size float64) font.Face {
+		fc, faceErr := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if faceErr != nil && err == nil {
+			err = faceErr
+		}
+		return fc
+	}
+	set := faces{
+		brand:   face(boldFont, 52),
+		title:   face(regularFont, 28),
+		heading: face(boldFont, 30),
+		bold:    face(boldFont, 23),
+		text:    face(regularFont, 23),
+		small:   face(regularFont, 19),
+	}
+	return set, err
+}
+
+// Render varaqni rasm sifatida chizadi
+func Render(s Sheet) (*image.RGBA, error) {
+	f, err := loadFaces()
+	if err != nil {
+		return nil, fmt.Errorf("load fonts: %w", err)
+	}
+	c := &canvas{img: image.NewRGBA(image.Rect(0, 0, Width, Height)), f: f}
+	c.fill(0, 0, Width, Height, color.White)
+
+	c.header(s)
+	y := 230
+	y = c.components(s, y)
+	y = c.stats(s.Stats, y+20)
+	if len(s.Bars) > 0 {
+		y = c.chart(s, y+30)
+	}
+	if s.Power != nil && s.Power.PSUW > 0 {
+		c.power(*s.Power, y+30)
+	}
+	if s.Footer != "" {
+		c.fill(0, Height-70, Width, 1, colorLine)
+		c.text(f.small, s.Footer, margin, Height-32, Width-2*margin, colorMuted)
+	}
+	return c.img, nil
+}
+
+// RenderPNG varaqni PNG baytlariga chizadi
+func RenderPNG(s Sheet) ([]byte, error) {
+	img, err := Render(s)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type canvas struct {
+	img *image.RGBA
+	f   faces
+}
+
+func (c *canvas) fill(x, y, w, h int, col color.Color) {
+	draw.Draw(c.img, image.Rect(x, y, x+w, y+h), image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// text satrni (x, baseline) nuqtadan chizadi, maxW dan oshsa "..." bilan qisqartiradi
+func (c *canvas) text(face font.Face, s string, x, baseline, maxW int, col color.Color) int {
+	s = fit(face, s, maxW)
+	d := &font.Drawer{Dst: c.img, Src: image.NewUniform(col), Face: face, Dot: fixed.P(x, baseline)}
+	d.DrawString(s)
+	return d.Dot.X.Round() - x
+}
+
+// textRight satrni o'ng chetga (right) tekislab chizadi
+func (c *canvas) textRight(face font.Face, s string, right, baseline int, col color.Color) {
+	w := font.MeasureString(face, s).Round()
+	c.text(face, s, right-w, baseline, w+1, col)
+}
+
+func fit(face font.Face, s string, maxW int) string {
+	if maxW <= 0 || font.MeasureString(face, s).Round() <= maxW {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		cut := strings.TrimRight(string(runes), " ") + "..."
+		if font.MeasureString(face, cut).Round() <= maxW {
+			return cut
+		}
+	}
+	return ""
+}
+
+func (c *canvas) header(s Sheet) {
+	c.fill(0, 0, Width, 170, colorInk)
+	c.fill(0, 170, Width, 8, colorAccent)
+	c.text(c.f.brand, s.Brand, margin, 92, Width/2, color.White)
+	c.text(c.f.title, s.Title, margin, 140, Width-2*margin-260, colorSoft)
+	c.textRight(c.f.title, s.Date, Width-margin, 92, color.White)
+	if s.Subtitle != "" {
+		c.textRight(c.f.title, fit(c.f.title, s.Subtitle, 260), Width-margin, 140, colorSoft)
+	}
+}
+
+func (c *canvas) components(s Sheet, y int) int {
+	rows := s.Components
+	if len(rows) > maxRows {
+		rows = rows[:maxRows]
+	}
+	priceCol := Width - margin - 20
+	for i, r := range rows {
+		if i%2 == 0 {
+			c.fill(margin, y, Width-2*margin, rowHeight, colorStripe)
+		}
+		base := y + rowHeight/2 + 8
+		c.text(c.f.bold, r.Label, margin+20, base, 150, colorMuted)
+		c.text(c.f.text, r.Value, margin+190, base, priceCol-margin-190-180, colorInk)
+		if r.Price != "" {
+			c.textRight(c.f.text, r.Price, priceCol, base, colorInk)
+		}
+		y += rowHeight
+	}
+	if s.Total != "" {
+		c.fill(margin, y, Width-2*margin, 3, colorAccent)
+		base := y + rowHeight/2 + 14
+		c.text(c.f.heading, s.TotalLabel, margin+20, base, 400, colorInk)
+		c.textRight(c.f.heading, s.Total, priceCol, base, colorAccent)
+		y += rowHeight + 10
+	}
+	return y
+}
+
+// stats ko'rsatkichlarni ikki ustunli to'r qilib chizadi
+func (c *canvas) stats(rows []Row, y int) int {
+	if len(rows) > maxStats {
+		rows = rows[:maxStats]
+	}
+	colW := (Width - 2*margin) / 2
+	for i, r := range rows {
+		x := margin + (i%2)*colW
+		base := y + (i/2)*42 + 30
+		w := c.text(c.f.small, r.Label+":", x+20, base, colW/2, colorMuted)
+		c.text(c.f.bold, r.Value, x+30+w, base, colW-w-50, colorInk)
+	}
+	return y + (len(rows)+1)/2*42 + 10
+}
+
+func (c *canvas) chart(s Sheet, y int) int {
+	c.text(c.f.heading, s.ChartTitle, margin, y+30, Width-2*margin-420, colorInk)
+	legendX := Width - margin
+	for i := len(s.Legend) - 1; i >= 0; i-- {
+		if s.Legend[i] == "" {
+			continue
+		}
+		col := colorAccent
+		if i == 1 {
+			col = colorSoft
+		}
+		w := font.MeasureString(c.f.small, s.Legend[i]).Round()
+		legendX -= w
+		c.text(c.f.small, s.Legend[i], legendX, y+28, w+1, colorMuted)
+		legendX -= 30
+		c.fill(legendX, y+12, 20, 20, col)
+		legendX -= 20
+	}
+	y += 55
+
+	bars := s.Bars
+	if len(bars) > maxBars {
+		bars = bars[:maxBars]
+	}
+	peak := 60
+	for _, b := range bars {
+		peak = max(peak, b.Primary, b.Secondary)
+	}
+	left, right := margin+280, Width-margin-90
+	span := right - left
+	for _, b := range bars {
+		c.text(c.f.text, b.Label, margin+20, y+28, 250, colorInk)
+		h := 18
+		if b.Secondary == 0 {
+			h = 30
+		}
+		c.bar(left, y+6, span*b.Primary/peak, h, colorAccent, b.Primary)
+		if b.Secondary > 0 {
+			c.bar(left, y+6+h+4, span*b.Secondary/peak, h, colorSoft, b.Secondary)
+		}
+		y += 52
+	}
+	return y
+}
+
+func (c *canvas) bar(x, y, w, h int, col color.Color, value int) {
+	c.fill(x, y, max(w, 2), h, col)
+	c.text(c.f.small, fmt.Sprintf("%d", value), x+max(w, 2)+10, y+h-2, 80, colorMuted)
+}
+
+func (c *canvas) power(p Power, y int) {
+	c.text(c.f.heading, p.Title, margin, y+30, Width-2*margin, colorInk)
+	y += 55
+	share := float64(p.LoadW) / float64(p.PSUW)
+	headroom := int((1 - share) * 100)
+	col := colorGood
+	switch {
+	case share > 1:
+		col = colorBad
+	case share > 0.8:
+		col = colorWarn
+	}
+	w := Width - 2*margin
+	c.fill(margin, y, w, 36, colorStripe)
+	c.fill(margin, y, int(float64(w)*min(share, 1)), 36, col)
+	y += 70
+	c.text(c.f.text, p.Caption, margin+20, y, w-300, colorInk)
+	if p.Headroom != "" {
+		c.textRight(c.f.bold, fmt.Sprintf("%s: %d%%", p.Headroom, headroom), Width-margin, y, col)
+	}
+}
@@ -0,0 +1,81 @@
+package specsheet
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func testSheet() Sheet {
+	return Sheet{
+		Brand:    "InGame",
+		Title:    "Спецификация компьютера",
+		Subtitle: "Gaming",
+		Date:     "18.10.2026",
+		Components: []Row{
+			{Label: "CPU", Value: "AMD Ryzen 5 7600", Price: "199.00$"},
+			{Label: "GPU", Value: "NVIDIA GeForce RTX 4060 Ti 8GB juda uzun nom bilan, qisqartirilishi kerak bo'lgan", Price: "399.00$"},
+			{Label: "PSU", Value: "650W 80+ Bronze", Price: "60.00$"},
+		},
+		TotalLabel: "Итого",
+		Total:      "658.00$",
+		Stats:      []Row{{Label: "Reyting", Value: "8.1/10"}, {Label: "Bottleneck", Value: "GPU 12%"}},
+		ChartTitle: "FPS",
+		Legend:     [2]string{"1080p", "1440p"},
+		Bars:       []Bar{{Label: "CS2", Primary: 310, Secondary: 240}, {Label: "Cyberpunk 2077", Primary: 72}},
+		Power:      &Power{Title: "Quvvat", LoadW: 420, PSUW: 650, Caption: "~420W / 650W", Headroom: "Zaxira"},
+		Footer:     "@Ingame_support",
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	data, err := RenderPNG(testSheet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png o'qilmadi: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+		t.Errorf("o'lcham = %v", b)
+	}
+	// Yuklama blokdan oshsa ham (manfiy zaxira) chizish xatosiz bo'lishi kerak
+	sheet := testSheet()
+	sheet.Power.LoadW = 800
+	if _, err := RenderPNG(sheet); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	data, err := RenderPDF(testSheet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("PDF sarlavha yoki oxiri noto'g'ri")
+	}
+	if !bytes.Contains(data, []byte(fmt.Sprintf("/Width %d /Height %d", Width, Height))) {
+		t.Error("rasm obyekti topilmadi")
+	}
+	// xref offsetlari obyektlar boshiga to'g'ri kelishi kerak
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("startxref yo'q")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	if len(entries) != 6 {
+		t.Fatalf("xref yozuvlari = %d", len(entries))
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(data[off:], []byte(want)) {
+			t.Errorf("xref %d noto'g'ri offset %d", i+1, off)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryPromotionRepository struct {
+	mu     sync.RWMutex
+	promos map[string]entity.Promotion // key: promotion ID
+	path   string                      // bo'sh bo'lmasa har o'zgarishda JSON ga yoziladi
+}
+
+// NewMemoryPromotionRepository in-memory promotion repository yaratish.
+// path berilsa aksiyalar shu JSON fayldan yuklanadi va o'zgarishlar unga saqlanadi.
+func NewMemoryPromotionRepository(path string) repository.PromotionRepository {
+	m := &memoryPromotionRepository{
+		promos: make(map[string]entity.Promotion),
+		path:   path,
+	}
+	if path != "" {
+		if b, err := os.ReadFile(path); err == nil {
+			var list []entity.Promotion
+			if err := json.Unmarshal(b, &list); err == nil {
+				for _, p := range list {
+					m.promos[p.ID] = p
+				}
+			}
+		}
+	}
+	return m
+}
+
+// List barcha aksiyalarni olish (ID bo'yicha tartiblangan)
+func (m *memoryPromotionRepository) List(ctx context.Context) ([]entity.Promotion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sortedLocked(), nil
+}
+
+// Save aksiyani saqlash
+func (m *memoryPromotionRepository) Save(ctx context.Context, promo entity.Promotion) error {
+	if promo.ID == "" {
+		return fmt.Errorf("promotion id is empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.promos[promo.ID] = promo
+	return m.persistLocked()
+}
+
+// Delete aksiyani o'chirish
+func (m *memoryPromotionRepository) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.promos[id]; !ok {
+		return fmt.Errorf("promotion not found: %s", id)
+	}
+	delete(m.promos, id)
+	return m.persistLocked()
+}
+
+// AddUses qo'llangan aksiyalar foydalanish sonini oshirish (limit tugagan bo'lsa hech biri)
+func (m *memoryPromotionRepository) AddUses(ctx context.Context, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		if p, ok := m.promos[id]; ok && p.Exhausted() {
+			return fmt.Errorf("%w: %s", entity.ErrPromotionExhausted, id)
+		}
+	}
+	for _, id := range ids {
+		if p, ok := m.promos[id]; ok {
+			p.Uses++
+			m.promos[id] = p
+		}
+	}
+	return m.persistLocked()
+}
+
+// ReleaseUses bekor qilingan buyurtma aksiyalari foydalanish sonini kamaytirish
+func (m *memoryPromotionRepository) ReleaseUses(ctx context.Context, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		if p, ok := m.promos[id]; ok && p.Uses > 0 {
+			p.Uses--
+			m.promos[id] = p
+		}
+	}
+	return m.persistLocked()
+}
+
+func (m *memoryPromotionRepository) sortedLocked() []entity.Promotion {
+	list := make([]entity.Promotion, 0, len(m.promos))
+	for _, p := range m.promos {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (m *memoryPromotionRepository) persistLocked() error {
+	if m.path == "" {
+		return nil
+	}
+	if dir := filepath.Dir(m.path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(m.sortedLocked(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, b, 0o600)
+}
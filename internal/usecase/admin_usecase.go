@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,6 +34,9 @@ type AdminUseCase interface {
 
 	// CleanAll barcha mahsulotlar va chat tarixlarini tozalash
 	CleanAll(ctx context.Context, userID int64) error
+
+	// SetCatalogListener katalog yangilangandan keyin chaqiriladigan funksiya (obunalarni tekshirish)
+	SetCatalogListener(fn func(ctx context.Context, products []entity.Product))
 }
 
 type adminUseCase struct {
@@ -41,6 +45,7 @@ type adminUseCase struct {
 	excelParser   repository.ExcelParser
 	chatRepo      repository.ChatRepository
 	adminPassword string
+	onCatalog     func(ctx context.Context, products []entity.Product)
 }
 
 // NewAdminUseCase yangi AdminUseCase yaratish
@@ -116,6 +121,11 @@ func (u *adminUseCase) uploadCatalogInternal(ctx context.Context, fileData []byt
 		return 0, fmt.Errorf("no products found in excel file")
 	}
 
+	// Xususiyatlarni normallashtirish (soket, VRAM, hajm, quvvat...) - keyingi bosqichlar nomni qayta parse qilmaydi
+	if report := NormalizeCatalogSpecs(products); len(report.Gaps) > 0 {
+		log.Printf("catalog specs: %d/%d complete, %d with gaps", report.Complete, report.Checked, len(report.Gaps))
+	}
+
 	// Katalogni yangilash
 	catalog := entity.ProductCatalog{
 		Products:  products,
@@ -126,10 +136,17 @@ func (u *adminUseCase) uploadCatalogInternal(ctx context.Context, fileData []byt
 	if err := u.productRepo.UpdateCatalog(ctx, catalog); err != nil {
 		return 0, fmt.Errorf("failed to update catalog: %w", err)
 	}
+	if u.onCatalog != nil {
+		u.onCatalog(ctx, products)
+	}
 
 	return len(products), nil
 }
 
+func (u *adminUseCase) SetCatalogListener(fn func(ctx context.Context, products []entity.Product)) {
+	u.onCatalog = fn
+}
+
 // Logout admin logout qilish
 func (u *adminUseCase) Logout(ctx context.Context, userID int64) error {
 	return u.adminRepo.DeleteSession(ctx, userID)
@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Mahalliy benchmark bazasi: CPU/GPU nisbiy ballari va o'yinlar bo'yicha FPS jadvallari.
+// PC tahlilidagi raqamlar shu bazadan deterministik hisoblanadi; admin CSV bilan yangilaydi.
+
+//go:embed benchdata/benchmarks.csv
+var defaultBenchmarksCSV []byte
+
+// Benchmark CSV ustunlari (sarlavha bo'yicha o'qiladi, tartibi ixtiyoriy)
+const (
+	benchColKind    = "kind"
+	benchColName    = "name"
+	benchColAliases = "aliases"
+	benchColScore   = "score"
+	benchColTDP     = "tdp"
+	benchColGPU     = "gpu"
+	benchCol1080    = "fps_1080p"
+	benchCol1440    = "fps_1440p"
+	benchColCPUFPS  = "cpu_fps"
+)
+
+// BenchEntry CPU yoki GPU yozuvi
+type BenchEntry struct {
+	Name  string
+	Keys  []string // normallashtirilgan nom va taxalluslar
+	Score float64  // nisbiy unumdorlik (etalon = 100)
+	TDP   int      // W, 0 - noma'lum
+}
+
+// BenchGame o'yin uchun etalon FPS: GPU chegarasi (score 100 GPU) va CPU chegarasi (score 100 CPU)
+type BenchGame struct {
+	Name    string
+	Keys    []string
+	FPS1080 float64
+	FPS1440 float64
+	CPUFPS  float64
+}
+
+// benchMeasured aniq GPU uchun o'lchangan FPS
+type benchMeasured struct {
+	FPS1080 float64
+	FPS1440 float64
+}
+
+// BenchmarkDB o'zgarmas benchmark bazasi (yangilanishda butunlay almashtiriladi)
+type BenchmarkDB struct {
+	CPUs     []BenchEntry
+	GPUs     []BenchEntry
+	Games    []BenchGame
+	measured map[string]map[string]benchMeasured // o'yin -> GPU nomi -> FPS
+}
+
+var (
+	defaultBenchOnce sync.Once
+	defaultBench     *BenchmarkDB
+)
+
+// DefaultBenchmarks dastur bilan birga keladigan baza
+func DefaultBenchmarks() *BenchmarkDB {
+	defaultBenchOnce.Do(func() {
+		db, err := ParseBenchmarkCSV(bytes.NewReader(defaultBenchmarksCSV))
+		if err != nil {
+			panic(fmt.Sprintf("embedded benchmarks: %v", err))
+		}
+		defaultBench = db
+	})
+	return defaultBench
+}
+
+// DefaultBenchmarksCSV o'rnatilgan CSV (admin uchun shablon)
+func DefaultBenchmarksCSV() []byte {
+	return append([]byte(nil), defaultBenchmarksCSV...)
+}
+
+var (
+	reBenchSep      = regexp.MustCompile(`[\s\-_/(),]+`)
+	reBenchLetterNo = regexp.MustCompile(`([a-z])(\d)`)
+)
+
+// benchKey solishtirish uchun: kichik harf, ajratgichlar bo'shliq, "rtx4070" -> "rtx 4070"
+func benchKey(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = reBenchLetterNo.ReplaceAllString(s, "$1 $2")
+	return strings.TrimSpace(reBenchSep.ReplaceAllString(s, " "))
+}
+
+func benchKeys(name, aliases string) []string {
+	keys := []string{benchKey(name)}
+	for _, a := range strings.Split(aliases, "|") {
+		if k := benchKey(a); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// benchMatch matnda butun so'z sifatida uchragan eng uzun kalit; -1 - topilmadi
+func benchMatch(text string, keys [][]string) int {
+	padded := " " + benchKey(text) + " "
+	best, bestLen := -1, 0
+	for i, ks := range keys {
+		for _, k := range ks {
+			if len(k) > bestLen && strings.Contains(padded, " "+k+" ") {
+				best, bestLen = i, len(k)
+			}
+		}
+	}
+	return best
+}
+
+// ParseBenchmarkCSV CSV ni o'qiydi; "#" bilan boshlangan qatorlar izoh
+func ParseBenchmarkCSV(r io.Reader) (*BenchmarkDB, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, required := range []string{benchColKind, benchColName} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+
+	db := &BenchmarkDB{measured: map[string]map[string]benchMeasured{}}
+	type pendingFPS struct {
+		line      int
+		game, gpu string
+		fps       benchMeasured
+	}
+	var pending []pendingFPS
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(col string) string {
+			if i, ok := cols[col]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		number := func(col string) (float64, error) {
+			v := field(col)
+			if v == "" {
+				return 0, nil
+			}
+			n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("line %d: invalid %s %q", line, col, v)
+			}
+			return n, nil
+		}
+
+		kind := strings.ToLower(field(benchColKind))
+		name := field(benchColName)
+		if kind == "" && name == "" {
+			continue
+		}
+		if name == "" {
+			return nil, fmt.Errorf("line %d: empty name", line)
+		}
+		switch kind {
+		case "cpu", "gpu":
+			score, err := number(benchColScore)
+			if err != nil {
+				return nil, err
+			}
+			if score <= 0 {
+				return nil, fmt.Errorf("line %d: %s needs a positive score", line, name)
+			}
+			tdp, err := number(benchColTDP)
+			if err != nil {
+				return nil, err
+			}
+			e := BenchEntry{Name: name, Keys: benchKeys(name, field(benchColAliases)), Score: score, TDP: int(tdp)}
+			if kind == "cpu" {
+				db.CPUs = append(db.CPUs, e)
+			} else {
+				db.GPUs = append(db.GPUs, e)
+			}
+		case "game":
+			g := BenchGame{Name: name, Keys: benchKeys(name, field(benchColAliases))}
+			for col, dst := range map[string]*float64{benchCol1080: &g.FPS1080, benchCol1440: &g.FPS1440, benchColCPUFPS: &g.CPUFPS} {
+				if *dst, err = number(col); err != nil {
+					return nil, err
+				}
+			}
+			if g.FPS1080 <= 0 || g.CPUFPS <= 0 {
+				return nil, fmt.Errorf("line %d: %s needs %s and %s", line, name, benchCol1080, benchColCPUFPS)
+			}
+			if g.FPS1440 <= 0 {
+				g.FPS1440 = g.FPS1080 * 0.65
+			}
+			db.Games = append(db.Games, g)
+		case "fps":
+			p := pendingFPS{line: line, game: name, gpu: field(benchColGPU)}
+			if p.fps.FPS1080, err = number(benchCol1080); err != nil {
+				return nil, err
+			}
+			if p.fps.FPS1440, err = number(benchCol1440); err != nil {
+				return nil, err
+			}
+			pending = append(pending, p)
+		default:
+			return nil, fmt.Errorf("line %d: unknown kind %q (cpu, gpu, game, fps)", line, kind)
+		}
+	}
+	if len(db.CPUs) == 0 || len(db.GPUs) == 0 || len(db.Games) == 0 {
+		return nil, fmt.Errorf("need at least one cpu, gpu and game row")
+	}
+
+	// O'lchovlar o'yin va GPU yozuvlariga bog'lanadi (tartibdan qat'i nazar)
+	gameKeys, gpuKeys := db.gameKeys(), db.gpuKeys()
+	for _, p := range pending {
+		gi := benchMatch(p.game, gameKeys)
+		if gi < 0 {
+			return nil, fmt.Errorf("line %d: unknown game %q", p.line, p.game)
+		}
+		gpu := benchMatch(p.gpu, gpuKeys)
+		if gpu < 0 {
+			return nil, fmt.Errorf("line %d: unknown gpu %q", p.line, p.gpu)
+		}
+		game, gpuName := db.Games[gi].Name, db.GPUs[gpu].Name
+		if db.measured[game] == nil {
+			db.measured[game] = map[string]benchMeasured{}
+		}
+		db.measured[game][gpuName] = p.fps
+	}
+	return db, nil
+}
+
+func (db *BenchmarkDB) gpuKeys() [][]string {
+	keys := make([][]string, len(db.GPUs))
+	for i, e := range db.GPUs {
+		keys[i] = e.Keys
+	}
+	return keys
+}
+
+func (db *BenchmarkDB) cpuKeys() [][]string {
+	keys := make([][]string, len(db.CPUs))
+	for i, e := range db.CPUs {
+		keys[i] = e.Keys
+	}
+	return keys
+}
+
+func (db *BenchmarkDB) gameKeys() [][]string {
+	keys := make([][]string, len(db.Games))
+	for i, g := range db.Games {
+		keys[i] = g.Keys
+	}
+	return keys
+}
+
+// MeasuredCount aniq o'lchovlar soni
+func (db *BenchmarkDB) MeasuredCount() int {
+	n := 0
+	for _, m := range db.measured {
+		n += len(m)
+	}
+	return n
+}
+
+// LookupCPU mahsulot nomi bo'yicha CPU yozuvi
+func (db *BenchmarkDB) LookupCPU(p entity.Product) (BenchEntry, bool) {
+	if i := benchMatch(p.Name, db.cpuKeys()); i >= 0 {
+		return db.CPUs[i], true
+	}
+	return BenchEntry{}, false
+}
+
+// LookupGPU mahsulot nomi (va aniqlangan GPU chipi) bo'yicha GPU yozuvi
+func (db *BenchmarkDB) LookupGPU(p entity.Product) (BenchEntry, bool) {
+	if i := benchMatch(p.Name+" "+p.Spec(entity.SpecGPUChip), db.gpuKeys()); i >= 0 {
+		return db.GPUs[i], true
+	}
+	return BenchEntry{}, false
+}
+
+// cpuBenchScore bazada bo'lmasa CPU darajasidan taxminiy ball (0-10 -> etalonga nisbatan)
+func (db *BenchmarkDB) cpuBenchScore(p entity.Product) (float64, int, bool) {
+	if e, ok := db.LookupCPU(p); ok {
+		return e.Score, e.TDP, true
+	}
+	score, _, _ := scoreCPUProduct(p)
+	return score * 10, 0, false
+}
+
+// gpuBenchScore bazada bo'lmasa GPU darajasidan taxminiy ball (scoreGPU ~ 10*(s/100)^0.4)
+func (db *BenchmarkDB) gpuBenchScore(p entity.Product) (float64, int, string, bool) {
+	if e, ok := db.LookupGPU(p); ok {
+		return e.Score, e.TDP, e.Name, true
+	}
+	score, _ := scoreGPUProduct(p)
+	return 100 * math.Pow(score/10, 2.5), 0, "", false
+}
+
+// gameLimits o'yin uchun GPU chegarasi (o'lchov yoki ball bo'yicha) va CPU chegarasi
+func (db *BenchmarkDB) gameLimits(g BenchGame, cpuScore, gpuScore float64, gpuName string) (gpu1080, gpu1440, cpuCap float64) {
+	gpu1080 = g.FPS1080 * gpuScore / 100
+	gpu1440 = g.FPS1440 * gpuScore / 100
+	if m, ok := db.measured[g.Name][gpuName]; ok && gpuName != "" {
+		if m.FPS1080 > 0 {
+			gpu1080 = m.FPS1080
+		}
+		if m.FPS1440 > 0 {
+			gpu1440 = m.FPS1440
+		}
+	}
+	return gpu1080, gpu1440, g.CPUFPS * cpuScore / 100
+}
+
+// GameFPS o'yin uchun 1080p/1440p FPS: GPU va CPU chegaralarining kichigi
+func (db *BenchmarkDB) GameFPS(g BenchGame, cpuScore, gpuScore float64, gpuName string) (fps1080, fps1440 int) {
+	gpu1080, gpu1440, cpuCap := db.gameLimits(g, cpuScore, gpuScore, gpuName)
+	return int(math.Round(math.Min(gpu1080, cpuCap))), int(math.Round(math.Min(gpu1440, cpuCap)))
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+func TestBenchmarkLookup(t *testing.T) {
+	db := DefaultBenchmarks()
+	cases := map[string]string{
+		"Intel Core i5-13400F BOX":             "Core i5-13400",
+		"AMD Ryzen 5 5600X":                    "Ryzen 5 5600X",
+		"AMD Ryzen 5 5600":                     "Ryzen 5 5600",
+		"AMD Ryzen 7 7800X3D (8/16)":           "Ryzen 7 7800X3D",
+		"MSI GeForce RTX 4060 Ti Ventus 2X 8G": "GeForce RTX 4060 Ti",
+		"Sapphire Pulse RX 7900 XTX 24GB":      "Radeon RX 7900 XTX",
+		"Palit RTX4070 Dual":                   "GeForce RTX 4070",
+	}
+	for name, want := range cases {
+		var got string
+		if e, ok := db.LookupCPU(entity.Product{Name: name}); ok {
+			got = e.Name
+		} else if e, ok := db.LookupGPU(entity.Product{Name: name}); ok {
+			got = e.Name
+		}
+		if got != want {
+			t.Errorf("%s: %q, kutilgan %q", name, got, want)
+		}
+	}
+}
+
+func TestComputeDeterministic(t *testing.T) {
+	analyzer := NewPCAnalyzer(nil, nil)
+	build := &entity.PCBuild{
+		Purpose: "Gaming",
+		CPU:     entity.Product{Name: "Intel Core i5-13400F"},
+		GPU:     entity.Product{Name: "RTX 4070"},
+		RAM:     entity.Product{Name: "32GB DDR5 6000"},
+		SSD:     entity.Product{Name: "Samsung 980 NVMe 1TB"},
+		PSU:     entity.Product{Name: "Corsair RM750e"},
+	}
+	a := analyzer.Compute(build, "uz")
+	if cs := a.FPS["CS2"]; cs.FPS1080p != 330 || cs.FPS1440p != 205 {
+		t.Fatalf("CS2 o'lchovdan olinishi kerak: %+v", cs)
+	}
+	if a.Bottleneck.HasBottleneck {
+		t.Fatalf("13400F + 4070 muvozanatda: %+v", a.Bottleneck)
+	}
+	if b := analyzer.Compute(build, "uz"); b.FPS["Cyberpunk 2077"] != a.FPS["Cyberpunk 2077"] || b.OverallScore != a.OverallScore {
+		t.Fatalf("natija o'zgarmasligi kerak")
+	}
+
+	build.CPU = entity.Product{Name: "Core i3-10100F"}
+	build.GPU = entity.Product{Name: "RTX 4090"}
+	if a := analyzer.Compute(build, "uz"); a.Bottleneck.BottleneckType != "CPU" {
+		t.Fatalf("CPU bottleneck kutilgan: %+v", a.Bottleneck)
+	}
+	// Izoh katalogdan, foiz matn ichida
+	enAnalytics := analyzer.Compute(build, "en")
+	gaming := enAnalytics.UseCaseMatch.Matches["Gaming"]
+	if len(gaming.Strengths) == 0 || gaming.Strengths[0] != "Strong GPU: for high FPS" {
+		t.Fatalf("inglizcha kuchli tomonlar: %+v", gaming)
+	}
+	en := enAnalytics.Bottleneck
+	if !strings.HasPrefix(en.Description, "At 1080p the processor") || !strings.Contains(en.Description, fmt.Sprintf("~%.0f%%", en.Percentage)) {
+		t.Fatalf("inglizcha izoh: %q", en.Description)
+	}
+}
+
+func TestParseBenchmarkCSVErrors(t *testing.T) {
+	bad := []string{
+		"name,score\nX,1\n",
+		"kind,name,score\ncpu,X,0\n",
+		"kind,name,score\nssd,X,10\n",
+		"kind,name,score,fps_1080p,cpu_fps,gpu\ncpu,A,50,,,\ngpu,B,50,,,\ngame,G,,100,100,\nfps,G,,90,,C\n",
+	}
+	for _, csv := range bad {
+		if _, err := ParseBenchmarkCSV(strings.NewReader(csv)); err == nil {
+			t.Errorf("xato kutilgan: %q", csv)
+		}
+	}
+}
@@ -0,0 +1,462 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Budjet optimizatori: qoldiqdagi komponentlardan moslik qoidalariga mos kombinatsiyalarni
+// ko'rib chiqadi va maqsad bo'yicha og'irlangan ballni (buildUseCaseScore) budjet ichida
+// maksimallashtiradi. Qidiruv maydoni Pareto-saralash bilan qisqartiriladi: qimmatroq, lekin
+// kuchliroq bo'lmagan komponent hech qachon eng yaxshi yig'maga kirmaydi.
+
+// minStorageGB - optimizator taklif qiladigan diskning eng kichik hajmi (hajm ma'lum bo'lsa)
+const minStorageGB = 480
+
+// igpuScore - o'rnatilgan grafikaning GPU bali
+const igpuScore = 3.0
+
+// OptimizeRequest - optimizator parametrlari
+type OptimizeRequest struct {
+	BudgetUSD float64
+	Purpose   string                       // Gaming/Office/Design/Developer/Server yoki erkin matn
+	CPUBrand  string                       // "intel", "amd"; bo'sh - farqi yo'q
+	GPUBrand  string                       // "rtx", "amd"...; bo'sh - farqi yo'q
+	NoGPU     bool                         // diskret videokarta kerak emas
+	Branch    string                       // qoldiq shu filial bo'yicha
+	Price     func(entity.Product) float64 // narx dollarda; nil - Price.Major()
+	Bench     *BenchmarkDB                 // nil - standart baza
+}
+
+// OptimizedBuild - bitta variant; deltalar ro'yxatdagi eng yaxshi variantga nisbatan
+type OptimizedBuild struct {
+	Build      *entity.PCBuild
+	PriceUSD   float64
+	Score      float64
+	Violations []Violation // faqat ogohlantirishlar - xatoli yig'malar taklif qilinmaydi
+	PriceDelta float64
+	ScoreDelta float64
+}
+
+// optPart - nomzod komponent va uning solishtirma ko'rsatkichi
+type optPart struct {
+	p      entity.Product
+	price  float64
+	perf   float64 // hardwareProfile dagi 0-10 ball (RAM uchun - GB)
+	group  string  // Pareto guruhi: soket yoki DDR avlodi
+	server bool
+	label  string // storageType
+}
+
+type optimizer struct {
+	req     OptimizeRequest
+	useCase string
+	compat  *CompatibilityChecker
+	bench   *BenchmarkDB
+
+	cpus, boards, rams, gpus, storage []optPart
+	psus, coolers, cases              []optPart
+}
+
+// OptimizeBuilds budjet ichidagi eng yuqori ballli limit ta variant (CPU+GPU juftligi har xil).
+// Kerakli kategoriyalardan biri bo'lmasa yoki budjet yetmasa bo'sh ro'yxat.
+func OptimizeBuilds(products []entity.Product, req OptimizeRequest, limit int) []OptimizedBuild {
+	if req.BudgetUSD <= 0 || limit <= 0 {
+		return nil
+	}
+	o := &optimizer{req: req, useCase: normalizeUseCase(req.Purpose), compat: NewCompatibilityChecker(), bench: req.Bench}
+	if o.useCase == "" {
+		o.useCase = "Gaming"
+	}
+	if o.bench == nil {
+		o.bench = DefaultBenchmarks()
+	}
+	if o.req.Price == nil {
+		o.req.Price = func(p entity.Product) float64 { return p.Price.Major() }
+	}
+	o.collect(inStockOrAll(products, req.Branch))
+	if len(o.cpus) == 0 || len(o.boards) == 0 || len(o.rams) == 0 || len(o.storage) == 0 {
+		return nil
+	}
+	return o.search(limit)
+}
+
+// inStockOrAll qoldig'i bor mahsulotlar; katalogda qoldiq ustuni bo'lmasa (hammasi 0) - barchasi
+func inStockOrAll(products []entity.Product, branch string) []entity.Product {
+	if in := filterInStockProducts(products, branch); len(in) > 0 {
+		return in
+	}
+	return products
+}
+
+// matchesBrand nom yoki GPU chipida brend bormi ("rtx" -> NVIDIA, "amd" -> Radeon ham)
+func matchesBrand(p entity.Product, brand string) bool {
+	brand = strings.ToLower(strings.TrimSpace(brand))
+	text := strings.ToLower(p.Name + " " + p.Spec(entity.SpecGPUChip))
+	switch brand {
+	case "rtx", "nvidia", "geforce":
+		return containsAny(text, "rtx", "gtx", "geforce", "nvidia")
+	case "amd", "radeon":
+		return containsAny(text, "amd", "radeon", "ryzen", "rx ")
+	case "intel":
+		return containsAny(text, "intel", "core", "pentium", "celeron", "xeon", "arc ")
+	}
+	return strings.Contains(text, brand)
+}
+
+// byBrand brend bo'yicha filtr; mosi bo'lmasa ro'yxat o'zgarmaydi
+func byBrand(parts []optPart, brand string) []optPart {
+	if strings.TrimSpace(brand) == "" {
+		return parts
+	}
+	var out []optPart
+	for _, c := range parts {
+		if matchesBrand(c.p, brand) {
+			out = append(out, c)
+		}
+	}
+	if len(out) == 0 {
+		return parts
+	}
+	return out
+}
+
+func (o *optimizer) collect(products []entity.Product) {
+	for _, p := range products {
+		price := o.req.Price(p)
+		if price <= 0 || price > o.req.BudgetUSD {
+			continue
+		}
+		c := optPart{p: p, price: price}
+		switch SpecKind(p.Category, p.Name) {
+		case PartCPU:
+			c.perf, c.server = benchCPUScore(o.bench, p)
+			c.group = PartAttrs(PartCPU, p).Socket
+			if o.req.NoGPU && PartAttrs(PartCPU, p).NoIGPU {
+				continue
+			}
+			o.cpus = append(o.cpus, c)
+		case PartMotherboard:
+			o.boards = append(o.boards, c)
+		case PartRAM:
+			c.perf = float64(productRAMGB(p))
+			if gens := PartAttrs(PartRAM, p).Memory; len(gens) == 1 {
+				c.group = gens[0]
+			}
+			o.rams = append(o.rams, c)
+		case PartGPU:
+			if !o.req.NoGPU {
+				c.perf = benchGPUScore(o.bench, p)
+				o.gpus = append(o.gpus, c)
+			}
+		case PartStorage:
+			if gb := productStorageGB(p); gb > 0 && gb < minStorageGB {
+				continue
+			}
+			c.perf, c.label, _, _ = scoreStorage(storageDescriptor(p))
+			o.storage = append(o.storage, c)
+		case PartPSU:
+			o.psus = append(o.psus, c)
+		case PartCooler:
+			o.coolers = append(o.coolers, c)
+		case PartCase:
+			o.cases = append(o.cases, c)
+		}
+	}
+	o.cpus = paretoFront(byBrand(o.cpus, o.req.CPUBrand))
+	o.gpus = paretoFront(byBrand(o.gpus, o.req.GPUBrand))
+	o.rams = paretoFront(o.rams)
+	o.storage = paretoFront(o.storage)
+	for _, list := range [][]optPart{o.boards, o.psus, o.coolers, o.cases} {
+		sortByPrice(list)
+	}
+}
+
+// benchCPUScore benchmark bazasidagi ball (etalon 100 -> 10), bo'lmasa CPU darajasi
+func benchCPUScore(db *BenchmarkDB, p entity.Product) (float64, bool) {
+	tier, _, server := scoreCPUProduct(p)
+	if e, ok := db.LookupCPU(p); ok {
+		return clampScore(e.Score / 10), server
+	}
+	return tier, server
+}
+
+// benchGPUScore gpuBenchScore ning teskarisi: 10*(s/100)^0.4; diskret karta yo'q - o'rnatilgan grafika
+func benchGPUScore(db *BenchmarkDB, p entity.Product) float64 {
+	if !hasGPU(p) {
+		return igpuScore
+	}
+	if e, ok := db.LookupGPU(p); ok {
+		return clampScore(10 * math.Pow(e.Score/100, 0.4))
+	}
+	score, _ := scoreGPUProduct(p)
+	return score
+}
+
+// benchProfile yig'maning maqsad bali uchun profili (optimizator bilan bir xil shkalada)
+func benchProfile(db *BenchmarkDB, build *entity.PCBuild) hardwareProfile {
+	cpu, server := benchCPUScore(db, build.CPU)
+	storage, label, _, _ := scoreStorage(storageDescriptor(build.SSD))
+	ramGB := productRAMGB(build.RAM)
+	return hardwareProfile{
+		cpuScore:     cpu,
+		gpuScore:     benchGPUScore(db, build.GPU),
+		ramScore:     scoreRAM(ramGB),
+		storageScore: storage,
+		ramGB:        ramGB,
+		storageType:  label,
+		serverCPU:    server,
+	}
+}
+
+func sortByPrice(parts []optPart) {
+	sort.SliceStable(parts, func(i, j int) bool { return parts[i].price < parts[j].price })
+}
+
+// paretoFront har guruhda arzonroq va kamida shunchalik kuchli muqobili bor nomzodlarni tashlaydi
+func paretoFront(parts []optPart) []optPart {
+	sort.SliceStable(parts, func(i, j int) bool {
+		if parts[i].price != parts[j].price {
+			return parts[i].price < parts[j].price
+		}
+		return parts[i].perf > parts[j].perf
+	})
+	best := map[string]float64{}
+	var out []optPart
+	for _, c := range parts {
+		if b, ok := best[c.group]; ok && c.perf <= b {
+			continue
+		}
+		best[c.group] = c.perf
+		out = append(out, c)
+	}
+	return out
+}
+
+// boardsFor CPU ga mos har bir DDR avlodidagi eng arzon plata (ogohlantirishsizi afzal)
+func (o *optimizer) boardsFor(cpu optPart) []optPart {
+	type pick struct {
+		part  optPart
+		clean bool
+	}
+	picks := map[string]pick{}
+	var order []string
+	for _, b := range o.boards {
+		// CPU+plata juftligi qoidalari (display_output kabi GPU ga bog'liqlari GPU bilan tekshiriladi)
+		var vs []Violation
+		for _, v := range o.compat.Check(&entity.PCBuild{CPU: cpu.p, Motherboard: b.p}) {
+			if containsString(v.Components, strings.TrimSpace(b.p.Name)) {
+				vs = append(vs, v)
+			}
+		}
+		if HasCompatErrors(vs) {
+			continue
+		}
+		gen := strings.Join(PartAttrs(PartMotherboard, b.p).Memory, "/")
+		cur, seen := picks[gen]
+		if !seen {
+			order = append(order, gen)
+		}
+		// platalar narx bo'yicha saralangan: birinchi mos - eng arzon
+		if !seen || (!cur.clean && len(vs) == 0) {
+			picks[gen] = pick{part: b, clean: len(vs) == 0}
+		}
+	}
+	out := make([]optPart, 0, len(order))
+	for _, gen := range order {
+		out = append(out, picks[gen].part)
+	}
+	return out
+}
+
+// ramsFor plata va CPU qo'llaydigan DDR avlodidagi (yoki avlodi noma'lum) modullar
+func (o *optimizer) ramsFor(cpu, board optPart) []optPart {
+	cpuGens := PartAttrs(PartCPU, cpu.p).Memory
+	boardGens := PartAttrs(PartMotherboard, board.p).Memory
+	var out []optPart
+	for _, r := range o.rams {
+		if r.group != "" && ((len(cpuGens) > 0 && !containsString(cpuGens, r.group)) ||
+			(len(boardGens) == 1 && boardGens[0] != r.group)) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// firstFitting narx bo'yicha saralangan ro'yxatdan xatosiz eng arzon nomzod; yangi ogohlantirish
+// qo'shmaydigani afzal. accept nil bo'lmasa avval unga mos nomzodlar qidiriladi.
+func (o *optimizer) firstFitting(list []optPart, build *entity.PCBuild, place func(*entity.PCBuild, *entity.Product), accept func(optPart) bool) (*entity.Product, float64, bool) {
+	base := len(o.compat.Check(build))
+	for _, strict := range []bool{true, false} {
+		if strict && accept == nil {
+			continue
+		}
+		var fallback *optPart
+		for i := range list {
+			c := list[i]
+			if strict && !accept(c) {
+				continue
+			}
+			trial := *build
+			product := c.p
+			place(&trial, &product)
+			vs := o.compat.Check(&trial)
+			if HasCompatErrors(vs) {
+				continue
+			}
+			if len(vs) <= base {
+				return &product, c.price, true
+			}
+			if fallback == nil {
+				fallback = &list[i]
+			}
+		}
+		if fallback != nil {
+			product := fallback.p
+			return &product, fallback.price, true
+		}
+	}
+	return nil, 0, false
+}
+
+// support PSU, sovutgich va korpusni tanlaydi (ball bermaydi - eng arzon mosi).
+// Katalogda tur umuman bo'lmasa o'tkazib yuboriladi; bor-u, mosi topilmasa false.
+func (o *optimizer) support(build *entity.PCBuild) (float64, bool) {
+	total := 0.0
+	if len(o.psus) > 0 {
+		_, recommended := EstimatePower(build)
+		psu, price, ok := o.firstFitting(o.psus, build, func(b *entity.PCBuild, p *entity.Product) { b.PSU = *p }, func(c optPart) bool {
+			return PartAttrs(PartPSU, c.p).Watts >= recommended
+		})
+		if !ok {
+			return 0, false
+		}
+		build.PSU = *psu
+		total += price
+	}
+	if len(o.coolers) > 0 {
+		cooler, price, ok := o.firstFitting(o.coolers, build, func(b *entity.PCBuild, p *entity.Product) { b.Cooler = p }, nil)
+		if !ok {
+			return 0, false
+		}
+		build.Cooler = cooler
+		total += price
+	}
+	if len(o.cases) > 0 {
+		cse, price, ok := o.firstFitting(o.cases, build, func(b *entity.PCBuild, p *entity.Product) { b.Case = p }, nil)
+		if !ok {
+			return 0, false
+		}
+		build.Case = cse
+		total += price
+	}
+	return total, true
+}
+
+// better a variant b dan yaxshiroqmi: ball, keyin kamroq ogohlantirish, keyin arzonroq
+func better(a, b OptimizedBuild) bool {
+	if math.Abs(a.Score-b.Score) > 1e-9 {
+		return a.Score > b.Score
+	}
+	if len(a.Violations) != len(b.Violations) {
+		return len(a.Violations) < len(b.Violations)
+	}
+	return a.PriceUSD < b.PriceUSD
+}
+
+func (o *optimizer) search(limit int) []OptimizedBuild {
+	gpus := o.gpus
+	if len(gpus) == 0 {
+		// videokarta kerak emas yoki qoldiqda yo'q - o'rnatilgan grafika
+		gpus = []optPart{{perf: igpuScore}}
+	}
+	budget := o.req.BudgetUSD
+	bestByPair := map[string]OptimizedBuild{}
+
+	for _, cpu := range o.cpus {
+		for _, board := range o.boardsFor(cpu) {
+			rams := o.ramsFor(cpu, board)
+			for _, gpu := range gpus {
+				core := cpu.price + board.price + gpu.price
+				if core >= budget {
+					continue
+				}
+				build := &entity.PCBuild{CPU: cpu.p, Motherboard: board.p, GPU: gpu.p, Purpose: o.useCase}
+				if len(rams) > 0 {
+					// RAM qoidalari (DDR avlodi) PSU/korpus tanloviga ta'sir qilmaydi, lekin Check to'liq bo'lsin
+					build.RAM = rams[0].p
+				}
+				supportPrice, ok := o.support(build)
+				if !ok {
+					continue
+				}
+				violations := o.compat.Check(build)
+				if HasCompatErrors(violations) {
+					continue
+				}
+				rest := budget - core - supportPrice
+				key := cpu.p.Name + "|" + gpu.p.Name
+				for _, ram := range rams {
+					if ram.price >= rest {
+						continue
+					}
+					for _, st := range o.storage {
+						if ram.price+st.price > rest {
+							continue
+						}
+						profile := hardwareProfile{
+							cpuScore:     cpu.perf,
+							gpuScore:     gpu.perf,
+							ramScore:     scoreRAM(int(ram.perf)),
+							storageScore: st.perf,
+							ramGB:        int(ram.perf),
+							storageType:  st.label,
+							serverCPU:    cpu.server,
+						}
+						cand := OptimizedBuild{
+							PriceUSD:   core + supportPrice + ram.price + st.price,
+							Score:      buildUseCaseScore(o.useCase, profile, "").Score,
+							Violations: violations,
+						}
+						if cur, seen := bestByPair[key]; seen && !better(cand, cur) {
+							continue
+						}
+						full := *build
+						full.RAM = ram.p
+						full.SSD = st.p
+						full.Budget = budget
+						cand.Build = &full
+						bestByPair[key] = cand
+					}
+				}
+			}
+		}
+	}
+
+	out := make([]OptimizedBuild, 0, len(bestByPair))
+	for _, b := range bestByPair {
+		out = append(out, b)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if better(out[i], out[j]) {
+			return true
+		}
+		if better(out[j], out[i]) {
+			return false
+		}
+		return out[i].Build.CPU.Name+out[i].Build.GPU.Name < out[j].Build.CPU.Name+out[j].Build.GPU.Name
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	for i := range out {
+		out[i].Violations = o.compat.Check(out[i].Build)
+		out[i].PriceDelta = out[i].PriceUSD - out[0].PriceUSD
+		out[i].ScoreDelta = out[i].Score - out[0].Score
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+func optimizerCatalog() []entity.Product {
+	usd := func(v float64) entity.Money { return entity.NewMoney(v, entity.CurrencyUSD) }
+	return []entity.Product{
+		{Name: "Intel Core i3-12100F", Category: "CPU", Price: usd(90)},
+		{Name: "Intel Core i5-13400F", Category: "CPU", Price: usd(190)},
+		{Name: "Intel Core i7-14700K", Category: "CPU", Price: usd(400)},
+		{Name: "AMD Ryzen 5 5600", Category: "CPU", Price: usd(120)},
+		{Name: "AMD Ryzen 7 7800X3D", Category: "CPU", Price: usd(380)},
+		{Name: "MSI PRO B760M-E DDR4", Category: "Motherboard", Price: usd(110)},
+		{Name: "ASUS PRIME Z790-P DDR5", Category: "Motherboard", Price: usd(220)},
+		{Name: "Gigabyte B550M DS3H", Category: "Motherboard", Price: usd(95)},
+		{Name: "MSI B650M Gaming Plus WiFi", Category: "Motherboard", Price: usd(170)},
+		{Name: "Kingston Fury 2x8GB DDR4 3200", Category: "RAM", Price: usd(40)},
+		{Name: "Kingston Fury 2x16GB DDR4 3200", Category: "RAM", Price: usd(70)},
+		{Name: "Kingston Fury 2x16GB DDR5 6000", Category: "RAM", Price: usd(100)},
+		{Name: "Palit GeForce RTX 3050 6GB", Category: "GPU", Price: usd(180)},
+		{Name: "MSI GeForce RTX 4060 Ventus 8G", Category: "GPU", Price: usd(300)},
+		{Name: "ASUS Dual GeForce RTX 4070 Super", Category: "GPU", Price: usd(600)},
+		{Name: "Sapphire Pulse Radeon RX 7800 XT", Category: "GPU", Price: usd(520)},
+		{Name: "Samsung 990 Pro 1TB NVMe", Category: "SSD", Price: usd(90)},
+		{Name: "Kingston A400 960GB SATA SSD", Category: "SSD", Price: usd(55)},
+		{Name: "Kingston A400 240GB SATA SSD", Category: "SSD", Price: usd(20)},
+		{Name: "Deepcool PK550D 550W", Category: "PSU", Price: usd(50)},
+		{Name: "Corsair RM750e 750W 80+ Gold", Category: "PSU", Price: usd(100)},
+		{Name: "Deepcool AK400", Category: "Cooler", Price: usd(35)},
+		{Name: "Zalman S2 ATX", Category: "Case", Price: usd(45)},
+	}
+}
+
+func TestOptimizeBuildsBudget(t *testing.T) {
+	builds := OptimizeBuilds(optimizerCatalog(), OptimizeRequest{BudgetUSD: 1000, Purpose: "Gaming"}, 3)
+	if len(builds) != 3 {
+		t.Fatalf("variantlar soni = %d, kutilgan 3", len(builds))
+	}
+	checker := NewCompatibilityChecker()
+	pairs := map[string]bool{}
+	for i, b := range builds {
+		if b.PriceUSD > 1000 {
+			t.Errorf("variant %d budjetdan oshdi: %.0f$", i, b.PriceUSD)
+		}
+		if !checker.Compatible(b.Build) {
+			t.Errorf("variant %d mos emas: %v", i, checker.Check(b.Build))
+		}
+		if b.Build.PSU.Name == "" || b.Build.Case == nil || b.Build.Cooler == nil {
+			t.Errorf("variant %d to'liq emas: %+v", i, b.Build)
+		}
+		if i > 0 && (b.Score > builds[0].Score || b.ScoreDelta != b.Score-builds[0].Score) {
+			t.Errorf("variant %d tartibi/deltasi noto'g'ri: %.2f (%.2f)", i, b.Score, b.ScoreDelta)
+		}
+		pair := b.Build.CPU.Name + "|" + b.Build.GPU.Name
+		if pairs[pair] {
+			t.Errorf("takroriy CPU+GPU: %s", pair)
+		}
+		pairs[pair] = true
+	}
+	if builds[0].PriceUSD < 800 {
+		t.Errorf("budjetning katta qismi ishlatilmadi: %.0f$", builds[0].PriceUSD)
+	}
+
+	// Brend istagi va qoldiq
+	products := optimizerCatalog()
+	for i := range products {
+		products[i].Stock = 1
+	}
+	products[14].Stock = 0 // RTX 4070 Super qolmagan
+	builds = OptimizeBuilds(products, OptimizeRequest{BudgetUSD: 1000, Purpose: "Gaming", GPUBrand: "rtx"}, 3)
+	for _, b := range builds {
+		if !matchesBrand(b.Build.GPU, "rtx") || b.Build.GPU.Name == "ASUS Dual GeForce RTX 4070 Super" {
+			t.Errorf("brend/qoldiq hisobga olinmadi: %s", b.Build.GPU.Name)
+		}
+	}
+
+	if got := OptimizeBuilds(optimizerCatalog(), OptimizeRequest{BudgetUSD: 200}, 3); len(got) != 0 {
+		t.Errorf("200$ ga yig'ma topilmasligi kerak edi: %d", len(got))
+	}
+}
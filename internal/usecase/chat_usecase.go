@@ -23,12 +23,16 @@ type ChatUseCase interface {
 	ProcessConfigMessage(ctx context.Context, userID int64, username, text string) (string, error)
 	ClearHistory(ctx context.Context, userID int64) error
 	GetHistory(ctx context.Context, userID int64) ([]entity.Message, error)
+	// SetBranchResolver mijoz tanlagan filialni qaytaruvchi funksiya (qoldiq shu filial bo'yicha filtrlanadi)
+	SetBranchResolver(fn func(userID int64) string)
 }
 
 type chatUseCase struct {
 	aiRepo      repository.AIRepository
 	chatRepo    repository.ChatRepository
 	productRepo repository.ProductRepository
+	promotions  PromotionUseCase // nil bo'lishi mumkin
+	branchOf    func(userID int64) string
 }
 
 var (
@@ -44,14 +48,28 @@ func NewChatUseCase(
 	aiRepo repository.AIRepository,
 	chatRepo repository.ChatRepository,
 	productRepo repository.ProductRepository,
+	promotions PromotionUseCase,
 ) ChatUseCase {
 	return &chatUseCase{
 		aiRepo:      aiRepo,
 		chatRepo:    chatRepo,
 		productRepo: productRepo,
+		promotions:  promotions,
 	}
 }
 
+func (u *chatUseCase) SetBranchResolver(fn func(userID int64) string) {
+	u.branchOf = fn
+}
+
+// userBranch mijoz filiali ("" - tanlanmagan)
+func (u *chatUseCase) userBranch(userID int64) string {
+	if u.branchOf == nil {
+		return ""
+	}
+	return u.branchOf(userID)
+}
+
 // ProcessMessage foydalanuvchi xabarini qayta ishlash
 func (u *chatUseCase) ProcessMessage(ctx context.Context, userID int64, username, text string) (string, error) {
 	// Oldingi tarixni olish (oxirgi 10 ta xabar)
@@ -64,7 +82,7 @@ func (u *chatUseCase) ProcessMessage(ctx context.Context, userID int64, username
 	csvData, csvFilename, csvErr := u.productRepo.GetCSV(ctx)
 	availableCSV := ""
 	if csvErr == nil && csvData != "" {
-		availableCSV = filterProductsByStock(csvData)
+		availableCSV = filterProductsByStock(csvData, u.userBranch(userID))
 	}
 	hasCSV := csvErr == nil && strings.TrimSpace(availableCSV) != ""
 
@@ -316,6 +334,9 @@ Fayl: %s
 				}
 				return sb.String()
 			}(), csvFilename, filteredCSV)
+		if requestedCategory == "Laptop" || requestedCategory == "Prebuilt" {
+			enrichedText += u.systemRankingHint(ctx, userID, text, purpose, requestedCategory, filteredCSV)
+		}
 		if includePhone {
 			enrichedText += "\nAdmin telefon raqami (aloqa uchun): " + constants.AdminContactPhone
 		}
@@ -325,7 +346,7 @@ Fayl: %s
 	} else {
 		// Fallback: Eski usul - product list dan
 		products, err := u.productRepo.GetAll(ctx)
-		availableProducts := filterInStockProducts(products)
+		availableProducts := filterInStockProducts(products, u.userBranch(userID))
 		hasProducts := err == nil && len(availableProducts) > 0
 
 		if hasProducts {
@@ -366,7 +387,7 @@ Fayl: %s
 
 	// ✅ POST-PROCESSING: AI javobini validatsiya qilish va noto'g'ri narxlarni to'g'rilash
 	if hasCSV {
-		response = u.validateAndFixPrices(response, availableCSV)
+		response = u.validateAndFixPrices(ctx, response, availableCSV)
 		response = syncTotalLineWithSinglePrice(response)
 
 		// The 'budget' variable is already correctly scoped from the top of the function.
@@ -819,13 +840,18 @@ func categoryAliases(category string) []string {
 		return []string{"Case", "CASE"}
 	case "mousepad", "pad", "accessory":
 		return []string{"Mousepad", "Accessory", "Pad"}
+	case "laptop", "notebook":
+		return []string{"Laptop", "Laptops", "Notebook", "Noutbuk", "Noutbuklar", "Ноутбук", "Ноутбуки"}
+	case "prebuilt", "pc":
+		return []string{"Prebuilt", "PC", "Desktop", "Tayyor PC", "Tayyor kompyuter", "Готовые ПК", "Системный блок"}
 	default:
 		return []string{cat}
 	}
 }
 
-// validateAndFixPrices AI javobidagi noto'g'ri narxlarni CSV dan to'g'rilaydi
-func (u *chatUseCase) validateAndFixPrices(response, csvData string) string {
+// validateAndFixPrices AI javobidagi noto'g'ri narxlarni CSV dan to'g'rilaydi,
+// keyin amaldagi aksiyalarni qo'llaydi
+func (u *chatUseCase) validateAndFixPrices(ctx context.Context, response, csvData string) string {
 	// CSV dan mahsulot nomlarini va narxlarini extract qilish
 	priceMap := make(map[string]string) // mahsulot nomi -> to'g'ri narx
 
@@ -898,7 +924,11 @@ func (u *chatUseCase) validateAndFixPrices(response, csvData string) string {
 		fixed = append(fixed, fixedLine)
 	}
 
-	return strings.Join(fixed, "\n")
+	result := strings.Join(fixed, "\n")
+	if u.promotions != nil {
+		result = u.promotions.ApplyToText(ctx, result)
+	}
+	return result
 }
 
 type priceCandidate struct {
@@ -1061,7 +1091,7 @@ func (u *chatUseCase) ProcessConfigMessage(ctx context.Context, userID int64, us
 	csvData, csvFilename, csvErr := u.productRepo.GetCSV(ctx)
 	availableCSV := ""
 	if csvErr == nil && csvData != "" {
-		availableCSV = filterProductsByStock(csvData)
+		availableCSV = filterProductsByStock(csvData, u.userBranch(userID))
 	}
 	hasCSV := csvErr == nil && strings.TrimSpace(availableCSV) != ""
 
@@ -1117,7 +1147,7 @@ Fayl: %s
 
 	// ✅ POST-PROCESSING: AI javobini validatsiya qilish va noto'g'ri narxlarni to'g'rilash
 	if hasCSV {
-		response = u.validateAndFixPrices(response, availableCSV)
+		response = u.validateAndFixPrices(ctx, response, availableCSV)
 		response = syncTotalLineWithSinglePrice(response)
 	}
 
@@ -1179,7 +1209,7 @@ func (u *chatUseCase) buildProductsContext(products []entity.Product) string {
 		sb.WriteString(fmt.Sprintf("\n📂 %s:\n", category))
 		for i, p := range prods {
 			// Narxni dollar formatida ko'rsatish (Stock 0 bo'lsa ham ko'rsatamiz - product mavjud)
-			sb.WriteString(fmt.Sprintf("  %d. %s - $%.2f", i+1, p.Name, p.Price))
+			sb.WriteString(fmt.Sprintf("  %d. %s - %s", i+1, p.Name, p.Price))
 
 			if p.Stock > 0 {
 				sb.WriteString(fmt.Sprintf(" (Omborda: %d ta)", p.Stock))
@@ -1382,13 +1412,16 @@ var (
 	csvNameHeaders  = []string{"name", "название", "товар", "product", "mahsulot", "nomi"}
 )
 
-func filterProductsByStock(csvData string) string {
+// filterProductsByStock qoldig'i 0 bo'lgan qatorlarni olib tashlaydi.
+// Filial ustunlari ("Stock: Chilonzor") bo'lsa, mijoz filiali bo'yicha; filial tanlanmagan bo'lsa -
+// umumiy ustun yoki istalgan filialda bor bo'lsa.
+func filterProductsByStock(csvData string, branch string) string {
 	if strings.TrimSpace(csvData) == "" {
 		return csvData
 	}
 	lines := strings.Split(csvData, "\n")
 	out := make([]string, 0, len(lines))
-	stockCol := -1
+	var stockCols []int
 
 	for _, raw := range lines {
 		line := strings.TrimRight(raw, "\r")
@@ -1401,9 +1434,9 @@ func filterProductsByStock(csvData string) string {
 			continue
 		}
 
-		if stockCol == -1 {
+		if stockCols == nil {
 			if col, ok := detectCSVStockHeader(rec); ok {
-				stockCol = col
+				stockCols = selectCSVStockColumns(rec, col, branch)
 				out = append(out, raw)
 				continue
 			}
@@ -1416,7 +1449,7 @@ func filterProductsByStock(csvData string) string {
 			}
 		}
 		if priceOk {
-			if stockVal, ok := extractCSVStockValue(rec, stockCol); ok && stockVal <= 0 {
+			if stockVal, ok := extractCSVStockTotal(rec, stockCols); ok && stockVal <= 0 {
 				continue
 			}
 		}
@@ -1426,6 +1459,73 @@ func filterProductsByStock(csvData string) string {
 	return strings.Join(out, "\n")
 }
 
+// csvStockBranch "Stock: Chilonzor" -> ("chilonzor", true), "Stock" -> ("", true)
+func csvStockBranch(cell string) (string, bool) {
+	lower := strings.ToLower(strings.TrimSpace(cell))
+	for _, kw := range csvStockHeaders {
+		idx := strings.Index(lower, kw)
+		if idx < 0 {
+			continue
+		}
+		rest := lower[idx+len(kw):]
+		if cut := strings.IndexAny(rest, ":(-–—/|"); cut >= 0 {
+			return strings.Join(strings.Fields(strings.Trim(rest[cut:], " :-–—()[]/|")), " "), true
+		}
+		return "", true
+	}
+	return "", false
+}
+
+// selectCSVStockColumns qaysi qoldiq ustun(lar)i tekshirilishini tanlaydi
+func selectCSVStockColumns(header []string, firstStockCol int, branch string) []int {
+	branch = strings.ToLower(strings.Join(strings.Fields(branch), " "))
+	plain := -1
+	var branchCols []int
+	for i, cell := range header {
+		name, ok := csvStockBranch(cell)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if plain < 0 {
+				plain = i
+			}
+			continue
+		}
+		if branch != "" && name == branch {
+			return []int{i}
+		}
+		branchCols = append(branchCols, i)
+	}
+	switch {
+	case plain >= 0:
+		return []int{plain}
+	case len(branchCols) > 0:
+		return branchCols
+	case firstStockCol >= 0:
+		return []int{firstStockCol}
+	}
+	return nil
+}
+
+// extractCSVStockTotal tanlangan ustunlar yig'indisi (ustun yo'q bo'lsa eski evristika)
+func extractCSVStockTotal(rec []string, cols []int) (float64, bool) {
+	if len(cols) == 0 {
+		return extractCSVStockValue(rec, -1)
+	}
+	total, found := 0.0, false
+	for _, col := range cols {
+		if col >= len(rec) {
+			continue
+		}
+		if v, ok := parseCatalogPrice(rec[col]); ok {
+			total += v
+			found = true
+		}
+	}
+	return total, found
+}
+
 func parseCSVLine(line string) ([]string, error) {
 	r := csv.NewReader(strings.NewReader(line))
 	r.FieldsPerRecord = -1
@@ -1481,13 +1581,13 @@ func containsCSVKeyword(value string, keywords []string) bool {
 	return false
 }
 
-func filterInStockProducts(products []entity.Product) []entity.Product {
+func filterInStockProducts(products []entity.Product, branch string) []entity.Product {
 	if len(products) == 0 {
 		return nil
 	}
 	out := make([]entity.Product, 0, len(products))
 	for _, p := range products {
-		if p.Stock > 0 {
+		if p.StockAt(branch) > 0 {
 			out = append(out, p)
 		}
 	}
@@ -1749,6 +1849,11 @@ func parseCatalogPrice(s string) (float64, bool) {
 func detectRequestedCategory(text string) string {
 	lower := strings.ToLower(text)
 	switch {
+	// Noutbuk/tayyor PC nomida GPU va CPU ham bo'ladi - ular birinchi
+	case containsAny(lower, "noutbuk", "laptop", "notebook", "ноутбук", "macbook", "ultrabook", "ультрабук"):
+		return "Laptop"
+	case containsAny(lower, "tayyor kompyuter", "tayyor pc", "prebuilt", "pre-built", "готовый пк", "готовый компьютер", "системный блок"):
+		return "Prebuilt"
 	case strings.Contains(lower, "monitor"):
 		return "Monitor"
 	case strings.Contains(lower, "gpu") || strings.Contains(lower, "rtx") || strings.Contains(lower, "radeon") ||
@@ -1788,6 +1893,49 @@ func detectRequestedCategory(text string) string {
 	}
 }
 
+// systemRankingHint noutbuk/tayyor PC so'rovida filtrlangan katalogdagi tizimlar xususiyatlar
+// bo'yicha reytingi (RankSystems) - AI variantlarni shu tartibda taklif qiladi
+func (u *chatUseCase) systemRankingHint(ctx context.Context, userID int64, text, purpose, category, filteredCSV string) string {
+	products, err := u.productRepo.GetAll(ctx)
+	if err != nil {
+		return ""
+	}
+	req := ParseSystemRequest(text)
+	req.Kind = PartLaptop
+	if category == "Prebuilt" {
+		req.Kind = PartPrebuilt
+	}
+	if req.Purpose == "" {
+		req.Purpose = purpose
+	}
+	// Budjet CSV filtrida qo'llangan
+	req.BudgetUSD = 0
+	req.Branch = u.userBranch(userID)
+
+	allowed := make(map[string]bool)
+	for _, line := range strings.Split(filteredCSV, "\n") {
+		if name, _, ok := parseCatalogLineNameAndPrice(strings.TrimSpace(line)); ok {
+			allowed[strings.ToLower(strings.TrimSpace(name))] = true
+		}
+	}
+	var sb strings.Builder
+	n := 0
+	for _, pick := range RankSystems(products, req) {
+		if !allowed[strings.ToLower(strings.TrimSpace(pick.Product.Name))] {
+			continue
+		}
+		n++
+		sb.WriteString(fmt.Sprintf("\n%d. %s — %s (ball %.1f)", n, pick.Product.Name, SystemSummary(pick.Specs), pick.Score))
+		if n == 5 {
+			break
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+	return "\n\n🏆 XUSUSIYATLAR BO'YICHA REYTING (maqsadga moslik, shu tartibda tavsiya qil):" + sb.String()
+}
+
 // validateProductsExistInCSV Verifies that all recommended products exist in the CSV
 // Returns a warning message if hallucinated products are found
 func validateProductsExistInCSV(response, filteredCSV string, budget int) string {
@@ -114,7 +114,7 @@ func TestProcessMessage_AsksCategoryWhenMissing(t *testing.T) {
 		csvData:     "Monitor\nA,10.00\nCPU\nX,100.00\n",
 	}
 
-	u := NewChatUseCase(ai, chat, prod)
+	u := NewChatUseCase(ai, chat, prod, nil)
 	resp, err := u.ProcessMessage(context.Background(), 1, "u", "tavsiya kerak")
 	if err != nil {
 		t.Fatalf("ProcessMessage returned error: %v", err)
@@ -146,7 +146,7 @@ func TestProcessMessage_NoBudget_DoesNotBlockAndProvides5Options(t *testing.T) {
 		csvData:     sampleCSV,
 	}
 
-	u := NewChatUseCase(ai, chat, prod)
+	u := NewChatUseCase(ai, chat, prod, nil)
 	resp, err := u.ProcessMessage(context.Background(), 1, "u", "monitor tavsiya")
 	if err != nil {
 		t.Fatalf("ProcessMessage returned error: %v", err)
@@ -181,7 +181,7 @@ func TestProcessMessage_ModelToken_DoesNotAskCategory(t *testing.T) {
 		csvData:     sampleCSV,
 	}
 
-	u := NewChatUseCase(ai, chat, prod)
+	u := NewChatUseCase(ai, chat, prod, nil)
 	resp, err := u.ProcessMessage(context.Background(), 1, "u", "13400f kerak")
 	if err != nil {
 		t.Fatalf("ProcessMessage returned error: %v", err)
@@ -193,3 +193,21 @@ func TestProcessMessage_ModelToken_DoesNotAskCategory(t *testing.T) {
 		t.Fatalf("unexpected response: %q", resp)
 	}
 }
+
+func TestFilterProductsByStock_Branch(t *testing.T) {
+	csvData := strings.Join([]string{
+		"Name,Stock: Chilonzor,Stock: Yunusobod,Price",
+		"RTX 4060,0,3,300.00",
+		"RTX 4070,2,0,550.00",
+		"RTX 4080,0,0,1200.00",
+	}, "\n")
+
+	chilonzor := filterProductsByStock(csvData, "Chilonzor")
+	if strings.Contains(chilonzor, "RTX 4060") || !strings.Contains(chilonzor, "RTX 4070") {
+		t.Fatalf("Chilonzor filter wrong:\n%s", chilonzor)
+	}
+	anywhere := filterProductsByStock(csvData, "")
+	if !strings.Contains(anywhere, "RTX 4060") || !strings.Contains(anywhere, "RTX 4070") || strings.Contains(anywhere, "RTX 4080") {
+		t.Fatalf("no-branch filter should keep items in stock anywhere:\n%s", anywhere)
+	}
+}
@@ -0,0 +1,858 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
+)
+
+// Moslik dvigateli: komponentlardan tiplangan atributlar (soket, chipset, DDR, form-faktor, TDP,
+// ulagichlar, o'lchamlar) olinadi va deklarativ qoidalar jadvali bo'yicha tekshiriladi.
+// Atributlar avval Product.Specs dan, bo'lmasa model nomidan aniqlanadi; noma'lum atribut
+// qoidani ishga tushirmaydi (yolg'on ogohlantirishdan ko'ra jim turgan afzal).
+
+// CompatSeverity - qoida buzilishi darajasi
+type CompatSeverity string
+
+const (
+	CompatError   CompatSeverity = "error"   // yig'ib/ishlatib bo'lmaydi
+	CompatWarning CompatSeverity = "warning" // ishlaydi, lekin cheklov bor
+)
+
+// Komponent turlari (PartAttrs uchun)
+const (
+	PartCPU         = "cpu"
+	PartMotherboard = "motherboard"
+	PartRAM         = "ram"
+	PartGPU         = "gpu"
+	PartPSU         = "psu"
+	PartCooler      = "cooler"
+	PartCase        = "case"
+)
+
+// Form-faktorlar
+const (
+	FormITX  = "Mini-ITX"
+	FormMATX = "Micro-ATX"
+	FormATX  = "ATX"
+	FormEATX = "E-ATX"
+	FormSFX  = "SFX"
+)
+
+// Violation - bitta qoida buzilishi
+type Violation struct {
+	Rule       string
+	Severity   CompatSeverity
+	Components []string // ishtirokchi komponent nomlari
+	Args       []any    // "compat.<Rule>" matni uchun "nom", qiymat juftliklari
+}
+
+// Message buzilish matni foydalanuvchi tilida
+func (v Violation) Message(lang string) string {
+	return i18n.T(lang, "compat."+v.Rule, v.Args...)
+}
+
+// HasCompatErrors ro'yxatda yig'ib bo'lmaydigan (error) buzilish bormi
+func HasCompatErrors(vs []Violation) bool {
+	for _, v := range vs {
+		if v.Severity == CompatError {
+			return true
+		}
+	}
+	return false
+}
+
+// ComponentAttrs - moslik uchun tiplangan atributlar; 0, "" va nil - noma'lum
+type ComponentAttrs struct {
+	Socket     string   // AM4, AM5, LGA1151, LGA1200, LGA1700, LGA1851
+	Chipset    string   // B650, X670E, Z790...
+	Memory     []string // RAM/plata: DDR avlodi; CPU: qo'llab-quvvatlanadigan avlodlar
+	FormFactor string   // plata: ATX/Micro-ATX/Mini-ITX/E-ATX; PSU: ATX/SFX
+	Fits       []string // korpus: sig'adigan plata form-faktorlari
+	PCIeGen    int      // plata GPU sloti / GPU interfeysi avlodi
+	PCIeLanes  int      // GPU: x16/x8/x4
+	TDP        int      // CPU TDP, GPU TBP, sovutgich sovuta oladigan issiqlik (W)
+	Watts      int      // PSU quvvati
+
+	Needs12VHPWR    bool // GPU 12VHPWR/12V-2x6 bilan ulanadi
+	Has12VHPWR      bool // PSU da 12VHPWR bor
+	ConnectorsKnown bool // PSU ulagichlari ma'lum (spec yoki ATX 3.x)
+	PCIe8Pin        int  // GPU talab qiladi / PSU beradi (8-pin soni)
+
+	Overclock bool // CPU: Intel K; plata: Z chipset
+	NoIGPU    bool // CPU: o'rnatilgan grafika yo'q (F/KF, AM4 non-G)
+
+	HeightMM          int      // havo sovutgich balandligi
+	Radiator          int      // AIO radiator o'lchami (mm)
+	Sockets           []string // sovutgich qo'llaydigan soketlar
+	LengthMM          int      // GPU uzunligi
+	MaxGPULengthMM    int      // korpus
+	MaxCoolerHeightMM int      // korpus
+	Radiators         []int    // korpusga sig'adigan radiatorlar
+}
+
+// PartAttrs komponent atributlarini aniqlaydi
+func PartAttrs(kind string, p entity.Product) ComponentAttrs {
+	switch kind {
+	case PartCPU:
+		return cpuAttrs(p)
+	case PartMotherboard:
+		return boardAttrs(p)
+	case PartRAM:
+		return ComponentAttrs{Memory: memoryGens(p.Spec(entity.SpecMemoryType) + " " + p.Name)}
+	case PartGPU:
+		return gpuAttrs(p)
+	case PartPSU:
+		return psuAttrs(p)
+	case PartCooler:
+		return coolerAttrs(p)
+	case PartCase:
+		return caseAttrs(p)
+	}
+	return ComponentAttrs{}
+}
+
+var (
+	reSocketAM   = regexp.MustCompile(`\bam([45])\b`)
+	reSocketLGA  = regexp.MustCompile(`lga\s*-?\s*(\d{4})`)
+	reRyzen      = regexp.MustCompile(`ryzen\s*(?:[3579]\s+)?(\d{4})\s*(x3d|xt|x|gt|ge|g|f)?\b`)
+	reIntelCore  = regexp.MustCompile(`\bi([3579])[\s-]*(\d{4,5})([a-z]{0,3})\b`)
+	reCoreUltra  = regexp.MustCompile(`ultra\s*[3579]\s*(\d{3})([a-z]{0,2})\b`)
+	reChipset    = regexp.MustCompile(`\b([abhxz])(\d{3})([emi]?)(?:[^0-9a-z]|$)`)
+	reDDR        = regexp.MustCompile(`\bd(?:dr)?\s*([45])\b`)
+	reGeForce    = regexp.MustCompile(`\b(rtx|gtx)\s*(\d{4})\s*(ti)?\s*(super)?\b`)
+	reRadeon     = regexp.MustCompile(`\brx\s*(\d{4})\s*(xtx|xt|gre)?\b`)
+	reMM         = regexp.MustCompile(`(\d{2,3})\s*mm\b`)
+	reWatts      = regexp.MustCompile(`(\d{3,4})\s*w\b`)
+	reModelWatts = regexp.MustCompile(`[a-z]*(\d{3,4})[a-z]*`)
+	reRadiator   = regexp.MustCompile(`\b(120|240|280|360|420)\b`)
+	reEightPin   = regexp.MustCompile(`(\d)\s*[x×]\s*(?:pcie\s*)?(?:6\+2|8)[\s-]*pin`)
+	reFirstInt   = regexp.MustCompile(`\d+`)
+	reBareATX    = regexp.MustCompile(`(^|[^a-z-])atx`)
+)
+
+// chipsetSocket - chipset -> soket
+var chipsetSocket = map[string]string{
+	"A320": "AM4", "B350": "AM4", "X370": "AM4", "B450": "AM4", "X470": "AM4", "A520": "AM4", "B550": "AM4", "X570": "AM4",
+	"A620": "AM5", "B650": "AM5", "X670": "AM5", "B840": "AM5", "B850": "AM5", "X870": "AM5",
+	"H410": "LGA1200", "B460": "LGA1200", "H470": "LGA1200", "Z490": "LGA1200", "H510": "LGA1200", "B560": "LGA1200", "Z590": "LGA1200",
+	"H610": "LGA1700", "B660": "LGA1700", "H670": "LGA1700", "Z690": "LGA1700", "B760": "LGA1700", "H770": "LGA1700", "Z790": "LGA1700",
+	"H810": "LGA1851", "B860": "LGA1851", "Z890": "LGA1851",
+}
+
+// chipsetPCIe - GPU sloti avlodi (E seriyali AMD chipsetlar - 5.0)
+var chipsetPCIe = map[string]int{
+	"A320": 3, "B350": 3, "X370": 3, "B450": 3, "X470": 3, "A520": 3, "B550": 4, "X570": 4,
+	"A620": 4, "B650": 4, "X670": 4, "B840": 3, "B850": 4, "X870": 5,
+	"H410": 3, "B460": 3, "H470": 3, "Z490": 3, "H510": 3, "B560": 4, "Z590": 4,
+	"H610": 4, "B660": 4, "H670": 5, "Z690": 5, "B760": 4, "H770": 5, "Z790": 5,
+	"H810": 4, "B860": 5, "Z890": 5,
+}
+
+// socketMemory - soket qo'llaydigan xotira avlodlari
+var socketMemory = map[string][]string{
+	"AM4":     {"DDR4"},
+	"AM5":     {"DDR5"},
+	"LGA1151": {"DDR4"},
+	"LGA1200": {"DDR4"},
+	"LGA1700": {"DDR4", "DDR5"},
+	"LGA1851": {"DDR5"},
+}
+
+// gpuPower - videokarta to'liq quvvati (TBP, W)
+var gpuPower = map[string]int{
+	"gtx 1650": 75, "gtx 1660": 120, "gtx 1660 super": 125, "gtx 1660 ti": 120,
+	"rtx 3050": 130, "rtx 3060": 170, "rtx 3060 ti": 200, "rtx 3070": 220, "rtx 3070 ti": 290,
+	"rtx 3080": 320, "rtx 3080 ti": 350, "rtx 3090": 350, "rtx 3090 ti": 450,
+	"rtx 4060": 115, "rtx 4060 ti": 160, "rtx 4070": 200, "rtx 4070 super": 220, "rtx 4070 ti": 285,
+	"rtx 4070 ti super": 285, "rtx 4080": 320, "rtx 4080 super": 320, "rtx 4090": 450,
+	"rtx 5060": 145, "rtx 5060 ti": 180, "rtx 5070": 250, "rtx 5070 ti": 300, "rtx 5080": 360, "rtx 5090": 575,
+	"rx 6500 xt": 107, "rx 6600": 132, "rx 6600 xt": 160, "rx 6650 xt": 180, "rx 6700 xt": 230, "rx 6750 xt": 250,
+	"rx 6800": 250, "rx 6800 xt": 300, "rx 6900 xt": 300,
+	"rx 7600": 165, "rx 7600 xt": 190, "rx 7700 xt": 245, "rx 7800 xt": 263, "rx 7900 gre": 260,
+	"rx 7900 xt": 315, "rx 7900 xtx": 355, "rx 9060 xt": 160, "rx 9070": 220, "rx 9070 xt": 304,
+}
+
+// gpuNarrowLanes - x16 dan tor interfeysli kartalar
+var gpuNarrowLanes = map[string]int{
+	"rtx 3050": 8, "rtx 4060": 8, "rtx 4060 ti": 8, "rtx 5060": 8, "rtx 5060 ti": 8,
+	"rx 6500 xt": 4, "rx 6600": 8, "rx 6600 xt": 8, "rx 6650 xt": 8, "rx 7600": 8, "rx 7600 xt": 8,
+}
+
+func normalizeSocket(s string) string {
+	s = strings.ToLower(s)
+	if m := reSocketAM.FindStringSubmatch(s); m != nil {
+		return "AM" + m[1]
+	}
+	if m := reSocketLGA.FindStringSubmatch(s); m != nil {
+		return "LGA" + m[1]
+	}
+	return ""
+}
+
+// specSocket - Specs dagi soket
+func specSocket(p entity.Product) string {
+	return socketValue(p.Spec(entity.SpecSocket))
+}
+
+// socketValue - jadval qiymatidagi soket; ko'pincha faqat raqam yoziladi ("1700")
+func socketValue(v string) string {
+	if s := normalizeSocket(v); s != "" {
+		return s
+	}
+	if n := reFirstInt.FindString(v); n == strings.TrimSpace(v) && len(n) == 4 {
+		return "LGA" + n
+	}
+	return ""
+}
+
+// memoryGens matndagi DDR avlodlari (takrorsiz)
+func memoryGens(s string) []string {
+	var out []string
+	for _, m := range reDDR.FindAllStringSubmatch(strings.ToLower(s), -1) {
+		gen := "DDR" + m[1]
+		if !containsString(out, gen) {
+			out = append(out, gen)
+		}
+	}
+	return out
+}
+
+func specInt(p entity.Product, key string) int {
+	v := reFirstInt.FindString(p.Spec(key))
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+func cpuAttrs(p entity.Product) ComponentAttrs {
+	var a ComponentAttrs
+	name := strings.ToLower(p.Name)
+	a.Socket = specSocket(p)
+	if a.Socket == "" {
+		a.Socket = normalizeSocket(name)
+	}
+
+	switch {
+	case reRyzen.MatchString(name):
+		m := reRyzen.FindStringSubmatch(name)
+		model, suffix := m[1], m[2]
+		socket := "AM4"
+		if model[0] >= '7' {
+			socket = "AM5"
+		}
+		if a.Socket == "" {
+			a.Socket = socket
+		}
+		a.NoIGPU = (socket == "AM4" && !strings.HasPrefix(suffix, "g")) || (socket == "AM5" && suffix == "f")
+		switch {
+		case suffix == "x3d":
+			a.TDP = 120
+		case suffix == "x" || suffix == "xt":
+			a.TDP = 105
+			if socket == "AM5" && (strings.HasSuffix(model, "950") || strings.HasSuffix(model, "900")) {
+				a.TDP = 170
+			}
+		case suffix == "ge":
+			a.TDP = 35
+		default:
+			a.TDP = 65
+		}
+	case reCoreUltra.MatchString(name):
+		m := reCoreUltra.FindStringSubmatch(name)
+		if a.Socket == "" {
+			a.Socket = "LGA1851"
+		}
+		a.Overclock = strings.Contains(m[2], "k")
+		a.NoIGPU = strings.Contains(m[2], "f")
+		a.TDP = 65
+		if a.Overclock {
+			a.TDP = 125
+		}
+	case reIntelCore.MatchString(name):
+		m := reIntelCore.FindStringSubmatch(name)
+		model, suffix := m[2], m[3]
+		gen, _ := strconv.Atoi(model[:1])
+		if len(model) == 5 {
+			gen, _ = strconv.Atoi(model[:2])
+		}
+		if a.Socket == "" {
+			switch {
+			case gen >= 12 && gen <= 14:
+				a.Socket = "LGA1700"
+			case gen == 10 || gen == 11:
+				a.Socket = "LGA1200"
+			case gen == 8 || gen == 9:
+				a.Socket = "LGA1151"
+			}
+		}
+		a.Overclock = strings.Contains(suffix, "k")
+		a.NoIGPU = strings.Contains(suffix, "f")
+		switch {
+		case a.Overclock:
+			a.TDP = 125
+		case strings.Contains(suffix, "t"):
+			a.TDP = 35
+		default:
+			a.TDP = 65
+		}
+	}
+
+	if tdp := specInt(p, entity.SpecTDP); tdp > 0 {
+		a.TDP = tdp
+	}
+	a.Memory = memoryGens(p.Spec(entity.SpecMemoryType))
+	if len(a.Memory) == 0 {
+		a.Memory = socketMemory[a.Socket]
+	}
+	return a
+}
+
+func boardAttrs(p entity.Product) ComponentAttrs {
+	var a ComponentAttrs
+	name := strings.ToLower(p.Name)
+	suffix := ""
+	for _, m := range reChipset.FindAllStringSubmatch(strings.ToLower(p.Spec(entity.SpecChipset)+" "+name), -1) {
+		chip := strings.ToUpper(m[1] + m[2])
+		if _, ok := chipsetSocket[chip]; ok {
+			a.Chipset, suffix = chip, m[3]
+			break
+		}
+	}
+	if a.Chipset != "" {
+		a.Socket = chipsetSocket[a.Chipset]
+		a.PCIeGen = chipsetPCIe[a.Chipset]
+		if suffix == "e" && a.Socket == "AM5" {
+			a.Chipset += "E"
+			a.PCIeGen = 5
+		}
+		a.Overclock = strings.HasPrefix(a.Chipset, "Z")
+	}
+	if s := specSocket(p); s != "" {
+		a.Socket = s
+	} else if s := normalizeSocket(name); s != "" {
+		a.Socket = s
+	}
+
+	a.FormFactor = parseFormFactor(p.Spec(entity.SpecFormFactor))
+	if a.FormFactor == "" {
+		switch {
+		case suffix == "i":
+			a.FormFactor = FormITX
+		case suffix == "m":
+			a.FormFactor = FormMATX
+		default:
+			a.FormFactor = parseFormFactor(name)
+		}
+	}
+	if a.FormFactor == "" && a.Chipset != "" {
+		a.FormFactor = FormATX
+	}
+
+	a.Memory = memoryGens(p.Spec(entity.SpecMemoryType) + " " + name)
+	if len(a.Memory) == 0 {
+		if gens := socketMemory[a.Socket]; len(gens) == 1 {
+			a.Memory = gens
+		}
+	}
+	if gen := pcieGen(p.Spec(entity.SpecPCIe)); gen > 0 {
+		a.PCIeGen = gen
+	}
+	return a
+}
+
+// parseFormFactor matndagi bitta form-faktor (eng aniq nomi birinchi)
+func parseFormFactor(s string) string {
+	s = strings.ToLower(s)
+	switch {
+	case strings.Contains(s, "e-atx") || strings.Contains(s, "eatx"):
+		return FormEATX
+	case strings.Contains(s, "itx"):
+		return FormITX
+	case strings.Contains(s, "matx") || strings.Contains(s, "m-atx") || strings.Contains(s, "micro"):
+		return FormMATX
+	case reBareATX.MatchString(s):
+		return FormATX
+	}
+	return ""
+}
+
+func pcieGen(s string) int {
+	s = strings.ToLower(s)
+	for gen := 5; gen >= 3; gen-- {
+		if strings.Contains(s, fmt.Sprintf("%d.0", gen)) || strings.Contains(s, fmt.Sprintf("gen%d", gen)) || strings.Contains(s, fmt.Sprintf("gen %d", gen)) {
+			return gen
+		}
+	}
+	return 0
+}
+
+// gpuModel - "rtx 4070 ti super", "rx 7800 xt" ko'rinishidagi kalit
+func gpuModel(name string) string {
+	name = strings.ToLower(name)
+	if m := reGeForce.FindStringSubmatch(name); m != nil {
+		key := m[1] + " " + m[2]
+		if m[3] != "" {
+			key += " ti"
+		}
+		if m[4] != "" {
+			key += " super"
+		}
+		return key
+	}
+	if m := reRadeon.FindStringSubmatch(name); m != nil {
+		key := "rx " + m[1]
+		if m[2] != "" {
+			key += " " + m[2]
+		}
+		return key
+	}
+	return ""
+}
+
+func has12VHPWR(s string) bool {
+	s = strings.ToLower(s)
+	return strings.Contains(s, "12vhpwr") || strings.Contains(s, "12v-2x6") || strings.Contains(s, "16-pin") || strings.Contains(s, "16 pin")
+}
+
+func eightPinCount(s string) int {
+	s = strings.ToLower(s)
+	if m := reEightPin.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n
+	}
+	if strings.Contains(s, "8-pin") || strings.Contains(s, "8 pin") || strings.Contains(s, "6+2") {
+		return 1
+	}
+	return 0
+}
+
+func gpuAttrs(p entity.Product) ComponentAttrs {
+	var a ComponentAttrs
+	model := gpuModel(p.Name)
+	a.TDP = gpuPower[model]
+	a.PCIeLanes = 16
+	if lanes, ok := gpuNarrowLanes[model]; ok {
+		a.PCIeLanes = lanes
+	}
+	switch {
+	case strings.HasPrefix(model, "rtx 5"), strings.HasPrefix(model, "rx 9"):
+		a.PCIeGen = 5
+	case strings.HasPrefix(model, "rtx"), strings.HasPrefix(model, "rx"):
+		a.PCIeGen = 4
+	case strings.HasPrefix(model, "gtx"):
+		a.PCIeGen = 3
+	}
+	if tdp := specInt(p, entity.SpecTDP); tdp > 0 {
+		a.TDP = tdp
+	}
+	if gen := pcieGen(p.Spec(entity.SpecPCIe)); gen > 0 {
+		a.PCIeGen = gen
+	}
+	a.LengthMM = specInt(p, entity.SpecLength)
+	if a.LengthMM == 0 {
+		if m := reMM.FindStringSubmatch(strings.ToLower(p.Name)); m != nil {
+			a.LengthMM, _ = strconv.Atoi(m[1])
+		}
+	}
+	connectors := p.Spec(entity.SpecConnectors)
+	a.Needs12VHPWR = has12VHPWR(connectors) || has12VHPWR(p.Name)
+	a.PCIe8Pin = eightPinCount(connectors)
+	return a
+}
+
+func psuAttrs(p entity.Product) ComponentAttrs {
+	var a ComponentAttrs
+	name := strings.ToLower(p.Name)
+	a.Watts = specInt(p, entity.SpecWattage)
+	if a.Watts == 0 {
+		if m := reWatts.FindStringSubmatch(name); m != nil {
+			a.Watts, _ = strconv.Atoi(m[1])
+		}
+	}
+	if a.Watts == 0 {
+		for _, m := range reModelWatts.FindAllStringSubmatch(name, -1) {
+			if n, _ := strconv.Atoi(m[1]); n >= 300 && n <= 2000 && n%50 == 0 {
+				a.Watts = n
+				break
+			}
+		}
+	}
+	if connectors := p.Spec(entity.SpecConnectors); connectors != "" {
+		a.ConnectorsKnown = true
+		a.Has12VHPWR = has12VHPWR(connectors)
+		a.PCIe8Pin = eightPinCount(connectors)
+	}
+	if has12VHPWR(name) || strings.Contains(name, "atx 3") || strings.Contains(name, "atx3") || strings.Contains(name, "pcie 5") {
+		a.ConnectorsKnown = true
+		a.Has12VHPWR = true
+	}
+	if strings.Contains(name, "sfx") {
+		a.FormFactor = FormSFX
+	}
+	return a
+}
+
+func isAIO(name string) bool {
+	name = strings.ToLower(name)
+	for _, token := range []string{"aio", "liquid", "water", "suyuq", "жидкост", "сво"} {
+		if strings.Contains(name, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func coolerAttrs(p entity.Product) ComponentAttrs {
+	var a ComponentAttrs
+	name := strings.ToLower(p.Name)
+	if isAIO(name) {
+		a.Radiator = specInt(p, entity.SpecRadiator)
+		if a.Radiator == 0 {
+			if m := reRadiator.FindStringSubmatch(name); m != nil {
+				a.Radiator, _ = strconv.Atoi(m[1])
+			}
+		}
+	} else {
+		a.HeightMM = specInt(p, entity.SpecHeight)
+		if a.HeightMM == 0 {
+			// Nomdagi 120/140 mm odatda ventilyator o'lchami, balandlik emas
+			for _, m := range reMM.FindAllStringSubmatch(name, -1) {
+				if n, _ := strconv.Atoi(m[1]); n >= 40 && n <= 200 && n != 92 && n != 120 && n != 140 {
+					a.HeightMM = n
+					break
+				}
+			}
+		}
+	}
+	a.TDP = specInt(p, entity.SpecTDP)
+	if a.TDP == 0 {
+		if m := reWatts.FindStringSubmatch(name); m != nil {
+			a.TDP, _ = strconv.Atoi(m[1])
+		}
+	}
+	for _, part := range strings.FieldsFunc(p.Spec(entity.SpecSocket), func(r rune) bool { return r == ',' || r == '/' || r == ';' }) {
+		if s := socketValue(part); s != "" {
+			a.Sockets = append(a.Sockets, s)
+		}
+	}
+	return a
+}
+
+func caseAttrs(p entity.Product) ComponentAttrs {
+	var a ComponentAttrs
+	name := strings.ToLower(p.Name)
+	if spec := strings.ToLower(p.Spec(entity.SpecFormFactor)); spec != "" {
+		for _, part := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == '/' || r == ';' }) {
+			if ff := parseFormFactor(part); ff != "" && !containsString(a.Fits, ff) {
+				a.Fits = append(a.Fits, ff)
+			}
+		}
+	}
+	if len(a.Fits) == 0 {
+		switch {
+		case strings.Contains(name, "e-atx") || strings.Contains(name, "eatx") || strings.Contains(name, "full tower"):
+			a.Fits = []string{FormEATX, FormATX, FormMATX, FormITX}
+		case reBareATX.MatchString(name):
+			a.Fits = []string{FormATX, FormMATX, FormITX}
+		case strings.Contains(name, "matx") || strings.Contains(name, "m-atx") || strings.Contains(name, "micro") || strings.Contains(name, "mini tower"):
+			a.Fits = []string{FormMATX, FormITX}
+		case strings.Contains(name, "itx"):
+			a.Fits = []string{FormITX}
+		default:
+			a.Fits = []string{FormATX, FormMATX, FormITX}
+		}
+	}
+	a.MaxGPULengthMM = specInt(p, entity.SpecMaxGPULength)
+	a.MaxCoolerHeightMM = specInt(p, entity.SpecMaxCoolerHeight)
+	for _, m := range reRadiator.FindAllStringSubmatch(p.Spec(entity.SpecRadiator), -1) {
+		n, _ := strconv.Atoi(m[1])
+		a.Radiators = append(a.Radiators, n)
+	}
+	return a
+}
+
+// hasGPU - diskret videokarta tanlanganmi ("Integrated Graphics - 0$" hisobga olinmaydi)
+func hasGPU(p entity.Product) bool {
+	name := strings.ToLower(strings.TrimSpace(p.Name))
+	if name == "" {
+		return false
+	}
+	for _, token := range []string{"integrated", "встроен", "o'rnatilgan", "kerak emas", "не нужн", "yo'q", "нет"} {
+		if strings.Contains(name, token) {
+			return false
+		}
+	}
+	return true
+}
+
+// EstimatePower tizimning uzoq yuklamadagi iste'moli va tavsiya etilgan PSU quvvati (W).
+// Batafsil hisob - ComputePowerBudget.
+func EstimatePower(build *entity.PCBuild) (load, recommended int) {
+	b := ComputePowerBudget(build, nil)
+	return b.Sustained, b.Recommended
+}
+
+// compatParts - bitta tekshiruv uchun yig'ilgan komponentlar va atributlar
+type compatParts struct {
+	build                                *entity.PCBuild
+	present                              map[string]bool
+	names                                map[string]string
+	cpu, board, ram, gpu, psu, cool, cse ComponentAttrs
+	power                                PowerBudget
+}
+
+func newCompatParts(build *entity.PCBuild) *compatParts {
+	p := &compatParts{
+		build:   build,
+		present: map[string]bool{},
+		names:   map[string]string{},
+	}
+	set := func(kind string, prod *entity.Product, ok bool) ComponentAttrs {
+		if prod == nil || !ok || strings.TrimSpace(prod.Name) == "" {
+			return ComponentAttrs{}
+		}
+		p.present[kind] = true
+		p.names[kind] = strings.TrimSpace(prod.Name)
+		return PartAttrs(kind, *prod)
+	}
+	p.cpu = set(PartCPU, &build.CPU, true)
+	p.board = set(PartMotherboard, &build.Motherboard, true)
+	p.ram = set(PartRAM, &build.RAM, true)
+	p.gpu = set(PartGPU, &build.GPU, hasGPU(build.GPU))
+	p.psu = set(PartPSU, &build.PSU, true)
+	p.cool = set(PartCooler, build.Cooler, true)
+	p.cse = set(PartCase, build.Case, true)
+	p.power = ComputePowerBudget(build, nil)
+	return p
+}
+
+// compatRule - deklarativ qoida: Needs dagi barcha komponentlar bo'lsa test ishlaydi;
+// test buzilish bo'lsa matn argumentlarini va true qaytaradi
+type compatRule struct {
+	ID       string
+	Severity CompatSeverity
+	Needs    []string
+	Test     func(p *compatParts) ([]any, bool)
+}
+
+// compatRules - qoidalar jadvali (tartib - xabarlar tartibi)
+var compatRules = []compatRule{
+	{
+		ID: "cpu_socket", Severity: CompatError, Needs: []string{PartCPU, PartMotherboard},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.cpu.Socket == "" || p.board.Socket == "" || p.cpu.Socket == p.board.Socket {
+				return nil, false
+			}
+			return []any{"cpu", p.names[PartCPU], "cpu_socket", p.cpu.Socket, "board", p.names[PartMotherboard], "board_socket", p.board.Socket}, true
+		},
+	},
+	{
+		ID: "cpu_memory", Severity: CompatError, Needs: []string{PartCPU, PartRAM},
+		Test: func(p *compatParts) ([]any, bool) {
+			if len(p.cpu.Memory) == 0 || len(p.ram.Memory) != 1 || containsString(p.cpu.Memory, p.ram.Memory[0]) {
+				return nil, false
+			}
+			return []any{"cpu", p.names[PartCPU], "ram_type", p.ram.Memory[0], "supported", strings.Join(p.cpu.Memory, "/")}, true
+		},
+	},
+	{
+		ID: "ram_type", Severity: CompatError, Needs: []string{PartMotherboard, PartRAM},
+		Test: func(p *compatParts) ([]any, bool) {
+			if len(p.board.Memory) != 1 || len(p.ram.Memory) != 1 || p.board.Memory[0] == p.ram.Memory[0] {
+				return nil, false
+			}
+			return []any{"ram", p.names[PartRAM], "ram_type", p.ram.Memory[0], "board", p.names[PartMotherboard], "board_type", p.board.Memory[0]}, true
+		},
+	},
+	{
+		ID: "cpu_overclock", Severity: CompatWarning, Needs: []string{PartCPU, PartMotherboard},
+		Test: func(p *compatParts) ([]any, bool) {
+			if !p.cpu.Overclock || p.board.Chipset == "" || p.board.Overclock || !strings.HasPrefix(p.board.Socket, "LGA") {
+				return nil, false
+			}
+			return []any{"cpu", p.names[PartCPU], "board", p.names[PartMotherboard], "chipset", p.board.Chipset}, true
+		},
+	},
+	{
+		ID: "display_output", Severity: CompatError, Needs: []string{PartCPU},
+		Test: func(p *compatParts) ([]any, bool) {
+			if !p.cpu.NoIGPU || p.present[PartGPU] {
+				return nil, false
+			}
+			return []any{"cpu", p.names[PartCPU]}, true
+		},
+	},
+	{
+		ID: "pcie_lanes", Severity: CompatWarning, Needs: []string{PartGPU, PartMotherboard},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.gpu.PCIeLanes == 0 || p.gpu.PCIeLanes >= 16 || p.board.PCIeGen == 0 || p.board.PCIeGen >= p.gpu.PCIeGen {
+				return nil, false
+			}
+			return []any{"gpu", p.names[PartGPU], "lanes", p.gpu.PCIeLanes, "board", p.names[PartMotherboard], "board_gen", p.board.PCIeGen}, true
+		},
+	},
+	{
+		ID: "case_form_factor", Severity: CompatError, Needs: []string{PartMotherboard, PartCase},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.board.FormFactor == "" || len(p.cse.Fits) == 0 || containsString(p.cse.Fits, p.board.FormFactor) {
+				return nil, false
+			}
+			return []any{"board", p.names[PartMotherboard], "form", p.board.FormFactor, "case", p.names[PartCase]}, true
+		},
+	},
+	{
+		ID: "gpu_length", Severity: CompatError, Needs: []string{PartGPU, PartCase},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.gpu.LengthMM == 0 || p.cse.MaxGPULengthMM == 0 || p.gpu.LengthMM <= p.cse.MaxGPULengthMM {
+				return nil, false
+			}
+			return []any{"gpu", p.names[PartGPU], "length", p.gpu.LengthMM, "case", p.names[PartCase], "max", p.cse.MaxGPULengthMM}, true
+		},
+	},
+	{
+		ID: "cooler_height", Severity: CompatError, Needs: []string{PartCooler, PartCase},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.cool.HeightMM == 0 || p.cse.MaxCoolerHeightMM == 0 || p.cool.HeightMM <= p.cse.MaxCoolerHeightMM {
+				return nil, false
+			}
+			return []any{"cooler", p.names[PartCooler], "height", p.cool.HeightMM, "case", p.names[PartCase], "max", p.cse.MaxCoolerHeightMM}, true
+		},
+	},
+	{
+		ID: "cooler_radiator", Severity: CompatError, Needs: []string{PartCooler, PartCase},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.cool.Radiator == 0 || len(p.cse.Radiators) == 0 {
+				return nil, false
+			}
+			for _, r := range p.cse.Radiators {
+				if r == p.cool.Radiator {
+					return nil, false
+				}
+			}
+			return []any{"cooler", p.names[PartCooler], "radiator", p.cool.Radiator, "case", p.names[PartCase]}, true
+		},
+	},
+	{
+		ID: "cooler_socket", Severity: CompatError, Needs: []string{PartCooler, PartCPU},
+		Test: func(p *compatParts) ([]any, bool) {
+			if len(p.cool.Sockets) == 0 || p.cpu.Socket == "" {
+				return nil, false
+			}
+			for _, s := range p.cool.Sockets {
+				if mountGroup(s) == mountGroup(p.cpu.Socket) {
+					return nil, false
+				}
+			}
+			return []any{"cooler", p.names[PartCooler], "socket", p.cpu.Socket}, true
+		},
+	},
+	{
+		ID: "cooler_tdp", Severity: CompatWarning, Needs: []string{PartCooler, PartCPU},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.cool.TDP == 0 || p.cpu.TDP == 0 || p.cool.TDP >= p.cpu.TDP {
+				return nil, false
+			}
+			return []any{"cooler", p.names[PartCooler], "rating", p.cool.TDP, "cpu", p.names[PartCPU], "tdp", p.cpu.TDP}, true
+		},
+	},
+	{
+		ID: "psu_wattage", Severity: CompatError, Needs: []string{PartPSU, PartCPU},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.power.Check(p.psu.Watts) != PSUUndersized {
+				return nil, false
+			}
+			return []any{"psu", p.names[PartPSU], "watts", p.psu.Watts, "load", p.power.Sustained, "peak", p.power.Transient, "min", p.power.Minimum}, true
+		},
+	},
+	{
+		ID: "psu_headroom", Severity: CompatWarning, Needs: []string{PartPSU, PartCPU},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.power.Check(p.psu.Watts) != PSULowHeadroom {
+				return nil, false
+			}
+			return []any{"psu", p.names[PartPSU], "watts", p.psu.Watts, "recommended", p.power.Recommended, "tier", p.power.Efficiency}, true
+		},
+	},
+	{
+		ID: "psu_pcie", Severity: CompatError, Needs: []string{PartPSU, PartGPU},
+		Test: func(p *compatParts) ([]any, bool) {
+			if p.gpu.PCIe8Pin == 0 || !p.psu.ConnectorsKnown || p.psu.PCIe8Pin == 0 || p.psu.PCIe8Pin >= p.gpu.PCIe8Pin {
+				return nil, false
+			}
+			return []any{"gpu", p.names[PartGPU], "need", p.gpu.PCIe8Pin, "psu", p.names[PartPSU], "have", p.psu.PCIe8Pin}, true
+		},
+	},
+	{
+		ID: "psu_connector", Severity: CompatWarning, Needs: []string{PartPSU, PartGPU},
+		Test: func(p *compatParts) ([]any, bool) {
+			if !p.gpu.Needs12VHPWR || !p.psu.ConnectorsKnown || p.psu.Has12VHPWR {
+				return nil, false
+			}
+			return []any{"gpu", p.names[PartGPU], "psu", p.names[PartPSU]}, true
+		},
+	},
+}
+
+// mountGroup - sovutgich mahkamlagichi bir xil soketlar (AM4/AM5, LGA1700/LGA1851, LGA115x/1200)
+func mountGroup(socket string) string {
+	switch socket {
+	case "AM4", "AM5":
+		return "AM"
+	case "LGA1700", "LGA1851":
+		return "LGA1700"
+	case "LGA1151", "LGA1200":
+		return "LGA1200"
+	}
+	return socket
+}
+
+// CompatibilityChecker PC yig'ma mosligini qoidalar jadvali bo'yicha tekshiradi
+type CompatibilityChecker struct {
+	rules []compatRule
+}
+
+// NewCompatibilityChecker standart qoidalar bilan tekshiruvchi
+func NewCompatibilityChecker() *CompatibilityChecker {
+	return &CompatibilityChecker{rules: compatRules}
+}
+
+// Check yig'mani tekshiradi; yo'q komponentlar qatnashgan qoidalar o'tkazib yuboriladi,
+// shu sabab qisman yig'mani ham (masalan, faqat CPU + plata) tekshirish mumkin
+func (c *CompatibilityChecker) Check(build *entity.PCBuild) []Violation {
+	if build == nil {
+		return nil
+	}
+	parts := newCompatParts(build)
+	var out []Violation
+	for _, rule := range c.rules {
+		ready := true
+		for _, need := range rule.Needs {
+			if !parts.present[need] {
+				ready = false
+				break
+			}
+		}
+		if !ready {
+			continue
+		}
+		args, violated := rule.Test(parts)
+		if !violated {
+			continue
+		}
+		var names []string
+		for _, need := range rule.Needs {
+			names = append(names, parts.names[need])
+		}
+		out = append(out, Violation{Rule: rule.ID, Severity: rule.Severity, Components: names, Args: args})
+	}
+	return out
+}
+
+// Compatible yig'mada error darajasidagi buzilish yo'qmi
+func (c *CompatibilityChecker) Compatible(build *entity.PCBuild) bool {
+	return !HasCompatErrors(c.Check(build))
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+func violationRules(vs []Violation) []string {
+	var out []string
+	for _, v := range vs {
+		out = append(out, v.Rule)
+	}
+	return out
+}
+
+func TestPartAttrsFromNames(t *testing.T) {
+	cpu := PartAttrs(PartCPU, entity.Product{Name: "AMD Ryzen 5 7600X"})
+	if cpu.Socket != "AM5" || cpu.TDP != 105 || cpu.NoIGPU || len(cpu.Memory) != 1 || cpu.Memory[0] != "DDR5" {
+		t.Fatalf("7600X: %+v", cpu)
+	}
+	cpu = PartAttrs(PartCPU, entity.Product{Name: "Intel Core i5-13600KF"})
+	if cpu.Socket != "LGA1700" || !cpu.Overclock || !cpu.NoIGPU || len(cpu.Memory) != 2 {
+		t.Fatalf("13600KF: %+v", cpu)
+	}
+	board := PartAttrs(PartMotherboard, entity.Product{Name: "MSI PRO B760M-A DDR4"})
+	if board.Socket != "LGA1700" || board.Chipset != "B760" || board.FormFactor != FormMATX || len(board.Memory) != 1 || board.Memory[0] != "DDR4" {
+		t.Fatalf("B760M: %+v", board)
+	}
+	board = PartAttrs(PartMotherboard, entity.Product{Name: "Gigabyte X670E AORUS MASTER", Specs: map[string]string{"Форм-фактор": "E-ATX"}})
+	if board.Socket != "AM5" || board.Chipset != "X670E" || board.FormFactor != FormEATX || board.PCIeGen != 5 || board.Memory[0] != "DDR5" {
+		t.Fatalf("X670E: %+v", board)
+	}
+	if psu := PartAttrs(PartPSU, entity.Product{Name: "Corsair RM850e ATX 3.0"}); psu.Watts != 850 || !psu.Has12VHPWR {
+		t.Fatalf("RM850e: %+v", psu)
+	}
+	if c := PartAttrs(PartCase, entity.Product{Name: "Cooler Master MasterBox Q300L mATX"}); len(c.Fits) != 2 || c.Fits[0] != FormMATX {
+		t.Fatalf("Q300L: %+v", c)
+	}
+}
+
+func TestCompatibilityCheck(t *testing.T) {
+	checker := NewCompatibilityChecker()
+
+	// AM5 protsessor + LGA1700 DDR4 plata + DDR4 xotira
+	build := &entity.PCBuild{
+		CPU:         entity.Product{Name: "Ryzen 7 7800X3D"},
+		Motherboard: entity.Product{Name: "ASUS PRIME B760M-A D4"},
+		RAM:         entity.Product{Name: "Kingston Fury 32GB DDR4 3200"},
+		GPU:         entity.Product{Name: "RTX 4070 Super", Specs: map[string]string{"Length": "336 mm"}},
+		PSU:         entity.Product{Name: "DeepCool PK400D"},
+		Case:        &entity.Product{Name: "Mini case", Specs: map[string]string{"Max GPU length": "320"}},
+	}
+	got := violationRules(checker.Check(build))
+	want := []string{"cpu_socket", "cpu_memory", "gpu_length", "psu_wattage"}
+	if len(got) != len(want) {
+		t.Fatalf("qoidalar: %v, kutilgan %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("qoidalar: %v, kutilgan %v", got, want)
+		}
+	}
+
+	// To'g'ri yig'ma
+	build = &entity.PCBuild{
+		CPU:         entity.Product{Name: "Ryzen 7 7800X3D"},
+		Motherboard: entity.Product{Name: "MSI MAG B650 TOMAHAWK WIFI"},
+		RAM:         entity.Product{Name: "G.Skill Flare X5 32GB DDR5 6000"},
+		GPU:         entity.Product{Name: "RTX 4070 Super"},
+		PSU:         entity.Product{Name: "Corsair RM750e"},
+		Cooler:      &entity.Product{Name: "Thermalright Peerless Assassin 120 SE", Specs: map[string]string{"Socket": "AM4, AM5, 1700"}},
+	}
+	if vs := checker.Check(build); len(vs) != 0 {
+		t.Fatalf("buzilish bo'lmasligi kerak: %v", violationRules(vs))
+	}
+
+	// Qisman yig'ma: faqat F protsessor, videokarta yo'q
+	build = &entity.PCBuild{CPU: entity.Product{Name: "Core i5-12400F"}, GPU: entity.Product{Name: "Integrated Graphics (CPU)"}}
+	if vs := checker.Check(build); len(vs) != 1 || vs[0].Rule != "display_output" || !HasCompatErrors(vs) {
+		t.Fatalf("display_output kutilgan: %v", violationRules(vs))
+	}
+}
+
+func TestEstimatePower(t *testing.T) {
+	load, rec := EstimatePower(&entity.PCBuild{
+		CPU: entity.Product{Name: "Intel Core i5-13600K"},
+		GPU: entity.Product{Name: "RTX 4070"},
+	})
+	if load != 494 || rec != 700 {
+		t.Fatalf("load=%d rec=%d", load, rec)
+	}
+}
@@ -3,352 +3,131 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
 )
 
 // PCAnalyzer PC konfiguratsiyasini tahlil qiladi
 type PCAnalyzer struct {
 	chatUseCase ChatUseCase
+	bench       *BenchmarkDB
 }
 
-func pickLang(lang, uz, ru string) string {
-	if strings.ToLower(strings.TrimSpace(lang)) == "ru" {
-		return ru
+// NewPCAnalyzer yangi PCAnalyzer yaratish; bench nil bo'lsa o'rnatilgan baza ishlatiladi
+func NewPCAnalyzer(chatUseCase ChatUseCase, bench *BenchmarkDB) *PCAnalyzer {
+	if bench == nil {
+		bench = DefaultBenchmarks()
 	}
-	return uz
-}
-
-// NewPCAnalyzer yangi PCAnalyzer yaratish
-func NewPCAnalyzer(chatUseCase ChatUseCase) *PCAnalyzer {
 	return &PCAnalyzer{
 		chatUseCase: chatUseCase,
+		bench:       bench,
 	}
 }
 
-// AnalyzePC to'liq PC tahlil (AI yordamida)
+// AnalyzePC to'liq PC tahlil: raqamlar benchmark bazasidan, AI faqat qisqa xulosa yozadi
 func (a *PCAnalyzer) AnalyzePC(ctx context.Context, build *entity.PCBuild, lang string) (*entity.PCAnalytics, error) {
-	// AI uchun prompt tayyorlash
-	promptUZ := `Quyidagi PC konfiguratsiyasini professional tahlil qilib ber:
-
-CPU: %s
-GPU: %s
-RAM: %s
-SSD: %s
-PSU: %s
-Case: %s
-Cooler: %s
-Maqsad: %s
-
-MUHIM: FPS ma'lumotlari REAL WORLD benchmark'lardan olish kerak (Tom's Hardware, TechPowerUp, GamersNexus)
-
-Tahlil quyidagi formatda bo'lishi SHART (har bir bo'lim alohida qatorda):
-
-FPS_CS2_1080P: [raqam, Competitive Low settings]
-FPS_CS2_1440P: [raqam, Competitive Low settings]
-FPS_CYBERPUNK_1080P: [raqam, High/Ultra settings]
-FPS_CYBERPUNK_1440P: [raqam, High settings]
-FPS_PUBG_1080P: [raqam, Medium settings]
-FPS_PUBG_1440P: [raqam, Medium settings]
-FPS_GTA5_1080P: [raqam, High/Very High settings]
-FPS_GTA5_1440P: [raqam, High settings]
-FPS_FORTNITE_1080P: [raqam, High/Epic settings]
-FPS_FORTNITE_1440P: [raqam, Medium/High settings]
-FPS_COD_1080P: [raqam, Medium-High settings]
-FPS_COD_1440P: [raqam, Medium settings]
-
-CPU_TEMP_IDLE: [30-45 raqam, °C]
-CPU_TEMP_LOAD: [70-95 raqam, °C]
-GPU_TEMP_IDLE: [30-50 raqam, °C]
-GPU_TEMP_LOAD: [75-85 raqam, °C]
-
-BOTTLENECK_PERCENT: [0-30 raqam]
-BOTTLENECK_TYPE: [CPU/GPU/None]
-BOTTLENECK_DESC: [qisqa tushuntirish]
-
-TDP_CPU_MAX: [raqam W]
-TDP_GPU_MAX: [raqam W]
-
-STORAGE_READ: [NVMe uchun 3000-7000 MB/s]
-STORAGE_WRITE: [NVMe uchun 2000-6000 MB/s]
-
-OVERALL_SCORE: [0-10 raqam]
-
-REAL WORLD EXAMPLES (I5-13400F + RTX 4070 uchun):
-- CS2 (Competitive Low): 1080p ~280+ FPS, 1440p ~170+ FPS
-- Cyberpunk 2077 (High/Ultra): 1080p ~100-110 FPS, 1440p ~65-75 FPS
-- Fortnite (High/Epic): 1080p ~180+ FPS, 1440p ~120+ FPS
-- GTA 5 (High/Very High): 1080p ~150+ FPS, 1440p ~100+ FPS
-- PUBG (Medium/High): 1080p ~160+ FPS, 1440p ~110+ FPS
-- Call of Duty (Medium/High): 1080p ~140+ FPS, 1440p ~90+ FPS
-
-Faqat shu formatda javob ber. Hech qanday qo'shimcha so'z yozma.`
-
-	promptRU := `Профессионально проанализируй следующую конфигурацию ПК:
-
-CPU: %s
-GPU: %s
-RAM: %s
-SSD: %s
-PSU: %s
-Case: %s
-Cooler: %s
-Назначение: %s
-
-ВАЖНО: значения FPS бери из реальных бенчмарков (Tom's Hardware, TechPowerUp, GamersNexus).
-
-Ответ ДОЛЖЕН быть строго в таком формате (каждый параметр на отдельной строке, без лишних слов):
-
-FPS_CS2_1080P: [число, Competitive Low settings]
-FPS_CS2_1440P: [число, Competitive Low settings]
-FPS_CYBERPUNK_1080P: [число, High/Ultra settings]
-FPS_CYBERPUNK_1440P: [число, High settings]
-FPS_PUBG_1080P: [число, Medium settings]
-FPS_PUBG_1440P: [число, Medium settings]
-FPS_GTA5_1080P: [число, High/Very High settings]
-FPS_GTA5_1440P: [число, High settings]
-FPS_FORTNITE_1080P: [число, High/Epic settings]
-FPS_FORTNITE_1440P: [число, Medium/High settings]
-FPS_COD_1080P: [число, Medium-High settings]
-FPS_COD_1440P: [число, Medium settings]
-
-CPU_TEMP_IDLE: [30-45 число, °C]
-CPU_TEMP_LOAD: [70-95 число, °C]
-GPU_TEMP_IDLE: [30-50 число, °C]
-GPU_TEMP_LOAD: [75-85 число, °C]
-
-BOTTLENECK_PERCENT: [0-30 число]
-BOTTLENECK_TYPE: [CPU/GPU/None]
-BOTTLENECK_DESC: [короткое объяснение]
-
-TDP_CPU_MAX: [число W]
-TDP_GPU_MAX: [число W]
-
-STORAGE_READ: [для NVMe 3000-7000 MB/s]
-STORAGE_WRITE: [для NVMe 2000-6000 MB/s]
-
-OVERALL_SCORE: [0-10 число]
-
-REAL WORLD EXAMPLES (для I5-13400F + RTX 4070):
-- CS2 (Competitive Low): 1080p ~280+ FPS, 1440p ~170+ FPS
-- Cyberpunk 2077 (High/Ultra): 1080p ~100-110 FPS, 1440p ~65-75 FPS
-- Fortnite (High/Epic): 1080p ~180+ FPS, 1440p ~120+ FPS
-- GTA 5 (High/Very High): 1080p ~150+ FPS, 1440p ~100+ FPS
-- PUBG (Medium/High): 1080p ~160+ FPS, 1440p ~110+ FPS
-- Call of Duty (Medium/High): 1080p ~140+ FPS, 1440p ~90+ FPS
-
-Отвечай только в этом формате. Никаких дополнительных слов.`
-
-	promptTemplate := promptUZ
-	if strings.ToLower(strings.TrimSpace(lang)) == "ru" {
-		promptTemplate = promptRU
-	}
-
-	prompt := fmt.Sprintf(promptTemplate,
-		build.CPU.Name,
-		build.GPU.Name,
-		build.RAM.Name,
-		build.SSD.Name,
-		build.PSU.Name,
-		safeName(build.Case),
-		safeName(build.Cooler),
-		build.Purpose,
-	)
-
-	// AI dan javob olish
-	response, err := a.chatUseCase.ProcessMessage(ctx, build.UserID, "system", prompt)
+	analytics := a.Compute(build, lang)
+	if a.chatUseCase == nil {
+		return analytics, nil
+	}
+	summary, err := a.chatUseCase.ProcessMessage(ctx, build.UserID, "system", a.summaryPrompt(build, analytics, lang))
 	if err != nil {
-		return nil, fmt.Errorf("AI analysis failed: %w", err)
+		// Xulosasiz ham tahlil to'liq
+		log.Printf("PC analysis summary failed: %v", err)
+		return analytics, nil
 	}
-
-	// Javobni parse qilish
-	analytics := a.parseAIResponse(response, build, lang)
+	analytics.Summary = strings.TrimSpace(summary)
 	return analytics, nil
 }
 
-func safeName(p *entity.Product) string {
-	if p == nil {
-		return "Not specified"
+// summaryPrompt hisoblangan natijalar bilan; AI raqamlarni o'zgartirmasligi kerak
+func (a *PCAnalyzer) summaryPrompt(build *entity.PCBuild, analytics *entity.PCAnalytics, lang string) string {
+	var facts strings.Builder
+	facts.WriteString(fmt.Sprintf("CPU: %s\nGPU: %s\nRAM: %s\nSSD: %s\nPSU: %s\n", build.CPU.Name, build.GPU.Name, build.RAM.Name, build.SSD.Name, build.PSU.Name))
+	facts.WriteString(fmt.Sprintf("Purpose: %s\nOverall: %.1f/10\n", analytics.UseCaseMatch.RequestedUseCase, analytics.OverallScore))
+	for _, g := range a.bench.Games {
+		if fps, ok := analytics.FPS[g.Name]; ok {
+			facts.WriteString(fmt.Sprintf("%s: 1080p %d FPS, 1440p %d FPS\n", g.Name, fps.FPS1080p, fps.FPS1440p))
+		}
 	}
-	return p.Name
+	facts.WriteString(fmt.Sprintf("Bottleneck: %s %.0f%%\n", analytics.Bottleneck.BottleneckType, analytics.Bottleneck.Percentage))
+	facts.WriteString(fmt.Sprintf("CPU load temp: %d°C, GPU load temp: %d°C\n", analytics.CPUTemp.Load, analytics.GPUTemp.Load))
+	facts.WriteString(fmt.Sprintf("Power: ~%dW (peak ~%dW), PSU %dW, recommended %dW\n", analytics.PowerConsumption.TotalWattage,
+		analytics.PowerConsumption.TransientWattage, analytics.PowerConsumption.PSUWattage, analytics.PowerConsumption.RecommendedWattage))
+
+	return i18n.T(lang, "analysis.summary_prompt") + facts.String()
 }
 
-// parseAIResponse AI javobini structga o'girish
-func (a *PCAnalyzer) parseAIResponse(response string, build *entity.PCBuild, lang string) *entity.PCAnalytics {
+// Compute benchmark bazasi asosida deterministik tahlil (bir xil yig'ma - bir xil natija)
+func (a *PCAnalyzer) Compute(build *entity.PCBuild, lang string) *entity.PCAnalytics {
 	analytics := &entity.PCAnalytics{
 		FPS: make(map[string]entity.FPSData),
 	}
 
-	lines := strings.Split(response, "\n")
-	var tdpCPU, tdpGPU int
-
-	for _, line := range lines {
-		parts := strings.SplitN(line, ":", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		// FPS parsing
-		if strings.HasPrefix(key, "FPS_") {
-			parts := strings.Split(key, "_")
-			if len(parts) >= 3 {
-				// Handle COD_WARZONE special case
-				var gameCode, res string
-				if parts[1] == "COD" && len(parts) >= 4 {
-					gameCode = "COD_WARZONE"
-					res = parts[3]
-				} else {
-					gameCode = parts[1]
-					res = parts[2]
-				}
-
-				fps, _ := strconv.Atoi(extractNumber(value))
-				gameName := mapGameCodeToName(gameCode)
-
-				data, ok := analytics.FPS[gameName]
-				if !ok {
-					// Use pointers if needed, but currently using value map
-					// Using map assignment replaces the struct value
-					data = entity.FPSData{GameName: gameName, Resolution: "Mixed"}
-				}
-
-				if res == "1080P" {
-					data.FPS1080p = fps
-				} else if res == "1440P" {
-					data.FPS1440p = fps
-				}
-
-				// Playability
-				data.IsPlayable = data.FPS1080p >= 60 || data.FPS1440p >= 60
-
-				if data.FPS1080p >= 144 {
-					data.Smoothness = "Smooth"
-				} else if data.FPS1080p >= 60 {
-					data.Smoothness = "Playable"
-				} else {
-					data.Smoothness = "Stuttering"
-				}
-				analytics.FPS[gameName] = data
-			}
-		}
-
-		// Temp parsing
-		if key == "CPU_TEMP_IDLE" {
-			analytics.CPUTemp.Idle, _ = strconv.Atoi(extractNumber(value))
-		} else if key == "CPU_TEMP_LOAD" {
-			analytics.CPUTemp.Load, _ = strconv.Atoi(extractNumber(value))
-			analytics.CPUTemp.Status = getTempStatus(analytics.CPUTemp.Load)
-		} else if key == "GPU_TEMP_IDLE" {
-			analytics.GPUTemp.Idle, _ = strconv.Atoi(extractNumber(value))
-		} else if key == "GPU_TEMP_LOAD" {
-			analytics.GPUTemp.Load, _ = strconv.Atoi(extractNumber(value))
-			analytics.GPUTemp.Status = getTempStatus(analytics.GPUTemp.Load)
-		}
-
-		// Bottleneck
-		if key == "BOTTLENECK_PERCENT" {
-			analytics.Bottleneck.Percentage, _ = strconv.ParseFloat(extractNumber(value), 64)
-		} else if key == "BOTTLENECK_TYPE" {
-			analytics.Bottleneck.BottleneckType = value
-			analytics.Bottleneck.HasBottleneck = value != "None"
-		} else if key == "BOTTLENECK_DESC" {
-			analytics.Bottleneck.Description = value
-		}
-
-		// Power Raw Data
-		if key == "TDP_CPU_MAX" {
-			tdpCPU, _ = strconv.Atoi(extractNumber(value))
-		} else if key == "TDP_GPU_MAX" {
-			tdpGPU, _ = strconv.Atoi(extractNumber(value))
-		}
-
-		// Storage & Boot
-		if key == "STORAGE_READ" {
-			analytics.StorageSpeed.ReadSpeed, _ = strconv.Atoi(extractNumber(value))
-		} else if key == "STORAGE_WRITE" {
-			analytics.StorageSpeed.WriteSpeed, _ = strconv.Atoi(extractNumber(value))
-		} else if key == "BOOT_TIME" {
-			analytics.BootTime.BootTime, _ = strconv.Atoi(extractNumber(value))
-		}
-
-		// Overall
-		if key == "OVERALL_SCORE" {
-			analytics.OverallScore, _ = strconv.ParseFloat(extractNumber(value), 64)
-		}
+	cpuScore, tdpCPU, _ := a.bench.cpuBenchScore(build.CPU)
+	gpuScore, tdpGPU, gpuName, _ := a.bench.gpuBenchScore(build.GPU)
+	discrete := hasGPU(build.GPU)
+	if tdpCPU == 0 {
+		tdpCPU = PartAttrs(PartCPU, build.CPU).TDP
 	}
-
-	// Power Logic Calculation (Manual)
-	// Base System overhead (Motherboard, RAM, SSD, Fans): ~60-80W
-	systemOverhead := 75
-
-	// If AI failed to give TDPs, use defaults to avoid 0
 	if tdpCPU == 0 {
 		tdpCPU = 65
 	} // Safe default for midrange
-	if tdpGPU == 0 {
-		tdpGPU = 115
-	} // Safe default for midrange
-
-	// Calculate Max Load
-	// We add 10% safety margin to component TDPs for potential boosts/AIB factory OCs
-	estimatedMaxLoad := int(float64(tdpCPU)*1.1+float64(tdpGPU)*1.1) + systemOverhead
-	analytics.PowerConsumption.TotalWattage = estimatedMaxLoad
-
-	// 2. Extract PSU wattage
-	psuWattage := extractWattageFromName(build.PSU.Name)
-	analytics.PowerConsumption.PSUWattage = psuWattage
+	if tdpGPU == 0 && discrete {
+		tdpGPU = PartAttrs(PartGPU, build.GPU).TDP
+		if tdpGPU == 0 {
+			tdpGPU = 115
+		} // Safe default for midrange
+	}
+
+	// FPS va bottleneck: har o'yinda GPU va CPU chegarasi solishtiriladi (1080p)
+	var cpuLoss, gpuLoss float64
+	for _, g := range a.bench.Games {
+		fps1080, fps1440 := a.bench.GameFPS(g, cpuScore, gpuScore, gpuName)
+		data := entity.FPSData{GameName: g.Name, Resolution: "Mixed", FPS1080p: fps1080, FPS1440p: fps1440}
+		data.IsPlayable = data.FPS1080p >= 60 || data.FPS1440p >= 60
+		if data.FPS1080p >= 144 {
+			data.Smoothness = "Smooth"
+		} else if data.FPS1080p >= 60 {
+			data.Smoothness = "Playable"
+		} else {
+			data.Smoothness = "Stuttering"
+		}
+		analytics.FPS[g.Name] = data
 
-	// 3. Calculate Recommended
-	// Recommended: Load + 200W safety margin (User request)
-	recommended := float64(estimatedMaxLoad + 200)
-	// Round up to nearest 50
-	recInt := int(recommended)
-	if recInt%50 != 0 {
-		recInt = ((recInt / 50) + 1) * 50
+		gpu1080, _, cpuCap := a.bench.gameLimits(g, cpuScore, gpuScore, gpuName)
+		if gpu1080 > 0 && cpuCap > 0 {
+			cpuLoss += math.Max(0, 1-cpuCap/gpu1080)
+			gpuLoss += math.Max(0, 1-gpu1080/cpuCap)
+		}
 	}
+	if n := float64(len(a.bench.Games)); n > 0 {
+		cpuLoss, gpuLoss = cpuLoss/n*100, gpuLoss/n*100
+	}
+	analytics.Bottleneck = bottleneckFromLoss(cpuLoss, gpuLoss, discrete, lang)
 
-	analytics.PowerConsumption.HeadRoom = float64(psuWattage - estimatedMaxLoad)
-
-	// Is Adequate?
-	// As long as PSU Wattage >= Estimated Load + 10%, it's "Technically Safe",
-	// but "Recommended" is usually higher.
-	// Let's say Strict Limit is Load + 50W.
-	safeLimit := estimatedMaxLoad + 50
-
-	analytics.PowerConsumption.IsAdequate = psuWattage >= safeLimit
-
-	if analytics.PowerConsumption.IsAdequate {
-		if psuWattage >= recInt {
-			analytics.PowerConsumption.Recommendation = fmt.Sprintf(pickLang(lang,
-				"✅ Mukammal! PSU quvvati yetarli va zaxirasi bor. (Rec: %dW)",
-				"✅ Отлично! Мощности БП хватает с запасом. (Rec: %dW)",
-			), recInt)
-		} else {
-			analytics.PowerConsumption.Recommendation = fmt.Sprintf(pickLang(lang,
-				"✅ Yetarli, lekin kelajakdagi upgrade uchun %dW yaxshiroq bo'lardi.",
-				"✅ Достаточно, но для будущего апгрейда лучше %dW.",
-			), recInt)
+	// Temperatura: TDP va sovutish turidan
+	analytics.CPUTemp = cpuTemperature(build.Cooler, tdpCPU, lang)
+	if discrete {
+		load := 60 + tdpGPU/20
+		if load > 84 {
+			load = 84
 		}
+		analytics.GPUTemp = entity.TemperatureData{Idle: 35, Load: load, CoolerType: "Air", Status: getTempStatus(load)}
 	} else {
-		analytics.PowerConsumption.Recommendation = fmt.Sprintf(pickLang(lang,
-			"⚠️ XAVFLI! PSU kuchsizlik qilishi mumkin. Tavsiya: %dW",
-			"⚠️ РИСКОВАННО! БП может не потянуть. Рекомендация: %dW",
-		), recInt)
+		analytics.GPUTemp = entity.TemperatureData{Idle: analytics.CPUTemp.Idle, Load: analytics.CPUTemp.Load, CoolerType: "iGPU", Status: analytics.CPUTemp.Status}
 	}
 
-	// Fill missing fields with defaults if needed
-	if analytics.BootTime.BootTime < 10 {
-		analytics.BootTime.Description = "Fast"
-	} else {
-		analytics.BootTime.Description = "Normal"
-	}
+	analytics.PowerConsumption = powerData(build, ComputePowerBudget(build, a.bench), lang)
 
-	storageScore, storageType, defaultRead, defaultWrite := scoreStorage(build.SSD.Name)
+	storageScore, storageType, defaultRead, defaultWrite := scoreStorage(storageDescriptor(build.SSD))
+	analytics.BootTime = bootTime(storageType)
 	if analytics.StorageSpeed.Type == "" {
 		analytics.StorageSpeed.Type = storageType
 	}
@@ -363,36 +142,83 @@ func (a *PCAnalyzer) parseAIResponse(response string, build *entity.PCBuild, lan
 	}
 
 	analytics.UseCaseMatch = computeUseCaseMatch(build, lang, storageScore, storageType)
+	analytics.OverallScore = analytics.UseCaseMatch.Matches[analytics.UseCaseMatch.RequestedUseCase].Score
 
 	return analytics
 }
 
-func extractNumber(s string) string {
-	re := regexp.MustCompile(`\d+(\.\d+)?`)
-	return re.FindString(s)
+// bottleneckFromLoss o'yinlardagi o'rtacha yo'qotish (%): CPU tufayli ishlatilmagan GPU quvvati va aksincha
+func bottleneckFromLoss(cpuLoss, gpuLoss float64, discrete bool, lang string) entity.BottleneckAnalysis {
+	switch {
+	case cpuLoss >= 10:
+		return entity.BottleneckAnalysis{
+			HasBottleneck:  true,
+			BottleneckType: "CPU",
+			Percentage:     math.Round(cpuLoss),
+			Description:    i18n.T(lang, "analysis.bottleneck.cpu", "percent", fmt.Sprintf("%.0f", cpuLoss)),
+			Recommendation: i18n.T(lang, "analysis.bottleneck.cpu_tip"),
+		}
+	case discrete && gpuLoss >= 60:
+		return entity.BottleneckAnalysis{
+			HasBottleneck:  true,
+			BottleneckType: "GPU",
+			Percentage:     math.Round(gpuLoss),
+			Description:    i18n.T(lang, "analysis.bottleneck.gpu"),
+			Recommendation: i18n.T(lang, "analysis.bottleneck.gpu_tip"),
+		}
+	default:
+		return entity.BottleneckAnalysis{
+			BottleneckType: "None",
+			Percentage:     math.Round(cpuLoss),
+			Description:    i18n.T(lang, "analysis.bottleneck.none"),
+		}
+	}
+}
+
+// cpuTemperature TDP va sovutgich turidan taxminiy harorat (sovutgich yo'q - boxed)
+func cpuTemperature(cooler *entity.Product, tdp int, lang string) entity.TemperatureData {
+	coolerType, idle, k := "Stock", 38, 0.30
+	if cooler != nil && strings.TrimSpace(cooler.Name) != "" {
+		coolerType, idle, k = "Tower", 35, 0.18
+		if isAIO(cooler.Name) {
+			coolerType, idle, k = "AIO", 32, 0.14
+		}
+	}
+	load := 45 + int(math.Round(float64(tdp)*k))
+	if load > 95 {
+		load = 95
+	}
+	temp := entity.TemperatureData{Idle: idle, Load: load, CoolerType: coolerType, Status: getTempStatus(load)}
+	if temp.Status == "Hot" {
+		temp.Warning = i18n.T(lang, "analysis.temp.hot")
+	}
+	return temp
 }
 
-func mapGameCodeToName(code string) string {
-	switch code {
-	case "CS2":
-		return "CS2"
-	case "CYBERPUNK":
-		return "Cyberpunk 2077"
-	case "RDR2":
-		return "Red Dead Redemption 2"
-	case "GTA5":
-		return "GTA 5"
-	case "PUBG":
-		return "PUBG"
-	case "FORTNITE":
-		return "Fortnite"
-	case "FORZA":
-		return "Forza Horizon 5"
-	case "COD_WARZONE":
-		return "Call of Duty: Warzone"
+// bootTime disk turidan Windows yuklanish vaqti (s)
+func bootTime(storageType string) entity.BootTimeData {
+	data := entity.BootTimeData{StorageType: storageType}
+	switch storageType {
+	case "NVMe Gen5", "NVMe Gen4":
+		data.BootTime = 10
+	case "NVMe Gen3", "NVMe":
+		data.BootTime = 12
+	case "SATA SSD":
+		data.BootTime = 18
+	case "HDD":
+		data.BootTime = 40
+	default:
+		data.BootTime = 20
+	}
+	switch {
+	case data.BootTime <= 12:
+		data.Description = "Fast"
+	case data.BootTime <= 20:
+		data.Description = "Normal"
 	default:
-		return code
+		data.Description = "Slow"
 	}
+	return data
 }
 
 func getTempStatus(temp int) string {
@@ -404,21 +230,39 @@ func getTempStatus(temp int) string {
 	return "Good"
 }
 
-func extractWattageFromName(name string) int {
-	re := regexp.MustCompile(`(\d{3,4})[Ww]`)
-	match := re.FindStringSubmatch(name)
-	if len(match) > 1 {
-		val, _ := strconv.Atoi(match[1])
-		return val
+// powerData quvvat budjetini o'rnatilgan blok bilan solishtiradi
+func powerData(build *entity.PCBuild, b PowerBudget, lang string) entity.PowerData {
+	watts := productPSUWatts(build.PSU)
+	data := entity.PowerData{
+		TotalWattage:       b.Sustained,
+		TransientWattage:   b.Transient,
+		PSUWattage:         watts,
+		PSUEfficiency:      PSUEfficiency(build.PSU),
+		MinimumWattage:     b.Minimum,
+		RecommendedWattage: b.Recommended,
+		RecommendedTier:    b.Efficiency,
+		ATX3:               b.ATX3,
+	}
+	status := b.Check(watts)
+	data.IsAdequate = status == PSUAdequate || status == PSULowHeadroom
+	if watts > 0 {
+		data.HeadRoom = float64(watts - b.Sustained)
+	}
+	switch status {
+	case PSUAdequate:
+		data.Recommendation = i18n.T(lang, "analysis.power.adequate", "recommended", b.Recommended, "tier", b.Efficiency)
+	case PSULowHeadroom:
+		data.Recommendation = i18n.T(lang, "analysis.power.low_headroom", "recommended", b.Recommended, "tier", b.Efficiency)
+	case PSUUndersized:
+		data.Recommendation = i18n.T(lang, "analysis.power.undersized",
+			"transient", b.Transient, "minimum", b.Minimum, "recommended", b.Recommended, "tier", b.Efficiency)
+	default:
+		data.Recommendation = i18n.T(lang, "analysis.power.unknown", "minimum", b.Minimum, "recommended", b.Recommended, "tier", b.Efficiency)
 	}
-	// Fallback: look for just 3-4 digits if "W" is missing but it looks like a PSU model?
-	re2 := regexp.MustCompile(`\d{3,4}`)
-	match2 := re2.FindStringSubmatch(name)
-	if len(match2) > 0 {
-		val, _ := strconv.Atoi(match2[0])
-		return val
+	if b.ATX3 {
+		data.Recommendation += i18n.T(lang, "analysis.power.atx3")
 	}
-	return 0
+	return data
 }
 
 type hardwareProfile struct {
@@ -471,13 +315,13 @@ func computeUseCaseMatch(build *entity.PCBuild, lang string, storageScore float6
 }
 
 func buildHardwareProfile(build *entity.PCBuild, storageScore float64, storageType string) hardwareProfile {
-	cpuScore, cpuTier, serverCPU := scoreCPU(build.CPU.Name)
-	gpuScore, gpuTier := scoreGPU(build.GPU.Name)
-	ramGB := extractRAMSizeGB(build.RAM.Name)
+	cpuScore, cpuTier, serverCPU := scoreCPUProduct(build.CPU)
+	gpuScore, gpuTier := scoreGPUProduct(build.GPU)
+	ramGB := productRAMGB(build.RAM)
 	ramScore := scoreRAM(ramGB)
 
 	if storageScore <= 0 {
-		storageScore, storageType, _, _ = scoreStorage(build.SSD.Name)
+		storageScore, storageType, _, _ = scoreStorage(storageDescriptor(build.SSD))
 	}
 
 	return hardwareProfile{
@@ -514,27 +358,27 @@ func buildUseCaseScore(useCase string, profile hardwareProfile, lang string) ent
 		}
 
 		if profile.cpuScore >= 7 {
-			strengths = append(strengths, pickLang(lang, "CPU kuchli: build/compile tez", "Сильный CPU: быстрая сборка/компиляция"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.dev.cpu_strong"))
 		}
 		if profile.ramGB >= 32 {
-			strengths = append(strengths, pickLang(lang, "RAM ko'p: Docker/VM uchun qulay", "Много ОЗУ: удобно для Docker/VM"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.dev.ram_plenty"))
 		} else if profile.ramGB >= 16 {
-			strengths = append(strengths, pickLang(lang, "RAM yetarli: IDE va multitasking", "ОЗУ достаточно: IDE и мультизадачность"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.dev.ram_enough"))
 		}
 		if profile.storageScore >= 8 {
-			strengths = append(strengths, pickLang(lang, "NVMe tezligi: build cache va git tez", "NVMe скорость: быстрые build cache и git"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.dev.nvme"))
 		} else if profile.storageScore >= 6 {
-			strengths = append(strengths, pickLang(lang, "SSD bor: umumiy ish tez", "Есть SSD: система отзывчивая"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.dev.ssd"))
 		}
 
 		if profile.ramGB < 16 {
-			weaknesses = append(weaknesses, pickLang(lang, "RAM kam: katta loyihalar/VM sekin", "Мало ОЗУ: большие проекты/VM будут медленнее"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.dev.ram_low"))
 		}
 		if profile.storageScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "Disk sekin: build va repo operatsiyalar kechikadi", "Медленный диск: build и git операции дольше"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.dev.disk_slow"))
 		}
 		if profile.cpuScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "CPU past: compile sekin", "Слабый CPU: компиляция медленнее"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.dev.cpu_weak"))
 		}
 	case "Design":
 		score = profile.gpuScore*0.35 + profile.cpuScore*0.25 + profile.ramScore*0.25 + profile.storageScore*0.15
@@ -551,31 +395,31 @@ func buildUseCaseScore(useCase string, profile hardwareProfile, lang string) ent
 		}
 
 		if profile.gpuScore >= 7 {
-			strengths = append(strengths, pickLang(lang, "GPU kuchli: 3D/render uchun yaxshi", "Сильный GPU: хорошо для 3D/рендера"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.design.gpu_strong"))
 		}
 		if profile.cpuScore >= 7 {
-			strengths = append(strengths, pickLang(lang, "CPU yaxshi: render/encode tez", "Хороший CPU: быстрый рендер/энкод"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.design.cpu_good"))
 		}
 		if profile.ramGB >= 32 {
-			strengths = append(strengths, pickLang(lang, "RAM ko'p: 4K/PSD loyihalar uchun qulay", "Много ОЗУ: удобно для 4K/PSD проектов"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.design.ram_plenty"))
 		} else if profile.ramGB >= 16 {
-			strengths = append(strengths, pickLang(lang, "RAM yetarli: dizayn va montaj uchun", "ОЗУ достаточно: для дизайна и монтажа"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.design.ram_enough"))
 		}
 		if profile.storageScore >= 8 {
-			strengths = append(strengths, pickLang(lang, "NVMe: media cache tez", "NVMe: быстрый медиакэш"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.design.nvme"))
 		}
 
 		if profile.gpuScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "GPU zaif: 3D/GPU effektlar sekin", "Слабый GPU: 3D/GPU эффекты будут медленнее"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.design.gpu_weak"))
 		}
 		if profile.ramGB < 16 {
-			weaknesses = append(weaknesses, pickLang(lang, "RAM kam: katta fayllarda lag bo'lishi mumkin", "Мало ОЗУ: возможны лаги на больших проектах"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.design.ram_low"))
 		}
 		if profile.cpuScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "CPU sekin: render/encode sekinlashadi", "Слабый CPU: рендер/энкод медленнее"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.design.cpu_weak"))
 		}
 		if profile.storageScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "Disk sekin: media cache sekin ishlaydi", "Медленный диск: медиакэш будет тормозить"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.design.disk_slow"))
 		}
 	case "Server":
 		score = profile.cpuScore*0.4 + profile.ramScore*0.35 + profile.storageScore*0.2 + profile.gpuScore*0.05
@@ -593,26 +437,26 @@ func buildUseCaseScore(useCase string, profile hardwareProfile, lang string) ent
 		}
 
 		if profile.serverCPU {
-			strengths = append(strengths, pickLang(lang, "Server sinfi CPU: barqaror va ko'p yadro", "Серверный CPU: стабильность и много ядер"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.server.server_cpu"))
 		}
 		if profile.cpuScore >= 7 {
-			strengths = append(strengths, pickLang(lang, "CPU ko'p yadroli: parallel servislar uchun", "Многопоточный CPU: для параллельных сервисов"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.server.cpu_cores"))
 		}
 		if profile.ramGB >= 32 {
-			strengths = append(strengths, pickLang(lang, "RAM ko'p: VM/DB uchun qulay", "Много ОЗУ: удобно для VM/БД"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.server.ram_plenty"))
 		}
 		if profile.storageScore >= 7 {
-			strengths = append(strengths, pickLang(lang, "Disk tez: IO/DB ishlari uchun yaxshi", "Быстрый диск: хорош для IO/БД"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.server.disk_fast"))
 		}
 
 		if profile.ramGB < 32 {
-			weaknesses = append(weaknesses, pickLang(lang, "RAM kam: ko'p servislar uchun cheklov bo'lishi mumkin", "Мало ОЗУ: может ограничить число сервисов"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.server.ram_low"))
 		}
 		if profile.cpuScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "CPU past: yuqori yukda sekinlashadi", "Слабый CPU: медленнее при высокой нагрузке"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.server.cpu_weak"))
 		}
 		if profile.storageScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "Disk sekin: IO/DB ishlashi pasayadi", "Медленный диск: IO/БД будут медленнее"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.server.disk_slow"))
 		}
 	case "Office":
 		score = profile.cpuScore*0.3 + profile.ramScore*0.3 + profile.storageScore*0.3 + profile.gpuScore*0.1
@@ -627,23 +471,23 @@ func buildUseCaseScore(useCase string, profile hardwareProfile, lang string) ent
 		}
 
 		if profile.cpuScore >= 5 {
-			strengths = append(strengths, pickLang(lang, "CPU yetarli: ofis ishlari uchun", "CPU достаточно: для офисных задач"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.office.cpu_enough"))
 		}
 		if profile.ramGB >= 8 {
-			strengths = append(strengths, pickLang(lang, "RAM yetarli: ko'p tab va zoom uchun", "ОЗУ достаточно: много вкладок и zoom"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.office.ram_enough"))
 		}
 		if profile.storageScore >= 6 {
-			strengths = append(strengths, pickLang(lang, "SSD bor: tizim tez yuklanadi", "Есть SSD: система быстро загружается"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.office.ssd"))
 		}
 
 		if profile.ramGB < 8 {
-			weaknesses = append(weaknesses, pickLang(lang, "RAM kam: brauzerda sekinlashishi mumkin", "Мало ОЗУ: браузер может тормозить"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.office.ram_low"))
 		}
 		if profile.storageScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "Disk sekin: ochilishlar sekinlashadi", "Медленный диск: запуск программ дольше"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.office.disk_slow"))
 		}
 		if profile.cpuScore < 4.5 {
-			weaknesses = append(weaknesses, pickLang(lang, "CPU past: ofis ishlarida ham sekin", "Слабый CPU: даже офисные задачи медленнее"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.office.cpu_weak"))
 		}
 	default: // Gaming
 		score = profile.gpuScore*0.45 + profile.cpuScore*0.3 + profile.ramScore*0.15 + profile.storageScore*0.1
@@ -658,23 +502,23 @@ func buildUseCaseScore(useCase string, profile hardwareProfile, lang string) ent
 		}
 
 		if profile.gpuScore >= 7 {
-			strengths = append(strengths, pickLang(lang, "GPU kuchli: yuqori FPS uchun", "Сильный GPU: для высокого FPS"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.gaming.gpu_strong"))
 		}
 		if profile.cpuScore >= 7 {
-			strengths = append(strengths, pickLang(lang, "CPU yaxshi: stabil FPS", "Хороший CPU: стабильный FPS"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.gaming.cpu_good"))
 		}
 		if profile.ramGB >= 16 {
-			strengths = append(strengths, pickLang(lang, "RAM yetarli: AAA o'yinlar uchun", "ОЗУ достаточно: для AAA игр"))
+			strengths = append(strengths, i18n.T(lang, "analysis.fit.gaming.ram_enough"))
 		}
 
 		if profile.gpuScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "GPU zaif: og'ir o'yinlarda FPS past bo'lishi mumkin", "Слабый GPU: низкий FPS в тяжелых играх"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.gaming.gpu_weak"))
 		}
 		if profile.cpuScore < 6 {
-			weaknesses = append(weaknesses, pickLang(lang, "CPU bottleneck bo'lishi mumkin", "Возможен bottleneck по CPU"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.gaming.cpu_weak"))
 		}
 		if profile.ramGB < 16 {
-			weaknesses = append(weaknesses, pickLang(lang, "RAM kam: stutter bo'lishi mumkin", "Мало ОЗУ: возможны подлагивания"))
+			weaknesses = append(weaknesses, i18n.T(lang, "analysis.fit.gaming.ram_low"))
 		}
 	}
 
@@ -719,15 +563,15 @@ func normalizeUseCase(purpose string) string {
 func scoreDescription(score float64, lang string) string {
 	switch {
 	case score >= 8.5:
-		return pickLang(lang, "A'lo", "Отлично")
+		return i18n.T(lang, "analysis.rating.excellent")
 	case score >= 7.0:
-		return pickLang(lang, "Juda yaxshi", "Очень хорошо")
+		return i18n.T(lang, "analysis.rating.very_good")
 	case score >= 5.5:
-		return pickLang(lang, "Yaxshi", "Хорошо")
+		return i18n.T(lang, "analysis.rating.good")
 	case score >= 4.0:
-		return pickLang(lang, "O'rtacha", "Средне")
+		return i18n.T(lang, "analysis.rating.average")
 	default:
-		return pickLang(lang, "Zaif", "Слабо")
+		return i18n.T(lang, "analysis.rating.weak")
 	}
 }
 
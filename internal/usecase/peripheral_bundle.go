@@ -0,0 +1,550 @@
+package usecase
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Periferiya to'plami: monitor, klaviatura, sichqoncha va quloqchin qoldiqdan mijoz xohishlari
+// (simli/simsiz, switch turi, DPI, rang/RGB, Hz va panel) bo'yicha tanlanadi. Budjet turlar
+// orasida ulushlarga bo'linadi, tejalgan qism keyingi turga o'tadi; monitor chastotasi va
+// o'lchami videokarta o'yinlarda beradigan FPS ga moslanadi (BenchmarkDB).
+
+const (
+	PartKeyboard = "keyboard"
+	PartMouse    = "mouse"
+	PartHeadset  = "headset"
+)
+
+// PeripheralKinds to'plamdagi turlar tanlov tartibida (qimmatidan)
+var PeripheralKinds = []string{PartMonitor, PartKeyboard, PartMouse, PartHeadset}
+
+// peripheralShares - PC budjetiga nisbatan odatiy ulush; to'plam budjeti ham shu nisbatda bo'linadi
+var peripheralShares = map[string]float64{
+	PartMonitor:  0.18,
+	PartKeyboard: 0.05,
+	PartMouse:    0.03,
+	PartHeadset:  0.04,
+}
+
+// refreshTiers sotuvdagi monitor chastotalari
+var refreshTiers = []int{60, 75, 100, 144, 165, 180, 240, 280, 360, 500}
+
+var (
+	reDPI         = regexp.MustCompile(`(\d{3,5})\s*dpi`)
+	reSwitchColor = regexp.MustCompile(`(red|brown|blue|yellow|silver|black)\s*(?:switch|свитч|свич)`)
+	reSwitchAfter = regexp.MustCompile(`(?:switch|свитч|свич)\w*[\s:]+(red|brown|blue|yellow|silver|black)`)
+	reBudgetUSD   = regexp.MustCompile(`(\d[\d\s]*(?:[.,]\d+)?)\s*(?:\$|usd|dollar|доллар)`)
+	reHzPref      = regexp.MustCompile(`(\d{2,3})\s*(?:hz|гц)`)
+)
+
+// PeripheralAttrs nom/spec dan aniqlangan xususiyatlar; 0 va "" - noma'lum
+type PeripheralAttrs struct {
+	Kind       string
+	Wireless   bool
+	Wired      bool // nomida aniq "simli" yozilgan
+	Mechanical bool
+	Switch     string // red, brown, blue, yellow, silver, black, optical, membrane
+	DPI        int
+	RGB        bool
+	Color      string // black, white, pink
+	RefreshHz  int
+	Panel      string
+	Resolution string
+}
+
+// PeripheralPrefs mijoz xohishlari; bo'sh qiymat - farqi yo'q
+type PeripheralPrefs struct {
+	Connection   string // wired, wireless
+	Switch       string
+	MinDPI       int
+	Color        string // black, white, pink, rgb
+	MonitorHz    int
+	MonitorPanel string // IPS, VA, TN, OLED, miniLED
+}
+
+// PeripheralRequest to'plam so'rovi
+type PeripheralRequest struct {
+	BudgetUSD float64  // to'plam budjeti; 0 - cheklanmagan
+	Kinds     []string // bo'sh - klaviatura, sichqoncha, quloqchin
+	Prefs     PeripheralPrefs
+	Purpose   string
+	GPU       entity.Product // monitor shu kartaga moslanadi; bo'sh - cheklovsiz
+	Branch    string
+	Price     func(entity.Product) float64 // narx dollarda; nil - Price.Major()
+	Bench     *BenchmarkDB
+}
+
+// PeripheralPick tanlangan mahsulot; Misses - bajarilmagan xohishlar (connection, switch, dpi, color, hz, panel)
+type PeripheralPick struct {
+	Kind       string
+	Product    entity.Product
+	PriceUSD   float64
+	Allotted   float64 // shu turga ajratilgan budjet (oldingidan qolgani bilan)
+	OverBudget bool
+	Misses     []string
+}
+
+// PeripheralBundle tanlov natijasi
+type PeripheralBundle struct {
+	Picks         []PeripheralPick
+	Missing       []string // qoldiqda topilmagan turlar
+	Split         map[string]float64
+	BudgetUSD     float64
+	TotalUSD      float64
+	MonitorCapHz  int    // videokarta o'yinlarda "tortadigan" chastota; 0 - cheklovsiz
+	MonitorCapRes string // tavsiya etilgan o'lcham
+	HzCapped      bool   // mijoz so'ragan chastota karta imkoniyatidan yuqori
+}
+
+// PeripheralKind mahsulot periferiya turi (monitor ham); periferiya bo'lmasa ""
+func PeripheralKind(category, name string) string {
+	if SpecKind(category, name) == PartMonitor {
+		return PartMonitor
+	}
+	text := strings.ToLower(category + " " + name)
+	switch {
+	case containsAny(text, "mousepad", "mouse pad", "коврик", "gilamcha", "chair", "стул", "desk"):
+		return ""
+	case containsAny(text, "keyboard", "клавиатур", "klaviatura"):
+		return PartKeyboard
+	case containsAny(text, "mouse", "мышь", "мышк", "sichqon"):
+		return PartMouse
+	case containsAny(text, "headset", "headphone", "наушник", "гарнитур", "quloqchin"):
+		return PartHeadset
+	}
+	return ""
+}
+
+// PeripheralAttrsOf mahsulot xususiyatlari (monitor uchun ExtractSpecs bilan bir xil qoidalar)
+func PeripheralAttrsOf(p entity.Product) PeripheralAttrs {
+	a := PeripheralAttrs{Kind: PeripheralKind(p.Category, p.Name)}
+	text := " " + strings.ToLower(p.Name+" "+p.Description) + " "
+	if a.Kind == PartMonitor {
+		specs := extractMonitorSpecs(p)
+		a.RefreshHz, _ = strconv.Atoi(specs[entity.SpecRefreshRate])
+		a.Panel = specs[entity.SpecPanel]
+		if containsAny(text, "mini led", "miniled", "mini-led") {
+			a.Panel = "miniLED"
+		}
+		a.Resolution = specs[entity.SpecResolution]
+		return a
+	}
+	a.Wireless = containsAny(text, "wireless", "simsiz", "беспровод", "2.4ghz", "2.4 ghz", "bluetooth", "lightspeed", "hyperspeed")
+	a.Wired = !a.Wireless && containsAny(text, "wired", "simli", "провод")
+	a.Switch = switchOf(text)
+	a.Mechanical = (a.Switch != "" && a.Switch != "membrane") || containsAny(text, "mechanical", "механич", "mexanik")
+	if m := reDPI.FindStringSubmatch(text); m != nil {
+		a.DPI, _ = strconv.Atoi(m[1])
+	}
+	a.RGB = containsAny(text, "rgb", "подсвет")
+	a.Color = colorOf(text)
+	return a
+}
+
+func switchOf(text string) string {
+	if m := reSwitchColor.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	if m := reSwitchAfter.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	switch {
+	case containsAny(text, "optical switch", "оптич", "optik"):
+		return "optical"
+	case containsAny(text, "membrane", "мембран"):
+		return "membrane"
+	case containsAny(text, "linear", "линейн"):
+		return "red"
+	case containsAny(text, "tactile", "тактиль"):
+		return "brown"
+	case containsAny(text, "clicky", "кликающ"):
+		return "blue"
+	}
+	return ""
+}
+
+func colorOf(text string) string {
+	switch {
+	case containsAny(text, "white", " oq", " бел"):
+		return "white"
+	case containsAny(text, "pink", "розов", "pushti"):
+		return "pink"
+	case containsAny(text, "black", "qora", "черн", "чёрн"):
+		return "black"
+	}
+	return ""
+}
+
+// NormalizePeripheralColor konfigurator rangini ("Oq", "Чёрный", "RGB") xohishga o'giradi
+func NormalizePeripheralColor(color string) string {
+	lower := " " + strings.ToLower(strings.TrimSpace(color)) + " "
+	if containsAny(lower, "rgb", "подсвет") {
+		return "rgb"
+	}
+	return colorOf(lower)
+}
+
+// ParsePeripheralPrefs erkin matndan xohishlar ("simsiz, red switch, 16000 dpi, oq, 165hz IPS")
+func ParsePeripheralPrefs(text string) PeripheralPrefs {
+	lower := " " + strings.ToLower(text) + " "
+	var p PeripheralPrefs
+	switch {
+	case containsAny(lower, "wireless", "simsiz", "беспровод", "bluetooth"):
+		p.Connection = "wireless"
+	case containsAny(lower, "wired", "simli", "провод"):
+		p.Connection = "wired"
+	}
+	p.Switch = switchOf(lower)
+	if m := reDPI.FindStringSubmatch(lower); m != nil {
+		p.MinDPI, _ = strconv.Atoi(m[1])
+	}
+	p.Color = NormalizePeripheralColor(lower)
+	if m := reHzPref.FindStringSubmatch(lower); m != nil {
+		p.MonitorHz, _ = strconv.Atoi(m[1])
+	}
+	p.MonitorPanel = normalizePanel(lower)
+	return p
+}
+
+// ParseHz konfigurator qiymatidan chastota ("144Hz", "300Hz+"); aniqlanmasa 0
+func ParseHz(s string) int {
+	if m := reHzPref.FindStringSubmatch(strings.ToLower(s)); m != nil {
+		hz, _ := strconv.Atoi(m[1])
+		return hz
+	}
+	return 0
+}
+
+func normalizePanel(text string) string {
+	text = " " + strings.ToLower(text) + " "
+	switch {
+	case containsAny(text, "miniled", "mini led", "mini-led"):
+		return "miniLED"
+	case strings.Contains(text, "oled"):
+		return "OLED"
+	case strings.Contains(text, "ips"):
+		return "IPS"
+	case containsAny(text, " va ", " va,"):
+		return "VA"
+	case containsAny(text, " tn ", " tn,"):
+		return "TN"
+	}
+	return ""
+}
+
+// ParsePeripheralRequest mijoz matnidan to'plam so'rovi: budjet ($), turlar, xohishlar va videokarta
+func ParsePeripheralRequest(text string, bench *BenchmarkDB) PeripheralRequest {
+	lower := strings.ToLower(text)
+	req := PeripheralRequest{Prefs: ParsePeripheralPrefs(text), Purpose: normalizeUseCase(text), Bench: bench}
+	if m := reBudgetUSD.FindStringSubmatch(lower); m != nil {
+		num := strings.ReplaceAll(strings.ReplaceAll(m[1], " ", ""), ",", ".")
+		req.BudgetUSD, _ = strconv.ParseFloat(num, 64)
+	}
+	for _, kind := range PeripheralKinds {
+		mentioned := false
+		switch kind {
+		case PartMonitor:
+			mentioned = containsAny(lower, "monitor", "монитор", "ekran", "экран") || req.Prefs.MonitorHz > 0
+		case PartKeyboard:
+			mentioned = containsAny(lower, "keyboard", "клавиатур", "klaviatura")
+		case PartMouse:
+			mentioned = containsAny(lower, "mouse", "мыш", "sichqon")
+		case PartHeadset:
+			mentioned = containsAny(lower, "headset", "headphone", "наушник", "гарнитур", "quloqchin")
+		}
+		if mentioned {
+			req.Kinds = append(req.Kinds, kind)
+		}
+	}
+	if build, ok := ParsePCDescription(text, bench); ok {
+		req.GPU = build.GPU
+	}
+	return req
+}
+
+// PeripheralBudget konfigurator uchun: PC budjetidan tanlangan turlarga ajratiladigan summa
+func PeripheralBudget(pcBudgetUSD float64, kinds []string) float64 {
+	total := 0.0
+	for _, kind := range kinds {
+		total += peripheralShares[kind]
+	}
+	return math.Round(pcBudgetUSD * total)
+}
+
+// SplitPeripheralBudget to'plam budjetini turlar orasida ulushlarga mutanosib bo'ladi
+func SplitPeripheralBudget(budgetUSD float64, kinds []string) map[string]float64 {
+	sum := 0.0
+	for _, kind := range kinds {
+		sum += peripheralShares[kind]
+	}
+	split := make(map[string]float64, len(kinds))
+	if sum == 0 {
+		return split
+	}
+	for _, kind := range kinds {
+		split[kind] = math.Round(budgetUSD * peripheralShares[kind] / sum)
+	}
+	return split
+}
+
+// MonitorCapability videokarta o'yinlarda (o'rtacha) beradigan FPS bo'yicha mos chastota va o'lcham.
+// Karta ko'rsatilmagan bo'lsa 0, "" - cheklov yo'q.
+func MonitorCapability(gpu entity.Product, bench *BenchmarkDB) (int, string) {
+	if strings.TrimSpace(gpu.Name) == "" {
+		return 0, ""
+	}
+	// Diskret karta yo'q - o'rnatilgan grafika
+	if _, tier := scoreGPUProduct(gpu); tier == "iGPU" || containsAny(strings.ToLower(gpu.Name), "встроен", "kerak emas", "не нужно") {
+		return 75, "1920x1080"
+	}
+	if bench == nil {
+		bench = DefaultBenchmarks()
+	}
+	score, _, name, _ := bench.gpuBenchScore(gpu)
+	var fps1080, fps1440 []float64
+	for _, g := range bench.Games {
+		if g.FPS1080 <= 0 || g.FPS1440 <= 0 {
+			continue
+		}
+		f1080, f1440, _ := bench.gameLimits(g, 0, score, name)
+		fps1080 = append(fps1080, f1080)
+		fps1440 = append(fps1440, f1440)
+	}
+	if len(fps1080) == 0 {
+		return 0, ""
+	}
+	m1080, m1440 := median(fps1080), median(fps1440)
+	// Tez dinamik o'yinlarda FPS o'rtachadan ancha yuqori - chastota zaxira bilan olinadi
+	switch {
+	case m1440 >= 170:
+		return refreshTier(m1440 * 0.55 * 1.65), "3840x2160"
+	case m1440 >= 75:
+		return refreshTier(m1440 * 1.65), "2560x1440"
+	}
+	return refreshTier(m1080 * 1.65), "1920x1080"
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// refreshTier fps dan oshmaydigan eng yuqori sotuvdagi chastota (kamida 60)
+func refreshTier(fps float64) int {
+	hz := refreshTiers[0]
+	for _, t := range refreshTiers {
+		if float64(t) <= fps {
+			hz = t
+		}
+	}
+	return hz
+}
+
+func resolutionPixels(res string) int {
+	parts := strings.Split(res, "x")
+	if len(parts) != 2 {
+		return 0
+	}
+	w, _ := strconv.Atoi(parts[0])
+	h, _ := strconv.Atoi(parts[1])
+	return w * h
+}
+
+type peripheralPicker struct {
+	req      PeripheralRequest
+	gaming   bool
+	targetHz int
+	capHz    int
+	capRes   string
+}
+
+// BuildPeripheralBundle qoldiqdagi mahsulotlardan xohish va budjetga eng mos to'plam
+func BuildPeripheralBundle(products []entity.Product, req PeripheralRequest) *PeripheralBundle {
+	if req.Price == nil {
+		req.Price = func(p entity.Product) float64 { return p.Price.Major() }
+	}
+	if len(req.Kinds) == 0 {
+		req.Kinds = []string{PartKeyboard, PartMouse, PartHeadset}
+	}
+	kinds := make([]string, 0, len(req.Kinds))
+	for _, kind := range PeripheralKinds {
+		for _, want := range req.Kinds {
+			if want == kind {
+				kinds = append(kinds, kind)
+				break
+			}
+		}
+	}
+	pk := &peripheralPicker{req: req, gaming: normalizeUseCase(req.Purpose) == "Gaming" || req.Purpose == ""}
+	bundle := &PeripheralBundle{BudgetUSD: req.BudgetUSD, Split: SplitPeripheralBudget(req.BudgetUSD, kinds)}
+	bundle.MonitorCapHz, bundle.MonitorCapRes = MonitorCapability(req.GPU, req.Bench)
+	pk.capHz, pk.capRes = bundle.MonitorCapHz, bundle.MonitorCapRes
+	pk.targetHz = req.Prefs.MonitorHz
+	if pk.capHz > 0 && pk.targetHz > pk.capHz {
+		pk.targetHz = pk.capHz
+		bundle.HzCapped = true
+	}
+	if pk.targetHz == 0 {
+		pk.targetHz = pk.capHz
+		if pk.targetHz == 0 {
+			pk.targetHz = 75
+			if pk.gaming {
+				pk.targetHz = 144
+			}
+		}
+	}
+
+	byKind := make(map[string][]entity.Product)
+	for _, p := range inStockOrAll(products, req.Branch) {
+		if kind := PeripheralKind(p.Category, p.Name); kind != "" && req.Price(p) > 0 {
+			byKind[kind] = append(byKind[kind], p)
+		}
+	}
+
+	carry := 0.0
+	for _, kind := range kinds {
+		allotted := bundle.Split[kind] + carry
+		pick, ok := pk.pick(kind, byKind[kind], allotted)
+		if !ok {
+			bundle.Missing = append(bundle.Missing, kind)
+			carry = allotted
+			continue
+		}
+		bundle.Picks = append(bundle.Picks, pick)
+		bundle.TotalUSD += pick.PriceUSD
+		if req.BudgetUSD > 0 {
+			carry = allotted - pick.PriceUSD
+		}
+	}
+	return bundle
+}
+
+// pick turdagi eng mos mahsulot: ajratilgan summaga sig'adiganlar ichida eng yuqori moslik,
+// teng bo'lsa qimmatrog'i (sifat); hech biri sig'masa - eng mosining eng arzoni
+func (pk *peripheralPicker) pick(kind string, candidates []entity.Product, allotted float64) (PeripheralPick, bool) {
+	var best PeripheralPick
+	bestFit, found := 0.0, false
+	var cheap PeripheralPick
+	cheapFit, cheapFound := 0.0, false
+	for _, p := range candidates {
+		price := pk.req.Price(p)
+		fit, misses := pk.fit(kind, PeripheralAttrsOf(p))
+		cand := PeripheralPick{Kind: kind, Product: p, PriceUSD: price, Allotted: allotted, Misses: misses}
+		if pk.req.BudgetUSD <= 0 || price <= allotted {
+			// Budjet cheklanmagan bo'lsa - eng mosining eng arzoni
+			better := !found || fit > bestFit || (fit == bestFit && ((pk.req.BudgetUSD > 0 && price > best.PriceUSD) || (pk.req.BudgetUSD <= 0 && price < best.PriceUSD)))
+			if better {
+				best, bestFit, found = cand, fit, true
+			}
+			continue
+		}
+		if !cheapFound || fit > cheapFit || (fit == cheapFit && price < cheap.PriceUSD) {
+			cheap, cheapFit, cheapFound = cand, fit, true
+		}
+	}
+	if found {
+		return best, true
+	}
+	if cheapFound {
+		cheap.OverBudget = true
+		return cheap, true
+	}
+	return PeripheralPick{}, false
+}
+
+// fit xohishlarga moslik bali va bajarilmagan xohishlar
+func (pk *peripheralPicker) fit(kind string, a PeripheralAttrs) (float64, []string) {
+	prefs := pk.req.Prefs
+	score := 0.0
+	var misses []string
+	miss := func(key string, penalty float64) {
+		score -= penalty
+		misses = append(misses, key)
+	}
+
+	if kind == PartMonitor {
+		switch {
+		case a.RefreshHz >= pk.targetHz:
+			score += 3
+			// Karta tortmaydigan chastota - ortiqcha pul
+			if pk.capHz > 0 && a.RefreshHz > pk.capHz*3/2 {
+				score -= 1
+			}
+		case a.RefreshHz > 0:
+			score += 3 * float64(a.RefreshHz) / float64(pk.targetHz)
+			if prefs.MonitorHz > 0 {
+				misses = append(misses, "hz")
+			}
+		}
+		if prefs.MonitorPanel != "" {
+			want, got := strings.ToUpper(prefs.MonitorPanel), strings.ToUpper(a.Panel)
+			if got == want || (want == "OLED" && got == "QD-OLED") {
+				score += 2
+			} else {
+				miss("panel", 0)
+			}
+		}
+		if pk.capRes != "" && a.Resolution != "" {
+			switch px, capPx := resolutionPixels(a.Resolution), resolutionPixels(pk.capRes); {
+			case px == capPx:
+				score++
+			case px > capPx:
+				score -= 1.5
+			}
+		}
+		return score, misses
+	}
+
+	if prefs.Connection != "" {
+		switch {
+		case prefs.Connection == "wireless" && a.Wireless, prefs.Connection == "wired" && !a.Wireless:
+			score += 2
+		default:
+			miss("connection", 2)
+		}
+	}
+	if prefs.Color != "" {
+		switch {
+		case prefs.Color == "rgb" && a.RGB, prefs.Color == a.Color:
+			score++
+		case prefs.Color == "rgb" || a.Color != "":
+			miss("color", 0.5)
+		}
+	}
+	switch kind {
+	case PartKeyboard:
+		if prefs.Switch != "" {
+			if a.Switch == prefs.Switch {
+				score += 2
+			} else {
+				miss("switch", 1)
+			}
+		}
+		if pk.gaming && a.Mechanical {
+			score++
+		}
+	case PartMouse:
+		if prefs.MinDPI > 0 {
+			switch {
+			case a.DPI >= prefs.MinDPI:
+				score += 2
+			case a.DPI > 0:
+				miss("dpi", 1)
+			default:
+				misses = append(misses, "dpi")
+			}
+		}
+	}
+	return score, misses
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+func TestPeripheralAttrsOf(t *testing.T) {
+	kb := PeripheralAttrsOf(entity.Product{Name: "Redragon Kumara K552 RGB Red Switch", Category: "Keyboard"})
+	if kb.Kind != PartKeyboard || kb.Switch != "red" || !kb.Mechanical || !kb.RGB || kb.Wireless {
+		t.Fatalf("K552: %+v", kb)
+	}
+	mouse := PeripheralAttrsOf(entity.Product{Name: "Mouse Logitech G305 Lightspeed Wireless 12000 DPI White", Category: "Peripherals"})
+	if mouse.Kind != PartMouse || !mouse.Wireless || mouse.DPI != 12000 || mouse.Color != "white" {
+		t.Fatalf("G305: %+v", mouse)
+	}
+	mon := PeripheralAttrsOf(entity.Product{Name: `Samsung Odyssey G5 27" 2560x1440 165Hz VA`, Category: "Monitor"})
+	if mon.Kind != PartMonitor || mon.RefreshHz != 165 || mon.Panel != "VA" || mon.Resolution != "2560x1440" {
+		t.Fatalf("G5: %+v", mon)
+	}
+	if kind := PeripheralKind("Peripherals", "HyperX Pulsefire Mousepad"); kind != "" {
+		t.Fatalf("mousepad: %q", kind)
+	}
+}
+
+func TestParsePeripheralRequest(t *testing.T) {
+	req := ParsePeripheralRequest("monitor, klaviatura va sichqoncha 400$, simsiz, brown switch, 16000 dpi, oq, 240hz IPS, RTX 4060", nil)
+	p := req.Prefs
+	if req.BudgetUSD != 400 || p.Connection != "wireless" || p.Switch != "brown" || p.MinDPI != 16000 || p.Color != "white" || p.MonitorHz != 240 || p.MonitorPanel != "IPS" {
+		t.Fatalf("so'rov: %+v", req)
+	}
+	if len(req.Kinds) != 3 || req.Kinds[0] != PartMonitor || req.GPU.Name == "" {
+		t.Fatalf("turlar: %v, GPU %q", req.Kinds, req.GPU.Name)
+	}
+}
+
+func TestSplitPeripheralBudget(t *testing.T) {
+	split := SplitPeripheralBudget(300, []string{PartKeyboard, PartMouse, PartHeadset})
+	if split[PartKeyboard] != 125 || split[PartMouse] != 75 || split[PartHeadset] != 100 {
+		t.Fatalf("split: %v", split)
+	}
+	if got := PeripheralBudget(1000, []string{PartMonitor, PartMouse}); got != 210 {
+		t.Fatalf("PeripheralBudget: %.0f", got)
+	}
+}
+
+func TestMonitorCapability(t *testing.T) {
+	lowHz, lowRes := MonitorCapability(entity.Product{Name: "GTX 1650"}, nil)
+	midHz, midRes := MonitorCapability(entity.Product{Name: "RTX 4070"}, nil)
+	topHz, topRes := MonitorCapability(entity.Product{Name: "RTX 4090"}, nil)
+	if lowRes != "1920x1080" || midRes != "2560x1440" || topRes != "3840x2160" {
+		t.Fatalf("o'lcham: %s %s %s", lowRes, midRes, topRes)
+	}
+	if lowHz >= midHz || lowHz < 60 {
+		t.Fatalf("chastota: %d %d %d", lowHz, midHz, topHz)
+	}
+	if hz, res := MonitorCapability(entity.Product{}, nil); hz != 0 || res != "" {
+		t.Fatalf("karta yo'q: %d %q", hz, res)
+	}
+}
+
+func TestBuildPeripheralBundle(t *testing.T) {
+	usd := func(v float64) entity.Money { return entity.NewMoney(v, entity.CurrencyUSD) }
+	products := []entity.Product{
+		{Name: "Redragon K552 Red Switch Wired", Category: "Keyboard", Price: usd(45), Stock: 3},
+		{Name: "Keychron K2 Wireless Brown Switch", Category: "Keyboard", Price: usd(90), Stock: 3},
+		{Name: "Logitech G502 X Plus Wireless 25600 DPI", Category: "Mouse", Price: usd(150), Stock: 3},
+		{Name: "Logitech G305 Wireless 12000 DPI", Category: "Mouse", Price: usd(40), Stock: 3},
+		{Name: "HyperX Cloud II Wired", Category: "Headset", Price: usd(80), Stock: 3},
+		{Name: `AOC 24G2 24" 1920x1080 144Hz IPS`, Category: "Monitor", Price: usd(170), Stock: 3},
+		{Name: `Samsung Odyssey G7 32" 3840x2160 144Hz VA`, Category: "Monitor", Price: usd(600), Stock: 3},
+		{Name: `LG 27GP850 27" 2560x1440 180Hz IPS`, Category: "Monitor", Price: usd(330), Stock: 3},
+		{Name: "CPU Intel Core i5-12400F", Category: "CPU", Price: usd(150), Stock: 3},
+	}
+
+	b := BuildPeripheralBundle(products, PeripheralRequest{
+		BudgetUSD: 600,
+		Kinds:     []string{PartMonitor, PartKeyboard, PartMouse, PartHeadset},
+		Prefs:     PeripheralPrefs{Connection: "wireless", Switch: "brown", MinDPI: 10000, MonitorHz: 240, MonitorPanel: "IPS"},
+		Purpose:   "Gaming",
+		GPU:       entity.Product{Name: "RTX 4070"},
+	})
+	names := map[string]string{}
+	for _, p := range b.Picks {
+		names[p.Kind] = p.Product.Name
+	}
+	// 4K monitor karta imkoniyatidan yuqori va budjetdan tashqari; 240 Hz karta uchun ko'p
+	if names[PartMonitor] != `LG 27GP850 27" 2560x1440 180Hz IPS` || !b.HzCapped {
+		t.Fatalf("monitor: %q capped=%v cap=%d", names[PartMonitor], b.HzCapped, b.MonitorCapHz)
+	}
+	if names[PartKeyboard] != "Keychron K2 Wireless Brown Switch" || names[PartMouse] != "Logitech G305 Wireless 12000 DPI" {
+		t.Fatalf("tanlov: %v", names)
+	}
+	if b.TotalUSD > 600 || len(b.Missing) != 0 {
+		t.Fatalf("jami %.0f, yo'q: %v", b.TotalUSD, b.Missing)
+	}
+	for _, p := range b.Picks {
+		if p.Kind == PartHeadset && (len(p.Misses) != 1 || p.Misses[0] != "connection") {
+			t.Fatalf("quloqchin: %+v", p)
+		}
+	}
+}
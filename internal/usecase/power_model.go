@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Quvvat modeli: har bir komponentning uzoq yuklamadagi iste'moli spec/jadvaldan olinadi,
+// videokartaning millisekundlik sakrashlari (transient) alohida hisoblanadi. Shu asosda blokning
+// minimal va tavsiya etilgan quvvati hamda 80 PLUS darajasi chiqariladi - AI ishtirokisiz.
+
+const (
+	boardWatts      = 50
+	caseFanWatts    = 9 // 3 ta korpus ventilyatori
+	towerFanWatts   = 5
+	aioWatts        = 15 // nasos + radiator ventilyatorlari
+	peripheralWatts = 15 // klaviatura, sichqoncha, quloqchin (USB)
+	rgbWatts        = 10
+
+	// Blok uzoq yuklamada quvvatining 90% idan oshmasligi kerak; tavsiya - 75% (sokin va
+	// samarali ishlash, upgrade zaxirasi). Oddiy blok qisqa sakrashni ~130% gacha, tavsiya
+	// etilgani ~110% gacha ko'taradi.
+	minSustainedShare  = 0.9
+	recSustainedShare  = 0.75
+	minTransientMargin = 1.3
+	recTransientMargin = 1.1
+	minPSUFloor        = 300
+	recPSUFloor        = 400
+)
+
+// efficiencyTiers 80 PLUS darajalari pastdan yuqoriga
+var efficiencyTiers = []string{"80+", "80+ White", "80+ Bronze", "80+ Silver", "80+ Gold", "80+ Platinum", "80+ Titanium"}
+
+// PowerBudget komponentlar bo'yicha quvvat hisobi (W)
+type PowerBudget struct {
+	CPU         int // AMD PPT / Intel PL2 taxmini
+	GPU         int // TBP
+	Board       int
+	Memory      int
+	Storage     int
+	Cooling     int // CPU sovutgichi va korpus ventilyatorlari
+	Peripherals int // USB qurilmalar, RGB
+	Sustained   int // yuqoridagilar yig'indisi
+	Transient   int // GPU sakrashlari bilan qisqa cho'qqi
+	Minimum     int // bundan kuchsiz blok - xavfli (50 W ga yaxlitlangan)
+	Recommended int // zaxira bilan tavsiya
+	Efficiency  string
+	ATX3        bool // kuchli sakrashli GPU - ATX 3.x blok ma'qul
+}
+
+// PSUStatus blokning budjetga nisbatan holati
+type PSUStatus int
+
+const (
+	PSUUnknown PSUStatus = iota
+	PSUUndersized
+	PSULowHeadroom
+	PSUAdequate
+)
+
+// ComputePowerBudget yig'ma uchun quvvat hisobi; bench nil bo'lsa TDP faqat spec/nomdan olinadi
+func ComputePowerBudget(build *entity.PCBuild, bench *BenchmarkDB) PowerBudget {
+	var b PowerBudget
+	if build == nil {
+		return b
+	}
+	if strings.TrimSpace(build.CPU.Name) != "" {
+		tdp := cpuAttrs(build.CPU).TDP
+		if tdp == 0 && bench != nil {
+			_, tdp, _ = bench.cpuBenchScore(build.CPU)
+		}
+		if tdp == 0 {
+			tdp = 65
+		}
+		b.CPU = cpuPeakWatts(build.CPU, tdp)
+	}
+	gpuFactor := 0.0
+	if hasGPU(build.GPU) {
+		gpu := gpuAttrs(build.GPU)
+		b.GPU = gpu.TDP
+		if b.GPU == 0 && bench != nil {
+			_, b.GPU, _, _ = bench.gpuBenchScore(build.GPU)
+		}
+		if b.GPU == 0 {
+			b.GPU = 200
+		}
+		gpuFactor = gpuTransientFactor(gpuModel(build.GPU.Name))
+		b.ATX3 = gpu.Needs12VHPWR || (b.GPU >= 285 && gpuFactor >= 1.6)
+	}
+	b.Board = boardWatts
+	b.Memory = memoryWatts(build.RAM)
+	b.Storage = storageWatts(build.SSD)
+	if build.HDD != nil {
+		b.Storage += storageWatts(*build.HDD)
+	}
+	if b.Storage == 0 {
+		b.Storage = 7
+	}
+	b.Cooling = caseFanWatts + towerFanWatts
+	if build.Cooler != nil && isAIO(build.Cooler.Name) {
+		b.Cooling = caseFanWatts + aioWatts
+	}
+	b.Peripherals = peripheralWatts
+	if strings.EqualFold(build.ColorScheme, "RGB") {
+		b.Peripherals += rgbWatts
+	}
+
+	b.Sustained = b.CPU + b.GPU + b.Board + b.Memory + b.Storage + b.Cooling + b.Peripherals
+	b.Transient = b.Sustained + int(float64(b.GPU)*(gpuFactor-1))
+	b.Minimum = max(minPSUFloor, roundUpWatts(float64(b.Sustained)/minSustainedShare), roundUpWatts(float64(b.Transient)/minTransientMargin))
+	b.Recommended = max(recPSUFloor, b.Minimum, roundUpWatts(float64(b.Sustained)/recSustainedShare), roundUpWatts(float64(b.Transient)/recTransientMargin))
+	switch {
+	case b.Sustained >= 750:
+		b.Efficiency = "80+ Platinum"
+	case b.Sustained >= 450:
+		b.Efficiency = "80+ Gold"
+	default:
+		b.Efficiency = "80+ Bronze"
+	}
+	return b
+}
+
+// Check blok quvvati (W) budjetga yetadimi; 0 - noma'lum
+func (b PowerBudget) Check(watts int) PSUStatus {
+	switch {
+	case watts <= 0:
+		return PSUUnknown
+	case watts < b.Minimum:
+		return PSUUndersized
+	case watts < b.Recommended:
+		return PSULowHeadroom
+	}
+	return PSUAdequate
+}
+
+// cpuPeakWatts uzoq yuklamadagi CPU iste'moli: AMD PPT = 1.35×TDP, Intel PL2 - TDP dan
+// seriyaga qarab (i7/i9 253 W gacha)
+func cpuPeakWatts(p entity.Product, tdp int) int {
+	name := strings.ToLower(p.Name)
+	factor := 1.25
+	switch {
+	case containsAny(name, "ryzen", "amd", "athlon", "threadripper"):
+		factor = 1.35
+	case containsAny(name, "i9", "i7", "ultra 9", "ultra 7"):
+		return min(tdp*2, 253)
+	case containsAny(name, "i5", "ultra 5"):
+		factor = 1.6
+	case containsAny(name, "intel", "core", "i3", "pentium", "celeron"):
+		factor = 1.4
+	}
+	return int(math.Round(float64(tdp) * factor))
+}
+
+// gpuTransientFactor TBP ga nisbatan qisqa sakrash: RTX 30 va RX 6000 eng "sakrovchi" avlodlar
+func gpuTransientFactor(model string) float64 {
+	switch {
+	case strings.HasPrefix(model, "rtx 30"), strings.HasPrefix(model, "rx 6"):
+		return 1.8
+	case strings.HasPrefix(model, "rx 7"), strings.HasPrefix(model, "rx 9"):
+		return 1.6
+	case strings.HasPrefix(model, "rtx 40"), strings.HasPrefix(model, "rtx 50"):
+		return 1.4
+	case strings.HasPrefix(model, "gtx"):
+		return 1.3
+	}
+	return 1.5
+}
+
+// memoryWatts modullar soni hajmdan taxmin qilinadi (64 GB dan - 4 ta), DDR5 modul ~5 W, DDR4 ~4 W
+func memoryWatts(p entity.Product) int {
+	modules := 2
+	if productRAMGB(p) >= 64 {
+		modules = 4
+	}
+	per := 4
+	if gens := memoryGens(p.Name + " " + p.Spec(entity.SpecMemoryType)); len(gens) == 1 && gens[0] == "DDR5" {
+		per = 5
+	}
+	return modules * per
+}
+
+func storageWatts(p entity.Product) int {
+	if strings.TrimSpace(p.Name) == "" {
+		return 0
+	}
+	_, kind, _, _ := scoreStorage(storageDescriptor(p))
+	switch {
+	case strings.HasPrefix(kind, "NVMe"):
+		return 7
+	case kind == "HDD":
+		return 9
+	}
+	return 4
+}
+
+func roundUpWatts(w float64) int {
+	return int(math.Ceil(w/50)) * 50
+}
+
+// PSUEfficiency blokning 80 PLUS darajasi (spec yoki nomdan); aniqlanmasa bo'sh
+func PSUEfficiency(p entity.Product) string {
+	return extractPSUSpecs(p)[entity.SpecEfficiency]
+}
+
+func efficiencyRank(tier string) int {
+	for i, t := range efficiencyTiers {
+		if strings.EqualFold(t, tier) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SuitablePSUs qoldiqdagi tavsiya quvvatidan kam bo'lmagan bloklar, arzonidan; tavsiya etilgan
+// darajadagi (yoki yuqori) bloklar oldinda
+func SuitablePSUs(products []entity.Product, budget PowerBudget, branch string, price func(entity.Product) float64, limit int) []entity.Product {
+	if price == nil {
+		price = func(p entity.Product) float64 { return p.Price.Major() }
+	}
+	want := efficiencyRank(budget.Efficiency)
+	var out []entity.Product
+	for _, p := range products {
+		if SpecKind(p.Category, p.Name) != PartPSU || p.StockAt(branch) <= 0 || productPSUWatts(p) < budget.Recommended {
+			continue
+		}
+		out = append(out, p)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ti, tj := efficiencyRank(PSUEfficiency(out[i])) >= want, efficiencyRank(PSUEfficiency(out[j])) >= want
+		if ti != tj {
+			return ti
+		}
+		return price(out[i]) < price(out[j])
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+func TestComputePowerBudget(t *testing.T) {
+	b := ComputePowerBudget(&entity.PCBuild{
+		CPU: entity.Product{Name: "AMD Ryzen 7 7800X3D"},
+		GPU: entity.Product{Name: "RTX 3080"},
+		RAM: entity.Product{Name: "Kingston Fury 32GB DDR5 6000"},
+		SSD: entity.Product{Name: "Samsung 990 Pro 1TB NVMe"},
+	}, nil)
+	// RTX 30 - eng kuchli sakrashlar: cho'qqi uzoq yuklamadan sezilarli yuqori
+	if b.CPU == 0 || b.GPU == 0 || b.Transient <= b.Sustained+b.GPU/2 {
+		t.Fatalf("budget: %+v", b)
+	}
+	if b.Minimum%50 != 0 || b.Recommended < b.Minimum || b.Recommended < 750 {
+		t.Fatalf("min=%d rec=%d", b.Minimum, b.Recommended)
+	}
+	if b.Efficiency != "80+ Gold" && b.Efficiency != "80+ Platinum" {
+		t.Fatalf("tier: %s", b.Efficiency)
+	}
+
+	office := ComputePowerBudget(&entity.PCBuild{CPU: entity.Product{Name: "Intel Core i3-12100"}}, nil)
+	if office.GPU != 0 || office.Transient != office.Sustained || office.Recommended != recPSUFloor || office.Efficiency != "80+ Bronze" {
+		t.Fatalf("office: %+v", office)
+	}
+}
+
+func TestPowerBudgetCheck(t *testing.T) {
+	b := PowerBudget{Minimum: 550, Recommended: 700}
+	cases := map[int]PSUStatus{0: PSUUnknown, 500: PSUUndersized, 550: PSULowHeadroom, 650: PSULowHeadroom, 700: PSUAdequate, 1000: PSUAdequate}
+	for watts, want := range cases {
+		if got := b.Check(watts); got != want {
+			t.Errorf("%d W: %v, kutilgan %v", watts, got, want)
+		}
+	}
+}
+
+// TestPowerDataRecommendation - blok tavsiyasi katalogdan, raqamlar o'rnida
+func TestPowerDataRecommendation(t *testing.T) {
+	b := PowerBudget{Sustained: 420, Transient: 610, Minimum: 550, Recommended: 700, Efficiency: "80+ Gold", ATX3: true}
+	build := &entity.PCBuild{PSU: entity.Product{Name: "DeepCool PK500D 500W"}}
+	got := powerData(build, b, "en").Recommendation
+	want := "⚠️ RISKY! The PSU may shut down at load spikes (~610W). At least 550W, recommended: 700W (80+ Gold) An ATX 3.x (12V-2x6) unit is preferred for the graphics card."
+	if got != want {
+		t.Fatalf("en:\n%s\nkutilgan:\n%s", got, want)
+	}
+	if ru := powerData(build, b, "ru").Recommendation; !strings.Contains(ru, "Минимум 550W") {
+		t.Fatalf("ru: %s", ru)
+	}
+}
+
+func TestSuitablePSUs(t *testing.T) {
+	usd := func(v float64) entity.Money { return entity.NewMoney(v, entity.CurrencyUSD) }
+	products := []entity.Product{
+		{Name: "DeepCool PK550D 550W", Category: "PSU", Price: usd(40), Stock: 5},
+		{Name: "Cougar GEX 750W 80+ Gold", Category: "PSU", Price: usd(95), Stock: 5},
+		{Name: "Aerocool 800W 80+ Bronze", Category: "PSU", Price: usd(60), Stock: 5},
+		{Name: "Corsair RM850e 850W 80+ Gold", Category: "PSU", Price: usd(110)},
+	}
+
+	got := SuitablePSUs(products, PowerBudget{Recommended: 700, Efficiency: "80+ Gold"}, "", nil, 0)
+	if len(got) != 2 || got[0].Name != "Cougar GEX 750W 80+ Gold" || got[1].Name != "Aerocool 800W 80+ Bronze" {
+		var names []string
+		for _, p := range got {
+			names = append(names, p.Name)
+		}
+		t.Fatalf("bloklar: %v", names)
+	}
+}
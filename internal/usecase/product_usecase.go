@@ -81,7 +81,7 @@ func (u *productUseCase) GetProductsAsText(ctx context.Context) (string, error)
 	for category, prods := range categoryMap {
 		sb.WriteString(fmt.Sprintf("📂 %s:\n", category))
 		for i, p := range prods {
-			sb.WriteString(fmt.Sprintf("%d. %s - $%.2f", i+1, p.Name, p.Price))
+			sb.WriteString(fmt.Sprintf("%d. %s - %s", i+1, p.Name, p.Price))
 			if p.Stock > 0 {
 				sb.WriteString(fmt.Sprintf(" (Omborda: %d)", p.Stock))
 			}
@@ -0,0 +1,389 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+var (
+	ErrPromoCodeUnknown   = errors.New("promo code not found")
+	ErrPromoCodeInactive  = errors.New("promo code is not active")
+	ErrPromoCodeExhausted = errors.New("promo code usage limit reached")
+	ErrPromoNotApplicable = errors.New("promo code does not apply to these items")
+	ErrPromotionInvalid   = errors.New("invalid promotion")
+	promoPriceLabelWords  = []string{"price", "narx", "narxi", "цена", "стоимость"}
+	rePromoLineNumber     = regexp.MustCompile(`^\d+[.)]\s+`)
+)
+
+// PromotionUseCase aksiyalar va chegirmalar bilan bog'liq business logic
+type PromotionUseCase interface {
+	// List barcha aksiyalar
+	List(ctx context.Context) ([]entity.Promotion, error)
+
+	// Save aksiyani tekshirib saqlash
+	Save(ctx context.Context, promo entity.Promotion) error
+
+	// Delete aksiyani o'chirish
+	Delete(ctx context.Context, id string) error
+
+	// Quote matndagi narxli qatorlarni katalog narxi bo'yicha hisoblaydi (code - promo kod, bo'sh bo'lishi mumkin)
+	Quote(ctx context.Context, text, code string) (entity.PriceQuote, error)
+
+	// ApplyToText avtomatik aksiyalarni matndagi narxlar va "Jami" qatoriga yozadi
+	ApplyToText(ctx context.Context, text string) string
+
+	// Redeem buyurtma berilganda qo'llangan aksiyalar foydalanish sonini oshiradi;
+	// limit shu orada tugagan bo'lsa ErrPromoCodeExhausted (hech biri band qilinmaydi)
+	Redeem(ctx context.Context, quote entity.PriceQuote) error
+
+	// Release bekor qilingan buyurtma aksiyalari foydalanish sonini qaytaradi
+	Release(ctx context.Context, ids []string) error
+
+	// HasCodes faol promo kodlar bormi (checkout'da taklif qilish uchun)
+	HasCodes(ctx context.Context) bool
+
+	// SetConverter fixed chegirmalarni boshqa valyutaga o'girish uchun kurs servisi
+	SetConverter(conv entity.Converter)
+}
+
+type promotionUseCase struct {
+	promoRepo   repository.PromotionRepository
+	productRepo repository.ProductRepository
+	converter   entity.Converter
+	now         func() time.Time
+}
+
+// NewPromotionUseCase yangi PromotionUseCase yaratish
+func NewPromotionUseCase(promoRepo repository.PromotionRepository, productRepo repository.ProductRepository) PromotionUseCase {
+	return &promotionUseCase{
+		promoRepo:   promoRepo,
+		productRepo: productRepo,
+		now:         time.Now,
+	}
+}
+
+func (u *promotionUseCase) SetConverter(conv entity.Converter) {
+	u.converter = conv
+}
+
+// List barcha aksiyalar
+func (u *promotionUseCase) List(ctx context.Context) ([]entity.Promotion, error) {
+	return u.promoRepo.List(ctx)
+}
+
+// Save aksiyani tekshirib saqlash
+func (u *promotionUseCase) Save(ctx context.Context, promo entity.Promotion) error {
+	promo.ID = strings.TrimSpace(promo.ID)
+	promo.Code = strings.ToUpper(strings.TrimSpace(promo.Code))
+	promo.Currency = strings.ToUpper(strings.TrimSpace(promo.Currency))
+	if err := validatePromotion(promo); err != nil {
+		return err
+	}
+	if promo.Code != "" {
+		list, err := u.promoRepo.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range list {
+			if p.ID != promo.ID && strings.EqualFold(p.Code, promo.Code) {
+				return fmt.Errorf("%w: code %s already used by %s", ErrPromotionInvalid, promo.Code, p.ID)
+			}
+		}
+	}
+	return u.promoRepo.Save(ctx, promo)
+}
+
+func validatePromotion(p entity.Promotion) error {
+	switch {
+	case p.ID == "":
+		return fmt.Errorf("%w: empty id", ErrPromotionInvalid)
+	case p.Value <= 0:
+		return fmt.Errorf("%w: value must be positive", ErrPromotionInvalid)
+	case p.Kind == entity.PromoPercent && p.Value > 100:
+		return fmt.Errorf("%w: percent must be <= 100", ErrPromotionInvalid)
+	case p.Kind != entity.PromoPercent && p.Kind != entity.PromoFixed:
+		return fmt.Errorf("%w: unknown kind %q", ErrPromotionInvalid, p.Kind)
+	case !p.StartsAt.IsZero() && !p.EndsAt.IsZero() && !p.EndsAt.After(p.StartsAt):
+		return fmt.Errorf("%w: end must be after start", ErrPromotionInvalid)
+	case p.MaxUses < 0:
+		return fmt.Errorf("%w: negative usage limit", ErrPromotionInvalid)
+	}
+	switch p.Scope {
+	case entity.ScopeAll:
+	case entity.ScopeProduct, entity.ScopeCategory, entity.ScopeBrand:
+		if strings.TrimSpace(p.Target) == "" {
+			return fmt.Errorf("%w: %s target is empty", ErrPromotionInvalid, p.Scope)
+		}
+	case entity.ScopeBundle:
+		if len(p.Bundle) < 2 {
+			return fmt.Errorf("%w: bundle needs at least 2 categories", ErrPromotionInvalid)
+		}
+	default:
+		return fmt.Errorf("%w: unknown scope %q", ErrPromotionInvalid, p.Scope)
+	}
+	return nil
+}
+
+// Delete aksiyani o'chirish
+func (u *promotionUseCase) Delete(ctx context.Context, id string) error {
+	return u.promoRepo.Delete(ctx, strings.TrimSpace(id))
+}
+
+// HasCodes faol promo kodlar bormi
+func (u *promotionUseCase) HasCodes(ctx context.Context) bool {
+	list, err := u.promoRepo.List(ctx)
+	if err != nil {
+		return false
+	}
+	now := u.now()
+	for _, p := range list {
+		if p.Code != "" && p.ActiveAt(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Quote matndagi narxli qatorlarni hisoblaydi
+func (u *promotionUseCase) Quote(ctx context.Context, text, code string) (entity.PriceQuote, error) {
+	promos, err := u.promoRepo.List(ctx)
+	if err != nil {
+		return entity.PriceQuote{}, err
+	}
+	code = strings.TrimSpace(code)
+	now := u.now()
+
+	codePromo := ""
+	if code != "" {
+		found := false
+		for _, p := range promos {
+			if p.Code == "" || !strings.EqualFold(p.Code, code) {
+				continue
+			}
+			found = true
+			switch {
+			case p.Exhausted():
+				return entity.PriceQuote{}, ErrPromoCodeExhausted
+			case !p.ActiveAt(now):
+				return entity.PriceQuote{}, ErrPromoCodeInactive
+			}
+			codePromo = p.ID
+		}
+		if !found {
+			return entity.PriceQuote{}, ErrPromoCodeUnknown
+		}
+	}
+
+	parsed, _ := u.parsePriceLines(ctx, text)
+	lines := make([]entity.PriceLine, 0, len(parsed))
+	for _, p := range parsed {
+		lines = append(lines, p.line)
+	}
+	quote, err := entity.ApplyPromotions(promos, lines, code, now, u.converter)
+	if err != nil {
+		return quote, err
+	}
+	if codePromo != "" && !containsString(quote.Applied, codePromo) {
+		return quote, ErrPromoNotApplicable
+	}
+	return quote, nil
+}
+
+// ApplyToText avtomatik aksiyalarni matnga yozadi: qator narxi chegirmali narxga almashadi,
+// "Jami" qatori yangi jami summaga o'zgaradi. Aksiya bo'lmasa matn o'zgarmaydi.
+func (u *promotionUseCase) ApplyToText(ctx context.Context, text string) string {
+	promos, err := u.promoRepo.List(ctx)
+	if err != nil || len(promos) == 0 {
+		return text
+	}
+	parsed, totalIdx := u.parsePriceLines(ctx, text)
+	if len(parsed) == 0 {
+		return text
+	}
+	lines := make([]entity.PriceLine, 0, len(parsed))
+	for _, p := range parsed {
+		lines = append(lines, p.line)
+	}
+	quote, err := entity.ApplyPromotions(promos, lines, "", u.now(), u.converter)
+	if err != nil || quote.Discount.IsZero() {
+		return text
+	}
+
+	out := strings.Split(text, "\n")
+	for i, q := range quote.Lines {
+		if q.Discount.IsZero() {
+			continue
+		}
+		p := parsed[i]
+		percent := 100 * float64(q.Discount.Amount) / float64(q.Price.Amount)
+		out[p.index] = strings.Replace(out[p.index], p.match, q.Final.String(), 1) + fmt.Sprintf(" 🏷-%.0f%%", percent)
+	}
+	if totalIdx >= 0 {
+		if m := rePriceWithCurrency.FindString(out[totalIdx]); m != "" {
+			out[totalIdx] = strings.Replace(out[totalIdx], strings.TrimSpace(m), quote.Total.String(), 1)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// Redeem qo'llangan aksiyalar foydalanish sonini oshiradi
+func (u *promotionUseCase) Redeem(ctx context.Context, quote entity.PriceQuote) error {
+	if len(quote.Applied) == 0 {
+		return nil
+	}
+	if err := u.promoRepo.AddUses(ctx, quote.Applied); err != nil {
+		if errors.Is(err, entity.ErrPromotionExhausted) {
+			return fmt.Errorf("%w: %w", ErrPromoCodeExhausted, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Release bekor qilingan buyurtma aksiyalari foydalanish sonini qaytaradi
+func (u *promotionUseCase) Release(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return u.promoRepo.ReleaseUses(ctx, ids)
+}
+
+// textPriceLine matndagi narxli qator va uning joylashuvi
+type textPriceLine struct {
+	index int    // matndagi qator raqami
+	match string // almashtiriladigan narx bo'lagi
+	line  entity.PriceLine
+}
+
+// parsePriceLines "• CPU: NAME - 199$", "-- NAME - 199$" yoki "NAME\nNarxi: 199$" qatorlarini ajratadi;
+// nom katalogda topilsa katalog narxi va kategoriyasi olinadi. Jami qatori indeksi alohida qaytadi (-1 - yo'q).
+func (u *promotionUseCase) parsePriceLines(ctx context.Context, text string) ([]textPriceLine, int) {
+	var catalog []entity.Product
+	if u.productRepo != nil {
+		catalog, _ = u.productRepo.GetAll(ctx)
+	}
+
+	var out []textPriceLine
+	totalIdx := -1
+	prevText := ""
+	for i, raw := range strings.Split(text, "\n") {
+		trim := strings.TrimSpace(raw)
+		if trim == "" {
+			continue
+		}
+		base := stripBulletPrefixLine(trim)
+		if reTotalLine.MatchString(base) {
+			totalIdx = i
+			continue
+		}
+		match := strings.TrimSpace(rePriceWithCurrency.FindString(trim))
+		if match == "" {
+			prevText = base
+			continue
+		}
+		amount, ok := parseCatalogPrice(match)
+		currency := entity.ParseCurrency(canonicalCurrencyFromMatch(match))
+		if !ok || amount <= 0 || currency == "" {
+			continue
+		}
+
+		name := base
+		if idx := strings.Index(name, match); idx >= 0 {
+			name = name[:idx]
+		}
+		name = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(name), "-–—:"))
+		label := ""
+		if idx := strings.Index(name, ":"); idx >= 0 {
+			label, name = strings.TrimSpace(name[:idx]), strings.TrimSpace(name[idx+1:])
+		}
+		name = rePromoLineNumber.ReplaceAllString(name, "")
+		if isPromoPriceLabel(label) {
+			label = ""
+		}
+		if name == "" || isPromoPriceLabel(name) {
+			// "Narxi: 199$" - nom oldingi qatorda
+			name = prevText
+		}
+		if name == "" {
+			continue
+		}
+
+		line := entity.PriceLine{Name: name, Category: label, Price: entity.NewMoney(amount, currency)}
+		if p, ok := MatchCatalogProduct(catalog, name); ok {
+			line.Name = p.Name
+			line.Category = p.Category
+			if p.Price.Amount > 0 {
+				line.Price = p.Price
+			}
+			line.Catalog = true
+		}
+		out = append(out, textPriceLine{index: i, match: match, line: line})
+		prevText = ""
+	}
+	return out, totalIdx
+}
+
+func isPromoPriceLabel(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, w := range promoPriceLabelWords {
+		if s == w {
+			return true
+		}
+	}
+	return false
+}
+
+// minCatalogMatchLen - bundan qisqa qisman moslik ("RTX", "Intel") tasodifiy mahsulotni olib keladi
+const minCatalogMatchLen = 6
+
+// MatchCatalogProduct nom bo'yicha katalog mahsulotini topadi (AI yozgan konfiguratsiya,
+// aksiya narx qatorlari): avval to'liq moslik (registr va bo'shliqlarsiz), keyin eng uzun
+// o'zaro qamrab olish; qamrov minCatalogMatchLen dan qisqa bo'lsa topilmagan hisoblanadi
+func MatchCatalogProduct(catalog []entity.Product, name string) (entity.Product, bool) {
+	key := catalogMatchKey(name)
+	if key == "" {
+		return entity.Product{}, false
+	}
+	var best entity.Product
+	bestLen := 0
+	for _, p := range catalog {
+		pk := catalogMatchKey(p.Name)
+		if pk == key {
+			return p, true
+		}
+		overlap := 0
+		switch {
+		case strings.Contains(pk, key):
+			overlap = len(key)
+		case strings.Contains(key, pk):
+			overlap = len(pk)
+		}
+		if overlap > bestLen {
+			best, bestLen = p, overlap
+		}
+	}
+	if bestLen < minCatalogMatchLen {
+		return entity.Product{}, false
+	}
+	return best, true
+}
+
+func catalogMatchKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
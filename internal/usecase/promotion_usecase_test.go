@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+type stubPromoRepo struct {
+	promos []entity.Promotion
+}
+
+func (s *stubPromoRepo) List(ctx context.Context) ([]entity.Promotion, error) {
+	return append([]entity.Promotion(nil), s.promos...), nil
+}
+func (s *stubPromoRepo) Save(ctx context.Context, promo entity.Promotion) error {
+	s.promos = append(s.promos, promo)
+	return nil
+}
+func (s *stubPromoRepo) Delete(ctx context.Context, id string) error { return nil }
+func (s *stubPromoRepo) AddUses(ctx context.Context, ids []string) error {
+	for _, p := range s.promos {
+		if containsString(ids, p.ID) && p.Exhausted() {
+			return entity.ErrPromotionExhausted
+		}
+	}
+	for i := range s.promos {
+		if containsString(ids, s.promos[i].ID) {
+			s.promos[i].Uses++
+		}
+	}
+	return nil
+}
+func (s *stubPromoRepo) ReleaseUses(ctx context.Context, ids []string) error {
+	for i := range s.promos {
+		if containsString(ids, s.promos[i].ID) && s.promos[i].Uses > 0 {
+			s.promos[i].Uses--
+		}
+	}
+	return nil
+}
+
+type catalogProductRepo struct {
+	stubProductRepo
+	products []entity.Product
+}
+
+func (c *catalogProductRepo) GetAll(ctx context.Context) ([]entity.Product, error) {
+	return c.products, nil
+}
+
+func newTestPromotionUseCase(promos ...entity.Promotion) (*promotionUseCase, *stubPromoRepo) {
+	repo := &stubPromoRepo{promos: promos}
+	catalog := &catalogProductRepo{products: []entity.Product{
+		{Name: "AMD Ryzen 5 7600", Category: "CPU", Price: entity.NewMoney(200, entity.CurrencyUSD)},
+		{Name: "MSI B650 Tomahawk", Category: "Motherboard", Price: entity.NewMoney(100, entity.CurrencyUSD)},
+		{Name: "Kingston Fury 32GB", Category: "RAM", Price: entity.NewMoney(80, entity.CurrencyUSD)},
+	}}
+	u := NewPromotionUseCase(repo, catalog).(*promotionUseCase)
+	u.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return u, repo
+}
+
+const promoTestConfig = "• CPU: AMD Ryzen 5 7600 - 200$\n• Motherboard: MSI B650 Tomahawk - 100$\n• RAM: Kingston Fury 32GB - 80$\n\nJami: 380$"
+
+func TestPromotionQuote_CategoryAndBundle(t *testing.T) {
+	u, _ := newTestPromotionUseCase(
+		entity.Promotion{ID: "ram10", Kind: entity.PromoPercent, Value: 10, Scope: entity.ScopeCategory, Target: "RAM"},
+		entity.Promotion{ID: "combo", Kind: entity.PromoFixed, Value: 30, Scope: entity.ScopeBundle, Bundle: []string{"CPU", "Motherboard"}},
+	)
+	quote, err := u.Quote(context.Background(), promoTestConfig, "")
+	if err != nil {
+		t.Fatalf("Quote returned error: %v", err)
+	}
+	if quote.Original.String() != "380.00$" || quote.Discount.String() != "38.00$" || quote.Total.String() != "342.00$" {
+		t.Fatalf("unexpected totals: %s - %s = %s", quote.Original, quote.Discount, quote.Total)
+	}
+	// bundle chegirmasi narxga mutanosib: 200:100 -> 20$ + 10$
+	if got := quote.Lines[0].Discount.String(); got != "20.00$" {
+		t.Fatalf("CPU bundle share = %s, want 20.00$", got)
+	}
+	if got := quote.Lines[2].Final.String(); got != "72.00$" {
+		t.Fatalf("RAM final = %s, want 72.00$", got)
+	}
+}
+
+func TestPromotionQuote_CodeLimitsAndWindow(t *testing.T) {
+	u, repo := newTestPromotionUseCase(
+		entity.Promotion{ID: "vip", Kind: entity.PromoPercent, Value: 5, Scope: entity.ScopeAll, Code: "VIP5", MaxUses: 1},
+		entity.Promotion{ID: "old", Kind: entity.PromoPercent, Value: 50, Scope: entity.ScopeAll, Code: "OLD",
+			EndsAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	)
+	ctx := context.Background()
+
+	if _, err := u.Quote(ctx, promoTestConfig, "nope"); !errors.Is(err, ErrPromoCodeUnknown) {
+		t.Fatalf("unknown code err = %v", err)
+	}
+	if _, err := u.Quote(ctx, promoTestConfig, "old"); !errors.Is(err, ErrPromoCodeInactive) {
+		t.Fatalf("expired code err = %v", err)
+	}
+	quote, err := u.Quote(ctx, promoTestConfig, "vip5")
+	if err != nil {
+		t.Fatalf("Quote with code returned error: %v", err)
+	}
+	if quote.Total.String() != "361.00$" {
+		t.Fatalf("total with code = %s, want 361.00$", quote.Total)
+	}
+	if err := u.Redeem(ctx, quote); err != nil {
+		t.Fatalf("Redeem returned error: %v", err)
+	}
+	if repo.promos[0].Uses != 1 {
+		t.Fatalf("uses = %d, want 1", repo.promos[0].Uses)
+	}
+	if _, err := u.Quote(ctx, promoTestConfig, "VIP5"); !errors.Is(err, ErrPromoCodeExhausted) {
+		t.Fatalf("exhausted code err = %v", err)
+	}
+	// Ikki mijoz bir vaqtda hisoblagan narx: ikkinchisi limitdan oshirmaydi
+	if err := u.Redeem(ctx, quote); !errors.Is(err, ErrPromoCodeExhausted) || repo.promos[0].Uses != 1 {
+		t.Fatalf("second redeem err = %v, uses = %d", err, repo.promos[0].Uses)
+	}
+	// Bekor qilingan buyurtma foydalanishni qaytaradi
+	if err := u.Release(ctx, quote.Applied); err != nil || repo.promos[0].Uses != 0 {
+		t.Fatalf("release err = %v, uses = %d", err, repo.promos[0].Uses)
+	}
+}
+
+func TestPromotionApplyToText(t *testing.T) {
+	u, _ := newTestPromotionUseCase(
+		entity.Promotion{ID: "amd", Kind: entity.PromoPercent, Value: 10, Scope: entity.ScopeBrand, Target: "AMD"},
+		entity.Promotion{ID: "code", Kind: entity.PromoPercent, Value: 50, Scope: entity.ScopeAll, Code: "HALF"},
+	)
+	out := u.ApplyToText(context.Background(), promoTestConfig)
+	if !strings.Contains(out, "AMD Ryzen 5 7600 - 180.00$ 🏷-10%") {
+		t.Fatalf("discounted line missing:\n%s", out)
+	}
+	if !strings.Contains(out, "Jami: 360.00$") {
+		t.Fatalf("total not rewritten:\n%s", out)
+	}
+	if !strings.Contains(out, "MSI B650 Tomahawk - 100$") {
+		t.Fatalf("non-discounted line changed:\n%s", out)
+	}
+}
+
+// TestMatchCatalogProduct - aksiyalar va konfiguratsiya tekshiruvi uchun bitta moslik qoidasi
+func TestMatchCatalogProduct(t *testing.T) {
+	catalog := []entity.Product{
+		{Name: "RTX 4070"},
+		{Name: "RTX 4070 Super"},
+		{Name: "Kingston Fury 32GB"},
+	}
+	cases := map[string]string{
+		"rtx  4070 super":                   "RTX 4070 Super", // aynan (registr/bo'shliqsiz)
+		"Gigabyte RTX 4070 Super Gaming OC": "RTX 4070 Super", // eng uzun qamrov
+		"Kingston Fury":                     "Kingston Fury 32GB",
+		"RTX":                               "", // juda qisqa
+		"":                                  "",
+	}
+	for name, want := range cases {
+		got, ok := MatchCatalogProduct(catalog, name)
+		if ok != (want != "") || got.Name != want {
+			t.Errorf("%q: %q ok=%v, kutilgan %q", name, got.Name, ok, want)
+		}
+	}
+}
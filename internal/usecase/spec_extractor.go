@@ -0,0 +1,504 @@
+package usecase
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Xususiyatlarni ajratish: import paytida har bir mahsulot uchun kategoriyaga mos extractor
+// nom va jadval ustunlaridan normallashgan qiymatlarni Product.Specs ga kanonik kalitlar bilan yozadi.
+// Keyingi bosqichlar (moslik, tahlil, konfigurator) nomni qayta parse qilmasdan shu kalitlarni o'qiydi.
+
+// Qo'shimcha komponent turlari (PartAttrs dagilarga qo'shimcha)
+const (
+	PartStorage = "storage"
+	PartMonitor = "monitor"
+)
+
+// SpecGap - majburiy xususiyatlari aniqlanmagan mahsulot
+type SpecGap struct {
+	Name     string
+	Category string
+	Kind     string
+	Missing  []string
+}
+
+// SpecReport - katalog bo'yicha xususiyatlar hisoboti
+type SpecReport struct {
+	Checked  int // turi aniqlangan mahsulotlar
+	Complete int // majburiy xususiyatlari to'liq
+	Gaps     []SpecGap
+}
+
+// specExtractor - tur uchun extractor va majburiy kalitlar
+type specExtractor struct {
+	Required []string
+	Extract  func(p entity.Product) map[string]string
+}
+
+var specExtractors = map[string]specExtractor{
+	PartCPU:         {Required: []string{entity.SpecSocket, entity.SpecCores}, Extract: extractCPUSpecs},
+	PartGPU:         {Required: []string{entity.SpecGPUChip, entity.SpecVRAM}, Extract: extractGPUSpecs},
+	PartRAM:         {Required: []string{entity.SpecMemoryType, entity.SpecCapacity, entity.SpecSpeed}, Extract: extractRAMSpecs},
+	PartStorage:     {Required: []string{entity.SpecInterface, entity.SpecCapacity}, Extract: extractStorageSpecs},
+	PartPSU:         {Required: []string{entity.SpecWattage, entity.SpecEfficiency}, Extract: extractPSUSpecs},
+	PartMonitor:     {Required: []string{entity.SpecScreenSize, entity.SpecRefreshRate, entity.SpecPanel}, Extract: extractMonitorSpecs},
+	PartMotherboard: {Required: []string{entity.SpecSocket, entity.SpecChipset, entity.SpecMemoryType}, Extract: extractBoardSpecs},
+}
+
+var (
+	reRyzenTier   = regexp.MustCompile(`ryzen\s*([3579])\b`)
+	reCoresName   = regexp.MustCompile(`(\d{1,2})\s*(?:-\s*)?(?:cores?|ядер|ядра|yadro)`)
+	reCoresThread = regexp.MustCompile(`\b(\d{1,2})\s*c\s*/\s*(\d{1,2})\s*t\b`)
+	reArc         = regexp.MustCompile(`\barc\s*([ab]\d{3})\b`)
+	reVRAM        = regexp.MustCompile(`\b(\d{1,2})\s*g(?:b)?\b`)
+	reRAMSpeed    = regexp.MustCompile(`(?:ddr[45][\s-]*(\d{4})|(\d{4})\s*(?:mhz|mt/s|мгц))`)
+	reTB          = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*tb\b`)
+	reGB          = regexp.MustCompile(`(\d{3,4})\s*gb\b`)
+	reScreenSize  = regexp.MustCompile(`(\d{2}(?:[.,]\d)?)\s*(?:"|''|”|″|inch|дюйм|dyuym)`)
+	reScreenToken = regexp.MustCompile(`(?:^|\s)((?:2[1-9]|3\d|4\d)(?:[.,]\d)?)(?:\s|$)`)
+	reHz          = regexp.MustCompile(`(\d{2,3})\s*hz`)
+	reResolution  = regexp.MustCompile(`(\d{3,4})\s*[x×]\s*(\d{3,4})`)
+)
+
+// intelCores - 12-14 avlod Intel: model -> yadro/oqim (K versiyalari farq qilsa alohida)
+var intelCores = map[string][2]int{
+	"12100": {4, 8}, "13100": {4, 8}, "14100": {4, 8},
+	"12400": {6, 12}, "12500": {6, 12}, "12600": {6, 12}, "12600k": {10, 16},
+	"13400": {10, 16}, "13500": {14, 20}, "13600": {14, 20},
+	"14400": {10, 16}, "14500": {14, 20}, "14600": {14, 20},
+	"12700": {12, 20}, "13700": {16, 24}, "14700": {20, 28},
+	"12900": {16, 24}, "13900": {24, 32}, "14900": {24, 32},
+	"ultra 225": {10, 10}, "ultra 235": {14, 14}, "ultra 245": {14, 14}, "ultra 265": {20, 20}, "ultra 285": {24, 24},
+}
+
+// gpuVRAM - chip -> standart video xotira (GB); bir nechta varianti borlar (4060 Ti 8/16) yo'q
+var gpuVRAM = map[string]int{
+	"gtx 1650": 4, "gtx 1660": 6, "gtx 1660 super": 6, "gtx 1660 ti": 6,
+	"rtx 3050": 8, "rtx 3060": 12, "rtx 3060 ti": 8, "rtx 3070": 8, "rtx 3070 ti": 8, "rtx 3080": 10, "rtx 3090": 24,
+	"rtx 4060": 8, "rtx 4070": 12, "rtx 4070 super": 12, "rtx 4070 ti": 12, "rtx 4070 ti super": 16,
+	"rtx 4080": 16, "rtx 4080 super": 16, "rtx 4090": 24,
+	"rtx 5060": 8, "rtx 5070": 12, "rtx 5070 ti": 16, "rtx 5080": 16, "rtx 5090": 32,
+	"rx 6500 xt": 4, "rx 6600": 8, "rx 6600 xt": 8, "rx 6650 xt": 8, "rx 6700 xt": 12, "rx 6750 xt": 12,
+	"rx 6800": 16, "rx 6800 xt": 16, "rx 6900 xt": 16,
+	"rx 7600": 8, "rx 7600 xt": 16, "rx 7700 xt": 12, "rx 7800 xt": 16, "rx 7900 gre": 16, "rx 7900 xt": 20, "rx 7900 xtx": 24,
+	"rx 9070": 16, "rx 9070 xt": 16,
+}
+
+// genericCategories - kategoriyasi aniq bo'lmagan satrlar (tur nomdan aniqlanadi)
+var genericCategories = map[string]bool{"boshqa": true, "others": true, "other": true, "прочее": true, "другое": true}
+
+// SpecKind mahsulot turi: avval jadvaldagi kategoriya, noma'lum bo'lsa model nomi bo'yicha
+func SpecKind(category, name string) string {
+	cat := strings.ToLower(strings.TrimSpace(category))
+	switch {
+	case cat == "", genericCategories[cat]:
+	case containsAny(cat, "cool", "кулер", "охлажд", "sovut", "fan"):
+		return PartCooler
+	case containsAny(cat, "cpu", "processor", "процессор", "protsessor"):
+		return PartCPU
+	case containsAny(cat, "gpu", "video", "видео", "graphics"):
+		return PartGPU
+	case containsAny(cat, "motherboard", "материн", "plata", "mainboard"):
+		return PartMotherboard
+	case cat == "ram" || containsAny(cat, "memory", "оператив", "operativ"):
+		return PartRAM
+	case containsAny(cat, "storage", "ssd", "hdd", "rom", "nvme", "накопит"):
+		return PartStorage
+	case containsAny(cat, "psu", "power supply", "блок пит", "quvvat"):
+		return PartPSU
+	case containsAny(cat, "monitor", "монитор"):
+		return PartMonitor
+	case containsAny(cat, "case", "корпус", "korpus"):
+		return PartCase
+	default:
+		// Noutbuk, periferiya va h.k. - nomida CPU/GPU bo'lsa ham komponent emas
+		return ""
+	}
+
+	// Noutbuk/tayyor kompyuter nomida ham CPU va GPU bor
+	if SystemKind("", name) != "" {
+		return ""
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case reRyzen.MatchString(lower) || reCoreUltra.MatchString(lower) || reIntelCore.MatchString(lower):
+		return PartCPU
+	case gpuModel(lower) != "" || reArc.MatchString(lower):
+		return PartGPU
+	case boardAttrs(entity.Product{Name: name}).Chipset != "":
+		return PartMotherboard
+	case reHz.MatchString(lower) && containsAny(lower, "monitor", "монитор", "ips", " va ", "oled", `"`):
+		return PartMonitor
+	case containsAny(lower, "nvme", "ssd", "hdd"):
+		return PartStorage
+	case containsAny(lower, "ddr4", "ddr5"):
+		return PartRAM
+	}
+	return ""
+}
+
+// ExtractSpecs mahsulotga kanonik xususiyatlarni yozadi; aniqlanmagan majburiy kalitlarni qaytaradi
+func ExtractSpecs(p *entity.Product) []string {
+	kind := SpecKind(p.Category, p.Name)
+	ex, ok := specExtractors[kind]
+	if !ok {
+		return nil
+	}
+	if p.Specs == nil {
+		p.Specs = make(map[string]string)
+	}
+	for key, value := range ex.Extract(*p) {
+		if value != "" {
+			p.Specs[key] = value
+		}
+	}
+	return missingSpecs(*p, ex.Required)
+}
+
+func missingSpecs(p entity.Product, required []string) []string {
+	var missing []string
+	for _, key := range required {
+		if strings.TrimSpace(p.Specs[key]) == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// NormalizeCatalogSpecs import paytida butun katalogga extractorlarni qo'llaydi
+func NormalizeCatalogSpecs(products []entity.Product) SpecReport {
+	var report SpecReport
+	for i := range products {
+		kind := SpecKind(products[i].Category, products[i].Name)
+		if _, ok := specExtractors[kind]; !ok {
+			continue
+		}
+		report.Checked++
+		missing := ExtractSpecs(&products[i])
+		if len(missing) == 0 {
+			report.Complete++
+			continue
+		}
+		report.Gaps = append(report.Gaps, SpecGap{Name: products[i].Name, Category: products[i].Category, Kind: kind, Missing: missing})
+	}
+	sort.SliceStable(report.Gaps, func(i, j int) bool { return report.Gaps[i].Kind < report.Gaps[j].Kind })
+	return report
+}
+
+// CatalogSpecReport saqlangan katalog bo'yicha hisobot (mahsulotlarni o'zgartirmaydi)
+func CatalogSpecReport(products []entity.Product) SpecReport {
+	copied := make([]entity.Product, len(products))
+	for i, p := range products {
+		copied[i] = p
+		copied[i].Specs = make(map[string]string, len(p.Specs))
+		for k, v := range p.Specs {
+			copied[i].Specs[k] = v
+		}
+	}
+	return NormalizeCatalogSpecs(copied)
+}
+
+func itoa(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func pcieLabel(gen int) string {
+	if gen <= 0 {
+		return ""
+	}
+	return "PCIe " + strconv.Itoa(gen) + ".0"
+}
+
+func extractCPUSpecs(p entity.Product) map[string]string {
+	a := cpuAttrs(p)
+	lower := strings.ToLower(p.Name)
+	out := map[string]string{
+		entity.SpecSocket:     a.Socket,
+		entity.SpecTDP:        itoa(a.TDP),
+		entity.SpecMemoryType: strings.Join(a.Memory, "/"),
+	}
+
+	cores, threads := specInt(p, entity.SpecCores), specInt(p, entity.SpecThreads)
+	if cores == 0 {
+		if m := reCoresThread.FindStringSubmatch(lower); m != nil {
+			cores, _ = strconv.Atoi(m[1])
+			threads, _ = strconv.Atoi(m[2])
+		} else if m := reCoresName.FindStringSubmatch(lower); m != nil {
+			cores, _ = strconv.Atoi(m[1])
+		}
+	}
+	if cores == 0 {
+		switch {
+		case reRyzen.MatchString(lower):
+			model := reRyzen.FindStringSubmatch(lower)[1]
+			tier := ""
+			if m := reRyzenTier.FindStringSubmatch(lower); m != nil {
+				tier = m[1]
+			}
+			switch tier {
+			case "3":
+				cores, threads = 4, 8
+			case "5":
+				cores, threads = 6, 12
+			case "7":
+				cores, threads = 8, 16
+			case "9":
+				cores, threads = 12, 24
+				if strings.HasSuffix(model, "950") {
+					cores, threads = 16, 32
+				}
+			}
+		case reCoreUltra.MatchString(lower):
+			if ct, ok := intelCores["ultra "+reCoreUltra.FindStringSubmatch(lower)[1]]; ok {
+				cores, threads = ct[0], ct[1]
+			}
+		case reIntelCore.MatchString(lower):
+			m := reIntelCore.FindStringSubmatch(lower)
+			ct, ok := intelCores[m[2]+"k"]
+			if !ok || !strings.Contains(m[3], "k") {
+				ct, ok = intelCores[m[2]]
+			}
+			if ok {
+				cores, threads = ct[0], ct[1]
+			}
+		}
+	}
+	out[entity.SpecCores] = itoa(cores)
+	out[entity.SpecThreads] = itoa(threads)
+	return out
+}
+
+func extractGPUSpecs(p entity.Product) map[string]string {
+	a := gpuAttrs(p)
+	lower := strings.ToLower(p.Name)
+	chip := strings.ToUpper(gpuModel(lower))
+	if chip == "" {
+		if m := reArc.FindStringSubmatch(lower); m != nil {
+			chip = "ARC " + strings.ToUpper(m[1])
+		}
+	}
+	if v := p.Spec(entity.SpecGPUChip); chip == "" && v != "" {
+		chip = strings.ToUpper(gpuModel(v))
+	}
+	vram := specInt(p, entity.SpecVRAM)
+	if vram == 0 {
+		for _, m := range reVRAM.FindAllStringSubmatch(lower, -1) {
+			if n, _ := strconv.Atoi(m[1]); n >= 2 && n <= 48 {
+				vram = n
+				break
+			}
+		}
+	}
+	if vram == 0 {
+		vram = gpuVRAM[strings.ToLower(chip)]
+	}
+	return map[string]string{
+		entity.SpecGPUChip: chip,
+		entity.SpecVRAM:    itoa(vram),
+		entity.SpecTDP:     itoa(a.TDP),
+		entity.SpecLength:  itoa(a.LengthMM),
+	}
+}
+
+func extractRAMSpecs(p entity.Product) map[string]string {
+	lower := strings.ToLower(p.Name)
+	speed := specInt(p, entity.SpecSpeed)
+	if speed == 0 {
+		if m := reRAMSpeed.FindStringSubmatch(lower); m != nil {
+			speed, _ = strconv.Atoi(m[1] + m[2])
+		}
+	}
+	capacity := specInt(p, entity.SpecCapacity)
+	if capacity == 0 {
+		capacity = extractRAMSizeGB(lower)
+	}
+	return map[string]string{
+		entity.SpecMemoryType: strings.Join(PartAttrs(PartRAM, p).Memory, "/"),
+		entity.SpecCapacity:   itoa(capacity),
+		entity.SpecSpeed:      itoa(speed),
+	}
+}
+
+func extractStorageSpecs(p entity.Product) map[string]string {
+	lower := strings.ToLower(p.Name + " " + p.Spec(entity.SpecInterface))
+	iface := ""
+	switch {
+	case containsAny(lower, "nvme", "m.2", "pcie"):
+		iface = "NVMe"
+	case containsAny(lower, "sata", "2.5"):
+		iface = "SATA"
+		if containsAny(lower, "hdd", "rpm") {
+			iface = "HDD"
+		}
+	case containsAny(lower, "hdd", "rpm", "3.5"):
+		iface = "HDD"
+	}
+	capacity := 0
+	if m := reTB.FindStringSubmatch(lower); m != nil {
+		tb, _ := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64)
+		capacity = int(tb * 1000)
+	} else if m := reGB.FindStringSubmatch(lower); m != nil {
+		capacity, _ = strconv.Atoi(m[1])
+	}
+	if v := specInt(p, entity.SpecCapacity); capacity == 0 && v > 0 {
+		capacity = v
+	}
+	return map[string]string{
+		entity.SpecInterface: iface,
+		entity.SpecCapacity:  itoa(capacity),
+	}
+}
+
+func extractPSUSpecs(p entity.Product) map[string]string {
+	lower := strings.ToLower(p.Name + " " + p.Spec(entity.SpecEfficiency))
+	rating := ""
+	for _, tier := range []string{"titanium", "platinum", "gold", "silver", "bronze", "white"} {
+		if strings.Contains(lower, tier) {
+			rating = "80+ " + strings.ToUpper(tier[:1]) + tier[1:]
+			break
+		}
+	}
+	if rating == "" && containsAny(lower, "80+", "80 plus") {
+		rating = "80+"
+	}
+	return map[string]string{
+		entity.SpecWattage:    itoa(psuAttrs(p).Watts),
+		entity.SpecEfficiency: rating,
+	}
+}
+
+func extractMonitorSpecs(p entity.Product) map[string]string {
+	lower := strings.ToLower(p.Name)
+	size := p.Spec(entity.SpecScreenSize)
+	if m := reScreenSize.FindStringSubmatch(size + " " + lower); m != nil {
+		size = m[1]
+	} else if m := reScreenToken.FindStringSubmatch(lower); m != nil && size == "" {
+		size = m[1]
+	}
+	size = strings.ReplaceAll(strings.Trim(size, `"' `), ",", ".")
+
+	hz := specInt(p, entity.SpecRefreshRate)
+	if m := reHz.FindStringSubmatch(lower); hz == 0 && m != nil {
+		hz, _ = strconv.Atoi(m[1])
+	}
+
+	panelText := " " + strings.ToLower(p.Spec(entity.SpecPanel)) + " " + lower + " "
+	panel := ""
+	switch {
+	case strings.Contains(panelText, "qd-oled") || strings.Contains(panelText, "qd oled"):
+		panel = "QD-OLED"
+	case strings.Contains(panelText, "oled"):
+		panel = "OLED"
+	case strings.Contains(panelText, "ips"):
+		panel = "IPS"
+	case strings.Contains(panelText, " va ") || strings.Contains(panelText, " va,") || strings.Contains(panelText, "va panel"):
+		panel = "VA"
+	case strings.Contains(panelText, " tn "):
+		panel = "TN"
+	}
+
+	resolution := ""
+	resText := strings.ToLower(p.Spec(entity.SpecResolution)) + " " + lower
+	switch {
+	case reResolution.MatchString(resText):
+		m := reResolution.FindStringSubmatch(resText)
+		resolution = m[1] + "x" + m[2]
+	case containsAny(resText, "4k", "uhd", "2160p"):
+		resolution = "3840x2160"
+	case containsAny(resText, "qhd", "2k", "1440p"):
+		resolution = "2560x1440"
+	case containsAny(resText, "fhd", "full hd", "1080p"):
+		resolution = "1920x1080"
+	}
+	return map[string]string{
+		entity.SpecScreenSize:  size,
+		entity.SpecRefreshRate: itoa(hz),
+		entity.SpecPanel:       panel,
+		entity.SpecResolution:  resolution,
+	}
+}
+
+func extractBoardSpecs(p entity.Product) map[string]string {
+	a := boardAttrs(p)
+	return map[string]string{
+		entity.SpecSocket:     a.Socket,
+		entity.SpecChipset:    a.Chipset,
+		entity.SpecMemoryType: strings.Join(a.Memory, "/"),
+		entity.SpecFormFactor: a.FormFactor,
+		entity.SpecPCIe:       pcieLabel(a.PCIeGen),
+	}
+}
+
+// Quyidagilar tahlil/tanlov uchun: katalogda aniqlangan Specs bo'lsa avval ular, bo'lmasa nom
+
+// productRAMGB RAM hajmi (GB)
+func productRAMGB(p entity.Product) int {
+	if v := specInt(p, entity.SpecCapacity); v > 0 {
+		return v
+	}
+	return extractRAMSizeGB(p.Name)
+}
+
+// productStorageGB disk hajmi (GB); aniqlanmasa 0
+func productStorageGB(p entity.Product) int {
+	if v := specInt(p, entity.SpecCapacity); v > 0 {
+		return v
+	}
+	v, _ := strconv.Atoi(extractStorageSpecs(p)[entity.SpecCapacity])
+	return v
+}
+
+// productPSUWatts blok quvvati (W)
+func productPSUWatts(p entity.Product) int {
+	return psuAttrs(p).Watts
+}
+
+// storageDescriptor scoreStorage uchun nom + interfeys (nomda NVMe/SSD yozilmagan bo'lsa ham)
+func storageDescriptor(p entity.Product) string {
+	switch p.Spec(entity.SpecInterface) {
+	case "NVMe":
+		return p.Name + " nvme"
+	case "SATA":
+		return p.Name + " sata ssd"
+	case "HDD":
+		return p.Name + " hdd"
+	}
+	return p.Name
+}
+
+// scoreCPUProduct nomdan seriya aniqlanmasa yadrolar soni bo'yicha baholaydi
+func scoreCPUProduct(p entity.Product) (float64, string, bool) {
+	lower := strings.ToLower(p.Name)
+	cores := specInt(p, entity.SpecCores)
+	if cores == 0 || containsAny(lower, "ryzen", "i3", "i5", "i7", "i9", "xeon", "threadripper", "epyc", "pentium", "celeron", "athlon") {
+		return scoreCPU(p.Name)
+	}
+	switch {
+	case cores >= 16:
+		return 9.0, "High", false
+	case cores >= 8:
+		return 7.8, "Upper CPU", false
+	case cores >= 6:
+		return 6.5, "Mid CPU", false
+	case cores >= 4:
+		return 5.0, "Entry CPU", false
+	default:
+		return 3.5, "Low CPU", false
+	}
+}
+
+// scoreGPUProduct nomga aniqlangan GPU chipini qo'shib baholaydi
+func scoreGPUProduct(p entity.Product) (float64, string) {
+	if chip := p.Spec(entity.SpecGPUChip); chip != "" {
+		return scoreGPU(p.Name + " " + chip)
+	}
+	return scoreGPU(p.Name)
+}
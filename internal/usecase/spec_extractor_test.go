@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+func TestExtractSpecs(t *testing.T) {
+	cases := []struct {
+		product entity.Product
+		want    map[string]string
+	}{
+		{entity.Product{Name: "Intel Core i5-13400F", Category: "CPU"}, map[string]string{entity.SpecSocket: "LGA1700"}},
+		{entity.Product{Name: "MSI GeForce RTX 4060 Ti Ventus 2X 8G", Category: "Videokarta"}, map[string]string{entity.SpecVRAM: "8"}},
+		{entity.Product{Name: "Kingston Fury Beast 2x16GB DDR5 6000", Category: "RAM"}, map[string]string{entity.SpecMemoryType: "DDR5", entity.SpecCapacity: "32", entity.SpecSpeed: "6000"}},
+		{entity.Product{Name: "Samsung 990 Pro 2TB M.2", Category: "SSD"}, map[string]string{entity.SpecInterface: "NVMe", entity.SpecCapacity: "2000"}},
+		{entity.Product{Name: "Corsair RM750e 80+ Gold", Category: "Блок питания"}, map[string]string{entity.SpecWattage: "750", entity.SpecEfficiency: "80+ Gold"}},
+		{entity.Product{Name: "Samsung Odyssey G5 27\" 165Hz VA QHD", Category: "Monitor"}, map[string]string{entity.SpecScreenSize: "27", entity.SpecRefreshRate: "165", entity.SpecPanel: "VA"}},
+	}
+	for _, tc := range cases {
+		p := tc.product
+		if missing := ExtractSpecs(&p); len(missing) != 0 {
+			t.Errorf("%s: aniqlanmadi %v (specs %v)", p.Name, missing, p.Specs)
+		}
+		for k, v := range tc.want {
+			if got := p.Spec(k); got != v {
+				t.Errorf("%s: %s = %q, kutilgan %q", p.Name, k, got, v)
+			}
+		}
+	}
+}
+
+func TestCatalogSpecReport(t *testing.T) {
+	products := []entity.Product{
+		{Name: "AMD Ryzen 7 7800X3D", Category: "CPU"},
+		{Name: "Noma'lum protsessor", Category: "CPU"},
+		{Name: "Logitech G102", Category: "Mouse"},
+	}
+	report := CatalogSpecReport(products)
+	if report.Checked != 2 || report.Complete != 1 || len(report.Gaps) != 1 || report.Gaps[0].Kind != PartCPU {
+		t.Fatalf("hisobot: %+v", report)
+	}
+	if products[0].Specs != nil {
+		t.Fatalf("CatalogSpecReport katalogni o'zgartirmasligi kerak")
+	}
+}
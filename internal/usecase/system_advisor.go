@@ -0,0 +1,464 @@
+package usecase
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Noutbuk va tayyor kompyuterlar: komponentlardan farqli ravishda butun tizim bo'lib sotiladi.
+// Nom/tavsif va Specs dan SystemSpecs (CPU, GPU, RAM, disk, ekran, og'irlik, batareya) ajratiladi,
+// suhbatdagi filtrlar (turi, budjet, maqsad, ko'chmalik) bo'yicha saralanadi va CPU/GPU kuchi
+// (BenchmarkDB, bo'lmasa daraja bo'yicha) maqsad vazniga ko'ra 0-10 reytingga aylanadi.
+
+const (
+	PartLaptop   = "laptop"
+	PartPrebuilt = "prebuilt"
+)
+
+// portableMaxKg "yengil/ko'chma" deyilganda og'irlik chegarasi
+const portableMaxKg = 1.8
+
+var (
+	reUltraTier   = regexp.MustCompile(`ultra\s*([3579])`)
+	reMobileRyzen = regexp.MustCompile(`ryzen\s*([3579])\s*(?:pro\s*)?(\d{4})\s*(hx3d|hx|hs|h|u|x3d|x|g|f)?\b`)
+	reRyzenAI     = regexp.MustCompile(`ryzen\s*ai\s*(?:max\+?\s*)?([3579])\s*(?:hx\s*)?(\d{3})\b`)
+	reAppleM      = regexp.MustCompile(`\bm([1-4])\s*(pro|max|ultra)?\b`)
+	reIntelN      = regexp.MustCompile(`\b(?:intel\s*)?(n\d{3,4}|celeron\s*\w+|pentium\s*\w+)`)
+	reSystemRAM   = regexp.MustCompile(`(\d{1,3})\s*gb\s*(?:ram|ddr\d?|lpddr\d?x?|озу|dram|unified)`)
+	reGBToken     = regexp.MustCompile(`(\d{1,4})\s*gb\b`)
+	reDiskSize    = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(tb|gb)\s*(?:ssd|nvme|hdd|emmc|pcie|m\.2)`)
+	reDiskAfter   = regexp.MustCompile(`(?:ssd|nvme)\s*(\d+(?:[.,]\d+)?)\s*(tb|gb)\b`)
+	reGPUMemory   = regexp.MustCompile(`\b(?:rtx|gtx|rx|arc)\s*\w*\d{3,4}\w*(?:\s*(?:ti|super|xt|laptop|mobile))*\s*\d{1,2}\s*gb\b`)
+	reLaptopInch  = regexp.MustCompile(`\b(1[0-8](?:[.,]\d)?)\s*(?:"|''|”|″|inch|дюйм|dyuym)|\b(1[0-8][.,]\d)\b`)
+	reWeightKg    = regexp.MustCompile(`(?:^|[^\d.,])(\d(?:[.,]\d{1,2})?)\s*(?:kg|кг)`)
+	reBatteryWh   = regexp.MustCompile(`(\d{2,3})\s*(?:wh|вт[·*\s]?ч)`)
+)
+
+// laptopLines - faqat noutbuklarda uchraydigan seriya nomlari (kategoriya aniq bo'lmasa)
+var laptopLines = []string{
+	"laptop", "notebook", "noutbuk", "ноутбук", "macbook", "vivobook", "zenbook", "ideapad", "thinkpad",
+	"thinkbook", "inspiron", "latitude", "victus", "zephyrus", "expertbook", "chromebook",
+}
+
+// prebuiltLines - tayyor tizim bloklari
+var prebuiltLines = []string{
+	"prebuilt", "pre-built", "tayyor pc", "tayyor kompyuter", "готовый пк", "системный блок", "system unit",
+	"desktop pc", "gaming pc", "mini pc", "моноблок", "all-in-one", "imac", "mac mini",
+}
+
+// SystemSpecs tizim xususiyatlari; 0 va "" - noma'lum
+type SystemSpecs struct {
+	Kind      string
+	CPU       string // "Core i7-13620H", "Ryzen 7 7840HS", "Apple M3"
+	GPU       string // "RTX 4060"; diskret karta yo'q bo'lsa "iGPU"
+	RAMGB     int
+	StorageGB int
+	ScreenIn  float64
+	RefreshHz int
+	WeightKg  float64
+	BatteryWh int
+}
+
+// SystemRequest suhbatdan olingan filtrlar
+type SystemRequest struct {
+	Kind        string  // PartLaptop, PartPrebuilt; "" - ikkalasi
+	BudgetUSD   float64 // 0 - cheklanmagan
+	Purpose     string  // Gaming, Design, Developer, Office; "" - umumiy
+	Portable    bool    // yengil va batareyasi uzoq (noutbuk uchun)
+	MaxWeightKg float64
+	MinRAMGB    int
+	ScreenIn    float64 // xohlangan diagonal; 0 - farqi yo'q
+	Branch      string
+	Price       func(entity.Product) float64 // narx dollarda; nil - Price.Major()
+	Bench       *BenchmarkDB
+}
+
+// SystemPick reytingdagi tizim; Misses - bajarilmagan filtrlar (weight, ram, screen)
+type SystemPick struct {
+	Product    entity.Product
+	Specs      SystemSpecs
+	PriceUSD   float64
+	Score      float64 // maqsad bo'yicha 0-10
+	CPUScore   float64
+	GPUScore   float64
+	OverBudget bool
+	Misses     []string
+}
+
+// systemWeights maqsad bo'yicha CPU, GPU, RAM va ko'chmalik vazni
+var systemWeights = map[string][4]float64{
+	"Gaming":    {0.25, 0.50, 0.15, 0.10},
+	"Design":    {0.35, 0.30, 0.25, 0.10},
+	"Developer": {0.40, 0.10, 0.30, 0.20},
+	"Office":    {0.30, 0.05, 0.20, 0.45},
+	"":          {0.35, 0.25, 0.20, 0.20},
+}
+
+// SystemKind mahsulot noutbuk yoki tayyor kompyutermi; aks holda ""
+func SystemKind(category, name string) string {
+	cat := strings.ToLower(strings.TrimSpace(category))
+	switch {
+	case containsAny(cat, "laptop", "notebook", "noutbuk", "ноутбук", "macbook"):
+		return PartLaptop
+	case cat == "pc" || cat == "desktop" || containsAny(cat, "prebuilt", "pre-built", "tayyor", "готов", "системн", "kompyuter", "компьютер", "моноблок", "desktop pc"):
+		return PartPrebuilt
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case containsAny(lower, laptopLines...):
+		return PartLaptop
+	case containsAny(lower, prebuiltLines...):
+		return PartPrebuilt
+	}
+	return ""
+}
+
+// SystemSpecsOf jadvaldagi Specs bo'lsa avval ular, bo'lmasa nom va tavsifdan
+func SystemSpecsOf(p entity.Product) SystemSpecs {
+	s := SystemSpecs{Kind: SystemKind(p.Category, p.Name)}
+	text := strings.ToLower(p.Name + " " + p.Description)
+
+	s.CPU = systemCPU(p.Spec(entity.SpecCPU) + " " + text)
+	s.GPU = systemGPU(p.Spec(entity.SpecGPUChip) + " " + text)
+
+	s.RAMGB = specInt(p, entity.SpecRAMSize)
+	if s.RAMGB == 0 {
+		s.RAMGB = systemRAM(text)
+	}
+	s.StorageGB = specInt(p, entity.SpecStorageGB)
+	if s.StorageGB == 0 {
+		s.StorageGB = systemDisk(text)
+	}
+
+	screenText := p.Spec(entity.SpecScreenSize) + " " + text
+	if m := reLaptopInch.FindStringSubmatch(screenText); m != nil {
+		s.ScreenIn = parseDecimal(m[1] + m[2])
+	} else if m := reScreenSize.FindStringSubmatch(screenText); m != nil {
+		s.ScreenIn = parseDecimal(m[1])
+	}
+	s.RefreshHz = specInt(p, entity.SpecRefreshRate)
+	if m := reHz.FindStringSubmatch(text); s.RefreshHz == 0 && m != nil {
+		s.RefreshHz, _ = strconv.Atoi(m[1])
+	}
+	if m := reWeightKg.FindStringSubmatch(p.Spec(entity.SpecWeight) + " kg " + text); m != nil {
+		s.WeightKg = parseDecimal(m[1])
+	}
+	if m := reBatteryWh.FindStringSubmatch(strings.ToLower(p.Spec(entity.SpecBattery)) + " wh " + text); m != nil {
+		s.BatteryWh, _ = strconv.Atoi(m[1])
+	}
+	return s
+}
+
+// SystemSummary qisqa tavsif: "Core i7-13620H, RTX 4060, 16GB RAM, 512GB, 15.6", 2.2kg"
+func SystemSummary(s SystemSpecs) string {
+	parts := []string{}
+	for _, v := range []string{s.CPU, s.GPU} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	if s.RAMGB > 0 {
+		parts = append(parts, strconv.Itoa(s.RAMGB)+"GB RAM")
+	}
+	if s.StorageGB > 0 {
+		parts = append(parts, FormatStorage(s.StorageGB))
+	}
+	if s.ScreenIn > 0 {
+		parts = append(parts, strconv.FormatFloat(s.ScreenIn, 'f', -1, 64)+`"`)
+	}
+	if s.WeightKg > 0 {
+		parts = append(parts, strconv.FormatFloat(s.WeightKg, 'f', -1, 64)+"kg")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatStorage 512 -> "512GB", 1000 -> "1TB"
+func FormatStorage(gb int) string {
+	if gb >= 1000 && gb%1000 == 0 {
+		return strconv.Itoa(gb/1000) + "TB"
+	}
+	return strconv.Itoa(gb) + "GB"
+}
+
+func parseDecimal(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", "."), 64)
+	return v
+}
+
+// systemCPU protsessorning qisqa nomi; mobil qo'shimchalari (H, HS, U) saqlanadi
+func systemCPU(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case reCoreUltra.MatchString(lower):
+		m := reCoreUltra.FindStringSubmatch(lower)
+		return "Core Ultra " + reUltraTier.FindStringSubmatch(lower)[1] + " " + m[1] + strings.ToUpper(m[2])
+	case reIntelCore.MatchString(lower):
+		m := reIntelCore.FindStringSubmatch(lower)
+		return "Core i" + m[1] + "-" + m[2] + strings.ToUpper(m[3])
+	case reRyzenAI.MatchString(lower):
+		m := reRyzenAI.FindStringSubmatch(lower)
+		return "Ryzen AI " + m[1] + " " + m[2]
+	case reMobileRyzen.MatchString(lower):
+		m := reMobileRyzen.FindStringSubmatch(lower)
+		return "Ryzen " + m[1] + " " + m[2] + strings.ToUpper(m[3])
+	case containsAny(lower, "apple", "macbook", "imac", "mac mini") && reAppleM.MatchString(lower):
+		m := reAppleM.FindStringSubmatch(lower)
+		name := "Apple M" + m[1]
+		if m[2] != "" {
+			name += " " + strings.ToUpper(m[2][:1]) + m[2][1:]
+		}
+		return name
+	case reIntelN.MatchString(lower):
+		m := reIntelN.FindStringSubmatch(lower)
+		return strings.ToUpper(m[1][:1]) + m[1][1:]
+	}
+	return ""
+}
+
+// systemGPU diskret karta ("RTX 4060"); topilmasa "iGPU"
+func systemGPU(text string) string {
+	lower := strings.ToLower(text)
+	if chip := gpuModel(lower); chip != "" {
+		return strings.ToUpper(chip)
+	}
+	if m := reArc.FindStringSubmatch(lower); m != nil {
+		return "ARC " + strings.ToUpper(m[1])
+	}
+	return "iGPU"
+}
+
+// systemRAM operativ xotira: "16GB RAM/DDR5" aniq yozilgani, bo'lmasa disk va videoxotira
+// bo'lmagan birinchi "NGB"
+func systemRAM(text string) int {
+	if m := reSystemRAM.FindStringSubmatch(text); m != nil {
+		v, _ := strconv.Atoi(m[1])
+		return v
+	}
+	text = reGPUMemory.ReplaceAllString(text, " ")
+	for _, idx := range reGBToken.FindAllStringSubmatchIndex(text, -1) {
+		v, _ := strconv.Atoi(text[idx[2]:idx[3]])
+		rest := strings.TrimLeft(text[idx[1]:], " /")
+		if v < 4 || v > 128 || containsAny(firstWord(rest), "ssd", "nvme", "hdd", "emmc", "rom", "pcie", "m.2") {
+			continue
+		}
+		return v
+	}
+	return 0
+}
+
+// systemDisk disk hajmi (GB)
+func systemDisk(text string) int {
+	m := reDiskSize.FindStringSubmatch(text)
+	if m == nil {
+		m = reDiskAfter.FindStringSubmatch(text)
+	}
+	if m == nil {
+		return 0
+	}
+	v := parseDecimal(m[1])
+	if m[2] == "tb" {
+		v *= 1000
+	}
+	return int(v)
+}
+
+func firstWord(s string) string {
+	if f := strings.Fields(s); len(f) > 0 {
+		return f[0]
+	}
+	return ""
+}
+
+// ParseSystemRequest mijoz matnidan filtrlar: turi, budjet ($), maqsad, ko'chmalik, RAM va diagonal
+func ParseSystemRequest(text string) SystemRequest {
+	lower := strings.ToLower(text)
+	req := SystemRequest{Kind: SystemKind("", lower), Purpose: normalizeUseCase(text)}
+	if req.Kind == "" && containsAny(lower, "tayyor", "готов", "kompyuter", "компьютер", "desktop", " pc") {
+		req.Kind = PartPrebuilt
+	}
+	if m := reBudgetUSD.FindStringSubmatch(lower); m != nil {
+		req.BudgetUSD = parseDecimal(strings.ReplaceAll(m[1], " ", ""))
+	}
+	req.Portable = containsAny(lower, "yengil", "ko'chma", "portable", "лёгк", "легк", "ultrabook", "ультрабук", "batareya", "батаре", "battery")
+	if m := reWeightKg.FindStringSubmatch(lower); m != nil {
+		req.MaxWeightKg = parseDecimal(m[1])
+	} else if req.Portable {
+		req.MaxWeightKg = portableMaxKg
+	}
+	if m := reSystemRAM.FindStringSubmatch(lower); m != nil {
+		req.MinRAMGB, _ = strconv.Atoi(m[1])
+	}
+	if m := reLaptopInch.FindStringSubmatch(lower); m != nil {
+		req.ScreenIn = parseDecimal(m[1] + m[2])
+	}
+	return req
+}
+
+// RankSystems qoldiqdagi noutbuk/tayyor kompyuterlarni maqsad bo'yicha saralaydi (eng yaxshisi birinchi).
+// Budjetga sig'adigani bo'lsa faqat ular, aks holda eng arzonlari OverBudget belgisi bilan.
+func RankSystems(products []entity.Product, req SystemRequest) []SystemPick {
+	if req.Price == nil {
+		req.Price = func(p entity.Product) float64 { return p.Price.Major() }
+	}
+	purpose := normalizeUseCase(req.Purpose)
+	if _, ok := systemWeights[purpose]; !ok {
+		purpose = ""
+	}
+
+	var fits, over []SystemPick
+	for _, p := range inStockOrAll(products, req.Branch) {
+		specs := SystemSpecsOf(p)
+		if specs.Kind == "" || (req.Kind != "" && specs.Kind != req.Kind) {
+			continue
+		}
+		price := req.Price(p)
+		if price <= 0 {
+			continue
+		}
+		pick := SystemPick{Product: p, Specs: specs, PriceUSD: price}
+		pick.CPUScore, pick.GPUScore = systemPerformance(specs, req.Bench)
+		pick.Score, pick.Misses = systemScore(pick, purpose, req)
+		if req.BudgetUSD > 0 && price > req.BudgetUSD {
+			pick.OverBudget = true
+			over = append(over, pick)
+			continue
+		}
+		fits = append(fits, pick)
+	}
+
+	if len(fits) == 0 {
+		sort.SliceStable(over, func(i, j int) bool { return over[i].PriceUSD < over[j].PriceUSD })
+		return over
+	}
+	sort.SliceStable(fits, func(i, j int) bool {
+		if fits[i].Score != fits[j].Score {
+			return fits[i].Score > fits[j].Score
+		}
+		return fits[i].PriceUSD < fits[j].PriceUSD
+	})
+	return fits
+}
+
+// systemPerformance CPU va GPU kuchi 0-10 (scoreCPU/scoreGPU shkalasi); noutbukda quvvat
+// cheklovi sabab mobil chiplar bir xil nomli desktop chipdan pastroq baholanadi
+func systemPerformance(s SystemSpecs, bench *BenchmarkDB) (float64, float64) {
+	if bench == nil {
+		bench = DefaultBenchmarks()
+	}
+	cpuScore, _, _ := scoreCPU(s.CPU)
+	lowerCPU := strings.ToLower(s.CPU)
+	switch {
+	case strings.HasPrefix(lowerCPU, "core ultra"):
+		cpuScore = map[string]float64{"9": 9.0, "7": 7.8, "5": 6.5, "3": 5.0}[reUltraTier.FindStringSubmatch(lowerCPU)[1]]
+	case strings.HasPrefix(lowerCPU, "ryzen ai"):
+		cpuScore = 8.0
+	case strings.HasPrefix(lowerCPU, "apple m"):
+		cpuScore = 7.0
+		if containsAny(lowerCPU, "pro", "max", "ultra") {
+			cpuScore = 8.5
+		}
+	case containsAny(lowerCPU, "n100", "n200", "celeron", "pentium"):
+		cpuScore = 3.0
+	case s.CPU == "":
+		cpuScore = 4.5
+	}
+
+	gpuScore := 3.0
+	if s.GPU != "iGPU" {
+		raw, _, _, _ := bench.gpuBenchScore(entity.Product{Name: s.GPU})
+		gpuScore = 10 * math.Pow(raw/100, 0.4)
+	} else if containsAny(lowerCPU, "apple m", "ryzen ai", "ultra") {
+		gpuScore = 4.0 // kuchli integratsiyalashgan grafika
+	}
+
+	if s.Kind == PartLaptop {
+		switch {
+		case strings.HasSuffix(lowerCPU, "u"):
+			cpuScore *= 0.8
+		case strings.HasSuffix(lowerCPU, "hx"), strings.HasPrefix(lowerCPU, "apple"):
+		default:
+			cpuScore *= 0.9
+		}
+		if s.GPU != "iGPU" {
+			gpuScore *= 0.85
+		}
+	}
+	return math.Min(cpuScore, 10), math.Min(gpuScore, 10)
+}
+
+// systemScore maqsad vazni bo'yicha umumiy ball va bajarilmagan filtrlar
+func systemScore(pick SystemPick, purpose string, req SystemRequest) (float64, []string) {
+	s := pick.Specs
+	w := systemWeights[purpose]
+	if req.Portable {
+		w[3] += 0.25
+	}
+	if s.Kind != PartLaptop {
+		// Tayyor kompyuter ko'chirilmaydi - vazn protsessorga o'tadi
+		w[0] += w[3]
+		w[3] = 0
+	}
+	total := w[0] + w[1] + w[2] + w[3]
+	score := (w[0]*pick.CPUScore + w[1]*pick.GPUScore + w[2]*ramScore(s.RAMGB) + w[3]*portabilityScore(s)) / total
+
+	var misses []string
+	if req.MaxWeightKg > 0 && s.Kind == PartLaptop && s.WeightKg > req.MaxWeightKg {
+		score--
+		misses = append(misses, "weight")
+	}
+	if req.MinRAMGB > 0 && s.RAMGB > 0 && s.RAMGB < req.MinRAMGB {
+		score--
+		misses = append(misses, "ram")
+	}
+	if req.ScreenIn > 0 && s.Kind == PartLaptop && s.ScreenIn > 0 && math.Abs(s.ScreenIn-req.ScreenIn) > 1 {
+		score -= 0.5
+		misses = append(misses, "screen")
+	}
+	return math.Max(0, math.Round(score*10)/10), misses
+}
+
+func ramScore(gb int) float64 {
+	switch {
+	case gb >= 64:
+		return 10
+	case gb >= 32:
+		return 9
+	case gb >= 16:
+		return 7
+	case gb >= 8:
+		return 4
+	case gb > 0:
+		return 2
+	}
+	return 5
+}
+
+// portabilityScore og'irlik (asosiy) va batareya sig'imi bo'yicha; noma'lum bo'lsa o'rtacha
+func portabilityScore(s SystemSpecs) float64 {
+	score := 5.0
+	switch {
+	case s.WeightKg <= 0:
+	case s.WeightKg <= 1.3:
+		score = 10
+	case s.WeightKg <= 1.6:
+		score = 8.5
+	case s.WeightKg <= 2.0:
+		score = 6.5
+	case s.WeightKg <= 2.4:
+		score = 4.5
+	default:
+		score = 3
+	}
+	switch {
+	case s.BatteryWh >= 70:
+		score++
+	case s.BatteryWh > 0 && s.BatteryWh <= 45:
+		score--
+	}
+	return math.Max(0, math.Min(score, 10))
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+func TestSystemSpecsOf(t *testing.T) {
+	tuf := SystemSpecsOf(entity.Product{
+		Name:     `ASUS TUF Gaming F15 i7-13620H/16GB DDR5/512GB SSD/RTX 4060 8GB/15.6" 144Hz/2.2kg/90Wh`,
+		Category: "Noutbuklar",
+	})
+	if tuf.Kind != PartLaptop || tuf.CPU != "Core i7-13620H" || tuf.GPU != "RTX 4060" || tuf.RAMGB != 16 || tuf.StorageGB != 512 {
+		t.Fatalf("TUF: %+v", tuf)
+	}
+	if tuf.ScreenIn != 15.6 || tuf.RefreshHz != 144 || tuf.WeightKg != 2.2 || tuf.BatteryWh != 90 {
+		t.Fatalf("TUF ekran/og'irlik: %+v", tuf)
+	}
+
+	// Jadval ustunlari nomdan ustun turadi; RAM videoxotira bilan adashmaydi
+	zen := SystemSpecsOf(entity.Product{
+		Name:  "ASUS Zenbook 14 Ryzen 7 7840HS 1TB SSD",
+		Specs: map[string]string{"RAM": "32", "Вес": "1.2"},
+	})
+	if zen.Kind != PartLaptop || zen.CPU != "Ryzen 7 7840HS" || zen.GPU != "iGPU" || zen.RAMGB != 32 || zen.StorageGB != 1000 || zen.WeightKg != 1.2 {
+		t.Fatalf("Zenbook: %+v", zen)
+	}
+	if kind := SpecKind("", "Lenovo IdeaPad Slim 3 i5-12450H"); kind != "" {
+		t.Fatalf("noutbuk komponent deb topildi: %q", kind)
+	}
+}
+
+func TestParseSystemRequest(t *testing.T) {
+	req := ParseSystemRequest("dasturchi uchun yengil noutbuk 1200$, 32gb ram, 14 dyuym")
+	if req.Kind != PartLaptop || req.BudgetUSD != 1200 || req.Purpose != "Developer" || !req.Portable {
+		t.Fatalf("so'rov: %+v", req)
+	}
+	if req.MaxWeightKg != portableMaxKg || req.MinRAMGB != 32 || req.ScreenIn != 14 {
+		t.Fatalf("filtrlar: %+v", req)
+	}
+	if got := ParseSystemRequest("o'yin uchun tayyor kompyuter").Kind; got != PartPrebuilt {
+		t.Fatalf("tayyor kompyuter: %q", got)
+	}
+}
+
+func TestRankSystems(t *testing.T) {
+	usd := func(v float64) entity.Money { return entity.NewMoney(v, entity.CurrencyUSD) }
+	products := []entity.Product{
+		{Name: `Lenovo IdeaPad Slim 5 i5-1335U/16GB/512GB SSD/14"/1.4kg/56Wh`, Category: "Laptop", Price: usd(700), Stock: 2},
+		{Name: `ASUS TUF Gaming F15 i7-13620H/16GB/512GB SSD/RTX 4060 8GB/15.6" 144Hz/2.2kg`, Category: "Laptop", Price: usd(1150), Stock: 2},
+		{Name: `HP Victus 15 i5-12450H/16GB/512GB SSD/RTX 3050 4GB/15.6" 144Hz/2.3kg`, Category: "Laptop", Price: usd(800), Stock: 2},
+		{Name: `MSI Raider GE78 i9-14900HX/32GB/2TB SSD/RTX 4080 12GB/17" 240Hz/3.1kg`, Category: "Laptop", Price: usd(3000), Stock: 2},
+		{Name: "Gaming PC Ryzen 5 7600/32GB/1TB NVMe/RTX 4070", Category: "Tayyor PC", Price: usd(1200), Stock: 2},
+		{Name: "CPU Intel Core i5-12400F", Category: "CPU", Price: usd(150), Stock: 5},
+	}
+
+	gaming := RankSystems(products, SystemRequest{Kind: PartLaptop, BudgetUSD: 1200, Purpose: "Gaming"})
+	if len(gaming) != 3 || gaming[0].Product.Name != products[1].Name {
+		t.Fatalf("o'yin: %+v", gaming)
+	}
+	for _, p := range gaming {
+		if p.Specs.Kind != PartLaptop || p.PriceUSD > 1200 {
+			t.Fatalf("filtrdan o'tmagan: %+v", p)
+		}
+	}
+
+	office := RankSystems(products, SystemRequest{Kind: PartLaptop, BudgetUSD: 1200, Purpose: "Office", Portable: true, MaxWeightKg: portableMaxKg})
+	if office[0].Product.Name != products[0].Name {
+		t.Fatalf("ofis: birinchi %q", office[0].Product.Name)
+	}
+	for _, p := range office[1:] {
+		if len(p.Misses) == 0 || p.Misses[0] != "weight" {
+			t.Fatalf("og'ir noutbuk belgilanmagan: %+v", p)
+		}
+	}
+
+	over := RankSystems(products, SystemRequest{Kind: PartLaptop, BudgetUSD: 500})
+	if len(over) == 0 || !over[0].OverBudget || over[0].PriceUSD != 700 {
+		t.Fatalf("budjetdan tashqari: %+v", over)
+	}
+	if prebuilt := RankSystems(products, SystemRequest{Kind: PartPrebuilt}); len(prebuilt) != 1 {
+		t.Fatalf("tayyor PC: %d", len(prebuilt))
+	}
+}
@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/i18n"
+)
+
+// Upgrade maslahatchisi: mijoz hozirgi kompyuterini erkin matnda yozadi (rasm bo'lsa, uning matni
+// shu ko'rinishga keltiriladi), zaif bo'g'in analizator bilan aniqlanadi va qoldiqdagi mos
+// mahsulotlardan almashtirishlar foyda/narx bo'yicha saralab taklif qilinadi. Ball optimizator
+// bilan bir xil (benchProfile + buildUseCaseScore), shu sabab natijalar solishtiriladigan.
+
+// minUpgradeGain - bundan kichik ball o'sishi upgrade hisoblanmaydi
+const minUpgradeGain = 0.2
+
+var (
+	reUpgradeSplit = regexp.MustCompile(`[\n,;|+]+`)
+	reUpgradeLabel = regexp.MustCompile(`^[\p{L} .]{2,20}:\s*`)
+	reUpgradeWatts = regexp.MustCompile(`\b\d{3,4}\s*(w|вт)\b`)
+)
+
+// UpgradeRequest - maslahatchi parametrlari
+type UpgradeRequest struct {
+	Branch string                       // qoldiq shu filial bo'yicha
+	Price  func(entity.Product) float64 // narx dollarda; nil - Price.Major()
+	Bench  *BenchmarkDB                 // nil - standart baza
+	Lang   string
+	Limit  int // 0 - barcha turlar bo'yicha bittadan
+}
+
+// UpgradeOption - bitta taklif; Requires - taklif bilan birga olinishi shart bo'lgan qismlar (masalan, PSU)
+type UpgradeOption struct {
+	Suggestion  entity.UpgradeSuggestion
+	Kind        string // PartCPU, PartGPU, PartRAM, PartStorage, PartPSU
+	Product     entity.Product
+	Requires    []entity.Product
+	PriceUSD    float64 // mahsulot va Requires jami
+	Gain        float64 // maqsad balining o'sishi (0-10 shkala)
+	MinPSUWatts int     // PSU noma'lum bo'lsa - yangi yig'ma uchun tavsiya etilgan quvvat
+	Violations  []Violation
+}
+
+// UpgradeAdvice - tahlil natijasi
+type UpgradeAdvice struct {
+	Current   *entity.PCBuild
+	Analytics *entity.PCAnalytics
+	Weakest   string  // eng zaif qism turi
+	Score     float64 // hozirgi maqsad bali
+	Options   []UpgradeOption
+}
+
+// ParsePCDescription mijoz matnidan komponentlarni ajratadi ("i5-9400F, GTX 1660, 16GB DDR4...").
+// ok=false - na protsessor, na videokarta aniqlanmadi.
+func ParsePCDescription(text string, bench *BenchmarkDB) (*entity.PCBuild, bool) {
+	if bench == nil {
+		bench = DefaultBenchmarks()
+	}
+	build := &entity.PCBuild{Purpose: normalizeUseCase(text)}
+	if build.Purpose == "" {
+		build.Purpose = "Gaming"
+	}
+	for _, seg := range reUpgradeSplit.Split(text, -1) {
+		seg = strings.TrimSpace(strings.Trim(strings.TrimSpace(seg), "-•*"))
+		lower := strings.ToLower(seg)
+		name := strings.TrimSpace(reUpgradeLabel.ReplaceAllString(seg, ""))
+		if name == "" {
+			continue
+		}
+		nameLower := strings.ToLower(name)
+		switch {
+		case build.CPU.Name == "" && (reRyzen.MatchString(nameLower) || reIntelCore.MatchString(nameLower) || reCoreUltra.MatchString(nameLower)):
+			build.CPU = describedPart("CPU", name)
+		case build.GPU.Name == "" && (gpuModel(nameLower) != "" || reArc.MatchString(nameLower)):
+			build.GPU = describedPart("GPU", name)
+		case build.RAM.Name == "" && containsAny(lower, "ddr", "ram", "озу", "operativ", "памят"):
+			build.RAM = describedPart("RAM", name)
+		case build.SSD.Name == "" && containsAny(lower, "ssd", "hdd", "nvme", "m.2", "storage", "disk", "диск", "накопит"):
+			build.SSD = describedPart("SSD", name)
+		case build.PSU.Name == "" && (reUpgradeWatts.MatchString(lower) || containsAny(lower, "psu", "блок пит", "power")):
+			build.PSU = describedPart("PSU", name)
+		case build.Motherboard.Name == "" && boardAttrs(entity.Product{Name: name}).Chipset != "":
+			build.Motherboard = describedPart("Motherboard", name)
+		}
+	}
+	// Model bazada bo'lsa, ajratgichsiz yozilgan matndan ham topiladi ("3600 va 1660 super")
+	if build.CPU.Name == "" {
+		if i := benchMatch(text, bench.cpuKeys()); i >= 0 {
+			build.CPU = describedPart("CPU", bench.CPUs[i].Name)
+		}
+	}
+	if build.GPU.Name == "" {
+		if i := benchMatch(text, bench.gpuKeys()); i >= 0 {
+			build.GPU = describedPart("GPU", bench.GPUs[i].Name)
+		}
+	}
+	return build, build.CPU.Name != "" || build.GPU.Name != ""
+}
+
+func describedPart(category, name string) entity.Product {
+	p := entity.Product{Name: name, Category: category}
+	ExtractSpecs(&p)
+	return p
+}
+
+type upgrader struct {
+	req     UpgradeRequest
+	useCase string
+	compat  *CompatibilityChecker
+	current *entity.PCBuild
+	score   float64
+	psus    []optPart
+}
+
+// AdviseUpgrades hozirgi yig'ma uchun zaif bo'g'in va qoldiqdagi mahsulotlardan upgrade takliflari
+func AdviseUpgrades(build *entity.PCBuild, products []entity.Product, req UpgradeRequest) *UpgradeAdvice {
+	if build == nil {
+		return nil
+	}
+	if req.Bench == nil {
+		req.Bench = DefaultBenchmarks()
+	}
+	if req.Price == nil {
+		req.Price = func(p entity.Product) float64 { return p.Price.Major() }
+	}
+	u := &upgrader{req: req, useCase: normalizeUseCase(build.Purpose), compat: NewCompatibilityChecker(), current: build}
+	if u.useCase == "" {
+		u.useCase = "Gaming"
+	}
+	u.score = u.scoreOf(build)
+	advice := &UpgradeAdvice{
+		Current:   build,
+		Analytics: NewPCAnalyzer(nil, req.Bench).Compute(build, req.Lang),
+		Score:     u.score,
+	}
+	advice.Weakest = u.weakest(advice.Analytics)
+
+	byKind := map[string][]entity.Product{}
+	for _, p := range inStockOrAll(products, req.Branch) {
+		if req.Price(p) <= 0 {
+			continue
+		}
+		kind := SpecKind(p.Category, p.Name)
+		byKind[kind] = append(byKind[kind], p)
+		if kind == PartPSU {
+			u.psus = append(u.psus, optPart{p: p, price: req.Price(p)})
+		}
+	}
+	sortByPrice(u.psus)
+
+	for _, kind := range []string{PartGPU, PartCPU, PartRAM, PartStorage} {
+		if opt, ok := u.bestFor(kind, byKind[kind], kind == advice.Weakest); ok {
+			advice.Options = append(advice.Options, opt)
+		}
+	}
+	if opt, ok := u.psuUpgrade(advice.Analytics); ok {
+		advice.Options = append(advice.Options, opt)
+	}
+
+	sort.SliceStable(advice.Options, func(i, j int) bool {
+		a, b := advice.Options[i], advice.Options[j]
+		if (a.Kind == advice.Weakest) != (b.Kind == advice.Weakest) {
+			return a.Kind == advice.Weakest
+		}
+		if pa, pb := priorityRank(a.Suggestion.Priority), priorityRank(b.Suggestion.Priority); pa != pb {
+			return pa < pb
+		}
+		return a.Gain/a.PriceUSD > b.Gain/b.PriceUSD
+	})
+	if req.Limit > 0 && len(advice.Options) > req.Limit {
+		advice.Options = advice.Options[:req.Limit]
+	}
+	return advice
+}
+
+func (u *upgrader) scoreOf(build *entity.PCBuild) float64 {
+	return buildUseCaseScore(u.useCase, benchProfile(u.req.Bench, build), "").Score
+}
+
+// weakest: analizator bottleneck topsa o'sha, aks holda CPU/GPU ning kuchsizi yoki juda past RAM/disk
+func (u *upgrader) weakest(analytics *entity.PCAnalytics) string {
+	switch analytics.Bottleneck.BottleneckType {
+	case "CPU":
+		return PartCPU
+	case "GPU":
+		return PartGPU
+	}
+	profile := benchProfile(u.req.Bench, u.current)
+	kind, score := PartCPU, profile.cpuScore
+	if (u.useCase == "Gaming" || u.useCase == "Design") && profile.gpuScore < score {
+		kind, score = PartGPU, profile.gpuScore
+	}
+	if strings.TrimSpace(u.current.RAM.Name) != "" && profile.ramGB > 0 && profile.ramScore < 6 && profile.ramScore < score {
+		kind, score = PartRAM, profile.ramScore
+	}
+	if strings.TrimSpace(u.current.SSD.Name) != "" && profile.storageScore < 6 && profile.storageScore < score {
+		kind = PartStorage
+	}
+	return kind
+}
+
+// bestFor tur bo'yicha eng foydali taklif: eng katta o'sishning kamida yarmini beradiganlar ichidan
+// har dollarga eng ko'p ball beradigani
+func (u *upgrader) bestFor(kind string, candidates []entity.Product, weakest bool) (UpgradeOption, bool) {
+	if !u.describes(kind) {
+		return UpgradeOption{}, false
+	}
+	var opts []UpgradeOption
+	maxGain := 0.0
+	for _, p := range candidates {
+		opt, ok := u.try(kind, p)
+		if !ok {
+			continue
+		}
+		opts = append(opts, opt)
+		maxGain = math.Max(maxGain, opt.Gain)
+	}
+	var best UpgradeOption
+	found := false
+	for _, opt := range opts {
+		if opt.Gain < maxGain/2 {
+			continue
+		}
+		if !found || opt.Gain/opt.PriceUSD > best.Gain/best.PriceUSD {
+			best, found = opt, true
+		}
+	}
+	if !found {
+		return UpgradeOption{}, false
+	}
+	best.Suggestion.Priority = upgradePriority(best.Gain, weakest)
+	return best, true
+}
+
+// describes mijoz shu qismni ko'rsatganmi (ko'rsatilmagan RAM/diskni almashtirish taklif qilinmaydi)
+func (u *upgrader) describes(kind string) bool {
+	switch kind {
+	case PartRAM:
+		return productRAMGB(u.current.RAM) > 0
+	case PartStorage:
+		return strings.TrimSpace(u.current.SSD.Name) != ""
+	case PartCPU:
+		return strings.TrimSpace(u.current.CPU.Name) != ""
+	}
+	return true
+}
+
+// try bitta nomzodni joriy yig'maga qo'yib ko'radi
+func (u *upgrader) try(kind string, p entity.Product) (UpgradeOption, bool) {
+	next := *u.current
+	current := u.current
+	switch kind {
+	case PartCPU:
+		// Plata noma'lum bo'lsa faqat o'sha soketdagi protsessor (plata almashmaydi)
+		if current.Motherboard.Name == "" {
+			socket := PartAttrs(PartCPU, current.CPU).Socket
+			if socket == "" || PartAttrs(PartCPU, p).Socket != socket {
+				return UpgradeOption{}, false
+			}
+		}
+		next.CPU = p
+	case PartGPU:
+		next.GPU = p
+	case PartRAM:
+		if productRAMGB(p) <= productRAMGB(current.RAM) || !u.sameMemory(p) {
+			return UpgradeOption{}, false
+		}
+		next.RAM = p
+	case PartStorage:
+		if gb := productStorageGB(p); gb > 0 && gb < productStorageGB(current.SSD) {
+			return UpgradeOption{}, false
+		}
+		next.SSD = p
+	}
+	gain := u.scoreOf(&next) - u.score
+	if gain < minUpgradeGain {
+		return UpgradeOption{}, false
+	}
+	opt := UpgradeOption{Kind: kind, Product: p, PriceUSD: u.req.Price(p), Gain: gain}
+
+	violations := u.compat.Check(&next)
+	if HasCompatErrors(violations) {
+		psu, ok := u.fixPSU(&next)
+		if !ok {
+			return UpgradeOption{}, false
+		}
+		next.PSU = psu.p
+		opt.Requires = append(opt.Requires, psu.p)
+		opt.PriceUSD += psu.price
+		violations = u.compat.Check(&next)
+	}
+	if productPSUWatts(current.PSU) == 0 && (kind == PartCPU || kind == PartGPU) {
+		_, opt.MinPSUWatts = EstimatePower(&next)
+	}
+	opt.Violations = violations
+	opt.Suggestion = u.suggestion(kind, p, opt.PriceUSD, gain)
+	return opt, true
+}
+
+// sameMemory plata noma'lum bo'lsa yangi RAM hozirgi avlodda bo'lishi kerak
+func (u *upgrader) sameMemory(p entity.Product) bool {
+	if u.current.Motherboard.Name != "" {
+		return true // ram_type qoidasi tekshiradi
+	}
+	gens := PartAttrs(PartRAM, u.current.RAM).Memory
+	if len(gens) != 1 {
+		gens = PartAttrs(PartCPU, u.current.CPU).Memory
+	}
+	cand := PartAttrs(PartRAM, p).Memory
+	return len(gens) == 1 && len(cand) == 1 && gens[0] == cand[0]
+}
+
+// fixPSU faqat quvvat bloki sabab bo'lgan xatolarni eng arzon yetarli PSU bilan tuzatadi
+func (u *upgrader) fixPSU(build *entity.PCBuild) (optPart, bool) {
+	if productPSUWatts(build.PSU) == 0 {
+		return optPart{}, false
+	}
+	_, recommended := EstimatePower(build)
+	for _, c := range u.psus {
+		if productPSUWatts(c.p) < recommended {
+			continue
+		}
+		next := *build
+		next.PSU = c.p
+		if !HasCompatErrors(u.compat.Check(&next)) {
+			return c, true
+		}
+	}
+	return optPart{}, false
+}
+
+// psuUpgrade hozirgi quvvat bloki yetarli bo'lmasa - eng arzon tavsiya etilgan quvvatdagi PSU
+func (u *upgrader) psuUpgrade(analytics *entity.PCAnalytics) (UpgradeOption, bool) {
+	watts := productPSUWatts(u.current.PSU)
+	if watts == 0 || analytics.PowerConsumption.IsAdequate {
+		return UpgradeOption{}, false
+	}
+	_, recommended := EstimatePower(u.current)
+	for _, c := range u.psus {
+		if w := productPSUWatts(c.p); w <= watts || w < recommended {
+			continue
+		}
+		next := *u.current
+		next.PSU = c.p
+		violations := u.compat.Check(&next)
+		if HasCompatErrors(violations) {
+			continue
+		}
+		s := u.suggestion(PartPSU, c.p, c.price, 0)
+		s.Priority = "High"
+		s.Benefit = i18n.T(u.req.Lang, "upgrade.benefit.psu", "watts", watts, "target", productPSUWatts(c.p))
+		return UpgradeOption{Kind: PartPSU, Product: c.p, PriceUSD: c.price, Violations: violations, Suggestion: s}, true
+	}
+	return UpgradeOption{}, false
+}
+
+func (u *upgrader) suggestion(kind string, p entity.Product, price, gain float64) entity.UpgradeSuggestion {
+	labels := map[string]string{PartCPU: "CPU", PartGPU: "GPU", PartRAM: "RAM", PartStorage: "SSD", PartPSU: "PSU"}
+	var current entity.Product
+	switch kind {
+	case PartCPU:
+		current = u.current.CPU
+	case PartGPU:
+		current = u.current.GPU
+	case PartRAM:
+		current = u.current.RAM
+	case PartStorage:
+		current = u.current.SSD
+	case PartPSU:
+		current = u.current.PSU
+	}
+	currentSpec := strings.TrimSpace(current.Name)
+	if kind == PartGPU && !hasGPU(current) {
+		currentSpec = i18n.T(u.req.Lang, "upgrade.integrated_graphics")
+	}
+	benefit := i18n.T(u.req.Lang, "upgrade.benefit.score",
+		"from", fmt.Sprintf("%.1f", u.score), "to", fmt.Sprintf("%.1f", u.score+gain), "gain", fmt.Sprintf("%.1f", gain))
+	if perf := u.perfGain(kind, current, p); perf > 0 {
+		benefit += i18n.T(u.req.Lang, "upgrade.benefit.perf", "perf", fmt.Sprintf("%.0f", perf))
+	}
+	return entity.UpgradeSuggestion{
+		Component:     labels[kind],
+		CurrentSpec:   currentSpec,
+		SuggestedSpec: p.Name,
+		Benefit:       benefit,
+		EstimatedCost: math.Round(price),
+	}
+}
+
+// perfGain benchmark bazasida ikkala model ham bo'lsa unumdorlik o'sishi (%)
+func (u *upgrader) perfGain(kind string, from, to entity.Product) float64 {
+	var a, b BenchEntry
+	var okA, okB bool
+	switch kind {
+	case PartCPU:
+		a, okA = u.req.Bench.LookupCPU(from)
+		b, okB = u.req.Bench.LookupCPU(to)
+	case PartGPU:
+		a, okA = u.req.Bench.LookupGPU(from)
+		b, okB = u.req.Bench.LookupGPU(to)
+	}
+	if !okA || !okB || a.Score <= 0 {
+		return 0
+	}
+	return (b.Score/a.Score - 1) * 100
+}
+
+func upgradePriority(gain float64, weakest bool) string {
+	switch {
+	case weakest || gain >= 1.5:
+		return "High"
+	case gain >= 0.7:
+		return "Medium"
+	default:
+		return "Low"
+	}
+}
+
+func priorityRank(priority string) int {
+	switch priority {
+	case "High":
+		return 0
+	case "Medium":
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePCDescription(t *testing.T) {
+	build, ok := ParsePCDescription("CPU: AMD Ryzen 5 3600\nVideokarta: GTX 1660 Super\nRAM: 16GB DDR4 3200\nSSD: 512GB SATA\nBlok: 500W", nil)
+	if !ok {
+		t.Fatal("komponentlar aniqlanmadi")
+	}
+	if !strings.Contains(build.CPU.Name, "3600") || !strings.Contains(build.GPU.Name, "1660") {
+		t.Errorf("CPU/GPU = %q / %q", build.CPU.Name, build.GPU.Name)
+	}
+	if productRAMGB(build.RAM) != 16 || productPSUWatts(build.PSU) != 500 || build.SSD.Name == "" {
+		t.Errorf("RAM/PSU/SSD = %q / %q / %q", build.RAM.Name, build.PSU.Name, build.SSD.Name)
+	}
+
+	build, ok = ParsePCDescription("o'yin uchun, i5 9400f va rtx 2060", nil)
+	if !ok || !strings.Contains(build.CPU.Name, "9400") || !strings.Contains(build.GPU.Name, "2060") || build.Purpose != "Gaming" {
+		t.Errorf("qisqa tavsif: %+v", build)
+	}
+	if _, ok := ParsePCDescription("salom, kompyuterim sekin", nil); ok {
+		t.Error("komponentsiz matn qabul qilinmasligi kerak")
+	}
+}
+
+func TestAdviseUpgrades(t *testing.T) {
+	build, _ := ParsePCDescription("Ryzen 5 3600, GTX 1660 Super, 16GB DDR4, 512GB SATA SSD, 450W", nil)
+	advice := AdviseUpgrades(build, optimizerCatalog(), UpgradeRequest{})
+	if advice.Weakest != PartGPU {
+		t.Errorf("zaif bo'g'in = %s, kutilgan GPU", advice.Weakest)
+	}
+	if len(advice.Options) == 0 || advice.Options[0].Kind != PartGPU || advice.Options[0].Suggestion.Priority != "High" {
+		t.Fatalf("birinchi taklif GPU bo'lishi kerak: %+v", advice.Options)
+	}
+	checker := NewCompatibilityChecker()
+	for _, opt := range advice.Options {
+		if opt.Kind != PartPSU && opt.Gain < minUpgradeGain {
+			t.Errorf("%s: o'sish juda kichik %.2f", opt.Product.Name, opt.Gain)
+		}
+		next := *build
+		switch opt.Kind {
+		case PartCPU:
+			// plata noma'lum - soket o'zgarmasligi kerak
+			if PartAttrs(PartCPU, opt.Product).Socket != "AM4" {
+				t.Errorf("boshqa soketdagi CPU taklif qilindi: %s", opt.Product.Name)
+			}
+			next.CPU = opt.Product
+		case PartGPU:
+			next.GPU = opt.Product
+		}
+		for _, r := range opt.Requires {
+			next.PSU = r
+		}
+		if !checker.Compatible(&next) {
+			t.Errorf("%s: mos emas %v", opt.Product.Name, checker.Check(&next))
+		}
+	}
+
+	// Foyda matni katalogdan, mijoz tilida
+	en := AdviseUpgrades(build, optimizerCatalog(), UpgradeRequest{Lang: "en"})
+	if len(en.Options) == 0 || !strings.HasPrefix(en.Options[0].Suggestion.Benefit, "Target score ") {
+		t.Errorf("inglizcha foyda matni: %+v", en.Options)
+	}
+
+	// Kuchsiz blok: kuchli videokarta uchun PSU ham qo'shiladi
+	build, _ = ParsePCDescription("Ryzen 5 3600, GTX 1660 Super, 16GB DDR4, 300W", nil)
+	advice = AdviseUpgrades(build, optimizerCatalog(), UpgradeRequest{})
+	for _, opt := range advice.Options {
+		next := *build
+		next.GPU = opt.Product
+		if load, _ := EstimatePower(&next); opt.Kind == PartGPU && load > 300 && len(opt.Requires) == 0 {
+			t.Errorf("300W blok bilan %s uchun PSU talab qilinmadi", opt.Product.Name)
+		}
+	}
+}